@@ -0,0 +1,81 @@
+package atc
+
+import "reflect"
+
+// AcrossCombinations returns the combinations of values an across step runs
+// its sub-step with. It starts from the cartesian product of the values of
+// each var, drops every combination matching one of the exclude rules, and
+// then appends each of the include rules that is not already present.
+//
+// The values of each combination are ordered the same as varNames.
+func AcrossCombinations(varNames []string, values [][]interface{}, include, exclude []AcrossCombination) [][]interface{} {
+	var combinations [][]interface{}
+	for _, combination := range cartesianProduct(values) {
+		excluded := false
+		for _, rule := range exclude {
+			if rule.Matches(varNames, combination) {
+				excluded = true
+				break
+			}
+		}
+
+		if !excluded {
+			combinations = append(combinations, combination)
+		}
+	}
+
+	for _, rule := range include {
+		combination := make([]interface{}, len(varNames))
+		for i, name := range varNames {
+			combination[i] = rule[name]
+		}
+
+		present := false
+		for _, existing := range combinations {
+			if reflect.DeepEqual(existing, combination) {
+				present = true
+				break
+			}
+		}
+
+		if !present {
+			combinations = append(combinations, combination)
+		}
+	}
+
+	return combinations
+}
+
+// Matches returns true if every var named in the rule has the rule's value
+// in the given combination.
+func (rule AcrossCombination) Matches(varNames []string, combination []interface{}) bool {
+	for i, name := range varNames {
+		value, found := rule[name]
+		if !found {
+			continue
+		}
+
+		if !reflect.DeepEqual(value, combination[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func cartesianProduct(values [][]interface{}) [][]interface{} {
+	if len(values) == 0 {
+		return make([][]interface{}, 1)
+	}
+
+	var product [][]interface{}
+	for _, vec := range cartesianProduct(values[:len(values)-1]) {
+		for _, val := range values[len(values)-1] {
+			combination := make([]interface{}, len(vec), len(vec)+1)
+			copy(combination, vec)
+			product = append(product, append(combination, val))
+		}
+	}
+
+	return product
+}
@@ -26,11 +26,13 @@ var DefaultRoles = map[string]string{
 	atc.GetBuildPreparation:           ViewerRole,
 	atc.GetJob:                        ViewerRole,
 	atc.CreateJobBuild:                OperatorRole,
+	atc.CreateJobOneOffBuild:          MemberRole,
 	atc.RerunJobBuild:                 OperatorRole,
 	atc.ListAllJobs:                   ViewerRole,
 	atc.ListJobs:                      ViewerRole,
 	atc.ListJobBuilds:                 ViewerRole,
 	atc.ListJobInputs:                 ViewerRole,
+	atc.GetJobSchedulingExplanation:   ViewerRole,
 	atc.GetJobBuild:                   ViewerRole,
 	atc.PauseJob:                      OperatorRole,
 	atc.UnpauseJob:                    OperatorRole,
@@ -47,6 +49,7 @@ var DefaultRoles = map[string]string{
 	atc.SetPinCommentOnResource:       OperatorRole,
 	atc.CheckResource:                 OperatorRole,
 	atc.CheckResourceWebHook:          OperatorRole,
+	atc.ReceiveWebhook:                OperatorRole,
 	atc.CheckResourceType:             OperatorRole,
 	atc.ListResourceVersions:          ViewerRole,
 	atc.GetResourceVersion:            ViewerRole,
@@ -68,6 +71,7 @@ var DefaultRoles = map[string]string{
 	atc.HidePipeline:                  MemberRole,
 	atc.RenamePipeline:                MemberRole,
 	atc.ListPipelineBuilds:            ViewerRole,
+	atc.SearchPipelineBuildLogs:       ViewerRole,
 	atc.CreatePipelineBuild:           MemberRole,
 	atc.PipelineBadge:                 ViewerRole,
 	atc.RegisterWorker:                MemberRole,
@@ -99,5 +103,7 @@ var DefaultRoles = map[string]string{
 	atc.CreateArtifact:                MemberRole,
 	atc.GetArtifact:                   MemberRole,
 	atc.ListBuildArtifacts:            ViewerRole,
+	atc.ListBuildSecretAccesses:       ViewerRole,
 	atc.GetWall:                       ViewerRole,
+	atc.ListPolicyDecisions:           ViewerRole,
 }
@@ -234,6 +234,17 @@ var _ = Describe("Builds API", func() {
 						Expect(page.UseDate).To(Equal(true))
 					})
 				})
+
+				Context("secret is provided", func() {
+					BeforeEach(func() {
+						queryParams = "?secret=some%2Fsecret"
+					})
+
+					It("filters the builds by the secret", func() {
+						_, page := dbBuildFactory.VisibleBuildsArgsForCall(0)
+						Expect(page.Secret).To(Equal("some/secret"))
+					})
+				})
 			})
 
 			Context("when getting the builds succeeds", func() {
@@ -301,6 +312,22 @@ var _ = Describe("Builds API", func() {
 				})
 			})
 
+			Context("when next/previous pages are filtered by a secret", func() {
+				BeforeEach(func() {
+					dbBuildFactory.VisibleBuildsReturns(returnedBuilds, db.Pagination{
+						Previous: &db.Page{Until: 4, Limit: 2, Secret: "some/secret"},
+						Next:     &db.Page{Since: 3, Limit: 2, Secret: "some/secret"},
+					}, nil)
+				})
+
+				It("keeps the secret in the Link headers", func() {
+					Expect(response.Header["Link"]).To(ConsistOf([]string{
+						fmt.Sprintf(`<%s/api/v1/builds?until=4&limit=2&secret=some%%2Fsecret>; rel="previous"`, externalURL),
+						fmt.Sprintf(`<%s/api/v1/builds?since=3&limit=2&secret=some%%2Fsecret>; rel="next"`, externalURL),
+					}))
+				})
+			})
+
 			Context("when getting all builds fails", func() {
 				BeforeEach(func() {
 					dbBuildFactory.VisibleBuildsReturns(nil, db.Pagination{}, errors.New("oh no!"))
@@ -597,12 +624,157 @@ var _ = Describe("Builds API", func() {
 						"reap_time": 200
 					}`))
 						})
+
+						Context("when the pipeline of the build is an instanced pipeline", func() {
+							BeforeEach(func() {
+								build.PipelineInstanceVarsReturns(atc.InstanceVars{"branch": "master"})
+							})
+
+							It("returns the instance vars of the pipeline", func() {
+								var returned atc.Build
+								err := json.NewDecoder(response.Body).Decode(&returned)
+								Expect(err).NotTo(HaveOccurred())
+
+								Expect(returned.PipelineRef()).To(Equal(atc.PipelineRef{
+									Name:         "pipeline1",
+									InstanceVars: atc.InstanceVars{"branch": "master"},
+								}))
+							})
+						})
+
+						Context("when the build has recorded resource usage", func() {
+							BeforeEach(func() {
+								build.ResourceUsageReturns(atc.ResourceUsage{
+									CPUTime:         1000,
+									MemoryPeak:      2048,
+									RootfsDiskUsage: 4096,
+								})
+							})
+
+							It("returns the resource usage of the build", func() {
+								var returned atc.Build
+								err := json.NewDecoder(response.Body).Decode(&returned)
+								Expect(err).NotTo(HaveOccurred())
+
+								Expect(returned.ResourceUsage).To(Equal(&atc.ResourceUsage{
+									CPUTime:         1000,
+									MemoryPeak:      2048,
+									RootfsDiskUsage: 4096,
+								}))
+							})
+						})
 					})
 				})
 			})
 		})
 	})
 
+	Describe("GET /api/v1/builds/:build_id/secrets-accessed", func() {
+		var response *http.Response
+
+		BeforeEach(func() {
+			build.TeamNameReturns("some-team")
+			dbBuildFactory.BuildReturns(build, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/builds/3/secrets-accessed")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated, but not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authenticated and authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the secret accesses are found", func() {
+				BeforeEach(func() {
+					build.SecretAccessesReturns([]atc.SecretAccess{
+						{
+							Path:       "/concourse/some-team/prod-db-password",
+							Manager:    "vault",
+							CacheHit:   true,
+							Found:      true,
+							AccessedAt: 1,
+						},
+						{
+							Path:      "/some-pipeline/token",
+							Manager:   "ssm",
+							VarSource: "some-ssm",
+						},
+					}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns Content-Type 'application/json'", func() {
+					expectedHeaderEntries := map[string]string{
+						"Content-Type": "application/json",
+					}
+					Expect(response).Should(IncludeHeaderEntries(expectedHeaderEntries))
+				})
+
+				It("returns the secret accesses of the build", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"path": "/concourse/some-team/prod-db-password",
+							"manager": "vault",
+							"cache_hit": true,
+							"found": true,
+							"accessed_at": 1
+						},
+						{
+							"path": "/some-pipeline/token",
+							"manager": "ssm",
+							"var_source": "some-ssm",
+							"cache_hit": false,
+							"found": false
+						}
+					]`))
+				})
+			})
+
+			Context("when fetching the secret accesses fails", func() {
+				BeforeEach(func() {
+					build.SecretAccessesReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
 	Describe("GET /api/v1/builds/:build_id/resources", func() {
 		var response *http.Response
 
@@ -1089,6 +1261,7 @@ var _ = Describe("Builds API", func() {
 					PausedPipeline:   db.BuildPreparationStatusNotBlocking,
 					PausedJob:        db.BuildPreparationStatusNotBlocking,
 					MaxRunningBuilds: db.BuildPreparationStatusBlocking,
+					TeamQuota:        db.BuildPreparationStatusNotBlocking,
 					Inputs: map[string]db.BuildPreparationStatus{
 						"foo": db.BuildPreparationStatusNotBlocking,
 						"bar": db.BuildPreparationStatusBlocking,
@@ -1226,6 +1399,7 @@ var _ = Describe("Builds API", func() {
 					"paused_pipeline": "not_blocking",
 					"paused_job": "not_blocking",
 					"max_running_builds": "blocking",
+					"team_quota": "not_blocking",
 					"inputs": {
 						"foo": "not_blocking",
 						"bar": "blocking"
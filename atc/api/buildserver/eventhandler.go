@@ -14,6 +14,36 @@ import (
 const ProtocolVersionHeader = "X-ATC-Stream-Version"
 const CurrentProtocolVersion = "2.0"
 
+//go:generate counterfeiter . EventArchive
+
+// An EventArchive holds the events of builds which were moved out of the
+// database.
+type EventArchive interface {
+	Events(buildID int, from uint) (db.EventSource, error)
+}
+
+// NewArchivedEventHandlerFactory returns an EventHandlerFactory which streams
+// the events of archived builds from the archive rather than the database.
+func NewArchivedEventHandlerFactory(archive EventArchive) EventHandlerFactory {
+	return func(logger lager.Logger, build db.Build) http.Handler {
+		if build.EventsArchived() {
+			build = archivedBuild{Build: build, archive: archive}
+		}
+
+		return NewEventHandler(logger, build)
+	}
+}
+
+type archivedBuild struct {
+	db.Build
+
+	archive EventArchive
+}
+
+func (build archivedBuild) Events(from uint) (db.EventSource, error) {
+	return build.archive.Events(build.ID(), from)
+}
+
 func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var eventID uint = 0
@@ -12,6 +12,7 @@ import (
 	"code.cloudfoundry.org/lager/lagertest"
 	. "github.com/concourse/concourse/atc/api/buildserver"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/api/buildserver/buildserverfakes"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/event"
 	"github.com/vito/go-sse/sse"
@@ -287,4 +288,79 @@ var _ = Describe("Handler", func() {
 			})
 		})
 	})
+
+	Describe("ArchivedEventHandlerFactory", func() {
+		var (
+			fakeArchive     *buildserverfakes.FakeEventArchive
+			fakeEventSource *dbfakes.FakeEventSource
+			response        *http.Response
+		)
+
+		BeforeEach(func() {
+			build.IDReturns(42)
+
+			fakeArchive = new(buildserverfakes.FakeEventArchive)
+
+			fakeEventSource = new(dbfakes.FakeEventSource)
+			fakeEventSource.NextReturnsOnCall(0, fakeEvent(`{"event":1}`), nil)
+			fakeEventSource.NextReturnsOnCall(1, event.Envelope{}, db.ErrEndOfBuildEventStream)
+		})
+
+		JustBeforeEach(func() {
+			server.Close()
+			server = httptest.NewServer(NewArchivedEventHandlerFactory(fakeArchive)(lagertest.NewTestLogger("test"), build))
+
+			request, err := http.NewRequest("GET", server.URL, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			request.Header.Set("Last-Event-ID", "1")
+
+			response, err = http.DefaultClient.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the events of the build have been archived", func() {
+			BeforeEach(func() {
+				build.EventsArchivedReturns(true)
+				fakeArchive.EventsReturns(fakeEventSource, nil)
+			})
+
+			It("streams them from the archive", func() {
+				defer db.Close(response.Body)
+				reader := sse.NewReadCloser(response.Body)
+
+				Expect(reader.Next()).To(Equal(sse.Event{
+					ID:   "2",
+					Name: "event",
+					Data: []byte(`{"data":{"event":1},"event":"fake","version":"42.0"}`),
+				}))
+
+				buildID, from := fakeArchive.EventsArgsForCall(0)
+				Expect(buildID).To(Equal(42))
+				Expect(from).To(Equal(uint(2)))
+
+				Expect(build.EventsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the events of the build have not been archived", func() {
+			BeforeEach(func() {
+				build.EventsReturns(fakeEventSource, nil)
+			})
+
+			It("streams them from the database", func() {
+				defer db.Close(response.Body)
+				reader := sse.NewReadCloser(response.Body)
+
+				Expect(reader.Next()).To(Equal(sse.Event{
+					ID:   "2",
+					Name: "event",
+					Data: []byte(`{"data":{"event":1},"event":"fake","version":"42.0"}`),
+				}))
+
+				Expect(build.EventsArgsForCall(0)).To(Equal(uint(2)))
+				Expect(fakeArchive.EventsCallCount()).To(BeZero())
+			})
+		})
+	})
 })
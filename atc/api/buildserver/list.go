@@ -42,7 +42,13 @@ func (s *Server) ListBuilds(w http.ResponseWriter, r *http.Request) {
 		limit = atc.PaginationAPIDefaultLimit
 	}
 
-	page := db.Page{Until: until, Since: since, Limit: limit, UseDate: useDate}
+	page := db.Page{
+		Until:   until,
+		Since:   since,
+		Limit:   limit,
+		UseDate: useDate,
+		Secret:  r.FormValue(atc.BuildsQuerySecret),
+	}
 
 	var builds []db.Build
 	var pagination db.Pagination
@@ -86,24 +92,26 @@ func (s *Server) ListBuilds(w http.ResponseWriter, r *http.Request) {
 
 func (s *Server) addNextLink(w http.ResponseWriter, page db.Page) {
 	w.Header().Add("Link", fmt.Sprintf(
-		`<%s/api/v1/builds?%s=%d&%s=%d>; rel="%s"`,
+		`<%s/api/v1/builds?%s=%d&%s=%d%s>; rel="%s"`,
 		s.externalURL,
 		atc.PaginationQuerySince,
 		page.Since,
 		atc.PaginationQueryLimit,
 		page.Limit,
+		atc.BuildsSecretLinkParam(page.Secret),
 		atc.LinkRelNext,
 	))
 }
 
 func (s *Server) addPreviousLink(w http.ResponseWriter, page db.Page) {
 	w.Header().Add("Link", fmt.Sprintf(
-		`<%s/api/v1/builds?%s=%d&%s=%d>; rel="%s"`,
+		`<%s/api/v1/builds?%s=%d&%s=%d%s>; rel="%s"`,
 		s.externalURL,
 		atc.PaginationQueryUntil,
 		page.Until,
 		atc.PaginationQueryLimit,
 		page.Limit,
+		atc.BuildsSecretLinkParam(page.Secret),
 		atc.LinkRelPrevious,
 	))
 }
@@ -0,0 +1,30 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListBuildSecretAccesses(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-build-secret-accesses")
+
+		accesses, err := build.SecretAccesses()
+		if err != nil {
+			logger.Error("failed-to-fetch-build-secret-accesses", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(accesses)
+		if err != nil {
+			logger.Error("failed-to-encode-build-secret-accesses", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
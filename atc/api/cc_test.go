@@ -114,6 +114,35 @@ var _ = Describe("cc.xml", func() {
 							})
 						})
 
+						Context("when the job belongs to a pipeline instance", func() {
+							BeforeEach(func() {
+								fakePipeline.DashboardReturns(atc.Dashboard{
+									{
+										Name:                 "some-job",
+										PipelineName:         "something-else",
+										PipelineInstanceVars: atc.InstanceVars{"branch": "master"},
+										TeamName:             "a-team",
+										FinishedBuild: &atc.DashboardBuild{
+											Name:    "42",
+											Status:  "succeeded",
+											EndTime: endTime,
+										},
+									},
+								}, nil)
+							})
+
+							It("includes the instance vars in the project name and url", func() {
+								body, err := ioutil.ReadAll(response.Body)
+								Expect(err).NotTo(HaveOccurred())
+
+								Expect(body).To(MatchXML(`
+<Projects>
+  <Project activity="Sleeping" lastBuildLabel="42" lastBuildStatus="Success" lastBuildTime="2018-11-04T21:26:38Z" name="something-else/branch:master/some-job" webUrl="https://example.com/teams/a-team/pipelines/something-else/jobs/some-job?vars=%7B%22branch%22%3A%22master%22%7D"/>
+</Projects>
+`))
+							})
+						})
+
 						Context("when the last build is aborted", func() {
 							BeforeEach(func() {
 								fakePipeline.DashboardReturns(atc.Dashboard{
@@ -101,6 +101,8 @@ func (s *Server) buildProject(j atc.DashboardJob) Project {
 		activity = "Sleeping"
 	}
 
+	pipelineRef := atc.PipelineRef{Name: j.PipelineName, InstanceVars: j.PipelineInstanceVars}
+
 	webUrl := s.createWebUrl([]string{
 		"teams",
 		j.TeamName,
@@ -108,9 +110,9 @@ func (s *Server) buildProject(j atc.DashboardJob) Project {
 		j.PipelineName,
 		"jobs",
 		j.Name,
-	})
+	}, pipelineRef.QueryParams())
 
-	projectName := fmt.Sprintf("%s/%s", j.PipelineName, j.Name)
+	projectName := fmt.Sprintf("%s/%s", pipelineRef.String(), j.Name)
 	return Project{
 		Activity:        activity,
 		LastBuildLabel:  fmt.Sprint(j.FinishedBuild.Name),
@@ -121,10 +123,15 @@ func (s *Server) buildProject(j atc.DashboardJob) Project {
 	}
 }
 
-func (s *Server) createWebUrl(pathComponents []string) string {
+func (s *Server) createWebUrl(pathComponents []string, query url.Values) string {
 	for i, c := range pathComponents {
 		pathComponents[i] = url.PathEscape(c)
 	}
 
-	return s.externalURL + "/" + strings.Join(pathComponents, "/")
+	webUrl := s.externalURL + "/" + strings.Join(pathComponents, "/")
+	if len(query) > 0 {
+		webUrl += "?" + query.Encode()
+	}
+
+	return webUrl
 }
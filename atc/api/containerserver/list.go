@@ -69,17 +69,24 @@ func createContainerLocatorFromRequest(team db.Team, r *http.Request, secretMana
 		}, nil
 	}
 
+	instanceVars, err := atc.InstanceVarsFromQueryParams(query)
+	if err != nil {
+		return nil, err
+	}
+
 	if query.Get("type") == "check" {
 		return &checkContainerLocator{
-			team:          team,
-			pipelineName:  query.Get("pipeline_name"),
+			team: team,
+			pipelineRef: atc.PipelineRef{
+				Name:         query.Get("pipeline_name"),
+				InstanceVars: instanceVars,
+			},
 			resourceName:  query.Get("resource_name"),
 			secretManager: secretManager,
 			varSourcePool: varSourcePool,
 		}, nil
 	}
 
-	var err error
 	var containerType db.ContainerType
 	if query.Get("type") != "" {
 		containerType, err = db.ContainerTypeFromString(query.Get("type"))
@@ -120,6 +127,7 @@ func createContainerLocatorFromRequest(team db.Team, r *http.Request, secretMana
 			JobName:      query.Get("job_name"),
 			BuildName:    query.Get("build_name"),
 		},
+		pipelineInstanceVars: instanceVars,
 	}, nil
 }
 
@@ -134,22 +142,40 @@ func (l *allContainersLocator) Locate(logger lager.Logger) ([]db.Container, map[
 
 type checkContainerLocator struct {
 	team          db.Team
-	pipelineName  string
+	pipelineRef   atc.PipelineRef
 	resourceName  string
 	secretManager creds.Secrets
 	varSourcePool creds.VarSourcePool
 }
 
 func (l *checkContainerLocator) Locate(logger lager.Logger) ([]db.Container, map[int]time.Time, error) {
-	return l.team.FindCheckContainers(logger, l.pipelineName, l.resourceName, l.secretManager, l.varSourcePool)
+	return l.team.FindCheckContainers(logger, l.pipelineRef, l.resourceName, l.secretManager, l.varSourcePool)
 }
 
 type stepContainerLocator struct {
 	team     db.Team
 	metadata db.ContainerMetadata
+
+	pipelineInstanceVars atc.InstanceVars
 }
 
 func (l *stepContainerLocator) Locate(logger lager.Logger) ([]db.Container, map[int]time.Time, error) {
+	if l.pipelineInstanceVars != nil && l.metadata.PipelineID == 0 && l.metadata.PipelineName != "" {
+		pipeline, found, err := l.team.Pipeline(atc.PipelineRef{
+			Name:         l.metadata.PipelineName,
+			InstanceVars: l.pipelineInstanceVars,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !found {
+			return []db.Container{}, nil, nil
+		}
+
+		l.metadata.PipelineID = pipeline.ID()
+	}
+
 	containers, err := l.team.FindContainersByMetadata(l.metadata)
 	return containers, nil, err
 }
@@ -489,6 +489,22 @@ var _ = Describe("Jobs API", func() {
 
 							})
 
+							Context("when the pipeline of the job is an instanced pipeline", func() {
+								BeforeEach(func() {
+									fakeJob.PipelineInstanceVarsReturns(atc.InstanceVars{"branch": "master"})
+									build1.PipelineInstanceVarsReturns(atc.InstanceVars{"branch": "master"})
+								})
+
+								It("returns the instance vars of the pipeline", func() {
+									var job atc.Job
+									err := json.NewDecoder(response.Body).Decode(&job)
+									Expect(err).NotTo(HaveOccurred())
+
+									Expect(job.PipelineInstanceVars).To(Equal(atc.InstanceVars{"branch": "master"}))
+									Expect(job.FinishedBuild.PipelineInstanceVars).To(Equal(atc.InstanceVars{"branch": "master"}))
+								})
+							})
+
 							Context("when there are no running or finished builds", func() {
 								BeforeEach(func() {
 									fakeJob.FinishedAndNextBuildReturns(nil, nil, nil)
@@ -1506,6 +1522,50 @@ var _ = Describe("Jobs API", func() {
 
 						It("triggers the build", func() {
 							Expect(fakeJob.CreateBuildCallCount()).To(Equal(1))
+							Expect(fakeJob.CreateBuildWithPriorityCallCount()).To(Equal(0))
+						})
+
+						Context("when a priority is given", func() {
+							BeforeEach(func() {
+								request.URL.RawQuery = "priority=10"
+
+								build := new(dbfakes.FakeBuild)
+								build.IDReturns(42)
+								build.NameReturns("1")
+								build.TeamNameReturns("some-team")
+								build.StatusReturns(db.BuildStatusPending)
+								build.PriorityReturns(10)
+
+								fakeJob.CreateBuildWithPriorityReturns(build, nil)
+							})
+
+							It("triggers the build with the priority", func() {
+								Expect(fakeJob.CreateBuildCallCount()).To(Equal(0))
+								Expect(fakeJob.CreateBuildWithPriorityCallCount()).To(Equal(1))
+								Expect(fakeJob.CreateBuildWithPriorityArgsForCall(0)).To(Equal(10))
+							})
+
+							It("returns the priority of the build", func() {
+								var build atc.Build
+								err := json.NewDecoder(response.Body).Decode(&build)
+								Expect(err).NotTo(HaveOccurred())
+								Expect(build.Priority).To(Equal(10))
+							})
+						})
+
+						Context("when the priority is not a number", func() {
+							BeforeEach(func() {
+								request.URL.RawQuery = "priority=urgent"
+							})
+
+							It("returns a 400", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							})
+
+							It("does not trigger the build", func() {
+								Expect(fakeJob.CreateBuildCallCount()).To(Equal(0))
+								Expect(fakeJob.CreateBuildWithPriorityCallCount()).To(Equal(0))
+							})
 						})
 
 						Context("when finding the pipeline resources fails", func() {
@@ -1622,6 +1682,197 @@ var _ = Describe("Jobs API", func() {
 		})
 	})
 
+	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/one-off-builds", func() {
+		var requestBody string
+		var response *http.Response
+
+		BeforeEach(func() {
+			requestBody = `{"inputs":{"some-input":12},"skip_puts":true}`
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Post(
+				server.URL+"/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/one-off-builds",
+				"application/json",
+				strings.NewReader(requestBody),
+			)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authorized and authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthorizedReturns(true)
+				fakeAccess.IsAuthenticatedReturns(true)
+
+				fakeJob.ConfigReturns(atc.JobConfig{
+					Name: "some-job",
+					PlanSequence: []atc.Step{
+						{
+							Config: &atc.GetStep{
+								Name:     "some-input",
+								Resource: "some-resource",
+							},
+						},
+						{
+							Config: &atc.GetStep{
+								Name:     "some-other-input",
+								Resource: "some-other-resource",
+							},
+						},
+						{
+							Config: &atc.PutStep{
+								Name: "some-resource",
+							},
+						},
+					},
+				}, nil)
+				fakePipeline.JobReturns(fakeJob, true, nil)
+
+				resource1 := new(dbfakes.FakeResource)
+				resource1.NameReturns("some-resource")
+				resource1.TypeReturns("some-type")
+				resource1.SourceReturns(atc.Source{"some": "source"})
+
+				resource2 := new(dbfakes.FakeResource)
+				resource2.NameReturns("some-other-resource")
+				resource2.TypeReturns("some-other-type")
+				resource2.SourceReturns(atc.Source{"some": "other-source"})
+
+				fakePipeline.ResourcesReturns([]db.Resource{resource1, resource2}, nil)
+
+				fakeJob.GetFullNextBuildInputsReturns([]db.BuildInput{
+					{
+						Name:    "some-other-input",
+						Version: atc.Version{"some": "other-version"},
+					},
+				}, true, nil)
+			})
+
+			Context("when the request body is malformed", func() {
+				BeforeEach(func() {
+					requestBody = `{`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when the job is not found", func() {
+				BeforeEach(func() {
+					fakePipeline.JobReturns(nil, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when an artifact is given for an unknown input", func() {
+				BeforeEach(func() {
+					requestBody = `{"inputs":{"bogus-input":12}}`
+				})
+
+				It("returns 400 without creating a build", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(body)).To(Equal("unknown input `bogus-input`"))
+
+					Expect(fakePipeline.CreateStartedBuildCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when an input has no version", func() {
+				BeforeEach(func() {
+					fakeJob.GetFullNextBuildInputsReturns(nil, false, nil)
+				})
+
+				It("returns 400 without creating a build", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(body)).To(Equal("version for input some-other-input not provided"))
+
+					Expect(fakePipeline.CreateStartedBuildCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when creating the build fails", func() {
+				BeforeEach(func() {
+					fakePipeline.CreateStartedBuildReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+
+			Context("when creating the build succeeds", func() {
+				BeforeEach(func() {
+					build := new(dbfakes.FakeBuild)
+					build.IDReturns(42)
+					build.NameReturns("1")
+					build.PipelineNameReturns("some-pipeline")
+					build.TeamNameReturns("some-team")
+					build.StatusReturns(db.BuildStatusStarted)
+
+					fakePipeline.CreateStartedBuildReturns(build, nil)
+				})
+
+				It("returns 201 with the build", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"id": 42,
+						"name": "1",
+						"status": "started",
+						"api_url": "/api/v1/builds/42",
+						"pipeline_name": "some-pipeline",
+						"team_name": "some-team"
+					}`))
+				})
+
+				It("plans the job with the artifacts and without the puts", func() {
+					Expect(fakePipeline.CreateStartedBuildCallCount()).To(Equal(1))
+
+					plan := fakePipeline.CreateStartedBuildArgsForCall(0)
+					Expect(plan.Do).NotTo(BeNil())
+					Expect(*plan.Do).To(HaveLen(2))
+
+					artifactInput := (*plan.Do)[0].ArtifactInput
+					Expect(artifactInput).To(Equal(&atc.ArtifactInputPlan{
+						ArtifactID: 12,
+						Name:       "some-input",
+					}))
+
+					get := (*plan.Do)[1].Get
+					Expect(get).NotTo(BeNil())
+					Expect(get.Resource).To(Equal("some-other-resource"))
+					Expect(get.Source).To(Equal(atc.Source{"some": "other-source"}))
+					Expect(get.Version).To(Equal(&atc.Version{"some": "other-version"}))
+				})
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/inputs", func() {
 		var response *http.Response
 
@@ -1835,6 +2086,151 @@ var _ = Describe("Jobs API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/scheduling-explanation", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/scheduling-explanation")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+			})
+
+			Context("when not authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthorizedReturns(false)
+				})
+
+				It("returns 403", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("when authorized", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthorizedReturns(true)
+				})
+
+				Context("when getting the job fails", func() {
+					BeforeEach(func() {
+						fakePipeline.JobReturns(nil, false, errors.New("some-error"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when the job is not found", func() {
+					BeforeEach(func() {
+						fakePipeline.JobReturns(nil, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when getting the job succeeds", func() {
+					BeforeEach(func() {
+						fakePipeline.JobReturns(fakeJob, true, nil)
+					})
+
+					It("looks up the requested job", func() {
+						Expect(fakePipeline.JobArgsForCall(0)).To(Equal("some-job"))
+					})
+
+					Context("when getting the explanation fails", func() {
+						BeforeEach(func() {
+							fakeJob.SchedulingExplanationReturns(atc.SchedulingExplanation{}, errors.New("some-error"))
+						})
+
+						It("returns 500", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+						})
+					})
+
+					Context("when getting the explanation succeeds", func() {
+						BeforeEach(func() {
+							fakeJob.SchedulingExplanationReturns(atc.SchedulingExplanation{
+								InputsDetermined: false,
+								Inputs: []atc.InputExplanation{
+									{
+										Name:     "some-input",
+										Resource: "some-resource",
+										Passed:   []string{"upstream-job"},
+										Error:    "no satisfiable builds from passed jobs found for set of inputs",
+										Candidates: []atc.CandidateVersion{
+											{
+												Version:   atc.Version{"ref": "v1"},
+												PassedJob: "upstream-job",
+												BuildID:   42,
+												BuildName: "7",
+												Rejection: "version is disabled",
+											},
+										},
+									},
+								},
+							}, nil)
+						})
+
+						It("returns 200 OK", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+						})
+
+						It("returns Content-Type 'application/json'", func() {
+							expectedHeaderEntries := map[string]string{
+								"Content-Type": "application/json",
+							}
+							Expect(response).Should(IncludeHeaderEntries(expectedHeaderEntries))
+						})
+
+						It("returns the explanation", func() {
+							body, err := ioutil.ReadAll(response.Body)
+							Expect(err).NotTo(HaveOccurred())
+
+							Expect(body).To(MatchJSON(`{
+								"inputs_determined": false,
+								"inputs": [
+									{
+										"name": "some-input",
+										"resource": "some-resource",
+										"passed": ["upstream-job"],
+										"error": "no satisfiable builds from passed jobs found for set of inputs",
+										"candidates": [
+											{
+												"version": {"ref": "v1"},
+												"passed_job": "upstream-job",
+												"build_id": 42,
+												"build_name": "7",
+												"rejection": "version is disabled"
+											}
+										]
+									}
+								]
+							}`))
+						})
+					})
+				})
+			})
+		})
+	})
+
 	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", func() {
 		var response *http.Response
 
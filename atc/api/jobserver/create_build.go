@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strconv"
 
+	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc/api/present"
 	"github.com/concourse/concourse/atc/db"
@@ -34,7 +36,19 @@ func (s *Server) CreateJobBuild(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		build, err := job.CreateBuild()
+		var build db.Build
+		if rawPriority := r.FormValue("priority"); rawPriority != "" {
+			priority, err := strconv.Atoi(rawPriority)
+			if err != nil {
+				logger.Info("malformed-priority", lager.Data{"priority": rawPriority})
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			build, err = job.CreateBuildWithPriority(priority)
+		} else {
+			build, err = job.CreateBuild()
+		}
 		if err != nil {
 			logger.Error("failed-to-create-job-build", err)
 			w.WriteHeader(http.StatusInternalServerError)
@@ -0,0 +1,132 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/builds"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// CreateJobOneOffBuild runs the plan of a job as a one-off build of its
+// pipeline, planned the same way as the job's own builds.
+func (s *Server) CreateJobOneOffBuild(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("create-job-one-off-build")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var config atc.JobOneOffBuildConfig
+		err := json.NewDecoder(r.Body).Decode(&config)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		jobName := r.FormValue(":job_name")
+
+		job, found, err := pipeline.Job(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		jobConfig, err := job.Config()
+		if err != nil {
+			logger.Error("failed-to-get-job-config", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		for name := range config.Inputs {
+			if !hasJobInput(jobConfig.Inputs(), name) {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "unknown input `%s`", name)
+				return
+			}
+		}
+
+		resources, err := pipeline.Resources()
+		if err != nil {
+			logger.Error("failed-to-get-resources", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		resourceTypes, err := pipeline.ResourceTypes()
+		if err != nil {
+			logger.Error("failed-to-get-resource-types", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		// inputs which are not overridden by an artifact need a version, which
+		// the planner reports if they are missing
+		buildInputs, _, err := job.GetFullNextBuildInputs()
+		if err != nil {
+			logger.Error("failed-to-get-next-build-inputs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		schedulerResources := make(db.SchedulerResources, len(resources))
+		for i, resource := range resources {
+			schedulerResources[i] = db.SchedulerResource{
+				Name:   resource.Name(),
+				Type:   resource.Type(),
+				Source: resource.Source(),
+			}
+		}
+
+		planner := builds.NewPlanner(atc.NewPlanFactory(time.Now().Unix()))
+
+		plan, err := planner.CreateOneOff(
+			jobConfig.StepConfig(),
+			schedulerResources,
+			resourceTypes.Deserialize(),
+			buildInputs,
+			config,
+		)
+		if err != nil {
+			logger.Info("failed-to-plan-job", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, err.Error())
+			return
+		}
+
+		build, err := pipeline.CreateStartedBuild(plan)
+		if err != nil {
+			logger.Error("failed-to-create-one-off-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		err = json.NewEncoder(w).Encode(present.Build(build))
+		if err != nil {
+			logger.Error("failed-to-encode-build", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func hasJobInput(inputs []atc.JobInputParams, name string) bool {
+	for _, input := range inputs {
+		if input.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
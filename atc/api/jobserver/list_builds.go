@@ -53,18 +53,17 @@ func (s *Server) ListJobBuilds(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		page := db.Page{
+			Since:  since,
+			Until:  until,
+			Limit:  limit,
+			Secret: r.FormValue(atc.BuildsQuerySecret),
+		}
+
 		if timestamps == "" {
-			builds, pagination, err = job.Builds(db.Page{
-				Since: since,
-				Until: until,
-				Limit: limit,
-			})
+			builds, pagination, err = job.Builds(page)
 		} else {
-			builds, pagination, err = job.BuildsWithTime(db.Page{
-				Since: since,
-				Until: until,
-				Limit: limit,
-			})
+			builds, pagination, err = job.BuildsWithTime(page)
 		}
 		if err != nil {
 			w.WriteHeader(http.StatusNotFound)
@@ -97,7 +96,7 @@ func (s *Server) ListJobBuilds(pipeline db.Pipeline) http.Handler {
 
 func (s *Server) addNextLink(w http.ResponseWriter, teamName, pipelineName, jobName string, page db.Page) {
 	w.Header().Add("Link", fmt.Sprintf(
-		`<%s/api/v1/teams/%s/pipelines/%s/jobs/%s/builds?%s=%d&%s=%d>; rel="%s"`,
+		`<%s/api/v1/teams/%s/pipelines/%s/jobs/%s/builds?%s=%d&%s=%d%s>; rel="%s"`,
 		s.externalURL,
 		teamName,
 		pipelineName,
@@ -106,13 +105,14 @@ func (s *Server) addNextLink(w http.ResponseWriter, teamName, pipelineName, jobN
 		page.Since,
 		atc.PaginationQueryLimit,
 		page.Limit,
+		atc.BuildsSecretLinkParam(page.Secret),
 		atc.LinkRelNext,
 	))
 }
 
 func (s *Server) addPreviousLink(w http.ResponseWriter, teamName, pipelineName, jobName string, page db.Page) {
 	w.Header().Add("Link", fmt.Sprintf(
-		`<%s/api/v1/teams/%s/pipelines/%s/jobs/%s/builds?%s=%d&%s=%d>; rel="%s"`,
+		`<%s/api/v1/teams/%s/pipelines/%s/jobs/%s/builds?%s=%d&%s=%d%s>; rel="%s"`,
 		s.externalURL,
 		teamName,
 		pipelineName,
@@ -121,6 +121,7 @@ func (s *Server) addPreviousLink(w http.ResponseWriter, teamName, pipelineName,
 		page.Until,
 		atc.PaginationQueryLimit,
 		page.Limit,
+		atc.BuildsSecretLinkParam(page.Secret),
 		atc.LinkRelPrevious,
 	))
 }
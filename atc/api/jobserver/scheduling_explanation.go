@@ -0,0 +1,44 @@
+package jobserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) GetSchedulingExplanation(pipeline db.Pipeline) http.Handler {
+	logger := s.logger.Session("get-scheduling-explanation")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jobName := r.FormValue(":job_name")
+
+		job, found, err := pipeline.Job(jobName)
+		if err != nil {
+			logger.Error("failed-to-get-job", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		explanation, err := job.SchedulingExplanation()
+		if err != nil {
+			logger.Error("failed-to-get-scheduling-explanation", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(explanation)
+		if err != nil {
+			logger.Error("failed-to-encode-scheduling-explanation", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -162,6 +162,30 @@ var _ = Describe("Pipelines API", func() {
 			})
 		})
 
+		Context("when pipelines are instances of the same pipeline", func() {
+			BeforeEach(func() {
+				publicPipeline.InstanceVarsReturns(atc.InstanceVars{"branch": "master"})
+				anotherPublicPipeline.NameReturns("public-pipeline")
+				anotherPublicPipeline.InstanceVarsReturns(atc.InstanceVars{"branch": "feature"})
+			})
+
+			It("returns the instance vars of each pipeline", func() {
+				var pipelines []atc.Pipeline
+				err := json.NewDecoder(response.Body).Decode(&pipelines)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(pipelines).To(HaveLen(2))
+				Expect(pipelines[0].Ref()).To(Equal(atc.PipelineRef{
+					Name:         "public-pipeline",
+					InstanceVars: atc.InstanceVars{"branch": "master"},
+				}))
+				Expect(pipelines[1].Ref()).To(Equal(atc.PipelineRef{
+					Name:         "public-pipeline",
+					InstanceVars: atc.InstanceVars{"branch": "feature"},
+				}))
+			})
+		})
+
 		Context("when not authenticated", func() {
 			It("returns only public pipelines", func() {
 				body, err := ioutil.ReadAll(response.Body)
@@ -835,8 +859,8 @@ var _ = Describe("Pipelines API", func() {
 				})
 
 				It("injects the proper pipelineDB", func() {
-					pipelineName := fakeTeam.PipelineArgsForCall(0)
-					Expect(pipelineName).To(Equal("a-pipeline-name"))
+					pipelineRef := fakeTeam.PipelineArgsForCall(0)
+					Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline-name"}))
 				})
 
 				It("deletes the named pipeline from the database", func() {
@@ -908,8 +932,8 @@ var _ = Describe("Pipelines API", func() {
 				})
 
 				It("injects the proper pipelineDB", func() {
-					pipelineName := fakeTeam.PipelineArgsForCall(0)
-					Expect(pipelineName).To(Equal("a-pipeline"))
+					pipelineRef := fakeTeam.PipelineArgsForCall(0)
+					Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 				})
 
 				Context("when pausing the pipeline succeeds", func() {
@@ -1034,8 +1058,8 @@ var _ = Describe("Pipelines API", func() {
 				})
 
 				It("injects the proper pipelineDB", func() {
-					pipelineName := fakeTeam.PipelineArgsForCall(0)
-					Expect(pipelineName).To(Equal("a-pipeline"))
+					pipelineRef := fakeTeam.PipelineArgsForCall(0)
+					Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 				})
 
 				Context("when unpausing the pipeline succeeds", func() {
@@ -1118,8 +1142,8 @@ var _ = Describe("Pipelines API", func() {
 
 				It("injects the proper pipelineDB", func() {
 					Expect(fakeTeam.PipelineCallCount()).To(Equal(1))
-					pipelineName := fakeTeam.PipelineArgsForCall(0)
-					Expect(pipelineName).To(Equal("a-pipeline"))
+					pipelineRef := fakeTeam.PipelineArgsForCall(0)
+					Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 				})
 
 				Context("when exposing the pipeline succeeds", func() {
@@ -1196,8 +1220,8 @@ var _ = Describe("Pipelines API", func() {
 				})
 
 				It("injects the proper pipeline", func() {
-					pipelineName := fakeTeam.PipelineArgsForCall(0)
-					Expect(pipelineName).To(Equal("a-pipeline"))
+					pipelineRef := fakeTeam.PipelineArgsForCall(0)
+					Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 				})
 
 				Context("when hiding the pipeline succeeds", func() {
@@ -1595,8 +1619,8 @@ var _ = Describe("Pipelines API", func() {
 				})
 
 				It("injects the proper pipeline", func() {
-					pipelineName := fakeTeam.PipelineArgsForCall(0)
-					Expect(pipelineName).To(Equal("a-pipeline"))
+					pipelineRef := fakeTeam.PipelineArgsForCall(0)
+					Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "a-pipeline"}))
 				})
 
 				It("returns 200", func() {
@@ -1835,6 +1859,234 @@ var _ = Describe("Pipelines API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/builds/search", func() {
+		var response *http.Response
+		var queryParams string
+
+		BeforeEach(func() {
+			queryParams = "?q=connection+refused"
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			fakePipeline.NameReturns("some-pipeline")
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/pipelines/some-pipeline/builds/search" + queryParams)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+				fakePipeline.PublicReturns(true)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when no query is given", func() {
+				BeforeEach(func() {
+					queryParams = ""
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakePipeline.SearchBuildLogsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when no pagination params are passed", func() {
+				It("searches the builds of the whole pipeline with the default limit", func() {
+					Expect(fakePipeline.SearchBuildLogsCallCount()).To(Equal(1))
+
+					query, jobID, page := fakePipeline.SearchBuildLogsArgsForCall(0)
+					Expect(query).To(Equal("connection refused"))
+					Expect(jobID).To(BeZero())
+					Expect(page).To(Equal(db.Page{Limit: 100}))
+				})
+			})
+
+			Context("when the limit is negative", func() {
+				BeforeEach(func() {
+					queryParams = "?q=oops&limit=-1"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakePipeline.SearchBuildLogsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the limit is not a number", func() {
+				BeforeEach(func() {
+					queryParams = "?q=oops&limit=lots"
+				})
+
+				It("returns 400 Bad Request", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					Expect(fakePipeline.SearchBuildLogsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the limit is too large", func() {
+				BeforeEach(func() {
+					queryParams = "?q=oops&limit=1000000"
+				})
+
+				It("caps it", func() {
+					Expect(fakePipeline.SearchBuildLogsCallCount()).To(Equal(1))
+
+					_, _, page := fakePipeline.SearchBuildLogsArgsForCall(0)
+					Expect(page).To(Equal(db.Page{Limit: 100}))
+				})
+			})
+
+			Context("when a job is given", func() {
+				BeforeEach(func() {
+					queryParams = "?q=oops&job=some-job&until=10&limit=5"
+				})
+
+				Context("when the job exists", func() {
+					BeforeEach(func() {
+						fakeJob := new(dbfakes.FakeJob)
+						fakeJob.IDReturns(42)
+						fakePipeline.JobReturns(fakeJob, true, nil)
+					})
+
+					It("only searches the builds of the job", func() {
+						Expect(fakePipeline.JobArgsForCall(0)).To(Equal("some-job"))
+						Expect(fakePipeline.SearchBuildLogsCallCount()).To(Equal(1))
+
+						query, jobID, page := fakePipeline.SearchBuildLogsArgsForCall(0)
+						Expect(query).To(Equal("oops"))
+						Expect(jobID).To(Equal(42))
+						Expect(page).To(Equal(db.Page{Until: 10, Limit: 5}))
+					})
+				})
+
+				Context("when the job does not exist", func() {
+					BeforeEach(func() {
+						fakePipeline.JobReturns(nil, false, nil)
+					})
+
+					It("returns 404 Not Found", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when getting the job fails", func() {
+					BeforeEach(func() {
+						fakePipeline.JobReturns(nil, false, errors.New("oh no!"))
+					})
+
+					It("returns 500 Internal Server Error", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the search succeeds", func() {
+				BeforeEach(func() {
+					fakePipeline.SearchBuildLogsReturns(atc.BuildLogSearch{
+						Matches: []atc.BuildLogMatch{
+							{
+								BuildID:   4,
+								BuildName: "2",
+								JobName:   "some-job",
+								StepID:    "some-plan-id",
+								StepName:  "some-task",
+								Source:    "stderr",
+								Time:      123,
+								Line:      "dial tcp: connection refused",
+							},
+						},
+						ArchivedBuilds: 3,
+					}, db.Pagination{}, nil)
+				})
+
+				It("returns 200 OK", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns Content-Type 'application/json'", func() {
+					expectedHeaderEntries := map[string]string{
+						"Content-Type": "application/json",
+					}
+					Expect(response).Should(IncludeHeaderEntries(expectedHeaderEntries))
+				})
+
+				It("returns the matching lines and the number of archived builds which were not searched", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`{
+						"matches": [
+							{
+								"build_id": 4,
+								"build_name": "2",
+								"job_name": "some-job",
+								"step_id": "some-plan-id",
+								"step_name": "some-task",
+								"source": "stderr",
+								"time": 123,
+								"line": "dial tcp: connection refused"
+							}
+						],
+						"archived_builds": 3
+					}`))
+				})
+
+				Context("when there are more builds with matches", func() {
+					BeforeEach(func() {
+						fakePipeline.SearchBuildLogsReturns(atc.BuildLogSearch{Matches: []atc.BuildLogMatch{}}, db.Pagination{
+							Next: &db.Page{Until: 4, Limit: 100},
+						}, nil)
+					})
+
+					It("returns a Link header for the next page, keeping the query", func() {
+						Expect(response.Header["Link"]).To(ConsistOf([]string{
+							fmt.Sprintf(`<%s/api/v1/teams/some-team/pipelines/some-pipeline/builds/search?limit=100&q=connection+refused&until=4>; rel="next"`, externalURL),
+						}))
+					})
+
+					Context("when the pipeline is an instanced pipeline", func() {
+						BeforeEach(func() {
+							fakePipeline.RefReturns(atc.PipelineRef{
+								Name:         "some-pipeline",
+								InstanceVars: atc.InstanceVars{"branch": "master"},
+							})
+						})
+
+						It("keeps the instance vars in the Link header", func() {
+							Expect(response.Header["Link"]).To(ConsistOf([]string{
+								fmt.Sprintf(`<%s/api/v1/teams/some-team/pipelines/some-pipeline/builds/search?limit=100&q=connection+refused&until=4&vars=%%7B%%22branch%%22%%3A%%22master%%22%%7D>; rel="next"`, externalURL),
+							}))
+						})
+					})
+				})
+			})
+
+			Context("when the search fails", func() {
+				BeforeEach(func() {
+					fakePipeline.SearchBuildLogsReturns(atc.BuildLogSearch{}, db.Pagination{}, errors.New("oh no!"))
+				})
+
+				It("returns 500 Internal Server Error", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
 	Describe("POST /api/v1/teams/:team_name/pipelines/:pipeline_name/builds", func() {
 		var plan atc.Plan
 		var response *http.Response
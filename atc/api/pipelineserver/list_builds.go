@@ -40,7 +40,12 @@ func (s *Server) ListPipelineBuilds(pipeline db.Pipeline) http.Handler {
 			limit = atc.PaginationAPIDefaultLimit
 		}
 
-		page := db.Page{Until: until, Since: since, Limit: limit}
+		page := db.Page{
+			Until:  until,
+			Since:  since,
+			Limit:  limit,
+			Secret: r.FormValue(atc.BuildsQuerySecret),
+		}
 
 		if timestamps == "" {
 			builds, pagination, err = pipeline.Builds(page)
@@ -85,7 +90,7 @@ func (s *Server) ListPipelineBuilds(pipeline db.Pipeline) http.Handler {
 
 func (s *Server) addNextLink(w http.ResponseWriter, teamName, pipelineName string, page db.Page) {
 	w.Header().Add("Link", fmt.Sprintf(
-		`<%s/api/v1/teams/%s/pipelines/%s/builds?%s=%d&%s=%d>; rel="%s"`,
+		`<%s/api/v1/teams/%s/pipelines/%s/builds?%s=%d&%s=%d%s>; rel="%s"`,
 		s.externalURL,
 		teamName,
 		pipelineName,
@@ -93,13 +98,14 @@ func (s *Server) addNextLink(w http.ResponseWriter, teamName, pipelineName strin
 		page.Since,
 		atc.PaginationQueryLimit,
 		page.Limit,
+		atc.BuildsSecretLinkParam(page.Secret),
 		atc.LinkRelNext,
 	))
 }
 
 func (s *Server) addPreviousLink(w http.ResponseWriter, teamName, pipelineName string, page db.Page) {
 	w.Header().Add("Link", fmt.Sprintf(
-		`<%s/api/v1/teams/%s/pipelines/%s/builds?%s=%d&%s=%d>; rel="%s"`,
+		`<%s/api/v1/teams/%s/pipelines/%s/builds?%s=%d&%s=%d%s>; rel="%s"`,
 		s.externalURL,
 		teamName,
 		pipelineName,
@@ -107,6 +113,7 @@ func (s *Server) addPreviousLink(w http.ResponseWriter, teamName, pipelineName s
 		page.Until,
 		atc.PaginationQueryLimit,
 		page.Limit,
+		atc.BuildsSecretLinkParam(page.Secret),
 		atc.LinkRelPrevious,
 	))
 }
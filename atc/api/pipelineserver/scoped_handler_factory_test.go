@@ -5,7 +5,9 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/api/auth"
 	"github.com/concourse/concourse/atc/api/pipelineserver"
 	"github.com/concourse/concourse/atc/db"
@@ -25,7 +27,8 @@ var _ = Describe("Handler", func() {
 		fakeTeam      *dbfakes.FakeTeam
 		fakePipeline  *dbfakes.FakePipeline
 
-		handler http.Handler
+		handler    http.Handler
+		extraQuery string
 	)
 
 	BeforeEach(func() {
@@ -37,12 +40,13 @@ var _ = Describe("Handler", func() {
 
 		handlerFactory := pipelineserver.NewScopedHandlerFactory(dbTeamFactory)
 		handler = handlerFactory.HandlerFor(delegate.GetHandler)
+		extraQuery = ""
 	})
 
 	JustBeforeEach(func() {
 		server = httptest.NewServer(handler)
 
-		request, err := http.NewRequest("POST", server.URL+"?:team_name=some-team&:pipeline_name=some-pipeline", nil)
+		request, err := http.NewRequest("POST", server.URL+"?:team_name=some-team&:pipeline_name=some-pipeline"+extraQuery, nil)
 		Expect(err).NotTo(HaveOccurred())
 
 		response, err = new(http.Client).Do(request)
@@ -115,7 +119,7 @@ var _ = Describe("Handler", func() {
 
 				It("looks up the pipeline by the right name", func() {
 					Expect(fakeTeam.PipelineCallCount()).To(Equal(1))
-					Expect(fakeTeam.PipelineArgsForCall(0)).To(Equal("some-pipeline"))
+					Expect(fakeTeam.PipelineArgsForCall(0)).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
 				})
 
 				It("returns 200", func() {
@@ -125,6 +129,33 @@ var _ = Describe("Handler", func() {
 				It("calls the scoped handler", func() {
 					Expect(delegate.IsCalled).To(BeTrue())
 				})
+
+				Context("when instance vars are given", func() {
+					BeforeEach(func() {
+						extraQuery = "&vars=" + url.QueryEscape(`{"branch":"feature"}`)
+					})
+
+					It("looks up the pipeline instance", func() {
+						Expect(fakeTeam.PipelineArgsForCall(0)).To(Equal(atc.PipelineRef{
+							Name:         "some-pipeline",
+							InstanceVars: atc.InstanceVars{"branch": "feature"},
+						}))
+					})
+				})
+
+				Context("when the instance vars are malformed", func() {
+					BeforeEach(func() {
+						extraQuery = "&vars=" + url.QueryEscape(`{"branch":`)
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+
+					It("does not call the scoped handler", func() {
+						Expect(delegate.IsCalled).To(BeFalse())
+					})
+				})
 			})
 
 			Context("when the pipeline does not exist", func() {
@@ -0,0 +1,108 @@
+package pipelineserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// maxBuildLogSearchLimit caps the number of builds with matches returned in a
+// single page of a log search.
+const maxBuildLogSearchLimit = 100
+
+// SearchBuildLogs searches the log lines of the pipeline's builds. The events
+// of builds archived to object storage are not searched; the response counts
+// them as archived_builds instead.
+func (s *Server) SearchBuildLogs(pipeline db.Pipeline) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("search-build-logs")
+
+		teamName := r.FormValue(":team_name")
+
+		query := r.FormValue("q")
+		if query == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "missing search query")
+			return
+		}
+
+		var jobID int
+		jobName := r.FormValue("job")
+		if jobName != "" {
+			job, found, err := pipeline.Job(jobName)
+			if err != nil {
+				logger.Error("failed-to-get-job", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			if !found {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			jobID = job.ID()
+		}
+
+		until, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryUntil))
+
+		limit := atc.PaginationAPIDefaultLimit
+		if r.FormValue(atc.PaginationQueryLimit) != "" {
+			var err error
+			limit, err = strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+			if err != nil || limit < 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, "limit must be a positive integer")
+				return
+			}
+
+			if limit > maxBuildLogSearchLimit {
+				limit = maxBuildLogSearchLimit
+			}
+		}
+
+		search, pagination, err := pipeline.SearchBuildLogs(query, jobID, db.Page{Until: until, Limit: limit})
+		if err != nil {
+			logger.Error("failed-to-search-build-logs", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if pagination.Next != nil {
+			params := pipeline.Ref().QueryParams()
+			if params == nil {
+				params = url.Values{}
+			}
+
+			params.Set("q", query)
+			if jobName != "" {
+				params.Set("job", jobName)
+			}
+			params.Set(atc.PaginationQueryUntil, strconv.Itoa(pagination.Next.Until))
+			params.Set(atc.PaginationQueryLimit, strconv.Itoa(pagination.Next.Limit))
+
+			w.Header().Add("Link", fmt.Sprintf(
+				`<%s/api/v1/teams/%s/pipelines/%s/builds/search?%s>; rel="%s"`,
+				s.externalURL,
+				teamName,
+				pipeline.Name(),
+				params.Encode(),
+				atc.LinkRelNext,
+			))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(search)
+		if err != nil {
+			logger.Error("failed-to-encode-build-log-matches", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
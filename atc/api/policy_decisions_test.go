@@ -0,0 +1,125 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/atc/testhelpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Policy Decisions API", func() {
+	Describe("GET /api/v1/teams/a-team/policy_decisions", func() {
+		var (
+			query    string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = ""
+			dbTeam.NameReturns("a-team")
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/a-team/policy_decisions" + query)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403 Forbidden", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+
+				dbPolicyDecisionFactory.DecisionsReturns([]atc.PolicyDecision{
+					{
+						ID:        2,
+						Team:      "a-team",
+						Pipeline:  "some-pipeline",
+						Action:    "SaveConfig",
+						Reasons:   []string{"some-reason"},
+						AuditOnly: true,
+						CreatedAt: 42,
+					},
+				}, nil)
+			})
+
+			It("returns 200", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			It("returns Content-Type 'application/json'", func() {
+				expectedHeaderEntries := map[string]string{
+					"Content-Type": "application/json",
+				}
+				Expect(response).Should(IncludeHeaderEntries(expectedHeaderEntries))
+			})
+
+			It("returns the decisions of the team", func() {
+				teamName, pipelineName, limit := dbPolicyDecisionFactory.DecisionsArgsForCall(0)
+				Expect(teamName).To(Equal("a-team"))
+				Expect(pipelineName).To(BeEmpty())
+				Expect(limit).To(Equal(atc.PaginationAPIDefaultLimit))
+
+				Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[
+					{
+						"id": 2,
+						"team": "a-team",
+						"pipeline": "some-pipeline",
+						"action": "SaveConfig",
+						"reasons": ["some-reason"],
+						"audit_only": true,
+						"created_at": 42
+					}
+				]`))
+			})
+
+			Context("when filtering by pipeline with a limit", func() {
+				BeforeEach(func() {
+					query = "?pipeline=some-pipeline&limit=5"
+				})
+
+				It("passes the filters on", func() {
+					_, pipelineName, limit := dbPolicyDecisionFactory.DecisionsArgsForCall(0)
+					Expect(pipelineName).To(Equal("some-pipeline"))
+					Expect(limit).To(Equal(5))
+				})
+			})
+
+			Context("when getting the decisions fails", func() {
+				BeforeEach(func() {
+					dbPolicyDecisionFactory.DecisionsReturns(nil, errors.New("disaster"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+})
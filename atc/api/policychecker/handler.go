@@ -49,6 +49,9 @@ func (h policyCheckingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 			fmt.Fprintf(w, policyCheckErr.Error())
 			return
 		}
+		if result.Warned {
+			r = r.WithContext(policy.RecordWarnings(r.Context(), result.Reasons))
+		}
 	}
 
 	h.handler.ServeHTTP(w, r)
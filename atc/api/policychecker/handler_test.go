@@ -19,6 +19,7 @@ import (
 var _ = Describe("Handler", func() {
 	var (
 		innerHandlerCalled   bool
+		innerRequest         *http.Request
 		dummyHandler         http.HandlerFunc
 		policyCheckerHandler http.Handler
 		req                  *http.Request
@@ -34,6 +35,7 @@ var _ = Describe("Handler", func() {
 		innerHandlerCalled = false
 		dummyHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			innerHandlerCalled = true
+			innerRequest = r
 		})
 
 		responseWriter = httptest.NewRecorder()
@@ -70,6 +72,28 @@ var _ = Describe("Handler", func() {
 			It("calls the inner handler", func() {
 				Expect(innerHandlerCalled).To(BeTrue())
 			})
+
+			It("does not record any warnings", func() {
+				Expect(policy.WarningsFromContext(innerRequest.Context())).To(BeEmpty())
+			})
+		})
+
+		Context("policy check doesn't pass in audit-only mode", func() {
+			BeforeEach(func() {
+				fakePolicyChecker.CheckReturns(policy.PolicyCheckOutput{
+					Allowed: true,
+					Warned:  true,
+					Reasons: []string{"a policy says you shouldn't do that"},
+				}, nil)
+			})
+
+			It("calls the inner handler", func() {
+				Expect(innerHandlerCalled).To(BeTrue())
+			})
+
+			It("passes the reasons on as warnings", func() {
+				Expect(policy.WarningsFromContext(innerRequest.Context())).To(Equal([]string{"a policy says you shouldn't do that"}))
+			})
 		})
 
 		Context("policy check doesn't pass", func() {
@@ -0,0 +1,41 @@
+package policydecisionserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListPolicyDecisions(team db.Team) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-policy-decisions", lager.Data{
+			"team": team.Name(),
+		})
+
+		pipelineName := r.FormValue("pipeline")
+
+		limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+		if limit == 0 {
+			limit = atc.PaginationAPIDefaultLimit
+		}
+
+		decisions, err := s.decisionFactory.Decisions(team.Name(), pipelineName, limit)
+		if err != nil {
+			logger.Error("failed-to-get-policy-decisions", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(decisions)
+		if err != nil {
+			logger.Error("failed-to-encode-policy-decisions", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
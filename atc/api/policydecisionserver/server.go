@@ -0,0 +1,18 @@
+package policydecisionserver
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type Server struct {
+	logger          lager.Logger
+	decisionFactory db.PolicyDecisionFactory
+}
+
+func NewServer(logger lager.Logger, decisionFactory db.PolicyDecisionFactory) *Server {
+	return &Server{
+		logger:          logger,
+		decisionFactory: decisionFactory,
+	}
+}
@@ -19,13 +19,16 @@ func Build(build db.Build) atc.Build {
 	}
 
 	atcBuild := atc.Build{
-		ID:           build.ID(),
-		Name:         build.Name(),
-		JobName:      build.JobName(),
-		PipelineName: build.PipelineName(),
-		TeamName:     build.TeamName(),
-		Status:       string(build.Status()),
-		APIURL:       apiURL,
+		ID:                   build.ID(),
+		Name:                 build.Name(),
+		JobName:              build.JobName(),
+		PipelineName:         build.PipelineName(),
+		PipelineInstanceVars: build.PipelineInstanceVars(),
+		TeamName:             build.TeamName(),
+		Status:               string(build.Status()),
+		APIURL:               apiURL,
+		Priority:             build.Priority(),
+		QuotaExceeded:        build.QuotaExceeded(),
 	}
 
 	if build.RerunOf() != 0 {
@@ -48,5 +51,9 @@ func Build(build db.Build) atc.Build {
 		atcBuild.ReapTime = build.ReapTime().Unix()
 	}
 
+	if usage := build.ResourceUsage(); usage != (atc.ResourceUsage{}) {
+		atcBuild.ResourceUsage = &usage
+	}
+
 	return atcBuild
 }
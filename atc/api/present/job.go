@@ -54,6 +54,7 @@ func Job(
 
 		Name:                 job.Name(),
 		PipelineName:         job.PipelineName(),
+		PipelineInstanceVars: job.PipelineInstanceVars(),
 		TeamName:             teamName,
 		DisableManualTrigger: job.DisableManualTrigger(),
 		Paused:               job.Paused(),
@@ -1,6 +1,8 @@
 package present
 
 import (
+	"time"
+
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
@@ -21,11 +23,12 @@ func Resource(resource db.Resource, showCheckError bool, teamName string) atc.Re
 	}
 
 	atcResource := atc.Resource{
-		Name:         resource.Name(),
-		PipelineName: resource.PipelineName(),
-		TeamName:     teamName,
-		Type:         resource.Type(),
-		Icon:         resource.Icon(),
+		Name:                 resource.Name(),
+		PipelineName:         resource.PipelineName(),
+		PipelineInstanceVars: resource.PipelineInstanceVars(),
+		TeamName:             teamName,
+		Type:                 resource.Type(),
+		Icon:                 resource.Icon(),
 
 		FailingToCheck:  failingToCheck,
 		CheckSetupError: checkErrString,
@@ -37,6 +40,10 @@ func Resource(resource db.Resource, showCheckError bool, teamName string) atc.Re
 		atcResource.LastChecked = resource.LastCheckEndTime().Unix()
 	}
 
+	if resource.LastCheckRateLimitDelay() >= time.Second {
+		atcResource.CheckRateLimitDelay = int64(resource.LastCheckRateLimitDelay().Round(time.Second) / time.Second)
+	}
+
 	if resource.ConfigPinnedVersion() != nil {
 		atcResource.PinnedVersion = resource.ConfigPinnedVersion()
 		atcResource.PinnedInConfig = true
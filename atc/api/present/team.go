@@ -6,9 +6,19 @@ import (
 )
 
 func Team(team db.Team) atc.Team {
-	return atc.Team{
+	atcTeam := atc.Team{
 		ID:   team.ID(),
 		Name: team.Name(),
 		Auth: team.Auth(),
 	}
+
+	if priority := team.DefaultBuildPriority(); priority != 0 {
+		atcTeam.DefaultBuildPriority = &priority
+	}
+
+	if quotas := team.Quotas(); quotas != (atc.TeamQuotas{}) {
+		atcTeam.Quotas = &quotas
+	}
+
+	return atcTeam
 }
@@ -40,8 +40,14 @@ func Worker(workerInfo db.Worker) atc.Worker {
 		State:            string(workerInfo.State()),
 		Version:          version,
 		Ephemeral:        workerInfo.Ephemeral(),
+		Unprivileged:     workerInfo.Unprivileged(),
+		SecurityProfiles: workerInfo.SecurityProfiles(),
 	}
 
+	allocatable := workerInfo.AllocatableResources()
+	atcWorker.AllocatableCPU = allocatable.CPU
+	atcWorker.AllocatableMemory = allocatable.Memory
+
 	if !workerInfo.StartTime().IsZero() {
 		atcWorker.StartTime = workerInfo.StartTime().Unix()
 	}
@@ -2,11 +2,15 @@ package api_test
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -54,6 +58,7 @@ var _ = Describe("Resources API", func() {
 				resource1.NameReturns("resource-1")
 				resource1.TypeReturns("type-1")
 				resource1.LastCheckEndTimeReturns(time.Unix(1513364881, 0))
+				resource1.LastCheckRateLimitDelayReturns(5 * time.Second)
 
 				resource2 := new(dbfakes.FakeResource)
 				resource2.IDReturns(2)
@@ -99,7 +104,8 @@ var _ = Describe("Resources API", func() {
 							"pipeline_name": "a-pipeline",
 							"team_name": "some-team",
 							"type": "type-1",
-							"last_checked": 1513364881
+							"last_checked": 1513364881,
+							"check_rate_limit_delay": 5
 						},
 						{
 							"name": "resource-2",
@@ -1029,6 +1035,25 @@ var _ = Describe("Resources API", func() {
 			})
 
 			Context("when the call to get a resource succeeds", func() {
+				Context("when the pipeline of the resource is an instanced pipeline", func() {
+					BeforeEach(func() {
+						resource1 := new(dbfakes.FakeResource)
+						resource1.PipelineNameReturns("a-pipeline")
+						resource1.PipelineInstanceVarsReturns(atc.InstanceVars{"branch": "master"})
+						resource1.NameReturns("resource-1")
+
+						fakePipeline.ResourceReturns(resource1, true, nil)
+					})
+
+					It("returns the instance vars of the pipeline", func() {
+						var resource atc.Resource
+						err := json.NewDecoder(response.Body).Decode(&resource)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(resource.PipelineInstanceVars).To(Equal(atc.InstanceVars{"branch": "master"}))
+					})
+				})
+
 				Context("when the resource version is pinned via pipeline config", func() {
 					BeforeEach(func() {
 						resource1 := new(dbfakes.FakeResource)
@@ -1545,4 +1570,413 @@ var _ = Describe("Resources API", func() {
 			})
 		})
 	})
+
+	Describe("POST /api/v1/teams/:team_name/webhooks/:provider", func() {
+		var (
+			provider string
+			payload  string
+			headers  http.Header
+			response *http.Response
+
+			matchingResource *dbfakes.FakeResource
+			otherBranch      *dbfakes.FakeResource
+			otherRepository  *dbfakes.FakeResource
+			otherTeam        *dbfakes.FakeResource
+			fakeCheck        *dbfakes.FakeCheck
+			fakeResourceType *dbfakes.FakeResourceType
+		)
+
+		sign := func(payload string) string {
+			mac := hmac.New(sha256.New, []byte("some-secret"))
+			mac.Write([]byte(payload))
+			return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+		}
+
+		newResource := func(team string, name string, source atc.Source) *dbfakes.FakeResource {
+			fakeResource := new(dbfakes.FakeResource)
+			fakeResource.TeamNameReturns(team)
+			fakeResource.PipelineNameReturns("some-pipeline")
+			fakeResource.NameReturns(name)
+			fakeResource.SourceReturns(source)
+			return fakeResource
+		}
+
+		BeforeEach(func() {
+			provider = "github"
+			payload = `{
+				"ref": "refs/heads/master",
+				"repository": {
+					"html_url": "https://github.com/concourse/concourse",
+					"clone_url": "https://github.com/concourse/concourse.git",
+					"ssh_url": "git@github.com:concourse/concourse.git"
+				}
+			}`
+			headers = http.Header{}
+			headers.Set("X-GitHub-Event", "push")
+
+			fakeSecretManager.GetStub = func(path string) (interface{}, *time.Time, bool, error) {
+				if path == "webhook_secret" {
+					return "some-secret", nil, true, nil
+				}
+				return nil, nil, false, nil
+			}
+
+			matchingResource = newResource("a-team", "matching", atc.Source{
+				"uri":    "git@github.com:concourse/concourse.git",
+				"branch": "master",
+			})
+			otherBranch = newResource("a-team", "other-branch", atc.Source{
+				"uri":    "https://github.com/concourse/concourse.git",
+				"branch": "release/6.7.x",
+			})
+			otherRepository = newResource("a-team", "other-repository", atc.Source{
+				"uri": "https://github.com/concourse/git-resource.git",
+			})
+			otherTeam = newResource("other-team", "other-team", atc.Source{
+				"uri": "https://github.com/concourse/concourse.git",
+			})
+
+			dbResourceFactory.VisibleResourcesReturns([]db.Resource{
+				matchingResource,
+				otherBranch,
+				otherRepository,
+				otherTeam,
+			}, nil)
+
+			fakeResourceType = new(dbfakes.FakeResourceType)
+			dbCheckFactory.ResourceTypesReturns([]db.ResourceType{fakeResourceType}, nil)
+
+			fakeCheck = new(dbfakes.FakeCheck)
+			fakeCheck.IDReturns(10)
+			fakeCheck.StatusReturns("started")
+			fakeCheck.CreateTimeReturns(time.Date(2000, 01, 01, 0, 0, 0, 0, time.UTC))
+			dbCheckFactory.TryCreateCheckReturns(fakeCheck, true, nil)
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/webhooks/"+provider, bytes.NewBufferString(payload))
+			Expect(err).NotTo(HaveOccurred())
+
+			for name, values := range headers {
+				request.Header[name] = values
+			}
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when the payload is too large", func() {
+			BeforeEach(func() {
+				payload = strings.Repeat(" ", 25*1024*1024+1)
+				headers.Set("X-Hub-Signature-256", sign(payload))
+			})
+
+			It("returns 400 without looking up the webhook secret", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				Expect(fakeSecretManager.GetCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the provider is github", func() {
+			Context("when the payload is signed with the team's webhook secret", func() {
+				BeforeEach(func() {
+					headers.Set("X-Hub-Signature-256", sign(payload))
+				})
+
+				It("looks up the resources of the team", func() {
+					Expect(dbResourceFactory.VisibleResourcesCallCount()).To(Equal(1))
+					Expect(dbResourceFactory.VisibleResourcesArgsForCall(0)).To(Equal([]string{"a-team"}))
+				})
+
+				It("checks the resources of the pushed repository and branch", func() {
+					Expect(dbCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
+					_, actualResource, actualResourceTypes, actualFromVersion, manuallyTriggered := dbCheckFactory.TryCreateCheckArgsForCall(0)
+					Expect(actualResource).To(Equal(matchingResource))
+					Expect(actualResourceTypes).To(Equal(db.ResourceTypes{fakeResourceType}))
+					Expect(actualFromVersion).To(BeNil())
+					Expect(manuallyTriggered).To(BeTrue())
+				})
+
+				It("notifies the checker", func() {
+					Expect(dbCheckFactory.NotifyCheckerCallCount()).To(Equal(1))
+				})
+
+				It("returns 200 with the created checks", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[{
+						"id": 10,
+						"status": "started",
+						"create_time": 946684800
+					}]`))
+				})
+
+				Context("when the push is not to a branch", func() {
+					BeforeEach(func() {
+						payload = `{
+							"ref": "refs/tags/v6.7.0",
+							"repository": {"clone_url": "https://github.com/concourse/concourse.git"}
+						}`
+						headers.Set("X-Hub-Signature-256", sign(payload))
+					})
+
+					It("does not check the resources tied to a branch", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(dbCheckFactory.TryCreateCheckCallCount()).To(BeZero())
+					})
+
+					Context("when a resource of the repository is not tied to a branch", func() {
+						BeforeEach(func() {
+							otherBranch.SourceReturns(atc.Source{
+								"uri": "https://github.com/concourse/concourse.git",
+							})
+						})
+
+						It("checks it", func() {
+							Expect(dbCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
+							_, actualResource, _, _, _ := dbCheckFactory.TryCreateCheckArgsForCall(0)
+							Expect(actualResource).To(Equal(otherBranch))
+						})
+					})
+				})
+
+				Context("when no resource matches", func() {
+					BeforeEach(func() {
+						payload = `{
+							"ref": "refs/heads/master",
+							"repository": {"clone_url": "https://github.com/concourse/docs.git"}
+						}`
+						headers.Set("X-Hub-Signature-256", sign(payload))
+					})
+
+					It("returns 200 without checking anything", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[]`))
+						Expect(dbCheckFactory.TryCreateCheckCallCount()).To(BeZero())
+						Expect(dbCheckFactory.NotifyCheckerCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when a check is already pending", func() {
+					BeforeEach(func() {
+						dbCheckFactory.TryCreateCheckReturns(nil, false, nil)
+					})
+
+					It("returns 200 without the check", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(ioutil.ReadAll(response.Body)).To(MatchJSON(`[]`))
+					})
+				})
+
+				Context("when creating a check fails", func() {
+					BeforeEach(func() {
+						dbCheckFactory.TryCreateCheckReturns(nil, false, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when getting the resources fails", func() {
+					BeforeEach(func() {
+						dbResourceFactory.VisibleResourcesReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+
+				Context("when the event is a ping", func() {
+					BeforeEach(func() {
+						payload = `{"zen": "Keep it logically awesome."}`
+						headers.Set("X-Hub-Signature-256", sign(payload))
+						headers.Set("X-GitHub-Event", "ping")
+					})
+
+					It("returns 200 without checking anything", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(dbResourceFactory.VisibleResourcesCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the event is not a push", func() {
+					BeforeEach(func() {
+						headers.Set("X-GitHub-Event", "issues")
+					})
+
+					It("returns 200 without checking anything", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(dbResourceFactory.VisibleResourcesCallCount()).To(BeZero())
+						Expect(dbCheckFactory.TryCreateCheckCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the payload is malformed", func() {
+					BeforeEach(func() {
+						payload = `{"repository": {}}`
+						headers.Set("X-Hub-Signature-256", sign(payload))
+					})
+
+					It("returns 400", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+					})
+				})
+			})
+
+			Context("when the signature does not match", func() {
+				BeforeEach(func() {
+					headers.Set("X-Hub-Signature-256", sign("something else"))
+				})
+
+				It("returns 401 without checking anything", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					Expect(dbResourceFactory.VisibleResourcesCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the payload is not signed", func() {
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("when the team has no webhook secret", func() {
+				BeforeEach(func() {
+					fakeSecretManager.GetStub = nil
+					fakeSecretManager.GetReturns(nil, nil, false, nil)
+					headers.Set("X-Hub-Signature-256", sign(payload))
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+		})
+
+		Context("when the provider is gitlab", func() {
+			BeforeEach(func() {
+				provider = "gitlab"
+				payload = `{
+					"ref": "refs/heads/master",
+					"project": {
+						"web_url": "https://gitlab.com/concourse/concourse",
+						"git_ssh_url": "git@gitlab.com:concourse/concourse.git",
+						"git_http_url": "https://gitlab.com/concourse/concourse.git"
+					}
+				}`
+
+				matchingResource.SourceReturns(atc.Source{
+					"uri":    "https://gitlab.com/Concourse/concourse",
+					"branch": "master",
+				})
+			})
+
+			Context("when the token is the team's webhook secret", func() {
+				BeforeEach(func() {
+					headers.Set("X-Gitlab-Token", "some-secret")
+					headers.Set("X-Gitlab-Event", "Push Hook")
+				})
+
+				Context("when the event is a tag push", func() {
+					BeforeEach(func() {
+						headers.Set("X-Gitlab-Event", "Tag Push Hook")
+					})
+
+					It("returns 200 without checking anything", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(dbCheckFactory.TryCreateCheckCallCount()).To(BeZero())
+					})
+				})
+
+				It("checks the resources of the pushed repository and branch", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(dbCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
+					_, actualResource, _, _, _ := dbCheckFactory.TryCreateCheckArgsForCall(0)
+					Expect(actualResource).To(Equal(matchingResource))
+				})
+			})
+
+			Context("when the token is wrong", func() {
+				BeforeEach(func() {
+					headers.Set("X-Gitlab-Token", "wrong-secret")
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+		})
+
+		Context("when the provider is bitbucket", func() {
+			BeforeEach(func() {
+				provider = "bitbucket"
+
+				matchingResource.SourceReturns(atc.Source{
+					"uri":    "git@bitbucket.org:concourse/concourse.git",
+					"branch": "master",
+				})
+			})
+
+			JustBeforeEach(func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+			})
+
+			Context("with a bitbucket cloud payload", func() {
+				BeforeEach(func() {
+					payload = `{
+						"repository": {"links": {"html": {"href": "https://bitbucket.org/concourse/concourse"}}},
+						"push": {"changes": [{"new": {"type": "branch", "name": "master"}}]}
+					}`
+					headers.Set("X-Hub-Signature", sign(payload))
+					headers.Set("X-Event-Key", "repo:push")
+				})
+
+				It("checks the resources of the pushed repository and branch", func() {
+					Expect(dbCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
+					_, actualResource, _, _, _ := dbCheckFactory.TryCreateCheckArgsForCall(0)
+					Expect(actualResource).To(Equal(matchingResource))
+				})
+
+				Context("when the event is a pull request", func() {
+					BeforeEach(func() {
+						headers.Set("X-Event-Key", "pullrequest:created")
+					})
+
+					It("does not check anything", func() {
+						Expect(dbCheckFactory.TryCreateCheckCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("with a bitbucket server payload", func() {
+				BeforeEach(func() {
+					payload = `{
+						"repository": {"links": {"clone": [
+							{"href": "ssh://git@bitbucket.org:7999/concourse/concourse.git", "name": "ssh"}
+						]}},
+						"changes": [{"ref": {"type": "BRANCH", "displayId": "master"}}]
+					}`
+					headers.Set("X-Hub-Signature", sign(payload))
+					headers.Set("X-Event-Key", "repo:refs_changed")
+				})
+
+				It("checks the resources of the pushed repository and branch", func() {
+					Expect(dbCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
+					_, actualResource, _, _, _ := dbCheckFactory.TryCreateCheckArgsForCall(0)
+					Expect(actualResource).To(Equal(matchingResource))
+				})
+			})
+		})
+
+		Context("when the provider is unknown", func() {
+			BeforeEach(func() {
+				provider = "svn"
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
 })
@@ -0,0 +1,168 @@
+package resourceserver
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/vars"
+)
+
+// webhookSecretVar is the credential, looked up in the team's credential
+// manager, that provider webhooks are signed with.
+const webhookSecretVar = "webhook_secret"
+
+// maxWebhookPayloadSize bounds the payloads read before they are verified.
+// GitHub caps the payloads it sends at 25 MB.
+const maxWebhookPayloadSize = 25 * 1024 * 1024
+
+// ReceiveWebhook handles a push webhook sent by a code hosting provider. Once
+// the payload is verified against the team's webhook secret, every resource of
+// the team whose source uri and branch match the pushed repository is checked.
+// Events other than pushes are acknowledged without checking anything.
+func (s *Server) ReceiveWebhook(w http.ResponseWriter, r *http.Request) {
+	teamName := r.FormValue(":team_name")
+	provider := r.FormValue(":provider")
+
+	logger := s.logger.Session("receive-webhook", lager.Data{
+		"team":     teamName,
+		"provider": provider,
+	})
+
+	switch provider {
+	case webhookProviderGitHub, webhookProviderGitLab, webhookProviderBitbucket:
+	default:
+		logger.Info("unknown-provider")
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxWebhookPayloadSize)
+
+	payload, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		logger.Error("failed-to-read-payload", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	variables := creds.NewVariables(s.secretManager, teamName, "", false)
+
+	secret, found, err := variables.Get(vars.VariableDefinition{
+		Ref: vars.VariableReference{Name: webhookSecretVar, Path: webhookSecretVar},
+	})
+	if err != nil {
+		logger.Error("failed-to-get-webhook-secret", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	secretString, ok := secret.(string)
+	if !found || !ok || secretString == "" {
+		logger.Info("no-webhook-secret")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	err = verifyWebhook(provider, r.Header, payload, secretString)
+	if err != nil {
+		logger.Info("invalid-signature")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if !isWebhookPush(provider, r.Header) {
+		logger.Debug("ignoring-event")
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	push, err := parseWebhook(provider, payload)
+	if err != nil {
+		logger.Info("malformed-payload", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	dbResources, err := s.resourceFactory.VisibleResources([]string{teamName})
+	if err != nil {
+		logger.Error("failed-to-get-resources", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	var resourceTypes db.ResourceTypes
+
+	checks := []atc.Check{}
+	for _, dbResource := range dbResources {
+		if dbResource.TeamName() != teamName {
+			continue
+		}
+
+		uri, _ := dbResource.Source()["uri"].(string)
+		branch, _ := dbResource.Source()["branch"].(string)
+		if !push.matches(uri, branch) {
+			continue
+		}
+
+		if resourceTypes == nil {
+			resourceTypes, err = s.checkFactory.ResourceTypes()
+			if err != nil {
+				logger.Error("failed-to-get-resource-types", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		check, created, err := s.checkFactory.TryCreateCheck(
+			lagerctx.NewContext(context.Background(), logger),
+			dbResource,
+			resourceTypes,
+			nil,
+			true,
+		)
+		if err != nil {
+			logger.Error("failed-to-create-check", err, lager.Data{
+				"pipeline": dbResource.PipelineName(),
+				"resource": dbResource.Name(),
+			})
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !created {
+			logger.Info("check-not-created", lager.Data{
+				"pipeline": dbResource.PipelineName(),
+				"resource": dbResource.Name(),
+			})
+			continue
+		}
+
+		checks = append(checks, present.Check(check))
+	}
+
+	if len(checks) > 0 {
+		err = s.checkFactory.NotifyChecker()
+		if err != nil {
+			logger.Error("failed-to-notify-checker", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(checks)
+	if err != nil {
+		logger.Error("failed-to-encode-checks", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,293 @@
+package resourceserver
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const (
+	webhookProviderGitHub    = "github"
+	webhookProviderGitLab    = "gitlab"
+	webhookProviderBitbucket = "bitbucket"
+)
+
+var (
+	errUnknownWebhookProvider  = errors.New("unknown webhook provider")
+	errInvalidWebhookSignature = errors.New("invalid webhook signature")
+)
+
+// webhookPush is the part of a webhook payload that decides which resources
+// are checked: the URLs the repository is known by and the branches which
+// were pushed to. A push without branches, e.g. one which only pushed tags,
+// only checks the resources of the repository which are not tied to a branch.
+type webhookPush struct {
+	RepositoryURLs []string
+	Branches       []string
+}
+
+// verifyWebhook checks that the payload was sent by someone who knows the
+// team's webhook secret, the way the provider signs its payloads.
+func verifyWebhook(provider string, header http.Header, payload []byte, secret string) error {
+	switch provider {
+	case webhookProviderGitHub:
+		return verifyHMACSignature(header.Get("X-Hub-Signature-256"), payload, secret)
+	case webhookProviderBitbucket:
+		return verifyHMACSignature(header.Get("X-Hub-Signature"), payload, secret)
+	case webhookProviderGitLab:
+		token := header.Get("X-Gitlab-Token")
+		if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+			return errInvalidWebhookSignature
+		}
+
+		return nil
+	default:
+		return errUnknownWebhookProvider
+	}
+}
+
+func verifyHMACSignature(signature string, payload []byte, secret string) error {
+	if !strings.HasPrefix(signature, "sha256=") {
+		return errInvalidWebhookSignature
+	}
+
+	digest, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil {
+		return errInvalidWebhookSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+
+	if !hmac.Equal(digest, mac.Sum(nil)) {
+		return errInvalidWebhookSignature
+	}
+
+	return nil
+}
+
+// isWebhookPush returns true for the push events of a provider. Every other
+// event a webhook may be subscribed to, such as pings, issues or pull
+// requests, carries no new versions to check.
+func isWebhookPush(provider string, header http.Header) bool {
+	switch provider {
+	case webhookProviderGitHub:
+		return header.Get("X-GitHub-Event") == "push"
+	case webhookProviderGitLab:
+		return header.Get("X-Gitlab-Event") == "Push Hook"
+	case webhookProviderBitbucket:
+		event := header.Get("X-Event-Key")
+		return event == "repo:push" || event == "repo:refs_changed"
+	default:
+		return false
+	}
+}
+
+type gitHubPayload struct {
+	Ref        string `json:"ref"`
+	Repository struct {
+		HTMLURL  string `json:"html_url"`
+		CloneURL string `json:"clone_url"`
+		SSHURL   string `json:"ssh_url"`
+		GitURL   string `json:"git_url"`
+	} `json:"repository"`
+}
+
+type gitLabRepository struct {
+	WebURL     string `json:"web_url"`
+	Homepage   string `json:"homepage"`
+	GitHTTPURL string `json:"git_http_url"`
+	GitSSHURL  string `json:"git_ssh_url"`
+}
+
+type gitLabPayload struct {
+	Ref        string           `json:"ref"`
+	Project    gitLabRepository `json:"project"`
+	Repository gitLabRepository `json:"repository"`
+}
+
+type bitbucketLink struct {
+	Href string `json:"href"`
+}
+
+type bitbucketPayload struct {
+	Repository struct {
+		Links struct {
+			HTML  bitbucketLink   `json:"html"`
+			Clone []bitbucketLink `json:"clone"`
+		} `json:"links"`
+	} `json:"repository"`
+
+	// Bitbucket Cloud
+	Push struct {
+		Changes []struct {
+			New struct {
+				Type string `json:"type"`
+				Name string `json:"name"`
+			} `json:"new"`
+		} `json:"changes"`
+	} `json:"push"`
+
+	// Bitbucket Server
+	Changes []struct {
+		Ref struct {
+			Type      string `json:"type"`
+			DisplayID string `json:"displayId"`
+		} `json:"ref"`
+	} `json:"changes"`
+}
+
+// parseWebhook reads the repository and branches out of a provider's payload.
+func parseWebhook(provider string, payload []byte) (webhookPush, error) {
+	var push webhookPush
+
+	switch provider {
+	case webhookProviderGitHub:
+		var event gitHubPayload
+		err := json.Unmarshal(payload, &event)
+		if err != nil {
+			return webhookPush{}, err
+		}
+
+		push.RepositoryURLs = []string{
+			event.Repository.HTMLURL,
+			event.Repository.CloneURL,
+			event.Repository.SSHURL,
+			event.Repository.GitURL,
+		}
+		push.Branches = refBranches(event.Ref)
+
+	case webhookProviderGitLab:
+		var event gitLabPayload
+		err := json.Unmarshal(payload, &event)
+		if err != nil {
+			return webhookPush{}, err
+		}
+
+		for _, repository := range []gitLabRepository{event.Project, event.Repository} {
+			push.RepositoryURLs = append(push.RepositoryURLs,
+				repository.WebURL,
+				repository.Homepage,
+				repository.GitHTTPURL,
+				repository.GitSSHURL,
+			)
+		}
+		push.Branches = refBranches(event.Ref)
+
+	case webhookProviderBitbucket:
+		var event bitbucketPayload
+		err := json.Unmarshal(payload, &event)
+		if err != nil {
+			return webhookPush{}, err
+		}
+
+		push.RepositoryURLs = append(push.RepositoryURLs, event.Repository.Links.HTML.Href)
+		for _, link := range event.Repository.Links.Clone {
+			push.RepositoryURLs = append(push.RepositoryURLs, link.Href)
+		}
+
+		for _, change := range event.Push.Changes {
+			if change.New.Type == "branch" && change.New.Name != "" {
+				push.Branches = append(push.Branches, change.New.Name)
+			}
+		}
+
+		for _, change := range event.Changes {
+			if change.Ref.Type == "BRANCH" && change.Ref.DisplayID != "" {
+				push.Branches = append(push.Branches, change.Ref.DisplayID)
+			}
+		}
+
+	default:
+		return webhookPush{}, errUnknownWebhookProvider
+	}
+
+	var repositories []string
+	for _, repositoryURL := range push.RepositoryURLs {
+		if repositoryURL != "" {
+			repositories = append(repositories, normalizeRepositoryURL(repositoryURL))
+		}
+	}
+
+	if len(repositories) == 0 {
+		return webhookPush{}, fmt.Errorf("no repository in %s payload", provider)
+	}
+
+	push.RepositoryURLs = repositories
+
+	return push, nil
+}
+
+func refBranches(ref string) []string {
+	if !strings.HasPrefix(ref, "refs/heads/") {
+		return nil
+	}
+
+	return []string{strings.TrimPrefix(ref, "refs/heads/")}
+}
+
+var scpLikeRepositoryURL = regexp.MustCompile(`^(?:[^@/]+@)?([^:/]+):(.*)$`)
+
+// normalizeRepositoryURL reduces the different ways of writing down the URL
+// of a repository to the same host and path, so that e.g.
+// https://github.com/concourse/concourse and
+// git@github.com:concourse/concourse.git match.
+func normalizeRepositoryURL(repositoryURL string) string {
+	repositoryURL = strings.TrimSpace(repositoryURL)
+
+	var host, path string
+	if u, err := url.Parse(repositoryURL); err == nil && u.Scheme != "" && u.Host != "" {
+		host, path = u.Hostname(), u.Path
+	} else if match := scpLikeRepositoryURL.FindStringSubmatch(repositoryURL); match != nil {
+		host, path = match[1], match[2]
+	} else {
+		path = repositoryURL
+	}
+
+	path = strings.Trim(path, "/")
+	path = strings.TrimSuffix(path, ".git")
+
+	return strings.ToLower(host + "/" + path)
+}
+
+// matches returns true if the source of a resource points at the pushed
+// repository and, if it is tied to a branch, one of the pushed branches.
+func (push webhookPush) matches(uri string, branch string) bool {
+	if uri == "" {
+		return false
+	}
+
+	normalized := normalizeRepositoryURL(uri)
+
+	matched := false
+	for _, repositoryURL := range push.RepositoryURLs {
+		if repositoryURL == normalized {
+			matched = true
+			break
+		}
+	}
+
+	if !matched {
+		return false
+	}
+
+	if branch == "" {
+		return true
+	}
+
+	for _, pushed := range push.Branches {
+		if pushed == branch {
+			return true
+		}
+	}
+
+	return false
+}
@@ -296,15 +296,23 @@ var _ = Describe("Teams API", func() {
 
 					It("updates provider auth", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusOK))
-						Expect(fakeTeam.UpdateProviderAuthCallCount()).To(Equal(1))
+						Expect(fakeTeam.UpdateSettingsCallCount()).To(Equal(1))
 
-						updatedProviderAuth := fakeTeam.UpdateProviderAuthArgsForCall(0)
+						updatedProviderAuth, _, _ := fakeTeam.UpdateSettingsArgsForCall(0)
 						Expect(updatedProviderAuth).To(Equal(atcTeam.Auth))
 					})
 
-					Context("when updating provider auth fails", func() {
+					It("leaves the default build priority and quotas unchanged", func() {
+						Expect(fakeTeam.UpdateSettingsCallCount()).To(Equal(1))
+
+						_, priority, quotas := fakeTeam.UpdateSettingsArgsForCall(0)
+						Expect(priority).To(BeNil())
+						Expect(quotas).To(BeNil())
+					})
+
+					Context("when updating the team fails", func() {
 						BeforeEach(func() {
-							fakeTeam.UpdateProviderAuthReturns(errors.New("stop trying to make fetch happen"))
+							fakeTeam.UpdateSettingsReturns(errors.New("stop trying to make fetch happen"))
 						})
 
 						It("returns 500 Internal Server error", func() {
@@ -319,7 +327,7 @@ var _ = Describe("Teams API", func() {
 
 						It("does not update provider auth", func() {
 							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
-							Expect(fakeTeam.UpdateProviderAuthCallCount()).To(Equal(0))
+							Expect(fakeTeam.UpdateSettingsCallCount()).To(Equal(0))
 						})
 					})
 
@@ -338,7 +346,7 @@ var _ = Describe("Teams API", func() {
 
 						It("does not update provider auth", func() {
 							Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
-							Expect(fakeTeam.UpdateProviderAuthCallCount()).To(Equal(0))
+							Expect(fakeTeam.UpdateSettingsCallCount()).To(Equal(0))
 						})
 					})
 				})
@@ -353,6 +361,62 @@ var _ = Describe("Teams API", func() {
 
 				authorizedTeamTests()
 
+				Context("when the team exists", func() {
+					BeforeEach(func() {
+						dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+					})
+
+					Context("when a default build priority is given", func() {
+						BeforeEach(func() {
+							priority := 5
+							atcTeam.DefaultBuildPriority = &priority
+						})
+
+						It("updates the default build priority", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(fakeTeam.UpdateSettingsCallCount()).To(Equal(1))
+
+							_, priority, _ := fakeTeam.UpdateSettingsArgsForCall(0)
+							Expect(priority).NotTo(BeNil())
+							Expect(*priority).To(Equal(5))
+						})
+					})
+
+					Context("when quotas are given", func() {
+						BeforeEach(func() {
+							atcTeam.Quotas = &atc.TeamQuotas{
+								MaxConcurrentBuilds: 3,
+								MaxActiveContainers: 20,
+							}
+						})
+
+						It("updates the quotas", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(fakeTeam.UpdateSettingsCallCount()).To(Equal(1))
+
+							_, _, quotas := fakeTeam.UpdateSettingsArgsForCall(0)
+							Expect(quotas).To(Equal(&atc.TeamQuotas{
+								MaxConcurrentBuilds: 3,
+								MaxActiveContainers: 20,
+							}))
+						})
+					})
+
+					Context("when empty quotas are given", func() {
+						BeforeEach(func() {
+							atcTeam.Quotas = &atc.TeamQuotas{}
+						})
+
+						It("clears the quotas", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusOK))
+							Expect(fakeTeam.UpdateSettingsCallCount()).To(Equal(1))
+
+							_, _, quotas := fakeTeam.UpdateSettingsArgsForCall(0)
+							Expect(quotas).To(Equal(&atc.TeamQuotas{}))
+						})
+					})
+				})
+
 				Context("when the team is not found", func() {
 					BeforeEach(func() {
 						dbTeamFactory.FindTeamReturns(nil, false, nil)
@@ -421,6 +485,35 @@ var _ = Describe("Teams API", func() {
 
 				authorizedTeamTests()
 
+				Context("when the team exists", func() {
+					BeforeEach(func() {
+						dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+					})
+
+					Context("when a default build priority is given", func() {
+						BeforeEach(func() {
+							priority := 5
+							atcTeam.DefaultBuildPriority = &priority
+						})
+
+						It("returns 403 without updating the team", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+							Expect(fakeTeam.UpdateSettingsCallCount()).To(Equal(0))
+						})
+					})
+
+					Context("when quotas are given", func() {
+						BeforeEach(func() {
+							atcTeam.Quotas = &atc.TeamQuotas{}
+						})
+
+						It("returns 403 without updating the team", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+							Expect(fakeTeam.UpdateSettingsCallCount()).To(Equal(0))
+						})
+					})
+				})
+
 				Context("when the team is not found", func() {
 					BeforeEach(func() {
 						dbTeamFactory.FindTeamReturns(nil, false, nil)
@@ -56,8 +56,14 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 
 	response := SetTeamResponse{}
 	if found {
+		if !acc.IsAdmin() && (atcTeam.DefaultBuildPriority != nil || atcTeam.Quotas != nil) {
+			hLog.Debug("not-allowed-to-change-quotas")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
 		hLog.Debug("updating-credentials")
-		err = team.UpdateProviderAuth(atcTeam.Auth)
+		err = team.UpdateSettings(atcTeam.Auth, atcTeam.DefaultBuildPriority, atcTeam.Quotas)
 		if err != nil {
 			hLog.Error("failed-to-update-team", err, lager.Data{"teamName": teamName})
 			w.WriteHeader(http.StatusInternalServerError)
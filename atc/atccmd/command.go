@@ -42,6 +42,7 @@ import (
 	"github.com/concourse/concourse/atc/engine/builder"
 	"github.com/concourse/concourse/atc/gc"
 	"github.com/concourse/concourse/atc/lidar"
+	"github.com/concourse/concourse/atc/logarchive"
 	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/resource"
@@ -87,6 +88,7 @@ import (
 	_ "github.com/concourse/concourse/atc/creds/credhub"
 	_ "github.com/concourse/concourse/atc/creds/dummy"
 	_ "github.com/concourse/concourse/atc/creds/kubernetes"
+	_ "github.com/concourse/concourse/atc/creds/local"
 	_ "github.com/concourse/concourse/atc/creds/secretsmanager"
 	_ "github.com/concourse/concourse/atc/creds/ssm"
 	_ "github.com/concourse/concourse/atc/creds/vault"
@@ -114,6 +116,10 @@ type RunCommand struct {
 	Logger flag.Lager
 
 	varSourcePool creds.VarSourcePool
+	leaseTracker  *creds.LeaseTracker
+
+	// checkRateLimits are parsed from the --check-rate-limits file by validate.
+	checkRateLimits []lidar.CheckRateLimit
 
 	BindIP   flag.IP `long:"bind-ip"   default:"0.0.0.0" description:"IP address on which to listen for web traffic."`
 	BindPort uint16  `long:"bind-port" default:"8080"    description:"Port on which to listen for HTTP traffic."`
@@ -154,9 +160,12 @@ type RunCommand struct {
 	GlobalResourceCheckTimeout          time.Duration `long:"global-resource-check-timeout" default:"1h" description:"Time limit on checking for new versions of resources."`
 	ResourceCheckingInterval            time.Duration `long:"resource-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources."`
 	ResourceWithWebhookCheckingInterval time.Duration `long:"resource-with-webhook-checking-interval" default:"1m" description:"Interval on which to check for new versions of resources that has webhook defined."`
+	AdaptiveResourceChecking            bool          `long:"adaptive-resource-checking" description:"Check resources which do not configure check_every at an adaptive interval, backing off while their checks fail or find no new versions. Resources can also opt in with check_every: adaptive."`
+	AdaptiveResourceCheckingMaxInterval time.Duration `long:"adaptive-resource-checking-max-interval" default:"1h" description:"Longest interval resources checked at an adaptive interval are checked at."`
 	MaxChecksPerSecond                  int           `long:"max-checks-per-second" description:"Maximum number of checks that can be started per second. If not specified, this will be calculated as (# of resources)/(resource checking interval). -1 value will remove this maximum limit of checks per second."`
+	CheckRateLimits                     flag.File     `long:"check-rate-limits" description:"File containing token bucket limits on the rate of checks per resource type, team, or source field, applied on top of --max-checks-per-second."`
 
-	ContainerPlacementStrategy        string        `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" choice:"fewest-build-containers" choice:"limit-active-tasks" description:"Method by which a worker is selected during container placement."`
+	ContainerPlacementStrategy        string        `long:"container-placement-strategy" default:"volume-locality" description:"Method by which a worker is selected during container placement. One of volume-locality, random, fewest-build-containers, limit-active-tasks or resource-fit. Several methods may be given separated by commas, in which case each one in turn narrows down the workers considered by the next, and a worker is picked at random from those that remain."`
 	MaxActiveTasksPerWorker           int           `long:"max-active-tasks-per-worker" default:"0" description:"Maximum allowed number of active build tasks per worker. Has effect only when used with limit-active-tasks placement strategy. 0 means no limit."`
 	BaggageclaimResponseHeaderTimeout time.Duration `long:"baggageclaim-response-header-timeout" default:"1m" description:"How long to wait for Baggageclaim to send the response header."`
 	StreamingArtifactsCompression     string        `long:"streaming-artifacts-compression" default:"gzip" choice:"gzip" choice:"zstd" description:"Compression algorithm for internal streaming."`
@@ -175,7 +184,8 @@ type RunCommand struct {
 	Tracing tracing.Config `group:"Tracing" namespace:"tracing"`
 
 	PolicyCheckers struct {
-		Filter policy.Filter
+		Filter    policy.Filter
+		AuditOnly bool `long:"policy-check-audit-only" description:"Record checks that do not pass and report them as warnings, rather than rejecting the action."`
 	} `group:"Policy Checking"`
 
 	Server struct {
@@ -191,11 +201,12 @@ type RunCommand struct {
 	GC struct {
 		Interval time.Duration `long:"interval" default:"30s" description:"Interval on which to perform garbage collection."`
 
-		OneOffBuildGracePeriod time.Duration `long:"one-off-grace-period" default:"5m" description:"Period after which one-off build containers will be garbage-collected."`
-		MissingGracePeriod     time.Duration `long:"missing-grace-period" default:"5m" description:"Period after which to reap containers and volumes that were created but went missing from the worker."`
-		HijackGracePeriod      time.Duration `long:"hijack-grace-period" default:"5m" description:"Period after which hijacked containers will be garbage collected"`
-		FailedGracePeriod      time.Duration `long:"failed-grace-period" default:"120h" description:"Period after which failed containers will be garbage collected"`
-		CheckRecyclePeriod     time.Duration `long:"check-recycle-period" default:"1m" description:"Period after which to reap checks that are completed."`
+		OneOffBuildGracePeriod  time.Duration `long:"one-off-grace-period" default:"5m" description:"Period after which one-off build containers will be garbage-collected."`
+		MissingGracePeriod      time.Duration `long:"missing-grace-period" default:"5m" description:"Period after which to reap containers and volumes that were created but went missing from the worker."`
+		HijackGracePeriod       time.Duration `long:"hijack-grace-period" default:"5m" description:"Period after which hijacked containers will be garbage collected"`
+		FailedGracePeriod       time.Duration `long:"failed-grace-period" default:"120h" description:"Period after which failed containers will be garbage collected"`
+		CheckRecyclePeriod      time.Duration `long:"check-recycle-period" default:"1m" description:"Period after which to reap checks that are completed."`
+		PolicyDecisionRetention time.Duration `long:"policy-decision-retention" default:"168h" description:"Period after which to reap recorded policy decisions."`
 	} `group:"Garbage Collection" namespace:"gc"`
 
 	BuildTrackerInterval time.Duration `long:"build-tracker-interval" default:"10s" description:"Interval on which to run build tracking."`
@@ -223,6 +234,7 @@ type RunCommand struct {
 		EnableTeamAuditLog      bool `long:"enable-team-auditing" description:"Enable auditing for all api requests connected to teams."`
 		EnableWorkerAuditLog    bool `long:"enable-worker-auditing" description:"Enable auditing for all api requests connected to workers."`
 		EnableVolumeAuditLog    bool `long:"enable-volume-auditing" description:"Enable auditing for all api requests connected to volumes."`
+		EnableSecretAuditLog    bool `long:"enable-secret-auditing" description:"Enable auditing for all secret lookups made on behalf of builds."`
 	}
 
 	Syslog struct {
@@ -233,6 +245,8 @@ type RunCommand struct {
 		CACerts       []string      `long:"syslog-ca-cert"              description:"Paths to PEM-encoded CA cert files to use to verify the Syslog server SSL cert."`
 	} ` group:"Syslog Drainer Configuration"`
 
+	BuildLogArchive logarchive.Config `group:"Build Log Archive" namespace:"build-log-archive"`
+
 	Auth struct {
 		AuthFlags     skycmd.AuthFlags
 		MainTeamFlags skycmd.AuthTeamFlags `group:"Authentication (Main Team)" namespace:"main-team"`
@@ -247,7 +261,7 @@ type RunCommand struct {
 		EnableGlobalResources                bool `long:"enable-global-resources" description:"Enable equivalent resources across pipelines and teams to share a single version history."`
 		EnableRedactSecrets                  bool `long:"enable-redact-secrets" description:"Enable redacting secrets in build logs."`
 		EnableBuildRerunWhenWorkerDisappears bool `long:"enable-rerun-when-worker-disappears" description:"Enable automatically build rerun when worker disappears or a network error occurs"`
-		EnableAcrossStep                     bool `long:"enable-across-step" description:"Enable the experimental across step to be used in jobs. The API is subject to change."`
+		EnableAcrossStep                     bool `long:"enable-across-step" hidden:"true" description:"Deprecated: the across step is always enabled."`
 	} `group:"Feature Flags"`
 }
 
@@ -480,7 +494,6 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 	atc.EnableGlobalResources = cmd.FeatureFlags.EnableGlobalResources
 	atc.EnableRedactSecrets = cmd.FeatureFlags.EnableRedactSecrets
 	atc.EnableBuildRerunWhenWorkerDisappears = cmd.FeatureFlags.EnableBuildRerunWhenWorkerDisappears
-	atc.EnableAcrossStep = cmd.FeatureFlags.EnableAcrossStep
 
 	//FIXME: These only need to run once for the entire binary. At the moment,
 	//they rely on state of the command.
@@ -499,7 +512,7 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		logger.RegisterSink(&errorSinkCollector)
 	}
 
-	err = cmd.Tracing.Prepare()
+	err = cmd.Tracing.Prepare(logger)
 	if err != nil {
 		return nil, err
 	}
@@ -541,7 +554,13 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		return nil, err
 	}
 
-	secretManager, err := cmd.secretManager(logger)
+	cmd.leaseTracker = creds.NewLeaseTracker(
+		logger.Session("secret-leases"),
+		db.NewSecretLeaseRepository(backendConn),
+		clock.NewClock(),
+	)
+
+	secretManager, err := cmd.secretManager(logger, backendConn)
 	if err != nil {
 		return nil, err
 	}
@@ -551,6 +570,7 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		5*time.Minute,
 		1*time.Minute,
 		clock.NewClock(),
+		cmd.leaseTracker,
 	)
 
 	members, err := cmd.constructMembers(logger, reconfigurableSink, apiConn, backendConn, gcConn, storage, lockFactory, secretManager)
@@ -586,6 +606,9 @@ func (cmd *RunCommand) Runner(positionalArguments []string) (ifrit.Runner, error
 		}
 
 		cmd.varSourcePool.Close()
+		cmd.leaseTracker.Close()
+
+		tracing.Shutdown()
 	}
 
 	return run(grouper.NewParallel(os.Interrupt, members), onReady, onExit), nil
@@ -611,7 +634,14 @@ func (cmd *RunCommand) constructMembers(
 		}()
 	}
 
-	policyChecker, err := policy.Initialize(logger, cmd.Server.ClusterName, concourse.Version, cmd.PolicyCheckers.Filter)
+	policyChecker, err := policy.Initialize(
+		logger,
+		cmd.Server.ClusterName,
+		concourse.Version,
+		cmd.PolicyCheckers.Filter,
+		cmd.PolicyCheckers.AuditOnly,
+		db.NewPolicyDecisionFactory(backendConn),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -760,6 +790,7 @@ func (cmd *RunCommand) constructAPIMembers(
 	dbAccessTokenFactory := db.NewAccessTokenFactory(dbConn)
 	dbClock := db.NewClock()
 	dbWall := db.NewWall(dbConn, &dbClock)
+	dbPolicyDecisionFactory := db.NewPolicyDecisionFactory(dbConn)
 
 	tokenVerifier := cmd.constructTokenVerifier(dbAccessTokenFactory)
 
@@ -800,6 +831,7 @@ func (cmd *RunCommand) constructAPIMembers(
 		credsManagers,
 		accessFactory,
 		dbWall,
+		dbPolicyDecisionFactory,
 		policyChecker,
 	)
 	if err != nil {
@@ -1009,7 +1041,7 @@ func (cmd *RunCommand) backendComponents(
 		return nil, err
 	}
 
-	buildContainerStrategy, err := cmd.chooseBuildContainerStrategy()
+	buildContainerStrategy, err := cmd.chooseBuildContainerStrategy(dbWorkerFactory)
 	if err != nil {
 		return nil, err
 	}
@@ -1026,6 +1058,7 @@ func (cmd *RunCommand) backendComponents(
 		defaultLimits,
 		buildContainerStrategy,
 		lockFactory,
+		policyChecker,
 	)
 
 	// In case that a user configures resource-checking-interval, but forgets to
@@ -1055,6 +1088,10 @@ func (cmd *RunCommand) backendComponents(
 				cmd.GlobalResourceCheckTimeout,
 				cmd.ResourceCheckingInterval,
 				cmd.ResourceWithWebhookCheckingInterval,
+				lidar.AdaptiveCheckInterval{
+					Default:     cmd.AdaptiveResourceChecking,
+					MaxInterval: cmd.AdaptiveResourceCheckingMaxInterval,
+				},
 			),
 		},
 		{
@@ -1071,6 +1108,11 @@ func (cmd *RunCommand) backendComponents(
 					ResourceCheckingInterval: cmd.ResourceCheckingInterval,
 					CheckableCounter:         dbCheckableCounter,
 				},
+				lidar.NewCheckRateLimiter(
+					logger.Session("check-rate-limiter"),
+					cmd.checkRateLimits,
+				),
+				clock.NewClock(),
 			),
 		},
 		{
@@ -1119,6 +1161,26 @@ func (cmd *RunCommand) backendComponents(
 		},
 	}
 
+	buildLogArchive, err := cmd.BuildLogArchive.Archive()
+	if err != nil {
+		return nil, err
+	}
+
+	if buildLogArchive != nil {
+		components = append(components, RunnableComponent{
+			Component: atc.Component{
+				Name:     atc.ComponentBuildLogArchiver,
+				Interval: cmd.BuildLogArchive.Interval,
+			},
+			Runnable: logarchive.NewArchiver(
+				dbBuildFactory,
+				buildLogArchive,
+				cmd.BuildLogArchive.BatchSize,
+				syslogDrainConfigured,
+			),
+		})
+	}
+
 	if syslogDrainConfigured {
 		components = append(components, RunnableComponent{
 			Component: atc.Component{
@@ -1149,6 +1211,8 @@ func (cmd *RunCommand) gcComponents(
 	dbArtifactLifecycle := db.NewArtifactLifecycle(gcConn)
 	dbCheckLifecycle := db.NewCheckLifecycle(gcConn)
 	dbAccessTokenLifecycle := db.NewAccessTokenLifecycle(gcConn)
+	dbPolicyDecisionLifecycle := db.NewPolicyDecisionLifecycle(gcConn)
+	dbSecretLeaseRepository := db.NewSecretLeaseRepository(gcConn)
 	resourceConfigCheckSessionLifecycle := db.NewResourceConfigCheckSessionLifecycle(gcConn)
 	dbBuildFactory := db.NewBuildFactory(gcConn, lockFactory, cmd.GC.OneOffBuildGracePeriod, cmd.GC.FailedGracePeriod)
 	dbResourceConfigFactory := db.NewResourceConfigFactory(gcConn, lockFactory)
@@ -1169,6 +1233,8 @@ func (cmd *RunCommand) gcComponents(
 		atc.ComponentCollectorCheckSessions:     gc.NewResourceConfigCheckSessionCollector(resourceConfigCheckSessionLifecycle),
 		atc.ComponentCollectorPipelines:         gc.NewPipelineCollector(dbPipelineLifecycle),
 		atc.ComponentCollectorAccessTokens:      gc.NewAccessTokensCollector(dbAccessTokenLifecycle, jwt.DefaultLeeway),
+		atc.ComponentCollectorPolicyDecisions:   gc.NewPolicyDecisionsCollector(dbPolicyDecisionLifecycle, cmd.GC.PolicyDecisionRetention),
+		atc.ComponentCollectorSecretLeases:      gc.NewSecretLeasesCollector(dbSecretLeaseRepository, cmd.leaseTracker),
 	}
 
 	var components []RunnableComponent
@@ -1248,12 +1314,38 @@ func (cmd *RunCommand) parseCustomRoles() (map[string]string, error) {
 	return mapping, nil
 }
 
+func (cmd *RunCommand) parseCheckRateLimits() ([]lidar.CheckRateLimit, error) {
+	path := cmd.CheckRateLimits.Path()
+	if path == "" {
+		return nil, nil
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open check rate limits file (%s): %w", cmd.CheckRateLimits, err)
+	}
+
+	var limits []lidar.CheckRateLimit
+	if err = yaml.Unmarshal(content, &limits); err != nil {
+		return nil, fmt.Errorf("failed to parse check rate limits file (%s): %w", cmd.CheckRateLimits, err)
+	}
+
+	for i, limit := range limits {
+		if err := limit.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid check rate limit %d in %s: %w", i, cmd.CheckRateLimits, err)
+		}
+	}
+
+	return limits, nil
+}
+
 func workerVersion() (version.Version, error) {
 	return version.NewVersionFromString(concourse.WorkerVersion)
 }
 
-func (cmd *RunCommand) secretManager(logger lager.Logger) (creds.Secrets, error) {
+func (cmd *RunCommand) secretManager(logger lager.Logger, conn db.Conn) (creds.Secrets, error) {
 	var secretsFactory creds.SecretsFactory = noop.NewNoopFactory()
+	managerName := "noop"
 	for name, manager := range cmd.CredentialManagers {
 		if !manager.IsConfigured() {
 			continue
@@ -1270,6 +1362,11 @@ func (cmd *RunCommand) secretManager(logger lager.Logger) (creds.Secrets, error)
 			return nil, err
 		}
 
+		if leasingManager, ok := manager.(creds.LeasingManager); ok {
+			cmd.leaseTracker.StoreLeasesOf(leasingManager.Leaser())
+			leasingManager.TrackLeases(cmd.leaseTracker)
+		}
+
 		err = manager.Validate()
 		if err != nil {
 			return nil, fmt.Errorf("credential manager '%s' misconfigured: %s", name, err)
@@ -1280,6 +1377,7 @@ func (cmd *RunCommand) secretManager(logger lager.Logger) (creds.Secrets, error)
 			return nil, err
 		}
 
+		managerName = name
 		break
 	}
 
@@ -1288,6 +1386,13 @@ func (cmd *RunCommand) secretManager(logger lager.Logger) (creds.Secrets, error)
 	if cmd.CredentialManagement.CacheConfig.Enabled {
 		result = creds.NewCachedSecrets(result, cmd.CredentialManagement.CacheConfig)
 	}
+
+	recorders := []creds.SecretAccessRecorder{db.NewSecretAccessRecorder(conn)}
+	if cmd.Auditor.EnableSecretAuditLog {
+		recorders = append(recorders, auditor.NewSecretAccessAuditor(logger.Session("secret-access")))
+	}
+
+	result = creds.NewAuditedSecrets(logger.Session("secret-access"), result, managerName, recorders...)
 	return result, nil
 }
 
@@ -1490,6 +1595,13 @@ func (cmd *RunCommand) validate() error {
 		errs = multierror.Append(errs, err)
 	}
 
+	checkRateLimits, err := cmd.parseCheckRateLimits()
+	if err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	cmd.checkRateLimits = checkRateLimits
+
 	return errs.ErrorOrNil()
 }
 
@@ -1559,26 +1671,49 @@ func (cmd *RunCommand) constructLockConn(driverName string) (*sql.DB, error) {
 	return dbConn, nil
 }
 
-func (cmd *RunCommand) chooseBuildContainerStrategy() (worker.ContainerPlacementStrategy, error) {
-	var strategy worker.ContainerPlacementStrategy
-	if cmd.ContainerPlacementStrategy != "limit-active-tasks" && cmd.MaxActiveTasksPerWorker != 0 {
+func (cmd *RunCommand) chooseBuildContainerStrategy(workerFactory db.WorkerFactory) (worker.ContainerPlacementStrategy, error) {
+	names := strings.Split(cmd.ContainerPlacementStrategy, ",")
+
+	limitActiveTasks := false
+	for _, name := range names {
+		if strings.TrimSpace(name) == "limit-active-tasks" {
+			limitActiveTasks = true
+		}
+	}
+
+	if !limitActiveTasks && cmd.MaxActiveTasksPerWorker != 0 {
 		return nil, errors.New("max-active-tasks-per-worker has only effect with limit-active-tasks strategy")
 	}
 	if cmd.MaxActiveTasksPerWorker < 0 {
 		return nil, errors.New("max-active-tasks-per-worker must be greater or equal than 0")
 	}
-	switch cmd.ContainerPlacementStrategy {
-	case "random":
-		strategy = worker.NewRandomPlacementStrategy()
-	case "fewest-build-containers":
-		strategy = worker.NewFewestBuildContainersPlacementStrategy()
-	case "limit-active-tasks":
-		strategy = worker.NewLimitActiveTasksPlacementStrategy(cmd.MaxActiveTasksPerWorker)
-	default:
-		strategy = worker.NewVolumeLocalityPlacementStrategy()
+
+	var strategies []worker.ContainerPlacementFilter
+	for _, name := range names {
+		var strategy worker.ContainerPlacementFilter
+		switch strings.TrimSpace(name) {
+		case "volume-locality":
+			strategy = worker.NewVolumeLocalityPlacementStrategy()
+		case "random":
+			strategy = worker.NewRandomPlacementStrategy()
+		case "fewest-build-containers":
+			strategy = worker.NewFewestBuildContainersPlacementStrategy()
+		case "limit-active-tasks":
+			strategy = worker.NewLimitActiveTasksPlacementStrategy(cmd.MaxActiveTasksPerWorker)
+		case "resource-fit":
+			strategy = worker.NewResourceFitPlacementStrategy(workerFactory)
+		default:
+			return nil, fmt.Errorf("unknown container placement strategy: %s", name)
+		}
+
+		strategies = append(strategies, strategy)
+	}
+
+	if len(strategies) == 1 {
+		return strategies[0], nil
 	}
 
-	return strategy, nil
+	return worker.NewChainedPlacementStrategy(strategies...), nil
 }
 
 func (cmd *RunCommand) configureAuthForDefaultTeam(teamFactory db.TeamFactory) error {
@@ -1616,6 +1751,7 @@ func (cmd *RunCommand) constructEngine(
 	defaultLimits atc.ContainerLimits,
 	strategy worker.ContainerPlacementStrategy,
 	lockFactory lock.LockFactory,
+	policyChecker *policy.Checker,
 ) engine.Engine {
 
 	stepFactory := builder.NewStepFactory(
@@ -1629,6 +1765,7 @@ func (cmd *RunCommand) constructEngine(
 		defaultLimits,
 		strategy,
 		lockFactory,
+		policyChecker,
 	)
 
 	stepBuilder := builder.NewStepBuilder(
@@ -1637,6 +1774,7 @@ func (cmd *RunCommand) constructEngine(
 		cmd.ExternalURL.String(),
 		secretManager,
 		cmd.varSourcePool,
+		cmd.leaseTracker,
 	)
 
 	return engine.NewEngine(stepBuilder)
@@ -1808,6 +1946,7 @@ func (cmd *RunCommand) constructAPIHandler(
 	credsManagers creds.Managers,
 	accessFactory accessor.AccessFactory,
 	dbWall db.Wall,
+	dbPolicyDecisionFactory db.PolicyDecisionFactory,
 	policyChecker *policy.Checker,
 ) (http.Handler, error) {
 
@@ -1831,6 +1970,17 @@ func (cmd *RunCommand) constructAPIHandler(
 		logger,
 	)
 
+	eventHandlerFactory := buildserver.EventHandlerFactory(buildserver.NewEventHandler)
+
+	buildLogArchive, err := cmd.BuildLogArchive.Archive()
+	if err != nil {
+		return nil, err
+	}
+
+	if buildLogArchive != nil {
+		eventHandlerFactory = buildserver.NewArchivedEventHandlerFactory(buildLogArchive)
+	}
+
 	customRoles, err := cmd.parseCustomRoles()
 	if err != nil {
 		return nil, err
@@ -1879,7 +2029,7 @@ func (cmd *RunCommand) constructAPIHandler(
 		resourceConfigFactory,
 		dbUserFactory,
 
-		buildserver.NewEventHandler,
+		eventHandlerFactory,
 
 		workerClient,
 
@@ -1896,6 +2046,7 @@ func (cmd *RunCommand) constructAPIHandler(
 		containerserver.NewInterceptTimeoutFactory(cmd.InterceptIdleTimeout),
 		time.Minute,
 		dbWall,
+		dbPolicyDecisionFactory,
 		clock.NewClock(),
 	)
 }
@@ -68,7 +68,8 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.ListBuildsWithVersionAsOutput,
 		atc.CreateArtifact,
 		atc.GetArtifact,
-		atc.ListBuildArtifacts:
+		atc.ListBuildArtifacts,
+		atc.ListBuildSecretAccesses:
 		return a.EnableBuildAuditLog
 	case atc.ListContainers,
 		atc.GetContainer,
@@ -78,10 +79,12 @@ func (a *auditor) ValidateAction(action string) bool {
 		return a.EnableContainerAuditLog
 	case atc.GetJob,
 		atc.CreateJobBuild,
+		atc.CreateJobOneOffBuild,
 		atc.ListAllJobs,
 		atc.ListJobs,
 		atc.ListJobBuilds,
 		atc.ListJobInputs,
+		atc.GetJobSchedulingExplanation,
 		atc.GetJobBuild,
 		atc.PauseJob,
 		atc.UnpauseJob,
@@ -101,6 +104,7 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.HidePipeline,
 		atc.RenamePipeline,
 		atc.ListPipelineBuilds,
+		atc.SearchPipelineBuildLogs,
 		atc.CreatePipelineBuild,
 		atc.PipelineBadge:
 		return a.EnablePipelineAuditLog
@@ -112,6 +116,7 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.SetPinCommentOnResource,
 		atc.CheckResource,
 		atc.CheckResourceWebHook,
+		atc.ReceiveWebhook,
 		atc.CheckResourceType,
 		atc.ListResourceVersions,
 		atc.GetResourceVersion,
@@ -143,6 +148,7 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.RenameTeam,
 		atc.DestroyTeam,
 		atc.ListTeamBuilds,
+		atc.ListPolicyDecisions,
 		atc.GetTeam:
 		return a.EnableTeamAuditLog
 	case atc.RegisterWorker,
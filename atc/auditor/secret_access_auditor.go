@@ -0,0 +1,32 @@
+package auditor
+
+import (
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+)
+
+type secretAccessAuditor struct {
+	logger lager.Logger
+}
+
+// NewSecretAccessAuditor logs the secret lookups made on behalf of builds
+// alongside the audit logs of API requests. Values are never logged.
+func NewSecretAccessAuditor(logger lager.Logger) *secretAccessAuditor {
+	return &secretAccessAuditor{
+		logger: logger,
+	}
+}
+
+func (a *secretAccessAuditor) RecordSecretAccess(buildID int, access atc.SecretAccess) error {
+	a.logger.Info("audit", lager.Data{
+		"action":     "SecretAccess",
+		"build":      buildID,
+		"path":       access.Path,
+		"manager":    access.Manager,
+		"var_source": access.VarSource,
+		"cache_hit":  access.CacheHit,
+		"found":      access.Found,
+	})
+
+	return nil
+}
@@ -12,18 +12,50 @@ const (
 )
 
 type Build struct {
-	ID           int           `json:"id"`
-	TeamName     string        `json:"team_name"`
-	Name         string        `json:"name"`
-	Status       string        `json:"status"`
-	JobName      string        `json:"job_name,omitempty"`
-	APIURL       string        `json:"api_url"`
-	PipelineName string        `json:"pipeline_name,omitempty"`
-	StartTime    int64         `json:"start_time,omitempty"`
-	EndTime      int64         `json:"end_time,omitempty"`
-	ReapTime     int64         `json:"reap_time,omitempty"`
-	RerunNumber  int           `json:"rerun_number,omitempty"`
-	RerunOf      *RerunOfBuild `json:"rerun_of,omitempty"`
+	ID                   int            `json:"id"`
+	TeamName             string         `json:"team_name"`
+	Name                 string         `json:"name"`
+	Status               string         `json:"status"`
+	JobName              string         `json:"job_name,omitempty"`
+	APIURL               string         `json:"api_url"`
+	PipelineName         string         `json:"pipeline_name,omitempty"`
+	PipelineInstanceVars InstanceVars   `json:"pipeline_instance_vars,omitempty"`
+	StartTime            int64          `json:"start_time,omitempty"`
+	EndTime              int64          `json:"end_time,omitempty"`
+	ReapTime             int64          `json:"reap_time,omitempty"`
+	RerunNumber          int            `json:"rerun_number,omitempty"`
+	RerunOf              *RerunOfBuild  `json:"rerun_of,omitempty"`
+	Priority             int            `json:"priority,omitempty"`
+	QuotaExceeded        bool           `json:"quota_exceeded,omitempty"`
+	ResourceUsage        *ResourceUsage `json:"resource_usage,omitempty"`
+}
+
+// ResourceUsage is the amount of resources consumed by the container of a
+// step, or by the containers of all the steps of a build.
+type ResourceUsage struct {
+	// CPUTime is the cpu time consumed, in nanoseconds.
+	CPUTime uint64 `json:"cpu_time"`
+
+	// MemoryPeak is the highest memory usage observed, in bytes.
+	MemoryPeak uint64 `json:"memory_peak"`
+
+	// RootfsDiskUsage is the highest disk usage observed of the container's
+	// own root filesystem, in bytes. Volumes, such as the inputs, outputs and
+	// caches, are not included.
+	RootfsDiskUsage uint64 `json:"rootfs_disk_usage"`
+}
+
+// Add accumulates the usage of another container: cpu time and rootfs disk
+// usage are summed up, while the memory peak is the highest of both.
+func (usage ResourceUsage) Add(other ResourceUsage) ResourceUsage {
+	usage.CPUTime += other.CPUTime
+	usage.RootfsDiskUsage += other.RootfsDiskUsage
+
+	if other.MemoryPeak > usage.MemoryPeak {
+		usage.MemoryPeak = other.MemoryPeak
+	}
+
+	return usage
 }
 
 type RerunOfBuild struct {
@@ -44,6 +76,13 @@ func (b Build) Abortable() bool {
 	return b.IsRunning()
 }
 
+func (b Build) PipelineRef() PipelineRef {
+	return PipelineRef{
+		Name:         b.PipelineName,
+		InstanceVars: b.PipelineInstanceVars,
+	}
+}
+
 func (b Build) OneOff() bool {
 	return b.JobName == ""
 }
@@ -63,6 +102,7 @@ type BuildPreparation struct {
 	PausedPipeline      BuildPreparationStatus            `json:"paused_pipeline"`
 	PausedJob           BuildPreparationStatus            `json:"paused_job"`
 	MaxRunningBuilds    BuildPreparationStatus            `json:"max_running_builds"`
+	TeamQuota           BuildPreparationStatus            `json:"team_quota"`
 	Inputs              map[string]BuildPreparationStatus `json:"inputs"`
 	InputsSatisfied     BuildPreparationStatus            `json:"inputs_satisfied"`
 	MissingInputReasons MissingInputReasons               `json:"missing_input_reasons"`
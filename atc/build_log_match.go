@@ -0,0 +1,23 @@
+package atc
+
+// BuildLogSearch is the result of searching the logs of builds.
+type BuildLogSearch struct {
+	Matches []BuildLogMatch `json:"matches"`
+
+	// ArchivedBuilds is the number of builds which were not searched because
+	// their events have been archived to object storage. Archived builds are
+	// never searched, so their logs can only be read build by build.
+	ArchivedBuilds int `json:"archived_builds,omitempty"`
+}
+
+// BuildLogMatch is a line of a build's log output which matched a search.
+type BuildLogMatch struct {
+	BuildID   int    `json:"build_id"`
+	BuildName string `json:"build_name"`
+	JobName   string `json:"job_name,omitempty"`
+	StepID    string `json:"step_id"`
+	StepName  string `json:"step_name,omitempty"`
+	Source    string `json:"source,omitempty"`
+	Time      int64  `json:"time"`
+	Line      string `json:"line"`
+}
@@ -37,6 +37,54 @@ func (planner Planner) Create(
 	return visitor.plan, nil
 }
 
+// CreateOneOff plans a one-off build running the given job plan. Get steps
+// with an artifact in the config use it instead of fetching the resource, so
+// they do not need an input version. Put steps are dropped from the plan if
+// the config skips them.
+func (planner Planner) CreateOneOff(
+	planConfig atc.StepConfig,
+	resources db.SchedulerResources,
+	resourceTypes atc.VersionedResourceTypes,
+	inputs []db.BuildInput,
+	config atc.JobOneOffBuildConfig,
+) (atc.Plan, error) {
+	visitor := &planVisitor{
+		planFactory: planner.planFactory,
+
+		resources:      resources,
+		resourceTypes:  resourceTypes,
+		inputs:         inputs,
+		artifactInputs: config.Inputs,
+		skipPuts:       config.SkipPuts,
+	}
+
+	err := planConfig.Visit(visitor)
+	if err != nil {
+		return atc.Plan{}, err
+	}
+
+	plan := visitor.plan
+	if dropped(plan) {
+		plan = planner.planFactory.NewPlan(atc.DoPlan{})
+	}
+
+	if len(config.Outputs) == 0 {
+		return plan, nil
+	}
+
+	outputs := atc.AggregatePlan{}
+	for _, name := range config.Outputs {
+		outputs = append(outputs, planner.planFactory.NewPlan(atc.ArtifactOutputPlan{
+			Name: name,
+		}))
+	}
+
+	return planner.planFactory.NewPlan(atc.EnsurePlan{
+		Step: plan,
+		Next: planner.planFactory.NewPlan(outputs),
+	}), nil
+}
+
 type planVisitor struct {
 	planFactory atc.PlanFactory
 
@@ -44,9 +92,18 @@ type planVisitor struct {
 	resourceTypes atc.VersionedResourceTypes
 	inputs        []db.BuildInput
 
+	artifactInputs map[string]int
+	skipPuts       bool
+
 	plan atc.Plan
 }
 
+// dropped returns true for the empty plan of a step which was left out of a
+// one-off build, e.g. a skipped put.
+func dropped(plan atc.Plan) bool {
+	return plan.ID == ""
+}
+
 func (visitor *planVisitor) VisitTask(step *atc.TaskStep) error {
 	visitor.plan = visitor.planFactory.NewPlan(atc.TaskPlan{
 		Name:              step.Name,
@@ -67,6 +124,15 @@ func (visitor *planVisitor) VisitTask(step *atc.TaskStep) error {
 }
 
 func (visitor *planVisitor) VisitGet(step *atc.GetStep) error {
+	if artifactID, found := visitor.artifactInputs[step.Name]; found {
+		visitor.plan = visitor.planFactory.NewPlan(atc.ArtifactInputPlan{
+			ArtifactID: artifactID,
+			Name:       step.Name,
+		})
+
+		return nil
+	}
+
 	resourceName := step.Resource
 	if resourceName == "" {
 		resourceName = step.Name
@@ -106,6 +172,11 @@ func (visitor *planVisitor) VisitGet(step *atc.GetStep) error {
 }
 
 func (visitor *planVisitor) VisitPut(step *atc.PutStep) error {
+	if visitor.skipPuts {
+		visitor.plan = atc.Plan{}
+		return nil
+	}
+
 	logicalName := step.Name
 
 	resourceName := step.Resource
@@ -162,7 +233,14 @@ func (visitor *planVisitor) VisitDo(step *atc.DoStep) error {
 			return err
 		}
 
-		do = append(do, visitor.plan)
+		if !dropped(visitor.plan) {
+			do = append(do, visitor.plan)
+		}
+	}
+
+	if len(do) == 0 {
+		visitor.plan = atc.Plan{}
+		return nil
 	}
 
 	visitor.plan = visitor.planFactory.NewPlan(do)
@@ -179,7 +257,14 @@ func (visitor *planVisitor) VisitAggregate(step *atc.AggregateStep) error {
 			return err
 		}
 
-		do = append(do, visitor.plan)
+		if !dropped(visitor.plan) {
+			do = append(do, visitor.plan)
+		}
+	}
+
+	if len(do) == 0 {
+		visitor.plan = atc.Plan{}
+		return nil
 	}
 
 	visitor.plan = visitor.planFactory.NewPlan(do)
@@ -196,7 +281,14 @@ func (visitor *planVisitor) VisitInParallel(step *atc.InParallelStep) error {
 			return err
 		}
 
-		steps = append(steps, visitor.plan)
+		if !dropped(visitor.plan) {
+			steps = append(steps, visitor.plan)
+		}
+	}
+
+	if len(steps) == 0 {
+		visitor.plan = atc.Plan{}
+		return nil
 	}
 
 	visitor.plan = visitor.planFactory.NewPlan(atc.InParallelPlan{
@@ -209,18 +301,37 @@ func (visitor *planVisitor) VisitInParallel(step *atc.InParallelStep) error {
 }
 
 func (visitor *planVisitor) VisitAcross(step *atc.AcrossStep) error {
+	varNames := make([]string, len(step.Vars))
+	values := make([][]interface{}, len(step.Vars))
+	static := true
+	for i, v := range step.Vars {
+		varNames[i] = v.Var
+		values[i], static = v.StaticValues()
+		if !static {
+			break
+		}
+	}
+
+	if !static {
+		return visitor.visitDynamicAcross(step)
+	}
+
 	vars := make([]atc.AcrossVar, len(step.Vars))
 	for i, v := range step.Vars {
-		maxInFlight := 1
-		if v.MaxInFlight != nil {
-			maxInFlight = v.MaxInFlight.Limit
-			if v.MaxInFlight.All {
-				maxInFlight = len(v.Values)
+		var maxInFlight *atc.MaxInFlightConfig
+		if step.MaxInFlight == nil {
+			maxInFlight = &atc.MaxInFlightConfig{Limit: 1}
+			if v.MaxInFlight != nil {
+				maxInFlight.Limit = v.MaxInFlight.Limit
+				if v.MaxInFlight.All {
+					maxInFlight.Limit = len(values[i])
+				}
 			}
 		}
+
 		vars[i] = atc.AcrossVar{
 			Var:         v.Var,
-			Values:      v.Values,
+			Values:      values[i],
 			MaxInFlight: maxInFlight,
 		}
 	}
@@ -228,15 +339,21 @@ func (visitor *planVisitor) VisitAcross(step *atc.AcrossStep) error {
 	acrossPlan := atc.AcrossPlan{
 		Vars:        vars,
 		Steps:       []atc.VarScopedPlan{},
+		MaxInFlight: step.MaxInFlight,
 		FailFast:    step.FailFast,
 	}
-	for _, vals := range cartesianProduct(step.Vars) {
+	for _, vals := range atc.AcrossCombinations(varNames, values, step.Include, step.Exclude) {
 		err := step.Step.Visit(visitor)
 		if err != nil {
 			return err
 		}
+
+		if dropped(visitor.plan) {
+			return nil
+		}
+
 		acrossPlan.Steps = append(acrossPlan.Steps, atc.VarScopedPlan{
-			Step:  visitor.plan,
+			Step:   visitor.plan,
 			Values: vals,
 		})
 	}
@@ -246,27 +363,51 @@ func (visitor *planVisitor) VisitAcross(step *atc.AcrossStep) error {
 	return nil
 }
 
-func cartesianProduct(vars []atc.AcrossVarConfig) [][]interface{} {
-	if len(vars) == 0 {
-		return make([][]interface{}, 1)
-	}
-	var product [][]interface{}
-	subProduct := cartesianProduct(vars[:len(vars)-1])
-	for _, vec := range subProduct {
-		for _, val := range vars[len(vars)-1].Values {
-			product = append(product, append(vec, val))
+// visitDynamicAcross plans an across step whose values are only known once
+// the build is running. The sub-step is planned once, and is used as a
+// template for each combination of values when they are resolved.
+func (visitor *planVisitor) visitDynamicAcross(step *atc.AcrossStep) error {
+	vars := make([]atc.AcrossVar, len(step.Vars))
+	for i, v := range step.Vars {
+		vars[i] = atc.AcrossVar{
+			Var:         v.Var,
+			Values:      v.Values,
+			MaxInFlight: v.MaxInFlight,
 		}
 	}
-	return product
+
+	err := step.Step.Visit(visitor)
+	if err != nil {
+		return err
+	}
+
+	if dropped(visitor.plan) {
+		return nil
+	}
+
+	template := visitor.plan
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.AcrossPlan{
+		Vars:            vars,
+		Steps:           []atc.VarScopedPlan{},
+		SubStepTemplate: &template,
+		Include:         step.Include,
+		Exclude:         step.Exclude,
+		MaxInFlight:     step.MaxInFlight,
+		FailFast:        step.FailFast,
+	})
+
+	return nil
 }
 
 func (visitor *planVisitor) VisitSetPipeline(step *atc.SetPipelineStep) error {
 	visitor.plan = visitor.planFactory.NewPlan(atc.SetPipelinePlan{
-		Name:     step.Name,
-		File:     step.File,
-		Team:     step.Team,
-		Vars:     step.Vars,
-		VarFiles: step.VarFiles,
+		Name:         step.Name,
+		File:         step.File,
+		Team:         step.Team,
+		Vars:         step.Vars,
+		VarFiles:     step.VarFiles,
+		InstanceVars: step.InstanceVars,
 	})
 
 	return nil
@@ -289,6 +430,10 @@ func (visitor *planVisitor) VisitTry(step *atc.TryStep) error {
 		return err
 	}
 
+	if dropped(visitor.plan) {
+		return nil
+	}
+
 	visitor.plan = visitor.planFactory.NewPlan(atc.TryPlan{
 		Step: visitor.plan,
 	})
@@ -302,6 +447,10 @@ func (visitor *planVisitor) VisitTimeout(step *atc.TimeoutStep) error {
 		return err
 	}
 
+	if dropped(visitor.plan) {
+		return nil
+	}
+
 	visitor.plan = visitor.planFactory.NewPlan(atc.TimeoutPlan{
 		Duration: step.Duration,
 		Step:     visitor.plan,
@@ -319,6 +468,10 @@ func (visitor *planVisitor) VisitRetry(step *atc.RetryStep) error {
 			return err
 		}
 
+		if dropped(visitor.plan) {
+			return nil
+		}
+
 		retryStep[i] = visitor.plan
 	}
 
@@ -330,20 +483,15 @@ func (visitor *planVisitor) VisitRetry(step *atc.RetryStep) error {
 func (visitor *planVisitor) VisitOnSuccess(step *atc.OnSuccessStep) error {
 	plan := atc.OnSuccessPlan{}
 
-	err := step.Step.Visit(visitor)
+	err := visitor.visitHook(step.Step, step.Hook, &plan.Step, &plan.Next, true)
 	if err != nil {
 		return err
 	}
 
-	plan.Step = visitor.plan
-
-	err = step.Hook.Config.Visit(visitor)
-	if err != nil {
-		return err
+	if dropped(plan.Step) || dropped(plan.Next) {
+		return nil
 	}
 
-	plan.Next = visitor.plan
-
 	visitor.plan = visitor.planFactory.NewPlan(plan)
 
 	return nil
@@ -352,20 +500,15 @@ func (visitor *planVisitor) VisitOnSuccess(step *atc.OnSuccessStep) error {
 func (visitor *planVisitor) VisitOnFailure(step *atc.OnFailureStep) error {
 	plan := atc.OnFailurePlan{}
 
-	err := step.Step.Visit(visitor)
+	err := visitor.visitHook(step.Step, step.Hook, &plan.Step, &plan.Next, false)
 	if err != nil {
 		return err
 	}
 
-	plan.Step = visitor.plan
-
-	err = step.Hook.Config.Visit(visitor)
-	if err != nil {
-		return err
+	if dropped(plan.Step) || dropped(plan.Next) {
+		return nil
 	}
 
-	plan.Next = visitor.plan
-
 	visitor.plan = visitor.planFactory.NewPlan(plan)
 
 	return nil
@@ -374,20 +517,15 @@ func (visitor *planVisitor) VisitOnFailure(step *atc.OnFailureStep) error {
 func (visitor *planVisitor) VisitOnAbort(step *atc.OnAbortStep) error {
 	plan := atc.OnAbortPlan{}
 
-	err := step.Step.Visit(visitor)
+	err := visitor.visitHook(step.Step, step.Hook, &plan.Step, &plan.Next, false)
 	if err != nil {
 		return err
 	}
 
-	plan.Step = visitor.plan
-
-	err = step.Hook.Config.Visit(visitor)
-	if err != nil {
-		return err
+	if dropped(plan.Step) || dropped(plan.Next) {
+		return nil
 	}
 
-	plan.Next = visitor.plan
-
 	visitor.plan = visitor.planFactory.NewPlan(plan)
 
 	return nil
@@ -396,42 +534,64 @@ func (visitor *planVisitor) VisitOnAbort(step *atc.OnAbortStep) error {
 func (visitor *planVisitor) VisitOnError(step *atc.OnErrorStep) error {
 	plan := atc.OnErrorPlan{}
 
-	err := step.Step.Visit(visitor)
+	err := visitor.visitHook(step.Step, step.Hook, &plan.Step, &plan.Next, false)
 	if err != nil {
 		return err
 	}
 
-	plan.Step = visitor.plan
+	if dropped(plan.Step) || dropped(plan.Next) {
+		return nil
+	}
 
-	err = step.Hook.Config.Visit(visitor)
+	visitor.plan = visitor.planFactory.NewPlan(plan)
+
+	return nil
+}
+
+func (visitor *planVisitor) VisitEnsure(step *atc.EnsureStep) error {
+	plan := atc.EnsurePlan{}
+
+	err := visitor.visitHook(step.Step, step.Hook, &plan.Step, &plan.Next, true)
 	if err != nil {
 		return err
 	}
 
-	plan.Next = visitor.plan
+	if dropped(plan.Step) || dropped(plan.Next) {
+		return nil
+	}
 
 	visitor.plan = visitor.planFactory.NewPlan(plan)
 
 	return nil
 }
-func (visitor *planVisitor) VisitEnsure(step *atc.EnsureStep) error {
-	plan := atc.EnsurePlan{}
 
-	err := step.Step.Visit(visitor)
+// visitHook plans a step and its hook. If either of them is dropped from a
+// one-off build, the plan is left as the one which remains, or as dropped if
+// the hook would never run without its step (e.g. on_failure of a skipped
+// put, which is as good as succeeded).
+func (visitor *planVisitor) visitHook(step atc.StepConfig, hook atc.Step, stepPlan *atc.Plan, hookPlan *atc.Plan, runsWithoutStep bool) error {
+	err := step.Visit(visitor)
 	if err != nil {
 		return err
 	}
 
-	plan.Step = visitor.plan
+	*stepPlan = visitor.plan
 
-	err = step.Hook.Config.Visit(visitor)
+	err = hook.Config.Visit(visitor)
 	if err != nil {
 		return err
 	}
 
-	plan.Next = visitor.plan
+	*hookPlan = visitor.plan
 
-	visitor.plan = visitor.planFactory.NewPlan(plan)
+	switch {
+	case dropped(*stepPlan) && runsWithoutStep:
+		visitor.plan = *hookPlan
+	case dropped(*stepPlan):
+		visitor.plan = atc.Plan{}
+	case dropped(*hookPlan):
+		visitor.plan = *stepPlan
+	}
 
 	return nil
 }
@@ -28,6 +28,9 @@ type PlannerTest struct {
 	Config atc.StepConfig
 	Inputs []db.BuildInput
 
+	// OneOff plans the config as a one-off build of a job
+	OneOff *atc.JobOneOffBuildConfig
+
 	CompareIDs bool
 	PlanJSON   string
 	Err        error
@@ -490,6 +493,128 @@ var factoryTests = []PlannerTest{
 			}
 		}`,
 	},
+	{
+		Title: "across step with include and exclude rules",
+
+		Config: &atc.AcrossStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Vars: []atc.AcrossVarConfig{
+				{
+					Var:    "var1",
+					Values: []interface{}{"a1", "a2"},
+				},
+				{
+					Var:    "var2",
+					Values: []interface{}{"b1", "b2"},
+				},
+			},
+			MaxInFlight: &atc.MaxInFlightConfig{Limit: 2},
+			Exclude: []atc.AcrossCombination{
+				{"var1": "a1"},
+				{"var1": "a2", "var2": "b1"},
+			},
+			Include: []atc.AcrossCombination{
+				{"var1": "a2", "var2": "b2"},
+				{"var1": "a3", "var2": "b3"},
+			},
+			FailFast: true,
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"across": {
+				"vars": [
+					{
+						"name": "var1",
+						"values": ["a1", "a2"]
+					},
+					{
+						"name": "var2",
+						"values": ["b1", "b2"]
+					}
+				],
+				"steps": [
+					{
+						"values": ["a2", "b2"],
+						"step": {
+							"id": "(unique)",
+							"load_var": {
+								"name": "some-var",
+								"file": "some-file"
+							}
+						}
+					},
+					{
+						"values": ["a3", "b3"],
+						"step": {
+							"id": "(unique)",
+							"load_var": {
+								"name": "some-var",
+								"file": "some-file"
+							}
+						}
+					}
+				],
+				"max_in_flight": 2,
+				"fail_fast": true
+			}
+		}`,
+	},
+	{
+		Title: "across step with values from a var",
+
+		Config: &atc.AcrossStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Vars: []atc.AcrossVarConfig{
+				{
+					Var:         "var1",
+					Values:      "((.:some-values))",
+					MaxInFlight: &atc.MaxInFlightConfig{All: true},
+				},
+				{
+					Var:    "var2",
+					Values: []interface{}{"b1", "b2"},
+				},
+			},
+			Exclude: []atc.AcrossCombination{
+				{"var2": "b1"},
+			},
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"across": {
+				"vars": [
+					{
+						"name": "var1",
+						"values": "((.:some-values))",
+						"max_in_flight": "all"
+					},
+					{
+						"name": "var2",
+						"values": ["b1", "b2"]
+					}
+				],
+				"steps": [],
+				"substep_template": {
+					"id": "(unique)",
+					"load_var": {
+						"name": "some-var",
+						"file": "some-file"
+					}
+				},
+				"exclude": [
+					{"var2": "b1"}
+				]
+			}
+		}`,
+	},
 	{
 		Title: "timeout modifier",
 
@@ -734,12 +859,249 @@ var factoryTests = []PlannerTest{
 			}
 		}`,
 	},
+	{
+		Title: "one-off get step with an artifact",
+
+		Config: &atc.GetStep{
+			Name:     "some-name",
+			Resource: "some-resource",
+		},
+		OneOff: &atc.JobOneOffBuildConfig{
+			Inputs: map[string]int{"some-name": 42},
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"artifact_input": {
+				"artifact_id": 42,
+				"name": "some-name"
+			}
+		}`,
+	},
+	{
+		Title: "one-off get step without an artifact or version",
+
+		Config: &atc.GetStep{
+			Name:     "some-name",
+			Resource: "some-resource",
+		},
+		OneOff: &atc.JobOneOffBuildConfig{
+			Inputs: map[string]int{"some-other-name": 42},
+		},
+
+		Err: builds.VersionNotProvidedError{Input: "some-name"},
+	},
+	{
+		Title: "one-off build skipping puts",
+
+		Config: &atc.DoStep{
+			Steps: []atc.Step{
+				{
+					Config: &atc.PutStep{
+						Name:     "some-name",
+						Resource: "some-resource",
+					},
+				},
+				{
+					Config: &atc.InParallelStep{
+						Config: atc.InParallelConfig{
+							Steps: []atc.Step{
+								{
+									Config: &atc.PutStep{
+										Name:     "some-other-name",
+										Resource: "some-resource",
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					Config: &atc.LoadVarStep{
+						Name: "some-var",
+						File: "some-file",
+					},
+				},
+			},
+		},
+		OneOff: &atc.JobOneOffBuildConfig{
+			SkipPuts: true,
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"do": [
+				{
+					"id": "(unique)",
+					"load_var": {
+						"name": "some-var",
+						"file": "some-file"
+					}
+				}
+			]
+		}`,
+	},
+	{
+		Title: "one-off build skipping the put of a hook",
+
+		Config: &atc.OnSuccessStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Hook: atc.Step{
+				Config: &atc.PutStep{
+					Name:     "some-name",
+					Resource: "some-resource",
+				},
+			},
+		},
+		OneOff: &atc.JobOneOffBuildConfig{
+			SkipPuts: true,
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"load_var": {
+				"name": "some-var",
+				"file": "some-file"
+			}
+		}`,
+	},
+	{
+		Title: "one-off build skipping a put with an on_success hook",
+
+		Config: &atc.OnSuccessStep{
+			Step: &atc.PutStep{
+				Name:     "some-name",
+				Resource: "some-resource",
+			},
+			Hook: atc.Step{
+				Config: &atc.LoadVarStep{
+					Name: "some-var",
+					File: "some-file",
+				},
+			},
+		},
+		OneOff: &atc.JobOneOffBuildConfig{
+			SkipPuts: true,
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"load_var": {
+				"name": "some-var",
+				"file": "some-file"
+			}
+		}`,
+	},
+	{
+		Title: "one-off build skipping a put with an on_failure hook",
+
+		Config: &atc.DoStep{
+			Steps: []atc.Step{
+				{
+					Config: &atc.OnFailureStep{
+						Step: &atc.PutStep{
+							Name:     "some-name",
+							Resource: "some-resource",
+						},
+						Hook: atc.Step{
+							Config: &atc.LoadVarStep{
+								Name: "some-var",
+								File: "some-file",
+							},
+						},
+					},
+				},
+				{
+					Config: &atc.LoadVarStep{
+						Name: "some-other-var",
+						File: "some-other-file",
+					},
+				},
+			},
+		},
+		OneOff: &atc.JobOneOffBuildConfig{
+			SkipPuts: true,
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"do": [
+				{
+					"id": "(unique)",
+					"load_var": {
+						"name": "some-other-var",
+						"file": "some-other-file"
+					}
+				}
+			]
+		}`,
+	},
+	{
+		Title: "one-off build with only puts",
+
+		Config: &atc.PutStep{
+			Name:     "some-name",
+			Resource: "some-resource",
+		},
+		OneOff: &atc.JobOneOffBuildConfig{
+			SkipPuts: true,
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"do": []
+		}`,
+	},
+	{
+		Title: "one-off build with outputs",
+
+		Config: &atc.LoadVarStep{
+			Name: "some-var",
+			File: "some-file",
+		},
+		OneOff: &atc.JobOneOffBuildConfig{
+			Outputs: []string{"some-output"},
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"ensure": {
+				"step": {
+					"id": "(unique)",
+					"load_var": {
+						"name": "some-var",
+						"file": "some-file"
+					}
+				},
+				"ensure": {
+					"id": "(unique)",
+					"aggregate": [
+						{
+							"id": "(unique)",
+							"artifact_output": {
+								"name": "some-output"
+							}
+						}
+					]
+				}
+			}
+		}`,
+	},
 }
 
 func (test PlannerTest) Run(s *PlannerSuite) {
 	factory := builds.NewPlanner(atc.NewPlanFactory(0))
 
-	actualPlan, actualErr := factory.Create(test.Config, resources, resourceTypes, test.Inputs)
+	var actualPlan atc.Plan
+	var actualErr error
+	if test.OneOff != nil {
+		actualPlan, actualErr = factory.CreateOneOff(test.Config, resources, resourceTypes, test.Inputs, *test.OneOff)
+	} else {
+		actualPlan, actualErr = factory.Create(test.Config, resources, resourceTypes, test.Inputs)
+	}
 
 	if test.Err != nil {
 		s.Equal(test.Err, actualErr)
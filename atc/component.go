@@ -9,6 +9,7 @@ const (
 	ComponentLidarChecker               = "checker"
 	ComponentBuildReaper                = "reaper"
 	ComponentSyslogDrainer              = "drainer"
+	ComponentBuildLogArchiver           = "build_log_archiver"
 	ComponentCollectorAccessTokens      = "collector_access_tokens"
 	ComponentCollectorArtifacts         = "collector_artifacts"
 	ComponentCollectorBuilds            = "collector_builds"
@@ -21,6 +22,8 @@ const (
 	ComponentCollectorVolumes           = "collector_volumes"
 	ComponentCollectorWorkers           = "collector_workers"
 	ComponentCollectorPipelines         = "collector_pipelines"
+	ComponentCollectorPolicyDecisions   = "collector_policy_decisions"
+	ComponentCollectorSecretLeases      = "collector_secret_leases"
 )
 
 type Component struct {
@@ -172,6 +172,11 @@ func (c VarSourceConfigs) OrderByDependency() (VarSourceConfigs, error) {
 	return ordered, nil
 }
 
+// CheckEveryAdaptive is the check_every value which has a resource checked
+// at an interval that backs off while its checks keep failing or stop
+// finding new versions.
+const CheckEveryAdaptive = "adaptive"
+
 type ResourceConfig struct {
 	Name         string  `json:"name"`
 	OldName      string  `json:"old_name,omitempty"`
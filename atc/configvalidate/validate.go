@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	. "github.com/concourse/concourse/atc"
@@ -168,6 +169,10 @@ func validateResources(c Config) ([]ConfigWarning, error) {
 		if resource.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		if err := validateCheckEvery(resource.CheckEvery); err != nil {
+			errorMessages = append(errorMessages, identifier+" has an invalid check_every: "+err.Error())
+		}
 	}
 
 	errorMessages = append(errorMessages, validateResourcesUnused(c)...)
@@ -210,11 +215,28 @@ func validateResourceTypes(c Config) ([]ConfigWarning, error) {
 		if resourceType.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		if err := validateCheckEvery(resourceType.CheckEvery); err != nil {
+			errorMessages = append(errorMessages, identifier+" has an invalid check_every: "+err.Error())
+		}
 	}
 
 	return warnings, compositeErr(errorMessages)
 }
 
+func validateCheckEvery(every string) error {
+	if every == "" || every == atc.CheckEveryAdaptive {
+		return nil
+	}
+
+	_, err := time.ParseDuration(every)
+	if err != nil {
+		return fmt.Errorf("must be a duration or '%s'", atc.CheckEveryAdaptive)
+	}
+
+	return nil
+}
+
 func validateResourcesUnused(c Config) []string {
 	usedResources := usedResources(c)
 
@@ -364,7 +386,7 @@ func validateVarSources(c Config) ([]ConfigWarning, error) {
 			// TODO: this check should eventually be removed once all credential managers
 			// are supported in pipeline. - @evanchaoli
 			switch cm.Type {
-			case "vault", "dummy", "ssm":
+			case "vault", "dummy", "ssm", "local":
 			default:
 				errorMessages = append(errorMessages, fmt.Sprintf("credential manager type %s is not supported in pipeline yet", cm.Type))
 			}
@@ -106,8 +106,6 @@ var _ = Describe("ValidateConfig", func() {
 				},
 			},
 		}
-
-		atc.EnableAcrossStep = true
 	})
 
 	JustBeforeEach(func() {
@@ -482,6 +480,28 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a resource checks adaptively", func() {
+			BeforeEach(func() {
+				config.Resources[0].CheckEvery = "adaptive"
+			})
+
+			It("does not return an error", func() {
+				Expect(errorMessages).To(HaveLen(0))
+			})
+		})
+
+		Context("when a resource has an invalid check_every", func() {
+			BeforeEach(func() {
+				config.Resources[0].CheckEvery = "sometimes"
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resources:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource has an invalid check_every: must be a duration or 'adaptive'"))
+			})
+		})
+
 		Context("when a resource has no name or type", func() {
 			BeforeEach(func() {
 				config.Resources = append(config.Resources, atc.ResourceConfig{
@@ -788,6 +808,22 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a resource type has an invalid check_every", func() {
+			BeforeEach(func() {
+				config.ResourceTypes = append(config.ResourceTypes, atc.ResourceType{
+					Name:       "some-other-resource-type",
+					Type:       "some-type",
+					CheckEvery: "sometimes",
+				})
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid resource types:"))
+				Expect(errorMessages[0]).To(ContainSubstring("resource_types.some-other-resource-type has an invalid check_every: must be a duration or 'adaptive'"))
+			})
+		})
+
 		Context("when a resource has no name or type", func() {
 			BeforeEach(func() {
 				config.ResourceTypes = append(config.ResourceTypes, atc.ResourceType{
@@ -980,6 +1016,53 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a privileged task plan specifies a security profile", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.TaskStep{
+							Name:       "some-task",
+							Privileged: true,
+							Config: &atc.TaskConfig{
+								Platform:        "linux",
+								Run:             atc.TaskRunConfig{Path: "some-script"},
+								SecurityProfile: "network-tools",
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(some-task): cannot specify a `security_profile:` for a privileged task"))
+				})
+			})
+
+			Context("when a task plan specifies an invalid security profile", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.TaskStep{
+							Name: "some-task",
+							Config: &atc.TaskConfig{
+								Platform:        "linux",
+								Run:             atc.TaskRunConfig{Path: "some-script"},
+								SecurityProfile: "../Network Tools",
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(some-task).config: invalid security_profile '../Network Tools'"))
+				})
+			})
+
 			Context("when a task plan is invalid", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
@@ -1980,10 +2063,143 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
-			Context("when the across step is not enabled", func() {
+			Context("when an across var has values that are neither a list nor a var", func() {
 				BeforeEach(func() {
-					atc.EnableAcrossStep = false
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.AcrossStep{
+							Step: &atc.PutStep{
+								Name: "some-resource",
+							},
+							Vars: []atc.AcrossVarConfig{
+								{
+									Var:    "var",
+									Values: "some-values",
+								},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].across[0].values: must be a list of values or a var"))
+				})
+			})
 
+			Context("when an across var has values loaded from a var", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence,
+						atc.Step{Config: &atc.LoadVarStep{
+							Name: "some-values",
+							File: "unused",
+						}},
+						atc.Step{
+							Config: &atc.AcrossStep{
+								Step: &atc.PutStep{
+									Name: "some-resource",
+								},
+								Vars: []atc.AcrossVarConfig{
+									{
+										Var:    "var",
+										Values: "((.:some-values))",
+									},
+								},
+							},
+						})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("succeeds", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("when an across step limits both the step and a var", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.AcrossStep{
+							Step: &atc.PutStep{
+								Name: "some-resource",
+							},
+							Vars: []atc.AcrossVarConfig{
+								{
+									Var:         "var",
+									Values:      []interface{}{"a", "b"},
+									MaxInFlight: &atc.MaxInFlightConfig{Limit: 1},
+								},
+							},
+							MaxInFlight: &atc.MaxInFlightConfig{All: true},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].across[0].max_in_flight: cannot be used together with the max_in_flight of the across step"))
+				})
+			})
+
+			Context("when an across step has a non-positive step limit", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.AcrossStep{
+							Step: &atc.PutStep{
+								Name: "some-resource",
+							},
+							Vars: []atc.AcrossVarConfig{
+								{
+									Var:    "var",
+									Values: []interface{}{"a", "b"},
+								},
+							},
+							MaxInFlight: &atc.MaxInFlightConfig{Limit: 0},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].across.max_in_flight: must be greater than 0"))
+				})
+			})
+
+			Context("when an across step excludes a combination of an unknown var", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.AcrossStep{
+							Step: &atc.PutStep{
+								Name: "some-resource",
+							},
+							Vars: []atc.AcrossVarConfig{
+								{
+									Var:    "var",
+									Values: []interface{}{"a", "b"},
+								},
+							},
+							Exclude: []atc.AcrossCombination{
+								{"var": "a", "bogus": "b"},
+							},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].across.exclude[0]: unknown var 'bogus'"))
+				})
+			})
+
+			Context("when an across step includes a combination missing a var", func() {
+				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
 						Config: &atc.AcrossStep{
 							Step: &atc.PutStep{
@@ -1991,8 +2207,16 @@ var _ = Describe("ValidateConfig", func() {
 							},
 							Vars: []atc.AcrossVarConfig{
 								{
-									Var: "var",
+									Var:    "var1",
+									Values: []interface{}{"a", "b"},
 								},
+								{
+									Var:    "var2",
+									Values: []interface{}{"c", "d"},
+								},
+							},
+							Include: []atc.AcrossCombination{
+								{"var1": "e"},
 							},
 						},
 					})
@@ -2002,7 +2226,7 @@ var _ = Describe("ValidateConfig", func() {
 
 				It("returns an error", func() {
 					Expect(errorMessages).To(HaveLen(1))
-					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].across: the across step must be explicitly opted-in to using the `--enable-across-step` flag"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].across.include[0]: missing value for var 'var2'"))
 				})
 			})
 		})
@@ -0,0 +1,137 @@
+package creds
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+)
+
+//go:generate counterfeiter . SecretAccessRecorder
+
+// A SecretAccessRecorder records the secret lookups made on behalf of a build.
+type SecretAccessRecorder interface {
+	RecordSecretAccess(buildID int, access atc.SecretAccess) error
+}
+
+// CacheAwareSecrets is implemented by Secrets which cache secrets, so that a
+// lookup can tell whether it was served from the cache.
+type CacheAwareSecrets interface {
+	GetCached(secretPath string) (value interface{}, expiration *time.Time, found bool, cacheHit bool, err error)
+}
+
+// AuditedSecrets records every lookup made on behalf of a build, but never the
+// values of the secrets. Lookups which are not made on behalf of a build, e.g.
+// for resource checks, are not recorded.
+type AuditedSecrets struct {
+	logger    lager.Logger
+	secrets   Secrets
+	manager   string
+	varSource string
+	recorders []SecretAccessRecorder
+
+	buildID  int
+	recorded *sync.Map
+}
+
+func NewAuditedSecrets(logger lager.Logger, secrets Secrets, manager string, recorders ...SecretAccessRecorder) *AuditedSecrets {
+	return &AuditedSecrets{
+		logger:    logger,
+		secrets:   secrets,
+		manager:   manager,
+		recorders: recorders,
+	}
+}
+
+func (as *AuditedSecrets) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	if as.buildID == 0 {
+		return as.secrets.Get(secretPath)
+	}
+
+	var (
+		value      interface{}
+		expiration *time.Time
+		found      bool
+		cacheHit   bool
+		err        error
+	)
+
+	if cached, ok := as.secrets.(CacheAwareSecrets); ok {
+		value, expiration, found, cacheHit, err = cached.GetCached(secretPath)
+	} else {
+		value, expiration, found, err = as.secrets.Get(secretPath)
+	}
+
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	as.record(atc.SecretAccess{
+		Path:      secretPath,
+		Manager:   as.manager,
+		VarSource: as.varSource,
+		CacheHit:  cacheHit,
+		Found:     found,
+	})
+
+	return value, expiration, found, nil
+}
+
+func (as *AuditedSecrets) NewSecretLookupPaths(teamName string, pipelineName string, allowRootPath bool) []SecretLookupPath {
+	return as.secrets.NewSecretLookupPaths(teamName, pipelineName, allowRootPath)
+}
+
+// ForBuild records the lookups of the returned Secrets against the build.
+func (as *AuditedSecrets) ForBuild(buildID int) Secrets {
+	return &AuditedSecrets{
+		logger:    as.logger,
+		secrets:   ForBuild(as.secrets, buildID),
+		manager:   as.manager,
+		varSource: as.varSource,
+		recorders: as.recorders,
+
+		buildID:  buildID,
+		recorded: new(sync.Map),
+	}
+}
+
+// AuditVarSource records the lookups made through a pipeline's var source
+// against the same build as the given global secrets, if any.
+func AuditVarSource(globalSecrets Secrets, secrets Secrets, varSourceName string, managerType string) Secrets {
+	audited, ok := globalSecrets.(*AuditedSecrets)
+	if !ok || audited.buildID == 0 {
+		return secrets
+	}
+
+	return &AuditedSecrets{
+		logger:    audited.logger,
+		secrets:   secrets,
+		manager:   managerType,
+		varSource: varSourceName,
+		recorders: audited.recorders,
+
+		buildID:  audited.buildID,
+		recorded: audited.recorded,
+	}
+}
+
+func (as *AuditedSecrets) record(access atc.SecretAccess) {
+	// the same secret is typically looked up many times over the course of a
+	// build, which is only worth recording once
+	key := fmt.Sprintf("%s:%s:%s:%t:%t", access.Manager, access.VarSource, access.Path, access.CacheHit, access.Found)
+	if _, recorded := as.recorded.LoadOrStore(key, true); recorded {
+		return
+	}
+
+	for _, recorder := range as.recorders {
+		err := recorder.RecordSecretAccess(as.buildID, access)
+		if err != nil {
+			as.logger.Error("failed-to-record-secret-access", err, lager.Data{
+				"build": as.buildID,
+				"path":  access.Path,
+			})
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package creds_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditedSecrets", func() {
+	var (
+		fakeSecrets  *credsfakes.FakeSecrets
+		fakeRecorder *credsfakes.FakeSecretAccessRecorder
+
+		auditedSecrets *creds.AuditedSecrets
+	)
+
+	BeforeEach(func() {
+		fakeSecrets = new(credsfakes.FakeSecrets)
+		fakeRecorder = new(credsfakes.FakeSecretAccessRecorder)
+
+		fakeSecrets.GetReturns("some-value", nil, true, nil)
+
+		auditedSecrets = creds.NewAuditedSecrets(lagertest.NewTestLogger("test"), fakeSecrets, "vault", fakeRecorder)
+	})
+
+	Context("when not scoped to a build", func() {
+		It("does not record the lookups", func() {
+			value, _, found, err := auditedSecrets.Get("/concourse/main/foo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-value"))
+
+			Expect(fakeRecorder.RecordSecretAccessCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when scoped to a build", func() {
+		var buildSecrets creds.Secrets
+
+		BeforeEach(func() {
+			buildSecrets = auditedSecrets.ForBuild(42)
+		})
+
+		It("records the lookup against the build without the value", func() {
+			value, _, found, err := buildSecrets.Get("/concourse/main/foo")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("some-value"))
+
+			Expect(fakeRecorder.RecordSecretAccessCallCount()).To(Equal(1))
+			buildID, access := fakeRecorder.RecordSecretAccessArgsForCall(0)
+			Expect(buildID).To(Equal(42))
+			Expect(access).To(Equal(atc.SecretAccess{
+				Path:    "/concourse/main/foo",
+				Manager: "vault",
+				Found:   true,
+			}))
+		})
+
+		It("records repeated lookups only once", func() {
+			_, _, _, err := buildSecrets.Get("/concourse/main/foo")
+			Expect(err).ToNot(HaveOccurred())
+
+			_, _, _, err = buildSecrets.Get("/concourse/main/foo")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(fakeRecorder.RecordSecretAccessCallCount()).To(Equal(1))
+		})
+
+		It("records lookups of secrets which are not found", func() {
+			fakeSecrets.GetReturns(nil, nil, false, nil)
+
+			_, _, found, err := buildSecrets.Get("/concourse/main/bar")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			_, access := fakeRecorder.RecordSecretAccessArgsForCall(0)
+			Expect(access.Found).To(BeFalse())
+		})
+
+		Context("when the lookup fails", func() {
+			BeforeEach(func() {
+				fakeSecrets.GetReturns(nil, nil, false, errors.New("nope"))
+			})
+
+			It("returns the error without recording the lookup", func() {
+				_, _, _, err := buildSecrets.Get("/concourse/main/foo")
+				Expect(err).To(MatchError("nope"))
+
+				Expect(fakeRecorder.RecordSecretAccessCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when recording the lookup fails", func() {
+			BeforeEach(func() {
+				fakeRecorder.RecordSecretAccessReturns(errors.New("nope"))
+			})
+
+			It("still returns the secret", func() {
+				value, _, found, err := buildSecrets.Get("/concourse/main/foo")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(value).To(Equal("some-value"))
+			})
+		})
+
+		Context("when the secrets are cached", func() {
+			BeforeEach(func() {
+				cachedSecrets := creds.NewCachedSecrets(fakeSecrets, creds.SecretCacheConfig{
+					Duration:         time.Minute,
+					DurationNotFound: time.Minute,
+					PurgeInterval:    time.Minute,
+				})
+
+				auditedSecrets = creds.NewAuditedSecrets(lagertest.NewTestLogger("test"), cachedSecrets, "vault", fakeRecorder)
+				buildSecrets = auditedSecrets.ForBuild(42)
+			})
+
+			It("records whether the lookup was served from the cache", func() {
+				_, _, _, err := buildSecrets.Get("/concourse/main/foo")
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, _, err = buildSecrets.Get("/concourse/main/foo")
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(fakeSecrets.GetCallCount()).To(Equal(1))
+				Expect(fakeRecorder.RecordSecretAccessCallCount()).To(Equal(2))
+
+				_, miss := fakeRecorder.RecordSecretAccessArgsForCall(0)
+				Expect(miss.CacheHit).To(BeFalse())
+
+				_, hit := fakeRecorder.RecordSecretAccessArgsForCall(1)
+				Expect(hit.CacheHit).To(BeTrue())
+			})
+		})
+
+		Context("when looking up secrets through a var source", func() {
+			var fakeVarSourceSecrets *credsfakes.FakeSecrets
+
+			BeforeEach(func() {
+				fakeVarSourceSecrets = new(credsfakes.FakeSecrets)
+				fakeVarSourceSecrets.GetReturns("some-other-value", nil, true, nil)
+			})
+
+			It("records the lookup against the same build", func() {
+				varSourceSecrets := creds.AuditVarSource(buildSecrets, fakeVarSourceSecrets, "some-ssm", "ssm")
+
+				value, _, _, err := varSourceSecrets.Get("/some-pipeline/token")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(value).To(Equal("some-other-value"))
+
+				buildID, access := fakeRecorder.RecordSecretAccessArgsForCall(0)
+				Expect(buildID).To(Equal(42))
+				Expect(access).To(Equal(atc.SecretAccess{
+					Path:      "/some-pipeline/token",
+					Manager:   "ssm",
+					VarSource: "some-ssm",
+					Found:     true,
+				}))
+			})
+
+			It("does not audit var sources outside of a build", func() {
+				varSourceSecrets := creds.AuditVarSource(auditedSecrets, fakeVarSourceSecrets, "some-ssm", "ssm")
+				Expect(varSourceSecrets).To(Equal(fakeVarSourceSecrets))
+			})
+		})
+	})
+})
@@ -37,11 +37,18 @@ func NewCachedSecrets(secrets Secrets, cacheConfig SecretCacheConfig) *CachedSec
 }
 
 func (cs *CachedSecrets) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	value, expiration, found, _, err := cs.GetCached(secretPath)
+	return value, expiration, found, err
+}
+
+// GetCached is like Get, but also returns whether the secret was served from
+// the cache.
+func (cs *CachedSecrets) GetCached(secretPath string) (interface{}, *time.Time, bool, bool, error) {
 	// if there is a corresponding entry in the cache, return it
 	entry, found := cs.cache.Get(secretPath)
 	if found {
 		result := entry.(CacheEntry)
-		return result.value, result.expiration, result.found, nil
+		return result.value, result.expiration, result.found, true, nil
 	}
 
 	// otherwise, let's make a request to the underlying secret manager
@@ -49,13 +56,19 @@ func (cs *CachedSecrets) Get(secretPath string) (interface{}, *time.Time, bool,
 
 	// we don't want to cache errors, let the errors be retried the next time around
 	if err != nil {
-		return nil, nil, false, err
+		return nil, nil, false, false, err
 	}
 
 	// here we want to cache secret value, expiration, and found flag too
 	// meaning that "secret not found" responses will be cached too!
 	entry = CacheEntry{value: value, expiration: expiration, found: found}
 
+	// secrets which are already expired (e.g. leased to a build) must not be
+	// cached, as go-cache would keep them forever
+	if found && expiration != nil && !expiration.After(time.Now()) {
+		return value, expiration, found, false, nil
+	}
+
 	if found {
 		// take default cache ttl
 		duration := cs.cacheConfig.Duration
@@ -71,9 +84,19 @@ func (cs *CachedSecrets) Get(secretPath string) (interface{}, *time.Time, bool,
 		cs.cache.Set(secretPath, entry, cs.cacheConfig.DurationNotFound)
 	}
 
-	return value, expiration, found, nil
+	return value, expiration, found, false, nil
 }
 
 func (cs *CachedSecrets) NewSecretLookupPaths(teamName string, pipelineName string, allowRootPath bool) []SecretLookupPath {
 	return cs.secrets.NewSecretLookupPaths(teamName, pipelineName, allowRootPath)
 }
+
+// ForBuild scopes the underlying secrets to the build, sharing the cache with
+// the unscoped secrets
+func (cs *CachedSecrets) ForBuild(buildID int) Secrets {
+	return &CachedSecrets{
+		secrets:     ForBuild(cs.secrets, buildID),
+		cacheConfig: cs.cacheConfig,
+		cache:       cs.cache,
+	}
+}
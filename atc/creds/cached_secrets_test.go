@@ -162,4 +162,27 @@ var _ = Describe("Caching of secrets", func() {
 		Expect(underlyingMisses).To(BeIdenticalTo(4))
 	})
 
+	It("should not cache secrets which have already expired", func() {
+		expiration := time.Now()
+		secretManager.GetStub = makeGetStub("foo", "value", &expiration, true, nil, &underlyingReads, &underlyingMisses)
+
+		_, _, _, _ = cachedSecretManager.Get("foo")
+		_, _, _, _ = cachedSecretManager.Get("foo")
+		Expect(underlyingReads).To(BeIdenticalTo(2))
+	})
+
+	It("should scope the underlying secrets to a build while sharing the cache", func() {
+		secretManager.GetStub = makeGetStub("foo", "value", nil, true, nil, &underlyingReads, &underlyingMisses)
+
+		_, _, _, _ = cachedSecretManager.Get("foo")
+		Expect(underlyingReads).To(BeIdenticalTo(1))
+
+		buildSecrets := cachedSecretManager.ForBuild(42)
+		value, _, found, err := buildSecrets.Get("foo")
+		Expect(value).To(BeIdenticalTo("value"))
+		Expect(found).To(BeTrue())
+		Expect(err).To(BeNil())
+		Expect(underlyingReads).To(BeIdenticalTo(1))
+	})
+
 })
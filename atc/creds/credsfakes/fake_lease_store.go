@@ -0,0 +1,342 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package credsfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc/creds"
+)
+
+type FakeLeaseStore struct {
+	BuildLeasesStub        func(int) ([]string, error)
+	buildLeasesMutex       sync.RWMutex
+	buildLeasesArgsForCall []struct {
+		arg1 int
+	}
+	buildLeasesReturns struct {
+		result1 []string
+		result2 error
+	}
+	buildLeasesReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	CreateLeaseStub        func(int, string) error
+	createLeaseMutex       sync.RWMutex
+	createLeaseArgsForCall []struct {
+		arg1 int
+		arg2 string
+	}
+	createLeaseReturns struct {
+		result1 error
+	}
+	createLeaseReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteLeaseStub        func(string) error
+	deleteLeaseMutex       sync.RWMutex
+	deleteLeaseArgsForCall []struct {
+		arg1 string
+	}
+	deleteLeaseReturns struct {
+		result1 error
+	}
+	deleteLeaseReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UnownedLeasesStub        func(time.Time) ([]string, error)
+	unownedLeasesMutex       sync.RWMutex
+	unownedLeasesArgsForCall []struct {
+		arg1 time.Time
+	}
+	unownedLeasesReturns struct {
+		result1 []string
+		result2 error
+	}
+	unownedLeasesReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeLeaseStore) BuildLeases(arg1 int) ([]string, error) {
+	fake.buildLeasesMutex.Lock()
+	ret, specificReturn := fake.buildLeasesReturnsOnCall[len(fake.buildLeasesArgsForCall)]
+	fake.buildLeasesArgsForCall = append(fake.buildLeasesArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("BuildLeases", []interface{}{arg1})
+	fake.buildLeasesMutex.Unlock()
+	if fake.BuildLeasesStub != nil {
+		return fake.BuildLeasesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.buildLeasesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLeaseStore) BuildLeasesCallCount() int {
+	fake.buildLeasesMutex.RLock()
+	defer fake.buildLeasesMutex.RUnlock()
+	return len(fake.buildLeasesArgsForCall)
+}
+
+func (fake *FakeLeaseStore) BuildLeasesCalls(stub func(int) ([]string, error)) {
+	fake.buildLeasesMutex.Lock()
+	defer fake.buildLeasesMutex.Unlock()
+	fake.BuildLeasesStub = stub
+}
+
+func (fake *FakeLeaseStore) BuildLeasesArgsForCall(i int) int {
+	fake.buildLeasesMutex.RLock()
+	defer fake.buildLeasesMutex.RUnlock()
+	argsForCall := fake.buildLeasesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeLeaseStore) BuildLeasesReturns(result1 []string, result2 error) {
+	fake.buildLeasesMutex.Lock()
+	defer fake.buildLeasesMutex.Unlock()
+	fake.BuildLeasesStub = nil
+	fake.buildLeasesReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLeaseStore) BuildLeasesReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.buildLeasesMutex.Lock()
+	defer fake.buildLeasesMutex.Unlock()
+	fake.BuildLeasesStub = nil
+	if fake.buildLeasesReturnsOnCall == nil {
+		fake.buildLeasesReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.buildLeasesReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLeaseStore) CreateLease(arg1 int, arg2 string) error {
+	fake.createLeaseMutex.Lock()
+	ret, specificReturn := fake.createLeaseReturnsOnCall[len(fake.createLeaseArgsForCall)]
+	fake.createLeaseArgsForCall = append(fake.createLeaseArgsForCall, struct {
+		arg1 int
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("CreateLease", []interface{}{arg1, arg2})
+	fake.createLeaseMutex.Unlock()
+	if fake.CreateLeaseStub != nil {
+		return fake.CreateLeaseStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.createLeaseReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeLeaseStore) CreateLeaseCallCount() int {
+	fake.createLeaseMutex.RLock()
+	defer fake.createLeaseMutex.RUnlock()
+	return len(fake.createLeaseArgsForCall)
+}
+
+func (fake *FakeLeaseStore) CreateLeaseCalls(stub func(int, string) error) {
+	fake.createLeaseMutex.Lock()
+	defer fake.createLeaseMutex.Unlock()
+	fake.CreateLeaseStub = stub
+}
+
+func (fake *FakeLeaseStore) CreateLeaseArgsForCall(i int) (int, string) {
+	fake.createLeaseMutex.RLock()
+	defer fake.createLeaseMutex.RUnlock()
+	argsForCall := fake.createLeaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeLeaseStore) CreateLeaseReturns(result1 error) {
+	fake.createLeaseMutex.Lock()
+	defer fake.createLeaseMutex.Unlock()
+	fake.CreateLeaseStub = nil
+	fake.createLeaseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLeaseStore) CreateLeaseReturnsOnCall(i int, result1 error) {
+	fake.createLeaseMutex.Lock()
+	defer fake.createLeaseMutex.Unlock()
+	fake.CreateLeaseStub = nil
+	if fake.createLeaseReturnsOnCall == nil {
+		fake.createLeaseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createLeaseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLeaseStore) DeleteLease(arg1 string) error {
+	fake.deleteLeaseMutex.Lock()
+	ret, specificReturn := fake.deleteLeaseReturnsOnCall[len(fake.deleteLeaseArgsForCall)]
+	fake.deleteLeaseArgsForCall = append(fake.deleteLeaseArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("DeleteLease", []interface{}{arg1})
+	fake.deleteLeaseMutex.Unlock()
+	if fake.DeleteLeaseStub != nil {
+		return fake.DeleteLeaseStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteLeaseReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeLeaseStore) DeleteLeaseCallCount() int {
+	fake.deleteLeaseMutex.RLock()
+	defer fake.deleteLeaseMutex.RUnlock()
+	return len(fake.deleteLeaseArgsForCall)
+}
+
+func (fake *FakeLeaseStore) DeleteLeaseCalls(stub func(string) error) {
+	fake.deleteLeaseMutex.Lock()
+	defer fake.deleteLeaseMutex.Unlock()
+	fake.DeleteLeaseStub = stub
+}
+
+func (fake *FakeLeaseStore) DeleteLeaseArgsForCall(i int) string {
+	fake.deleteLeaseMutex.RLock()
+	defer fake.deleteLeaseMutex.RUnlock()
+	argsForCall := fake.deleteLeaseArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeLeaseStore) DeleteLeaseReturns(result1 error) {
+	fake.deleteLeaseMutex.Lock()
+	defer fake.deleteLeaseMutex.Unlock()
+	fake.DeleteLeaseStub = nil
+	fake.deleteLeaseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLeaseStore) DeleteLeaseReturnsOnCall(i int, result1 error) {
+	fake.deleteLeaseMutex.Lock()
+	defer fake.deleteLeaseMutex.Unlock()
+	fake.DeleteLeaseStub = nil
+	if fake.deleteLeaseReturnsOnCall == nil {
+		fake.deleteLeaseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteLeaseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeLeaseStore) UnownedLeases(arg1 time.Time) ([]string, error) {
+	fake.unownedLeasesMutex.Lock()
+	ret, specificReturn := fake.unownedLeasesReturnsOnCall[len(fake.unownedLeasesArgsForCall)]
+	fake.unownedLeasesArgsForCall = append(fake.unownedLeasesArgsForCall, struct {
+		arg1 time.Time
+	}{arg1})
+	fake.recordInvocation("UnownedLeases", []interface{}{arg1})
+	fake.unownedLeasesMutex.Unlock()
+	if fake.UnownedLeasesStub != nil {
+		return fake.UnownedLeasesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.unownedLeasesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeLeaseStore) UnownedLeasesCallCount() int {
+	fake.unownedLeasesMutex.RLock()
+	defer fake.unownedLeasesMutex.RUnlock()
+	return len(fake.unownedLeasesArgsForCall)
+}
+
+func (fake *FakeLeaseStore) UnownedLeasesCalls(stub func(time.Time) ([]string, error)) {
+	fake.unownedLeasesMutex.Lock()
+	defer fake.unownedLeasesMutex.Unlock()
+	fake.UnownedLeasesStub = stub
+}
+
+func (fake *FakeLeaseStore) UnownedLeasesArgsForCall(i int) time.Time {
+	fake.unownedLeasesMutex.RLock()
+	defer fake.unownedLeasesMutex.RUnlock()
+	argsForCall := fake.unownedLeasesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeLeaseStore) UnownedLeasesReturns(result1 []string, result2 error) {
+	fake.unownedLeasesMutex.Lock()
+	defer fake.unownedLeasesMutex.Unlock()
+	fake.UnownedLeasesStub = nil
+	fake.unownedLeasesReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLeaseStore) UnownedLeasesReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.unownedLeasesMutex.Lock()
+	defer fake.unownedLeasesMutex.Unlock()
+	fake.UnownedLeasesStub = nil
+	if fake.unownedLeasesReturnsOnCall == nil {
+		fake.unownedLeasesReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.unownedLeasesReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeLeaseStore) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.buildLeasesMutex.RLock()
+	defer fake.buildLeasesMutex.RUnlock()
+	fake.createLeaseMutex.RLock()
+	defer fake.createLeaseMutex.RUnlock()
+	fake.deleteLeaseMutex.RLock()
+	defer fake.deleteLeaseMutex.RUnlock()
+	fake.unownedLeasesMutex.RLock()
+	defer fake.unownedLeasesMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeLeaseStore) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ creds.LeaseStore = new(FakeLeaseStore)
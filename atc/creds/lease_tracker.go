@@ -0,0 +1,434 @@
+package creds
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/hashicorp/go-multierror"
+)
+
+// A Lease is held on a dynamic secret, e.g. database credentials generated
+// by Vault. It is only valid for Duration unless it is renewed.
+type Lease struct {
+	ID        string
+	Duration  time.Duration
+	Renewable bool
+}
+
+//go:generate counterfeiter . Leaser
+
+// A Leaser renews and revokes the leases held on dynamic secrets.
+type Leaser interface {
+	RenewLease(Lease) (Lease, error)
+	RevokeLease(leaseID string) error
+}
+
+//go:generate counterfeiter . LeaseStore
+
+// A LeaseStore remembers which build acquired which lease, so that the leases
+// can still be revoked if the ATC holding them goes away mid-build.
+type LeaseStore interface {
+	CreateLease(buildID int, leaseID string) error
+	BuildLeases(buildID int) ([]string, error)
+	DeleteLease(leaseID string) error
+
+	// UnownedLeases returns the leases acquired outside of a build before
+	// the given time.
+	UnownedLeases(createdBefore time.Time) ([]string, error)
+}
+
+//go:generate counterfeiter . LeaseReleaser
+
+// A LeaseReleaser revokes the leases held by a build once it is done.
+type LeaseReleaser interface {
+	Release(buildID int) error
+}
+
+// BuildSecrets is implemented by Secrets which can hand out secrets whose
+// lifetime is tied to a build.
+type BuildSecrets interface {
+	// ForBuild returns Secrets which acquire leases on behalf of the build.
+	ForBuild(buildID int) Secrets
+}
+
+// ForBuild scopes the secrets to the given build, if supported.
+func ForBuild(secrets Secrets, buildID int) Secrets {
+	if buildSecrets, ok := secrets.(BuildSecrets); ok {
+		return buildSecrets.ForBuild(buildID)
+	}
+
+	return secrets
+}
+
+// VarSourcePoolForBuild scopes the var sources found in the pool to the given
+// build, so that the leases they acquire are held by it too.
+func VarSourcePoolForBuild(pool VarSourcePool, buildID int) VarSourcePool {
+	return buildVarSourcePool{
+		VarSourcePool: pool,
+		buildID:       buildID,
+	}
+}
+
+type buildVarSourcePool struct {
+	VarSourcePool
+
+	buildID int
+}
+
+func (pool buildVarSourcePool) FindOrCreate(logger lager.Logger, teamName string, config map[string]interface{}, factory ManagerFactory) (Secrets, error) {
+	secrets, err := pool.VarSourcePool.FindOrCreate(logger, teamName, config, factory)
+	if err != nil {
+		return nil, err
+	}
+
+	return ForBuild(secrets, pool.buildID), nil
+}
+
+// UnownedLeaseHold is how long the leases on dynamic secrets read outside of
+// a build (e.g. by a resource check) are held before being revoked. There is
+// no build whose end they could be tied to, so they are held for as long as a
+// check may run by default.
+const UnownedLeaseHold = time.Hour
+
+// LeaseTrackingInterval is how often the leases which are due are renewed or
+// revoked.
+const LeaseTrackingInterval = 10 * time.Second
+
+// leaseRenewalRetryInterval is how long to wait before retrying a failed
+// renewal, for as long as the lease is still valid.
+const leaseRenewalRetryInterval = time.Minute
+
+// The LeaseTracker keeps the leases acquired by a build alive for as long as
+// the build is running, and revokes them once it is released. Leases acquired
+// outside of a build (i.e. by build 0) are revoked once UnownedLeaseHold has
+// passed.
+//
+// A single LeaseTracker is shared by every credential manager, including the
+// ones configured through var sources, each of them tracking its leases
+// along with the Leaser to renew and revoke them through. The leases are all
+// renewed and revoked by one goroutine, and the secret of a lease is reused
+// by further reads of the same build until it is halfway through its
+// lifetime.
+type LeaseTracker struct {
+	logger lager.Logger
+	store  LeaseStore
+	clock  clock.Clock
+
+	storedLeaser Leaser
+
+	leasesL sync.Mutex
+	leases  map[string]*trackedLease
+	secrets map[leaseKey]*trackedLease
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+type leaseKey struct {
+	buildID int
+	leaser  Leaser
+	path    string
+}
+
+type trackedLease struct {
+	key    leaseKey
+	lease  Lease
+	secret interface{}
+	stored bool
+
+	expiry     time.Time
+	reuseUntil time.Time
+
+	// renewAt is zero once the lease is no longer renewed, and revokeAt is
+	// zero for leases which are revoked once their build is released
+	renewAt  time.Time
+	revokeAt time.Time
+}
+
+func NewLeaseTracker(logger lager.Logger, store LeaseStore, clock clock.Clock) *LeaseTracker {
+	tracker := &LeaseTracker{
+		logger: logger,
+		store:  store,
+		clock:  clock,
+
+		leases:  map[string]*trackedLease{},
+		secrets: map[leaseKey]*trackedLease{},
+
+		closed: make(chan struct{}),
+	}
+
+	go tracker.trackLoop(LeaseTrackingInterval)
+
+	return tracker
+}
+
+// StoreLeasesOf records the leases acquired through the given Leaser in the
+// LeaseStore, so that they are revoked through it even if the ATC holding
+// them goes away. This is only done for the global credential manager, as the
+// ones configured through var sources may not be around by then; their
+// leases are left to expire instead.
+func (t *LeaseTracker) StoreLeasesOf(leaser Leaser) {
+	t.leasesL.Lock()
+	t.storedLeaser = leaser
+	t.leasesL.Unlock()
+}
+
+// Find returns the secret of a lease the build already holds on the secret
+// at the given path, as long as the lease is not halfway through its
+// lifetime.
+func (t *LeaseTracker) Find(buildID int, leaser Leaser, path string) (interface{}, bool) {
+	t.leasesL.Lock()
+	defer t.leasesL.Unlock()
+
+	tracked, found := t.secrets[leaseKey{buildID, leaser, path}]
+	if !found || !t.clock.Now().Before(tracked.reuseUntil) {
+		return nil, false
+	}
+
+	return tracked.secret, true
+}
+
+// Track records the lease on the secret at the given path as held by the
+// build and, if the lease is renewable, keeps renewing it until the build is
+// released.
+func (t *LeaseTracker) Track(buildID int, leaser Leaser, path string, lease Lease, secret interface{}) error {
+	t.leasesL.Lock()
+	stored := t.storedLeaser != nil && leaser == t.storedLeaser
+	t.leasesL.Unlock()
+
+	if stored {
+		err := t.store.CreateLease(buildID, lease.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	now := t.clock.Now()
+
+	tracked := &trackedLease{
+		key:    leaseKey{buildID, leaser, path},
+		lease:  lease,
+		secret: secret,
+		stored: stored,
+		expiry: now.Add(lease.Duration),
+	}
+
+	if buildID == 0 {
+		tracked.revokeAt = now.Add(UnownedLeaseHold)
+	} else if lease.Renewable && lease.Duration > 0 {
+		tracked.renewAt = now.Add(lease.Duration / 2)
+	}
+
+	tracked.extendReuse(now)
+
+	t.leasesL.Lock()
+	t.leases[lease.ID] = tracked
+	t.secrets[tracked.key] = tracked
+	t.leasesL.Unlock()
+
+	return nil
+}
+
+// Release stops renewing the leases held by the build and revokes them, along
+// with any leases recorded in the LeaseStore for it. For build 0, only the
+// stored leases held for longer than UnownedLeaseHold are revoked, the ones
+// tracked here being revoked as they come due. Every lease is attempted even
+// if revoking some of them fails; stored ones are kept around so that they can
+// be revoked later.
+func (t *LeaseTracker) Release(buildID int) error {
+	var released []*trackedLease
+
+	t.leasesL.Lock()
+	if buildID != 0 {
+		for _, tracked := range t.leases {
+			if tracked.key.buildID == buildID {
+				released = append(released, tracked)
+				t.forget(tracked)
+			}
+		}
+	}
+	storedLeaser := t.storedLeaser
+	t.leasesL.Unlock()
+
+	var errs error
+
+	attempted := map[string]bool{}
+	for _, tracked := range released {
+		attempted[tracked.lease.ID] = true
+
+		err := t.revoke(tracked.key.leaser, tracked.lease.ID, tracked.stored)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	if storedLeaser == nil {
+		return errs
+	}
+
+	var (
+		leaseIDs []string
+		err      error
+	)
+
+	if buildID == 0 {
+		leaseIDs, err = t.store.UnownedLeases(t.clock.Now().Add(-UnownedLeaseHold))
+	} else {
+		leaseIDs, err = t.store.BuildLeases(buildID)
+	}
+	if err != nil {
+		return multierror.Append(errs, err)
+	}
+
+	for _, leaseID := range leaseIDs {
+		if attempted[leaseID] || t.isTracked(leaseID) {
+			continue
+		}
+
+		err := t.revoke(storedLeaser, leaseID, true)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// Close stops renewing and revoking the leases as they come due.
+func (t *LeaseTracker) Close() {
+	t.closeOnce.Do(func() {
+		close(t.closed)
+	})
+}
+
+func (t *LeaseTracker) trackLoop(interval time.Duration) {
+	ticker := t.clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.closed:
+			return
+		case <-ticker.C():
+			t.tick()
+		}
+	}
+}
+
+func (t *LeaseTracker) tick() {
+	now := t.clock.Now()
+
+	var revoking, renewing []*trackedLease
+
+	t.leasesL.Lock()
+	for _, tracked := range t.leases {
+		if !tracked.revokeAt.IsZero() && !now.Before(tracked.revokeAt) {
+			revoking = append(revoking, tracked)
+			t.forget(tracked)
+		} else if !tracked.renewAt.IsZero() && !now.Before(tracked.renewAt) {
+			renewing = append(renewing, tracked)
+		}
+	}
+	t.leasesL.Unlock()
+
+	for _, tracked := range revoking {
+		// if this fails a stored lease is left to the secret leases collector
+		err := t.revoke(tracked.key.leaser, tracked.lease.ID, tracked.stored)
+		if err != nil {
+			t.logger.Error("failed-to-revoke-lease", err, lager.Data{"lease": tracked.lease.ID})
+		}
+	}
+
+	for _, tracked := range renewing {
+		t.renew(tracked)
+	}
+}
+
+func (t *LeaseTracker) renew(tracked *trackedLease) {
+	logger := t.logger.Session("renew-lease", lager.Data{
+		"build": tracked.key.buildID,
+		"lease": tracked.lease.ID,
+	})
+
+	renewed, err := tracked.key.leaser.RenewLease(tracked.lease)
+
+	t.leasesL.Lock()
+	defer t.leasesL.Unlock()
+
+	now := t.clock.Now()
+
+	if err != nil {
+		logger.Error("failed-to-renew-lease", err)
+
+		tracked.renewAt = now.Add(leaseRenewalRetryInterval)
+		if tracked.renewAt.After(tracked.expiry) {
+			tracked.renewAt = time.Time{}
+		}
+
+		return
+	}
+
+	tracked.lease = renewed
+	tracked.expiry = now.Add(renewed.Duration)
+	tracked.extendReuse(now)
+
+	if !renewed.Renewable || renewed.Duration <= 0 {
+		logger.Debug("lease-no-longer-renewable")
+		tracked.renewAt = time.Time{}
+		return
+	}
+
+	// renew halfway through the lease so that a slow renewal does not leave
+	// the build with revoked credentials
+	tracked.renewAt = now.Add(renewed.Duration / 2)
+}
+
+func (t *LeaseTracker) revoke(leaser Leaser, leaseID string, stored bool) error {
+	err := leaser.RevokeLease(leaseID)
+	if err != nil {
+		return fmt.Errorf("revoke lease %s: %w", leaseID, err)
+	}
+
+	if !stored {
+		return nil
+	}
+
+	err = t.store.DeleteLease(leaseID)
+	if err != nil {
+		return fmt.Errorf("delete lease %s: %w", leaseID, err)
+	}
+
+	return nil
+}
+
+func (t *LeaseTracker) isTracked(leaseID string) bool {
+	t.leasesL.Lock()
+	defer t.leasesL.Unlock()
+
+	_, found := t.leases[leaseID]
+	return found
+}
+
+// forget must be called with leasesL held.
+func (t *LeaseTracker) forget(tracked *trackedLease) {
+	delete(t.leases, tracked.lease.ID)
+
+	if t.secrets[tracked.key] == tracked {
+		delete(t.secrets, tracked.key)
+	}
+}
+
+// extendReuse lets the secret be reused until the lease is halfway through
+// the rest of its lifetime, so that whoever reuses it still gets to hold it
+// for a while.
+func (tracked *trackedLease) extendReuse(now time.Time) {
+	end := tracked.expiry
+	if !tracked.revokeAt.IsZero() && tracked.revokeAt.Before(end) {
+		end = tracked.revokeAt
+	}
+
+	tracked.reuseUntil = now.Add(end.Sub(now) / 2)
+}
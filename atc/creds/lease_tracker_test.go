@@ -0,0 +1,351 @@
+package creds_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LeaseTracker", func() {
+	var (
+		fakeLeaser     *credsfakes.FakeLeaser
+		fakeLeaseStore *credsfakes.FakeLeaseStore
+		fakeClock      *fakeclock.FakeClock
+
+		tracker *creds.LeaseTracker
+	)
+
+	BeforeEach(func() {
+		fakeLeaser = new(credsfakes.FakeLeaser)
+		fakeLeaseStore = new(credsfakes.FakeLeaseStore)
+		fakeClock = fakeclock.NewFakeClock(time.Now())
+
+		tracker = creds.NewLeaseTracker(lagertest.NewTestLogger("test"), fakeLeaseStore, fakeClock)
+		tracker.StoreLeasesOf(fakeLeaser)
+	})
+
+	AfterEach(func() {
+		tracker.Close()
+	})
+
+	// tick lets the tracker catch up with the given amount of time
+	tick := func(d time.Duration) {
+		fakeClock.WaitForWatcherAndIncrement(d)
+	}
+
+	Describe("Track", func() {
+		var (
+			lease    creds.Lease
+			buildID  int
+			trackErr error
+		)
+
+		BeforeEach(func() {
+			lease = creds.Lease{
+				ID:        "database/creds/readonly/some-lease",
+				Duration:  time.Hour,
+				Renewable: true,
+			}
+
+			buildID = 42
+		})
+
+		JustBeforeEach(func() {
+			trackErr = tracker.Track(buildID, fakeLeaser, "/some/path", lease, "some-secret")
+		})
+
+		It("stores the lease for the build", func() {
+			Expect(trackErr).ToNot(HaveOccurred())
+			Expect(fakeLeaseStore.CreateLeaseCallCount()).To(Equal(1))
+
+			buildID, leaseID := fakeLeaseStore.CreateLeaseArgsForCall(0)
+			Expect(buildID).To(Equal(42))
+			Expect(leaseID).To(Equal("database/creds/readonly/some-lease"))
+		})
+
+		It("lets the build reuse the secret until the lease is halfway through", func() {
+			secret, found := tracker.Find(42, fakeLeaser, "/some/path")
+			Expect(found).To(BeTrue())
+			Expect(secret).To(Equal("some-secret"))
+
+			_, found = tracker.Find(43, fakeLeaser, "/some/path")
+			Expect(found).To(BeFalse())
+
+			_, found = tracker.Find(42, new(credsfakes.FakeLeaser), "/some/path")
+			Expect(found).To(BeFalse())
+
+			fakeClock.Increment(30 * time.Minute)
+
+			_, found = tracker.Find(42, fakeLeaser, "/some/path")
+			Expect(found).To(BeFalse())
+		})
+
+		It("renews the lease halfway through its duration", func() {
+			fakeLeaser.RenewLeaseReturns(lease, nil)
+
+			tick(30 * time.Minute)
+			Eventually(fakeLeaser.RenewLeaseCallCount).Should(Equal(1))
+			Expect(fakeLeaser.RenewLeaseArgsForCall(0)).To(Equal(lease))
+
+			tick(creds.LeaseTrackingInterval)
+			Consistently(fakeLeaser.RenewLeaseCallCount).Should(Equal(1))
+
+			tick(30 * time.Minute)
+			Eventually(fakeLeaser.RenewLeaseCallCount).Should(Equal(2))
+		})
+
+		It("lets the secret be reused again once the lease is renewed", func() {
+			fakeLeaser.RenewLeaseReturns(lease, nil)
+
+			tick(30 * time.Minute)
+			Eventually(fakeLeaser.RenewLeaseCallCount).Should(Equal(1))
+
+			Eventually(func() bool {
+				_, found := tracker.Find(42, fakeLeaser, "/some/path")
+				return found
+			}).Should(BeTrue())
+		})
+
+		Context("when the lease is not renewable", func() {
+			BeforeEach(func() {
+				lease.Renewable = false
+			})
+
+			It("does not renew it", func() {
+				tick(time.Hour)
+				Consistently(fakeLeaser.RenewLeaseCallCount).Should(BeZero())
+			})
+		})
+
+		It("stops renewing the lease once the build is released", func() {
+			Expect(tracker.Release(42)).To(Succeed())
+
+			_, found := tracker.Find(42, fakeLeaser, "/some/path")
+			Expect(found).To(BeFalse())
+
+			tick(time.Hour)
+			Consistently(fakeLeaser.RenewLeaseCallCount).Should(BeZero())
+		})
+
+		Context("when renewing the lease fails", func() {
+			BeforeEach(func() {
+				fakeLeaser.RenewLeaseReturnsOnCall(0, creds.Lease{}, errors.New("nope"))
+				fakeLeaser.RenewLeaseReturnsOnCall(1, lease, nil)
+			})
+
+			It("retries while the lease is still valid", func() {
+				tick(30 * time.Minute)
+				Eventually(fakeLeaser.RenewLeaseCallCount).Should(Equal(1))
+
+				tick(time.Minute)
+				Eventually(fakeLeaser.RenewLeaseCallCount).Should(Equal(2))
+			})
+		})
+
+		Context("when the lease is acquired outside of a build", func() {
+			BeforeEach(func() {
+				buildID = 0
+			})
+
+			It("stores the lease as unowned", func() {
+				Expect(trackErr).ToNot(HaveOccurred())
+
+				buildID, _ := fakeLeaseStore.CreateLeaseArgsForCall(0)
+				Expect(buildID).To(BeZero())
+			})
+
+			It("lets other reads outside of a build reuse the secret", func() {
+				secret, found := tracker.Find(0, fakeLeaser, "/some/path")
+				Expect(found).To(BeTrue())
+				Expect(secret).To(Equal("some-secret"))
+			})
+
+			It("revokes the lease once it has been held for long enough", func() {
+				tick(creds.UnownedLeaseHold - creds.LeaseTrackingInterval)
+				Consistently(fakeLeaser.RevokeLeaseCallCount).Should(BeZero())
+
+				tick(creds.LeaseTrackingInterval)
+				Eventually(fakeLeaser.RevokeLeaseCallCount).Should(Equal(1))
+				Expect(fakeLeaser.RevokeLeaseArgsForCall(0)).To(Equal("database/creds/readonly/some-lease"))
+				Eventually(fakeLeaseStore.DeleteLeaseCallCount).Should(Equal(1))
+
+				Expect(fakeLeaser.RenewLeaseCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the lease is acquired through another leaser", func() {
+			var otherLeaser *credsfakes.FakeLeaser
+
+			BeforeEach(func() {
+				otherLeaser = new(credsfakes.FakeLeaser)
+			})
+
+			JustBeforeEach(func() {
+				trackErr = tracker.Track(43, otherLeaser, "/some/path", lease, "other-secret")
+			})
+
+			It("does not store it, as it could not be revoked once the leaser is gone", func() {
+				Expect(trackErr).ToNot(HaveOccurred())
+				Expect(fakeLeaseStore.CreateLeaseCallCount()).To(Equal(1))
+			})
+
+			It("renews and revokes it through that leaser", func() {
+				otherLeaser.RenewLeaseReturns(lease, nil)
+				fakeLeaser.RenewLeaseReturns(lease, nil)
+
+				tick(30 * time.Minute)
+				Eventually(otherLeaser.RenewLeaseCallCount).Should(Equal(1))
+
+				Expect(tracker.Release(43)).To(Succeed())
+				Expect(otherLeaser.RevokeLeaseCallCount()).To(Equal(1))
+				Expect(fakeLeaser.RevokeLeaseCallCount()).To(BeZero())
+				Expect(fakeLeaseStore.DeleteLeaseCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when storing the lease fails", func() {
+			BeforeEach(func() {
+				fakeLeaseStore.CreateLeaseReturns(errors.New("nope"))
+			})
+
+			It("returns the error", func() {
+				Expect(trackErr).To(MatchError("nope"))
+			})
+
+			It("does not let the secret be reused", func() {
+				_, found := tracker.Find(42, fakeLeaser, "/some/path")
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("Release", func() {
+		var releaseErr error
+
+		BeforeEach(func() {
+			fakeLeaseStore.BuildLeasesReturns([]string{"lease-1", "lease-2"}, nil)
+		})
+
+		JustBeforeEach(func() {
+			releaseErr = tracker.Release(42)
+		})
+
+		It("revokes and forgets all leases of the build", func() {
+			Expect(releaseErr).ToNot(HaveOccurred())
+			Expect(fakeLeaseStore.BuildLeasesArgsForCall(0)).To(Equal(42))
+
+			Expect(fakeLeaser.RevokeLeaseCallCount()).To(Equal(2))
+			Expect(fakeLeaser.RevokeLeaseArgsForCall(0)).To(Equal("lease-1"))
+			Expect(fakeLeaser.RevokeLeaseArgsForCall(1)).To(Equal("lease-2"))
+
+			Expect(fakeLeaseStore.DeleteLeaseCallCount()).To(Equal(2))
+			Expect(fakeLeaseStore.DeleteLeaseArgsForCall(0)).To(Equal("lease-1"))
+			Expect(fakeLeaseStore.DeleteLeaseArgsForCall(1)).To(Equal("lease-2"))
+		})
+
+		Context("when the build holds tracked leases", func() {
+			BeforeEach(func() {
+				fakeLeaseStore.BuildLeasesReturns([]string{"lease-1"}, nil)
+
+				err := tracker.Track(42, fakeLeaser, "/some/path", creds.Lease{ID: "lease-1", Duration: time.Hour}, "some-secret")
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("only revokes each lease once", func() {
+				Expect(releaseErr).ToNot(HaveOccurred())
+				Expect(fakeLeaser.RevokeLeaseCallCount()).To(Equal(1))
+				Expect(fakeLeaser.RevokeLeaseArgsForCall(0)).To(Equal("lease-1"))
+			})
+		})
+
+		Context("when revoking a lease fails", func() {
+			BeforeEach(func() {
+				fakeLeaser.RevokeLeaseReturnsOnCall(0, errors.New("nope"))
+			})
+
+			It("keeps the lease around so that it can be revoked later", func() {
+				Expect(releaseErr).To(HaveOccurred())
+				Expect(releaseErr.Error()).To(ContainSubstring("revoke lease lease-1: nope"))
+			})
+
+			It("still revokes the other leases", func() {
+				Expect(fakeLeaser.RevokeLeaseCallCount()).To(Equal(2))
+				Expect(fakeLeaseStore.DeleteLeaseCallCount()).To(Equal(1))
+				Expect(fakeLeaseStore.DeleteLeaseArgsForCall(0)).To(Equal("lease-2"))
+			})
+		})
+
+		Context("when releasing the leases acquired outside of a build", func() {
+			BeforeEach(func() {
+				fakeLeaseStore.UnownedLeasesReturns([]string{"lease-3"}, nil)
+			})
+
+			JustBeforeEach(func() {
+				releaseErr = tracker.Release(0)
+			})
+
+			It("only revokes the leases held for long enough", func() {
+				Expect(releaseErr).ToNot(HaveOccurred())
+				Expect(fakeLeaseStore.UnownedLeasesArgsForCall(0)).To(Equal(fakeClock.Now().Add(-creds.UnownedLeaseHold)))
+
+				Expect(fakeLeaser.RevokeLeaseArgsForCall(2)).To(Equal("lease-3"))
+			})
+		})
+	})
+})
+
+var _ = Describe("VarSourcePoolForBuild", func() {
+	var (
+		fakeVarSourcePool *credsfakes.FakeVarSourcePool
+		fakeSecrets       *credsfakes.FakeSecrets
+	)
+
+	BeforeEach(func() {
+		fakeVarSourcePool = new(credsfakes.FakeVarSourcePool)
+		fakeSecrets = new(credsfakes.FakeSecrets)
+	})
+
+	It("scopes the var sources to the build", func() {
+		fakeVarSourcePool.FindOrCreateReturns(buildScopedSecrets{Secrets: fakeSecrets}, nil)
+
+		secrets, err := creds.VarSourcePoolForBuild(fakeVarSourcePool, 42).FindOrCreate(lagertest.NewTestLogger("test"), "some-team", nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secrets).To(Equal(buildScopedSecrets{Secrets: fakeSecrets, buildID: 42}))
+	})
+
+	It("leaves var sources which can't be scoped to a build as they are", func() {
+		fakeVarSourcePool.FindOrCreateReturns(fakeSecrets, nil)
+
+		secrets, err := creds.VarSourcePoolForBuild(fakeVarSourcePool, 42).FindOrCreate(lagertest.NewTestLogger("test"), "some-team", nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secrets).To(Equal(fakeSecrets))
+	})
+
+	Context("when finding the var source fails", func() {
+		BeforeEach(func() {
+			fakeVarSourcePool.FindOrCreateReturns(nil, errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			_, err := creds.VarSourcePoolForBuild(fakeVarSourcePool, 42).FindOrCreate(lagertest.NewTestLogger("test"), "some-team", nil, nil)
+			Expect(err).To(MatchError("nope"))
+		})
+	})
+})
+
+type buildScopedSecrets struct {
+	creds.Secrets
+
+	buildID int
+}
+
+func (secrets buildScopedSecrets) ForBuild(buildID int) creds.Secrets {
+	return buildScopedSecrets{Secrets: secrets.Secrets, buildID: buildID}
+}
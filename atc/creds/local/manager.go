@@ -0,0 +1,246 @@
+package local
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"filippo.io/age"
+
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/mitchellh/mapstructure"
+)
+
+type Manager struct {
+	Path            string        `mapstructure:"path" long:"path" description:"Path to a directory tree or a (SOPS-encrypted) YAML file containing secrets."`
+	PathPrefix      string        `mapstructure:"path_prefix" long:"path-prefix" default:"/concourse" description:"Path under which to namespace credential lookup."`
+	LookupTemplates []string      `mapstructure:"lookup_templates" long:"lookup-templates" default:"/{{.Team}}/{{.Pipeline}}/{{.Secret}}" default:"/{{.Team}}/{{.Secret}}" description:"Path templates for credential lookup"`
+	SharedPath      string        `mapstructure:"shared_path" long:"shared-path" description:"Path under which to lookup shared credentials."`
+	AgeIdentityFile string        `mapstructure:"-" long:"age-identity-file" description:"Path to a file containing the age identities used to decrypt SOPS-encrypted secrets files. If not set, SOPS reads them from the file at $SOPS_AGE_KEY_FILE."`
+	ReloadInterval  time.Duration `mapstructure:"reload_interval" long:"reload-interval" default:"10s" description:"Interval on which to check the secrets for changes on disk."`
+	VarSourceRoot   string        `mapstructure:"-" long:"var-source-root" description:"Directory under which pipelines may read secrets using 'local' var sources, from the directory named after their team. If not set, 'local' var sources are disallowed."`
+
+	Store *Store
+
+	// managers created for var sources may only read secrets beneath the
+	// directory of their team in the var source root configured for the ATC
+	varSource     bool
+	varSourceRoot string
+	team          string
+	teamRoot      string
+
+	stop chan struct{}
+}
+
+func (manager *Manager) Config(config map[string]interface{}) error {
+	// apply defaults
+	manager.PathPrefix = "/concourse"
+	manager.ReloadInterval = 10 * time.Second
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:  mapstructure.StringToTimeDurationHookFunc(),
+		ErrorUnused: true,
+		Result:      &manager,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = decoder.Decode(config)
+	if err != nil {
+		return err
+	}
+
+	if _, setsTemplates := config["lookup_templates"]; !setsTemplates {
+		manager.LookupTemplates = []string{
+			"/{{.Team}}/{{.Pipeline}}/{{.Secret}}",
+			"/{{.Team}}/{{.Secret}}",
+		}
+	}
+
+	return nil
+}
+
+func (manager *Manager) Init(log lager.Logger) error {
+	identities, err := manager.ageIdentities()
+	if err != nil {
+		log.Error("failed-to-read-age-identities", err)
+		return err
+	}
+
+	var store *Store
+
+	if manager.varSource {
+		err = manager.checkVarSourcePath()
+		if err != nil {
+			return err
+		}
+
+		store, err = NewConfinedStore(manager.Path, manager.teamRoot, identities)
+	} else {
+		store, err = NewStore(manager.Path, identities)
+	}
+	if err != nil {
+		log.Error("failed-to-load-secrets", err)
+		return err
+	}
+
+	manager.Store = store
+
+	return nil
+}
+
+// ageIdentities reads the identities of the age identity file, if any.
+func (manager *Manager) ageIdentities() ([]age.Identity, error) {
+	if manager.AgeIdentityFile == "" {
+		return nil, nil
+	}
+
+	file, err := os.Open(manager.AgeIdentityFile)
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	identities, err := age.ParseIdentities(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse age identity file: %w", err)
+	}
+
+	return identities, nil
+}
+
+func (manager *Manager) MarshalJSON() ([]byte, error) {
+	health, err := manager.Health()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&map[string]interface{}{
+		"path":             manager.Path,
+		"path_prefix":      manager.PathPrefix,
+		"lookup_templates": manager.LookupTemplates,
+		"shared_path":      manager.SharedPath,
+		"reload_interval":  manager.ReloadInterval,
+		"health":           health,
+	})
+}
+
+func (manager Manager) IsConfigured() bool {
+	return manager.Path != ""
+}
+
+func (manager Manager) Validate() error {
+	if manager.Path == "" {
+		return errors.New("must configure path")
+	}
+
+	if manager.PathPrefix == "" {
+		return errors.New("path prefix must be a non-empty string")
+	}
+
+	if manager.ReloadInterval <= 0 {
+		return errors.New("reload interval must be positive")
+	}
+
+	for i, tmpl := range manager.LookupTemplates {
+		name := fmt.Sprintf("lookup-template-%d", i)
+		if _, err := creds.BuildSecretTemplate(name, manager.PathPrefix+tmpl); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (manager Manager) Health() (*creds.HealthResponse, error) {
+	health := &creds.HealthResponse{
+		Method: "Stat",
+	}
+
+	_, err := os.Stat(manager.Path)
+	if err != nil {
+		health.Error = err.Error()
+		return health, nil
+	}
+
+	health.Response = map[string]string{
+		"status": "UP",
+	}
+
+	return health, nil
+}
+
+func (manager *Manager) NewSecretsFactory(logger lager.Logger) (creds.SecretsFactory, error) {
+	templates := []*creds.SecretTemplate{}
+	for i, tmpl := range manager.LookupTemplates {
+		name := fmt.Sprintf("lookup-template-%d", i)
+		scopedTemplate := path.Join(manager.PathPrefix, tmpl)
+		template, err := creds.BuildSecretTemplate(name, scopedTemplate)
+		if err != nil {
+			return nil, err
+		}
+
+		templates = append(templates, template)
+	}
+
+	if manager.stop == nil {
+		manager.stop = make(chan struct{})
+		go manager.Store.Watch(logger.Session("watch"), clock.NewClock(), manager.ReloadInterval, manager.stop)
+	}
+
+	return NewSecretsFactory(manager.Store, manager.PathPrefix, templates, manager.SharedPath), nil
+}
+
+func (manager *Manager) Close(logger lager.Logger) {
+	if manager.stop != nil {
+		close(manager.stop)
+		manager.stop = nil
+	}
+}
+
+func (manager *Manager) checkVarSourcePath() error {
+	if manager.varSourceRoot == "" {
+		return errors.New("local var sources are not allowed by this Concourse")
+	}
+
+	if manager.team == "" || manager.team == "." || manager.team == ".." || filepath.Base(manager.team) != manager.team {
+		return fmt.Errorf("invalid team for local var source: '%s'", manager.team)
+	}
+
+	root, err := filepath.Abs(filepath.Join(manager.varSourceRoot, manager.team))
+	if err != nil {
+		return err
+	}
+
+	secretsPath, err := filepath.Abs(filepath.Join(root, manager.Path))
+	if err != nil {
+		return err
+	}
+
+	secretsPath, err = filepath.EvalSymlinks(secretsPath)
+	if err != nil {
+		return err
+	}
+
+	root, err = filepath.EvalSymlinks(root)
+	if err != nil {
+		return err
+	}
+
+	if !isWithin(secretsPath, root) {
+		return fmt.Errorf("path %s is outside of the var source root of team '%s'", manager.Path, manager.team)
+	}
+
+	manager.Path = secretsPath
+	manager.teamRoot = root
+
+	return nil
+}
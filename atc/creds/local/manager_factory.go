@@ -0,0 +1,67 @@
+package local
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/atc/creds"
+	flags "github.com/jessevdk/go-flags"
+)
+
+type managerFactory struct {
+	// the manager configured through flags, which decides where var sources
+	// may read secrets from
+	flags *Manager
+}
+
+func init() {
+	creds.Register("local", NewManagerFactory())
+}
+
+func NewManagerFactory() creds.ManagerFactory {
+	return &managerFactory{}
+}
+
+func (factory *managerFactory) AddConfig(group *flags.Group) creds.Manager {
+	manager := &Manager{}
+
+	subGroup, err := group.AddGroup("Local Credential Management", "", manager)
+	if err != nil {
+		panic(err)
+	}
+
+	subGroup.Namespace = "local-creds"
+
+	factory.flags = manager
+
+	return manager
+}
+
+// NewInstance creates a manager for a var source that does not belong to any
+// team, which can't read any secrets but can be validated.
+func (factory *managerFactory) NewInstance(config interface{}) (creds.Manager, error) {
+	return factory.NewTeamInstance("", config)
+}
+
+// NewTeamInstance creates a manager for a var source of a pipeline of the
+// team, which may only read secrets beneath the directory of the team in the
+// var source root.
+func (factory *managerFactory) NewTeamInstance(teamName string, config interface{}) (creds.Manager, error) {
+	c, ok := config.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid local credential manager config format")
+	}
+
+	manager := &Manager{varSource: true, team: teamName}
+
+	err := manager.Config(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if factory.flags != nil {
+		manager.varSourceRoot = factory.flags.VarSourceRoot
+		manager.AgeIdentityFile = factory.flags.AgeIdentityFile
+	}
+
+	return manager, nil
+}
@@ -0,0 +1,249 @@
+package local_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"filippo.io/age"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/local"
+	"github.com/concourse/concourse/vars"
+	flags "github.com/jessevdk/go-flags"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manager", func() {
+	var (
+		tmpDir  string
+		manager *local.Manager
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "local-creds")
+		Expect(err).ToNot(HaveOccurred())
+
+		path := filepath.Join(tmpDir, "secrets", "concourse", "team")
+		Expect(os.MkdirAll(filepath.Join(path, "pipeline"), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(path, "foo"), []byte("team-foo"), 0600)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(path, "pipeline", "foo"), []byte("pipeline-foo"), 0600)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(path, "bar"), []byte("team-bar"), 0600)).To(Succeed())
+
+		manager = &local.Manager{}
+		_, err = flags.ParseArgs(manager, []string{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	Describe("IsConfigured()", func() {
+		It("fails on an empty Manager", func() {
+			Expect(manager.IsConfigured()).To(BeFalse())
+		})
+
+		It("passes if Path is set", func() {
+			manager.Path = tmpDir
+			Expect(manager.IsConfigured()).To(BeTrue())
+		})
+	})
+
+	Describe("Validate()", func() {
+		BeforeEach(func() {
+			manager.Path = tmpDir
+		})
+
+		It("passes on default parameters", func() {
+			Expect(manager.Validate()).To(Succeed())
+		})
+
+		It("fails on an invalid lookup template", func() {
+			manager.LookupTemplates = []string{"/{{.Teem}}/{{.Secret}}"}
+			Expect(manager.Validate()).ToNot(Succeed())
+		})
+
+		It("fails on an empty path prefix", func() {
+			manager.PathPrefix = ""
+			Expect(manager.Validate()).ToNot(Succeed())
+		})
+	})
+
+	Describe("NewSecretsFactory()", func() {
+		var variables vars.Variables
+
+		BeforeEach(func() {
+			manager.Path = filepath.Join(tmpDir, "secrets")
+
+			logger := lagertest.NewTestLogger("test")
+			Expect(manager.Init(logger)).To(Succeed())
+
+			factory, err := manager.NewSecretsFactory(logger)
+			Expect(err).ToNot(HaveOccurred())
+
+			variables = creds.NewVariables(factory.NewSecrets(), "team", "pipeline", false)
+		})
+
+		AfterEach(func() {
+			manager.Close(lagertest.NewTestLogger("test"))
+		})
+
+		It("looks up pipeline secrets first", func() {
+			value, found, err := variables.Get(vars.VariableDefinition{Ref: vars.VariableReference{Path: "foo"}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("pipeline-foo"))
+		})
+
+		It("falls back to team secrets", func() {
+			value, found, err := variables.Get(vars.VariableDefinition{Ref: vars.VariableReference{Path: "bar"}})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("team-bar"))
+		})
+	})
+
+	Describe("Init()", func() {
+		var identity *age.X25519Identity
+
+		BeforeEach(func() {
+			var err error
+			identity, err = age.GenerateX25519Identity()
+			Expect(err).ToNot(HaveOccurred())
+
+			manager.Path = filepath.Join(tmpDir, "secrets.yml")
+			manager.AgeIdentityFile = filepath.Join(tmpDir, "keys.txt")
+
+			encrypted := sopsEncrypt("concourse:\n  team:\n    foo: sops-foo\n", identity.Recipient().String())
+			Expect(ioutil.WriteFile(manager.Path, []byte(encrypted), 0600)).To(Succeed())
+		})
+
+		It("decrypts the secrets with the identities of the age identity file", func() {
+			Expect(ioutil.WriteFile(manager.AgeIdentityFile, []byte(identity.String()+"\n"), 0600)).To(Succeed())
+			Expect(manager.Init(lagertest.NewTestLogger("test"))).To(Succeed())
+
+			value, found := manager.Store.Get("/concourse/team/foo")
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("sops-foo"))
+		})
+
+		Context("when the age identity file is invalid", func() {
+			BeforeEach(func() {
+				Expect(ioutil.WriteFile(manager.AgeIdentityFile, []byte("not-an-identity\n"), 0600)).To(Succeed())
+			})
+
+			It("fails", func() {
+				Expect(manager.Init(lagertest.NewTestLogger("test"))).To(MatchError(ContainSubstring("failed to parse age identity file")))
+			})
+		})
+	})
+
+	Describe("as a var source", func() {
+		var (
+			factory  creds.ManagerFactory
+			config   map[string]interface{}
+			teamName string
+			initErr  error
+		)
+
+		BeforeEach(func() {
+			factory = local.NewManagerFactory()
+			config = map[string]interface{}{"path": "secrets"}
+			teamName = "team"
+
+			for _, team := range []string{"team", "other-team"} {
+				path := filepath.Join(tmpDir, "var-sources", team, "secrets")
+				Expect(os.MkdirAll(path, 0755)).To(Succeed())
+				Expect(ioutil.WriteFile(filepath.Join(path, "foo"), []byte(team+"-foo"), 0600)).To(Succeed())
+			}
+		})
+
+		JustBeforeEach(func() {
+			instance, err := factory.(creds.TeamManagerFactory).NewTeamInstance(teamName, config)
+			Expect(err).ToNot(HaveOccurred())
+
+			initErr = instance.Init(lagertest.NewTestLogger("test"))
+		})
+
+		Context("when no var source root is configured", func() {
+			It("is disallowed", func() {
+				Expect(initErr).To(MatchError(ContainSubstring("not allowed")))
+			})
+		})
+
+		Context("when a var source root is configured", func() {
+			BeforeEach(func() {
+				group, err := flags.NewParser(&struct{}{}, flags.Default).AddGroup("creds", "", &struct{}{})
+				Expect(err).ToNot(HaveOccurred())
+
+				flagsManager := factory.AddConfig(group).(*local.Manager)
+				flagsManager.VarSourceRoot = filepath.Join(tmpDir, "var-sources")
+			})
+
+			It("reads secrets beneath the directory of the team", func() {
+				Expect(initErr).ToNot(HaveOccurred())
+			})
+
+			It("can't be initialized without a team", func() {
+				instance, err := factory.NewInstance(config)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(instance.Init(lagertest.NewTestLogger("test"))).To(MatchError(ContainSubstring("invalid team")))
+			})
+
+			Context("when the path escapes the root", func() {
+				BeforeEach(func() {
+					config["path"] = "../../"
+				})
+
+				It("is disallowed", func() {
+					Expect(initErr).To(MatchError(ContainSubstring("outside of the var source root")))
+				})
+			})
+
+			Context("when the path leads to the directory of another team", func() {
+				BeforeEach(func() {
+					config["path"] = "../other-team/secrets"
+				})
+
+				It("is disallowed", func() {
+					Expect(initErr).To(MatchError(ContainSubstring("outside of the var source root of team 'team'")))
+				})
+			})
+
+			Context("when the secrets link to the directory of another team", func() {
+				BeforeEach(func() {
+					Expect(os.Symlink(
+						filepath.Join(tmpDir, "var-sources", "other-team", "secrets"),
+						filepath.Join(tmpDir, "var-sources", "team", "secrets", "other"),
+					)).To(Succeed())
+				})
+
+				It("is disallowed", func() {
+					Expect(initErr).To(MatchError(ContainSubstring("links outside of")))
+				})
+			})
+
+			Context("when the team name is not a plain directory name", func() {
+				BeforeEach(func() {
+					teamName = "../team"
+				})
+
+				It("is disallowed", func() {
+					Expect(initErr).To(MatchError(ContainSubstring("invalid team")))
+				})
+			})
+		})
+
+		Context("with an unknown config key", func() {
+			It("fails to create the manager", func() {
+				_, err := factory.NewInstance(map[string]interface{}{"var_source_root": "/"})
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
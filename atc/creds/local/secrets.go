@@ -0,0 +1,51 @@
+package local
+
+import (
+	"path"
+	"time"
+
+	"github.com/concourse/concourse/atc/creds"
+)
+
+// Secrets looks up secrets in a Store, following the same lookup rules as
+// the Vault credential manager.
+type Secrets struct {
+	Store           *Store
+	Prefix          string
+	LookupTemplates []*creds.SecretTemplate
+	SharedPath      string
+}
+
+// NewSecretLookupPaths defines how variables will be searched in the underlying secret manager
+func (s Secrets) NewSecretLookupPaths(teamName string, pipelineName string, allowRootPath bool) []creds.SecretLookupPath {
+	lookupPaths := []creds.SecretLookupPath{}
+	for _, tmpl := range s.LookupTemplates {
+		if lPath := creds.NewSecretLookupWithTemplate(tmpl, teamName, pipelineName); lPath != nil {
+			lookupPaths = append(lookupPaths, lPath)
+		}
+	}
+	if s.SharedPath != "" {
+		lookupPaths = append(lookupPaths, creds.NewSecretLookupWithPrefix(path.Join(s.Prefix, s.SharedPath)+"/"))
+	}
+	if allowRootPath {
+		lookupPaths = append(lookupPaths, creds.NewSecretLookupWithPrefix(s.Prefix+"/"))
+	}
+	return lookupPaths
+}
+
+// Get retrieves the value of an individual secret. Secrets read from disk do
+// not expire; changes are picked up by reloading the store instead.
+func (s Secrets) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	secret, found := s.Store.Get(secretPath)
+	if !found {
+		return nil, nil, false, nil
+	}
+
+	if fields, ok := secret.(map[string]interface{}); ok {
+		if val, found := fields["value"]; found {
+			return val, nil, true, nil
+		}
+	}
+
+	return secret, nil, true, nil
+}
@@ -0,0 +1,30 @@
+package local
+
+import (
+	"github.com/concourse/concourse/atc/creds"
+)
+
+type secretsFactory struct {
+	store           *Store
+	prefix          string
+	lookupTemplates []*creds.SecretTemplate
+	sharedPath      string
+}
+
+func NewSecretsFactory(store *Store, prefix string, lookupTemplates []*creds.SecretTemplate, sharedPath string) *secretsFactory {
+	return &secretsFactory{
+		store:           store,
+		prefix:          prefix,
+		lookupTemplates: lookupTemplates,
+		sharedPath:      sharedPath,
+	}
+}
+
+func (factory *secretsFactory) NewSecrets() creds.Secrets {
+	return &Secrets{
+		Store:           factory.store,
+		Prefix:          factory.prefix,
+		LookupTemplates: factory.lookupTemplates,
+		SharedPath:      factory.sharedPath,
+	}
+}
@@ -0,0 +1,110 @@
+package local
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"go.mozilla.org/sops/v3/aes"
+	"go.mozilla.org/sops/v3/keyservice"
+	sopsyaml "go.mozilla.org/sops/v3/stores/yaml"
+	"google.golang.org/grpc"
+	"sigs.k8s.io/yaml"
+)
+
+// decryptSops decrypts a YAML file encrypted by SOPS, which also verifies the
+// MAC of the file. The data key of the file is decrypted with the given age
+// identities, or, if there are none, with the ones SOPS reads from the file at
+// $SOPS_AGE_KEY_FILE.
+func decryptSops(content []byte, identities []age.Identity) (map[string]interface{}, error) {
+	cleartext, err := decryptSopsData(content, identities)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secrets file: %w", err)
+	}
+
+	var secrets map[string]interface{}
+	err = yaml.Unmarshal(cleartext, &secrets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse decrypted secrets file: %w", err)
+	}
+
+	if secrets == nil {
+		return map[string]interface{}{}, nil
+	}
+
+	return secrets, nil
+}
+
+// decryptSopsData does what SOPS' decrypt.Data does, except that the age
+// identities are passed in rather than read from the environment, which is
+// shared by every manager.
+func decryptSopsData(content []byte, identities []age.Identity) ([]byte, error) {
+	store := &sopsyaml.Store{}
+
+	tree, err := store.LoadEncryptedFile(content)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := tree.Metadata.GetDataKeyWithKeyServices([]keyservice.KeyServiceClient{
+		ageKeyService{
+			LocalClient: keyservice.NewLocalClient(),
+			identities:  identities,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cipher := aes.NewCipher()
+
+	mac, err := tree.Decrypt(key, cipher)
+	if err != nil {
+		return nil, err
+	}
+
+	originalMac, err := cipher.Decrypt(
+		tree.Metadata.MessageAuthenticationCode,
+		key,
+		tree.Metadata.LastModified.Format(time.RFC3339),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if originalMac != mac {
+		return nil, fmt.Errorf("failed to verify data integrity: expected mac %q, got %q", originalMac, mac)
+	}
+
+	return store.EmitPlainFile(tree.Branches)
+}
+
+// ageKeyService decrypts the age keys of a file with its identities, and
+// leaves every other kind of key to SOPS.
+type ageKeyService struct {
+	keyservice.LocalClient
+
+	identities []age.Identity
+}
+
+func (ks ageKeyService) Decrypt(ctx context.Context, req *keyservice.DecryptRequest, opts ...grpc.CallOption) (*keyservice.DecryptResponse, error) {
+	if req.Key.GetAgeKey() == nil || len(ks.identities) == 0 {
+		return ks.LocalClient.Decrypt(ctx, req, opts...)
+	}
+
+	r, err := age.Decrypt(armor.NewReader(bytes.NewReader(req.Ciphertext)), ks.identities...)
+	if err != nil {
+		return nil, fmt.Errorf("no age identity could decrypt the data key: %w", err)
+	}
+
+	plaintext, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &keyservice.DecryptResponse{Plaintext: plaintext}, nil
+}
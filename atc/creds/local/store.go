@@ -0,0 +1,257 @@
+package local
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"filippo.io/age"
+	"sigs.k8s.io/yaml"
+)
+
+// A Store holds the secrets read from either a directory tree or a YAML file,
+// as a tree of nested maps.
+//
+// In a directory tree every regular file is a secret whose value is the
+// content of the file, and every directory is a secret whose fields are the
+// files and directories within it.
+type Store struct {
+	path string
+
+	// root, if set, is the directory that the symlinks within a directory
+	// tree must resolve beneath
+	root string
+
+	// identities decrypt a SOPS-encrypted YAML file
+	identities []age.Identity
+
+	secretsL    sync.RWMutex
+	secrets     map[string]interface{}
+	fingerprint string
+}
+
+func NewStore(path string, identities []age.Identity) (*Store, error) {
+	return newStore(&Store{path: path, identities: identities})
+}
+
+// NewConfinedStore creates a Store whose directory tree may only link to
+// files and directories beneath root.
+func NewConfinedStore(path string, root string, identities []age.Identity) (*Store, error) {
+	return newStore(&Store{path: path, root: root, identities: identities})
+}
+
+func newStore(store *Store) (*Store, error) {
+	_, err := store.Reload()
+	if err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// Get looks up the secret at the given slash-separated path.
+func (s *Store) Get(secretPath string) (interface{}, bool) {
+	s.secretsL.RLock()
+	defer s.secretsL.RUnlock()
+
+	var node interface{} = s.secrets
+	for _, segment := range strings.Split(secretPath, "/") {
+		if segment == "" {
+			continue
+		}
+
+		fields, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		node, ok = fields[segment]
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return node, true
+}
+
+// Reload reads the secrets again if anything changed on disk since they were
+// last read, returning whether they were reloaded.
+func (s *Store) Reload() (bool, error) {
+	fingerprint, err := s.currentFingerprint()
+	if err != nil {
+		return false, err
+	}
+
+	s.secretsL.RLock()
+	unchanged := s.secrets != nil && fingerprint == s.fingerprint
+	s.secretsL.RUnlock()
+
+	if unchanged {
+		return false, nil
+	}
+
+	secrets, err := s.load()
+	if err != nil {
+		return false, err
+	}
+
+	s.secretsL.Lock()
+	s.secrets = secrets
+	s.fingerprint = fingerprint
+	s.secretsL.Unlock()
+
+	return true, nil
+}
+
+// Watch reloads the secrets on every interval until stop is closed.
+func (s *Store) Watch(logger lager.Logger, clock clock.Clock, interval time.Duration, stop <-chan struct{}) {
+	ticker := clock.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C():
+			reloaded, err := s.Reload()
+			if err != nil {
+				logger.Error("failed-to-reload-secrets", err)
+				continue
+			}
+
+			if reloaded {
+				logger.Info("reloaded-secrets")
+			}
+		}
+	}
+}
+
+func (s *Store) load() (map[string]interface{}, error) {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		return loadDir(s.path, s.root)
+	}
+
+	return loadFile(s.path, s.identities)
+}
+
+// currentFingerprint summarizes the size and modification time of every file
+// making up the secrets, so that changes can be detected without reading them.
+func (s *Store) currentFingerprint() (string, error) {
+	var fingerprint strings.Builder
+
+	err := filepath.Walk(s.path, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(&fingerprint, "%s:%d:%d;", path, info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fingerprint.String(), nil
+}
+
+func loadDir(dir string, root string) (map[string]interface{}, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := map[string]interface{}{}
+	for _, info := range infos {
+		// skip hidden files, e.g. editor swap files or the '..data' links of
+		// kubernetes secret volumes
+		if strings.HasPrefix(info.Name(), ".") {
+			continue
+		}
+
+		path := filepath.Join(dir, info.Name())
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			if root != "" {
+				err = checkLinkWithin(path, root)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			info, err = os.Stat(path)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if info.IsDir() {
+			fields, err := loadDir(path, root)
+			if err != nil {
+				return nil, err
+			}
+
+			secrets[filepath.Base(path)] = fields
+		} else if info.Mode().IsRegular() {
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				return nil, err
+			}
+
+			secrets[filepath.Base(path)] = strings.TrimSuffix(string(content), "\n")
+		}
+	}
+
+	return secrets, nil
+}
+
+// checkLinkWithin returns an error if the symlink resolves outside of root.
+func checkLinkWithin(path string, root string) error {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return err
+	}
+
+	if !isWithin(resolved, root) {
+		return fmt.Errorf("%s links outside of %s", path, root)
+	}
+
+	return nil
+}
+
+func isWithin(path string, root string) bool {
+	return path == root || strings.HasPrefix(path, root+string(filepath.Separator))
+}
+
+func loadFile(path string, identities []age.Identity) (map[string]interface{}, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var secrets map[string]interface{}
+	err = yaml.Unmarshal(content, &secrets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse secrets file: %w", err)
+	}
+
+	if secrets == nil {
+		return map[string]interface{}{}, nil
+	}
+
+	if _, encrypted := secrets["sops"]; encrypted {
+		return decryptSops(content, identities)
+	}
+
+	return secrets, nil
+}
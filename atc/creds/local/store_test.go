@@ -0,0 +1,349 @@
+package local_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	"github.com/concourse/concourse/atc/creds/local"
+	"go.mozilla.org/sops/v3"
+	"go.mozilla.org/sops/v3/aes"
+	sopsage "go.mozilla.org/sops/v3/age"
+	sopsyaml "go.mozilla.org/sops/v3/stores/yaml"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Store", func() {
+	var (
+		tmpDir string
+		store  *local.Store
+	)
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "local-creds")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	writeFile := func(path string, content string) {
+		path = filepath.Join(tmpDir, path)
+		Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(path, []byte(content), 0600)).To(Succeed())
+	}
+
+	Context("with a directory tree", func() {
+		BeforeEach(func() {
+			writeFile("concourse/main/foo", "bar\n")
+			writeFile("concourse/main/pipeline/creds/username", "some-user")
+			writeFile("concourse/main/pipeline/creds/password", "some-password")
+			writeFile("concourse/main/.foo.swp", "ignored")
+
+			var err error
+			store, err = local.NewStore(tmpDir, nil)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("reads files as secrets", func() {
+			value, found := store.Get("/concourse/main/foo")
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("bar"))
+		})
+
+		It("reads directories as secrets with fields", func() {
+			value, found := store.Get("/concourse/main/pipeline/creds")
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal(map[string]interface{}{
+				"username": "some-user",
+				"password": "some-password",
+			}))
+		})
+
+		It("ignores hidden files", func() {
+			_, found := store.Get("/concourse/main/.foo.swp")
+			Expect(found).To(BeFalse())
+		})
+
+		It("does not find missing secrets", func() {
+			_, found := store.Get("/concourse/main/missing")
+			Expect(found).To(BeFalse())
+
+			_, found = store.Get("/concourse/main/foo/bar")
+			Expect(found).To(BeFalse())
+		})
+
+		Describe("Reload", func() {
+			It("does nothing if nothing changed", func() {
+				reloaded, err := store.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(reloaded).To(BeFalse())
+			})
+
+			It("picks up changed secrets", func() {
+				writeFile("concourse/main/foo", "baz-but-longer")
+
+				reloaded, err := store.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(reloaded).To(BeTrue())
+
+				value, found := store.Get("/concourse/main/foo")
+				Expect(found).To(BeTrue())
+				Expect(value).To(Equal("baz-but-longer"))
+			})
+
+			It("picks up removed secrets", func() {
+				Expect(os.Remove(filepath.Join(tmpDir, "concourse/main/foo"))).To(Succeed())
+
+				reloaded, err := store.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(reloaded).To(BeTrue())
+
+				_, found := store.Get("/concourse/main/foo")
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Context("with a YAML file", func() {
+		BeforeEach(func() {
+			writeFile("secrets.yml", `
+concourse:
+  main:
+    foo: bar
+    pipeline:
+      creds:
+        username: some-user
+        password: some-password
+`)
+
+			var err error
+			store, err = local.NewStore(filepath.Join(tmpDir, "secrets.yml"), nil)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("reads the secrets", func() {
+			value, found := store.Get("/concourse/main/foo")
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("bar"))
+
+			value, found = store.Get("/concourse/main/pipeline/creds")
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal(map[string]interface{}{
+				"username": "some-user",
+				"password": "some-password",
+			}))
+		})
+
+		It("keeps the previous secrets if the file becomes invalid", func() {
+			// make sure the modification time differs
+			time.Sleep(10 * time.Millisecond)
+			writeFile("secrets.yml", "{")
+
+			_, err := store.Reload()
+			Expect(err).To(HaveOccurred())
+
+			value, found := store.Get("/concourse/main/foo")
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("bar"))
+		})
+	})
+
+	Context("with symlinks in a directory tree", func() {
+		var root string
+
+		BeforeEach(func() {
+			writeFile("team/foo", "bar")
+			writeFile("other-team/foo", "other-bar")
+
+			var err error
+			root, err = filepath.EvalSymlinks(filepath.Join(tmpDir, "team"))
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("follows links within the root", func() {
+			Expect(os.Symlink(filepath.Join(root, "foo"), filepath.Join(root, "linked"))).To(Succeed())
+
+			var err error
+			store, err = local.NewConfinedStore(root, root, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			value, found := store.Get("/linked")
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("bar"))
+		})
+
+		It("refuses links outside of the root", func() {
+			Expect(os.Symlink(filepath.Join(tmpDir, "other-team"), filepath.Join(root, "linked"))).To(Succeed())
+
+			_, err := local.NewConfinedStore(root, root, nil)
+			Expect(err).To(MatchError(ContainSubstring("links outside of")))
+		})
+
+		It("follows any link without a root", func() {
+			Expect(os.Symlink(filepath.Join(tmpDir, "other-team"), filepath.Join(root, "linked"))).To(Succeed())
+
+			var err error
+			store, err = local.NewStore(root, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			value, found := store.Get("/linked/foo")
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("other-bar"))
+		})
+	})
+
+	Context("with a SOPS-encrypted YAML file", func() {
+		var (
+			identity   *age.X25519Identity
+			identities []age.Identity
+			encrypted  string
+		)
+
+		BeforeEach(func() {
+			var err error
+			identity, err = age.GenerateX25519Identity()
+			Expect(err).ToNot(HaveOccurred())
+
+			identities = []age.Identity{identity}
+
+			encrypted = sopsEncrypt(`
+concourse:
+  main:
+    foo: bar
+    port: 5432
+    creds:
+      username: some-user
+      comment_unencrypted: not a secret
+`, identity.Recipient().String())
+		})
+
+		JustBeforeEach(func() {
+			writeFile("secrets.yml", encrypted)
+		})
+
+		It("decrypts the secrets using the age identity", func() {
+			var err error
+			store, err = local.NewStore(filepath.Join(tmpDir, "secrets.yml"), identities)
+			Expect(err).ToNot(HaveOccurred())
+
+			value, found := store.Get("/concourse/main/foo")
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("bar"))
+
+			value, found = store.Get("/concourse/main/port")
+			Expect(found).To(BeTrue())
+			Expect(value).To(BeEquivalentTo(5432))
+
+			value, found = store.Get("/concourse/main/creds")
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal(map[string]interface{}{
+				"username":            "some-user",
+				"comment_unencrypted": "not a secret",
+			}))
+
+			_, found = store.Get("/sops")
+			Expect(found).To(BeFalse())
+		})
+
+		It("fails with the wrong identity", func() {
+			otherIdentity, err := age.GenerateX25519Identity()
+			Expect(err).ToNot(HaveOccurred())
+
+			identities = []age.Identity{otherIdentity}
+
+			_, err = local.NewStore(filepath.Join(tmpDir, "secrets.yml"), identities)
+			Expect(err).To(MatchError(ContainSubstring("failed to decrypt secrets file")))
+		})
+
+		Context("without identities", func() {
+			var (
+				oldAgeKeyFile string
+				hadAgeKeyFile bool
+			)
+
+			BeforeEach(func() {
+				identities = nil
+
+				oldAgeKeyFile, hadAgeKeyFile = os.LookupEnv("SOPS_AGE_KEY_FILE")
+				Expect(os.Setenv("SOPS_AGE_KEY_FILE", filepath.Join(tmpDir, "keys.txt"))).To(Succeed())
+				writeFile("keys.txt", identity.String()+"\n")
+			})
+
+			AfterEach(func() {
+				if hadAgeKeyFile {
+					os.Setenv("SOPS_AGE_KEY_FILE", oldAgeKeyFile)
+				} else {
+					os.Unsetenv("SOPS_AGE_KEY_FILE")
+				}
+			})
+
+			It("decrypts the secrets using the identities at $SOPS_AGE_KEY_FILE", func() {
+				var err error
+				store, err = local.NewStore(filepath.Join(tmpDir, "secrets.yml"), identities)
+				Expect(err).ToNot(HaveOccurred())
+
+				value, found := store.Get("/concourse/main/foo")
+				Expect(found).To(BeTrue())
+				Expect(value).To(Equal("bar"))
+			})
+		})
+
+		Context("when a value has been tampered with", func() {
+			BeforeEach(func() {
+				Expect(encrypted).To(ContainSubstring("not a secret"))
+				encrypted = strings.Replace(encrypted, "not a secret", "tampered", 1)
+			})
+
+			It("fails to verify the mac", func() {
+				_, err := local.NewStore(filepath.Join(tmpDir, "secrets.yml"), identities)
+				Expect(err).To(MatchError(ContainSubstring("failed to decrypt secrets file")))
+			})
+		})
+	})
+})
+
+// sopsEncrypt encrypts the YAML document for the age recipient the way the
+// sops CLI does.
+func sopsEncrypt(plaintext string, recipient string) string {
+	store := &sopsyaml.Store{}
+
+	branches, err := store.LoadPlainFile([]byte(plaintext))
+	Expect(err).ToNot(HaveOccurred())
+
+	masterKey, err := sopsage.MasterKeyFromRecipient(recipient)
+	Expect(err).ToNot(HaveOccurred())
+
+	tree := sops.Tree{
+		Branches: branches,
+		Metadata: sops.Metadata{
+			KeyGroups:         []sops.KeyGroup{{masterKey}},
+			UnencryptedSuffix: sops.DefaultUnencryptedSuffix,
+			Version:           "3.7.0",
+		},
+	}
+
+	dataKey, errs := tree.GenerateDataKey()
+	Expect(errs).To(BeEmpty())
+
+	cipher := aes.NewCipher()
+
+	mac, err := tree.Encrypt(dataKey, cipher)
+	Expect(err).ToNot(HaveOccurred())
+
+	tree.Metadata.LastModified = time.Now().UTC()
+	tree.Metadata.MessageAuthenticationCode, err = cipher.Encrypt(mac, dataKey, tree.Metadata.LastModified.Format(time.RFC3339))
+	Expect(err).ToNot(HaveOccurred())
+
+	encrypted, err := store.EmitEncryptedFile(tree)
+	Expect(err).ToNot(HaveOccurred())
+
+	return string(encrypted)
+}
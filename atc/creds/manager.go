@@ -15,11 +15,29 @@ type Manager interface {
 	NewSecretsFactory(lager.Logger) (SecretsFactory, error)
 }
 
+// LeasingManager is implemented by Managers which can hand out dynamic
+// secrets, whose leases are tied to the lifetime of the build reading them.
+type LeasingManager interface {
+	// Leaser returns what renews and revokes the leases on the manager's
+	// secrets. It is only valid once the manager is initialized.
+	Leaser() Leaser
+
+	// TrackLeases makes the manager track the leases on its secrets through
+	// the given LeaseTracker, which is shared with every other manager.
+	TrackLeases(*LeaseTracker)
+}
+
 type ManagerFactory interface {
 	AddConfig(*flags.Group) Manager
 	NewInstance(interface{}) (Manager, error)
 }
 
+// TeamManagerFactory is implemented by ManagerFactories whose var sources may
+// only read the secrets of the team owning the pipeline.
+type TeamManagerFactory interface {
+	NewTeamInstance(teamName string, config interface{}) (Manager, error)
+}
+
 type Managers map[string]Manager
 
 type CredentialManagementConfig struct {
@@ -13,7 +13,7 @@ import (
 //go:generate counterfeiter . VarSourcePool
 
 type VarSourcePool interface {
-	FindOrCreate(lager.Logger, map[string]interface{}, ManagerFactory) (Secrets, error)
+	FindOrCreate(lager.Logger, string, map[string]interface{}, ManagerFactory) (Secrets, error)
 	Size() int
 	Close()
 }
@@ -40,6 +40,8 @@ type varSourcePool struct {
 	ttl   time.Duration
 	clock clock.Clock
 
+	leases *LeaseTracker
+
 	closeOnce sync.Once
 	closed    chan struct{}
 }
@@ -49,6 +51,7 @@ func NewVarSourcePool(
 	ttl time.Duration,
 	collectInterval time.Duration,
 	clock clock.Clock,
+	leases *LeaseTracker,
 ) VarSourcePool {
 	pool := &varSourcePool{
 		pool:  map[string]*inPoolManager{},
@@ -56,6 +59,8 @@ func NewVarSourcePool(
 		ttl:   ttl,
 		clock: clock,
 
+		leases: leases,
+
 		closeOnce: sync.Once{},
 		closed:    make(chan struct{}),
 	}
@@ -74,19 +79,25 @@ func (pool *varSourcePool) Size() int {
 	return len(pool.pool)
 }
 
-func (pool *varSourcePool) FindOrCreate(logger lager.Logger, config map[string]interface{}, factory ManagerFactory) (Secrets, error) {
+func (pool *varSourcePool) FindOrCreate(logger lager.Logger, teamName string, config map[string]interface{}, factory ManagerFactory) (Secrets, error) {
 	b, err := json.Marshal(config)
 	if err != nil {
 		return nil, err
 	}
 
-	key := string(b)
+	// the same config may resolve to different secrets for different teams
+	key := teamName + ":" + string(b)
 
 	pool.lock.Lock()
 	defer pool.lock.Unlock()
 
 	if _, ok := pool.pool[key]; !ok {
-		manager, err := factory.NewInstance(config)
+		var manager Manager
+		if teamFactory, ok := factory.(TeamManagerFactory); ok {
+			manager, err = teamFactory.NewTeamInstance(teamName, config)
+		} else {
+			manager, err = factory.NewInstance(config)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -94,6 +105,9 @@ func (pool *varSourcePool) FindOrCreate(logger lager.Logger, config map[string]i
 		if err != nil {
 			return nil, err
 		}
+		if leasingManager, ok := manager.(LeasingManager); ok && pool.leases != nil {
+			leasingManager.TrackLeases(pool.leases)
+		}
 		secretsFactory, err := manager.NewSecretsFactory(logger)
 		if err != nil {
 			return nil, err
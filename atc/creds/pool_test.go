@@ -41,7 +41,7 @@ var _ = Context("pool", func() {
 
 	Context("FindOrCreate", func() {
 		BeforeEach(func() {
-			varSourcePool = creds.NewVarSourcePool(logger, 5*time.Minute, time.Minute, fakeClock)
+			varSourcePool = creds.NewVarSourcePool(logger, 5*time.Minute, time.Minute, fakeClock, nil)
 		})
 
 		AfterEach(func() {
@@ -55,7 +55,7 @@ var _ = Context("pool", func() {
 			)
 
 			JustBeforeEach(func() {
-				secrets, err = varSourcePool.FindOrCreate(logger, config1, factory)
+				secrets, err = varSourcePool.FindOrCreate(logger, "some-team", config1, factory)
 				Expect(err).ToNot(HaveOccurred())
 			})
 
@@ -83,9 +83,9 @@ var _ = Context("pool", func() {
 				err                error
 			)
 			JustBeforeEach(func() {
-				secrets1, err = varSourcePool.FindOrCreate(logger, config1, factory)
+				secrets1, err = varSourcePool.FindOrCreate(logger, "some-team", config1, factory)
 				Expect(err).ToNot(HaveOccurred())
-				secrets2, err = varSourcePool.FindOrCreate(logger, config2, factory)
+				secrets2, err = varSourcePool.FindOrCreate(logger, "some-team", config2, factory)
 				Expect(err).ToNot(HaveOccurred())
 			})
 
@@ -124,17 +124,17 @@ var _ = Context("pool", func() {
 				err                error
 			)
 			JustBeforeEach(func() {
-				secrets1, err = varSourcePool.FindOrCreate(logger, config1, factory)
+				secrets1, err = varSourcePool.FindOrCreate(logger, "some-team", config1, factory)
 				Expect(err).ToNot(HaveOccurred())
-				secrets1, err = varSourcePool.FindOrCreate(logger, config1, factory)
+				secrets1, err = varSourcePool.FindOrCreate(logger, "some-team", config1, factory)
 				Expect(err).ToNot(HaveOccurred())
-				secrets1, err = varSourcePool.FindOrCreate(logger, config1, factory)
+				secrets1, err = varSourcePool.FindOrCreate(logger, "some-team", config1, factory)
 				Expect(err).ToNot(HaveOccurred())
-				secrets2, err = varSourcePool.FindOrCreate(logger, config2, factory)
+				secrets2, err = varSourcePool.FindOrCreate(logger, "some-team", config2, factory)
 				Expect(err).ToNot(HaveOccurred())
-				secrets2, err = varSourcePool.FindOrCreate(logger, config2, factory)
+				secrets2, err = varSourcePool.FindOrCreate(logger, "some-team", config2, factory)
 				Expect(err).ToNot(HaveOccurred())
-				secrets2, err = varSourcePool.FindOrCreate(logger, config2, factory)
+				secrets2, err = varSourcePool.FindOrCreate(logger, "some-team", config2, factory)
 				Expect(err).ToNot(HaveOccurred())
 			})
 
@@ -166,22 +166,35 @@ var _ = Context("pool", func() {
 				Expect(varSourcePool.Size()).To(Equal(2))
 			})
 		})
+
+		Context("add same config for different teams", func() {
+			JustBeforeEach(func() {
+				_, err := varSourcePool.FindOrCreate(logger, "some-team", config1, factory)
+				Expect(err).ToNot(HaveOccurred())
+				_, err = varSourcePool.FindOrCreate(logger, "other-team", config1, factory)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("pool size should be 2", func() {
+				Expect(varSourcePool.Size()).To(Equal(2))
+			})
+		})
 	})
 
 	Describe("Close", func() {
 		var err error
 
 		BeforeEach(func() {
-			varSourcePool = creds.NewVarSourcePool(logger, 7*time.Second, 1*time.Second, fakeClock)
+			varSourcePool = creds.NewVarSourcePool(logger, 7*time.Second, 1*time.Second, fakeClock, nil)
 		})
 
 		It("cleans up all var sources", func() {
-			_, err = varSourcePool.FindOrCreate(logger, config1, factory)
+			_, err = varSourcePool.FindOrCreate(logger, "some-team", config1, factory)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(varSourcePool.Size()).To(Equal(1))
 
 			fakeClock.WaitForWatcherAndIncrement(4 * time.Second)
-			_, err = varSourcePool.FindOrCreate(logger, config2, factory)
+			_, err = varSourcePool.FindOrCreate(logger, "some-team", config2, factory)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(varSourcePool.Size()).To(Equal(2))
 
@@ -194,7 +207,7 @@ var _ = Context("pool", func() {
 		var err error
 
 		BeforeEach(func() {
-			varSourcePool = creds.NewVarSourcePool(logger, 7*time.Second, 1*time.Second, fakeClock)
+			varSourcePool = creds.NewVarSourcePool(logger, 7*time.Second, 1*time.Second, fakeClock, nil)
 		})
 
 		AfterEach(func() {
@@ -202,12 +215,12 @@ var _ = Context("pool", func() {
 		})
 
 		It("should clean up once ttl expires", func() {
-			_, err = varSourcePool.FindOrCreate(logger, config1, factory)
+			_, err = varSourcePool.FindOrCreate(logger, "some-team", config1, factory)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(varSourcePool.Size()).To(Equal(1))
 
 			fakeClock.WaitForWatcherAndIncrement(4 * time.Second)
-			_, err = varSourcePool.FindOrCreate(logger, config2, factory)
+			_, err = varSourcePool.FindOrCreate(logger, "some-team", config2, factory)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(varSourcePool.Size()).To(Equal(2))
 
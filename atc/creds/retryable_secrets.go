@@ -43,3 +43,8 @@ func (rs RetryableSecrets) Get(secretPath string) (interface{}, *time.Time, bool
 func (rs RetryableSecrets) NewSecretLookupPaths(teamName string, pipelineName string, allowRootPath bool) []SecretLookupPath {
 	return rs.secrets.NewSecretLookupPaths(teamName, pipelineName, allowRootPath)
 }
+
+// ForBuild scopes the underlying secrets to the build, retrying in the same way
+func (rs RetryableSecrets) ForBuild(buildID int) Secrets {
+	return &RetryableSecrets{secrets: ForBuild(rs.secrets, buildID), retryConfig: rs.retryConfig}
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/hashicorp/go-rootcerts"
 	vaultapi "github.com/hashicorp/vault/api"
 )
@@ -61,6 +62,27 @@ func (ac *APIClient) Read(path string) (*vaultapi.Secret, error) {
 	return ac.client().Logical().Read(path)
 }
 
+// RenewLease extends a lease held on a dynamic secret by its original
+// duration.
+func (ac *APIClient) RenewLease(lease creds.Lease) (creds.Lease, error) {
+	secret, err := ac.client().Sys().Renew(lease.ID, int(lease.Duration.Seconds()))
+	if err != nil {
+		return creds.Lease{}, err
+	}
+
+	return creds.Lease{
+		ID:        secret.LeaseID,
+		Duration:  time.Duration(secret.LeaseDuration) * time.Second,
+		Renewable: secret.Renewable,
+	}, nil
+}
+
+// RevokeLease revokes a lease held on a dynamic secret, invalidating the
+// secret immediately.
+func (ac *APIClient) RevokeLease(leaseID string) error {
+	return ac.client().Sys().Revoke(leaseID)
+}
+
 func (ac *APIClient) loginParams() map[string]interface{} {
 	loginParams := make(map[string]interface{})
 	for k, v := range ac.authConfig.Params {
@@ -29,6 +29,7 @@ type VaultManager struct {
 	Client        *APIClient
 	ReAuther      *ReAuther
 	SecretFactory *vaultFactory
+	Leases        *creds.LeaseTracker
 }
 
 type TLSConfig struct {
@@ -198,12 +199,25 @@ func (manager *VaultManager) NewSecretsFactory(logger lager.Logger) (creds.Secre
 			manager.PathPrefix,
 			templates,
 			manager.SharedPath,
+			manager.Leases,
 		)
 	}
 
 	return manager.SecretFactory, nil
 }
 
+// Leaser returns the client through which the leases on dynamic secrets are
+// renewed and revoked.
+func (manager *VaultManager) Leaser() creds.Leaser {
+	return manager.Client
+}
+
+// TrackLeases makes builds hold on to the leases of the dynamic secrets they
+// read, renewing them while the build runs and revoking them once it is done.
+func (manager *VaultManager) TrackLeases(leases *creds.LeaseTracker) {
+	manager.Leases = leases
+}
+
 func (manager VaultManager) Close(logger lager.Logger) {
 	manager.ReAuther.Close()
 }
@@ -22,6 +22,25 @@ type Vault struct {
 	Prefix          string
 	LookupTemplates []*creds.SecretTemplate
 	SharedPath      string
+
+	// Leases tracks the leases on dynamic secrets, whether acquired by builds
+	// or not, renewing and revoking them through Leaser. If nil, the leases
+	// are left to expire on their own.
+	Leaser creds.Leaser
+	Leases *creds.LeaseTracker
+
+	buildID int
+}
+
+// ForBuild returns a Vault which tracks the leases on dynamic secrets read on
+// behalf of the build, so that they are renewed while the build is running.
+func (v Vault) ForBuild(buildID int) creds.Secrets {
+	if v.Leases == nil {
+		return v
+	}
+
+	v.buildID = buildID
+	return v
 }
 
 // NewSecretLookupPaths defines how variables will be searched in the underlying secret manager
@@ -60,12 +79,42 @@ func (v Vault) Get(secretPath string) (interface{}, *time.Time, bool, error) {
 }
 
 func (v Vault) findSecret(path string) (*vaultapi.Secret, *time.Time, bool, error) {
+	if v.Leases != nil {
+		// the build (or a check, when read outside of a build) already holds
+		// a lease on this secret, so there's no need for another one
+		if leased, found := v.Leases.Find(v.buildID, v.Leaser, path); found {
+			expiration := time.Now()
+			return leased.(*vaultapi.Secret), &expiration, true, nil
+		}
+	}
+
 	secret, err := v.SecretReader.Read(path)
 	if err != nil {
 		return nil, nil, false, err
 	}
 
 	if secret != nil {
+		if secret.LeaseID != "" {
+			// leases read outside of a build are tracked too, as unowned
+			if v.Leases != nil {
+				err := v.Leases.Track(v.buildID, v.Leaser, path, creds.Lease{
+					ID:        secret.LeaseID,
+					Duration:  time.Duration(secret.LeaseDuration) * time.Second,
+					Renewable: secret.Renewable,
+				}, secret)
+				if err != nil {
+					return nil, nil, false, err
+				}
+			}
+
+			// Dynamic secrets are generated for, and revoked along with, the
+			// build that read them (or after a while when read outside of a
+			// build). A cached one could outlive its lease or be served to
+			// another build, so they must never be cached.
+			expiration := time.Now()
+			return secret, &expiration, true, nil
+		}
+
 		// The lease duration is TTL: the time in seconds for which the lease is valid
 		// A consumer of this secret must renew the lease within that time.
 		duration := time.Duration(secret.LeaseDuration) * time.Second / 2
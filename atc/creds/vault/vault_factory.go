@@ -13,15 +13,25 @@ type vaultFactory struct {
 	sharedPath      string
 	lookupTemplates []*creds.SecretTemplate
 	loggedIn        <-chan struct{}
+	leaser          creds.Leaser
+	leases          *creds.LeaseTracker
 }
 
-func NewVaultFactory(sr SecretReader, loggedIn <-chan struct{}, prefix string, lookupTemplates []*creds.SecretTemplate, sharedPath string) *vaultFactory {
+// NewVaultFactory creates a vaultFactory. If leases is not nil, the leases on
+// dynamic secrets read through sr are tracked and renewed or revoked through
+// it; sr must then be a creds.Leaser too.
+func NewVaultFactory(sr SecretReader, loggedIn <-chan struct{}, prefix string, lookupTemplates []*creds.SecretTemplate, sharedPath string, leases *creds.LeaseTracker) *vaultFactory {
 	factory := &vaultFactory{
 		sr:               sr,
 		prefix:           prefix,
 		lookupTemplates: lookupTemplates,
 		sharedPath:       sharedPath,
 		loggedIn:         loggedIn,
+		leases:           leases,
+	}
+
+	if leaser, ok := sr.(creds.Leaser); ok {
+		factory.leaser = leaser
 	}
 
 	return factory
@@ -39,5 +49,7 @@ func (factory *vaultFactory) NewSecrets() creds.Secrets {
 		Prefix:          factory.prefix,
 		LookupTemplates: factory.lookupTemplates,
 		SharedPath:      factory.sharedPath,
+		Leaser:          factory.leaser,
+		Leases:          factory.leases,
 	}
 }
@@ -1,7 +1,12 @@
 package vault_test
 
 import (
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/credsfakes"
 	"github.com/concourse/concourse/atc/creds/vault"
 	"github.com/concourse/concourse/vars"
 	vaultapi "github.com/hashicorp/vault/api"
@@ -30,6 +35,16 @@ func (msr *MockSecretReader) Read(lookupPath string) (*vaultapi.Secret, error) {
 	return nil, nil
 }
 
+type CountingSecretReader struct {
+	vault.SecretReader
+	reads int
+}
+
+func (csr *CountingSecretReader) Read(lookupPath string) (*vaultapi.Secret, error) {
+	csr.reads++
+	return csr.SecretReader.Read(lookupPath)
+}
+
 var _ = Describe("Vault", func() {
 
 	var v *vault.Vault
@@ -252,5 +267,132 @@ var _ = Describe("Vault", func() {
 				})
 			})
 		})
+
+		Context("with dynamic secrets", func() {
+			var fakeLeaser *credsfakes.FakeLeaser
+			var fakeLeaseStore *credsfakes.FakeLeaseStore
+			var fakeClock *fakeclock.FakeClock
+			var leases *creds.LeaseTracker
+			var csr *CountingSecretReader
+
+			BeforeEach(func() {
+				fakeLeaser = new(credsfakes.FakeLeaser)
+				fakeLeaseStore = new(credsfakes.FakeLeaseStore)
+				fakeClock = fakeclock.NewFakeClock(time.Now())
+
+				csr = &CountingSecretReader{
+					SecretReader: &MockSecretReader{&[]MockSecret{
+						{
+							path: "/concourse/team/foo",
+							secret: &vaultapi.Secret{
+								LeaseID:       "database/creds/readonly/some-lease",
+								LeaseDuration: 3600,
+								Data:          map[string]interface{}{"username": "v-user"},
+							},
+						}},
+					},
+				}
+
+				leases = creds.NewLeaseTracker(lagertest.NewTestLogger("test"), fakeLeaseStore, fakeClock)
+				leases.StoreLeasesOf(fakeLeaser)
+
+				v.SecretReader = csr
+				v.Leaser = fakeLeaser
+				v.Leases = leases
+			})
+
+			AfterEach(func() {
+				leases.Close()
+			})
+
+			It("should track the lease for the build reading the secret", func() {
+				buildVariables := creds.NewVariables(creds.ForBuild(v, 42), "team", "pipeline", false)
+
+				value, found, err := buildVariables.Get(varFoo)
+				Expect(value).To(Equal(map[string]interface{}{"username": "v-user"}))
+				Expect(found).To(BeTrue())
+				Expect(err).To(BeNil())
+
+				Expect(fakeLeaseStore.CreateLeaseCallCount()).To(Equal(1))
+				buildID, leaseID := fakeLeaseStore.CreateLeaseArgsForCall(0)
+				Expect(buildID).To(Equal(42))
+				Expect(leaseID).To(Equal("database/creds/readonly/some-lease"))
+			})
+
+			It("should reuse the lease of the build while it is valid", func() {
+				buildSecrets := creds.ForBuild(v, 42)
+
+				_, _, found, err := buildSecrets.Get("/concourse/team/foo")
+				Expect(found).To(BeTrue())
+				Expect(err).To(BeNil())
+
+				value, _, found, err := buildSecrets.Get("/concourse/team/foo")
+				Expect(value).To(Equal(map[string]interface{}{"username": "v-user"}))
+				Expect(found).To(BeTrue())
+				Expect(err).To(BeNil())
+
+				Expect(csr.reads).To(Equal(1))
+				Expect(fakeLeaseStore.CreateLeaseCallCount()).To(Equal(1))
+
+				_, _, _, err = creds.ForBuild(v, 43).Get("/concourse/team/foo")
+				Expect(err).To(BeNil())
+				Expect(csr.reads).To(Equal(2))
+
+				fakeClock.Increment(30 * time.Minute)
+
+				_, _, _, err = buildSecrets.Get("/concourse/team/foo")
+				Expect(err).To(BeNil())
+				Expect(csr.reads).To(Equal(3))
+			})
+
+			It("should track the lease outside of a build as unowned", func() {
+				_, found, err := variables.Get(varFoo)
+				Expect(found).To(BeTrue())
+				Expect(err).To(BeNil())
+
+				Expect(fakeLeaseStore.CreateLeaseCallCount()).To(Equal(1))
+				buildID, leaseID := fakeLeaseStore.CreateLeaseArgsForCall(0)
+				Expect(buildID).To(BeZero())
+				Expect(leaseID).To(Equal("database/creds/readonly/some-lease"))
+			})
+
+			It("should not let the secret be cached outside of a build", func() {
+				_, expiration, found, err := v.Get("/concourse/team/foo")
+				Expect(found).To(BeTrue())
+				Expect(err).To(BeNil())
+
+				Expect(expiration).ToNot(BeNil())
+				Expect(expiration.After(time.Now())).To(BeFalse())
+
+				_, expiration, found, err = v.Get("/concourse/team/foo")
+				Expect(found).To(BeTrue())
+				Expect(err).To(BeNil())
+
+				Expect(expiration).ToNot(BeNil())
+				Expect(expiration.After(time.Now())).To(BeFalse())
+				Expect(csr.reads).To(Equal(1))
+			})
+
+			It("should not let the secret be cached without lease tracking", func() {
+				v.Leases = nil
+
+				_, expiration, found, err := creds.ForBuild(v, 42).Get("/concourse/team/foo")
+				Expect(found).To(BeTrue())
+				Expect(err).To(BeNil())
+
+				Expect(expiration).ToNot(BeNil())
+				Expect(expiration.After(time.Now())).To(BeFalse())
+			})
+
+			It("should revoke the leases of the build once it is released", func() {
+				_, _, _, err := creds.ForBuild(v, 42).Get("/concourse/team/foo")
+				Expect(err).To(BeNil())
+
+				Expect(leases.Release(42)).To(Succeed())
+
+				Expect(fakeLeaser.RevokeLeaseCallCount()).To(Equal(1))
+				Expect(fakeLeaser.RevokeLeaseArgsForCall(0)).To(Equal("database/creds/readonly/some-lease"))
+			})
+		})
 	})
 })
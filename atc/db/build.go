@@ -73,6 +73,7 @@ var buildsQuery = psql.Select(`
 		j.name,
 		b.pipeline_id,
 		p.name,
+		p.instance_vars,
 		t.name,
 		b.nonce,
 		b.drained,
@@ -82,7 +83,13 @@ var buildsQuery = psql.Select(`
 		b.rerun_of,
 		r.name,
 		b.rerun_number,
-		b.span_context
+		b.span_context,
+		b.events_archived,
+		b.priority,
+		b.quota_exceeded,
+		b.cpu_time,
+		b.memory_peak,
+		b.rootfs_disk_usage
 	`).
 	From("builds b").
 	JoinClause("LEFT OUTER JOIN jobs j ON b.job_id = j.id").
@@ -125,6 +132,9 @@ type Build interface {
 	RerunOf() int
 	RerunOfName() string
 	RerunNumber() int
+	Priority() int
+	QuotaExceeded() bool
+	ResourceUsage() atc.ResourceUsage
 
 	Reload() (bool, error)
 
@@ -140,12 +150,16 @@ type Build interface {
 
 	SetInterceptible(bool) error
 
+	AddResourceUsage(atc.ResourceUsage) error
+
 	Events(uint) (EventSource, error)
 	SaveEvent(event atc.Event) error
 
 	Artifacts() ([]WorkerArtifact, error)
 	Artifact(artifactID int) (WorkerArtifact, error)
 
+	SecretAccesses() ([]atc.SecretAccess, error)
+
 	SaveOutput(string, atc.Source, atc.VersionedResourceTypes, atc.Version, ResourceConfigMetadataFields, string, string) error
 	AdoptInputsAndPipes() ([]BuildInput, bool, error)
 	AdoptRerunInputsAndPipes() ([]BuildInput, bool, error)
@@ -161,10 +175,13 @@ type Build interface {
 	IsDrained() bool
 	SetDrained(bool) error
 
+	EventsArchived() bool
+	MarkEventsArchived() error
+
 	SpanContext() propagators.Supplier
 
 	SavePipeline(
-		pipelineName string,
+		pipelineRef atc.PipelineRef,
 		teamId int,
 		config atc.Config,
 		from ConfigVersion,
@@ -193,6 +210,11 @@ type build struct {
 	rerunOfName string
 	rerunNumber int
 
+	priority      int
+	quotaExceeded bool
+
+	resourceUsage atc.ResourceUsage
+
 	schema      string
 	privatePlan atc.Plan
 	publicPlan  *json.RawMessage
@@ -202,9 +224,10 @@ type build struct {
 	endTime    time.Time
 	reapTime   time.Time
 
-	drained   bool
-	aborted   bool
-	completed bool
+	drained        bool
+	aborted        bool
+	completed      bool
+	eventsArchived bool
 
 	spanContext SpanContext
 }
@@ -246,14 +269,19 @@ func (b *build) ReapTime() time.Time  { return b.reapTime }
 func (b *build) Status() BuildStatus  { return b.status }
 func (b *build) IsScheduled() bool    { return b.scheduled }
 func (b *build) IsDrained() bool      { return b.drained }
+func (b *build) EventsArchived() bool { return b.eventsArchived }
 func (b *build) IsRunning() bool      { return !b.completed }
 func (b *build) IsAborted() bool      { return b.aborted }
 func (b *build) IsCompleted() bool    { return b.completed }
+func (b *build) Priority() int        { return b.priority }
+func (b *build) QuotaExceeded() bool  { return b.quotaExceeded }
 func (b *build) InputsReady() bool    { return b.inputsReady }
 func (b *build) RerunOf() int         { return b.rerunOf }
 func (b *build) RerunOfName() string  { return b.rerunOfName }
 func (b *build) RerunNumber() int     { return b.rerunNumber }
 
+func (b *build) ResourceUsage() atc.ResourceUsage { return b.resourceUsage }
+
 func (b *build) Reload() (bool, error) {
 	row := buildsQuery.Where(sq.Eq{"b.id": b.id}).
 		RunWith(b.conn).
@@ -630,6 +658,70 @@ func (b *build) SetDrained(drained bool) error {
 	return err
 }
 
+// AddResourceUsage accumulates the resources consumed by the container of one
+// of the build's steps into the usage of the whole build.
+func (b *build) AddResourceUsage(usage atc.ResourceUsage) error {
+	var resourceUsage atc.ResourceUsage
+	err := psql.Update("builds").
+		Set("cpu_time", sq.Expr("cpu_time + ?", usage.CPUTime)).
+		Set("memory_peak", sq.Expr("GREATEST(memory_peak, ?)", usage.MemoryPeak)).
+		Set("rootfs_disk_usage", sq.Expr("rootfs_disk_usage + ?", usage.RootfsDiskUsage)).
+		Where(sq.Eq{"id": b.id}).
+		Suffix("RETURNING cpu_time, memory_peak, rootfs_disk_usage").
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&resourceUsage.CPUTime, &resourceUsage.MemoryPeak, &resourceUsage.RootfsDiskUsage)
+	if err != nil {
+		return err
+	}
+
+	b.resourceUsage = resourceUsage
+
+	return nil
+}
+
+// MarkEventsArchived deletes the events of the build from the database once
+// they have been archived elsewhere.
+func (b *build) MarkEventsArchived() error {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	table := fmt.Sprintf("team_build_events_%d", b.teamID)
+	if b.pipelineID != 0 {
+		table = fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
+	}
+
+	_, err = psql.Delete(table).
+		Where(sq.Eq{"build_id": b.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Update("builds").
+		Set("events_archived", true).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	b.eventsArchived = true
+
+	return nil
+}
+
 func (b *build) Delete() (bool, error) {
 	rows, err := psql.Delete("builds").
 		Where(sq.Eq{
@@ -750,6 +842,7 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 			PausedPipeline:      BuildPreparationStatusNotBlocking,
 			PausedJob:           BuildPreparationStatusNotBlocking,
 			MaxRunningBuilds:    BuildPreparationStatusNotBlocking,
+			TeamQuota:           BuildPreparationStatusNotBlocking,
 			Inputs:              map[string]BuildPreparationStatus{},
 			InputsSatisfied:     BuildPreparationStatusNotBlocking,
 			MissingInputReasons: MissingInputReasons{},
@@ -793,6 +886,11 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 		maxInFlightReachedStatus = BuildPreparationStatusBlocking
 	}
 
+	teamQuotaStatus := BuildPreparationStatusNotBlocking
+	if b.quotaExceeded {
+		teamQuotaStatus = BuildPreparationStatusBlocking
+	}
+
 	tf := NewTeamFactory(b.conn, b.lockFactory)
 	t, found, err := tf.FindTeam(b.teamName)
 	if err != nil {
@@ -803,7 +901,7 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 		return BuildPreparation{}, false, nil
 	}
 
-	pipeline, found, err := t.Pipeline(b.pipelineName)
+	pipeline, found, err := t.Pipeline(b.PipelineRef())
 	if err != nil {
 		return BuildPreparation{}, false, err
 	}
@@ -894,6 +992,7 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 		PausedPipeline:      pausedPipelineStatus,
 		PausedJob:           pausedJobStatus,
 		MaxRunningBuilds:    maxInFlightReachedStatus,
+		TeamQuota:           teamQuotaStatus,
 		Inputs:              inputs,
 		InputsSatisfied:     inputsSatisfiedStatus,
 		MissingInputReasons: missingInputReasons,
@@ -995,6 +1094,45 @@ func (b *build) Artifacts() ([]WorkerArtifact, error) {
 	return artifacts, nil
 }
 
+// SecretAccesses returns the secret lookups made on behalf of the build, in
+// the order they were made.
+func (b *build) SecretAccesses() ([]atc.SecretAccess, error) {
+	rows, err := psql.Select("path", "manager", "var_source", "cache_hit", "found", "accessed_at").
+		From("build_secret_accesses").
+		Where(sq.Eq{
+			"build_id": b.id,
+		}).
+		OrderBy("id").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	accesses := []atc.SecretAccess{}
+	for rows.Next() {
+		var (
+			access     atc.SecretAccess
+			varSource  sql.NullString
+			accessedAt time.Time
+		)
+
+		err = rows.Scan(&access.Path, &access.Manager, &varSource, &access.CacheHit, &access.Found, &accessedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		access.VarSource = varSource.String
+		access.AccessedAt = accessedAt.Unix()
+
+		accesses = append(accesses, access)
+	}
+
+	return accesses, nil
+}
+
 func (b *build) SaveOutput(
 	resourceType string,
 	source atc.Source,
@@ -1534,7 +1672,7 @@ func (b *build) SpanContext() propagators.Supplier {
 }
 
 func (b *build) SavePipeline(
-	pipelineName string,
+	pipelineRef atc.PipelineRef,
 	teamID int,
 	config atc.Config,
 	from ConfigVersion,
@@ -1549,7 +1687,7 @@ func (b *build) SavePipeline(
 
 	jobID := newNullInt64(b.jobID)
 	buildID := newNullInt64(b.id)
-	pipelineID, isNewPipeline, err := savePipeline(tx, pipelineName, config, from, initiallyPaused, teamID, jobID, buildID)
+	pipelineID, isNewPipeline, err := savePipeline(tx, pipelineRef, config, from, initiallyPaused, teamID, jobID, buildID)
 	if err != nil {
 		return nil, false, err
 	}
@@ -1597,8 +1735,8 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		jobID, pipelineID, rerunOf, rerunNumber                             sql.NullInt64
 		schema, privatePlan, jobName, pipelineName, publicPlan, rerunOfName sql.NullString
 		createTime, startTime, endTime, reapTime                            pq.NullTime
-		nonce, spanContext                                                  sql.NullString
-		drained, aborted, completed                                         bool
+		nonce, spanContext, pipelineInstanceVars                            sql.NullString
+		drained, aborted, completed, eventsArchived                         bool
 		status                                                              string
 	)
 
@@ -1620,6 +1758,7 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		&jobName,
 		&pipelineID,
 		&pipelineName,
+		&pipelineInstanceVars,
 		&b.teamName,
 		&nonce,
 		&drained,
@@ -1630,6 +1769,12 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		&rerunOfName,
 		&rerunNumber,
 		&spanContext,
+		&eventsArchived,
+		&b.priority,
+		&b.quotaExceeded,
+		&b.resourceUsage.CPUTime,
+		&b.resourceUsage.MemoryPeak,
+		&b.resourceUsage.RootfsDiskUsage,
 	)
 	if err != nil {
 		return err
@@ -1648,10 +1793,16 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 	b.drained = drained
 	b.aborted = aborted
 	b.completed = completed
+	b.eventsArchived = eventsArchived
 	b.rerunOf = int(rerunOf.Int64)
 	b.rerunOfName = rerunOfName.String
 	b.rerunNumber = int(rerunNumber.Int64)
 
+	err = b.scanInstanceVars(pipelineInstanceVars)
+	if err != nil {
+		return err
+	}
+
 	var (
 		noncense      *string
 		decryptedPlan []byte
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
@@ -19,6 +20,7 @@ type BuildFactory interface {
 	PublicBuilds(Page) ([]Build, Pagination, error)
 	GetAllStartedBuilds() ([]Build, error)
 	GetDrainableBuilds() ([]Build, error)
+	GetArchivableBuilds(afterBuildID int, limit int, drainedOnly bool) ([]Build, error)
 	// TODO: move to BuildLifecycle, new interface (see WorkerLifecycle)
 	MarkNonInterceptibleBuilds() error
 }
@@ -125,6 +127,28 @@ func (f *buildFactory) GetDrainableBuilds() ([]Build, error) {
 	return getBuilds(query, f.conn, f.lockFactory)
 }
 
+// GetArchivableBuilds returns completed builds whose events are still in the
+// database, oldest first, starting after the given build so that builds which
+// fail to be archived don't keep the later ones from being returned. If
+// drainedOnly is true, the builds whose events have not been drained yet are
+// left out.
+func (f *buildFactory) GetArchivableBuilds(afterBuildID int, limit int, drainedOnly bool) ([]Build, error) {
+	query := buildsQuery.Where(sq.Eq{
+		"b.completed":       true,
+		"b.events_archived": false,
+		"b.reap_time":       nil,
+	}).
+		Where(sq.Gt{"b.id": afterBuildID}).
+		OrderBy("b.id ASC").
+		Limit(uint64(limit))
+
+	if drainedOnly {
+		query = query.Where(sq.Eq{"b.drained": true})
+	}
+
+	return getBuilds(query, f.conn, f.lockFactory)
+}
+
 func (f *buildFactory) GetAllStartedBuilds() ([]Build, error) {
 	query := buildsQuery.Where(sq.Eq{
 		"b.status": BuildStatusStarted,
@@ -157,7 +181,7 @@ func getBuilds(buildsQuery sq.SelectBuilder, conn Conn, lockFactory lock.LockFac
 }
 
 func getBuildsWithDates(buildsQuery, minMaxIdQuery sq.SelectBuilder, page Page, conn Conn, lockFactory lock.LockFactory) ([]Build, Pagination, error) {
-	var newPage = Page{Limit: page.Limit}
+	var newPage = Page{Limit: page.Limit, Secret: page.Secret}
 
 	tx, err := conn.Begin()
 	if err != nil {
@@ -166,8 +190,10 @@ func getBuildsWithDates(buildsQuery, minMaxIdQuery sq.SelectBuilder, page Page,
 
 	defer Rollback(tx)
 
+	filteredQuery := filterBuildsBySecret(buildsQuery, page.Secret)
+
 	if page.Since != 0 {
-		sinceRow, err := buildsQuery.
+		sinceRow, err := filteredQuery.
 			Where(sq.Expr("b.start_time >= to_timestamp(" + strconv.Itoa(page.Since) + ")")).
 			OrderBy("COALESCE(b.rerun_of, b.id) ASC, b.id ASC").
 			Limit(1).
@@ -208,7 +234,7 @@ func getBuildsWithDates(buildsQuery, minMaxIdQuery sq.SelectBuilder, page Page,
 	}
 
 	if page.Until != 0 {
-		untilRow, err := buildsQuery.
+		untilRow, err := filteredQuery.
 			Where(sq.Expr("b.start_time <= to_timestamp(" + strconv.Itoa(page.Until) + ")")).
 			OrderBy("COALESCE(b.rerun_of, b.id) DESC, b.id DESC").
 			Limit(1).
@@ -267,7 +293,8 @@ func getBuildsWithPagination(buildsQuery, minMaxIdQuery sq.SelectBuilder, page P
 
 	defer Rollback(tx)
 
-	buildsQuery = buildsQuery.Limit(uint64(page.Limit))
+	buildsQuery = filterBuildsBySecret(buildsQuery, page.Secret).Limit(uint64(page.Limit))
+	minMaxIdQuery = filterBuildsBySecret(minMaxIdQuery, page.Secret)
 
 	if page.Since == 0 && page.Until == 0 { // none
 		buildsQuery = buildsQuery.
@@ -342,17 +369,39 @@ func getBuildsWithPagination(buildsQuery, minMaxIdQuery sq.SelectBuilder, page P
 	var pagination Pagination
 	if first.ID() < maxID {
 		pagination.Previous = &Page{
-			Until: first.ID(),
-			Limit: page.Limit,
+			Until:  first.ID(),
+			Limit:  page.Limit,
+			Secret: page.Secret,
 		}
 	}
 
 	if last.ID() > minID {
 		pagination.Next = &Page{
-			Since: last.ID(),
-			Limit: page.Limit,
+			Since:  last.ID(),
+			Limit:  page.Limit,
+			Secret: page.Secret,
 		}
 	}
 
 	return builds, pagination, nil
 }
+
+// filterBuildsBySecret restricts the query to builds which found the secret
+// at the given path, or at a path ending with it (e.g. "/concourse/main/foo"
+// for "foo"). An empty secret leaves the query untouched.
+func filterBuildsBySecret(query sq.SelectBuilder, secret string) sq.SelectBuilder {
+	if secret == "" {
+		return query
+	}
+
+	suffix := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).
+		Replace(strings.TrimPrefix(secret, "/"))
+
+	return query.Where(sq.Expr(`EXISTS (
+		SELECT 1
+		FROM build_secret_accesses sa
+		WHERE sa.build_id = b.id
+		AND sa.found
+		AND (sa.path = ? OR sa.path LIKE ?)
+	)`, secret, "%/"+suffix))
+}
@@ -116,7 +116,7 @@ var _ = Describe("BuildFactory", func() {
 				err = build2.Finish(db.BuildStatusErrored)
 				Expect(err).NotTo(HaveOccurred())
 
-				p, _, err := defaultTeam.SavePipeline("other-pipeline", atc.Config{
+				p, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{
 							Name: "some-other-job",
@@ -251,7 +251,7 @@ var _ = Describe("BuildFactory", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			config := atc.Config{Jobs: atc.JobConfigs{{Name: "some-job"}}}
-			privatePipeline, _, err := team.SavePipeline("private-pipeline", config, db.ConfigVersion(1), false)
+			privatePipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "private-pipeline"}, config, db.ConfigVersion(1), false)
 			Expect(err).NotTo(HaveOccurred())
 
 			privateJob, found, err := privatePipeline.Job("some-job")
@@ -261,7 +261,7 @@ var _ = Describe("BuildFactory", func() {
 			build2, err = privateJob.CreateBuild()
 			Expect(err).NotTo(HaveOccurred())
 
-			publicPipeline, _, err := team.SavePipeline("public-pipeline", config, db.ConfigVersion(1), false)
+			publicPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "public-pipeline"}, config, db.ConfigVersion(1), false)
 			Expect(err).NotTo(HaveOccurred())
 			err = publicPipeline.Expose()
 			Expect(err).NotTo(HaveOccurred())
@@ -310,7 +310,7 @@ var _ = Describe("BuildFactory", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			config := atc.Config{Jobs: atc.JobConfigs{{Name: "some-job"}}}
-			privatePipeline, _, err := team.SavePipeline("private-pipeline", config, db.ConfigVersion(1), false)
+			privatePipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "private-pipeline"}, config, db.ConfigVersion(1), false)
 			Expect(err).NotTo(HaveOccurred())
 
 			privateJob, found, err := privatePipeline.Job("some-job")
@@ -320,7 +320,7 @@ var _ = Describe("BuildFactory", func() {
 			build2, err = privateJob.CreateBuild()
 			Expect(err).NotTo(HaveOccurred())
 
-			publicPipeline, _, err := team.SavePipeline("public-pipeline", config, db.ConfigVersion(1), false)
+			publicPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "public-pipeline"}, config, db.ConfigVersion(1), false)
 			Expect(err).NotTo(HaveOccurred())
 			err = publicPipeline.Expose()
 			Expect(err).NotTo(HaveOccurred())
@@ -346,6 +346,53 @@ var _ = Describe("BuildFactory", func() {
 			Expect(builds).To(HaveLen(4))
 			Expect(builds).To(ConsistOf(build1, build2, build3, build4))
 		})
+
+		Context("when filtering by a secret", func() {
+			BeforeEach(func() {
+				recorder := db.NewSecretAccessRecorder(dbConn)
+
+				Expect(recorder.RecordSecretAccess(build1.ID(), atc.SecretAccess{
+					Path:  "/concourse/main/prod-db-password",
+					Found: true,
+				})).To(Succeed())
+
+				Expect(recorder.RecordSecretAccess(build2.ID(), atc.SecretAccess{
+					Path:  "/concourse/main/private-pipeline/prod-db-password",
+					Found: false,
+				})).To(Succeed())
+
+				Expect(recorder.RecordSecretAccess(build3.ID(), atc.SecretAccess{
+					Path:  "/concourse/main/other-prod-db-password",
+					Found: true,
+				})).To(Succeed())
+
+				Expect(recorder.RecordSecretAccess(build4.ID(), atc.SecretAccess{
+					Path:  "prod-db-password",
+					Found: true,
+				})).To(Succeed())
+			})
+
+			It("returns only the builds which found the secret", func() {
+				builds, pagination, err := buildFactory.AllBuilds(db.Page{Limit: 1, Secret: "prod-db-password"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(builds).To(ConsistOf(build4))
+				Expect(pagination.Next).To(Equal(&db.Page{Since: build4.ID(), Limit: 1, Secret: "prod-db-password"}))
+
+				builds, pagination, err = buildFactory.AllBuilds(*pagination.Next)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(builds).To(ConsistOf(build1))
+				Expect(pagination.Next).To(BeNil())
+			})
+
+			It("does not treat the secret as a pattern", func() {
+				builds, _, err := buildFactory.AllBuilds(db.Page{Limit: 10, Secret: "prod_db%password"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(builds).To(BeEmpty())
+			})
+		})
 	})
 
 	Describe("PublicBuilds", func() {
@@ -356,7 +403,7 @@ var _ = Describe("BuildFactory", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			config := atc.Config{Jobs: atc.JobConfigs{{Name: "some-job"}}}
-			privatePipeline, _, err := team.SavePipeline("private-pipeline", config, db.ConfigVersion(1), false)
+			privatePipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "private-pipeline"}, config, db.ConfigVersion(1), false)
 			Expect(err).NotTo(HaveOccurred())
 
 			privateJob, found, err := privatePipeline.Job("some-job")
@@ -366,7 +413,7 @@ var _ = Describe("BuildFactory", func() {
 			_, err = privateJob.CreateBuild()
 			Expect(err).NotTo(HaveOccurred())
 
-			publicPipeline, _, err := team.SavePipeline("public-pipeline", config, db.ConfigVersion(1), false)
+			publicPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "public-pipeline"}, config, db.ConfigVersion(1), false)
 			Expect(err).NotTo(HaveOccurred())
 			err = publicPipeline.Expose()
 			Expect(err).NotTo(HaveOccurred())
@@ -392,7 +439,7 @@ var _ = Describe("BuildFactory", func() {
 		var build2DB, build3DB, build4DB db.Build
 
 		BeforeEach(func() {
-			pipeline, _, err := team.SavePipeline("other-pipeline", atc.Config{
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, atc.Config{
 				Jobs: atc.JobConfigs{
 					{
 						Name: "some-job",
@@ -442,12 +489,73 @@ var _ = Describe("BuildFactory", func() {
 		})
 	})
 
+	Describe("GetArchivableBuilds", func() {
+		var finishedBuild, archivedBuild, otherFinishedBuild db.Build
+
+		BeforeEach(func() {
+			_, err := team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+
+			finishedBuild, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(finishedBuild.Finish(db.BuildStatusSucceeded)).To(Succeed())
+
+			archivedBuild, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(archivedBuild.Finish(db.BuildStatusFailed)).To(Succeed())
+			Expect(archivedBuild.MarkEventsArchived()).To(Succeed())
+
+			otherFinishedBuild, err = team.CreateOneOffBuild()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(otherFinishedBuild.Finish(db.BuildStatusErrored)).To(Succeed())
+		})
+
+		It("returns the completed builds which have not been archived, oldest first", func() {
+			builds, err := buildFactory.GetArchivableBuilds(0, 10, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(builds).To(HaveLen(2))
+			Expect(builds[0].ID()).To(Equal(finishedBuild.ID()))
+			Expect(builds[1].ID()).To(Equal(otherFinishedBuild.ID()))
+		})
+
+		It("returns at most the given number of builds", func() {
+			builds, err := buildFactory.GetArchivableBuilds(0, 1, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].ID()).To(Equal(finishedBuild.ID()))
+		})
+
+		It("returns the builds after the given build", func() {
+			builds, err := buildFactory.GetArchivableBuilds(finishedBuild.ID(), 10, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(builds).To(HaveLen(1))
+			Expect(builds[0].ID()).To(Equal(otherFinishedBuild.ID()))
+		})
+
+		Context("when only drained builds are asked for", func() {
+			BeforeEach(func() {
+				Expect(otherFinishedBuild.SetDrained(true)).To(Succeed())
+			})
+
+			It("leaves out the builds which have not been drained", func() {
+				builds, err := buildFactory.GetArchivableBuilds(0, 1, true)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(builds).To(HaveLen(1))
+				Expect(builds[0].ID()).To(Equal(otherFinishedBuild.ID()))
+			})
+		})
+	})
+
 	Describe("GetAllStartedBuilds", func() {
 		var build1DB db.Build
 		var build2DB db.Build
 
 		BeforeEach(func() {
-			pipeline, _, err := team.SavePipeline("other-pipeline", atc.Config{
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, atc.Config{
 				Jobs: atc.JobConfigs{
 					{
 						Name: "some-job",
@@ -496,7 +604,7 @@ var _ = Describe("BuildFactory", func() {
 		var build2DB db.Build
 
 		BeforeEach(func() {
-			pipeline, _, err := team.SavePipeline("other-pipeline", atc.Config{
+			pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, atc.Config{
 				Jobs: atc.JobConfigs{
 					{
 						Name: "some-job",
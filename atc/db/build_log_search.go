@@ -0,0 +1,295 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
+)
+
+// MaxLogMatchesPerBuild caps the number of matching lines returned for a
+// single build, so that a build which printed the pattern in a loop does not
+// drown out the others.
+const MaxLogMatchesPerBuild = 100
+
+// logSearchMatch must match the expression of the pipeline_build_events_*
+// log search indexes for them to be used.
+const logSearchMatch = "to_tsvector('simple', e.payload::jsonb ->> 'payload') @@ plainto_tsquery('simple', ?)"
+
+// SearchBuildLogs returns the log lines of the pipeline's builds, or of a
+// single job's builds if jobID is not zero, which contain every word of the
+// query. The page limits the number of builds with matches that are returned,
+// newest first. The events of archived builds are gone, so they are counted
+// as not searched instead.
+func (p *pipeline) SearchBuildLogs(query string, jobID int, page Page) (atc.BuildLogSearch, Pagination, error) {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return atc.BuildLogSearch{Matches: []atc.BuildLogMatch{}}, Pagination{}, nil
+	}
+
+	if page.Limit <= 0 {
+		page.Limit = atc.PaginationAPIDefaultLimit
+	}
+
+	archivedBuilds, err := p.countArchivedBuilds(jobID, page)
+	if err != nil {
+		return atc.BuildLogSearch{}, Pagination{}, err
+	}
+
+	matches, pagination, err := p.searchBuildLogs(query, terms, jobID, page)
+	if err != nil {
+		return atc.BuildLogSearch{}, Pagination{}, err
+	}
+
+	return atc.BuildLogSearch{
+		Matches:        matches,
+		ArchivedBuilds: archivedBuilds,
+	}, pagination, nil
+}
+
+// countArchivedBuilds counts the builds within the page whose events have been
+// archived, which the search can't see.
+func (p *pipeline) countArchivedBuilds(jobID int, page Page) (int, error) {
+	query := psql.Select("COUNT(*)").
+		From("builds b").
+		Where(sq.Eq{
+			"b.pipeline_id":     p.id,
+			"b.events_archived": true,
+		})
+
+	if jobID != 0 {
+		query = query.Where(sq.Eq{"b.job_id": jobID})
+	}
+
+	if page.Until != 0 {
+		query = query.Where(sq.Lt{"b.id": page.Until})
+	}
+
+	var count int
+	err := query.RunWith(p.conn).QueryRow().Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (p *pipeline) searchBuildLogs(query string, terms []string, jobID int, page Page) ([]atc.BuildLogMatch, Pagination, error) {
+	table := fmt.Sprintf("pipeline_build_events_%d e", p.id)
+
+	buildIDsQuery := psql.Select("DISTINCT e.build_id").
+		From(table).
+		Where(sq.Eq{"e.type": string(event.EventTypeLog)}).
+		Where(sq.Expr(logSearchMatch, query)).
+		OrderBy("e.build_id DESC").
+		Limit(uint64(page.Limit + 1))
+
+	if jobID != 0 {
+		buildIDsQuery = buildIDsQuery.
+			Join("builds b ON b.id = e.build_id").
+			Where(sq.Eq{"b.job_id": jobID})
+	}
+
+	if page.Until != 0 {
+		buildIDsQuery = buildIDsQuery.Where(sq.Lt{"e.build_id": page.Until})
+	}
+
+	rows, err := buildIDsQuery.RunWith(p.conn).Query()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	defer Close(rows)
+
+	buildIDs := []int{}
+	for rows.Next() {
+		var buildID int
+		err = rows.Scan(&buildID)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		buildIDs = append(buildIDs, buildID)
+	}
+
+	if len(buildIDs) == 0 {
+		return []atc.BuildLogMatch{}, Pagination{}, nil
+	}
+
+	var pagination Pagination
+	if len(buildIDs) > page.Limit {
+		buildIDs = buildIDs[:page.Limit]
+		pagination.Next = &Page{
+			Until: buildIDs[len(buildIDs)-1],
+			Limit: page.Limit,
+		}
+	}
+
+	builds, err := p.logSearchBuilds(buildIDs)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	rows, err = psql.Select("e.build_id", "e.payload").
+		From(table).
+		Where(sq.Eq{"e.type": string(event.EventTypeLog)}).
+		Where(sq.Expr(logSearchMatch, query)).
+		Where(sq.Eq{"e.build_id": buildIDs}).
+		OrderBy("e.build_id DESC", "e.event_id ASC").
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	defer Close(rows)
+
+	matches := []atc.BuildLogMatch{}
+	matchesPerBuild := map[int]int{}
+	for rows.Next() {
+		var (
+			buildID int
+			payload string
+		)
+
+		err = rows.Scan(&buildID, &payload)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		if matchesPerBuild[buildID] >= MaxLogMatchesPerBuild {
+			continue
+		}
+
+		var log event.Log
+		err = json.Unmarshal([]byte(payload), &log)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		build := builds[buildID]
+
+		// the full-text search matches whole words anywhere in the event, so
+		// narrow it down to the lines which actually contain the query
+		for _, line := range strings.Split(log.Payload, "\n") {
+			line = strings.TrimRight(line, "\r")
+			if !containsAll(strings.ToLower(line), terms) {
+				continue
+			}
+
+			matches = append(matches, atc.BuildLogMatch{
+				BuildID:   buildID,
+				BuildName: build.name,
+				JobName:   build.jobName,
+				StepID:    string(log.Origin.ID),
+				StepName:  build.stepNames[string(log.Origin.ID)],
+				Source:    string(log.Origin.Source),
+				Time:      log.Time,
+				Line:      line,
+			})
+
+			matchesPerBuild[buildID]++
+			if matchesPerBuild[buildID] >= MaxLogMatchesPerBuild {
+				break
+			}
+		}
+	}
+
+	return matches, pagination, nil
+}
+
+type logSearchBuild struct {
+	name      string
+	jobName   string
+	stepNames map[string]string
+}
+
+func (p *pipeline) logSearchBuilds(buildIDs []int) (map[int]logSearchBuild, error) {
+	rows, err := psql.Select("b.id", "b.name", "j.name", "b.public_plan").
+		From("builds b").
+		LeftJoin("jobs j ON j.id = b.job_id").
+		Where(sq.Eq{"b.id": buildIDs}).
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	builds := map[int]logSearchBuild{}
+	for rows.Next() {
+		var (
+			id                  int
+			name                string
+			jobName, publicPlan sql.NullString
+		)
+
+		err = rows.Scan(&id, &name, &jobName, &publicPlan)
+		if err != nil {
+			return nil, err
+		}
+
+		stepNames := map[string]string{}
+		if publicPlan.Valid {
+			var plan interface{}
+			err = json.Unmarshal([]byte(publicPlan.String), &plan)
+			if err != nil {
+				return nil, err
+			}
+
+			collectStepNames(plan, stepNames)
+		}
+
+		builds[id] = logSearchBuild{
+			name:      name,
+			jobName:   jobName.String,
+			stepNames: stepNames,
+		}
+	}
+
+	return builds, nil
+}
+
+// collectStepNames walks a public build plan, recording the name of each step
+// by its plan ID, which is what log events refer to as their origin.
+func collectStepNames(node interface{}, stepNames map[string]string) {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		if id, ok := value["id"].(string); ok {
+			for _, stepType := range []string{"get", "put", "task", "check", "set_pipeline", "load_var"} {
+				step, ok := value[stepType].(map[string]interface{})
+				if !ok {
+					continue
+				}
+
+				if name, ok := step["name"].(string); ok {
+					stepNames[id] = name
+				}
+			}
+		}
+
+		for _, v := range value {
+			collectStepNames(v, stepNames)
+		}
+
+	case []interface{}:
+		for _, v := range value {
+			collectStepNames(v, stepNames)
+		}
+	}
+}
+
+func containsAll(line string, terms []string) bool {
+	for _, term := range terms {
+		if !strings.Contains(line, term) {
+			return false
+		}
+	}
+
+	return true
+}
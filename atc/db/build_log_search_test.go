@@ -0,0 +1,172 @@
+package db_test
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SearchBuildLogs", func() {
+	var (
+		pipeline db.Pipeline
+		otherJob db.Job
+
+		firstBuild, secondBuild, otherJobBuild db.Build
+	)
+
+	saveLog := func(build db.Build, stepID string, payload string) {
+		Expect(build.SaveEvent(event.Log{
+			Time:    42,
+			Origin:  event.Origin{ID: event.OriginID(stepID), Source: event.OriginSourceStdout},
+			Payload: payload,
+		})).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		var err error
+		pipeline, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "search-pipeline"}, atc.Config{
+			Jobs: atc.JobConfigs{
+				{Name: "some-job"},
+				{Name: "other-job"},
+			},
+		}, db.ConfigVersion(0), false)
+		Expect(err).NotTo(HaveOccurred())
+
+		job, found, err := pipeline.Job("some-job")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		otherJob, found, err = pipeline.Job("other-job")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		plan := atc.Plan{
+			ID: "some-do",
+			Do: &atc.DoPlan{
+				{ID: "some-task", Task: &atc.TaskPlan{Name: "unit"}},
+			},
+		}
+
+		firstBuild, err = job.CreateBuild()
+		Expect(err).NotTo(HaveOccurred())
+		_, err = firstBuild.Start(plan)
+		Expect(err).NotTo(HaveOccurred())
+		saveLog(firstBuild, "some-task", "compiling\nerror: connection refused\n")
+
+		secondBuild, err = job.CreateBuild()
+		Expect(err).NotTo(HaveOccurred())
+		_, err = secondBuild.Start(plan)
+		Expect(err).NotTo(HaveOccurred())
+		saveLog(secondBuild, "some-task", "all good\n")
+		saveLog(secondBuild, "some-task", "Error: Connection refused by peer\n")
+
+		otherJobBuild, err = otherJob.CreateBuild()
+		Expect(err).NotTo(HaveOccurred())
+		saveLog(otherJobBuild, "some-other-step", "connection refused\n")
+	})
+
+	It("returns the matching lines of the pipeline's builds, newest first", func() {
+		search, pagination, err := pipeline.SearchBuildLogs("connection refused", 0, db.Page{Limit: 10})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pagination.Next).To(BeNil())
+		Expect(search.ArchivedBuilds).To(BeZero())
+
+		Expect(search.Matches).To(Equal([]atc.BuildLogMatch{
+			{
+				BuildID:   otherJobBuild.ID(),
+				BuildName: otherJobBuild.Name(),
+				JobName:   "other-job",
+				StepID:    "some-other-step",
+				Source:    "stdout",
+				Time:      42,
+				Line:      "connection refused",
+			},
+			{
+				BuildID:   secondBuild.ID(),
+				BuildName: secondBuild.Name(),
+				JobName:   "some-job",
+				StepID:    "some-task",
+				StepName:  "unit",
+				Source:    "stdout",
+				Time:      42,
+				Line:      "Error: Connection refused by peer",
+			},
+			{
+				BuildID:   firstBuild.ID(),
+				BuildName: firstBuild.Name(),
+				JobName:   "some-job",
+				StepID:    "some-task",
+				StepName:  "unit",
+				Source:    "stdout",
+				Time:      42,
+				Line:      "error: connection refused",
+			},
+		}))
+	})
+
+	It("only searches the builds of the given job", func() {
+		search, _, err := pipeline.SearchBuildLogs("connection refused", otherJob.ID(), db.Page{Limit: 10})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(search.Matches).To(HaveLen(1))
+		Expect(search.Matches[0].BuildID).To(Equal(otherJobBuild.ID()))
+	})
+
+	It("paginates by build", func() {
+		search, pagination, err := pipeline.SearchBuildLogs("refused", 0, db.Page{Limit: 2})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(search.Matches).To(HaveLen(2))
+		Expect(pagination.Next).To(Equal(&db.Page{Until: secondBuild.ID(), Limit: 2}))
+
+		search, pagination, err = pipeline.SearchBuildLogs("refused", 0, *pagination.Next)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(search.Matches).To(HaveLen(1))
+		Expect(search.Matches[0].BuildID).To(Equal(firstBuild.ID()))
+		Expect(pagination.Next).To(BeNil())
+	})
+
+	It("caps the number of matching lines per build", func() {
+		for i := 0; i <= db.MaxLogMatchesPerBuild; i++ {
+			saveLog(firstBuild, "some-task", fmt.Sprintf("timeout %d\n", i))
+		}
+
+		search, _, err := pipeline.SearchBuildLogs("timeout", 0, db.Page{Limit: 10})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(search.Matches).To(HaveLen(db.MaxLogMatchesPerBuild))
+	})
+
+	It("does not match lines which only partly contain the query", func() {
+		search, _, err := pipeline.SearchBuildLogs("compiling refused", 0, db.Page{Limit: 10})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(search.Matches).To(BeEmpty())
+	})
+
+	Context("when the events of a build have been archived", func() {
+		BeforeEach(func() {
+			Expect(firstBuild.MarkEventsArchived()).To(Succeed())
+		})
+
+		It("counts it as not searched", func() {
+			search, _, err := pipeline.SearchBuildLogs("connection refused", 0, db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(search.ArchivedBuilds).To(Equal(1))
+
+			Expect(search.Matches).To(HaveLen(2))
+			Expect(search.Matches[0].BuildID).To(Equal(otherJobBuild.ID()))
+			Expect(search.Matches[1].BuildID).To(Equal(secondBuild.ID()))
+		})
+
+		It("does not count it when searching the builds of another job", func() {
+			search, _, err := pipeline.SearchBuildLogs("connection refused", otherJob.ID(), db.Page{Limit: 10})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(search.ArchivedBuilds).To(BeZero())
+		})
+	})
+})
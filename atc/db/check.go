@@ -43,6 +43,7 @@ type Check interface {
 	ManuallyTriggered() bool
 
 	Start() error
+	SetRateLimitDelay(time.Duration) error
 	Finish() error
 	FinishWithError(err error) error
 
@@ -92,12 +93,13 @@ type check struct {
 }
 
 type CheckMetadata struct {
-	TeamID             int    `json:"team_id"`
-	TeamName           string `json:"team_name"`
-	PipelineID         int    `json:"pipeline_id"`
-	PipelineName       string `json:"pipeline_name"`
-	ResourceConfigID   int    `json:"resource_config_id"`
-	BaseResourceTypeID int    `json:"base_resource_type_id"`
+	TeamID               int              `json:"team_id"`
+	TeamName             string           `json:"team_name"`
+	PipelineID           int              `json:"pipeline_id"`
+	PipelineName         string           `json:"pipeline_name"`
+	PipelineInstanceVars atc.InstanceVars `json:"pipeline_instance_vars,omitempty"`
+	ResourceConfigID     int              `json:"resource_config_id"`
+	BaseResourceTypeID   int              `json:"base_resource_type_id"`
 }
 
 func newEmptyCheck(conn Conn, lockFactory lock.LockFactory) *check {
@@ -190,6 +192,17 @@ func (c *check) Start() error {
 	return nil
 }
 
+func (c *check) SetRateLimitDelay(delay time.Duration) error {
+	_, err := psql.Update("resource_config_scopes").
+		Set("last_check_rate_limit_delay", int64(delay)).
+		Where(sq.Eq{
+			"id": c.resourceConfigScopeID,
+		}).
+		RunWith(c.conn).
+		Exec()
+	return err
+}
+
 func (c *check) Finish() error {
 	return c.finish(CheckStatusSucceeded, nil)
 }
@@ -234,9 +247,13 @@ func (c *check) finish(status CheckStatus, checkError error) error {
 		})
 
 	if checkError != nil {
-		builder = builder.Set("check_error", checkError.Error())
+		builder = builder.
+			Set("check_error", checkError.Error()).
+			Set("consecutive_check_failures", sq.Expr("consecutive_check_failures + 1"))
 	} else {
-		builder = builder.Set("check_error", nil)
+		builder = builder.
+			Set("check_error", nil).
+			Set("consecutive_check_failures", 0)
 	}
 
 	_, err = builder.
@@ -396,6 +413,7 @@ func scanCheck(c *check, row scannable) error {
 
 	c.pipelineID = c.metadata.PipelineID
 	c.pipelineName = c.metadata.PipelineName
+	c.pipelineInstanceVars = c.metadata.PipelineInstanceVars
 
 	if checkError.Valid {
 		c.checkError = errors.New(checkError.String)
@@ -30,6 +30,8 @@ type Checkable interface {
 	CheckEvery() string
 	CheckTimeout() string
 	LastCheckEndTime() time.Time
+	ConsecutiveCheckFailures() int
+	IdleSince() time.Time
 	CurrentPinnedVersion() atc.Version
 
 	HasWebhook() bool
@@ -212,12 +214,13 @@ func (c *checkFactory) TryCreateCheck(ctx context.Context, checkable Checkable,
 	}
 
 	meta := CheckMetadata{
-		TeamID:             checkable.TeamID(),
-		TeamName:           checkable.TeamName(),
-		PipelineName:       checkable.PipelineName(),
-		PipelineID:         checkable.PipelineID(),
-		ResourceConfigID:   resourceConfigScope.ResourceConfig().ID(),
-		BaseResourceTypeID: resourceConfigScope.ResourceConfig().OriginBaseResourceType().ID,
+		TeamID:               checkable.TeamID(),
+		TeamName:             checkable.TeamName(),
+		PipelineName:         checkable.PipelineName(),
+		PipelineInstanceVars: checkable.PipelineInstanceVars(),
+		PipelineID:           checkable.PipelineID(),
+		ResourceConfigID:     resourceConfigScope.ResourceConfig().ID(),
+		BaseResourceTypeID:   resourceConfigScope.ResourceConfig().OriginBaseResourceType().ID,
 	}
 
 	check, created, err := c.CreateCheck(
@@ -306,6 +309,20 @@ func (c *checkFactory) CreateCheck(
 		return nil, false, err
 	}
 
+	if manuallyTriggered {
+		// a manual check or a webhook hints at new versions, so check the
+		// resource at its regular interval again rather than an adaptive one
+		_, err = psql.Update("resource_config_scopes").
+			Set("consecutive_check_failures", 0).
+			Set("idle_since", sq.Expr("now()")).
+			Where(sq.Eq{"id": resourceConfigScopeID}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return nil, false, err
@@ -321,10 +338,11 @@ func (c *checkFactory) CreateCheck(
 		metadata:              meta,
 
 		pipelineRef: pipelineRef{
-			conn:         c.conn,
-			lockFactory:  c.lockFactory,
-			pipelineID:   meta.PipelineID,
-			pipelineName: meta.PipelineName,
+			conn:                 c.conn,
+			lockFactory:          c.lockFactory,
+			pipelineID:           meta.PipelineID,
+			pipelineName:         meta.PipelineName,
+			pipelineInstanceVars: meta.PipelineInstanceVars,
 		},
 
 		spanContext: sc,
@@ -315,11 +315,16 @@ var _ = Describe("CheckFactory", func() {
 	Describe("CreateCheck", func() {
 		var created bool
 		var check db.Check
+		var manuallyTriggered bool
+
+		BeforeEach(func() {
+			manuallyTriggered = false
+		})
 
 		JustBeforeEach(func() {
 			check, created, err = checkFactory.CreateCheck(
 				resourceConfigScope.ID(),
-				false,
+				manuallyTriggered,
 				atc.Plan{Check: &atc.CheckPlan{Name: "some-name", Type: "some-type"}},
 				metadata,
 				map[string]string{"fake": "span"},
@@ -362,6 +367,48 @@ var _ = Describe("CheckFactory", func() {
 				Expect(err).NotTo(HaveOccurred())
 			})
 		})
+
+		Context("when the resource has been failing to check", func() {
+			BeforeEach(func() {
+				failedCheck, created, err := checkFactory.CreateCheck(
+					resourceConfigScope.ID(),
+					false,
+					atc.Plan{},
+					metadata,
+					map[string]string{"fake": "span"},
+				)
+				Expect(created).To(BeTrue())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(failedCheck.FinishWithError(errors.New("nope"))).To(Succeed())
+			})
+
+			Context("when the check is triggered manually", func() {
+				BeforeEach(func() {
+					manuallyTriggered = true
+				})
+
+				It("resets the adaptive check interval of the resource", func() {
+					Expect(created).To(BeTrue())
+
+					reloaded, err := defaultResource.Reload()
+					Expect(reloaded).To(BeTrue())
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(defaultResource.ConsecutiveCheckFailures()).To(BeZero())
+					Expect(defaultResource.IdleSince()).To(BeTemporally("~", time.Now(), time.Second))
+				})
+			})
+
+			Context("when the check is not triggered manually", func() {
+				It("keeps counting the check failures", func() {
+					_, err := defaultResource.Reload()
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(defaultResource.ConsecutiveCheckFailures()).To(Equal(1))
+				})
+			})
+		})
 	})
 
 	Describe("StartedChecks", func() {
@@ -429,7 +476,7 @@ var _ = Describe("CheckFactory", func() {
 			var nonManuallyTriggeredCheck, manuallyTriggeredCheck db.Check
 
 			BeforeEach(func() {
-				defaultPipeline, _, err = defaultTeam.SavePipeline("default-pipeline", atc.Config{
+				defaultPipeline, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "default-pipeline"}, atc.Config{
 					Resources: atc.ResourceConfigs{
 						{
 							Name: "some-resource",
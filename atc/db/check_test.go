@@ -77,6 +77,22 @@ var _ = Describe("Check", func() {
 		})
 	})
 
+	Describe("SetRateLimitDelay", func() {
+		JustBeforeEach(func() {
+			err = check.SetRateLimitDelay(3 * time.Second)
+		})
+
+		It("succeeds", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("updates resource last check rate limit delay", func() {
+			defaultResource.Reload()
+
+			Expect(defaultResource.LastCheckRateLimitDelay()).To(Equal(3 * time.Second))
+		})
+	})
+
 	Describe("Finish", func() {
 		JustBeforeEach(func() {
 			err = check.Finish()
@@ -111,6 +127,18 @@ var _ = Describe("Check", func() {
 
 			Expect(defaultResource.CheckError()).To(BeNil())
 		})
+
+		Context("when previous checks failed", func() {
+			BeforeEach(func() {
+				Expect(check.FinishWithError(errors.New("nope"))).To(Succeed())
+			})
+
+			It("resets the consecutive check failures of the resource", func() {
+				defaultResource.Reload()
+
+				Expect(defaultResource.ConsecutiveCheckFailures()).To(BeZero())
+			})
+		})
 	})
 
 	Describe("FinishWithError", func() {
@@ -142,6 +170,14 @@ var _ = Describe("Check", func() {
 			Expect(defaultResource.LastCheckEndTime()).To(BeTemporally("~", time.Now(), time.Second))
 			Expect(defaultResource.CheckError()).To(Equal(errors.New("nope")))
 		})
+
+		It("counts the consecutive check failures of the resource", func() {
+			Expect(check.FinishWithError(errors.New("nope again"))).To(Succeed())
+
+			defaultResource.Reload()
+
+			Expect(defaultResource.ConsecutiveCheckFailures()).To(Equal(2))
+		})
 	})
 
 	Describe("AllCheckables", func() {
@@ -18,6 +18,9 @@ type ContainerMetadata struct {
 	PipelineName string
 	JobName      string
 	BuildName    string
+
+	CPULimit    uint64
+	MemoryLimit uint64
 }
 
 type ContainerType string
@@ -91,6 +94,14 @@ func (metadata ContainerMetadata) SQLMap() map[string]interface{} {
 		m["meta_build_name"] = metadata.BuildName
 	}
 
+	if metadata.CPULimit != 0 {
+		m["meta_cpu_limit"] = metadata.CPULimit
+	}
+
+	if metadata.MemoryLimit != 0 {
+		m["meta_memory_limit"] = metadata.MemoryLimit
+	}
+
 	return m
 }
 
@@ -106,6 +117,8 @@ var containerMetadataColumns = []string{
 	"meta_pipeline_name",
 	"meta_job_name",
 	"meta_build_name",
+	"meta_cpu_limit",
+	"meta_memory_limit",
 }
 
 func (metadata *ContainerMetadata) ScanTargets() []interface{} {
@@ -121,5 +134,7 @@ func (metadata *ContainerMetadata) ScanTargets() []interface{} {
 		&metadata.PipelineName,
 		&metadata.JobName,
 		&metadata.BuildName,
+		&metadata.CPULimit,
+		&metadata.MemoryLimit,
 	}
 }
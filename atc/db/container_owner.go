@@ -98,6 +98,13 @@ func NewBuildStepContainerOwner(
 	}
 }
 
+// IsBuildStepContainerOwner reports whether the owner is a step of a build,
+// whose containers count towards the team's active containers quota.
+func IsBuildStepContainerOwner(owner ContainerOwner) bool {
+	_, ok := owner.(buildStepContainerOwner)
+	return ok
+}
+
 type buildStepContainerOwner struct {
 	BuildID int
 	PlanID  atc.PlanID
@@ -81,6 +81,9 @@ var (
 
 		WorkingDirectory: "/some/work/dir",
 		User:             "some-user",
+
+		CPULimit:    1024,
+		MemoryLimit: 2048,
 	}
 
 	psql = sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
@@ -182,7 +185,7 @@ var _ = BeforeEach(func() {
 		},
 	}
 
-	defaultPipeline, _, err = defaultTeam.SavePipeline("default-pipeline", defaultPipelineConfig, db.ConfigVersion(0), false)
+	defaultPipeline, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "default-pipeline"}, defaultPipelineConfig, db.ConfigVersion(0), false)
 	Expect(err).NotTo(HaveOccurred())
 
 	var found bool
@@ -42,6 +42,17 @@ type FakeBuild struct {
 		result2 bool
 		result3 error
 	}
+	AddResourceUsageStub        func(atc.ResourceUsage) error
+	addResourceUsageMutex       sync.RWMutex
+	addResourceUsageArgsForCall []struct {
+		arg1 atc.ResourceUsage
+	}
+	addResourceUsageReturns struct {
+		result1 error
+	}
+	addResourceUsageReturnsOnCall map[int]struct {
+		result1 error
+	}
 	AdoptInputsAndPipesStub        func() ([]db.BuildInput, bool, error)
 	adoptInputsAndPipesMutex       sync.RWMutex
 	adoptInputsAndPipesArgsForCall []struct {
@@ -130,6 +141,16 @@ type FakeBuild struct {
 		result1 db.EventSource
 		result2 error
 	}
+	EventsArchivedStub        func() bool
+	eventsArchivedMutex       sync.RWMutex
+	eventsArchivedArgsForCall []struct {
+	}
+	eventsArchivedReturns struct {
+		result1 bool
+	}
+	eventsArchivedReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	FinishStub        func(db.BuildStatus) error
 	finishMutex       sync.RWMutex
 	finishArgsForCall []struct {
@@ -284,6 +305,16 @@ type FakeBuild struct {
 	markAsAbortedReturnsOnCall map[int]struct {
 		result1 error
 	}
+	MarkEventsArchivedStub        func() error
+	markEventsArchivedMutex       sync.RWMutex
+	markEventsArchivedArgsForCall []struct {
+	}
+	markEventsArchivedReturns struct {
+		result1 error
+	}
+	markEventsArchivedReturnsOnCall map[int]struct {
+		result1 error
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -318,6 +349,16 @@ type FakeBuild struct {
 	pipelineIDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	PipelineInstanceVarsStub        func() atc.InstanceVars
+	pipelineInstanceVarsMutex       sync.RWMutex
+	pipelineInstanceVarsArgsForCall []struct {
+	}
+	pipelineInstanceVarsReturns struct {
+		result1 atc.InstanceVars
+	}
+	pipelineInstanceVarsReturnsOnCall map[int]struct {
+		result1 atc.InstanceVars
+	}
 	PipelineNameStub        func() string
 	pipelineNameMutex       sync.RWMutex
 	pipelineNameArgsForCall []struct {
@@ -328,6 +369,16 @@ type FakeBuild struct {
 	pipelineNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	PipelineRefStub        func() atc.PipelineRef
+	pipelineRefMutex       sync.RWMutex
+	pipelineRefArgsForCall []struct {
+	}
+	pipelineRefReturns struct {
+		result1 atc.PipelineRef
+	}
+	pipelineRefReturnsOnCall map[int]struct {
+		result1 atc.PipelineRef
+	}
 	PreparationStub        func() (db.BuildPreparation, bool, error)
 	preparationMutex       sync.RWMutex
 	preparationArgsForCall []struct {
@@ -342,6 +393,16 @@ type FakeBuild struct {
 		result2 bool
 		result3 error
 	}
+	PriorityStub        func() int
+	priorityMutex       sync.RWMutex
+	priorityArgsForCall []struct {
+	}
+	priorityReturns struct {
+		result1 int
+	}
+	priorityReturnsOnCall map[int]struct {
+		result1 int
+	}
 	PrivatePlanStub        func() atc.Plan
 	privatePlanMutex       sync.RWMutex
 	privatePlanArgsForCall []struct {
@@ -362,6 +423,16 @@ type FakeBuild struct {
 	publicPlanReturnsOnCall map[int]struct {
 		result1 *json.RawMessage
 	}
+	QuotaExceededStub        func() bool
+	quotaExceededMutex       sync.RWMutex
+	quotaExceededArgsForCall []struct {
+	}
+	quotaExceededReturns struct {
+		result1 bool
+	}
+	quotaExceededReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	ReapTimeStub        func() time.Time
 	reapTimeMutex       sync.RWMutex
 	reapTimeArgsForCall []struct {
@@ -414,6 +485,16 @@ type FakeBuild struct {
 	rerunOfNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	ResourceUsageStub        func() atc.ResourceUsage
+	resourceUsageMutex       sync.RWMutex
+	resourceUsageArgsForCall []struct {
+	}
+	resourceUsageReturns struct {
+		result1 atc.ResourceUsage
+	}
+	resourceUsageReturnsOnCall map[int]struct {
+		result1 atc.ResourceUsage
+	}
 	ResourcesStub        func() ([]db.BuildInput, []db.BuildOutput, error)
 	resourcesMutex       sync.RWMutex
 	resourcesArgsForCall []struct {
@@ -479,10 +560,10 @@ type FakeBuild struct {
 	saveOutputReturnsOnCall map[int]struct {
 		result1 error
 	}
-	SavePipelineStub        func(string, int, atc.Config, db.ConfigVersion, bool) (db.Pipeline, bool, error)
+	SavePipelineStub        func(atc.PipelineRef, int, atc.Config, db.ConfigVersion, bool) (db.Pipeline, bool, error)
 	savePipelineMutex       sync.RWMutex
 	savePipelineArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 int
 		arg3 atc.Config
 		arg4 db.ConfigVersion
@@ -508,6 +589,18 @@ type FakeBuild struct {
 	schemaReturnsOnCall map[int]struct {
 		result1 string
 	}
+	SecretAccessesStub        func() ([]atc.SecretAccess, error)
+	secretAccessesMutex       sync.RWMutex
+	secretAccessesArgsForCall []struct {
+	}
+	secretAccessesReturns struct {
+		result1 []atc.SecretAccess
+		result2 error
+	}
+	secretAccessesReturnsOnCall map[int]struct {
+		result1 []atc.SecretAccess
+		result2 error
+	}
 	SetDrainedStub        func(bool) error
 	setDrainedMutex       sync.RWMutex
 	setDrainedArgsForCall []struct {
@@ -719,6 +812,66 @@ func (fake *FakeBuild) AcquireTrackingLockReturnsOnCall(i int, result1 lock.Lock
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) AddResourceUsage(arg1 atc.ResourceUsage) error {
+	fake.addResourceUsageMutex.Lock()
+	ret, specificReturn := fake.addResourceUsageReturnsOnCall[len(fake.addResourceUsageArgsForCall)]
+	fake.addResourceUsageArgsForCall = append(fake.addResourceUsageArgsForCall, struct {
+		arg1 atc.ResourceUsage
+	}{arg1})
+	fake.recordInvocation("AddResourceUsage", []interface{}{arg1})
+	fake.addResourceUsageMutex.Unlock()
+	if fake.AddResourceUsageStub != nil {
+		return fake.AddResourceUsageStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.addResourceUsageReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) AddResourceUsageCallCount() int {
+	fake.addResourceUsageMutex.RLock()
+	defer fake.addResourceUsageMutex.RUnlock()
+	return len(fake.addResourceUsageArgsForCall)
+}
+
+func (fake *FakeBuild) AddResourceUsageCalls(stub func(atc.ResourceUsage) error) {
+	fake.addResourceUsageMutex.Lock()
+	defer fake.addResourceUsageMutex.Unlock()
+	fake.AddResourceUsageStub = stub
+}
+
+func (fake *FakeBuild) AddResourceUsageArgsForCall(i int) atc.ResourceUsage {
+	fake.addResourceUsageMutex.RLock()
+	defer fake.addResourceUsageMutex.RUnlock()
+	argsForCall := fake.addResourceUsageArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) AddResourceUsageReturns(result1 error) {
+	fake.addResourceUsageMutex.Lock()
+	defer fake.addResourceUsageMutex.Unlock()
+	fake.AddResourceUsageStub = nil
+	fake.addResourceUsageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) AddResourceUsageReturnsOnCall(i int, result1 error) {
+	fake.addResourceUsageMutex.Lock()
+	defer fake.addResourceUsageMutex.Unlock()
+	fake.AddResourceUsageStub = nil
+	if fake.addResourceUsageReturnsOnCall == nil {
+		fake.addResourceUsageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.addResourceUsageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) AdoptInputsAndPipes() ([]db.BuildInput, bool, error) {
 	fake.adoptInputsAndPipesMutex.Lock()
 	ret, specificReturn := fake.adoptInputsAndPipesReturnsOnCall[len(fake.adoptInputsAndPipesArgsForCall)]
@@ -1123,6 +1276,58 @@ func (fake *FakeBuild) EventsReturnsOnCall(i int, result1 db.EventSource, result
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) EventsArchived() bool {
+	fake.eventsArchivedMutex.Lock()
+	ret, specificReturn := fake.eventsArchivedReturnsOnCall[len(fake.eventsArchivedArgsForCall)]
+	fake.eventsArchivedArgsForCall = append(fake.eventsArchivedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("EventsArchived", []interface{}{})
+	fake.eventsArchivedMutex.Unlock()
+	if fake.EventsArchivedStub != nil {
+		return fake.EventsArchivedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.eventsArchivedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) EventsArchivedCallCount() int {
+	fake.eventsArchivedMutex.RLock()
+	defer fake.eventsArchivedMutex.RUnlock()
+	return len(fake.eventsArchivedArgsForCall)
+}
+
+func (fake *FakeBuild) EventsArchivedCalls(stub func() bool) {
+	fake.eventsArchivedMutex.Lock()
+	defer fake.eventsArchivedMutex.Unlock()
+	fake.EventsArchivedStub = stub
+}
+
+func (fake *FakeBuild) EventsArchivedReturns(result1 bool) {
+	fake.eventsArchivedMutex.Lock()
+	defer fake.eventsArchivedMutex.Unlock()
+	fake.EventsArchivedStub = nil
+	fake.eventsArchivedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) EventsArchivedReturnsOnCall(i int, result1 bool) {
+	fake.eventsArchivedMutex.Lock()
+	defer fake.eventsArchivedMutex.Unlock()
+	fake.EventsArchivedStub = nil
+	if fake.eventsArchivedReturnsOnCall == nil {
+		fake.eventsArchivedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.eventsArchivedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeBuild) Finish(arg1 db.BuildStatus) error {
 	fake.finishMutex.Lock()
 	ret, specificReturn := fake.finishReturnsOnCall[len(fake.finishArgsForCall)]
@@ -1922,6 +2127,58 @@ func (fake *FakeBuild) MarkAsAbortedReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) MarkEventsArchived() error {
+	fake.markEventsArchivedMutex.Lock()
+	ret, specificReturn := fake.markEventsArchivedReturnsOnCall[len(fake.markEventsArchivedArgsForCall)]
+	fake.markEventsArchivedArgsForCall = append(fake.markEventsArchivedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("MarkEventsArchived", []interface{}{})
+	fake.markEventsArchivedMutex.Unlock()
+	if fake.MarkEventsArchivedStub != nil {
+		return fake.MarkEventsArchivedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.markEventsArchivedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) MarkEventsArchivedCallCount() int {
+	fake.markEventsArchivedMutex.RLock()
+	defer fake.markEventsArchivedMutex.RUnlock()
+	return len(fake.markEventsArchivedArgsForCall)
+}
+
+func (fake *FakeBuild) MarkEventsArchivedCalls(stub func() error) {
+	fake.markEventsArchivedMutex.Lock()
+	defer fake.markEventsArchivedMutex.Unlock()
+	fake.MarkEventsArchivedStub = stub
+}
+
+func (fake *FakeBuild) MarkEventsArchivedReturns(result1 error) {
+	fake.markEventsArchivedMutex.Lock()
+	defer fake.markEventsArchivedMutex.Unlock()
+	fake.MarkEventsArchivedStub = nil
+	fake.markEventsArchivedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) MarkEventsArchivedReturnsOnCall(i int, result1 error) {
+	fake.markEventsArchivedMutex.Lock()
+	defer fake.markEventsArchivedMutex.Unlock()
+	fake.MarkEventsArchivedStub = nil
+	if fake.markEventsArchivedReturnsOnCall == nil {
+		fake.markEventsArchivedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.markEventsArchivedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -2084,6 +2341,58 @@ func (fake *FakeBuild) PipelineIDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeBuild) PipelineInstanceVars() atc.InstanceVars {
+	fake.pipelineInstanceVarsMutex.Lock()
+	ret, specificReturn := fake.pipelineInstanceVarsReturnsOnCall[len(fake.pipelineInstanceVarsArgsForCall)]
+	fake.pipelineInstanceVarsArgsForCall = append(fake.pipelineInstanceVarsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PipelineInstanceVars", []interface{}{})
+	fake.pipelineInstanceVarsMutex.Unlock()
+	if fake.PipelineInstanceVarsStub != nil {
+		return fake.PipelineInstanceVarsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.pipelineInstanceVarsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) PipelineInstanceVarsCallCount() int {
+	fake.pipelineInstanceVarsMutex.RLock()
+	defer fake.pipelineInstanceVarsMutex.RUnlock()
+	return len(fake.pipelineInstanceVarsArgsForCall)
+}
+
+func (fake *FakeBuild) PipelineInstanceVarsCalls(stub func() atc.InstanceVars) {
+	fake.pipelineInstanceVarsMutex.Lock()
+	defer fake.pipelineInstanceVarsMutex.Unlock()
+	fake.PipelineInstanceVarsStub = stub
+}
+
+func (fake *FakeBuild) PipelineInstanceVarsReturns(result1 atc.InstanceVars) {
+	fake.pipelineInstanceVarsMutex.Lock()
+	defer fake.pipelineInstanceVarsMutex.Unlock()
+	fake.PipelineInstanceVarsStub = nil
+	fake.pipelineInstanceVarsReturns = struct {
+		result1 atc.InstanceVars
+	}{result1}
+}
+
+func (fake *FakeBuild) PipelineInstanceVarsReturnsOnCall(i int, result1 atc.InstanceVars) {
+	fake.pipelineInstanceVarsMutex.Lock()
+	defer fake.pipelineInstanceVarsMutex.Unlock()
+	fake.PipelineInstanceVarsStub = nil
+	if fake.pipelineInstanceVarsReturnsOnCall == nil {
+		fake.pipelineInstanceVarsReturnsOnCall = make(map[int]struct {
+			result1 atc.InstanceVars
+		})
+	}
+	fake.pipelineInstanceVarsReturnsOnCall[i] = struct {
+		result1 atc.InstanceVars
+	}{result1}
+}
+
 func (fake *FakeBuild) PipelineName() string {
 	fake.pipelineNameMutex.Lock()
 	ret, specificReturn := fake.pipelineNameReturnsOnCall[len(fake.pipelineNameArgsForCall)]
@@ -2136,6 +2445,58 @@ func (fake *FakeBuild) PipelineNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeBuild) PipelineRef() atc.PipelineRef {
+	fake.pipelineRefMutex.Lock()
+	ret, specificReturn := fake.pipelineRefReturnsOnCall[len(fake.pipelineRefArgsForCall)]
+	fake.pipelineRefArgsForCall = append(fake.pipelineRefArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PipelineRef", []interface{}{})
+	fake.pipelineRefMutex.Unlock()
+	if fake.PipelineRefStub != nil {
+		return fake.PipelineRefStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.pipelineRefReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) PipelineRefCallCount() int {
+	fake.pipelineRefMutex.RLock()
+	defer fake.pipelineRefMutex.RUnlock()
+	return len(fake.pipelineRefArgsForCall)
+}
+
+func (fake *FakeBuild) PipelineRefCalls(stub func() atc.PipelineRef) {
+	fake.pipelineRefMutex.Lock()
+	defer fake.pipelineRefMutex.Unlock()
+	fake.PipelineRefStub = stub
+}
+
+func (fake *FakeBuild) PipelineRefReturns(result1 atc.PipelineRef) {
+	fake.pipelineRefMutex.Lock()
+	defer fake.pipelineRefMutex.Unlock()
+	fake.PipelineRefStub = nil
+	fake.pipelineRefReturns = struct {
+		result1 atc.PipelineRef
+	}{result1}
+}
+
+func (fake *FakeBuild) PipelineRefReturnsOnCall(i int, result1 atc.PipelineRef) {
+	fake.pipelineRefMutex.Lock()
+	defer fake.pipelineRefMutex.Unlock()
+	fake.PipelineRefStub = nil
+	if fake.pipelineRefReturnsOnCall == nil {
+		fake.pipelineRefReturnsOnCall = make(map[int]struct {
+			result1 atc.PipelineRef
+		})
+	}
+	fake.pipelineRefReturnsOnCall[i] = struct {
+		result1 atc.PipelineRef
+	}{result1}
+}
+
 func (fake *FakeBuild) Preparation() (db.BuildPreparation, bool, error) {
 	fake.preparationMutex.Lock()
 	ret, specificReturn := fake.preparationReturnsOnCall[len(fake.preparationArgsForCall)]
@@ -2194,6 +2555,58 @@ func (fake *FakeBuild) PreparationReturnsOnCall(i int, result1 db.BuildPreparati
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) Priority() int {
+	fake.priorityMutex.Lock()
+	ret, specificReturn := fake.priorityReturnsOnCall[len(fake.priorityArgsForCall)]
+	fake.priorityArgsForCall = append(fake.priorityArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Priority", []interface{}{})
+	fake.priorityMutex.Unlock()
+	if fake.PriorityStub != nil {
+		return fake.PriorityStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.priorityReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) PriorityCallCount() int {
+	fake.priorityMutex.RLock()
+	defer fake.priorityMutex.RUnlock()
+	return len(fake.priorityArgsForCall)
+}
+
+func (fake *FakeBuild) PriorityCalls(stub func() int) {
+	fake.priorityMutex.Lock()
+	defer fake.priorityMutex.Unlock()
+	fake.PriorityStub = stub
+}
+
+func (fake *FakeBuild) PriorityReturns(result1 int) {
+	fake.priorityMutex.Lock()
+	defer fake.priorityMutex.Unlock()
+	fake.PriorityStub = nil
+	fake.priorityReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) PriorityReturnsOnCall(i int, result1 int) {
+	fake.priorityMutex.Lock()
+	defer fake.priorityMutex.Unlock()
+	fake.PriorityStub = nil
+	if fake.priorityReturnsOnCall == nil {
+		fake.priorityReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.priorityReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeBuild) PrivatePlan() atc.Plan {
 	fake.privatePlanMutex.Lock()
 	ret, specificReturn := fake.privatePlanReturnsOnCall[len(fake.privatePlanArgsForCall)]
@@ -2298,6 +2711,58 @@ func (fake *FakeBuild) PublicPlanReturnsOnCall(i int, result1 *json.RawMessage)
 	}{result1}
 }
 
+func (fake *FakeBuild) QuotaExceeded() bool {
+	fake.quotaExceededMutex.Lock()
+	ret, specificReturn := fake.quotaExceededReturnsOnCall[len(fake.quotaExceededArgsForCall)]
+	fake.quotaExceededArgsForCall = append(fake.quotaExceededArgsForCall, struct {
+	}{})
+	fake.recordInvocation("QuotaExceeded", []interface{}{})
+	fake.quotaExceededMutex.Unlock()
+	if fake.QuotaExceededStub != nil {
+		return fake.QuotaExceededStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.quotaExceededReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) QuotaExceededCallCount() int {
+	fake.quotaExceededMutex.RLock()
+	defer fake.quotaExceededMutex.RUnlock()
+	return len(fake.quotaExceededArgsForCall)
+}
+
+func (fake *FakeBuild) QuotaExceededCalls(stub func() bool) {
+	fake.quotaExceededMutex.Lock()
+	defer fake.quotaExceededMutex.Unlock()
+	fake.QuotaExceededStub = stub
+}
+
+func (fake *FakeBuild) QuotaExceededReturns(result1 bool) {
+	fake.quotaExceededMutex.Lock()
+	defer fake.quotaExceededMutex.Unlock()
+	fake.QuotaExceededStub = nil
+	fake.quotaExceededReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBuild) QuotaExceededReturnsOnCall(i int, result1 bool) {
+	fake.quotaExceededMutex.Lock()
+	defer fake.quotaExceededMutex.Unlock()
+	fake.QuotaExceededStub = nil
+	if fake.quotaExceededReturnsOnCall == nil {
+		fake.quotaExceededReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.quotaExceededReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeBuild) ReapTime() time.Time {
 	fake.reapTimeMutex.Lock()
 	ret, specificReturn := fake.reapTimeReturnsOnCall[len(fake.reapTimeArgsForCall)]
@@ -2561,6 +3026,58 @@ func (fake *FakeBuild) RerunOfNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeBuild) ResourceUsage() atc.ResourceUsage {
+	fake.resourceUsageMutex.Lock()
+	ret, specificReturn := fake.resourceUsageReturnsOnCall[len(fake.resourceUsageArgsForCall)]
+	fake.resourceUsageArgsForCall = append(fake.resourceUsageArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ResourceUsage", []interface{}{})
+	fake.resourceUsageMutex.Unlock()
+	if fake.ResourceUsageStub != nil {
+		return fake.ResourceUsageStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.resourceUsageReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) ResourceUsageCallCount() int {
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
+	return len(fake.resourceUsageArgsForCall)
+}
+
+func (fake *FakeBuild) ResourceUsageCalls(stub func() atc.ResourceUsage) {
+	fake.resourceUsageMutex.Lock()
+	defer fake.resourceUsageMutex.Unlock()
+	fake.ResourceUsageStub = stub
+}
+
+func (fake *FakeBuild) ResourceUsageReturns(result1 atc.ResourceUsage) {
+	fake.resourceUsageMutex.Lock()
+	defer fake.resourceUsageMutex.Unlock()
+	fake.ResourceUsageStub = nil
+	fake.resourceUsageReturns = struct {
+		result1 atc.ResourceUsage
+	}{result1}
+}
+
+func (fake *FakeBuild) ResourceUsageReturnsOnCall(i int, result1 atc.ResourceUsage) {
+	fake.resourceUsageMutex.Lock()
+	defer fake.resourceUsageMutex.Unlock()
+	fake.ResourceUsageStub = nil
+	if fake.resourceUsageReturnsOnCall == nil {
+		fake.resourceUsageReturnsOnCall = make(map[int]struct {
+			result1 atc.ResourceUsage
+		})
+	}
+	fake.resourceUsageReturnsOnCall[i] = struct {
+		result1 atc.ResourceUsage
+	}{result1}
+}
+
 func (fake *FakeBuild) Resources() ([]db.BuildInput, []db.BuildOutput, error) {
 	fake.resourcesMutex.Lock()
 	ret, specificReturn := fake.resourcesReturnsOnCall[len(fake.resourcesArgsForCall)]
@@ -2860,11 +3377,11 @@ func (fake *FakeBuild) SaveOutputReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeBuild) SavePipeline(arg1 string, arg2 int, arg3 atc.Config, arg4 db.ConfigVersion, arg5 bool) (db.Pipeline, bool, error) {
+func (fake *FakeBuild) SavePipeline(arg1 atc.PipelineRef, arg2 int, arg3 atc.Config, arg4 db.ConfigVersion, arg5 bool) (db.Pipeline, bool, error) {
 	fake.savePipelineMutex.Lock()
 	ret, specificReturn := fake.savePipelineReturnsOnCall[len(fake.savePipelineArgsForCall)]
 	fake.savePipelineArgsForCall = append(fake.savePipelineArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 int
 		arg3 atc.Config
 		arg4 db.ConfigVersion
@@ -2888,13 +3405,13 @@ func (fake *FakeBuild) SavePipelineCallCount() int {
 	return len(fake.savePipelineArgsForCall)
 }
 
-func (fake *FakeBuild) SavePipelineCalls(stub func(string, int, atc.Config, db.ConfigVersion, bool) (db.Pipeline, bool, error)) {
+func (fake *FakeBuild) SavePipelineCalls(stub func(atc.PipelineRef, int, atc.Config, db.ConfigVersion, bool) (db.Pipeline, bool, error)) {
 	fake.savePipelineMutex.Lock()
 	defer fake.savePipelineMutex.Unlock()
 	fake.SavePipelineStub = stub
 }
 
-func (fake *FakeBuild) SavePipelineArgsForCall(i int) (string, int, atc.Config, db.ConfigVersion, bool) {
+func (fake *FakeBuild) SavePipelineArgsForCall(i int) (atc.PipelineRef, int, atc.Config, db.ConfigVersion, bool) {
 	fake.savePipelineMutex.RLock()
 	defer fake.savePipelineMutex.RUnlock()
 	argsForCall := fake.savePipelineArgsForCall[i]
@@ -2982,6 +3499,61 @@ func (fake *FakeBuild) SchemaReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeBuild) SecretAccesses() ([]atc.SecretAccess, error) {
+	fake.secretAccessesMutex.Lock()
+	ret, specificReturn := fake.secretAccessesReturnsOnCall[len(fake.secretAccessesArgsForCall)]
+	fake.secretAccessesArgsForCall = append(fake.secretAccessesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("SecretAccesses", []interface{}{})
+	fake.secretAccessesMutex.Unlock()
+	if fake.SecretAccessesStub != nil {
+		return fake.SecretAccessesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.secretAccessesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) SecretAccessesCallCount() int {
+	fake.secretAccessesMutex.RLock()
+	defer fake.secretAccessesMutex.RUnlock()
+	return len(fake.secretAccessesArgsForCall)
+}
+
+func (fake *FakeBuild) SecretAccessesCalls(stub func() ([]atc.SecretAccess, error)) {
+	fake.secretAccessesMutex.Lock()
+	defer fake.secretAccessesMutex.Unlock()
+	fake.SecretAccessesStub = stub
+}
+
+func (fake *FakeBuild) SecretAccessesReturns(result1 []atc.SecretAccess, result2 error) {
+	fake.secretAccessesMutex.Lock()
+	defer fake.secretAccessesMutex.Unlock()
+	fake.SecretAccessesStub = nil
+	fake.secretAccessesReturns = struct {
+		result1 []atc.SecretAccess
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) SecretAccessesReturnsOnCall(i int, result1 []atc.SecretAccess, result2 error) {
+	fake.secretAccessesMutex.Lock()
+	defer fake.secretAccessesMutex.Unlock()
+	fake.SecretAccessesStub = nil
+	if fake.secretAccessesReturnsOnCall == nil {
+		fake.secretAccessesReturnsOnCall = make(map[int]struct {
+			result1 []atc.SecretAccess
+			result2 error
+		})
+	}
+	fake.secretAccessesReturnsOnCall[i] = struct {
+		result1 []atc.SecretAccess
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) SetDrained(arg1 bool) error {
 	fake.setDrainedMutex.Lock()
 	ret, specificReturn := fake.setDrainedReturnsOnCall[len(fake.setDrainedArgsForCall)]
@@ -3432,6 +4004,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.abortNotifierMutex.RUnlock()
 	fake.acquireTrackingLockMutex.RLock()
 	defer fake.acquireTrackingLockMutex.RUnlock()
+	fake.addResourceUsageMutex.RLock()
+	defer fake.addResourceUsageMutex.RUnlock()
 	fake.adoptInputsAndPipesMutex.RLock()
 	defer fake.adoptInputsAndPipesMutex.RUnlock()
 	fake.adoptRerunInputsAndPipesMutex.RLock()
@@ -3446,6 +4020,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.endTimeMutex.RUnlock()
 	fake.eventsMutex.RLock()
 	defer fake.eventsMutex.RUnlock()
+	fake.eventsArchivedMutex.RLock()
+	defer fake.eventsArchivedMutex.RUnlock()
 	fake.finishMutex.RLock()
 	defer fake.finishMutex.RUnlock()
 	fake.hasPlanMutex.RLock()
@@ -3476,20 +4052,30 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.jobNameMutex.RUnlock()
 	fake.markAsAbortedMutex.RLock()
 	defer fake.markAsAbortedMutex.RUnlock()
+	fake.markEventsArchivedMutex.RLock()
+	defer fake.markEventsArchivedMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
 	fake.pipelineMutex.RLock()
 	defer fake.pipelineMutex.RUnlock()
 	fake.pipelineIDMutex.RLock()
 	defer fake.pipelineIDMutex.RUnlock()
+	fake.pipelineInstanceVarsMutex.RLock()
+	defer fake.pipelineInstanceVarsMutex.RUnlock()
 	fake.pipelineNameMutex.RLock()
 	defer fake.pipelineNameMutex.RUnlock()
+	fake.pipelineRefMutex.RLock()
+	defer fake.pipelineRefMutex.RUnlock()
 	fake.preparationMutex.RLock()
 	defer fake.preparationMutex.RUnlock()
+	fake.priorityMutex.RLock()
+	defer fake.priorityMutex.RUnlock()
 	fake.privatePlanMutex.RLock()
 	defer fake.privatePlanMutex.RUnlock()
 	fake.publicPlanMutex.RLock()
 	defer fake.publicPlanMutex.RUnlock()
+	fake.quotaExceededMutex.RLock()
+	defer fake.quotaExceededMutex.RUnlock()
 	fake.reapTimeMutex.RLock()
 	defer fake.reapTimeMutex.RUnlock()
 	fake.reloadMutex.RLock()
@@ -3500,6 +4086,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.rerunOfMutex.RUnlock()
 	fake.rerunOfNameMutex.RLock()
 	defer fake.rerunOfNameMutex.RUnlock()
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
 	fake.resourcesMutex.RLock()
 	defer fake.resourcesMutex.RUnlock()
 	fake.resourcesCheckedMutex.RLock()
@@ -3514,6 +4102,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.savePipelineMutex.RUnlock()
 	fake.schemaMutex.RLock()
 	defer fake.schemaMutex.RUnlock()
+	fake.secretAccessesMutex.RLock()
+	defer fake.secretAccessesMutex.RUnlock()
 	fake.setDrainedMutex.RLock()
 	defer fake.setDrainedMutex.RUnlock()
 	fake.setInterceptibleMutex.RLock()
@@ -50,6 +50,21 @@ type FakeBuildFactory struct {
 		result1 []db.Build
 		result2 error
 	}
+	GetArchivableBuildsStub        func(int, int, bool) ([]db.Build, error)
+	getArchivableBuildsMutex       sync.RWMutex
+	getArchivableBuildsArgsForCall []struct {
+		arg1 int
+		arg2 int
+		arg3 bool
+	}
+	getArchivableBuildsReturns struct {
+		result1 []db.Build
+		result2 error
+	}
+	getArchivableBuildsReturnsOnCall map[int]struct {
+		result1 []db.Build
+		result2 error
+	}
 	GetDrainableBuildsStub        func() ([]db.Build, error)
 	getDrainableBuildsMutex       sync.RWMutex
 	getDrainableBuildsArgsForCall []struct {
@@ -294,6 +309,71 @@ func (fake *FakeBuildFactory) GetAllStartedBuildsReturnsOnCall(i int, result1 []
 	}{result1, result2}
 }
 
+func (fake *FakeBuildFactory) GetArchivableBuilds(arg1 int, arg2 int, arg3 bool) ([]db.Build, error) {
+	fake.getArchivableBuildsMutex.Lock()
+	ret, specificReturn := fake.getArchivableBuildsReturnsOnCall[len(fake.getArchivableBuildsArgsForCall)]
+	fake.getArchivableBuildsArgsForCall = append(fake.getArchivableBuildsArgsForCall, struct {
+		arg1 int
+		arg2 int
+		arg3 bool
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("GetArchivableBuilds", []interface{}{arg1, arg2, arg3})
+	fake.getArchivableBuildsMutex.Unlock()
+	if fake.GetArchivableBuildsStub != nil {
+		return fake.GetArchivableBuildsStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getArchivableBuildsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildFactory) GetArchivableBuildsCallCount() int {
+	fake.getArchivableBuildsMutex.RLock()
+	defer fake.getArchivableBuildsMutex.RUnlock()
+	return len(fake.getArchivableBuildsArgsForCall)
+}
+
+func (fake *FakeBuildFactory) GetArchivableBuildsCalls(stub func(int, int, bool) ([]db.Build, error)) {
+	fake.getArchivableBuildsMutex.Lock()
+	defer fake.getArchivableBuildsMutex.Unlock()
+	fake.GetArchivableBuildsStub = stub
+}
+
+func (fake *FakeBuildFactory) GetArchivableBuildsArgsForCall(i int) (int, int, bool) {
+	fake.getArchivableBuildsMutex.RLock()
+	defer fake.getArchivableBuildsMutex.RUnlock()
+	argsForCall := fake.getArchivableBuildsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeBuildFactory) GetArchivableBuildsReturns(result1 []db.Build, result2 error) {
+	fake.getArchivableBuildsMutex.Lock()
+	defer fake.getArchivableBuildsMutex.Unlock()
+	fake.GetArchivableBuildsStub = nil
+	fake.getArchivableBuildsReturns = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildFactory) GetArchivableBuildsReturnsOnCall(i int, result1 []db.Build, result2 error) {
+	fake.getArchivableBuildsMutex.Lock()
+	defer fake.getArchivableBuildsMutex.Unlock()
+	fake.GetArchivableBuildsStub = nil
+	if fake.getArchivableBuildsReturnsOnCall == nil {
+		fake.getArchivableBuildsReturnsOnCall = make(map[int]struct {
+			result1 []db.Build
+			result2 error
+		})
+	}
+	fake.getArchivableBuildsReturnsOnCall[i] = struct {
+		result1 []db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuildFactory) GetDrainableBuilds() ([]db.Build, error) {
 	fake.getDrainableBuildsMutex.Lock()
 	ret, specificReturn := fake.getDrainableBuildsReturnsOnCall[len(fake.getDrainableBuildsArgsForCall)]
@@ -548,6 +628,8 @@ func (fake *FakeBuildFactory) Invocations() map[string][][]interface{} {
 	defer fake.buildMutex.RUnlock()
 	fake.getAllStartedBuildsMutex.RLock()
 	defer fake.getAllStartedBuildsMutex.RUnlock()
+	fake.getArchivableBuildsMutex.RLock()
+	defer fake.getArchivableBuildsMutex.RUnlock()
 	fake.getDrainableBuildsMutex.RLock()
 	defer fake.getDrainableBuildsMutex.RUnlock()
 	fake.markNonInterceptibleBuildsMutex.RLock()
@@ -145,6 +145,16 @@ type FakeCheck struct {
 	pipelineIDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	PipelineInstanceVarsStub        func() atc.InstanceVars
+	pipelineInstanceVarsMutex       sync.RWMutex
+	pipelineInstanceVarsArgsForCall []struct {
+	}
+	pipelineInstanceVarsReturns struct {
+		result1 atc.InstanceVars
+	}
+	pipelineInstanceVarsReturnsOnCall map[int]struct {
+		result1 atc.InstanceVars
+	}
 	PipelineNameStub        func() string
 	pipelineNameMutex       sync.RWMutex
 	pipelineNameArgsForCall []struct {
@@ -155,6 +165,16 @@ type FakeCheck struct {
 	pipelineNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	PipelineRefStub        func() atc.PipelineRef
+	pipelineRefMutex       sync.RWMutex
+	pipelineRefArgsForCall []struct {
+	}
+	pipelineRefReturns struct {
+		result1 atc.PipelineRef
+	}
+	pipelineRefReturnsOnCall map[int]struct {
+		result1 atc.PipelineRef
+	}
 	PlanStub        func() atc.Plan
 	planMutex       sync.RWMutex
 	planArgsForCall []struct {
@@ -219,6 +239,17 @@ type FakeCheck struct {
 	schemaReturnsOnCall map[int]struct {
 		result1 string
 	}
+	SetRateLimitDelayStub        func(time.Duration) error
+	setRateLimitDelayMutex       sync.RWMutex
+	setRateLimitDelayArgsForCall []struct {
+		arg1 time.Duration
+	}
+	setRateLimitDelayReturns struct {
+		result1 error
+	}
+	setRateLimitDelayReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SpanContextStub        func() propagators.Supplier
 	spanContextMutex       sync.RWMutex
 	spanContextArgsForCall []struct {
@@ -938,6 +969,58 @@ func (fake *FakeCheck) PipelineIDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeCheck) PipelineInstanceVars() atc.InstanceVars {
+	fake.pipelineInstanceVarsMutex.Lock()
+	ret, specificReturn := fake.pipelineInstanceVarsReturnsOnCall[len(fake.pipelineInstanceVarsArgsForCall)]
+	fake.pipelineInstanceVarsArgsForCall = append(fake.pipelineInstanceVarsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PipelineInstanceVars", []interface{}{})
+	fake.pipelineInstanceVarsMutex.Unlock()
+	if fake.PipelineInstanceVarsStub != nil {
+		return fake.PipelineInstanceVarsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.pipelineInstanceVarsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheck) PipelineInstanceVarsCallCount() int {
+	fake.pipelineInstanceVarsMutex.RLock()
+	defer fake.pipelineInstanceVarsMutex.RUnlock()
+	return len(fake.pipelineInstanceVarsArgsForCall)
+}
+
+func (fake *FakeCheck) PipelineInstanceVarsCalls(stub func() atc.InstanceVars) {
+	fake.pipelineInstanceVarsMutex.Lock()
+	defer fake.pipelineInstanceVarsMutex.Unlock()
+	fake.PipelineInstanceVarsStub = stub
+}
+
+func (fake *FakeCheck) PipelineInstanceVarsReturns(result1 atc.InstanceVars) {
+	fake.pipelineInstanceVarsMutex.Lock()
+	defer fake.pipelineInstanceVarsMutex.Unlock()
+	fake.PipelineInstanceVarsStub = nil
+	fake.pipelineInstanceVarsReturns = struct {
+		result1 atc.InstanceVars
+	}{result1}
+}
+
+func (fake *FakeCheck) PipelineInstanceVarsReturnsOnCall(i int, result1 atc.InstanceVars) {
+	fake.pipelineInstanceVarsMutex.Lock()
+	defer fake.pipelineInstanceVarsMutex.Unlock()
+	fake.PipelineInstanceVarsStub = nil
+	if fake.pipelineInstanceVarsReturnsOnCall == nil {
+		fake.pipelineInstanceVarsReturnsOnCall = make(map[int]struct {
+			result1 atc.InstanceVars
+		})
+	}
+	fake.pipelineInstanceVarsReturnsOnCall[i] = struct {
+		result1 atc.InstanceVars
+	}{result1}
+}
+
 func (fake *FakeCheck) PipelineName() string {
 	fake.pipelineNameMutex.Lock()
 	ret, specificReturn := fake.pipelineNameReturnsOnCall[len(fake.pipelineNameArgsForCall)]
@@ -990,6 +1073,58 @@ func (fake *FakeCheck) PipelineNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeCheck) PipelineRef() atc.PipelineRef {
+	fake.pipelineRefMutex.Lock()
+	ret, specificReturn := fake.pipelineRefReturnsOnCall[len(fake.pipelineRefArgsForCall)]
+	fake.pipelineRefArgsForCall = append(fake.pipelineRefArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PipelineRef", []interface{}{})
+	fake.pipelineRefMutex.Unlock()
+	if fake.PipelineRefStub != nil {
+		return fake.PipelineRefStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.pipelineRefReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheck) PipelineRefCallCount() int {
+	fake.pipelineRefMutex.RLock()
+	defer fake.pipelineRefMutex.RUnlock()
+	return len(fake.pipelineRefArgsForCall)
+}
+
+func (fake *FakeCheck) PipelineRefCalls(stub func() atc.PipelineRef) {
+	fake.pipelineRefMutex.Lock()
+	defer fake.pipelineRefMutex.Unlock()
+	fake.PipelineRefStub = stub
+}
+
+func (fake *FakeCheck) PipelineRefReturns(result1 atc.PipelineRef) {
+	fake.pipelineRefMutex.Lock()
+	defer fake.pipelineRefMutex.Unlock()
+	fake.PipelineRefStub = nil
+	fake.pipelineRefReturns = struct {
+		result1 atc.PipelineRef
+	}{result1}
+}
+
+func (fake *FakeCheck) PipelineRefReturnsOnCall(i int, result1 atc.PipelineRef) {
+	fake.pipelineRefMutex.Lock()
+	defer fake.pipelineRefMutex.Unlock()
+	fake.PipelineRefStub = nil
+	if fake.pipelineRefReturnsOnCall == nil {
+		fake.pipelineRefReturnsOnCall = make(map[int]struct {
+			result1 atc.PipelineRef
+		})
+	}
+	fake.pipelineRefReturnsOnCall[i] = struct {
+		result1 atc.PipelineRef
+	}{result1}
+}
+
 func (fake *FakeCheck) Plan() atc.Plan {
 	fake.planMutex.Lock()
 	ret, specificReturn := fake.planReturnsOnCall[len(fake.planArgsForCall)]
@@ -1319,6 +1454,66 @@ func (fake *FakeCheck) SchemaReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeCheck) SetRateLimitDelay(arg1 time.Duration) error {
+	fake.setRateLimitDelayMutex.Lock()
+	ret, specificReturn := fake.setRateLimitDelayReturnsOnCall[len(fake.setRateLimitDelayArgsForCall)]
+	fake.setRateLimitDelayArgsForCall = append(fake.setRateLimitDelayArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	fake.recordInvocation("SetRateLimitDelay", []interface{}{arg1})
+	fake.setRateLimitDelayMutex.Unlock()
+	if fake.SetRateLimitDelayStub != nil {
+		return fake.SetRateLimitDelayStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.setRateLimitDelayReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheck) SetRateLimitDelayCallCount() int {
+	fake.setRateLimitDelayMutex.RLock()
+	defer fake.setRateLimitDelayMutex.RUnlock()
+	return len(fake.setRateLimitDelayArgsForCall)
+}
+
+func (fake *FakeCheck) SetRateLimitDelayCalls(stub func(time.Duration) error) {
+	fake.setRateLimitDelayMutex.Lock()
+	defer fake.setRateLimitDelayMutex.Unlock()
+	fake.SetRateLimitDelayStub = stub
+}
+
+func (fake *FakeCheck) SetRateLimitDelayArgsForCall(i int) time.Duration {
+	fake.setRateLimitDelayMutex.RLock()
+	defer fake.setRateLimitDelayMutex.RUnlock()
+	argsForCall := fake.setRateLimitDelayArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCheck) SetRateLimitDelayReturns(result1 error) {
+	fake.setRateLimitDelayMutex.Lock()
+	defer fake.setRateLimitDelayMutex.Unlock()
+	fake.SetRateLimitDelayStub = nil
+	fake.setRateLimitDelayReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCheck) SetRateLimitDelayReturnsOnCall(i int, result1 error) {
+	fake.setRateLimitDelayMutex.Lock()
+	defer fake.setRateLimitDelayMutex.Unlock()
+	fake.SetRateLimitDelayStub = nil
+	if fake.setRateLimitDelayReturnsOnCall == nil {
+		fake.setRateLimitDelayReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setRateLimitDelayReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeCheck) SpanContext() propagators.Supplier {
 	fake.spanContextMutex.Lock()
 	ret, specificReturn := fake.spanContextReturnsOnCall[len(fake.spanContextArgsForCall)]
@@ -1658,8 +1853,12 @@ func (fake *FakeCheck) Invocations() map[string][][]interface{} {
 	defer fake.pipelineMutex.RUnlock()
 	fake.pipelineIDMutex.RLock()
 	defer fake.pipelineIDMutex.RUnlock()
+	fake.pipelineInstanceVarsMutex.RLock()
+	defer fake.pipelineInstanceVarsMutex.RUnlock()
 	fake.pipelineNameMutex.RLock()
 	defer fake.pipelineNameMutex.RUnlock()
+	fake.pipelineRefMutex.RLock()
+	defer fake.pipelineRefMutex.RUnlock()
 	fake.planMutex.RLock()
 	defer fake.planMutex.RUnlock()
 	fake.reloadMutex.RLock()
@@ -1672,6 +1871,8 @@ func (fake *FakeCheck) Invocations() map[string][][]interface{} {
 	defer fake.saveVersionsMutex.RUnlock()
 	fake.schemaMutex.RLock()
 	defer fake.schemaMutex.RUnlock()
+	fake.setRateLimitDelayMutex.RLock()
+	defer fake.setRateLimitDelayMutex.RUnlock()
 	fake.spanContextMutex.RLock()
 	defer fake.spanContextMutex.RUnlock()
 	fake.startMutex.RLock()
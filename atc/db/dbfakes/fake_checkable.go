@@ -30,6 +30,16 @@ type FakeCheckable struct {
 	checkTimeoutReturnsOnCall map[int]struct {
 		result1 string
 	}
+	ConsecutiveCheckFailuresStub        func() int
+	consecutiveCheckFailuresMutex       sync.RWMutex
+	consecutiveCheckFailuresArgsForCall []struct {
+	}
+	consecutiveCheckFailuresReturns struct {
+		result1 int
+	}
+	consecutiveCheckFailuresReturnsOnCall map[int]struct {
+		result1 int
+	}
 	CurrentPinnedVersionStub        func() atc.Version
 	currentPinnedVersionMutex       sync.RWMutex
 	currentPinnedVersionArgsForCall []struct {
@@ -50,6 +60,16 @@ type FakeCheckable struct {
 	hasWebhookReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	IdleSinceStub        func() time.Time
+	idleSinceMutex       sync.RWMutex
+	idleSinceArgsForCall []struct {
+	}
+	idleSinceReturns struct {
+		result1 time.Time
+	}
+	idleSinceReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	LastCheckEndTimeStub        func() time.Time
 	lastCheckEndTimeMutex       sync.RWMutex
 	lastCheckEndTimeArgsForCall []struct {
@@ -94,6 +114,16 @@ type FakeCheckable struct {
 	pipelineIDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	PipelineInstanceVarsStub        func() atc.InstanceVars
+	pipelineInstanceVarsMutex       sync.RWMutex
+	pipelineInstanceVarsArgsForCall []struct {
+	}
+	pipelineInstanceVarsReturns struct {
+		result1 atc.InstanceVars
+	}
+	pipelineInstanceVarsReturnsOnCall map[int]struct {
+		result1 atc.InstanceVars
+	}
 	PipelineNameStub        func() string
 	pipelineNameMutex       sync.RWMutex
 	pipelineNameArgsForCall []struct {
@@ -104,6 +134,16 @@ type FakeCheckable struct {
 	pipelineNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	PipelineRefStub        func() atc.PipelineRef
+	pipelineRefMutex       sync.RWMutex
+	pipelineRefArgsForCall []struct {
+	}
+	pipelineRefReturns struct {
+		result1 atc.PipelineRef
+	}
+	pipelineRefReturnsOnCall map[int]struct {
+		result1 atc.PipelineRef
+	}
 	ResourceConfigScopeIDStub        func() int
 	resourceConfigScopeIDMutex       sync.RWMutex
 	resourceConfigScopeIDArgsForCall []struct {
@@ -297,6 +337,58 @@ func (fake *FakeCheckable) CheckTimeoutReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeCheckable) ConsecutiveCheckFailures() int {
+	fake.consecutiveCheckFailuresMutex.Lock()
+	ret, specificReturn := fake.consecutiveCheckFailuresReturnsOnCall[len(fake.consecutiveCheckFailuresArgsForCall)]
+	fake.consecutiveCheckFailuresArgsForCall = append(fake.consecutiveCheckFailuresArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ConsecutiveCheckFailures", []interface{}{})
+	fake.consecutiveCheckFailuresMutex.Unlock()
+	if fake.ConsecutiveCheckFailuresStub != nil {
+		return fake.ConsecutiveCheckFailuresStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.consecutiveCheckFailuresReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheckable) ConsecutiveCheckFailuresCallCount() int {
+	fake.consecutiveCheckFailuresMutex.RLock()
+	defer fake.consecutiveCheckFailuresMutex.RUnlock()
+	return len(fake.consecutiveCheckFailuresArgsForCall)
+}
+
+func (fake *FakeCheckable) ConsecutiveCheckFailuresCalls(stub func() int) {
+	fake.consecutiveCheckFailuresMutex.Lock()
+	defer fake.consecutiveCheckFailuresMutex.Unlock()
+	fake.ConsecutiveCheckFailuresStub = stub
+}
+
+func (fake *FakeCheckable) ConsecutiveCheckFailuresReturns(result1 int) {
+	fake.consecutiveCheckFailuresMutex.Lock()
+	defer fake.consecutiveCheckFailuresMutex.Unlock()
+	fake.ConsecutiveCheckFailuresStub = nil
+	fake.consecutiveCheckFailuresReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeCheckable) ConsecutiveCheckFailuresReturnsOnCall(i int, result1 int) {
+	fake.consecutiveCheckFailuresMutex.Lock()
+	defer fake.consecutiveCheckFailuresMutex.Unlock()
+	fake.ConsecutiveCheckFailuresStub = nil
+	if fake.consecutiveCheckFailuresReturnsOnCall == nil {
+		fake.consecutiveCheckFailuresReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.consecutiveCheckFailuresReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeCheckable) CurrentPinnedVersion() atc.Version {
 	fake.currentPinnedVersionMutex.Lock()
 	ret, specificReturn := fake.currentPinnedVersionReturnsOnCall[len(fake.currentPinnedVersionArgsForCall)]
@@ -401,6 +493,58 @@ func (fake *FakeCheckable) HasWebhookReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeCheckable) IdleSince() time.Time {
+	fake.idleSinceMutex.Lock()
+	ret, specificReturn := fake.idleSinceReturnsOnCall[len(fake.idleSinceArgsForCall)]
+	fake.idleSinceArgsForCall = append(fake.idleSinceArgsForCall, struct {
+	}{})
+	fake.recordInvocation("IdleSince", []interface{}{})
+	fake.idleSinceMutex.Unlock()
+	if fake.IdleSinceStub != nil {
+		return fake.IdleSinceStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.idleSinceReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheckable) IdleSinceCallCount() int {
+	fake.idleSinceMutex.RLock()
+	defer fake.idleSinceMutex.RUnlock()
+	return len(fake.idleSinceArgsForCall)
+}
+
+func (fake *FakeCheckable) IdleSinceCalls(stub func() time.Time) {
+	fake.idleSinceMutex.Lock()
+	defer fake.idleSinceMutex.Unlock()
+	fake.IdleSinceStub = stub
+}
+
+func (fake *FakeCheckable) IdleSinceReturns(result1 time.Time) {
+	fake.idleSinceMutex.Lock()
+	defer fake.idleSinceMutex.Unlock()
+	fake.IdleSinceStub = nil
+	fake.idleSinceReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeCheckable) IdleSinceReturnsOnCall(i int, result1 time.Time) {
+	fake.idleSinceMutex.Lock()
+	defer fake.idleSinceMutex.Unlock()
+	fake.IdleSinceStub = nil
+	if fake.idleSinceReturnsOnCall == nil {
+		fake.idleSinceReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.idleSinceReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeCheckable) LastCheckEndTime() time.Time {
 	fake.lastCheckEndTimeMutex.Lock()
 	ret, specificReturn := fake.lastCheckEndTimeReturnsOnCall[len(fake.lastCheckEndTimeArgsForCall)]
@@ -615,6 +759,58 @@ func (fake *FakeCheckable) PipelineIDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeCheckable) PipelineInstanceVars() atc.InstanceVars {
+	fake.pipelineInstanceVarsMutex.Lock()
+	ret, specificReturn := fake.pipelineInstanceVarsReturnsOnCall[len(fake.pipelineInstanceVarsArgsForCall)]
+	fake.pipelineInstanceVarsArgsForCall = append(fake.pipelineInstanceVarsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PipelineInstanceVars", []interface{}{})
+	fake.pipelineInstanceVarsMutex.Unlock()
+	if fake.PipelineInstanceVarsStub != nil {
+		return fake.PipelineInstanceVarsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.pipelineInstanceVarsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheckable) PipelineInstanceVarsCallCount() int {
+	fake.pipelineInstanceVarsMutex.RLock()
+	defer fake.pipelineInstanceVarsMutex.RUnlock()
+	return len(fake.pipelineInstanceVarsArgsForCall)
+}
+
+func (fake *FakeCheckable) PipelineInstanceVarsCalls(stub func() atc.InstanceVars) {
+	fake.pipelineInstanceVarsMutex.Lock()
+	defer fake.pipelineInstanceVarsMutex.Unlock()
+	fake.PipelineInstanceVarsStub = stub
+}
+
+func (fake *FakeCheckable) PipelineInstanceVarsReturns(result1 atc.InstanceVars) {
+	fake.pipelineInstanceVarsMutex.Lock()
+	defer fake.pipelineInstanceVarsMutex.Unlock()
+	fake.PipelineInstanceVarsStub = nil
+	fake.pipelineInstanceVarsReturns = struct {
+		result1 atc.InstanceVars
+	}{result1}
+}
+
+func (fake *FakeCheckable) PipelineInstanceVarsReturnsOnCall(i int, result1 atc.InstanceVars) {
+	fake.pipelineInstanceVarsMutex.Lock()
+	defer fake.pipelineInstanceVarsMutex.Unlock()
+	fake.PipelineInstanceVarsStub = nil
+	if fake.pipelineInstanceVarsReturnsOnCall == nil {
+		fake.pipelineInstanceVarsReturnsOnCall = make(map[int]struct {
+			result1 atc.InstanceVars
+		})
+	}
+	fake.pipelineInstanceVarsReturnsOnCall[i] = struct {
+		result1 atc.InstanceVars
+	}{result1}
+}
+
 func (fake *FakeCheckable) PipelineName() string {
 	fake.pipelineNameMutex.Lock()
 	ret, specificReturn := fake.pipelineNameReturnsOnCall[len(fake.pipelineNameArgsForCall)]
@@ -667,6 +863,58 @@ func (fake *FakeCheckable) PipelineNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeCheckable) PipelineRef() atc.PipelineRef {
+	fake.pipelineRefMutex.Lock()
+	ret, specificReturn := fake.pipelineRefReturnsOnCall[len(fake.pipelineRefArgsForCall)]
+	fake.pipelineRefArgsForCall = append(fake.pipelineRefArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PipelineRef", []interface{}{})
+	fake.pipelineRefMutex.Unlock()
+	if fake.PipelineRefStub != nil {
+		return fake.PipelineRefStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.pipelineRefReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCheckable) PipelineRefCallCount() int {
+	fake.pipelineRefMutex.RLock()
+	defer fake.pipelineRefMutex.RUnlock()
+	return len(fake.pipelineRefArgsForCall)
+}
+
+func (fake *FakeCheckable) PipelineRefCalls(stub func() atc.PipelineRef) {
+	fake.pipelineRefMutex.Lock()
+	defer fake.pipelineRefMutex.Unlock()
+	fake.PipelineRefStub = stub
+}
+
+func (fake *FakeCheckable) PipelineRefReturns(result1 atc.PipelineRef) {
+	fake.pipelineRefMutex.Lock()
+	defer fake.pipelineRefMutex.Unlock()
+	fake.PipelineRefStub = nil
+	fake.pipelineRefReturns = struct {
+		result1 atc.PipelineRef
+	}{result1}
+}
+
+func (fake *FakeCheckable) PipelineRefReturnsOnCall(i int, result1 atc.PipelineRef) {
+	fake.pipelineRefMutex.Lock()
+	defer fake.pipelineRefMutex.Unlock()
+	fake.PipelineRefStub = nil
+	if fake.pipelineRefReturnsOnCall == nil {
+		fake.pipelineRefReturnsOnCall = make(map[int]struct {
+			result1 atc.PipelineRef
+		})
+	}
+	fake.pipelineRefReturnsOnCall[i] = struct {
+		result1 atc.PipelineRef
+	}{result1}
+}
+
 func (fake *FakeCheckable) ResourceConfigScopeID() int {
 	fake.resourceConfigScopeIDMutex.Lock()
 	ret, specificReturn := fake.resourceConfigScopeIDReturnsOnCall[len(fake.resourceConfigScopeIDArgsForCall)]
@@ -1110,10 +1358,14 @@ func (fake *FakeCheckable) Invocations() map[string][][]interface{} {
 	defer fake.checkEveryMutex.RUnlock()
 	fake.checkTimeoutMutex.RLock()
 	defer fake.checkTimeoutMutex.RUnlock()
+	fake.consecutiveCheckFailuresMutex.RLock()
+	defer fake.consecutiveCheckFailuresMutex.RUnlock()
 	fake.currentPinnedVersionMutex.RLock()
 	defer fake.currentPinnedVersionMutex.RUnlock()
 	fake.hasWebhookMutex.RLock()
 	defer fake.hasWebhookMutex.RUnlock()
+	fake.idleSinceMutex.RLock()
+	defer fake.idleSinceMutex.RUnlock()
 	fake.lastCheckEndTimeMutex.RLock()
 	defer fake.lastCheckEndTimeMutex.RUnlock()
 	fake.nameMutex.RLock()
@@ -1122,8 +1374,12 @@ func (fake *FakeCheckable) Invocations() map[string][][]interface{} {
 	defer fake.pipelineMutex.RUnlock()
 	fake.pipelineIDMutex.RLock()
 	defer fake.pipelineIDMutex.RUnlock()
+	fake.pipelineInstanceVarsMutex.RLock()
+	defer fake.pipelineInstanceVarsMutex.RUnlock()
 	fake.pipelineNameMutex.RLock()
 	defer fake.pipelineNameMutex.RUnlock()
+	fake.pipelineRefMutex.RLock()
+	defer fake.pipelineRefMutex.RUnlock()
 	fake.resourceConfigScopeIDMutex.RLock()
 	defer fake.resourceConfigScopeIDMutex.RUnlock()
 	fake.setCheckSetupErrorMutex.RLock()
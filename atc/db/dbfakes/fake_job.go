@@ -123,6 +123,19 @@ type FakeJob struct {
 		result1 db.Build
 		result2 error
 	}
+	CreateBuildWithPriorityStub        func(int) (db.Build, error)
+	createBuildWithPriorityMutex       sync.RWMutex
+	createBuildWithPriorityArgsForCall []struct {
+		arg1 int
+	}
+	createBuildWithPriorityReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	createBuildWithPriorityReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
 	DisableManualTriggerStub        func() bool
 	disableManualTriggerMutex       sync.RWMutex
 	disableManualTriggerArgsForCall []struct {
@@ -314,6 +327,16 @@ type FakeJob struct {
 	pipelineIDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	PipelineInstanceVarsStub        func() atc.InstanceVars
+	pipelineInstanceVarsMutex       sync.RWMutex
+	pipelineInstanceVarsArgsForCall []struct {
+	}
+	pipelineInstanceVarsReturns struct {
+		result1 atc.InstanceVars
+	}
+	pipelineInstanceVarsReturnsOnCall map[int]struct {
+		result1 atc.InstanceVars
+	}
 	PipelineNameStub        func() string
 	pipelineNameMutex       sync.RWMutex
 	pipelineNameArgsForCall []struct {
@@ -324,6 +347,16 @@ type FakeJob struct {
 	pipelineNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	PipelineRefStub        func() atc.PipelineRef
+	pipelineRefMutex       sync.RWMutex
+	pipelineRefArgsForCall []struct {
+	}
+	pipelineRefReturns struct {
+		result1 atc.PipelineRef
+	}
+	pipelineRefReturnsOnCall map[int]struct {
+		result1 atc.PipelineRef
+	}
 	PublicStub        func() bool
 	publicMutex       sync.RWMutex
 	publicArgsForCall []struct {
@@ -404,6 +437,18 @@ type FakeJob struct {
 	scheduleRequestedTimeReturnsOnCall map[int]struct {
 		result1 time.Time
 	}
+	SchedulingExplanationStub        func() (atc.SchedulingExplanation, error)
+	schedulingExplanationMutex       sync.RWMutex
+	schedulingExplanationArgsForCall []struct {
+	}
+	schedulingExplanationReturns struct {
+		result1 atc.SchedulingExplanation
+		result2 error
+	}
+	schedulingExplanationReturnsOnCall map[int]struct {
+		result1 atc.SchedulingExplanation
+		result2 error
+	}
 	SetHasNewInputsStub        func(bool) error
 	setHasNewInputsMutex       sync.RWMutex
 	setHasNewInputsArgsForCall []struct {
@@ -974,6 +1019,69 @@ func (fake *FakeJob) CreateBuildReturnsOnCall(i int, result1 db.Build, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeJob) CreateBuildWithPriority(arg1 int) (db.Build, error) {
+	fake.createBuildWithPriorityMutex.Lock()
+	ret, specificReturn := fake.createBuildWithPriorityReturnsOnCall[len(fake.createBuildWithPriorityArgsForCall)]
+	fake.createBuildWithPriorityArgsForCall = append(fake.createBuildWithPriorityArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("CreateBuildWithPriority", []interface{}{arg1})
+	fake.createBuildWithPriorityMutex.Unlock()
+	if fake.CreateBuildWithPriorityStub != nil {
+		return fake.CreateBuildWithPriorityStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createBuildWithPriorityReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) CreateBuildWithPriorityCallCount() int {
+	fake.createBuildWithPriorityMutex.RLock()
+	defer fake.createBuildWithPriorityMutex.RUnlock()
+	return len(fake.createBuildWithPriorityArgsForCall)
+}
+
+func (fake *FakeJob) CreateBuildWithPriorityCalls(stub func(int) (db.Build, error)) {
+	fake.createBuildWithPriorityMutex.Lock()
+	defer fake.createBuildWithPriorityMutex.Unlock()
+	fake.CreateBuildWithPriorityStub = stub
+}
+
+func (fake *FakeJob) CreateBuildWithPriorityArgsForCall(i int) int {
+	fake.createBuildWithPriorityMutex.RLock()
+	defer fake.createBuildWithPriorityMutex.RUnlock()
+	argsForCall := fake.createBuildWithPriorityArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeJob) CreateBuildWithPriorityReturns(result1 db.Build, result2 error) {
+	fake.createBuildWithPriorityMutex.Lock()
+	defer fake.createBuildWithPriorityMutex.Unlock()
+	fake.CreateBuildWithPriorityStub = nil
+	fake.createBuildWithPriorityReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CreateBuildWithPriorityReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.createBuildWithPriorityMutex.Lock()
+	defer fake.createBuildWithPriorityMutex.Unlock()
+	fake.CreateBuildWithPriorityStub = nil
+	if fake.createBuildWithPriorityReturnsOnCall == nil {
+		fake.createBuildWithPriorityReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.createBuildWithPriorityReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) DisableManualTrigger() bool {
 	fake.disableManualTriggerMutex.Lock()
 	ret, specificReturn := fake.disableManualTriggerReturnsOnCall[len(fake.disableManualTriggerArgsForCall)]
@@ -1896,6 +2004,58 @@ func (fake *FakeJob) PipelineIDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeJob) PipelineInstanceVars() atc.InstanceVars {
+	fake.pipelineInstanceVarsMutex.Lock()
+	ret, specificReturn := fake.pipelineInstanceVarsReturnsOnCall[len(fake.pipelineInstanceVarsArgsForCall)]
+	fake.pipelineInstanceVarsArgsForCall = append(fake.pipelineInstanceVarsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PipelineInstanceVars", []interface{}{})
+	fake.pipelineInstanceVarsMutex.Unlock()
+	if fake.PipelineInstanceVarsStub != nil {
+		return fake.PipelineInstanceVarsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.pipelineInstanceVarsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) PipelineInstanceVarsCallCount() int {
+	fake.pipelineInstanceVarsMutex.RLock()
+	defer fake.pipelineInstanceVarsMutex.RUnlock()
+	return len(fake.pipelineInstanceVarsArgsForCall)
+}
+
+func (fake *FakeJob) PipelineInstanceVarsCalls(stub func() atc.InstanceVars) {
+	fake.pipelineInstanceVarsMutex.Lock()
+	defer fake.pipelineInstanceVarsMutex.Unlock()
+	fake.PipelineInstanceVarsStub = stub
+}
+
+func (fake *FakeJob) PipelineInstanceVarsReturns(result1 atc.InstanceVars) {
+	fake.pipelineInstanceVarsMutex.Lock()
+	defer fake.pipelineInstanceVarsMutex.Unlock()
+	fake.PipelineInstanceVarsStub = nil
+	fake.pipelineInstanceVarsReturns = struct {
+		result1 atc.InstanceVars
+	}{result1}
+}
+
+func (fake *FakeJob) PipelineInstanceVarsReturnsOnCall(i int, result1 atc.InstanceVars) {
+	fake.pipelineInstanceVarsMutex.Lock()
+	defer fake.pipelineInstanceVarsMutex.Unlock()
+	fake.PipelineInstanceVarsStub = nil
+	if fake.pipelineInstanceVarsReturnsOnCall == nil {
+		fake.pipelineInstanceVarsReturnsOnCall = make(map[int]struct {
+			result1 atc.InstanceVars
+		})
+	}
+	fake.pipelineInstanceVarsReturnsOnCall[i] = struct {
+		result1 atc.InstanceVars
+	}{result1}
+}
+
 func (fake *FakeJob) PipelineName() string {
 	fake.pipelineNameMutex.Lock()
 	ret, specificReturn := fake.pipelineNameReturnsOnCall[len(fake.pipelineNameArgsForCall)]
@@ -1948,6 +2108,58 @@ func (fake *FakeJob) PipelineNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeJob) PipelineRef() atc.PipelineRef {
+	fake.pipelineRefMutex.Lock()
+	ret, specificReturn := fake.pipelineRefReturnsOnCall[len(fake.pipelineRefArgsForCall)]
+	fake.pipelineRefArgsForCall = append(fake.pipelineRefArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PipelineRef", []interface{}{})
+	fake.pipelineRefMutex.Unlock()
+	if fake.PipelineRefStub != nil {
+		return fake.PipelineRefStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.pipelineRefReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) PipelineRefCallCount() int {
+	fake.pipelineRefMutex.RLock()
+	defer fake.pipelineRefMutex.RUnlock()
+	return len(fake.pipelineRefArgsForCall)
+}
+
+func (fake *FakeJob) PipelineRefCalls(stub func() atc.PipelineRef) {
+	fake.pipelineRefMutex.Lock()
+	defer fake.pipelineRefMutex.Unlock()
+	fake.PipelineRefStub = stub
+}
+
+func (fake *FakeJob) PipelineRefReturns(result1 atc.PipelineRef) {
+	fake.pipelineRefMutex.Lock()
+	defer fake.pipelineRefMutex.Unlock()
+	fake.PipelineRefStub = nil
+	fake.pipelineRefReturns = struct {
+		result1 atc.PipelineRef
+	}{result1}
+}
+
+func (fake *FakeJob) PipelineRefReturnsOnCall(i int, result1 atc.PipelineRef) {
+	fake.pipelineRefMutex.Lock()
+	defer fake.pipelineRefMutex.Unlock()
+	fake.PipelineRefStub = nil
+	if fake.pipelineRefReturnsOnCall == nil {
+		fake.pipelineRefReturnsOnCall = make(map[int]struct {
+			result1 atc.PipelineRef
+		})
+	}
+	fake.pipelineRefReturnsOnCall[i] = struct {
+		result1 atc.PipelineRef
+	}{result1}
+}
+
 func (fake *FakeJob) Public() bool {
 	fake.publicMutex.Lock()
 	ret, specificReturn := fake.publicReturnsOnCall[len(fake.publicArgsForCall)]
@@ -2346,6 +2558,61 @@ func (fake *FakeJob) ScheduleRequestedTimeReturnsOnCall(i int, result1 time.Time
 	}{result1}
 }
 
+func (fake *FakeJob) SchedulingExplanation() (atc.SchedulingExplanation, error) {
+	fake.schedulingExplanationMutex.Lock()
+	ret, specificReturn := fake.schedulingExplanationReturnsOnCall[len(fake.schedulingExplanationArgsForCall)]
+	fake.schedulingExplanationArgsForCall = append(fake.schedulingExplanationArgsForCall, struct {
+	}{})
+	fake.recordInvocation("SchedulingExplanation", []interface{}{})
+	fake.schedulingExplanationMutex.Unlock()
+	if fake.SchedulingExplanationStub != nil {
+		return fake.SchedulingExplanationStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.schedulingExplanationReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) SchedulingExplanationCallCount() int {
+	fake.schedulingExplanationMutex.RLock()
+	defer fake.schedulingExplanationMutex.RUnlock()
+	return len(fake.schedulingExplanationArgsForCall)
+}
+
+func (fake *FakeJob) SchedulingExplanationCalls(stub func() (atc.SchedulingExplanation, error)) {
+	fake.schedulingExplanationMutex.Lock()
+	defer fake.schedulingExplanationMutex.Unlock()
+	fake.SchedulingExplanationStub = stub
+}
+
+func (fake *FakeJob) SchedulingExplanationReturns(result1 atc.SchedulingExplanation, result2 error) {
+	fake.schedulingExplanationMutex.Lock()
+	defer fake.schedulingExplanationMutex.Unlock()
+	fake.SchedulingExplanationStub = nil
+	fake.schedulingExplanationReturns = struct {
+		result1 atc.SchedulingExplanation
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) SchedulingExplanationReturnsOnCall(i int, result1 atc.SchedulingExplanation, result2 error) {
+	fake.schedulingExplanationMutex.Lock()
+	defer fake.schedulingExplanationMutex.Unlock()
+	fake.SchedulingExplanationStub = nil
+	if fake.schedulingExplanationReturnsOnCall == nil {
+		fake.schedulingExplanationReturnsOnCall = make(map[int]struct {
+			result1 atc.SchedulingExplanation
+			result2 error
+		})
+	}
+	fake.schedulingExplanationReturnsOnCall[i] = struct {
+		result1 atc.SchedulingExplanation
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) SetHasNewInputs(arg1 bool) error {
 	fake.setHasNewInputsMutex.Lock()
 	ret, specificReturn := fake.setHasNewInputsReturnsOnCall[len(fake.setHasNewInputsArgsForCall)]
@@ -2753,6 +3020,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.configMutex.RUnlock()
 	fake.createBuildMutex.RLock()
 	defer fake.createBuildMutex.RUnlock()
+	fake.createBuildWithPriorityMutex.RLock()
+	defer fake.createBuildWithPriorityMutex.RUnlock()
 	fake.disableManualTriggerMutex.RLock()
 	defer fake.disableManualTriggerMutex.RUnlock()
 	fake.ensurePendingBuildExistsMutex.RLock()
@@ -2787,8 +3056,12 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.pipelineMutex.RUnlock()
 	fake.pipelineIDMutex.RLock()
 	defer fake.pipelineIDMutex.RUnlock()
+	fake.pipelineInstanceVarsMutex.RLock()
+	defer fake.pipelineInstanceVarsMutex.RUnlock()
 	fake.pipelineNameMutex.RLock()
 	defer fake.pipelineNameMutex.RUnlock()
+	fake.pipelineRefMutex.RLock()
+	defer fake.pipelineRefMutex.RUnlock()
 	fake.publicMutex.RLock()
 	defer fake.publicMutex.RUnlock()
 	fake.reloadMutex.RLock()
@@ -2803,6 +3076,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.scheduleBuildMutex.RUnlock()
 	fake.scheduleRequestedTimeMutex.RLock()
 	defer fake.scheduleRequestedTimeMutex.RUnlock()
+	fake.schedulingExplanationMutex.RLock()
+	defer fake.schedulingExplanationMutex.RUnlock()
 	fake.setHasNewInputsMutex.RLock()
 	defer fake.setHasNewInputsMutex.RUnlock()
 	fake.tagsMutex.RLock()
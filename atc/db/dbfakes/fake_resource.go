@@ -80,6 +80,16 @@ type FakeResource struct {
 	configPinnedVersionReturnsOnCall map[int]struct {
 		result1 atc.Version
 	}
+	ConsecutiveCheckFailuresStub        func() int
+	consecutiveCheckFailuresMutex       sync.RWMutex
+	consecutiveCheckFailuresArgsForCall []struct {
+	}
+	consecutiveCheckFailuresReturns struct {
+		result1 int
+	}
+	consecutiveCheckFailuresReturnsOnCall map[int]struct {
+		result1 int
+	}
 	CurrentPinnedVersionStub        func() atc.Version
 	currentPinnedVersionMutex       sync.RWMutex
 	currentPinnedVersionArgsForCall []struct {
@@ -142,6 +152,16 @@ type FakeResource struct {
 	iconReturnsOnCall map[int]struct {
 		result1 string
 	}
+	IdleSinceStub        func() time.Time
+	idleSinceMutex       sync.RWMutex
+	idleSinceArgsForCall []struct {
+	}
+	idleSinceReturns struct {
+		result1 time.Time
+	}
+	idleSinceReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	LastCheckEndTimeStub        func() time.Time
 	lastCheckEndTimeMutex       sync.RWMutex
 	lastCheckEndTimeArgsForCall []struct {
@@ -152,6 +172,16 @@ type FakeResource struct {
 	lastCheckEndTimeReturnsOnCall map[int]struct {
 		result1 time.Time
 	}
+	LastCheckRateLimitDelayStub        func() time.Duration
+	lastCheckRateLimitDelayMutex       sync.RWMutex
+	lastCheckRateLimitDelayArgsForCall []struct {
+	}
+	lastCheckRateLimitDelayReturns struct {
+		result1 time.Duration
+	}
+	lastCheckRateLimitDelayReturnsOnCall map[int]struct {
+		result1 time.Duration
+	}
 	LastCheckStartTimeStub        func() time.Time
 	lastCheckStartTimeMutex       sync.RWMutex
 	lastCheckStartTimeArgsForCall []struct {
@@ -229,6 +259,16 @@ type FakeResource struct {
 	pipelineIDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	PipelineInstanceVarsStub        func() atc.InstanceVars
+	pipelineInstanceVarsMutex       sync.RWMutex
+	pipelineInstanceVarsArgsForCall []struct {
+	}
+	pipelineInstanceVarsReturns struct {
+		result1 atc.InstanceVars
+	}
+	pipelineInstanceVarsReturnsOnCall map[int]struct {
+		result1 atc.InstanceVars
+	}
 	PipelineNameStub        func() string
 	pipelineNameMutex       sync.RWMutex
 	pipelineNameArgsForCall []struct {
@@ -239,6 +279,16 @@ type FakeResource struct {
 	pipelineNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	PipelineRefStub        func() atc.PipelineRef
+	pipelineRefMutex       sync.RWMutex
+	pipelineRefArgsForCall []struct {
+	}
+	pipelineRefReturns struct {
+		result1 atc.PipelineRef
+	}
+	pipelineRefReturnsOnCall map[int]struct {
+		result1 atc.PipelineRef
+	}
 	PublicStub        func() bool
 	publicMutex       sync.RWMutex
 	publicArgsForCall []struct {
@@ -818,6 +868,58 @@ func (fake *FakeResource) ConfigPinnedVersionReturnsOnCall(i int, result1 atc.Ve
 	}{result1}
 }
 
+func (fake *FakeResource) ConsecutiveCheckFailures() int {
+	fake.consecutiveCheckFailuresMutex.Lock()
+	ret, specificReturn := fake.consecutiveCheckFailuresReturnsOnCall[len(fake.consecutiveCheckFailuresArgsForCall)]
+	fake.consecutiveCheckFailuresArgsForCall = append(fake.consecutiveCheckFailuresArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ConsecutiveCheckFailures", []interface{}{})
+	fake.consecutiveCheckFailuresMutex.Unlock()
+	if fake.ConsecutiveCheckFailuresStub != nil {
+		return fake.ConsecutiveCheckFailuresStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.consecutiveCheckFailuresReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) ConsecutiveCheckFailuresCallCount() int {
+	fake.consecutiveCheckFailuresMutex.RLock()
+	defer fake.consecutiveCheckFailuresMutex.RUnlock()
+	return len(fake.consecutiveCheckFailuresArgsForCall)
+}
+
+func (fake *FakeResource) ConsecutiveCheckFailuresCalls(stub func() int) {
+	fake.consecutiveCheckFailuresMutex.Lock()
+	defer fake.consecutiveCheckFailuresMutex.Unlock()
+	fake.ConsecutiveCheckFailuresStub = stub
+}
+
+func (fake *FakeResource) ConsecutiveCheckFailuresReturns(result1 int) {
+	fake.consecutiveCheckFailuresMutex.Lock()
+	defer fake.consecutiveCheckFailuresMutex.Unlock()
+	fake.ConsecutiveCheckFailuresStub = nil
+	fake.consecutiveCheckFailuresReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeResource) ConsecutiveCheckFailuresReturnsOnCall(i int, result1 int) {
+	fake.consecutiveCheckFailuresMutex.Lock()
+	defer fake.consecutiveCheckFailuresMutex.Unlock()
+	fake.ConsecutiveCheckFailuresStub = nil
+	if fake.consecutiveCheckFailuresReturnsOnCall == nil {
+		fake.consecutiveCheckFailuresReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.consecutiveCheckFailuresReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeResource) CurrentPinnedVersion() atc.Version {
 	fake.currentPinnedVersionMutex.Lock()
 	ret, specificReturn := fake.currentPinnedVersionReturnsOnCall[len(fake.currentPinnedVersionArgsForCall)]
@@ -1146,6 +1248,58 @@ func (fake *FakeResource) IconReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeResource) IdleSince() time.Time {
+	fake.idleSinceMutex.Lock()
+	ret, specificReturn := fake.idleSinceReturnsOnCall[len(fake.idleSinceArgsForCall)]
+	fake.idleSinceArgsForCall = append(fake.idleSinceArgsForCall, struct {
+	}{})
+	fake.recordInvocation("IdleSince", []interface{}{})
+	fake.idleSinceMutex.Unlock()
+	if fake.IdleSinceStub != nil {
+		return fake.IdleSinceStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.idleSinceReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) IdleSinceCallCount() int {
+	fake.idleSinceMutex.RLock()
+	defer fake.idleSinceMutex.RUnlock()
+	return len(fake.idleSinceArgsForCall)
+}
+
+func (fake *FakeResource) IdleSinceCalls(stub func() time.Time) {
+	fake.idleSinceMutex.Lock()
+	defer fake.idleSinceMutex.Unlock()
+	fake.IdleSinceStub = stub
+}
+
+func (fake *FakeResource) IdleSinceReturns(result1 time.Time) {
+	fake.idleSinceMutex.Lock()
+	defer fake.idleSinceMutex.Unlock()
+	fake.IdleSinceStub = nil
+	fake.idleSinceReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeResource) IdleSinceReturnsOnCall(i int, result1 time.Time) {
+	fake.idleSinceMutex.Lock()
+	defer fake.idleSinceMutex.Unlock()
+	fake.IdleSinceStub = nil
+	if fake.idleSinceReturnsOnCall == nil {
+		fake.idleSinceReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.idleSinceReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeResource) LastCheckEndTime() time.Time {
 	fake.lastCheckEndTimeMutex.Lock()
 	ret, specificReturn := fake.lastCheckEndTimeReturnsOnCall[len(fake.lastCheckEndTimeArgsForCall)]
@@ -1198,6 +1352,58 @@ func (fake *FakeResource) LastCheckEndTimeReturnsOnCall(i int, result1 time.Time
 	}{result1}
 }
 
+func (fake *FakeResource) LastCheckRateLimitDelay() time.Duration {
+	fake.lastCheckRateLimitDelayMutex.Lock()
+	ret, specificReturn := fake.lastCheckRateLimitDelayReturnsOnCall[len(fake.lastCheckRateLimitDelayArgsForCall)]
+	fake.lastCheckRateLimitDelayArgsForCall = append(fake.lastCheckRateLimitDelayArgsForCall, struct {
+	}{})
+	fake.recordInvocation("LastCheckRateLimitDelay", []interface{}{})
+	fake.lastCheckRateLimitDelayMutex.Unlock()
+	if fake.LastCheckRateLimitDelayStub != nil {
+		return fake.LastCheckRateLimitDelayStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.lastCheckRateLimitDelayReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) LastCheckRateLimitDelayCallCount() int {
+	fake.lastCheckRateLimitDelayMutex.RLock()
+	defer fake.lastCheckRateLimitDelayMutex.RUnlock()
+	return len(fake.lastCheckRateLimitDelayArgsForCall)
+}
+
+func (fake *FakeResource) LastCheckRateLimitDelayCalls(stub func() time.Duration) {
+	fake.lastCheckRateLimitDelayMutex.Lock()
+	defer fake.lastCheckRateLimitDelayMutex.Unlock()
+	fake.LastCheckRateLimitDelayStub = stub
+}
+
+func (fake *FakeResource) LastCheckRateLimitDelayReturns(result1 time.Duration) {
+	fake.lastCheckRateLimitDelayMutex.Lock()
+	defer fake.lastCheckRateLimitDelayMutex.Unlock()
+	fake.LastCheckRateLimitDelayStub = nil
+	fake.lastCheckRateLimitDelayReturns = struct {
+		result1 time.Duration
+	}{result1}
+}
+
+func (fake *FakeResource) LastCheckRateLimitDelayReturnsOnCall(i int, result1 time.Duration) {
+	fake.lastCheckRateLimitDelayMutex.Lock()
+	defer fake.lastCheckRateLimitDelayMutex.Unlock()
+	fake.LastCheckRateLimitDelayStub = nil
+	if fake.lastCheckRateLimitDelayReturnsOnCall == nil {
+		fake.lastCheckRateLimitDelayReturnsOnCall = make(map[int]struct {
+			result1 time.Duration
+		})
+	}
+	fake.lastCheckRateLimitDelayReturnsOnCall[i] = struct {
+		result1 time.Duration
+	}{result1}
+}
+
 func (fake *FakeResource) LastCheckStartTime() time.Time {
 	fake.lastCheckStartTimeMutex.Lock()
 	ret, specificReturn := fake.lastCheckStartTimeReturnsOnCall[len(fake.lastCheckStartTimeArgsForCall)]
@@ -1579,6 +1785,58 @@ func (fake *FakeResource) PipelineIDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeResource) PipelineInstanceVars() atc.InstanceVars {
+	fake.pipelineInstanceVarsMutex.Lock()
+	ret, specificReturn := fake.pipelineInstanceVarsReturnsOnCall[len(fake.pipelineInstanceVarsArgsForCall)]
+	fake.pipelineInstanceVarsArgsForCall = append(fake.pipelineInstanceVarsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PipelineInstanceVars", []interface{}{})
+	fake.pipelineInstanceVarsMutex.Unlock()
+	if fake.PipelineInstanceVarsStub != nil {
+		return fake.PipelineInstanceVarsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.pipelineInstanceVarsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) PipelineInstanceVarsCallCount() int {
+	fake.pipelineInstanceVarsMutex.RLock()
+	defer fake.pipelineInstanceVarsMutex.RUnlock()
+	return len(fake.pipelineInstanceVarsArgsForCall)
+}
+
+func (fake *FakeResource) PipelineInstanceVarsCalls(stub func() atc.InstanceVars) {
+	fake.pipelineInstanceVarsMutex.Lock()
+	defer fake.pipelineInstanceVarsMutex.Unlock()
+	fake.PipelineInstanceVarsStub = stub
+}
+
+func (fake *FakeResource) PipelineInstanceVarsReturns(result1 atc.InstanceVars) {
+	fake.pipelineInstanceVarsMutex.Lock()
+	defer fake.pipelineInstanceVarsMutex.Unlock()
+	fake.PipelineInstanceVarsStub = nil
+	fake.pipelineInstanceVarsReturns = struct {
+		result1 atc.InstanceVars
+	}{result1}
+}
+
+func (fake *FakeResource) PipelineInstanceVarsReturnsOnCall(i int, result1 atc.InstanceVars) {
+	fake.pipelineInstanceVarsMutex.Lock()
+	defer fake.pipelineInstanceVarsMutex.Unlock()
+	fake.PipelineInstanceVarsStub = nil
+	if fake.pipelineInstanceVarsReturnsOnCall == nil {
+		fake.pipelineInstanceVarsReturnsOnCall = make(map[int]struct {
+			result1 atc.InstanceVars
+		})
+	}
+	fake.pipelineInstanceVarsReturnsOnCall[i] = struct {
+		result1 atc.InstanceVars
+	}{result1}
+}
+
 func (fake *FakeResource) PipelineName() string {
 	fake.pipelineNameMutex.Lock()
 	ret, specificReturn := fake.pipelineNameReturnsOnCall[len(fake.pipelineNameArgsForCall)]
@@ -1631,6 +1889,58 @@ func (fake *FakeResource) PipelineNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeResource) PipelineRef() atc.PipelineRef {
+	fake.pipelineRefMutex.Lock()
+	ret, specificReturn := fake.pipelineRefReturnsOnCall[len(fake.pipelineRefArgsForCall)]
+	fake.pipelineRefArgsForCall = append(fake.pipelineRefArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PipelineRef", []interface{}{})
+	fake.pipelineRefMutex.Unlock()
+	if fake.PipelineRefStub != nil {
+		return fake.PipelineRefStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.pipelineRefReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) PipelineRefCallCount() int {
+	fake.pipelineRefMutex.RLock()
+	defer fake.pipelineRefMutex.RUnlock()
+	return len(fake.pipelineRefArgsForCall)
+}
+
+func (fake *FakeResource) PipelineRefCalls(stub func() atc.PipelineRef) {
+	fake.pipelineRefMutex.Lock()
+	defer fake.pipelineRefMutex.Unlock()
+	fake.PipelineRefStub = stub
+}
+
+func (fake *FakeResource) PipelineRefReturns(result1 atc.PipelineRef) {
+	fake.pipelineRefMutex.Lock()
+	defer fake.pipelineRefMutex.Unlock()
+	fake.PipelineRefStub = nil
+	fake.pipelineRefReturns = struct {
+		result1 atc.PipelineRef
+	}{result1}
+}
+
+func (fake *FakeResource) PipelineRefReturnsOnCall(i int, result1 atc.PipelineRef) {
+	fake.pipelineRefMutex.Lock()
+	defer fake.pipelineRefMutex.Unlock()
+	fake.PipelineRefStub = nil
+	if fake.pipelineRefReturnsOnCall == nil {
+		fake.pipelineRefReturnsOnCall = make(map[int]struct {
+			result1 atc.PipelineRef
+		})
+	}
+	fake.pipelineRefReturnsOnCall[i] = struct {
+		result1 atc.PipelineRef
+	}{result1}
+}
+
 func (fake *FakeResource) Public() bool {
 	fake.publicMutex.Lock()
 	ret, specificReturn := fake.publicReturnsOnCall[len(fake.publicArgsForCall)]
@@ -2673,6 +2983,8 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.configMutex.RUnlock()
 	fake.configPinnedVersionMutex.RLock()
 	defer fake.configPinnedVersionMutex.RUnlock()
+	fake.consecutiveCheckFailuresMutex.RLock()
+	defer fake.consecutiveCheckFailuresMutex.RUnlock()
 	fake.currentPinnedVersionMutex.RLock()
 	defer fake.currentPinnedVersionMutex.RUnlock()
 	fake.disableVersionMutex.RLock()
@@ -2685,8 +2997,12 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.iDMutex.RUnlock()
 	fake.iconMutex.RLock()
 	defer fake.iconMutex.RUnlock()
+	fake.idleSinceMutex.RLock()
+	defer fake.idleSinceMutex.RUnlock()
 	fake.lastCheckEndTimeMutex.RLock()
 	defer fake.lastCheckEndTimeMutex.RUnlock()
+	fake.lastCheckRateLimitDelayMutex.RLock()
+	defer fake.lastCheckRateLimitDelayMutex.RUnlock()
 	fake.lastCheckStartTimeMutex.RLock()
 	defer fake.lastCheckStartTimeMutex.RUnlock()
 	fake.nameMutex.RLock()
@@ -2701,8 +3017,12 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.pipelineMutex.RUnlock()
 	fake.pipelineIDMutex.RLock()
 	defer fake.pipelineIDMutex.RUnlock()
+	fake.pipelineInstanceVarsMutex.RLock()
+	defer fake.pipelineInstanceVarsMutex.RUnlock()
 	fake.pipelineNameMutex.RLock()
 	defer fake.pipelineNameMutex.RUnlock()
+	fake.pipelineRefMutex.RLock()
+	defer fake.pipelineRefMutex.RUnlock()
 	fake.publicMutex.RLock()
 	defer fake.publicMutex.RUnlock()
 	fake.reloadMutex.RLock()
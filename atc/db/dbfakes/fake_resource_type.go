@@ -50,6 +50,16 @@ type FakeResourceType struct {
 	checkTimeoutReturnsOnCall map[int]struct {
 		result1 string
 	}
+	ConsecutiveCheckFailuresStub        func() int
+	consecutiveCheckFailuresMutex       sync.RWMutex
+	consecutiveCheckFailuresArgsForCall []struct {
+	}
+	consecutiveCheckFailuresReturns struct {
+		result1 int
+	}
+	consecutiveCheckFailuresReturnsOnCall map[int]struct {
+		result1 int
+	}
 	CurrentPinnedVersionStub        func() atc.Version
 	currentPinnedVersionMutex       sync.RWMutex
 	currentPinnedVersionArgsForCall []struct {
@@ -80,6 +90,16 @@ type FakeResourceType struct {
 	iDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	IdleSinceStub        func() time.Time
+	idleSinceMutex       sync.RWMutex
+	idleSinceArgsForCall []struct {
+	}
+	idleSinceReturns struct {
+		result1 time.Time
+	}
+	idleSinceReturnsOnCall map[int]struct {
+		result1 time.Time
+	}
 	LastCheckEndTimeStub        func() time.Time
 	lastCheckEndTimeMutex       sync.RWMutex
 	lastCheckEndTimeArgsForCall []struct {
@@ -144,6 +164,16 @@ type FakeResourceType struct {
 	pipelineIDReturnsOnCall map[int]struct {
 		result1 int
 	}
+	PipelineInstanceVarsStub        func() atc.InstanceVars
+	pipelineInstanceVarsMutex       sync.RWMutex
+	pipelineInstanceVarsArgsForCall []struct {
+	}
+	pipelineInstanceVarsReturns struct {
+		result1 atc.InstanceVars
+	}
+	pipelineInstanceVarsReturnsOnCall map[int]struct {
+		result1 atc.InstanceVars
+	}
 	PipelineNameStub        func() string
 	pipelineNameMutex       sync.RWMutex
 	pipelineNameArgsForCall []struct {
@@ -154,6 +184,16 @@ type FakeResourceType struct {
 	pipelineNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	PipelineRefStub        func() atc.PipelineRef
+	pipelineRefMutex       sync.RWMutex
+	pipelineRefArgsForCall []struct {
+	}
+	pipelineRefReturns struct {
+		result1 atc.PipelineRef
+	}
+	pipelineRefReturnsOnCall map[int]struct {
+		result1 atc.PipelineRef
+	}
 	PrivilegedStub        func() bool
 	privilegedMutex       sync.RWMutex
 	privilegedArgsForCall []struct {
@@ -493,6 +533,58 @@ func (fake *FakeResourceType) CheckTimeoutReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeResourceType) ConsecutiveCheckFailures() int {
+	fake.consecutiveCheckFailuresMutex.Lock()
+	ret, specificReturn := fake.consecutiveCheckFailuresReturnsOnCall[len(fake.consecutiveCheckFailuresArgsForCall)]
+	fake.consecutiveCheckFailuresArgsForCall = append(fake.consecutiveCheckFailuresArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ConsecutiveCheckFailures", []interface{}{})
+	fake.consecutiveCheckFailuresMutex.Unlock()
+	if fake.ConsecutiveCheckFailuresStub != nil {
+		return fake.ConsecutiveCheckFailuresStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.consecutiveCheckFailuresReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceType) ConsecutiveCheckFailuresCallCount() int {
+	fake.consecutiveCheckFailuresMutex.RLock()
+	defer fake.consecutiveCheckFailuresMutex.RUnlock()
+	return len(fake.consecutiveCheckFailuresArgsForCall)
+}
+
+func (fake *FakeResourceType) ConsecutiveCheckFailuresCalls(stub func() int) {
+	fake.consecutiveCheckFailuresMutex.Lock()
+	defer fake.consecutiveCheckFailuresMutex.Unlock()
+	fake.ConsecutiveCheckFailuresStub = stub
+}
+
+func (fake *FakeResourceType) ConsecutiveCheckFailuresReturns(result1 int) {
+	fake.consecutiveCheckFailuresMutex.Lock()
+	defer fake.consecutiveCheckFailuresMutex.Unlock()
+	fake.ConsecutiveCheckFailuresStub = nil
+	fake.consecutiveCheckFailuresReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeResourceType) ConsecutiveCheckFailuresReturnsOnCall(i int, result1 int) {
+	fake.consecutiveCheckFailuresMutex.Lock()
+	defer fake.consecutiveCheckFailuresMutex.Unlock()
+	fake.ConsecutiveCheckFailuresStub = nil
+	if fake.consecutiveCheckFailuresReturnsOnCall == nil {
+		fake.consecutiveCheckFailuresReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.consecutiveCheckFailuresReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeResourceType) CurrentPinnedVersion() atc.Version {
 	fake.currentPinnedVersionMutex.Lock()
 	ret, specificReturn := fake.currentPinnedVersionReturnsOnCall[len(fake.currentPinnedVersionArgsForCall)]
@@ -649,6 +741,58 @@ func (fake *FakeResourceType) IDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeResourceType) IdleSince() time.Time {
+	fake.idleSinceMutex.Lock()
+	ret, specificReturn := fake.idleSinceReturnsOnCall[len(fake.idleSinceArgsForCall)]
+	fake.idleSinceArgsForCall = append(fake.idleSinceArgsForCall, struct {
+	}{})
+	fake.recordInvocation("IdleSince", []interface{}{})
+	fake.idleSinceMutex.Unlock()
+	if fake.IdleSinceStub != nil {
+		return fake.IdleSinceStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.idleSinceReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceType) IdleSinceCallCount() int {
+	fake.idleSinceMutex.RLock()
+	defer fake.idleSinceMutex.RUnlock()
+	return len(fake.idleSinceArgsForCall)
+}
+
+func (fake *FakeResourceType) IdleSinceCalls(stub func() time.Time) {
+	fake.idleSinceMutex.Lock()
+	defer fake.idleSinceMutex.Unlock()
+	fake.IdleSinceStub = stub
+}
+
+func (fake *FakeResourceType) IdleSinceReturns(result1 time.Time) {
+	fake.idleSinceMutex.Lock()
+	defer fake.idleSinceMutex.Unlock()
+	fake.IdleSinceStub = nil
+	fake.idleSinceReturns = struct {
+		result1 time.Time
+	}{result1}
+}
+
+func (fake *FakeResourceType) IdleSinceReturnsOnCall(i int, result1 time.Time) {
+	fake.idleSinceMutex.Lock()
+	defer fake.idleSinceMutex.Unlock()
+	fake.IdleSinceStub = nil
+	if fake.idleSinceReturnsOnCall == nil {
+		fake.idleSinceReturnsOnCall = make(map[int]struct {
+			result1 time.Time
+		})
+	}
+	fake.idleSinceReturnsOnCall[i] = struct {
+		result1 time.Time
+	}{result1}
+}
+
 func (fake *FakeResourceType) LastCheckEndTime() time.Time {
 	fake.lastCheckEndTimeMutex.Lock()
 	ret, specificReturn := fake.lastCheckEndTimeReturnsOnCall[len(fake.lastCheckEndTimeArgsForCall)]
@@ -967,6 +1111,58 @@ func (fake *FakeResourceType) PipelineIDReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeResourceType) PipelineInstanceVars() atc.InstanceVars {
+	fake.pipelineInstanceVarsMutex.Lock()
+	ret, specificReturn := fake.pipelineInstanceVarsReturnsOnCall[len(fake.pipelineInstanceVarsArgsForCall)]
+	fake.pipelineInstanceVarsArgsForCall = append(fake.pipelineInstanceVarsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PipelineInstanceVars", []interface{}{})
+	fake.pipelineInstanceVarsMutex.Unlock()
+	if fake.PipelineInstanceVarsStub != nil {
+		return fake.PipelineInstanceVarsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.pipelineInstanceVarsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceType) PipelineInstanceVarsCallCount() int {
+	fake.pipelineInstanceVarsMutex.RLock()
+	defer fake.pipelineInstanceVarsMutex.RUnlock()
+	return len(fake.pipelineInstanceVarsArgsForCall)
+}
+
+func (fake *FakeResourceType) PipelineInstanceVarsCalls(stub func() atc.InstanceVars) {
+	fake.pipelineInstanceVarsMutex.Lock()
+	defer fake.pipelineInstanceVarsMutex.Unlock()
+	fake.PipelineInstanceVarsStub = stub
+}
+
+func (fake *FakeResourceType) PipelineInstanceVarsReturns(result1 atc.InstanceVars) {
+	fake.pipelineInstanceVarsMutex.Lock()
+	defer fake.pipelineInstanceVarsMutex.Unlock()
+	fake.PipelineInstanceVarsStub = nil
+	fake.pipelineInstanceVarsReturns = struct {
+		result1 atc.InstanceVars
+	}{result1}
+}
+
+func (fake *FakeResourceType) PipelineInstanceVarsReturnsOnCall(i int, result1 atc.InstanceVars) {
+	fake.pipelineInstanceVarsMutex.Lock()
+	defer fake.pipelineInstanceVarsMutex.Unlock()
+	fake.PipelineInstanceVarsStub = nil
+	if fake.pipelineInstanceVarsReturnsOnCall == nil {
+		fake.pipelineInstanceVarsReturnsOnCall = make(map[int]struct {
+			result1 atc.InstanceVars
+		})
+	}
+	fake.pipelineInstanceVarsReturnsOnCall[i] = struct {
+		result1 atc.InstanceVars
+	}{result1}
+}
+
 func (fake *FakeResourceType) PipelineName() string {
 	fake.pipelineNameMutex.Lock()
 	ret, specificReturn := fake.pipelineNameReturnsOnCall[len(fake.pipelineNameArgsForCall)]
@@ -1019,6 +1215,58 @@ func (fake *FakeResourceType) PipelineNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeResourceType) PipelineRef() atc.PipelineRef {
+	fake.pipelineRefMutex.Lock()
+	ret, specificReturn := fake.pipelineRefReturnsOnCall[len(fake.pipelineRefArgsForCall)]
+	fake.pipelineRefArgsForCall = append(fake.pipelineRefArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PipelineRef", []interface{}{})
+	fake.pipelineRefMutex.Unlock()
+	if fake.PipelineRefStub != nil {
+		return fake.PipelineRefStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.pipelineRefReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeResourceType) PipelineRefCallCount() int {
+	fake.pipelineRefMutex.RLock()
+	defer fake.pipelineRefMutex.RUnlock()
+	return len(fake.pipelineRefArgsForCall)
+}
+
+func (fake *FakeResourceType) PipelineRefCalls(stub func() atc.PipelineRef) {
+	fake.pipelineRefMutex.Lock()
+	defer fake.pipelineRefMutex.Unlock()
+	fake.PipelineRefStub = stub
+}
+
+func (fake *FakeResourceType) PipelineRefReturns(result1 atc.PipelineRef) {
+	fake.pipelineRefMutex.Lock()
+	defer fake.pipelineRefMutex.Unlock()
+	fake.PipelineRefStub = nil
+	fake.pipelineRefReturns = struct {
+		result1 atc.PipelineRef
+	}{result1}
+}
+
+func (fake *FakeResourceType) PipelineRefReturnsOnCall(i int, result1 atc.PipelineRef) {
+	fake.pipelineRefMutex.Lock()
+	defer fake.pipelineRefMutex.Unlock()
+	fake.PipelineRefStub = nil
+	if fake.pipelineRefReturnsOnCall == nil {
+		fake.pipelineRefReturnsOnCall = make(map[int]struct {
+			result1 atc.PipelineRef
+		})
+	}
+	fake.pipelineRefReturnsOnCall[i] = struct {
+		result1 atc.PipelineRef
+	}{result1}
+}
+
 func (fake *FakeResourceType) Privileged() bool {
 	fake.privilegedMutex.Lock()
 	ret, specificReturn := fake.privilegedReturnsOnCall[len(fake.privilegedArgsForCall)]
@@ -1677,12 +1925,16 @@ func (fake *FakeResourceType) Invocations() map[string][][]interface{} {
 	defer fake.checkSetupErrorMutex.RUnlock()
 	fake.checkTimeoutMutex.RLock()
 	defer fake.checkTimeoutMutex.RUnlock()
+	fake.consecutiveCheckFailuresMutex.RLock()
+	defer fake.consecutiveCheckFailuresMutex.RUnlock()
 	fake.currentPinnedVersionMutex.RLock()
 	defer fake.currentPinnedVersionMutex.RUnlock()
 	fake.hasWebhookMutex.RLock()
 	defer fake.hasWebhookMutex.RUnlock()
 	fake.iDMutex.RLock()
 	defer fake.iDMutex.RUnlock()
+	fake.idleSinceMutex.RLock()
+	defer fake.idleSinceMutex.RUnlock()
 	fake.lastCheckEndTimeMutex.RLock()
 	defer fake.lastCheckEndTimeMutex.RUnlock()
 	fake.lastCheckStartTimeMutex.RLock()
@@ -1695,8 +1947,12 @@ func (fake *FakeResourceType) Invocations() map[string][][]interface{} {
 	defer fake.pipelineMutex.RUnlock()
 	fake.pipelineIDMutex.RLock()
 	defer fake.pipelineIDMutex.RUnlock()
+	fake.pipelineInstanceVarsMutex.RLock()
+	defer fake.pipelineInstanceVarsMutex.RUnlock()
 	fake.pipelineNameMutex.RLock()
 	defer fake.pipelineNameMutex.RUnlock()
+	fake.pipelineRefMutex.RLock()
+	defer fake.pipelineRefMutex.RUnlock()
 	fake.privilegedMutex.RLock()
 	defer fake.privilegedMutex.RUnlock()
 	fake.reloadMutex.RLock()
@@ -99,6 +99,16 @@ type FakeTeam struct {
 		result1 db.Build
 		result2 error
 	}
+	DefaultBuildPriorityStub        func() int
+	defaultBuildPriorityMutex       sync.RWMutex
+	defaultBuildPriorityArgsForCall []struct {
+	}
+	defaultBuildPriorityReturns struct {
+		result1 int
+	}
+	defaultBuildPriorityReturnsOnCall map[int]struct {
+		result1 int
+	}
 	DeleteStub        func() error
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
@@ -109,11 +119,11 @@ type FakeTeam struct {
 	deleteReturnsOnCall map[int]struct {
 		result1 error
 	}
-	FindCheckContainersStub        func(lager.Logger, string, string, creds.Secrets, creds.VarSourcePool) ([]db.Container, map[int]time.Time, error)
+	FindCheckContainersStub        func(lager.Logger, atc.PipelineRef, string, creds.Secrets, creds.VarSourcePool) ([]db.Container, map[int]time.Time, error)
 	findCheckContainersMutex       sync.RWMutex
 	findCheckContainersArgsForCall []struct {
 		arg1 lager.Logger
-		arg2 string
+		arg2 atc.PipelineRef
 		arg3 string
 		arg4 creds.Secrets
 		arg5 creds.VarSourcePool
@@ -274,10 +284,10 @@ type FakeTeam struct {
 	orderPipelinesReturnsOnCall map[int]struct {
 		result1 error
 	}
-	PipelineStub        func(string) (db.Pipeline, bool, error)
+	PipelineStub        func(atc.PipelineRef) (db.Pipeline, bool, error)
 	pipelineMutex       sync.RWMutex
 	pipelineArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}
 	pipelineReturns struct {
 		result1 db.Pipeline
@@ -328,6 +338,28 @@ type FakeTeam struct {
 		result1 []db.Pipeline
 		result2 error
 	}
+	QuotaUsageStub        func() (db.TeamQuotaUsage, error)
+	quotaUsageMutex       sync.RWMutex
+	quotaUsageArgsForCall []struct {
+	}
+	quotaUsageReturns struct {
+		result1 db.TeamQuotaUsage
+		result2 error
+	}
+	quotaUsageReturnsOnCall map[int]struct {
+		result1 db.TeamQuotaUsage
+		result2 error
+	}
+	QuotasStub        func() atc.TeamQuotas
+	quotasMutex       sync.RWMutex
+	quotasArgsForCall []struct {
+	}
+	quotasReturns struct {
+		result1 atc.TeamQuotas
+	}
+	quotasReturnsOnCall map[int]struct {
+		result1 atc.TeamQuotas
+	}
 	RenameStub        func(string) error
 	renameMutex       sync.RWMutex
 	renameArgsForCall []struct {
@@ -339,10 +371,10 @@ type FakeTeam struct {
 	renameReturnsOnCall map[int]struct {
 		result1 error
 	}
-	SavePipelineStub        func(string, atc.Config, db.ConfigVersion, bool) (db.Pipeline, bool, error)
+	SavePipelineStub        func(atc.PipelineRef, atc.Config, db.ConfigVersion, bool) (db.Pipeline, bool, error)
 	savePipelineMutex       sync.RWMutex
 	savePipelineArgsForCall []struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 atc.Config
 		arg3 db.ConfigVersion
 		arg4 bool
@@ -371,6 +403,17 @@ type FakeTeam struct {
 		result1 db.Worker
 		result2 error
 	}
+	UpdateDefaultBuildPriorityStub        func(int) error
+	updateDefaultBuildPriorityMutex       sync.RWMutex
+	updateDefaultBuildPriorityArgsForCall []struct {
+		arg1 int
+	}
+	updateDefaultBuildPriorityReturns struct {
+		result1 error
+	}
+	updateDefaultBuildPriorityReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateProviderAuthStub        func(atc.TeamAuth) error
 	updateProviderAuthMutex       sync.RWMutex
 	updateProviderAuthArgsForCall []struct {
@@ -382,6 +425,30 @@ type FakeTeam struct {
 	updateProviderAuthReturnsOnCall map[int]struct {
 		result1 error
 	}
+	UpdateQuotasStub        func(atc.TeamQuotas) error
+	updateQuotasMutex       sync.RWMutex
+	updateQuotasArgsForCall []struct {
+		arg1 atc.TeamQuotas
+	}
+	updateQuotasReturns struct {
+		result1 error
+	}
+	updateQuotasReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateSettingsStub        func(atc.TeamAuth, *int, *atc.TeamQuotas) error
+	updateSettingsMutex       sync.RWMutex
+	updateSettingsArgsForCall []struct {
+		arg1 atc.TeamAuth
+		arg2 *int
+		arg3 *atc.TeamQuotas
+	}
+	updateSettingsReturns struct {
+		result1 error
+	}
+	updateSettingsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	WorkersStub        func() ([]db.Worker, error)
 	workersMutex       sync.RWMutex
 	workersArgsForCall []struct {
@@ -807,6 +874,58 @@ func (fake *FakeTeam) CreateStartedBuildReturnsOnCall(i int, result1 db.Build, r
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) DefaultBuildPriority() int {
+	fake.defaultBuildPriorityMutex.Lock()
+	ret, specificReturn := fake.defaultBuildPriorityReturnsOnCall[len(fake.defaultBuildPriorityArgsForCall)]
+	fake.defaultBuildPriorityArgsForCall = append(fake.defaultBuildPriorityArgsForCall, struct {
+	}{})
+	fake.recordInvocation("DefaultBuildPriority", []interface{}{})
+	fake.defaultBuildPriorityMutex.Unlock()
+	if fake.DefaultBuildPriorityStub != nil {
+		return fake.DefaultBuildPriorityStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.defaultBuildPriorityReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) DefaultBuildPriorityCallCount() int {
+	fake.defaultBuildPriorityMutex.RLock()
+	defer fake.defaultBuildPriorityMutex.RUnlock()
+	return len(fake.defaultBuildPriorityArgsForCall)
+}
+
+func (fake *FakeTeam) DefaultBuildPriorityCalls(stub func() int) {
+	fake.defaultBuildPriorityMutex.Lock()
+	defer fake.defaultBuildPriorityMutex.Unlock()
+	fake.DefaultBuildPriorityStub = stub
+}
+
+func (fake *FakeTeam) DefaultBuildPriorityReturns(result1 int) {
+	fake.defaultBuildPriorityMutex.Lock()
+	defer fake.defaultBuildPriorityMutex.Unlock()
+	fake.DefaultBuildPriorityStub = nil
+	fake.defaultBuildPriorityReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeTeam) DefaultBuildPriorityReturnsOnCall(i int, result1 int) {
+	fake.defaultBuildPriorityMutex.Lock()
+	defer fake.defaultBuildPriorityMutex.Unlock()
+	fake.DefaultBuildPriorityStub = nil
+	if fake.defaultBuildPriorityReturnsOnCall == nil {
+		fake.defaultBuildPriorityReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.defaultBuildPriorityReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeTeam) Delete() error {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
@@ -859,12 +978,12 @@ func (fake *FakeTeam) DeleteReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeTeam) FindCheckContainers(arg1 lager.Logger, arg2 string, arg3 string, arg4 creds.Secrets, arg5 creds.VarSourcePool) ([]db.Container, map[int]time.Time, error) {
+func (fake *FakeTeam) FindCheckContainers(arg1 lager.Logger, arg2 atc.PipelineRef, arg3 string, arg4 creds.Secrets, arg5 creds.VarSourcePool) ([]db.Container, map[int]time.Time, error) {
 	fake.findCheckContainersMutex.Lock()
 	ret, specificReturn := fake.findCheckContainersReturnsOnCall[len(fake.findCheckContainersArgsForCall)]
 	fake.findCheckContainersArgsForCall = append(fake.findCheckContainersArgsForCall, struct {
 		arg1 lager.Logger
-		arg2 string
+		arg2 atc.PipelineRef
 		arg3 string
 		arg4 creds.Secrets
 		arg5 creds.VarSourcePool
@@ -887,13 +1006,13 @@ func (fake *FakeTeam) FindCheckContainersCallCount() int {
 	return len(fake.findCheckContainersArgsForCall)
 }
 
-func (fake *FakeTeam) FindCheckContainersCalls(stub func(lager.Logger, string, string, creds.Secrets, creds.VarSourcePool) ([]db.Container, map[int]time.Time, error)) {
+func (fake *FakeTeam) FindCheckContainersCalls(stub func(lager.Logger, atc.PipelineRef, string, creds.Secrets, creds.VarSourcePool) ([]db.Container, map[int]time.Time, error)) {
 	fake.findCheckContainersMutex.Lock()
 	defer fake.findCheckContainersMutex.Unlock()
 	fake.FindCheckContainersStub = stub
 }
 
-func (fake *FakeTeam) FindCheckContainersArgsForCall(i int) (lager.Logger, string, string, creds.Secrets, creds.VarSourcePool) {
+func (fake *FakeTeam) FindCheckContainersArgsForCall(i int) (lager.Logger, atc.PipelineRef, string, creds.Secrets, creds.VarSourcePool) {
 	fake.findCheckContainersMutex.RLock()
 	defer fake.findCheckContainersMutex.RUnlock()
 	argsForCall := fake.findCheckContainersArgsForCall[i]
@@ -1618,11 +1737,11 @@ func (fake *FakeTeam) OrderPipelinesReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeTeam) Pipeline(arg1 string) (db.Pipeline, bool, error) {
+func (fake *FakeTeam) Pipeline(arg1 atc.PipelineRef) (db.Pipeline, bool, error) {
 	fake.pipelineMutex.Lock()
 	ret, specificReturn := fake.pipelineReturnsOnCall[len(fake.pipelineArgsForCall)]
 	fake.pipelineArgsForCall = append(fake.pipelineArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 	}{arg1})
 	fake.recordInvocation("Pipeline", []interface{}{arg1})
 	fake.pipelineMutex.Unlock()
@@ -1642,13 +1761,13 @@ func (fake *FakeTeam) PipelineCallCount() int {
 	return len(fake.pipelineArgsForCall)
 }
 
-func (fake *FakeTeam) PipelineCalls(stub func(string) (db.Pipeline, bool, error)) {
+func (fake *FakeTeam) PipelineCalls(stub func(atc.PipelineRef) (db.Pipeline, bool, error)) {
 	fake.pipelineMutex.Lock()
 	defer fake.pipelineMutex.Unlock()
 	fake.PipelineStub = stub
 }
 
-func (fake *FakeTeam) PipelineArgsForCall(i int) string {
+func (fake *FakeTeam) PipelineArgsForCall(i int) atc.PipelineRef {
 	fake.pipelineMutex.RLock()
 	defer fake.pipelineMutex.RUnlock()
 	argsForCall := fake.pipelineArgsForCall[i]
@@ -1860,6 +1979,113 @@ func (fake *FakeTeam) PublicPipelinesReturnsOnCall(i int, result1 []db.Pipeline,
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) QuotaUsage() (db.TeamQuotaUsage, error) {
+	fake.quotaUsageMutex.Lock()
+	ret, specificReturn := fake.quotaUsageReturnsOnCall[len(fake.quotaUsageArgsForCall)]
+	fake.quotaUsageArgsForCall = append(fake.quotaUsageArgsForCall, struct {
+	}{})
+	fake.recordInvocation("QuotaUsage", []interface{}{})
+	fake.quotaUsageMutex.Unlock()
+	if fake.QuotaUsageStub != nil {
+		return fake.QuotaUsageStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.quotaUsageReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) QuotaUsageCallCount() int {
+	fake.quotaUsageMutex.RLock()
+	defer fake.quotaUsageMutex.RUnlock()
+	return len(fake.quotaUsageArgsForCall)
+}
+
+func (fake *FakeTeam) QuotaUsageCalls(stub func() (db.TeamQuotaUsage, error)) {
+	fake.quotaUsageMutex.Lock()
+	defer fake.quotaUsageMutex.Unlock()
+	fake.QuotaUsageStub = stub
+}
+
+func (fake *FakeTeam) QuotaUsageReturns(result1 db.TeamQuotaUsage, result2 error) {
+	fake.quotaUsageMutex.Lock()
+	defer fake.quotaUsageMutex.Unlock()
+	fake.QuotaUsageStub = nil
+	fake.quotaUsageReturns = struct {
+		result1 db.TeamQuotaUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) QuotaUsageReturnsOnCall(i int, result1 db.TeamQuotaUsage, result2 error) {
+	fake.quotaUsageMutex.Lock()
+	defer fake.quotaUsageMutex.Unlock()
+	fake.QuotaUsageStub = nil
+	if fake.quotaUsageReturnsOnCall == nil {
+		fake.quotaUsageReturnsOnCall = make(map[int]struct {
+			result1 db.TeamQuotaUsage
+			result2 error
+		})
+	}
+	fake.quotaUsageReturnsOnCall[i] = struct {
+		result1 db.TeamQuotaUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) Quotas() atc.TeamQuotas {
+	fake.quotasMutex.Lock()
+	ret, specificReturn := fake.quotasReturnsOnCall[len(fake.quotasArgsForCall)]
+	fake.quotasArgsForCall = append(fake.quotasArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Quotas", []interface{}{})
+	fake.quotasMutex.Unlock()
+	if fake.QuotasStub != nil {
+		return fake.QuotasStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.quotasReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) QuotasCallCount() int {
+	fake.quotasMutex.RLock()
+	defer fake.quotasMutex.RUnlock()
+	return len(fake.quotasArgsForCall)
+}
+
+func (fake *FakeTeam) QuotasCalls(stub func() atc.TeamQuotas) {
+	fake.quotasMutex.Lock()
+	defer fake.quotasMutex.Unlock()
+	fake.QuotasStub = stub
+}
+
+func (fake *FakeTeam) QuotasReturns(result1 atc.TeamQuotas) {
+	fake.quotasMutex.Lock()
+	defer fake.quotasMutex.Unlock()
+	fake.QuotasStub = nil
+	fake.quotasReturns = struct {
+		result1 atc.TeamQuotas
+	}{result1}
+}
+
+func (fake *FakeTeam) QuotasReturnsOnCall(i int, result1 atc.TeamQuotas) {
+	fake.quotasMutex.Lock()
+	defer fake.quotasMutex.Unlock()
+	fake.QuotasStub = nil
+	if fake.quotasReturnsOnCall == nil {
+		fake.quotasReturnsOnCall = make(map[int]struct {
+			result1 atc.TeamQuotas
+		})
+	}
+	fake.quotasReturnsOnCall[i] = struct {
+		result1 atc.TeamQuotas
+	}{result1}
+}
+
 func (fake *FakeTeam) Rename(arg1 string) error {
 	fake.renameMutex.Lock()
 	ret, specificReturn := fake.renameReturnsOnCall[len(fake.renameArgsForCall)]
@@ -1920,11 +2146,11 @@ func (fake *FakeTeam) RenameReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeTeam) SavePipeline(arg1 string, arg2 atc.Config, arg3 db.ConfigVersion, arg4 bool) (db.Pipeline, bool, error) {
+func (fake *FakeTeam) SavePipeline(arg1 atc.PipelineRef, arg2 atc.Config, arg3 db.ConfigVersion, arg4 bool) (db.Pipeline, bool, error) {
 	fake.savePipelineMutex.Lock()
 	ret, specificReturn := fake.savePipelineReturnsOnCall[len(fake.savePipelineArgsForCall)]
 	fake.savePipelineArgsForCall = append(fake.savePipelineArgsForCall, struct {
-		arg1 string
+		arg1 atc.PipelineRef
 		arg2 atc.Config
 		arg3 db.ConfigVersion
 		arg4 bool
@@ -1947,13 +2173,13 @@ func (fake *FakeTeam) SavePipelineCallCount() int {
 	return len(fake.savePipelineArgsForCall)
 }
 
-func (fake *FakeTeam) SavePipelineCalls(stub func(string, atc.Config, db.ConfigVersion, bool) (db.Pipeline, bool, error)) {
+func (fake *FakeTeam) SavePipelineCalls(stub func(atc.PipelineRef, atc.Config, db.ConfigVersion, bool) (db.Pipeline, bool, error)) {
 	fake.savePipelineMutex.Lock()
 	defer fake.savePipelineMutex.Unlock()
 	fake.SavePipelineStub = stub
 }
 
-func (fake *FakeTeam) SavePipelineArgsForCall(i int) (string, atc.Config, db.ConfigVersion, bool) {
+func (fake *FakeTeam) SavePipelineArgsForCall(i int) (atc.PipelineRef, atc.Config, db.ConfigVersion, bool) {
 	fake.savePipelineMutex.RLock()
 	defer fake.savePipelineMutex.RUnlock()
 	argsForCall := fake.savePipelineArgsForCall[i]
@@ -2053,6 +2279,66 @@ func (fake *FakeTeam) SaveWorkerReturnsOnCall(i int, result1 db.Worker, result2
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) UpdateDefaultBuildPriority(arg1 int) error {
+	fake.updateDefaultBuildPriorityMutex.Lock()
+	ret, specificReturn := fake.updateDefaultBuildPriorityReturnsOnCall[len(fake.updateDefaultBuildPriorityArgsForCall)]
+	fake.updateDefaultBuildPriorityArgsForCall = append(fake.updateDefaultBuildPriorityArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("UpdateDefaultBuildPriority", []interface{}{arg1})
+	fake.updateDefaultBuildPriorityMutex.Unlock()
+	if fake.UpdateDefaultBuildPriorityStub != nil {
+		return fake.UpdateDefaultBuildPriorityStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateDefaultBuildPriorityReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateDefaultBuildPriorityCallCount() int {
+	fake.updateDefaultBuildPriorityMutex.RLock()
+	defer fake.updateDefaultBuildPriorityMutex.RUnlock()
+	return len(fake.updateDefaultBuildPriorityArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateDefaultBuildPriorityCalls(stub func(int) error) {
+	fake.updateDefaultBuildPriorityMutex.Lock()
+	defer fake.updateDefaultBuildPriorityMutex.Unlock()
+	fake.UpdateDefaultBuildPriorityStub = stub
+}
+
+func (fake *FakeTeam) UpdateDefaultBuildPriorityArgsForCall(i int) int {
+	fake.updateDefaultBuildPriorityMutex.RLock()
+	defer fake.updateDefaultBuildPriorityMutex.RUnlock()
+	argsForCall := fake.updateDefaultBuildPriorityArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdateDefaultBuildPriorityReturns(result1 error) {
+	fake.updateDefaultBuildPriorityMutex.Lock()
+	defer fake.updateDefaultBuildPriorityMutex.Unlock()
+	fake.UpdateDefaultBuildPriorityStub = nil
+	fake.updateDefaultBuildPriorityReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateDefaultBuildPriorityReturnsOnCall(i int, result1 error) {
+	fake.updateDefaultBuildPriorityMutex.Lock()
+	defer fake.updateDefaultBuildPriorityMutex.Unlock()
+	fake.UpdateDefaultBuildPriorityStub = nil
+	if fake.updateDefaultBuildPriorityReturnsOnCall == nil {
+		fake.updateDefaultBuildPriorityReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateDefaultBuildPriorityReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTeam) UpdateProviderAuth(arg1 atc.TeamAuth) error {
 	fake.updateProviderAuthMutex.Lock()
 	ret, specificReturn := fake.updateProviderAuthReturnsOnCall[len(fake.updateProviderAuthArgsForCall)]
@@ -2113,6 +2399,128 @@ func (fake *FakeTeam) UpdateProviderAuthReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTeam) UpdateQuotas(arg1 atc.TeamQuotas) error {
+	fake.updateQuotasMutex.Lock()
+	ret, specificReturn := fake.updateQuotasReturnsOnCall[len(fake.updateQuotasArgsForCall)]
+	fake.updateQuotasArgsForCall = append(fake.updateQuotasArgsForCall, struct {
+		arg1 atc.TeamQuotas
+	}{arg1})
+	fake.recordInvocation("UpdateQuotas", []interface{}{arg1})
+	fake.updateQuotasMutex.Unlock()
+	if fake.UpdateQuotasStub != nil {
+		return fake.UpdateQuotasStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateQuotasReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateQuotasCallCount() int {
+	fake.updateQuotasMutex.RLock()
+	defer fake.updateQuotasMutex.RUnlock()
+	return len(fake.updateQuotasArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateQuotasCalls(stub func(atc.TeamQuotas) error) {
+	fake.updateQuotasMutex.Lock()
+	defer fake.updateQuotasMutex.Unlock()
+	fake.UpdateQuotasStub = stub
+}
+
+func (fake *FakeTeam) UpdateQuotasArgsForCall(i int) atc.TeamQuotas {
+	fake.updateQuotasMutex.RLock()
+	defer fake.updateQuotasMutex.RUnlock()
+	argsForCall := fake.updateQuotasArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdateQuotasReturns(result1 error) {
+	fake.updateQuotasMutex.Lock()
+	defer fake.updateQuotasMutex.Unlock()
+	fake.UpdateQuotasStub = nil
+	fake.updateQuotasReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateQuotasReturnsOnCall(i int, result1 error) {
+	fake.updateQuotasMutex.Lock()
+	defer fake.updateQuotasMutex.Unlock()
+	fake.UpdateQuotasStub = nil
+	if fake.updateQuotasReturnsOnCall == nil {
+		fake.updateQuotasReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateQuotasReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateSettings(arg1 atc.TeamAuth, arg2 *int, arg3 *atc.TeamQuotas) error {
+	fake.updateSettingsMutex.Lock()
+	ret, specificReturn := fake.updateSettingsReturnsOnCall[len(fake.updateSettingsArgsForCall)]
+	fake.updateSettingsArgsForCall = append(fake.updateSettingsArgsForCall, struct {
+		arg1 atc.TeamAuth
+		arg2 *int
+		arg3 *atc.TeamQuotas
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("UpdateSettings", []interface{}{arg1, arg2, arg3})
+	fake.updateSettingsMutex.Unlock()
+	if fake.UpdateSettingsStub != nil {
+		return fake.UpdateSettingsStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateSettingsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateSettingsCallCount() int {
+	fake.updateSettingsMutex.RLock()
+	defer fake.updateSettingsMutex.RUnlock()
+	return len(fake.updateSettingsArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateSettingsCalls(stub func(atc.TeamAuth, *int, *atc.TeamQuotas) error) {
+	fake.updateSettingsMutex.Lock()
+	defer fake.updateSettingsMutex.Unlock()
+	fake.UpdateSettingsStub = stub
+}
+
+func (fake *FakeTeam) UpdateSettingsArgsForCall(i int) (atc.TeamAuth, *int, *atc.TeamQuotas) {
+	fake.updateSettingsMutex.RLock()
+	defer fake.updateSettingsMutex.RUnlock()
+	argsForCall := fake.updateSettingsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) UpdateSettingsReturns(result1 error) {
+	fake.updateSettingsMutex.Lock()
+	defer fake.updateSettingsMutex.Unlock()
+	fake.UpdateSettingsStub = nil
+	fake.updateSettingsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateSettingsReturnsOnCall(i int, result1 error) {
+	fake.updateSettingsMutex.Lock()
+	defer fake.updateSettingsMutex.Unlock()
+	fake.UpdateSettingsStub = nil
+	if fake.updateSettingsReturnsOnCall == nil {
+		fake.updateSettingsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateSettingsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTeam) Workers() ([]db.Worker, error) {
 	fake.workersMutex.Lock()
 	ret, specificReturn := fake.workersReturnsOnCall[len(fake.workersArgsForCall)]
@@ -2185,6 +2593,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.createOneOffBuildMutex.RUnlock()
 	fake.createStartedBuildMutex.RLock()
 	defer fake.createStartedBuildMutex.RUnlock()
+	fake.defaultBuildPriorityMutex.RLock()
+	defer fake.defaultBuildPriorityMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
 	fake.findCheckContainersMutex.RLock()
@@ -2219,14 +2629,24 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.privateAndPublicBuildsMutex.RUnlock()
 	fake.publicPipelinesMutex.RLock()
 	defer fake.publicPipelinesMutex.RUnlock()
+	fake.quotaUsageMutex.RLock()
+	defer fake.quotaUsageMutex.RUnlock()
+	fake.quotasMutex.RLock()
+	defer fake.quotasMutex.RUnlock()
 	fake.renameMutex.RLock()
 	defer fake.renameMutex.RUnlock()
 	fake.savePipelineMutex.RLock()
 	defer fake.savePipelineMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
 	defer fake.saveWorkerMutex.RUnlock()
+	fake.updateDefaultBuildPriorityMutex.RLock()
+	defer fake.updateDefaultBuildPriorityMutex.RUnlock()
 	fake.updateProviderAuthMutex.RLock()
 	defer fake.updateProviderAuthMutex.RUnlock()
+	fake.updateQuotasMutex.RLock()
+	defer fake.updateQuotasMutex.RUnlock()
+	fake.updateSettingsMutex.RLock()
+	defer fake.updateSettingsMutex.RUnlock()
 	fake.workersMutex.RLock()
 	defer fake.workersMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
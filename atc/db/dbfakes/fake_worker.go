@@ -42,6 +42,16 @@ type FakeWorker struct {
 	activeVolumesReturnsOnCall map[int]struct {
 		result1 int
 	}
+	AllocatableResourcesStub        func() db.WorkerResources
+	allocatableResourcesMutex       sync.RWMutex
+	allocatableResourcesArgsForCall []struct {
+	}
+	allocatableResourcesReturns struct {
+		result1 db.WorkerResources
+	}
+	allocatableResourcesReturnsOnCall map[int]struct {
+		result1 db.WorkerResources
+	}
 	BaggageclaimURLStub        func() *string
 	baggageclaimURLMutex       sync.RWMutex
 	baggageclaimURLArgsForCall []struct {
@@ -76,6 +86,20 @@ type FakeWorker struct {
 		result1 db.CreatingContainer
 		result2 error
 	}
+	CreateContainerWithinResourcesStub        func(db.ContainerOwner, db.ContainerMetadata) (db.CreatingContainer, error)
+	createContainerWithinResourcesMutex       sync.RWMutex
+	createContainerWithinResourcesArgsForCall []struct {
+		arg1 db.ContainerOwner
+		arg2 db.ContainerMetadata
+	}
+	createContainerWithinResourcesReturns struct {
+		result1 db.CreatingContainer
+		result2 error
+	}
+	createContainerWithinResourcesReturnsOnCall map[int]struct {
+		result1 db.CreatingContainer
+		result2 error
+	}
 	DecreaseActiveTasksStub        func() error
 	decreaseActiveTasksMutex       sync.RWMutex
 	decreaseActiveTasksArgsForCall []struct {
@@ -267,6 +291,16 @@ type FakeWorker struct {
 	retireReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SecurityProfilesStub        func() []string
+	securityProfilesMutex       sync.RWMutex
+	securityProfilesArgsForCall []struct {
+	}
+	securityProfilesReturns struct {
+		result1 []string
+	}
+	securityProfilesReturnsOnCall map[int]struct {
+		result1 []string
+	}
 	StartTimeStub        func() time.Time
 	startTimeMutex       sync.RWMutex
 	startTimeArgsForCall []struct {
@@ -317,6 +351,16 @@ type FakeWorker struct {
 	teamNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	UnprivilegedStub        func() bool
+	unprivilegedMutex       sync.RWMutex
+	unprivilegedArgsForCall []struct {
+	}
+	unprivilegedReturns struct {
+		result1 bool
+	}
+	unprivilegedReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	VersionStub        func() *string
 	versionMutex       sync.RWMutex
 	versionArgsForCall []struct {
@@ -490,6 +534,58 @@ func (fake *FakeWorker) ActiveVolumesReturnsOnCall(i int, result1 int) {
 	}{result1}
 }
 
+func (fake *FakeWorker) AllocatableResources() db.WorkerResources {
+	fake.allocatableResourcesMutex.Lock()
+	ret, specificReturn := fake.allocatableResourcesReturnsOnCall[len(fake.allocatableResourcesArgsForCall)]
+	fake.allocatableResourcesArgsForCall = append(fake.allocatableResourcesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("AllocatableResources", []interface{}{})
+	fake.allocatableResourcesMutex.Unlock()
+	if fake.AllocatableResourcesStub != nil {
+		return fake.AllocatableResourcesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.allocatableResourcesReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) AllocatableResourcesCallCount() int {
+	fake.allocatableResourcesMutex.RLock()
+	defer fake.allocatableResourcesMutex.RUnlock()
+	return len(fake.allocatableResourcesArgsForCall)
+}
+
+func (fake *FakeWorker) AllocatableResourcesCalls(stub func() db.WorkerResources) {
+	fake.allocatableResourcesMutex.Lock()
+	defer fake.allocatableResourcesMutex.Unlock()
+	fake.AllocatableResourcesStub = stub
+}
+
+func (fake *FakeWorker) AllocatableResourcesReturns(result1 db.WorkerResources) {
+	fake.allocatableResourcesMutex.Lock()
+	defer fake.allocatableResourcesMutex.Unlock()
+	fake.AllocatableResourcesStub = nil
+	fake.allocatableResourcesReturns = struct {
+		result1 db.WorkerResources
+	}{result1}
+}
+
+func (fake *FakeWorker) AllocatableResourcesReturnsOnCall(i int, result1 db.WorkerResources) {
+	fake.allocatableResourcesMutex.Lock()
+	defer fake.allocatableResourcesMutex.Unlock()
+	fake.AllocatableResourcesStub = nil
+	if fake.allocatableResourcesReturnsOnCall == nil {
+		fake.allocatableResourcesReturnsOnCall = make(map[int]struct {
+			result1 db.WorkerResources
+		})
+	}
+	fake.allocatableResourcesReturnsOnCall[i] = struct {
+		result1 db.WorkerResources
+	}{result1}
+}
+
 func (fake *FakeWorker) BaggageclaimURL() *string {
 	fake.baggageclaimURLMutex.Lock()
 	ret, specificReturn := fake.baggageclaimURLReturnsOnCall[len(fake.baggageclaimURLArgsForCall)]
@@ -658,6 +754,70 @@ func (fake *FakeWorker) CreateContainerReturnsOnCall(i int, result1 db.CreatingC
 	}{result1, result2}
 }
 
+func (fake *FakeWorker) CreateContainerWithinResources(arg1 db.ContainerOwner, arg2 db.ContainerMetadata) (db.CreatingContainer, error) {
+	fake.createContainerWithinResourcesMutex.Lock()
+	ret, specificReturn := fake.createContainerWithinResourcesReturnsOnCall[len(fake.createContainerWithinResourcesArgsForCall)]
+	fake.createContainerWithinResourcesArgsForCall = append(fake.createContainerWithinResourcesArgsForCall, struct {
+		arg1 db.ContainerOwner
+		arg2 db.ContainerMetadata
+	}{arg1, arg2})
+	fake.recordInvocation("CreateContainerWithinResources", []interface{}{arg1, arg2})
+	fake.createContainerWithinResourcesMutex.Unlock()
+	if fake.CreateContainerWithinResourcesStub != nil {
+		return fake.CreateContainerWithinResourcesStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createContainerWithinResourcesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorker) CreateContainerWithinResourcesCallCount() int {
+	fake.createContainerWithinResourcesMutex.RLock()
+	defer fake.createContainerWithinResourcesMutex.RUnlock()
+	return len(fake.createContainerWithinResourcesArgsForCall)
+}
+
+func (fake *FakeWorker) CreateContainerWithinResourcesCalls(stub func(db.ContainerOwner, db.ContainerMetadata) (db.CreatingContainer, error)) {
+	fake.createContainerWithinResourcesMutex.Lock()
+	defer fake.createContainerWithinResourcesMutex.Unlock()
+	fake.CreateContainerWithinResourcesStub = stub
+}
+
+func (fake *FakeWorker) CreateContainerWithinResourcesArgsForCall(i int) (db.ContainerOwner, db.ContainerMetadata) {
+	fake.createContainerWithinResourcesMutex.RLock()
+	defer fake.createContainerWithinResourcesMutex.RUnlock()
+	argsForCall := fake.createContainerWithinResourcesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorker) CreateContainerWithinResourcesReturns(result1 db.CreatingContainer, result2 error) {
+	fake.createContainerWithinResourcesMutex.Lock()
+	defer fake.createContainerWithinResourcesMutex.Unlock()
+	fake.CreateContainerWithinResourcesStub = nil
+	fake.createContainerWithinResourcesReturns = struct {
+		result1 db.CreatingContainer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) CreateContainerWithinResourcesReturnsOnCall(i int, result1 db.CreatingContainer, result2 error) {
+	fake.createContainerWithinResourcesMutex.Lock()
+	defer fake.createContainerWithinResourcesMutex.Unlock()
+	fake.CreateContainerWithinResourcesStub = nil
+	if fake.createContainerWithinResourcesReturnsOnCall == nil {
+		fake.createContainerWithinResourcesReturnsOnCall = make(map[int]struct {
+			result1 db.CreatingContainer
+			result2 error
+		})
+	}
+	fake.createContainerWithinResourcesReturnsOnCall[i] = struct {
+		result1 db.CreatingContainer
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorker) DecreaseActiveTasks() error {
 	fake.decreaseActiveTasksMutex.Lock()
 	ret, specificReturn := fake.decreaseActiveTasksReturnsOnCall[len(fake.decreaseActiveTasksArgsForCall)]
@@ -1617,6 +1777,58 @@ func (fake *FakeWorker) RetireReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeWorker) SecurityProfiles() []string {
+	fake.securityProfilesMutex.Lock()
+	ret, specificReturn := fake.securityProfilesReturnsOnCall[len(fake.securityProfilesArgsForCall)]
+	fake.securityProfilesArgsForCall = append(fake.securityProfilesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("SecurityProfiles", []interface{}{})
+	fake.securityProfilesMutex.Unlock()
+	if fake.SecurityProfilesStub != nil {
+		return fake.SecurityProfilesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.securityProfilesReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) SecurityProfilesCallCount() int {
+	fake.securityProfilesMutex.RLock()
+	defer fake.securityProfilesMutex.RUnlock()
+	return len(fake.securityProfilesArgsForCall)
+}
+
+func (fake *FakeWorker) SecurityProfilesCalls(stub func() []string) {
+	fake.securityProfilesMutex.Lock()
+	defer fake.securityProfilesMutex.Unlock()
+	fake.SecurityProfilesStub = stub
+}
+
+func (fake *FakeWorker) SecurityProfilesReturns(result1 []string) {
+	fake.securityProfilesMutex.Lock()
+	defer fake.securityProfilesMutex.Unlock()
+	fake.SecurityProfilesStub = nil
+	fake.securityProfilesReturns = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *FakeWorker) SecurityProfilesReturnsOnCall(i int, result1 []string) {
+	fake.securityProfilesMutex.Lock()
+	defer fake.securityProfilesMutex.Unlock()
+	fake.SecurityProfilesStub = nil
+	if fake.securityProfilesReturnsOnCall == nil {
+		fake.securityProfilesReturnsOnCall = make(map[int]struct {
+			result1 []string
+		})
+	}
+	fake.securityProfilesReturnsOnCall[i] = struct {
+		result1 []string
+	}{result1}
+}
+
 func (fake *FakeWorker) StartTime() time.Time {
 	fake.startTimeMutex.Lock()
 	ret, specificReturn := fake.startTimeReturnsOnCall[len(fake.startTimeArgsForCall)]
@@ -1877,6 +2089,58 @@ func (fake *FakeWorker) TeamNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Unprivileged() bool {
+	fake.unprivilegedMutex.Lock()
+	ret, specificReturn := fake.unprivilegedReturnsOnCall[len(fake.unprivilegedArgsForCall)]
+	fake.unprivilegedArgsForCall = append(fake.unprivilegedArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Unprivileged", []interface{}{})
+	fake.unprivilegedMutex.Unlock()
+	if fake.UnprivilegedStub != nil {
+		return fake.UnprivilegedStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.unprivilegedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) UnprivilegedCallCount() int {
+	fake.unprivilegedMutex.RLock()
+	defer fake.unprivilegedMutex.RUnlock()
+	return len(fake.unprivilegedArgsForCall)
+}
+
+func (fake *FakeWorker) UnprivilegedCalls(stub func() bool) {
+	fake.unprivilegedMutex.Lock()
+	defer fake.unprivilegedMutex.Unlock()
+	fake.UnprivilegedStub = stub
+}
+
+func (fake *FakeWorker) UnprivilegedReturns(result1 bool) {
+	fake.unprivilegedMutex.Lock()
+	defer fake.unprivilegedMutex.Unlock()
+	fake.UnprivilegedStub = nil
+	fake.unprivilegedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeWorker) UnprivilegedReturnsOnCall(i int, result1 bool) {
+	fake.unprivilegedMutex.Lock()
+	defer fake.unprivilegedMutex.Unlock()
+	fake.UnprivilegedStub = nil
+	if fake.unprivilegedReturnsOnCall == nil {
+		fake.unprivilegedReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.unprivilegedReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeWorker) Version() *string {
 	fake.versionMutex.Lock()
 	ret, specificReturn := fake.versionReturnsOnCall[len(fake.versionArgsForCall)]
@@ -1938,12 +2202,16 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.activeTasksMutex.RUnlock()
 	fake.activeVolumesMutex.RLock()
 	defer fake.activeVolumesMutex.RUnlock()
+	fake.allocatableResourcesMutex.RLock()
+	defer fake.allocatableResourcesMutex.RUnlock()
 	fake.baggageclaimURLMutex.RLock()
 	defer fake.baggageclaimURLMutex.RUnlock()
 	fake.certsPathMutex.RLock()
 	defer fake.certsPathMutex.RUnlock()
 	fake.createContainerMutex.RLock()
 	defer fake.createContainerMutex.RUnlock()
+	fake.createContainerWithinResourcesMutex.RLock()
+	defer fake.createContainerWithinResourcesMutex.RUnlock()
 	fake.decreaseActiveTasksMutex.RLock()
 	defer fake.decreaseActiveTasksMutex.RUnlock()
 	fake.deleteMutex.RLock()
@@ -1980,6 +2248,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.resourceTypesMutex.RUnlock()
 	fake.retireMutex.RLock()
 	defer fake.retireMutex.RUnlock()
+	fake.securityProfilesMutex.RLock()
+	defer fake.securityProfilesMutex.RUnlock()
 	fake.startTimeMutex.RLock()
 	defer fake.startTimeMutex.RUnlock()
 	fake.stateMutex.RLock()
@@ -1990,6 +2260,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.teamIDMutex.RUnlock()
 	fake.teamNameMutex.RLock()
 	defer fake.teamNameMutex.RUnlock()
+	fake.unprivilegedMutex.RLock()
+	defer fake.unprivilegedMutex.RUnlock()
 	fake.versionMutex.RLock()
 	defer fake.versionMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
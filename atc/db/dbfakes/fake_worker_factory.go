@@ -10,6 +10,18 @@ import (
 )
 
 type FakeWorkerFactory struct {
+	AllocatedResourcesPerWorkerStub        func() (map[string]db.WorkerResources, error)
+	allocatedResourcesPerWorkerMutex       sync.RWMutex
+	allocatedResourcesPerWorkerArgsForCall []struct {
+	}
+	allocatedResourcesPerWorkerReturns struct {
+		result1 map[string]db.WorkerResources
+		result2 error
+	}
+	allocatedResourcesPerWorkerReturnsOnCall map[int]struct {
+		result1 map[string]db.WorkerResources
+		result2 error
+	}
 	BuildContainersCountPerWorkerStub        func() (map[string]int, error)
 	buildContainersCountPerWorkerMutex       sync.RWMutex
 	buildContainersCountPerWorkerArgsForCall []struct {
@@ -107,6 +119,61 @@ type FakeWorkerFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeWorkerFactory) AllocatedResourcesPerWorker() (map[string]db.WorkerResources, error) {
+	fake.allocatedResourcesPerWorkerMutex.Lock()
+	ret, specificReturn := fake.allocatedResourcesPerWorkerReturnsOnCall[len(fake.allocatedResourcesPerWorkerArgsForCall)]
+	fake.allocatedResourcesPerWorkerArgsForCall = append(fake.allocatedResourcesPerWorkerArgsForCall, struct {
+	}{})
+	fake.recordInvocation("AllocatedResourcesPerWorker", []interface{}{})
+	fake.allocatedResourcesPerWorkerMutex.Unlock()
+	if fake.AllocatedResourcesPerWorkerStub != nil {
+		return fake.AllocatedResourcesPerWorkerStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.allocatedResourcesPerWorkerReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerFactory) AllocatedResourcesPerWorkerCallCount() int {
+	fake.allocatedResourcesPerWorkerMutex.RLock()
+	defer fake.allocatedResourcesPerWorkerMutex.RUnlock()
+	return len(fake.allocatedResourcesPerWorkerArgsForCall)
+}
+
+func (fake *FakeWorkerFactory) AllocatedResourcesPerWorkerCalls(stub func() (map[string]db.WorkerResources, error)) {
+	fake.allocatedResourcesPerWorkerMutex.Lock()
+	defer fake.allocatedResourcesPerWorkerMutex.Unlock()
+	fake.AllocatedResourcesPerWorkerStub = stub
+}
+
+func (fake *FakeWorkerFactory) AllocatedResourcesPerWorkerReturns(result1 map[string]db.WorkerResources, result2 error) {
+	fake.allocatedResourcesPerWorkerMutex.Lock()
+	defer fake.allocatedResourcesPerWorkerMutex.Unlock()
+	fake.AllocatedResourcesPerWorkerStub = nil
+	fake.allocatedResourcesPerWorkerReturns = struct {
+		result1 map[string]db.WorkerResources
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerFactory) AllocatedResourcesPerWorkerReturnsOnCall(i int, result1 map[string]db.WorkerResources, result2 error) {
+	fake.allocatedResourcesPerWorkerMutex.Lock()
+	defer fake.allocatedResourcesPerWorkerMutex.Unlock()
+	fake.AllocatedResourcesPerWorkerStub = nil
+	if fake.allocatedResourcesPerWorkerReturnsOnCall == nil {
+		fake.allocatedResourcesPerWorkerReturnsOnCall = make(map[int]struct {
+			result1 map[string]db.WorkerResources
+			result2 error
+		})
+	}
+	fake.allocatedResourcesPerWorkerReturnsOnCall[i] = struct {
+		result1 map[string]db.WorkerResources
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorkerFactory) BuildContainersCountPerWorker() (map[string]int, error) {
 	fake.buildContainersCountPerWorkerMutex.Lock()
 	ret, specificReturn := fake.buildContainersCountPerWorkerReturnsOnCall[len(fake.buildContainersCountPerWorkerArgsForCall)]
@@ -545,6 +612,8 @@ func (fake *FakeWorkerFactory) WorkersReturnsOnCall(i int, result1 []db.Worker,
 func (fake *FakeWorkerFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.allocatedResourcesPerWorkerMutex.RLock()
+	defer fake.allocatedResourcesPerWorkerMutex.RUnlock()
 	fake.buildContainersCountPerWorkerMutex.RLock()
 	defer fake.buildContainersCountPerWorkerMutex.RUnlock()
 	fake.findWorkersForContainerByOwnerMutex.RLock()
@@ -34,6 +34,93 @@ type InputResult struct {
 	Input          *AlgorithmInput
 	PassedBuildIDs []int
 	ResolveError   ResolutionFailure
+	Explanation    InputExplanation
+}
+
+type CandidateRejection string
+
+const (
+	CandidateDisabled      CandidateRejection = "version is disabled"
+	CandidatePinMismatch   CandidateRejection = "version does not match the pinned version"
+	CandidateMismatch      CandidateRejection = "build used a different version than the one chosen through another passed job"
+	CandidateMissing       CandidateRejection = "version no longer exists"
+	CandidateBuildRejected CandidateRejection = "another version from the same build could not be used"
+	CandidateUnsatisfiable CandidateRejection = "no build of another passed job could be used alongside this version"
+)
+
+// MaxExplainedCandidates caps the number of candidates recorded for an input,
+// as resolving passed constraints may go through a great many builds.
+const MaxExplainedCandidates = 100
+
+// An InputExplanation records the versions the algorithm considered while
+// resolving an input, and why the ones it did not pick were rejected.
+type InputExplanation struct {
+	Candidates []CandidateExplanation `json:"candidates,omitempty"`
+	Truncated  bool                   `json:"truncated,omitempty"`
+}
+
+// A CandidateExplanation is a version considered for an input. Versions which
+// come from the builds of a passed job record the build they came from.
+type CandidateExplanation struct {
+	Version     ResourceVersion    `json:"version"`
+	PassedJobID int                `json:"passed_job_id,omitempty"`
+	BuildID     int                `json:"build_id,omitempty"`
+	Rejection   CandidateRejection `json:"rejection,omitempty"`
+	Conflict    *CandidateConflict `json:"conflict,omitempty"`
+}
+
+// A CandidateConflict is the passed job which could not be satisfied alongside
+// a candidate, along with the latest of its builds that was tried, if any.
+type CandidateConflict struct {
+	PassedJobID int `json:"passed_job_id"`
+	BuildID     int `json:"build_id,omitempty"`
+}
+
+// Record adds the candidate to the explanation, returning its index or -1 if
+// there were already too many. A candidate which was already recorded, e.g.
+// when the algorithm backtracks to the same build, is updated instead so that
+// the explanation reflects the last attempt.
+func (e *InputExplanation) Record(candidate CandidateExplanation) int {
+	for i, existing := range e.Candidates {
+		if existing.Version == candidate.Version &&
+			existing.PassedJobID == candidate.PassedJobID &&
+			existing.BuildID == candidate.BuildID {
+			e.Candidates[i].Rejection = candidate.Rejection
+			e.Candidates[i].Conflict = candidate.Conflict
+			return i
+		}
+	}
+
+	if len(e.Candidates) >= MaxExplainedCandidates {
+		e.Truncated = true
+		return -1
+	}
+
+	e.Candidates = append(e.Candidates, candidate)
+
+	return len(e.Candidates) - 1
+}
+
+// Reject records why the candidate at the given index was not picked, unless
+// it was already rejected for another reason.
+func (e *InputExplanation) Reject(index int, rejection CandidateRejection) {
+	if index < 0 || e.Candidates[index].Rejection != "" {
+		return
+	}
+
+	e.Candidates[index].Rejection = rejection
+}
+
+// RejectConflict records that the candidate at the given index was not picked
+// because the given passed job could not be satisfied alongside it, unless it
+// was already rejected for another reason.
+func (e *InputExplanation) RejectConflict(index int, conflict CandidateConflict) {
+	if index < 0 || e.Candidates[index].Rejection != "" {
+		return
+	}
+
+	e.Candidates[index].Rejection = CandidateUnsatisfiable
+	e.Candidates[index].Conflict = &conflict
 }
 
 type ResourceVersion string
@@ -80,6 +80,7 @@ type Job interface {
 
 	ScheduleBuild(Build) (bool, error)
 	CreateBuild() (Build, error)
+	CreateBuildWithPriority(int) (Build, error)
 	RerunBuild(Build) (Build, error)
 
 	RequestSchedule() error
@@ -96,6 +97,7 @@ type Job interface {
 	GetNextBuildInputs() ([]BuildInput, error)
 	GetFullNextBuildInputs() ([]BuildInput, bool, error)
 	SaveNextInputMapping(inputMapping InputMapping, inputsDetermined bool) error
+	SchedulingExplanation() (atc.SchedulingExplanation, error)
 
 	ClearTaskCache(string, string) (int64, error)
 
@@ -105,7 +107,7 @@ type Job interface {
 	HasNewInputs() bool
 }
 
-var jobsQuery = psql.Select("j.id", "j.name", "j.config", "j.paused", "j.public", "j.first_logged_build_id", "j.pipeline_id", "p.name", "p.team_id", "t.name", "j.nonce", "j.tags", "j.has_new_inputs", "j.schedule_requested", "j.max_in_flight", "j.disable_manual_trigger").
+var jobsQuery = psql.Select("j.id", "j.name", "j.config", "j.paused", "j.public", "j.first_logged_build_id", "j.pipeline_id", "p.name", "p.instance_vars", "p.team_id", "t.name", "j.nonce", "j.tags", "j.has_new_inputs", "j.schedule_requested", "j.max_in_flight", "j.disable_manual_trigger").
 	From("jobs j, pipelines p").
 	LeftJoin("teams t ON p.team_id = t.id").
 	Where(sq.Expr("j.pipeline_id = p.id"))
@@ -575,10 +577,19 @@ func (j *job) ScheduleBuild(build Build) (bool, error) {
 		return false, NonOneRowAffectedError{rowsAffected}
 	}
 
-	var scheduled bool
+	var quotaExceeded bool
 	if !reached {
+		quotaExceeded, err = j.isTeamBuildQuotaExceeded(tx, build)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	scheduled := !reached && !quotaExceeded
+	if scheduled || quotaExceeded != build.QuotaExceeded() {
 		result, err = psql.Update("builds").
-			Set("scheduled", true).
+			Set("scheduled", scheduled).
+			Set("quota_exceeded", quotaExceeded).
 			Where(sq.Eq{"id": build.ID()}).
 			RunWith(tx).
 			Exec()
@@ -594,8 +605,6 @@ func (j *job) ScheduleBuild(build Build) (bool, error) {
 		if rowsAffected != 1 {
 			return false, NonOneRowAffectedError{rowsAffected}
 		}
-
-		scheduled = true
 	}
 
 	err = tx.Commit()
@@ -684,13 +693,18 @@ func (j *job) EnsurePendingBuildExists(ctx context.Context) error {
 		return err
 	}
 
+	priority, err := j.buildPriority(tx, nil)
+	if err != nil {
+		return err
+	}
+
 	rows, err := tx.Query(`
-		INSERT INTO builds (name, job_id, pipeline_id, team_id, status, needs_v6_migration, span_context)
-		SELECT $1, $2, $3, $4, 'pending', false, $5
+		INSERT INTO builds (name, job_id, pipeline_id, team_id, status, needs_v6_migration, span_context, priority)
+		SELECT $1, $2, $3, $4, 'pending', false, $5, $6
 		WHERE NOT EXISTS
 			(SELECT id FROM builds WHERE job_id = $2 AND status = 'pending')
 		RETURNING id
-	`, buildName, j.id, j.pipelineID, j.teamID, string(spanContextJSON))
+	`, buildName, j.id, j.pipelineID, j.teamID, string(spanContextJSON), priority)
 	if err != nil {
 		return err
 	}
@@ -750,7 +764,7 @@ func (j *job) GetPendingBuilds() ([]Build, error) {
 			"b.job_id": j.id,
 			"b.status": BuildStatusPending,
 		}).
-		OrderBy("COALESCE(b.rerun_of, b.id) ASC, b.id ASC").
+		OrderBy("b.priority DESC, COALESCE(b.rerun_of, b.id) ASC, b.id ASC").
 		RunWith(j.conn).
 		Query()
 	if err != nil {
@@ -773,6 +787,17 @@ func (j *job) GetPendingBuilds() ([]Build, error) {
 }
 
 func (j *job) CreateBuild() (Build, error) {
+	return j.createBuild(nil)
+}
+
+// CreateBuildWithPriority creates a manually triggered build which overrides
+// the priority configured for the job and its team, up to the team's maximum
+// build priority.
+func (j *job) CreateBuildWithPriority(priority int) (Build, error) {
+	return j.createBuild(&priority)
+}
+
+func (j *job) createBuild(priority *int) (Build, error) {
 	tx, err := j.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -785,6 +810,11 @@ func (j *job) CreateBuild() (Build, error) {
 		return nil, err
 	}
 
+	buildPriority, err := j.buildPriority(tx, priority)
+	if err != nil {
+		return nil, err
+	}
+
 	build := newEmptyBuild(j.conn, j.lockFactory)
 	err = createBuild(tx, build, map[string]interface{}{
 		"name":               buildName,
@@ -793,6 +823,7 @@ func (j *job) CreateBuild() (Build, error) {
 		"team_id":            j.teamID,
 		"status":             BuildStatusPending,
 		"manually_triggered": true,
+		"priority":           buildPriority,
 	})
 	if err != nil {
 		return nil, err
@@ -854,6 +885,13 @@ func (j *job) tryRerunBuild(buildToRerun Build) (Build, error) {
 		return nil, err
 	}
 
+	// the team's maximum build priority may have been lowered since
+	priority := buildToRerun.Priority()
+	priority, err = j.buildPriority(tx, &priority)
+	if err != nil {
+		return nil, err
+	}
+
 	rerunBuild := newEmptyBuild(j.conn, j.lockFactory)
 	err = createBuild(tx, rerunBuild, map[string]interface{}{
 		"name":         rerunBuildName,
@@ -863,6 +901,7 @@ func (j *job) tryRerunBuild(buildToRerun Build) (Build, error) {
 		"status":       BuildStatusPending,
 		"rerun_of":     buildToRerunID,
 		"rerun_number": rerunNumber,
+		"priority":     priority,
 	})
 	if err != nil {
 		return nil, err
@@ -891,6 +930,56 @@ func (j *job) tryRerunBuild(buildToRerun Build) (Build, error) {
 	return rerunBuild, nil
 }
 
+// buildPriority returns the priority of a new build: the given one if any,
+// otherwise the priority configured on the job if set, otherwise the default
+// build priority of its team. It is capped at the team's maximum build
+// priority, so that a team can't get its builds and tasks ahead of those of
+// other teams by configuring an ever higher priority.
+func (j *job) buildPriority(tx Tx, requested *int) (int, error) {
+	var (
+		defaultPriority int
+		encodedQuotas   sql.NullString
+	)
+
+	err := psql.Select("default_build_priority", "quotas").
+		From("teams").
+		Where(sq.Eq{"id": j.teamID}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&defaultPriority, &encodedQuotas)
+	if err != nil {
+		return 0, err
+	}
+
+	priority := defaultPriority
+	if requested != nil {
+		priority = *requested
+	} else {
+		config, err := j.Config()
+		if err != nil {
+			return 0, err
+		}
+
+		if config.Priority != nil {
+			priority = *config.Priority
+		}
+	}
+
+	if encodedQuotas.Valid {
+		var quotas atc.TeamQuotas
+		err = json.Unmarshal([]byte(encodedQuotas.String), &quotas)
+		if err != nil {
+			return 0, err
+		}
+
+		if quotas.MaxBuildPriority != nil && priority > *quotas.MaxBuildPriority {
+			priority = *quotas.MaxBuildPriority
+		}
+	}
+
+	return priority, nil
+}
+
 func (j *job) ClearTaskCache(stepName string, cachePath string) (int64, error) {
 	tx, err := j.conn.Begin()
 	if err != nil {
@@ -971,6 +1060,90 @@ func (j *job) isMaxInFlightReached(tx Tx, buildID int) (bool, error) {
 	return false, nil
 }
 
+// isTeamBuildQuotaExceeded returns true if the job's team is already running
+// as many builds as its max concurrent builds quota allows, counting the
+// pending builds of the team's other jobs which have a higher priority than
+// the build as if they were already running, so that the builds of the quota
+// which free up go to the highest priority builds across all of its jobs. Only
+// the pending builds which were last held back by nothing but the quota, and
+// whose job's inputs could be determined, are counted, as the others could
+// not be started anyway and would starve the lower priority builds. The team
+// is locked until the transaction ends so that the builds of its jobs
+// scheduled concurrently can't both take the last build of the quota.
+func (j *job) isTeamBuildQuotaExceeded(tx Tx, build Build) (bool, error) {
+	var encodedQuotas sql.NullString
+	err := psql.Select("quotas").
+		From("teams").
+		Where(sq.Eq{"id": j.teamID}).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&encodedQuotas)
+	if err != nil {
+		return false, err
+	}
+
+	if !encodedQuotas.Valid {
+		return false, nil
+	}
+
+	var quotas atc.TeamQuotas
+	err = json.Unmarshal([]byte(encodedQuotas.String), &quotas)
+	if err != nil {
+		return false, err
+	}
+
+	if quotas.MaxConcurrentBuilds == 0 {
+		return false, nil
+	}
+
+	var running int
+	err = psql.Select("COUNT(*)").
+		From("builds").
+		Where(sq.Eq{
+			"team_id":   j.teamID,
+			"scheduled": true,
+			"completed": false,
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&running)
+	if err != nil {
+		return false, err
+	}
+
+	if running >= quotas.MaxConcurrentBuilds {
+		return true, nil
+	}
+
+	var ahead int
+	err = psql.Select("COUNT(*)").
+		From("builds b").
+		Join("jobs j ON j.id = b.job_id").
+		Join("pipelines p ON p.id = j.pipeline_id").
+		Where(sq.Eq{
+			"b.team_id":               j.teamID,
+			"b.status":                BuildStatusPending,
+			"b.scheduled":             false,
+			"b.quota_exceeded":        true,
+			"j.active":                true,
+			"j.paused":                false,
+			"j.max_in_flight_reached": false,
+			"j.inputs_determined":     true,
+			"p.paused":                false,
+		}).
+		Where(sq.NotEq{"b.job_id": j.id}).
+		Where(sq.Gt{"b.priority": build.Priority()}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&ahead)
+	if err != nil {
+		return false, err
+	}
+
+	return running+ahead >= quotas.MaxConcurrentBuilds, nil
+}
+
 func (j *job) getSerialGroups(tx Tx) ([]string, error) {
 	rows, err := psql.Select("serial_group").
 		From("jobs_serial_groups").
@@ -1157,7 +1330,7 @@ func (j *job) SaveNextInputMapping(inputMapping InputMapping, inputsDetermined b
 	}
 
 	builder := psql.Insert("next_build_inputs").
-		Columns("input_name", "job_id", "version_md5", "resource_id", "first_occurrence", "resolve_error")
+		Columns("input_name", "job_id", "version_md5", "resource_id", "first_occurrence", "resolve_error", "explanation")
 
 	for inputName, inputResult := range inputMapping {
 		var resolveError sql.NullString
@@ -1165,6 +1338,11 @@ func (j *job) SaveNextInputMapping(inputMapping InputMapping, inputsDetermined b
 		var versionMD5 sql.NullString
 		var resourceID sql.NullInt64
 
+		explanation, err := json.Marshal(inputResult.Explanation)
+		if err != nil {
+			return err
+		}
+
 		if inputResult.ResolveError != "" {
 			resolveError = sql.NullString{String: string(inputResult.ResolveError), Valid: true}
 		} else {
@@ -1177,7 +1355,7 @@ func (j *job) SaveNextInputMapping(inputMapping InputMapping, inputsDetermined b
 			versionMD5 = sql.NullString{String: string(inputResult.Input.Version), Valid: true}
 		}
 
-		builder = builder.Values(inputName, j.id, versionMD5, resourceID, firstOccurrence, resolveError)
+		builder = builder.Values(inputName, j.id, versionMD5, resourceID, firstOccurrence, resolveError, explanation)
 	}
 
 	if len(inputMapping) != 0 {
@@ -1374,11 +1552,17 @@ func (j *job) isPipelineOrJobPaused(tx Tx) (bool, error) {
 
 func scanJob(j *job, row scannable) error {
 	var (
-		config sql.NullString
-		nonce  sql.NullString
+		config       sql.NullString
+		nonce        sql.NullString
+		instanceVars sql.NullString
 	)
 
-	err := row.Scan(&j.id, &j.name, &config, &j.paused, &j.public, &j.firstLoggedBuildID, &j.pipelineID, &j.pipelineName, &j.teamID, &j.teamName, &nonce, pq.Array(&j.tags), &j.hasNewInputs, &j.scheduleRequestedTime, &j.maxInFlight, &j.disableManualTrigger)
+	err := row.Scan(&j.id, &j.name, &config, &j.paused, &j.public, &j.firstLoggedBuildID, &j.pipelineID, &j.pipelineName, &instanceVars, &j.teamID, &j.teamName, &nonce, pq.Array(&j.tags), &j.hasNewInputs, &j.scheduleRequestedTime, &j.maxInFlight, &j.disableManualTrigger)
+	if err != nil {
+		return err
+	}
+
+	err = j.scanInstanceVars(instanceVars)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,304 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"sort"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/lib/pq"
+)
+
+// SchedulingExplanation describes how the inputs for the next build of the
+// job were last determined, resolving the versions and builds recorded by the
+// algorithm into something meaningful to users.
+func (j *job) SchedulingExplanation() (atc.SchedulingExplanation, error) {
+	inputConfigs, err := j.AlgorithmInputs()
+	if err != nil {
+		return atc.SchedulingExplanation{}, err
+	}
+
+	tx, err := j.conn.Begin()
+	if err != nil {
+		return atc.SchedulingExplanation{}, err
+	}
+
+	defer Rollback(tx)
+
+	var inputsDetermined bool
+	err = psql.Select("inputs_determined").
+		From("jobs").
+		Where(sq.Eq{"id": j.id}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&inputsDetermined)
+	if err != nil {
+		return atc.SchedulingExplanation{}, err
+	}
+
+	results, err := j.nextInputResults(tx)
+	if err != nil {
+		return atc.SchedulingExplanation{}, err
+	}
+
+	names := explanationNames{
+		resources: map[int]string{},
+		jobs:      map[int]string{},
+		builds:    map[int]string{},
+	}
+
+	versions := map[int]map[ResourceVersion]atc.Version{}
+	for _, input := range inputConfigs {
+		names.resources[input.ResourceID] = ""
+
+		for jobID := range input.Passed {
+			names.jobs[jobID] = ""
+		}
+
+		md5s := []string{}
+		if result, found := results[input.Name]; found {
+			if result.Input != nil {
+				md5s = append(md5s, string(result.Input.Version))
+			}
+
+			for _, candidate := range result.Explanation.Candidates {
+				md5s = append(md5s, string(candidate.Version))
+
+				if candidate.BuildID != 0 {
+					names.jobs[candidate.PassedJobID] = ""
+					names.builds[candidate.BuildID] = ""
+				}
+
+				if candidate.Conflict != nil {
+					names.jobs[candidate.Conflict.PassedJobID] = ""
+
+					if candidate.Conflict.BuildID != 0 {
+						names.builds[candidate.Conflict.BuildID] = ""
+					}
+				}
+			}
+		}
+
+		versions[input.ResourceID], err = explainedVersions(tx, input.ResourceID, md5s)
+		if err != nil {
+			return atc.SchedulingExplanation{}, err
+		}
+	}
+
+	err = names.load(tx)
+	if err != nil {
+		return atc.SchedulingExplanation{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return atc.SchedulingExplanation{}, err
+	}
+
+	explanation := atc.SchedulingExplanation{
+		InputsDetermined: inputsDetermined,
+		Inputs:           []atc.InputExplanation{},
+	}
+
+	for _, input := range inputConfigs {
+		inputExplanation := atc.InputExplanation{
+			Name:          input.Name,
+			Resource:      names.resources[input.ResourceID],
+			Every:         input.UseEveryVersion,
+			PinnedVersion: input.PinnedVersion,
+		}
+
+		for jobID := range input.Passed {
+			inputExplanation.Passed = append(inputExplanation.Passed, names.jobs[jobID])
+		}
+
+		sort.Strings(inputExplanation.Passed)
+
+		result, found := results[input.Name]
+		if found {
+			if result.Input != nil {
+				inputExplanation.Version = versions[input.ResourceID][result.Input.Version]
+			}
+
+			inputExplanation.Error = string(result.ResolveError)
+			inputExplanation.CandidatesTruncated = result.Explanation.Truncated
+
+			for _, candidate := range result.Explanation.Candidates {
+				candidateVersion := atc.CandidateVersion{
+					Version:   versions[input.ResourceID][candidate.Version],
+					Rejection: string(candidate.Rejection),
+				}
+
+				if candidate.BuildID != 0 {
+					candidateVersion.PassedJob = names.jobs[candidate.PassedJobID]
+					candidateVersion.BuildID = candidate.BuildID
+					candidateVersion.BuildName = names.builds[candidate.BuildID]
+				}
+
+				if candidate.Conflict != nil {
+					candidateVersion.Conflict = &atc.CandidateConflict{
+						PassedJob: names.jobs[candidate.Conflict.PassedJobID],
+						BuildID:   candidate.Conflict.BuildID,
+						BuildName: names.builds[candidate.Conflict.BuildID],
+					}
+				}
+
+				inputExplanation.Candidates = append(inputExplanation.Candidates, candidateVersion)
+			}
+		}
+
+		explanation.Inputs = append(explanation.Inputs, inputExplanation)
+	}
+
+	sort.Slice(explanation.Inputs, func(i, j int) bool {
+		return explanation.Inputs[i].Name < explanation.Inputs[j].Name
+	})
+
+	return explanation, nil
+}
+
+func (j *job) nextInputResults(tx Tx) (map[string]InputResult, error) {
+	rows, err := psql.Select("input_name", "version_md5", "resource_id", "resolve_error", "explanation").
+		From("next_build_inputs").
+		Where(sq.Eq{"job_id": j.id}).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	results := map[string]InputResult{}
+	for rows.Next() {
+		var (
+			inputName              string
+			versionMD5, resolveErr sql.NullString
+			resourceID             sql.NullInt64
+			explanation            sql.NullString
+		)
+
+		err = rows.Scan(&inputName, &versionMD5, &resourceID, &resolveErr, &explanation)
+		if err != nil {
+			return nil, err
+		}
+
+		result := InputResult{
+			ResolveError: ResolutionFailure(resolveErr.String),
+		}
+
+		if versionMD5.Valid {
+			result.Input = &AlgorithmInput{
+				AlgorithmVersion: AlgorithmVersion{
+					ResourceID: int(resourceID.Int64),
+					Version:    ResourceVersion(versionMD5.String),
+				},
+			}
+		}
+
+		if explanation.Valid {
+			err = json.Unmarshal([]byte(explanation.String), &result.Explanation)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		results[inputName] = result
+	}
+
+	return results, nil
+}
+
+func explainedVersions(tx Tx, resourceID int, md5s []string) (map[ResourceVersion]atc.Version, error) {
+	versions := map[ResourceVersion]atc.Version{}
+	if len(md5s) == 0 {
+		return versions, nil
+	}
+
+	rows, err := psql.Select("v.version_md5", "v.version").
+		From("resource_config_versions v").
+		Join("resources r ON r.resource_config_scope_id = v.resource_config_scope_id").
+		Where(sq.Eq{"r.id": resourceID}).
+		Where(sq.Expr("v.version_md5 = ANY(?)", pq.Array(md5s))).
+		RunWith(tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var (
+			md5     string
+			payload string
+		)
+
+		err = rows.Scan(&md5, &payload)
+		if err != nil {
+			return nil, err
+		}
+
+		var version atc.Version
+		err = json.Unmarshal([]byte(payload), &version)
+		if err != nil {
+			return nil, err
+		}
+
+		versions[ResourceVersion(md5)] = version
+	}
+
+	return versions, nil
+}
+
+type explanationNames struct {
+	resources map[int]string
+	jobs      map[int]string
+	builds    map[int]string
+}
+
+func (n explanationNames) load(tx Tx) error {
+	for table, names := range map[string]map[int]string{
+		"resources": n.resources,
+		"jobs":      n.jobs,
+		"builds":    n.builds,
+	} {
+		if len(names) == 0 {
+			continue
+		}
+
+		ids := []int{}
+		for id := range names {
+			ids = append(ids, id)
+		}
+
+		rows, err := psql.Select("id", "name").
+			From(table).
+			Where(sq.Eq{"id": ids}).
+			RunWith(tx).
+			Query()
+		if err != nil {
+			return err
+		}
+
+		for rows.Next() {
+			var (
+				id   int
+				name string
+			)
+
+			err = rows.Scan(&id, &name)
+			if err != nil {
+				Close(rows)
+				return err
+			}
+
+			names[id] = name
+		}
+
+		Close(rows)
+	}
+
+	return nil
+}
@@ -26,7 +26,7 @@ var _ = Describe("Job", func() {
 		Expect(err).ToNot(HaveOccurred())
 
 		var created bool
-		pipeline, created, err = team.SavePipeline("fake-pipeline", atc.Config{
+		pipeline, created, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, atc.Config{
 			Jobs: atc.JobConfigs{
 				{
 					Name: "some-job",
@@ -212,7 +212,7 @@ var _ = Describe("Job", func() {
 		BeforeEach(func() {
 			var created bool
 			var err error
-			otherPipeline, created, err = team.SavePipeline("other-pipeline", atc.Config{
+			otherPipeline, created, err = team.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, atc.Config{
 				Jobs: atc.JobConfigs{
 					{Name: "some-job"},
 				},
@@ -442,7 +442,7 @@ var _ = Describe("Job", func() {
 					},
 				},
 			}
-			pipeline, _, err = team.SavePipeline("some-pipeline", config, db.ConfigVersion(1), false)
+			pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline"}, config, db.ConfigVersion(1), false)
 			Expect(err).ToNot(HaveOccurred())
 
 			job, found, err = pipeline.Job("some-job")
@@ -679,7 +679,7 @@ var _ = Describe("Job", func() {
 		saveMaxInFlightPipeline := func() {
 			BeforeEach(func() {
 				var err error
-				pipeline, _, err = team.SavePipeline("fake-pipeline", atc.Config{
+				pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{
 							Name: "some-job",
@@ -758,7 +758,7 @@ var _ = Describe("Job", func() {
 		saveSerialGroupsPipeline := func() {
 			BeforeEach(func() {
 				var err error
-				pipeline, _, err = team.SavePipeline("fake-pipeline", atc.Config{
+				pipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{
 							Name: "some-job",
@@ -864,7 +864,7 @@ var _ = Describe("Job", func() {
 				BeforeEach(func() {
 					var created bool
 					var err error
-					pipeline, created, err = team.SavePipeline("other-pipeline", atc.Config{
+					pipeline, created, err = team.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, atc.Config{
 						Jobs: atc.JobConfigs{
 							{
 								Name: "some-job",
@@ -922,6 +922,155 @@ var _ = Describe("Job", func() {
 							Expect(schedulingBuild.IsScheduled()).To(BeTrue())
 						})
 					})
+
+					Context("when the team's concurrent builds quota is reached", func() {
+						BeforeEach(func() {
+							err := team.UpdateQuotas(atc.TeamQuotas{MaxConcurrentBuilds: 1})
+							Expect(err).ToNot(HaveOccurred())
+
+							runningBuild, err := job.CreateBuild()
+							Expect(err).ToNot(HaveOccurred())
+
+							scheduled, err := job.ScheduleBuild(runningBuild)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(scheduled).To(BeTrue())
+						})
+
+						It("leaves the build pending with its quota exceeded", func() {
+							Expect(schedulingErr).ToNot(HaveOccurred())
+							Expect(scheduleFound).To(BeFalse())
+							Expect(reloadFound).To(BeTrue())
+							Expect(schedulingBuild.IsScheduled()).To(BeFalse())
+							Expect(schedulingBuild.QuotaExceeded()).To(BeTrue())
+						})
+
+						Context("when the quota is raised", func() {
+							JustBeforeEach(func() {
+								err := team.UpdateQuotas(atc.TeamQuotas{MaxConcurrentBuilds: 2})
+								Expect(err).ToNot(HaveOccurred())
+
+								scheduleFound, schedulingErr = job.ScheduleBuild(schedulingBuild)
+
+								reloadFound, err = schedulingBuild.Reload()
+								Expect(err).ToNot(HaveOccurred())
+							})
+
+							It("schedules the build and clears its quota exceeded state", func() {
+								Expect(schedulingErr).ToNot(HaveOccurred())
+								Expect(scheduleFound).To(BeTrue())
+								Expect(schedulingBuild.IsScheduled()).To(BeTrue())
+								Expect(schedulingBuild.QuotaExceeded()).To(BeFalse())
+							})
+						})
+					})
+
+					Context("when the team's concurrent builds quota is limited", func() {
+						var otherJob db.Job
+
+						BeforeEach(func() {
+							err := team.UpdateQuotas(atc.TeamQuotas{MaxConcurrentBuilds: 1})
+							Expect(err).ToNot(HaveOccurred())
+
+							otherPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "priority-pipeline"}, atc.Config{
+								Jobs: atc.JobConfigs{
+									{
+										Name: "other-job",
+									},
+								},
+							}, db.ConfigVersion(0), false)
+							Expect(err).ToNot(HaveOccurred())
+
+							var found bool
+							otherJob, found, err = otherPipeline.Job("other-job")
+							Expect(err).ToNot(HaveOccurred())
+							Expect(found).To(BeTrue())
+						})
+
+						Context("when another job has a pending build with a higher priority", func() {
+							var higherPriorityBuild db.Build
+
+							BeforeEach(func() {
+								err := otherJob.SaveNextInputMapping(nil, true)
+								Expect(err).ToNot(HaveOccurred())
+
+								higherPriorityBuild, err = otherJob.CreateBuildWithPriority(5)
+								Expect(err).ToNot(HaveOccurred())
+							})
+
+							Context("when it was last held back by the quota", func() {
+								BeforeEach(func() {
+									runningBuild, err := otherJob.CreateBuildWithPriority(10)
+									Expect(err).ToNot(HaveOccurred())
+
+									scheduled, err := otherJob.ScheduleBuild(runningBuild)
+									Expect(err).ToNot(HaveOccurred())
+									Expect(scheduled).To(BeTrue())
+
+									scheduled, err = otherJob.ScheduleBuild(higherPriorityBuild)
+									Expect(err).ToNot(HaveOccurred())
+									Expect(scheduled).To(BeFalse())
+
+									err = runningBuild.Finish(db.BuildStatusSucceeded)
+									Expect(err).ToNot(HaveOccurred())
+								})
+
+								It("leaves the build pending with its quota exceeded", func() {
+									Expect(schedulingErr).ToNot(HaveOccurred())
+									Expect(scheduleFound).To(BeFalse())
+									Expect(schedulingBuild.IsScheduled()).To(BeFalse())
+									Expect(schedulingBuild.QuotaExceeded()).To(BeTrue())
+								})
+
+								Context("when the other job is paused", func() {
+									BeforeEach(func() {
+										err := otherJob.Pause()
+										Expect(err).ToNot(HaveOccurred())
+									})
+
+									It("schedules the build", func() {
+										Expect(schedulingErr).ToNot(HaveOccurred())
+										Expect(scheduleFound).To(BeTrue())
+										Expect(schedulingBuild.IsScheduled()).To(BeTrue())
+									})
+								})
+
+								Context("when the inputs of the other job can't be determined", func() {
+									BeforeEach(func() {
+										err := otherJob.SaveNextInputMapping(nil, false)
+										Expect(err).ToNot(HaveOccurred())
+									})
+
+									It("schedules the build", func() {
+										Expect(schedulingErr).ToNot(HaveOccurred())
+										Expect(scheduleFound).To(BeTrue())
+										Expect(schedulingBuild.IsScheduled()).To(BeTrue())
+									})
+								})
+							})
+
+							Context("when it has not been held back by the quota", func() {
+								It("schedules the build, as the other build may never be startable", func() {
+									Expect(schedulingErr).ToNot(HaveOccurred())
+									Expect(scheduleFound).To(BeTrue())
+									Expect(schedulingBuild.IsScheduled()).To(BeTrue())
+								})
+							})
+						})
+
+						Context("when another job has a pending build with a lower priority", func() {
+							BeforeEach(func() {
+								_, err := otherJob.CreateBuildWithPriority(-5)
+								Expect(err).ToNot(HaveOccurred())
+							})
+
+							It("schedules the build", func() {
+								Expect(schedulingErr).ToNot(HaveOccurred())
+								Expect(scheduleFound).To(BeTrue())
+								Expect(schedulingBuild.IsScheduled()).To(BeTrue())
+								Expect(schedulingBuild.QuotaExceeded()).To(BeFalse())
+							})
+						})
+					})
 				})
 
 				Context("when the build does not exist", func() {
@@ -1300,7 +1449,7 @@ var _ = Describe("Job", func() {
 			Expect(setupTx.Commit()).To(Succeed())
 
 			var created bool
-			pipeline, created, err = team.SavePipeline("build-inputs-pipeline", atc.Config{
+			pipeline, created, err = team.SavePipeline(atc.PipelineRef{Name: "build-inputs-pipeline"}, atc.Config{
 				Jobs: atc.JobConfigs{
 					{
 						Name: "some-job",
@@ -1470,6 +1619,198 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("SchedulingExplanation", func() {
+		var (
+			job1        db.Job
+			job1Build   db.Build
+			resource    db.Resource
+			explanation atc.SchedulingExplanation
+			explainErr  error
+		)
+
+		BeforeEach(func() {
+			setupTx, err := dbConn.Begin()
+			Expect(err).ToNot(HaveOccurred())
+
+			brt := db.BaseResourceType{
+				Name: "some-type",
+			}
+
+			_, err = brt.FindOrCreate(setupTx, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(setupTx.Commit()).To(Succeed())
+
+			var found bool
+			resource, found, err = pipeline.Resource("some-resource")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			resourceConfigScope, err := resource.SetResourceConfig(atc.Source{}, atc.VersionedResourceTypes{})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = resourceConfigScope.SaveVersions(nil, []atc.Version{
+				{"version": "v1"},
+				{"version": "v2"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			job1, found, err = pipeline.Job("job-1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			job1Build, err = job1.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			explanation, explainErr = job.SchedulingExplanation()
+		})
+
+		Context("when the job has not been scheduled yet", func() {
+			It("explains the configuration of the inputs", func() {
+				Expect(explainErr).ToNot(HaveOccurred())
+				Expect(explanation).To(Equal(atc.SchedulingExplanation{
+					InputsDetermined: false,
+					Inputs: []atc.InputExplanation{
+						{
+							Name:     "some-input",
+							Resource: "some-resource",
+							Passed:   []string{"job-1", "job-2"},
+						},
+					},
+				}))
+			})
+		})
+
+		Context("when the inputs could not be determined", func() {
+			var job2Build db.Build
+
+			BeforeEach(func() {
+				job2, found, err := pipeline.Job("job-2")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				job2Build, err = job2.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				err = job.SaveNextInputMapping(db.InputMapping{
+					"some-input": db.InputResult{
+						ResolveError: db.NoSatisfiableBuilds,
+						Explanation: db.InputExplanation{
+							Candidates: []db.CandidateExplanation{
+								{
+									Version:     db.ResourceVersion(convertToMD5(atc.Version{"version": "v2"})),
+									PassedJobID: job1.ID(),
+									BuildID:     job1Build.ID(),
+									Rejection:   db.CandidateDisabled,
+								},
+								{
+									Version:     db.ResourceVersion(convertToMD5(atc.Version{"version": "v1"})),
+									PassedJobID: job1.ID(),
+									BuildID:     job1Build.ID(),
+									Rejection:   db.CandidateUnsatisfiable,
+									Conflict: &db.CandidateConflict{
+										PassedJobID: job2.ID(),
+										BuildID:     job2Build.ID(),
+									},
+								},
+							},
+						},
+					},
+				}, false)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("explains why the candidates were rejected", func() {
+				Expect(explainErr).ToNot(HaveOccurred())
+				Expect(explanation).To(Equal(atc.SchedulingExplanation{
+					InputsDetermined: false,
+					Inputs: []atc.InputExplanation{
+						{
+							Name:     "some-input",
+							Resource: "some-resource",
+							Passed:   []string{"job-1", "job-2"},
+							Error:    string(db.NoSatisfiableBuilds),
+							Candidates: []atc.CandidateVersion{
+								{
+									Version:   atc.Version{"version": "v2"},
+									PassedJob: "job-1",
+									BuildID:   job1Build.ID(),
+									BuildName: job1Build.Name(),
+									Rejection: string(db.CandidateDisabled),
+								},
+								{
+									Version:   atc.Version{"version": "v1"},
+									PassedJob: "job-1",
+									BuildID:   job1Build.ID(),
+									BuildName: job1Build.Name(),
+									Rejection: string(db.CandidateUnsatisfiable),
+									Conflict: &atc.CandidateConflict{
+										PassedJob: "job-2",
+										BuildID:   job2Build.ID(),
+										BuildName: job2Build.Name(),
+									},
+								},
+							},
+						},
+					},
+				}))
+			})
+		})
+
+		Context("when the inputs were determined", func() {
+			BeforeEach(func() {
+				v1 := db.ResourceVersion(convertToMD5(atc.Version{"version": "v1"}))
+
+				err := job.SaveNextInputMapping(db.InputMapping{
+					"some-input": db.InputResult{
+						Input: &db.AlgorithmInput{
+							AlgorithmVersion: db.AlgorithmVersion{
+								ResourceID: resource.ID(),
+								Version:    v1,
+							},
+						},
+						Explanation: db.InputExplanation{
+							Candidates: []db.CandidateExplanation{
+								{
+									Version:     v1,
+									PassedJobID: job1.ID(),
+									BuildID:     job1Build.ID(),
+								},
+							},
+							Truncated: true,
+						},
+					},
+				}, true)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("explains which version was chosen", func() {
+				Expect(explainErr).ToNot(HaveOccurred())
+				Expect(explanation).To(Equal(atc.SchedulingExplanation{
+					InputsDetermined: true,
+					Inputs: []atc.InputExplanation{
+						{
+							Name:     "some-input",
+							Resource: "some-resource",
+							Passed:   []string{"job-1", "job-2"},
+							Version:  atc.Version{"version": "v1"},
+							Candidates: []atc.CandidateVersion{
+								{
+									Version:   atc.Version{"version": "v1"},
+									PassedJob: "job-1",
+									BuildID:   job1Build.ID(),
+									BuildName: job1Build.Name(),
+								},
+							},
+							CandidatesTruncated: true,
+						},
+					},
+				}))
+			})
+		})
+	})
+
 	Describe("GetFullNextBuildInputs", func() {
 		var (
 			pipeline2           db.Pipeline
@@ -1544,7 +1885,7 @@ var _ = Describe("Job", func() {
 				},
 			}
 
-			pipeline2, _, err = team.SavePipeline("some-pipeline-2", config, 1, false)
+			pipeline2, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-pipeline-2"}, config, 1, false)
 			Expect(err).ToNot(HaveOccurred())
 
 			resource2, found, err = pipeline2.Resource("some-resource")
@@ -1727,7 +2068,7 @@ var _ = Describe("Job", func() {
 				},
 			}
 			var err error
-			otherPipeline, _, err = team.SavePipeline("some-other-pipeline", pipelineConfig, db.ConfigVersion(1), false)
+			otherPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "some-other-pipeline"}, pipelineConfig, db.ConfigVersion(1), false)
 			Expect(err).ToNot(HaveOccurred())
 
 			build1DB, err = job.CreateBuild()
@@ -1965,6 +2306,148 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("build priority", func() {
+		It("gives builds the default build priority of the team", func() {
+			err := team.UpdateDefaultBuildPriority(3)
+			Expect(err).ToNot(HaveOccurred())
+
+			build, err := job.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(build.Priority()).To(Equal(3))
+		})
+
+		It("gives builds the explicitly requested priority", func() {
+			build, err := job.CreateBuildWithPriority(-2)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(build.Priority()).To(Equal(-2))
+		})
+
+		It("gives reruns the priority of the build being rerun", func() {
+			build, err := job.CreateBuildWithPriority(5)
+			Expect(err).ToNot(HaveOccurred())
+
+			rerunBuild, err := job.RerunBuild(build)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rerunBuild.Priority()).To(Equal(5))
+		})
+
+		It("returns pending builds with a higher priority first", func() {
+			lowBuild, err := job.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			highBuild, err := job.CreateBuildWithPriority(5)
+			Expect(err).ToNot(HaveOccurred())
+
+			pendingBuilds, err := job.GetPendingBuilds()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pendingBuilds).To(HaveLen(2))
+			Expect(pendingBuilds[0].ID()).To(Equal(highBuild.ID()))
+			Expect(pendingBuilds[1].ID()).To(Equal(lowBuild.ID()))
+		})
+
+		Context("when the job configures a priority", func() {
+			var prioritizedJob db.Job
+
+			BeforeEach(func() {
+				err := team.UpdateDefaultBuildPriority(3)
+				Expect(err).ToNot(HaveOccurred())
+
+				priority := 7
+				prioritizedPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "prioritized-pipeline"}, atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name:     "prioritized-job",
+							Priority: &priority,
+						},
+					},
+				}, db.ConfigVersion(0), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				var found bool
+				prioritizedJob, found, err = prioritizedPipeline.Job("prioritized-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+
+			It("takes precedence over the default build priority of the team", func() {
+				build, err := prioritizedJob.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(build.Priority()).To(Equal(7))
+			})
+
+			It("is given to builds created by the scheduler", func() {
+				err := prioritizedJob.EnsurePendingBuildExists(context.TODO())
+				Expect(err).ToNot(HaveOccurred())
+
+				pendingBuilds, err := prioritizedJob.GetPendingBuilds()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pendingBuilds).To(HaveLen(1))
+				Expect(pendingBuilds[0].Priority()).To(Equal(7))
+			})
+
+			Context("when it is above the team's maximum build priority", func() {
+				BeforeEach(func() {
+					maxPriority := 4
+					err := team.UpdateQuotas(atc.TeamQuotas{MaxBuildPriority: &maxPriority})
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("lowers it to the maximum", func() {
+					build, err := prioritizedJob.CreateBuild()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(build.Priority()).To(Equal(4))
+
+					err = prioritizedJob.EnsurePendingBuildExists(context.TODO())
+					Expect(err).ToNot(HaveOccurred())
+
+					pendingBuilds, err := prioritizedJob.GetPendingBuilds()
+					Expect(err).ToNot(HaveOccurred())
+					for _, pendingBuild := range pendingBuilds {
+						Expect(pendingBuild.Priority()).To(Equal(4))
+					}
+				})
+			})
+		})
+
+		Context("when the team has a maximum build priority", func() {
+			BeforeEach(func() {
+				maxPriority := 2
+				err := team.UpdateQuotas(atc.TeamQuotas{MaxBuildPriority: &maxPriority})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("lowers higher requested priorities to it", func() {
+				build, err := job.CreateBuildWithPriority(100)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(build.Priority()).To(Equal(2))
+			})
+
+			It("leaves lower requested priorities as they are", func() {
+				build, err := job.CreateBuildWithPriority(-2)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(build.Priority()).To(Equal(-2))
+			})
+
+			It("lowers the priority of reruns of builds created before it was set", func() {
+				maxPriority := 10
+				err := team.UpdateQuotas(atc.TeamQuotas{MaxBuildPriority: &maxPriority})
+				Expect(err).ToNot(HaveOccurred())
+
+				build, err := job.CreateBuildWithPriority(5)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(build.Priority()).To(Equal(5))
+
+				maxPriority = 2
+				err = team.UpdateQuotas(atc.TeamQuotas{MaxBuildPriority: &maxPriority})
+				Expect(err).ToNot(HaveOccurred())
+
+				rerunBuild, err := job.RerunBuild(build)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(rerunBuild.Priority()).To(Equal(2))
+			})
+		})
+	})
+
 	Describe("EnsurePendingBuildExists", func() {
 		Context("when only a started build exists", func() {
 			It("creates a build and updates the next build for the job", func() {
@@ -2201,7 +2684,7 @@ var _ = Describe("Job", func() {
 		Context("when there is an input configured for the job", func() {
 			BeforeEach(func() {
 				var err error
-				inputsPipeline, _, err = team.SavePipeline("inputs-pipeline", atc.Config{
+				inputsPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "inputs-pipeline"}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{
 							Name: "some-job",
@@ -2274,7 +2757,7 @@ var _ = Describe("Job", func() {
 		Context("when the input is pinned through the get step", func() {
 			BeforeEach(func() {
 				var err error
-				inputsPipeline, _, err = team.SavePipeline("inputs-pipeline", atc.Config{
+				inputsPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "inputs-pipeline"}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{
 							Name: "some-job",
@@ -2374,7 +2857,7 @@ var _ = Describe("Job", func() {
 		Context("when the input is pinned through the resource config", func() {
 			BeforeEach(func() {
 				var err error
-				inputsPipeline, _, err = team.SavePipeline("inputs-pipeline", atc.Config{
+				inputsPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "inputs-pipeline"}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{
 							Name: "some-job",
@@ -2424,7 +2907,7 @@ var _ = Describe("Job", func() {
 		Context("when the input is pinned through the api", func() {
 			BeforeEach(func() {
 				var err error
-				inputsPipeline, _, err = team.SavePipeline("inputs-pipeline", atc.Config{
+				inputsPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "inputs-pipeline"}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{
 							Name: "some-job",
@@ -2504,7 +2987,7 @@ var _ = Describe("Job", func() {
 		Context("when there are multiple inputs", func() {
 			BeforeEach(func() {
 				var err error
-				inputsPipeline, _, err = team.SavePipeline("inputs-pipeline", atc.Config{
+				inputsPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "inputs-pipeline"}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{
 							Name: "some-job",
@@ -2597,7 +3080,7 @@ var _ = Describe("Job", func() {
 		Context("when the job has puts and tasks", func() {
 			BeforeEach(func() {
 				var err error
-				inputsPipeline, _, err = team.SavePipeline("inputs-pipeline", atc.Config{
+				inputsPipeline, _, err = team.SavePipeline(atc.PipelineRef{Name: "inputs-pipeline"}, atc.Config{
 					Jobs: atc.JobConfigs{
 						{
 							Name: "some-job",
@@ -2660,7 +3143,7 @@ var _ = Describe("Job", func() {
 		var inputsJob db.Job
 
 		BeforeEach(func() {
-			inputsPipeline, _, err := team.SavePipeline("inputs-pipeline", atc.Config{
+			inputsPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "inputs-pipeline"}, atc.Config{
 				Jobs: atc.JobConfigs{
 					{
 						Name: "some-job",
@@ -2785,7 +3268,7 @@ var _ = Describe("Job", func() {
 		var outputsJob db.Job
 
 		BeforeEach(func() {
-			outputsPipeline, _, err := team.SavePipeline("outputs-pipeline", atc.Config{
+			outputsPipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "outputs-pipeline"}, atc.Config{
 				Jobs: atc.JobConfigs{
 					{
 						Name: "some-job",
@@ -0,0 +1,3 @@
+BEGIN;
+  DROP TABLE policy_decisions;
+COMMIT;
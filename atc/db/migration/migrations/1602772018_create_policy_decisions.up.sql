@@ -0,0 +1,15 @@
+BEGIN;
+  CREATE TABLE policy_decisions (
+    id bigserial PRIMARY KEY,
+    team_name text NOT NULL,
+    pipeline_name text,
+    action text NOT NULL,
+    reasons jsonb,
+    audit_only boolean NOT NULL DEFAULT false,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+  );
+
+  CREATE INDEX policy_decisions_team_name_idx ON policy_decisions (team_name);
+
+  CREATE INDEX policy_decisions_created_at_idx ON policy_decisions (created_at);
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+  CREATE TABLE build_secret_leases (
+    lease_id text PRIMARY KEY,
+    build_id integer NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+  );
+
+  CREATE INDEX build_secret_leases_build_id_idx ON build_secret_leases (build_id);
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  DROP TABLE build_secret_accesses;
+COMMIT;
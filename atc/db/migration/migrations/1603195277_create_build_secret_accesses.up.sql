@@ -0,0 +1,16 @@
+BEGIN;
+  CREATE TABLE build_secret_accesses (
+    id bigserial PRIMARY KEY,
+    build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    path text NOT NULL,
+    manager text NOT NULL,
+    var_source text,
+    cache_hit boolean NOT NULL DEFAULT false,
+    found boolean NOT NULL DEFAULT false,
+    accessed_at timestamp with time zone NOT NULL DEFAULT now()
+  );
+
+  CREATE INDEX build_secret_accesses_build_id_idx ON build_secret_accesses (build_id);
+
+  CREATE INDEX build_secret_accesses_path_idx ON build_secret_accesses (path);
+COMMIT;
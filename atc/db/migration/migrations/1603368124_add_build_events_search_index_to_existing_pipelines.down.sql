@@ -0,0 +1,11 @@
+BEGIN;
+  DO $$
+  DECLARE
+    pipeline record;
+  BEGIN
+    FOR pipeline IN SELECT id FROM pipelines LOOP
+      EXECUTE format('DROP INDEX IF EXISTS pipeline_build_events_%s_log_search', pipeline.id);
+    END LOOP;
+  END
+  $$;
+COMMIT;
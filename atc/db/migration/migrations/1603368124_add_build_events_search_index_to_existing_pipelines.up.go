@@ -0,0 +1,61 @@
+package migrations
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Up_1603368124 builds the log search index of every existing pipeline's
+// build events table. The indexes are built concurrently and outside of a
+// transaction so that builds can keep writing events during the upgrade.
+func (self *migrations) Up_1603368124() error {
+	rows, err := self.DB.Query("SELECT id FROM pipelines WHERE to_regclass(format('pipeline_build_events_%s', id)) IS NOT NULL")
+	if err != nil {
+		return err
+	}
+
+	var pipelineIDs []int
+	for rows.Next() {
+		var id int
+		if err = rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+
+		pipelineIDs = append(pipelineIDs, id)
+	}
+
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	for _, id := range pipelineIDs {
+		index := fmt.Sprintf("pipeline_build_events_%d_log_search", id)
+
+		// an index left over from a concurrent build that was interrupted is
+		// invalid and has to be built again
+		var valid bool
+		err = self.DB.QueryRow("SELECT indisvalid FROM pg_index WHERE indexrelid = to_regclass($1)", index).Scan(&valid)
+		if err != nil && err != sql.ErrNoRows {
+			return err
+		}
+
+		if valid {
+			continue
+		}
+
+		_, err = self.DB.Exec("DROP INDEX CONCURRENTLY IF EXISTS " + index)
+		if err != nil {
+			return err
+		}
+
+		_, err = self.DB.Exec(fmt.Sprintf("CREATE INDEX CONCURRENTLY %s ON pipeline_build_events_%d USING gin (to_tsvector('simple', payload::jsonb ->> 'payload')) WHERE type = 'log'", index, id))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
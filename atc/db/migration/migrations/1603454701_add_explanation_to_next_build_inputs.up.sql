@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE next_build_inputs ADD COLUMN explanation jsonb;
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE resource_config_scopes DROP COLUMN last_check_rate_limit_delay;
+COMMIT;
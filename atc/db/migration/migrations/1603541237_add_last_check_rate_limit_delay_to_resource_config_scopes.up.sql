@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE resource_config_scopes ADD COLUMN last_check_rate_limit_delay bigint;
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE resource_config_scopes
+    DROP COLUMN consecutive_check_failures,
+    DROP COLUMN idle_since;
+COMMIT;
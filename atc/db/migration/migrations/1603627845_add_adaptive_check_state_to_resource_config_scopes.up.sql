@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE resource_config_scopes
+    ADD COLUMN consecutive_check_failures integer NOT NULL DEFAULT 0,
+    ADD COLUMN idle_since timestamp with time zone NOT NULL DEFAULT now();
+COMMIT;
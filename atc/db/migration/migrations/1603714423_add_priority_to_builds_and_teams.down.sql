@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE builds DROP COLUMN priority;
+
+  ALTER TABLE teams DROP COLUMN default_build_priority;
+COMMIT;
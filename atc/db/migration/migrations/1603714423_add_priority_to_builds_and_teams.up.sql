@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE builds ADD COLUMN priority integer NOT NULL DEFAULT 0;
+
+  ALTER TABLE teams ADD COLUMN default_build_priority integer NOT NULL DEFAULT 0;
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE builds DROP COLUMN quota_exceeded;
+
+  ALTER TABLE teams DROP COLUMN quotas;
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE teams ADD COLUMN quotas json;
+
+  ALTER TABLE builds ADD COLUMN quota_exceeded boolean NOT NULL DEFAULT false;
+COMMIT;
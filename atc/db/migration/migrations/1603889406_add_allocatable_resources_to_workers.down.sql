@@ -0,0 +1,9 @@
+BEGIN;
+  ALTER TABLE containers
+    DROP COLUMN meta_cpu_limit,
+    DROP COLUMN meta_memory_limit;
+
+  ALTER TABLE workers
+    DROP COLUMN allocatable_cpu,
+    DROP COLUMN allocatable_memory;
+COMMIT;
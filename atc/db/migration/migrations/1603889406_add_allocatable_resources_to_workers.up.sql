@@ -0,0 +1,9 @@
+BEGIN;
+  ALTER TABLE workers
+    ADD COLUMN allocatable_cpu bigint NOT NULL DEFAULT 0,
+    ADD COLUMN allocatable_memory bigint NOT NULL DEFAULT 0;
+
+  ALTER TABLE containers
+    ADD COLUMN meta_cpu_limit bigint NOT NULL DEFAULT 0,
+    ADD COLUMN meta_memory_limit bigint NOT NULL DEFAULT 0;
+COMMIT;
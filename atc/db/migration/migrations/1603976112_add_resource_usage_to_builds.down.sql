@@ -0,0 +1,6 @@
+BEGIN;
+  ALTER TABLE builds
+    DROP COLUMN cpu_time,
+    DROP COLUMN memory_peak,
+    DROP COLUMN rootfs_disk_usage;
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+  ALTER TABLE builds
+    ADD COLUMN cpu_time bigint NOT NULL DEFAULT 0,
+    ADD COLUMN memory_peak bigint NOT NULL DEFAULT 0,
+    ADD COLUMN rootfs_disk_usage bigint NOT NULL DEFAULT 0;
+COMMIT;
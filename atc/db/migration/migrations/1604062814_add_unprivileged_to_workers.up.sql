@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE workers
+    ADD COLUMN unprivileged boolean NOT NULL DEFAULT false;
+COMMIT;
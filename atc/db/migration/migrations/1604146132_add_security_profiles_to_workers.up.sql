@@ -0,0 +1,4 @@
+BEGIN;
+  ALTER TABLE workers
+    ADD COLUMN security_profiles text;
+COMMIT;
@@ -9,6 +9,10 @@ type Page struct {
 
 	Limit   int
 	UseDate bool
+
+	// Secret only includes builds which found a secret at this path, or at a
+	// path ending with it.
+	Secret string
 }
 
 type Pagination struct {
@@ -38,6 +38,8 @@ type Cause struct {
 type Pipeline interface {
 	ID() int
 	Name() string
+	InstanceVars() atc.InstanceVars
+	Ref() atc.PipelineRef
 	TeamID() int
 	TeamName() string
 	ParentJobID() int
@@ -66,6 +68,7 @@ type Pipeline interface {
 	CreateStartedBuild(plan atc.Plan) (Build, error)
 
 	BuildsWithTime(page Page) ([]Build, Pagination, error)
+	SearchBuildLogs(query string, jobID int, page Page) (atc.BuildLogSearch, Pagination, error)
 
 	DeleteBuildEventsByBuildIDs(buildIDs []int) error
 
@@ -102,6 +105,7 @@ type Pipeline interface {
 type pipeline struct {
 	id            int
 	name          string
+	instanceVars  atc.InstanceVars
 	teamID        int
 	teamName      string
 	parentJobID   int
@@ -125,6 +129,7 @@ type ConfigVersion int
 var pipelinesQuery = psql.Select(`
 		p.id,
 		p.name,
+		p.instance_vars,
 		p.groups,
 		p.var_sources,
 		p.display,
@@ -149,13 +154,21 @@ func newPipeline(conn Conn, lockFactory lock.LockFactory) *pipeline {
 	}
 }
 
-func (p *pipeline) ID() int                  { return p.id }
-func (p *pipeline) Name() string             { return p.name }
-func (p *pipeline) TeamID() int              { return p.teamID }
-func (p *pipeline) TeamName() string         { return p.teamName }
-func (p *pipeline) ParentJobID() int         { return p.parentJobID }
-func (p *pipeline) ParentBuildID() int       { return p.parentBuildID }
-func (p *pipeline) Groups() atc.GroupConfigs { return p.groups }
+func (p *pipeline) ID() int                        { return p.id }
+func (p *pipeline) Name() string                   { return p.name }
+func (p *pipeline) InstanceVars() atc.InstanceVars { return p.instanceVars }
+func (p *pipeline) TeamID() int                    { return p.teamID }
+func (p *pipeline) TeamName() string               { return p.teamName }
+func (p *pipeline) ParentJobID() int               { return p.parentJobID }
+func (p *pipeline) ParentBuildID() int             { return p.parentBuildID }
+func (p *pipeline) Groups() atc.GroupConfigs       { return p.groups }
+
+func (p *pipeline) Ref() atc.PipelineRef {
+	return atc.PipelineRef{
+		Name:         p.name,
+		InstanceVars: p.instanceVars,
+	}
+}
 
 func (p *pipeline) VarSources() atc.VarSourceConfigs { return p.varSources }
 func (p *pipeline) Display() *atc.DisplayConfig      { return p.display }
@@ -1159,10 +1172,11 @@ func (p *pipeline) Variables(logger lager.Logger, globalSecrets creds.Secrets, v
 		if !ok {
 			return nil, fmt.Errorf("var_source '%s' invalid config", cm.Name)
 		}
-		secrets, err := varSourcePool.FindOrCreate(logger, config, factory)
+		secrets, err := varSourcePool.FindOrCreate(logger, p.TeamName(), config, factory)
 		if err != nil {
 			return nil, errors.Wrapf(err, "create var_source '%s' error", cm.Name)
 		}
+		secrets = creds.AuditVarSource(globalSecrets, secrets, cm.Name, cm.Type)
 		namedVarsMap[cm.Name] = creds.NewVariables(secrets, p.TeamName(), p.Name(), true)
 	}
 
@@ -2,8 +2,11 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
+
 	sq "github.com/Masterminds/squirrel"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db/lock"
 )
 
@@ -13,23 +16,27 @@ import (
 type PipelineRef interface {
 	PipelineID() int
 	PipelineName() string
+	PipelineInstanceVars() atc.InstanceVars
+	PipelineRef() atc.PipelineRef
 	Pipeline() (Pipeline, bool, error)
 }
 
 type pipelineRef struct {
-	pipelineID   int
-	pipelineName string
+	pipelineID           int
+	pipelineName         string
+	pipelineInstanceVars atc.InstanceVars
 
 	conn        Conn
 	lockFactory lock.LockFactory
 }
 
-func NewPipelineRef(id int, name string, conn Conn, lockFactory lock.LockFactory) PipelineRef {
+func NewPipelineRef(id int, name string, instanceVars atc.InstanceVars, conn Conn, lockFactory lock.LockFactory) PipelineRef {
 	return pipelineRef{
-		pipelineID:   id,
-		pipelineName: name,
-		conn:         conn,
-		lockFactory:  lockFactory,
+		pipelineID:           id,
+		pipelineName:         name,
+		pipelineInstanceVars: instanceVars,
+		conn:                 conn,
+		lockFactory:          lockFactory,
 	}
 }
 
@@ -41,6 +48,17 @@ func (r pipelineRef) PipelineName() string {
 	return r.pipelineName
 }
 
+func (r pipelineRef) PipelineInstanceVars() atc.InstanceVars {
+	return r.pipelineInstanceVars
+}
+
+func (r pipelineRef) PipelineRef() atc.PipelineRef {
+	return atc.PipelineRef{
+		Name:         r.pipelineName,
+		InstanceVars: r.pipelineInstanceVars,
+	}
+}
+
 func (r pipelineRef) Pipeline() (Pipeline, bool, error) {
 	if r.PipelineID() == 0 {
 		return nil, false, nil
@@ -62,3 +80,34 @@ func (r pipelineRef) Pipeline() (Pipeline, bool, error) {
 
 	return pipeline, true, nil
 }
+
+func (r *pipelineRef) scanInstanceVars(instanceVars sql.NullString) error {
+	r.pipelineInstanceVars = nil
+
+	if !instanceVars.Valid {
+		return nil
+	}
+
+	return json.Unmarshal([]byte(instanceVars.String), &r.pipelineInstanceVars)
+}
+
+// pipelineRefCondition matches the pipeline with the given name and instance
+// vars. Pipelines without instance vars have a NULL instance_vars column.
+func pipelineRefCondition(ref atc.PipelineRef, table string) (sq.Sqlizer, error) {
+	if len(ref.InstanceVars) == 0 {
+		return sq.Eq{
+			table + ".name":          ref.Name,
+			table + ".instance_vars": nil,
+		}, nil
+	}
+
+	instanceVars, err := json.Marshal(ref.InstanceVars)
+	if err != nil {
+		return nil, err
+	}
+
+	return sq.And{
+		sq.Eq{table + ".name": ref.Name},
+		sq.Expr(table+".instance_vars = ?::jsonb", string(instanceVars)),
+	}, nil
+}
@@ -13,7 +13,7 @@ var _ = Describe("PipelineRef", func() {
 	)
 
 	BeforeEach(func(){
-		pr = db.NewPipelineRef(defaultPipeline.ID(), defaultPipeline.Name(), dbConn, lockFactory)
+		pr = db.NewPipelineRef(defaultPipeline.ID(), defaultPipeline.Name(), defaultPipeline.InstanceVars(), dbConn, lockFactory)
 	})
 
 	It("id should be correct", func() {
@@ -0,0 +1,102 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+//go:generate counterfeiter . PolicyDecisionFactory
+
+type PolicyDecisionFactory interface {
+	CreateDecision(atc.PolicyDecision) error
+	Decisions(teamName string, pipelineName string, limit int) ([]atc.PolicyDecision, error)
+}
+
+func NewPolicyDecisionFactory(conn Conn) PolicyDecisionFactory {
+	return &policyDecisionFactory{conn}
+}
+
+type policyDecisionFactory struct {
+	conn Conn
+}
+
+func (f *policyDecisionFactory) CreateDecision(decision atc.PolicyDecision) error {
+	reasons, err := json.Marshal(decision.Reasons)
+	if err != nil {
+		return err
+	}
+
+	var pipelineName sql.NullString
+	if decision.Pipeline != "" {
+		pipelineName = sql.NullString{String: decision.Pipeline, Valid: true}
+	}
+
+	_, err = psql.Insert("policy_decisions").
+		Columns("team_name", "pipeline_name", "action", "reasons", "audit_only").
+		Values(decision.Team, pipelineName, decision.Action, reasons, decision.AuditOnly).
+		RunWith(f.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Decisions returns the most recent decisions recorded for the team, newest
+// first. An empty pipelineName matches decisions of every pipeline and a limit
+// of 0 returns all of them.
+func (f *policyDecisionFactory) Decisions(teamName string, pipelineName string, limit int) ([]atc.PolicyDecision, error) {
+	query := psql.Select("id", "team_name", "pipeline_name", "action", "reasons", "audit_only", "created_at").
+		From("policy_decisions").
+		Where(sq.Eq{"team_name": teamName}).
+		OrderBy("id DESC")
+
+	if pipelineName != "" {
+		query = query.Where(sq.Eq{"pipeline_name": pipelineName})
+	}
+
+	if limit > 0 {
+		query = query.Limit(uint64(limit))
+	}
+
+	rows, err := query.RunWith(f.conn).Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	decisions := []atc.PolicyDecision{}
+	for rows.Next() {
+		var (
+			decision     atc.PolicyDecision
+			pipelineName sql.NullString
+			reasons      []byte
+			createdAt    time.Time
+		)
+
+		err = rows.Scan(&decision.ID, &decision.Team, &pipelineName, &decision.Action, &reasons, &decision.AuditOnly, &createdAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if reasons != nil {
+			err = json.Unmarshal(reasons, &decision.Reasons)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		decision.Pipeline = pipelineName.String
+		decision.CreatedAt = createdAt.Unix()
+
+		decisions = append(decisions, decision)
+	}
+
+	return decisions, nil
+}
@@ -0,0 +1,74 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Policy Decision Factory", func() {
+	var factory db.PolicyDecisionFactory
+
+	BeforeEach(func() {
+		factory = db.NewPolicyDecisionFactory(dbConn)
+	})
+
+	Describe("Decisions", func() {
+		BeforeEach(func() {
+			err := factory.CreateDecision(atc.PolicyDecision{
+				Team:      "some-team",
+				Pipeline:  "some-pipeline",
+				Action:    "SaveConfig",
+				Reasons:   []string{"some-reason"},
+				AuditOnly: true,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = factory.CreateDecision(atc.PolicyDecision{
+				Team:   "some-team",
+				Action: "UseImage",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			err = factory.CreateDecision(atc.PolicyDecision{
+				Team:     "other-team",
+				Pipeline: "some-pipeline",
+				Action:   "RunTask",
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("returns the decisions of the team, newest first", func() {
+			decisions, err := factory.Decisions("some-team", "", 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(decisions).To(HaveLen(2))
+
+			Expect(decisions[0].Action).To(Equal("UseImage"))
+			Expect(decisions[0].Pipeline).To(BeEmpty())
+			Expect(decisions[0].AuditOnly).To(BeFalse())
+
+			Expect(decisions[1].Team).To(Equal("some-team"))
+			Expect(decisions[1].Pipeline).To(Equal("some-pipeline"))
+			Expect(decisions[1].Action).To(Equal("SaveConfig"))
+			Expect(decisions[1].Reasons).To(Equal([]string{"some-reason"}))
+			Expect(decisions[1].AuditOnly).To(BeTrue())
+			Expect(decisions[1].CreatedAt).ToNot(BeZero())
+		})
+
+		It("filters by pipeline", func() {
+			decisions, err := factory.Decisions("some-team", "some-pipeline", 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(decisions).To(HaveLen(1))
+			Expect(decisions[0].Action).To(Equal("SaveConfig"))
+		})
+
+		It("limits the number of decisions", func() {
+			decisions, err := factory.Decisions("some-team", "", 1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(decisions).To(HaveLen(1))
+			Expect(decisions[0].Action).To(Equal("UseImage"))
+		})
+	})
+})
@@ -0,0 +1,37 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+//go:generate counterfeiter . PolicyDecisionLifecycle
+
+type PolicyDecisionLifecycle interface {
+	RemoveDecisionsOlderThan(retention time.Duration) (int, error)
+}
+
+type policyDecisionLifecycle struct {
+	conn Conn
+}
+
+func NewPolicyDecisionLifecycle(conn Conn) PolicyDecisionLifecycle {
+	return &policyDecisionLifecycle{conn}
+}
+
+func (l policyDecisionLifecycle) RemoveDecisionsOlderThan(retention time.Duration) (int, error) {
+	res, err := psql.Delete("policy_decisions").
+		Where(sq.Expr("created_at < NOW() - ?::interval", fmt.Sprintf("%d seconds", int(retention.Seconds())))).
+		RunWith(l.conn).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(n), nil
+}
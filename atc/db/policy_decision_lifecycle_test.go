@@ -0,0 +1,43 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Policy Decision Lifecycle", func() {
+	var (
+		factory   db.PolicyDecisionFactory
+		lifecycle db.PolicyDecisionLifecycle
+	)
+
+	BeforeEach(func() {
+		factory = db.NewPolicyDecisionFactory(dbConn)
+		lifecycle = db.NewPolicyDecisionLifecycle(dbConn)
+
+		err := factory.CreateDecision(atc.PolicyDecision{Team: "some-team", Action: "old"})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = dbConn.Exec(`UPDATE policy_decisions SET created_at = now() - interval '2 days'`)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = factory.CreateDecision(atc.PolicyDecision{Team: "some-team", Action: "new"})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("removes decisions older than the retention period", func() {
+		n, err := lifecycle.RemoveDecisionsOlderThan(24 * time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(n).To(Equal(1))
+
+		decisions, err := factory.Decisions("some-team", "", 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(decisions).To(HaveLen(1))
+		Expect(decisions[0].Action).To(Equal("new"))
+	})
+})
@@ -33,6 +33,9 @@ type Resource interface {
 	CheckTimeout() string
 	LastCheckStartTime() time.Time
 	LastCheckEndTime() time.Time
+	LastCheckRateLimitDelay() time.Duration
+	ConsecutiveCheckFailures() int
+	IdleSince() time.Time
 	Tags() atc.Tags
 	CheckSetupError() error
 	CheckError() error
@@ -76,11 +79,15 @@ var resourcesQuery = psql.Select(
 	"r.check_error",
 	"rs.last_check_start_time",
 	"rs.last_check_end_time",
+	"rs.last_check_rate_limit_delay",
+	"rs.consecutive_check_failures",
+	"rs.idle_since",
 	"r.pipeline_id",
 	"r.nonce",
 	"r.resource_config_id",
 	"r.resource_config_scope_id",
 	"p.name",
+	"p.instance_vars",
 	"t.id",
 	"t.name",
 	"rs.check_error",
@@ -98,21 +105,24 @@ var resourcesQuery = psql.Select(
 type resource struct {
 	pipelineRef
 
-	id                    int
-	name                  string
-	teamID                int
-	teamName              string
-	type_                 string
-	lastCheckStartTime    time.Time
-	lastCheckEndTime      time.Time
-	checkSetupError       error
-	checkError            error
-	config                atc.ResourceConfig
-	configPinnedVersion   atc.Version
-	apiPinnedVersion      atc.Version
-	pinComment            string
-	resourceConfigID      int
-	resourceConfigScopeID int
+	id                       int
+	name                     string
+	teamID                   int
+	teamName                 string
+	type_                    string
+	lastCheckStartTime       time.Time
+	lastCheckEndTime         time.Time
+	lastCheckRateLimitDelay  time.Duration
+	consecutiveCheckFailures int
+	idleSince                time.Time
+	checkSetupError          error
+	checkError               error
+	config                   atc.ResourceConfig
+	configPinnedVersion      atc.Version
+	apiPinnedVersion         atc.Version
+	pinComment               string
+	resourceConfigID         int
+	resourceConfigScopeID    int
 }
 
 func newEmptyResource(conn Conn, lockFactory lock.LockFactory) *resource {
@@ -147,28 +157,31 @@ func (resources Resources) Configs() atc.ResourceConfigs {
 	return configs
 }
 
-func (r *resource) ID() int                          { return r.id }
-func (r *resource) Name() string                     { return r.name }
-func (r *resource) Public() bool                     { return r.config.Public }
-func (r *resource) TeamID() int                      { return r.teamID }
-func (r *resource) TeamName() string                 { return r.teamName }
-func (r *resource) Type() string                     { return r.type_ }
-func (r *resource) Source() atc.Source               { return r.config.Source }
-func (r *resource) CheckEvery() string               { return r.config.CheckEvery }
-func (r *resource) CheckTimeout() string             { return r.config.CheckTimeout }
-func (r *resource) LastCheckStartTime() time.Time    { return r.lastCheckStartTime }
-func (r *resource) LastCheckEndTime() time.Time      { return r.lastCheckEndTime }
-func (r *resource) Tags() atc.Tags                   { return r.config.Tags }
-func (r *resource) CheckSetupError() error           { return r.checkSetupError }
-func (r *resource) CheckError() error                { return r.checkError }
-func (r *resource) WebhookToken() string             { return r.config.WebhookToken }
-func (r *resource) Config() atc.ResourceConfig       { return r.config }
-func (r *resource) ConfigPinnedVersion() atc.Version { return r.configPinnedVersion }
-func (r *resource) APIPinnedVersion() atc.Version    { return r.apiPinnedVersion }
-func (r *resource) PinComment() string               { return r.pinComment }
-func (r *resource) ResourceConfigID() int            { return r.resourceConfigID }
-func (r *resource) ResourceConfigScopeID() int       { return r.resourceConfigScopeID }
-func (r *resource) Icon() string                     { return r.config.Icon }
+func (r *resource) ID() int                                { return r.id }
+func (r *resource) Name() string                           { return r.name }
+func (r *resource) Public() bool                           { return r.config.Public }
+func (r *resource) TeamID() int                            { return r.teamID }
+func (r *resource) TeamName() string                       { return r.teamName }
+func (r *resource) Type() string                           { return r.type_ }
+func (r *resource) Source() atc.Source                     { return r.config.Source }
+func (r *resource) CheckEvery() string                     { return r.config.CheckEvery }
+func (r *resource) CheckTimeout() string                   { return r.config.CheckTimeout }
+func (r *resource) LastCheckStartTime() time.Time          { return r.lastCheckStartTime }
+func (r *resource) LastCheckEndTime() time.Time            { return r.lastCheckEndTime }
+func (r *resource) LastCheckRateLimitDelay() time.Duration { return r.lastCheckRateLimitDelay }
+func (r *resource) ConsecutiveCheckFailures() int          { return r.consecutiveCheckFailures }
+func (r *resource) IdleSince() time.Time                   { return r.idleSince }
+func (r *resource) Tags() atc.Tags                         { return r.config.Tags }
+func (r *resource) CheckSetupError() error                 { return r.checkSetupError }
+func (r *resource) CheckError() error                      { return r.checkError }
+func (r *resource) WebhookToken() string                   { return r.config.WebhookToken }
+func (r *resource) Config() atc.ResourceConfig             { return r.config }
+func (r *resource) ConfigPinnedVersion() atc.Version       { return r.configPinnedVersion }
+func (r *resource) APIPinnedVersion() atc.Version          { return r.apiPinnedVersion }
+func (r *resource) PinComment() string                     { return r.pinComment }
+func (r *resource) ResourceConfigID() int                  { return r.resourceConfigID }
+func (r *resource) ResourceConfigScopeID() int             { return r.resourceConfigScopeID }
+func (r *resource) Icon() string                           { return r.config.Icon }
 
 func (r *resource) HasWebhook() bool { return r.WebhookToken() != "" }
 
@@ -721,17 +734,28 @@ func scanResource(r *resource, row scannable) error {
 	var (
 		configBlob                                                               sql.NullString
 		checkErr, rcsCheckErr, nonce, rcID, rcScopeID, pinnedVersion, pinComment sql.NullString
-		lastCheckStartTime, lastCheckEndTime                                     pq.NullTime
+		instanceVars                                                             sql.NullString
+		lastCheckStartTime, lastCheckEndTime, idleSince                          pq.NullTime
 		pinnedThroughConfig                                                      sql.NullBool
+		consecutiveCheckFailures, rateLimitDelay                                 sql.NullInt64
 	)
 
-	err := row.Scan(&r.id, &r.name, &r.type_, &configBlob, &checkErr, &lastCheckStartTime, &lastCheckEndTime, &r.pipelineID, &nonce, &rcID, &rcScopeID, &r.pipelineName, &r.teamID, &r.teamName, &rcsCheckErr, &pinnedVersion, &pinComment, &pinnedThroughConfig)
+	err := row.Scan(&r.id, &r.name, &r.type_, &configBlob, &checkErr, &lastCheckStartTime, &lastCheckEndTime, &rateLimitDelay, &consecutiveCheckFailures, &idleSince, &r.pipelineID, &nonce, &rcID, &rcScopeID, &r.pipelineName, &instanceVars, &r.teamID, &r.teamName, &rcsCheckErr, &pinnedVersion, &pinComment, &pinnedThroughConfig)
 	if err != nil {
 		return err
 	}
 
 	r.lastCheckStartTime = lastCheckStartTime.Time
 	r.lastCheckEndTime = lastCheckEndTime.Time
+	r.consecutiveCheckFailures = int(consecutiveCheckFailures.Int64)
+	r.idleSince = idleSince.Time
+
+	r.lastCheckRateLimitDelay = time.Duration(rateLimitDelay.Int64)
+
+	err = r.scanInstanceVars(instanceVars)
+	if err != nil {
+		return err
+	}
 
 	es := r.conn.EncryptionStrategy()
 
@@ -107,6 +107,15 @@ func saveVersions(conn Conn, rcsID int, versions []atc.Version, spanContext Span
 		if err != nil {
 			return err
 		}
+
+		_, err = psql.Update("resource_config_scopes").
+			Set("idle_since", sq.Expr("now()")).
+			Where(sq.Eq{"id": rcsID}).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
 	}
 
 	err = tx.Commit()
@@ -27,7 +27,7 @@ var _ = Describe("Resource Config Scope", func() {
 		Expect(err).NotTo(HaveOccurred())
 		Expect(setupTx.Commit()).To(Succeed())
 
-		pipeline, _, err = defaultTeam.SavePipeline("scope-pipeline", atc.Config{
+		pipeline, _, err = defaultTeam.SavePipeline(atc.PipelineRef{Name: "scope-pipeline"}, atc.Config{
 			Resources: atc.ResourceConfigs{
 				{
 					Name: "some-resource",
@@ -148,6 +148,24 @@ var _ = Describe("Resource Config Scope", func() {
 			})
 
 			Context("when a new version is added", func() {
+				It("resets how long the resource has been idle for", func() {
+					_, err := resource.Reload()
+					Expect(err).ToNot(HaveOccurred())
+
+					idleSince := resource.IdleSince()
+
+					err = resourceScope.SaveVersions(nil, []atc.Version{
+						{"ref": "v0"},
+						{"ref": "v3"},
+					})
+					Expect(err).ToNot(HaveOccurred())
+
+					_, err = resource.Reload()
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(resource.IdleSince()).To(BeTemporally(">", idleSince))
+				})
+
 				It("requests schedule on the jobs that use the resource", func() {
 					err := resourceScope.SaveVersions(nil, originalVersionSlice)
 					Expect(err).ToNot(HaveOccurred())
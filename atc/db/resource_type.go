@@ -40,6 +40,8 @@ type ResourceType interface {
 	CheckTimeout() string
 	LastCheckStartTime() time.Time
 	LastCheckEndTime() time.Time
+	ConsecutiveCheckFailures() int
+	IdleSince() time.Time
 	CheckSetupError() error
 	CheckError() error
 	UniqueVersionHistory() bool
@@ -134,12 +136,15 @@ var resourceTypesQuery = psql.Select(
 	"r.nonce",
 	"r.check_error",
 	"p.name",
+	"p.instance_vars",
 	"t.id",
 	"t.name",
 	"ro.id",
 	"ro.check_error",
 	"ro.last_check_start_time",
 	"ro.last_check_end_time",
+	"ro.consecutive_check_failures",
+	"ro.idle_since",
 ).
 	From("resource_types r").
 	Join("pipelines p ON p.id = r.pipeline_id").
@@ -158,23 +163,25 @@ var resourceTypesQuery = psql.Select(
 type resourceType struct {
 	pipelineRef
 
-	id                    int
-	teamID                int
-	resourceConfigScopeID int
-	teamName              string
-	name                  string
-	type_                 string
-	privileged            bool
-	source                atc.Source
-	params                atc.Params
-	tags                  atc.Tags
-	version               atc.Version
-	checkEvery            string
-	lastCheckStartTime    time.Time
-	lastCheckEndTime      time.Time
-	checkSetupError       error
-	checkError            error
-	uniqueVersionHistory  bool
+	id                       int
+	teamID                   int
+	resourceConfigScopeID    int
+	teamName                 string
+	name                     string
+	type_                    string
+	privileged               bool
+	source                   atc.Source
+	params                   atc.Params
+	tags                     atc.Tags
+	version                  atc.Version
+	checkEvery               string
+	lastCheckStartTime       time.Time
+	lastCheckEndTime         time.Time
+	consecutiveCheckFailures int
+	idleSince                time.Time
+	checkSetupError          error
+	checkError               error
+	uniqueVersionHistory     bool
 }
 
 func (t *resourceType) ID() int                       { return t.id }
@@ -187,6 +194,8 @@ func (t *resourceType) CheckEvery() string            { return t.checkEvery }
 func (t *resourceType) CheckTimeout() string          { return "" }
 func (r *resourceType) LastCheckStartTime() time.Time { return r.lastCheckStartTime }
 func (r *resourceType) LastCheckEndTime() time.Time   { return r.lastCheckEndTime }
+func (t *resourceType) ConsecutiveCheckFailures() int { return t.consecutiveCheckFailures }
+func (t *resourceType) IdleSince() time.Time          { return t.idleSince }
 func (t *resourceType) Source() atc.Source            { return t.source }
 func (t *resourceType) Params() atc.Params            { return t.params }
 func (t *resourceType) Tags() atc.Tags                { return t.tags }
@@ -285,18 +294,27 @@ func (t *resourceType) SetCheckSetupError(cause error) error {
 
 func scanResourceType(t *resourceType, row scannable) error {
 	var (
-		configJSON                                   sql.NullString
-		checkErr, rcsCheckErr, rcsID, version, nonce sql.NullString
-		lastCheckStartTime, lastCheckEndTime         pq.NullTime
+		configJSON                                      sql.NullString
+		checkErr, rcsCheckErr, rcsID, version, nonce    sql.NullString
+		instanceVars                                    sql.NullString
+		lastCheckStartTime, lastCheckEndTime, idleSince pq.NullTime
+		consecutiveCheckFailures                        sql.NullInt64
 	)
 
-	err := row.Scan(&t.id, &t.pipelineID, &t.name, &t.type_, &configJSON, &version, &nonce, &checkErr, &t.pipelineName, &t.teamID, &t.teamName, &rcsID, &rcsCheckErr, &lastCheckStartTime, &lastCheckEndTime)
+	err := row.Scan(&t.id, &t.pipelineID, &t.name, &t.type_, &configJSON, &version, &nonce, &checkErr, &t.pipelineName, &instanceVars, &t.teamID, &t.teamName, &rcsID, &rcsCheckErr, &lastCheckStartTime, &lastCheckEndTime, &consecutiveCheckFailures, &idleSince)
 	if err != nil {
 		return err
 	}
 
 	t.lastCheckStartTime = lastCheckStartTime.Time
 	t.lastCheckEndTime = lastCheckEndTime.Time
+	t.consecutiveCheckFailures = int(consecutiveCheckFailures.Int64)
+	t.idleSince = idleSince.Time
+
+	err = t.scanInstanceVars(instanceVars)
+	if err != nil {
+		return err
+	}
 
 	if version.Valid {
 		err = json.Unmarshal([]byte(version.String), &t.version)
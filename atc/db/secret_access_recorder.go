@@ -0,0 +1,35 @@
+package db
+
+import (
+	"database/sql"
+
+	"github.com/concourse/concourse/atc"
+)
+
+//go:generate counterfeiter . SecretAccessRecorder
+
+type SecretAccessRecorder interface {
+	RecordSecretAccess(buildID int, access atc.SecretAccess) error
+}
+
+func NewSecretAccessRecorder(conn Conn) SecretAccessRecorder {
+	return &secretAccessRecorder{conn}
+}
+
+type secretAccessRecorder struct {
+	conn Conn
+}
+
+func (r *secretAccessRecorder) RecordSecretAccess(buildID int, access atc.SecretAccess) error {
+	var varSource sql.NullString
+	if access.VarSource != "" {
+		varSource = sql.NullString{String: access.VarSource, Valid: true}
+	}
+
+	_, err := psql.Insert("build_secret_accesses").
+		Columns("build_id", "path", "manager", "var_source", "cache_hit", "found").
+		Values(buildID, access.Path, access.Manager, varSource, access.CacheHit, access.Found).
+		RunWith(r.conn).
+		Exec()
+	return err
+}
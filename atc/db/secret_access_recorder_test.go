@@ -0,0 +1,80 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecretAccessRecorder", func() {
+	var (
+		recorder db.SecretAccessRecorder
+
+		build      db.Build
+		otherBuild db.Build
+	)
+
+	BeforeEach(func() {
+		recorder = db.NewSecretAccessRecorder(dbConn)
+
+		var err error
+		build, err = defaultTeam.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		otherBuild, err = defaultTeam.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("records the secret accesses per build, in order", func() {
+		Expect(recorder.RecordSecretAccess(build.ID(), atc.SecretAccess{
+			Path:    "/concourse/main/foo",
+			Manager: "vault",
+			Found:   true,
+		})).To(Succeed())
+
+		Expect(recorder.RecordSecretAccess(build.ID(), atc.SecretAccess{
+			Path:      "/some-pipeline/bar",
+			Manager:   "ssm",
+			VarSource: "some-ssm",
+			CacheHit:  true,
+		})).To(Succeed())
+
+		Expect(recorder.RecordSecretAccess(otherBuild.ID(), atc.SecretAccess{
+			Path:    "/concourse/main/baz",
+			Manager: "vault",
+		})).To(Succeed())
+
+		accesses, err := build.SecretAccesses()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(accesses).To(HaveLen(2))
+
+		Expect(accesses[0].Path).To(Equal("/concourse/main/foo"))
+		Expect(accesses[0].Manager).To(Equal("vault"))
+		Expect(accesses[0].VarSource).To(BeEmpty())
+		Expect(accesses[0].Found).To(BeTrue())
+		Expect(accesses[0].CacheHit).To(BeFalse())
+		Expect(accesses[0].AccessedAt).ToNot(BeZero())
+
+		Expect(accesses[1].Path).To(Equal("/some-pipeline/bar"))
+		Expect(accesses[1].Manager).To(Equal("ssm"))
+		Expect(accesses[1].VarSource).To(Equal("some-ssm"))
+		Expect(accesses[1].Found).To(BeFalse())
+		Expect(accesses[1].CacheHit).To(BeTrue())
+	})
+
+	It("forgets the secret accesses of deleted builds", func() {
+		Expect(recorder.RecordSecretAccess(build.ID(), atc.SecretAccess{
+			Path:    "/concourse/main/foo",
+			Manager: "vault",
+		})).To(Succeed())
+
+		_, err := build.Delete()
+		Expect(err).ToNot(HaveOccurred())
+
+		accesses, err := build.SecretAccesses()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(accesses).To(BeEmpty())
+	})
+})
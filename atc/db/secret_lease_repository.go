@@ -0,0 +1,118 @@
+package db
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+//go:generate counterfeiter . SecretLeaseRepository
+
+type SecretLeaseRepository interface {
+	CreateLease(buildID int, leaseID string) error
+	BuildLeases(buildID int) ([]string, error)
+	DeleteLease(leaseID string) error
+	UnownedLeases(createdBefore time.Time) ([]string, error)
+
+	OrphanedLeaseBuilds() ([]int, error)
+}
+
+func NewSecretLeaseRepository(conn Conn) SecretLeaseRepository {
+	return &secretLeaseRepository{conn}
+}
+
+type secretLeaseRepository struct {
+	conn Conn
+}
+
+func (r *secretLeaseRepository) CreateLease(buildID int, leaseID string) error {
+	_, err := psql.Insert("build_secret_leases").
+		Columns("lease_id", "build_id").
+		Values(leaseID, buildID).
+		Suffix("ON CONFLICT (lease_id) DO NOTHING").
+		RunWith(r.conn).
+		Exec()
+	return err
+}
+
+func (r *secretLeaseRepository) BuildLeases(buildID int) ([]string, error) {
+	return r.leases(sq.Eq{"build_id": buildID})
+}
+
+// UnownedLeases returns the leases acquired outside of a build, which are
+// recorded as held by build 0, before the given time.
+func (r *secretLeaseRepository) UnownedLeases(createdBefore time.Time) ([]string, error) {
+	return r.leases(sq.And{
+		sq.Eq{"build_id": 0},
+		sq.Lt{"created_at": createdBefore},
+	})
+}
+
+func (r *secretLeaseRepository) leases(where sq.Sqlizer) ([]string, error) {
+	rows, err := psql.Select("lease_id").
+		From("build_secret_leases").
+		Where(where).
+		OrderBy("created_at").
+		RunWith(r.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var leaseIDs []string
+	for rows.Next() {
+		var leaseID string
+		err = rows.Scan(&leaseID)
+		if err != nil {
+			return nil, err
+		}
+
+		leaseIDs = append(leaseIDs, leaseID)
+	}
+
+	return leaseIDs, nil
+}
+
+func (r *secretLeaseRepository) DeleteLease(leaseID string) error {
+	_, err := psql.Delete("build_secret_leases").
+		Where(sq.Eq{"lease_id": leaseID}).
+		RunWith(r.conn).
+		Exec()
+	return err
+}
+
+// OrphanedLeaseBuilds returns the builds which still hold leases even though
+// they are no longer running, e.g. because the ATC running them went away
+// before it could revoke them. Build 0 is returned whenever there are unowned
+// leases.
+func (r *secretLeaseRepository) OrphanedLeaseBuilds() ([]int, error) {
+	rows, err := psql.Select("DISTINCT l.build_id").
+		From("build_secret_leases l").
+		LeftJoin("builds b ON b.id = l.build_id").
+		Where(sq.Or{
+			sq.Eq{"b.id": nil},
+			sq.Eq{"b.completed": true},
+		}).
+		RunWith(r.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var buildIDs []int
+	for rows.Next() {
+		var buildID int
+		err = rows.Scan(&buildID)
+		if err != nil {
+			return nil, err
+		}
+
+		buildIDs = append(buildIDs, buildID)
+	}
+
+	return buildIDs, nil
+}
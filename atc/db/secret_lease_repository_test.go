@@ -0,0 +1,100 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecretLeaseRepository", func() {
+	var (
+		repository db.SecretLeaseRepository
+
+		runningBuild  db.Build
+		finishedBuild db.Build
+	)
+
+	BeforeEach(func() {
+		repository = db.NewSecretLeaseRepository(dbConn)
+
+		var err error
+		runningBuild, err = defaultTeam.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		finishedBuild, err = defaultTeam.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		err = finishedBuild.Finish(db.BuildStatusSucceeded)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("CreateLease", func() {
+		It("stores the leases per build", func() {
+			Expect(repository.CreateLease(runningBuild.ID(), "lease-1")).To(Succeed())
+			Expect(repository.CreateLease(runningBuild.ID(), "lease-2")).To(Succeed())
+			Expect(repository.CreateLease(finishedBuild.ID(), "lease-3")).To(Succeed())
+
+			leaseIDs, err := repository.BuildLeases(runningBuild.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(leaseIDs).To(ConsistOf("lease-1", "lease-2"))
+		})
+
+		It("ignores leases which are already stored", func() {
+			Expect(repository.CreateLease(runningBuild.ID(), "lease-1")).To(Succeed())
+			Expect(repository.CreateLease(runningBuild.ID(), "lease-1")).To(Succeed())
+
+			leaseIDs, err := repository.BuildLeases(runningBuild.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(leaseIDs).To(ConsistOf("lease-1"))
+		})
+	})
+
+	Describe("DeleteLease", func() {
+		It("forgets the lease", func() {
+			Expect(repository.CreateLease(runningBuild.ID(), "lease-1")).To(Succeed())
+			Expect(repository.DeleteLease("lease-1")).To(Succeed())
+
+			leaseIDs, err := repository.BuildLeases(runningBuild.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(leaseIDs).To(BeEmpty())
+		})
+	})
+
+	Describe("UnownedLeases", func() {
+		BeforeEach(func() {
+			Expect(repository.CreateLease(0, "lease-1")).To(Succeed())
+			Expect(repository.CreateLease(0, "lease-2")).To(Succeed())
+			Expect(repository.CreateLease(runningBuild.ID(), "lease-3")).To(Succeed())
+		})
+
+		It("returns the leases acquired outside of a build before the given time", func() {
+			leaseIDs, err := repository.UnownedLeases(time.Now().Add(time.Minute))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(leaseIDs).To(ConsistOf("lease-1", "lease-2"))
+		})
+
+		It("does not return the leases acquired since", func() {
+			leaseIDs, err := repository.UnownedLeases(time.Now().Add(-time.Hour))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(leaseIDs).To(BeEmpty())
+		})
+	})
+
+	Describe("OrphanedLeaseBuilds", func() {
+		BeforeEach(func() {
+			Expect(repository.CreateLease(runningBuild.ID(), "lease-1")).To(Succeed())
+			Expect(repository.CreateLease(finishedBuild.ID(), "lease-2")).To(Succeed())
+			Expect(repository.CreateLease(finishedBuild.ID(), "lease-3")).To(Succeed())
+			Expect(repository.CreateLease(123456, "lease-4")).To(Succeed())
+		})
+
+		It("returns the finished and deleted builds still holding leases", func() {
+			buildIDs, err := repository.OrphanedLeaseBuilds()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buildIDs).To(ConsistOf(finishedBuild.ID(), 123456))
+		})
+	})
+})
@@ -28,18 +28,20 @@ type Team interface {
 	Admin() bool
 
 	Auth() atc.TeamAuth
+	DefaultBuildPriority() int
+	Quotas() atc.TeamQuotas
 
 	Delete() error
 	Rename(string) error
 
 	SavePipeline(
-		pipelineName string,
+		pipelineRef atc.PipelineRef,
 		config atc.Config,
 		from ConfigVersion,
 		initiallyPaused bool,
 	) (Pipeline, bool, error)
 
-	Pipeline(pipelineName string) (Pipeline, bool, error)
+	Pipeline(pipelineRef atc.PipelineRef) (Pipeline, bool, error)
 	Pipelines() ([]Pipeline, error)
 	PublicPipelines() ([]Pipeline, error)
 	OrderPipelines([]string) error
@@ -60,13 +62,25 @@ type Team interface {
 	IsContainerWithinTeam(string, bool) (bool, error)
 
 	FindContainerByHandle(string) (Container, bool, error)
-	FindCheckContainers(lager.Logger, string, string, creds.Secrets, creds.VarSourcePool) ([]Container, map[int]time.Time, error)
+	FindCheckContainers(lager.Logger, atc.PipelineRef, string, creds.Secrets, creds.VarSourcePool) ([]Container, map[int]time.Time, error)
 	FindContainersByMetadata(ContainerMetadata) ([]Container, error)
 	FindCreatedContainerByHandle(string) (CreatedContainer, bool, error)
 	FindWorkerForContainer(handle string) (Worker, bool, error)
 	FindWorkerForVolume(handle string) (Worker, bool, error)
 
 	UpdateProviderAuth(auth atc.TeamAuth) error
+	UpdateDefaultBuildPriority(priority int) error
+	UpdateQuotas(quotas atc.TeamQuotas) error
+	UpdateSettings(auth atc.TeamAuth, defaultBuildPriority *int, quotas *atc.TeamQuotas) error
+
+	QuotaUsage() (TeamQuotaUsage, error)
+}
+
+// TeamQuotaUsage is a team's quotas along with how much of them the team is
+// currently using.
+type TeamQuotaUsage struct {
+	Quotas           atc.TeamQuotas
+	ActiveContainers int
 }
 
 type team struct {
@@ -78,6 +92,9 @@ type team struct {
 	admin bool
 
 	auth atc.TeamAuth
+
+	defaultBuildPriority int
+	quotas               atc.TeamQuotas
 }
 
 func (t *team) ID() int      { return t.id }
@@ -86,6 +103,10 @@ func (t *team) Admin() bool  { return t.admin }
 
 func (t *team) Auth() atc.TeamAuth { return t.auth }
 
+func (t *team) DefaultBuildPriority() int { return t.defaultBuildPriority }
+
+func (t *team) Quotas() atc.TeamQuotas { return t.quotas }
+
 func (t *team) Delete() error {
 	_, err := psql.Delete("teams").
 		Where(sq.Eq{
@@ -341,7 +362,7 @@ func (t *team) FindCreatedContainerByHandle(
 
 func savePipeline(
 	tx Tx,
-	pipelineName string,
+	pipelineRef atc.PipelineRef,
 	config atc.Config,
 	from ConfigVersion,
 	initiallyPaused bool,
@@ -349,17 +370,33 @@ func savePipeline(
 	jobID sql.NullInt64,
 	buildID sql.NullInt64,
 ) (int, bool, error) {
+	pipelineCond, err := pipelineRefCondition(pipelineRef, "pipelines")
+	if err != nil {
+		return 0, false, err
+	}
+
 	var existingConfig bool
-	err := tx.QueryRow(`SELECT EXISTS (
-		SELECT 1
-		FROM pipelines
-		WHERE name = $1
-		AND team_id = $2
-	)`, pipelineName, teamID).Scan(&existingConfig)
+	err = psql.Select("1").
+		Prefix("SELECT EXISTS (").
+		From("pipelines").
+		Where(pipelineCond).
+		Where(sq.Eq{"team_id": teamID}).
+		Suffix(")").
+		RunWith(tx).
+		QueryRow().
+		Scan(&existingConfig)
 	if err != nil {
 		return 0, false, err
 	}
 
+	var instanceVarsPayload []byte
+	if len(pipelineRef.InstanceVars) != 0 {
+		instanceVarsPayload, err = json.Marshal(pipelineRef.InstanceVars)
+		if err != nil {
+			return 0, false, err
+		}
+	}
+
 	groupsPayload, err := json.Marshal(config.Groups)
 	if err != nil {
 		return 0, false, err
@@ -384,13 +421,19 @@ func savePipeline(
 	if !existingConfig {
 		err = psql.Insert("pipelines").
 			SetMap(map[string]interface{}{
-				"name":            pipelineName,
-				"groups":          groupsPayload,
-				"var_sources":     encryptedVarSourcesPayload,
-				"display":         displayPayload,
-				"nonce":           nonce,
-				"version":         sq.Expr("nextval('config_version_seq')"),
-				"ordering":        sq.Expr("currval('pipelines_id_seq')"),
+				"name":          pipelineRef.Name,
+				"instance_vars": instanceVarsPayload,
+				"groups":        groupsPayload,
+				"var_sources":   encryptedVarSourcesPayload,
+				"display":       displayPayload,
+				"nonce":         nonce,
+				"version":       sq.Expr("nextval('config_version_seq')"),
+				// instances of the same pipeline share an ordering so that
+				// they are listed together
+				"ordering": sq.Expr(`COALESCE(
+					(SELECT MAX(ordering) FROM pipelines WHERE name = ? AND team_id = ?),
+					currval('pipelines_id_seq')
+				)`, pipelineRef.Name, teamID),
 				"paused":          initiallyPaused,
 				"last_updated":    sq.Expr("now()"),
 				"team_id":         teamID,
@@ -415,8 +458,8 @@ func savePipeline(
 			Set("last_updated", sq.Expr("now()")).
 			Set("parent_job_id", jobID).
 			Set("parent_build_id", buildID).
+			Where(pipelineCond).
 			Where(sq.Eq{
-				"name":    pipelineName,
 				"version": from,
 				"team_id": teamID,
 			})
@@ -432,12 +475,12 @@ func savePipeline(
 		if err != nil {
 			if err == sql.ErrNoRows {
 				var currentParentBuildID sql.NullInt64
-				err = tx.QueryRow(`
-					SELECT parent_build_id
-					FROM pipelines
-					WHERE name = $1
-					AND team_id = $2 `,
-					pipelineName, teamID).
+				err = psql.Select("parent_build_id").
+					From("pipelines").
+					Where(pipelineCond).
+					Where(sq.Eq{"team_id": teamID}).
+					RunWith(tx).
+					QueryRow().
 					Scan(&currentParentBuildID)
 				if err != nil {
 					return 0, false, err
@@ -513,7 +556,7 @@ func savePipeline(
 }
 
 func (t *team) SavePipeline(
-	pipelineName string,
+	pipelineRef atc.PipelineRef,
 	config atc.Config,
 	from ConfigVersion,
 	initiallyPaused bool,
@@ -526,7 +569,7 @@ func (t *team) SavePipeline(
 	defer Rollback(tx)
 
 	nullID := sql.NullInt64{Valid: false}
-	pipelineID, isNewPipeline, err := savePipeline(tx, pipelineName, config, from, initiallyPaused, t.id, nullID, nullID)
+	pipelineID, isNewPipeline, err := savePipeline(tx, pipelineRef, config, from, initiallyPaused, t.id, nullID, nullID)
 	if err != nil {
 		return nil, false, err
 	}
@@ -552,16 +595,19 @@ func (t *team) SavePipeline(
 	return pipeline, isNewPipeline, nil
 }
 
-func (t *team) Pipeline(pipelineName string) (Pipeline, bool, error) {
+func (t *team) Pipeline(pipelineRef atc.PipelineRef) (Pipeline, bool, error) {
+	pipelineCond, err := pipelineRefCondition(pipelineRef, "p")
+	if err != nil {
+		return nil, false, err
+	}
+
 	pipeline := newPipeline(t.conn, t.lockFactory)
 
-	err := scanPipeline(
+	err = scanPipeline(
 		pipeline,
 		pipelinesQuery.
-			Where(sq.Eq{
-				"p.team_id": t.id,
-				"p.name":    pipelineName,
-			}).
+			Where(sq.Eq{"p.team_id": t.id}).
+			Where(pipelineCond).
 			RunWith(t.conn).
 			QueryRow(),
 	)
@@ -580,7 +626,7 @@ func (t *team) Pipelines() ([]Pipeline, error) {
 		Where(sq.Eq{
 			"team_id": t.id,
 		}).
-		OrderBy("ordering").
+		OrderBy("ordering", "p.id").
 		RunWith(t.conn).
 		Query()
 	if err != nil {
@@ -601,7 +647,7 @@ func (t *team) PublicPipelines() ([]Pipeline, error) {
 			"team_id": t.id,
 			"public":  true,
 		}).
-		OrderBy("t.name ASC", "ordering ASC").
+		OrderBy("t.name ASC", "ordering ASC", "p.id ASC").
 		RunWith(t.conn).
 		Query()
 	if err != nil {
@@ -784,7 +830,7 @@ func (t *team) UpdateProviderAuth(auth atc.TeamAuth) error {
 		UPDATE teams
 		SET auth = $1, legacy_auth = NULL, nonce = NULL
 		WHERE id = $2
-		RETURNING id, name, admin, auth, nonce
+		RETURNING id, name, admin, auth, nonce, default_build_priority, quotas
 	`
 	err = t.queryTeam(tx, query, jsonEncodedProviderAuth, t.id)
 	if err != nil {
@@ -794,8 +840,151 @@ func (t *team) UpdateProviderAuth(auth atc.TeamAuth) error {
 	return tx.Commit()
 }
 
-func (t *team) FindCheckContainers(logger lager.Logger, pipelineName string, resourceName string, secretManager creds.Secrets, varSourcePool creds.VarSourcePool) ([]Container, map[int]time.Time, error) {
-	pipeline, found, err := t.Pipeline(pipelineName)
+func (t *team) UpdateDefaultBuildPriority(priority int) error {
+	result, err := psql.Update("teams").
+		Set("default_build_priority", priority).
+		Where(sq.Eq{"id": t.id}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return NonOneRowAffectedError{rowsAffected}
+	}
+
+	t.defaultBuildPriority = priority
+
+	return nil
+}
+
+func (t *team) UpdateQuotas(quotas atc.TeamQuotas) error {
+	encodedQuotas, err := encodeTeamQuotas(quotas)
+	if err != nil {
+		return err
+	}
+
+	result, err := psql.Update("teams").
+		Set("quotas", encodedQuotas).
+		Where(sq.Eq{"id": t.id}).
+		RunWith(t.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected != 1 {
+		return NonOneRowAffectedError{rowsAffected}
+	}
+
+	t.quotas = quotas
+
+	return nil
+}
+
+// UpdateSettings replaces the team's auth and, when given, its default build
+// priority and quotas in a single transaction. A nil priority or quotas
+// leaves the current value in place.
+func (t *team) UpdateSettings(auth atc.TeamAuth, defaultBuildPriority *int, quotas *atc.TeamQuotas) error {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer Rollback(tx)
+
+	jsonEncodedProviderAuth, err := json.Marshal(auth)
+	if err != nil {
+		return err
+	}
+
+	update := psql.Update("teams").
+		Set("auth", jsonEncodedProviderAuth).
+		Set("legacy_auth", nil).
+		Set("nonce", nil).
+		Where(sq.Eq{"id": t.id}).
+		Suffix("RETURNING id, name, admin, auth, nonce, default_build_priority, quotas")
+
+	if defaultBuildPriority != nil {
+		update = update.Set("default_build_priority", *defaultBuildPriority)
+	}
+
+	if quotas != nil {
+		encodedQuotas, err := encodeTeamQuotas(*quotas)
+		if err != nil {
+			return err
+		}
+
+		update = update.Set("quotas", encodedQuotas)
+	}
+
+	query, args, err := update.ToSql()
+	if err != nil {
+		return err
+	}
+
+	err = t.queryTeam(tx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func encodeTeamQuotas(quotas atc.TeamQuotas) (interface{}, error) {
+	if quotas == (atc.TeamQuotas{}) {
+		return nil, nil
+	}
+
+	return json.Marshal(quotas)
+}
+
+func (t *team) QuotaUsage() (TeamQuotaUsage, error) {
+	var (
+		usage  TeamQuotaUsage
+		quotas sql.NullString
+	)
+
+	err := psql.Select("t.quotas").
+		Column(`(
+			SELECT COUNT(*)
+			FROM containers c
+			JOIN builds b ON b.id = c.build_id
+			WHERE c.team_id = t.id
+			AND b.completed = false
+			AND c.state IN (?, ?)
+		)`, atc.ContainerStateCreating, atc.ContainerStateCreated).
+		From("teams t").
+		Where(sq.Eq{"t.id": t.id}).
+		RunWith(t.conn).
+		QueryRow().
+		Scan(&quotas, &usage.ActiveContainers)
+	if err != nil {
+		return TeamQuotaUsage{}, err
+	}
+
+	if quotas.Valid {
+		err = json.Unmarshal([]byte(quotas.String), &usage.Quotas)
+		if err != nil {
+			return TeamQuotaUsage{}, err
+		}
+	}
+
+	return usage, nil
+}
+
+func (t *team) FindCheckContainers(logger lager.Logger, pipelineRef atc.PipelineRef, resourceName string, secretManager creds.Secrets, varSourcePool creds.VarSourcePool) ([]Container, map[int]time.Time, error) {
+	pipeline, found, err := t.Pipeline(pipelineRef)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -1226,6 +1415,7 @@ func (t *team) findContainer(whereClause sq.Sqlizer) (CreatingContainer, Created
 
 func scanPipeline(p *pipeline, scan scannable) error {
 	var (
+		instanceVars  sql.NullString
 		groups        sql.NullString
 		varSources    sql.NullString
 		display       sql.NullString
@@ -1235,7 +1425,7 @@ func scanPipeline(p *pipeline, scan scannable) error {
 		parentJobID   sql.NullInt64
 		parentBuildID sql.NullInt64
 	)
-	err := scan.Scan(&p.id, &p.name, &groups, &varSources, &display, &nonce, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public, &p.archived, &lastUpdated, &parentJobID, &parentBuildID)
+	err := scan.Scan(&p.id, &p.name, &instanceVars, &groups, &varSources, &display, &nonce, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public, &p.archived, &lastUpdated, &parentJobID, &parentBuildID)
 	if err != nil {
 		return err
 	}
@@ -1244,6 +1434,14 @@ func scanPipeline(p *pipeline, scan scannable) error {
 	p.parentJobID = int(parentJobID.Int64)
 	p.parentBuildID = int(parentBuildID.Int64)
 
+	p.instanceVars = nil
+	if instanceVars.Valid {
+		err = json.Unmarshal([]byte(instanceVars.String), &p.instanceVars)
+		if err != nil {
+			return err
+		}
+	}
+
 	if groups.Valid {
 		var pipelineGroups atc.GroupConfigs
 		err = json.Unmarshal([]byte(groups.String), &pipelineGroups)
@@ -1332,7 +1530,7 @@ func scanContainers(rows *sql.Rows, conn Conn, initContainers []Container) ([]Co
 }
 
 func (t *team) queryTeam(tx Tx, query string, params ...interface{}) error {
-	var providerAuth, nonce sql.NullString
+	var providerAuth, nonce, quotas sql.NullString
 
 	err := tx.QueryRow(query, params...).Scan(
 		&t.id,
@@ -1340,6 +1538,8 @@ func (t *team) queryTeam(tx Tx, query string, params ...interface{}) error {
 		&t.admin,
 		&providerAuth,
 		&nonce,
+		&t.defaultBuildPriority,
+		&quotas,
 	)
 	if err != nil {
 		return err
@@ -1354,6 +1554,14 @@ func (t *team) queryTeam(tx Tx, query string, params ...interface{}) error {
 		t.auth = auth
 	}
 
+	t.quotas = atc.TeamQuotas{}
+	if quotas.Valid {
+		err = json.Unmarshal([]byte(quotas.String), &t.quotas)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
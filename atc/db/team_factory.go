@@ -52,10 +52,23 @@ func (factory *teamFactory) createTeam(t atc.Team, admin bool) (Team, error) {
 		return nil, err
 	}
 
+	var quotas interface{}
+	if t.Quotas != nil {
+		quotas, err = encodeTeamQuotas(*t.Quotas)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var defaultBuildPriority int
+	if t.DefaultBuildPriority != nil {
+		defaultBuildPriority = *t.DefaultBuildPriority
+	}
+
 	row := psql.Insert("teams").
-		Columns("name, auth, admin").
-		Values(t.Name, auth, admin).
-		Suffix("RETURNING id, name, admin, auth").
+		Columns("name, auth, admin, default_build_priority, quotas").
+		Values(t.Name, auth, admin, defaultBuildPriority, quotas).
+		Suffix("RETURNING id, name, admin, auth, default_build_priority, quotas").
 		RunWith(tx).
 		QueryRow()
 
@@ -91,7 +104,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 		lockFactory: factory.lockFactory,
 	}
 
-	row := psql.Select("id, name, admin, auth").
+	row := psql.Select("id, name, admin, auth, default_build_priority, quotas").
 		From("teams").
 		Where(sq.Eq{"LOWER(name)": strings.ToLower(teamName)}).
 		RunWith(factory.conn).
@@ -110,7 +123,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 }
 
 func (factory *teamFactory) GetTeams() ([]Team, error) {
-	rows, err := psql.Select("id, name, admin, auth").
+	rows, err := psql.Select("id, name, admin, auth, default_build_priority, quotas").
 		From("teams").
 		OrderBy("name ASC").
 		RunWith(factory.conn).
@@ -176,13 +189,15 @@ func (factory *teamFactory) NotifyCacher() error {
 }
 
 func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
-	var providerAuth sql.NullString
+	var providerAuth, quotas sql.NullString
 
 	err := rows.Scan(
 		&t.id,
 		&t.name,
 		&t.admin,
 		&providerAuth,
+		&t.defaultBuildPriority,
+		&quotas,
 	)
 
 	if providerAuth.Valid {
@@ -192,5 +207,12 @@ func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
 		}
 	}
 
+	if quotas.Valid {
+		err = json.Unmarshal([]byte(quotas.String), &t.quotas)
+		if err != nil {
+			return err
+		}
+	}
+
 	return err
 }
@@ -2,6 +2,7 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -16,6 +17,11 @@ import (
 var (
 	ErrWorkerNotPresent         = errors.New("worker not present in db")
 	ErrCannotPruneRunningWorker = errors.New("worker not stalled for pruning")
+
+	// ErrWorkerResourcesExhausted is returned when a container is created
+	// within the allocatable resources of a worker which no longer has room
+	// for the container's limits.
+	ErrWorkerResourcesExhausted = errors.New("worker does not have enough allocatable resources left for the container")
 )
 
 type ContainerOwnerDisappearedError struct {
@@ -26,6 +32,14 @@ func (e ContainerOwnerDisappearedError) Error() string {
 	return fmt.Sprintf("container owner %T disappeared", e.owner)
 }
 
+type ContainerQuotaExceededError struct {
+	MaxActiveContainers int
+}
+
+func (e ContainerQuotaExceededError) Error() string {
+	return fmt.Sprintf("team has reached its quota of %d active containers", e.MaxActiveContainers)
+}
+
 type WorkerState string
 
 const (
@@ -69,6 +83,8 @@ type Worker interface {
 	StartTime() time.Time
 	ExpiresAt() time.Time
 	Ephemeral() bool
+	Unprivileged() bool
+	SecurityProfiles() []string
 
 	Reload() (bool, error)
 
@@ -81,8 +97,11 @@ type Worker interface {
 	IncreaseActiveTasks() error
 	DecreaseActiveTasks() error
 
+	AllocatableResources() WorkerResources
+
 	FindContainer(owner ContainerOwner) (CreatingContainer, CreatedContainer, error)
 	CreateContainer(owner ContainerOwner, meta ContainerMetadata) (CreatingContainer, error)
+	CreateContainerWithinResources(owner ContainerOwner, meta ContainerMetadata) (CreatingContainer, error)
 }
 
 type worker struct {
@@ -108,6 +127,17 @@ type worker struct {
 	expiresAt        time.Time
 	certsPath        *string
 	ephemeral        bool
+	unprivileged     bool
+	securityProfiles []string
+
+	allocatable WorkerResources
+}
+
+// WorkerResources is an amount of CPU and memory on a worker, in the same
+// units as task container limits. A zero value means the amount is unknown.
+type WorkerResources struct {
+	CPU    uint64
+	Memory uint64
 }
 
 func (worker *worker) Name() string             { return worker.name }
@@ -128,6 +158,10 @@ func (worker *worker) Tags() []string                          { return worker.t
 func (worker *worker) TeamID() int                             { return worker.teamID }
 func (worker *worker) TeamName() string                        { return worker.teamName }
 func (worker *worker) Ephemeral() bool                         { return worker.ephemeral }
+func (worker *worker) Unprivileged() bool                      { return worker.unprivileged }
+func (worker *worker) SecurityProfiles() []string              { return worker.securityProfiles }
+
+func (worker *worker) AllocatableResources() WorkerResources { return worker.allocatable }
 
 func (worker *worker) StartTime() time.Time { return worker.startTime }
 func (worker *worker) ExpiresAt() time.Time { return worker.expiresAt }
@@ -293,6 +327,19 @@ func (worker *worker) FindContainer(owner ContainerOwner) (CreatingContainer, Cr
 }
 
 func (worker *worker) CreateContainer(owner ContainerOwner, meta ContainerMetadata) (CreatingContainer, error) {
+	return worker.createContainer(owner, meta, false)
+}
+
+// CreateContainerWithinResources creates the container like CreateContainer,
+// but only if its limits fit within the allocatable resources the worker has
+// left, returning ErrWorkerResourcesExhausted otherwise. The worker is locked
+// until the container is created so that containers created concurrently
+// can't both take the last of its resources.
+func (worker *worker) CreateContainerWithinResources(owner ContainerOwner, meta ContainerMetadata) (CreatingContainer, error) {
+	return worker.createContainer(owner, meta, true)
+}
+
+func (worker *worker) createContainer(owner ContainerOwner, meta ContainerMetadata, withinResources bool) (CreatingContainer, error) {
 	handle, err := uuid.NewV4()
 	if err != nil {
 		return nil, err
@@ -324,6 +371,21 @@ func (worker *worker) CreateContainer(owner ContainerOwner, meta ContainerMetada
 		insMap[k] = v
 	}
 
+	_, ownedByBuild := ownerCols["build_id"]
+	if teamID, ok := ownerCols["team_id"].(int); ok && ownedByBuild {
+		err = checkTeamContainerQuota(tx, teamID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if withinResources {
+		err = checkWorkerResources(tx, worker.name, meta)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	err = psql.Insert("containers").
 		SetMap(insMap).
 		Suffix("RETURNING id, " + strings.Join(containerMetadataColumns, ", ")).
@@ -352,6 +414,157 @@ func (worker *worker) CreateContainer(owner ContainerOwner, meta ContainerMetada
 	), nil
 }
 
+// checkTeamContainerQuota returns a ContainerQuotaExceededError if the team's
+// running builds already have as many active containers as its quota allows.
+// Image check and get containers are left out, as they're created on behalf of
+// a build step container that already counts, and so are the containers kept
+// around for finished builds. When the team has a quota, it is locked until
+// the transaction ends so that concurrent creations can't both take the last
+// container of the quota.
+func checkTeamContainerQuota(tx Tx, teamID int) error {
+	quotas, err := teamQuotas(tx, teamID, false)
+	if err != nil {
+		return err
+	}
+
+	if quotas.MaxActiveContainers == 0 {
+		return nil
+	}
+
+	// read the quotas again while locking the team, as they may have changed
+	quotas, err = teamQuotas(tx, teamID, true)
+	if err != nil {
+		return err
+	}
+
+	if quotas.MaxActiveContainers == 0 {
+		return nil
+	}
+
+	var active int
+	err = psql.Select("COUNT(*)").
+		From("containers c").
+		Join("builds b ON b.id = c.build_id").
+		Where(sq.Eq{
+			"c.team_id":   teamID,
+			"c.state":     []string{atc.ContainerStateCreating, atc.ContainerStateCreated},
+			"b.completed": false,
+		}).
+		RunWith(tx).
+		QueryRow().
+		Scan(&active)
+	if err != nil {
+		return err
+	}
+
+	if active >= quotas.MaxActiveContainers {
+		return ContainerQuotaExceededError{MaxActiveContainers: quotas.MaxActiveContainers}
+	}
+
+	return nil
+}
+
+func teamQuotas(tx Tx, teamID int, lock bool) (atc.TeamQuotas, error) {
+	query := psql.Select("quotas").
+		From("teams").
+		Where(sq.Eq{"id": teamID})
+	if lock {
+		query = query.Suffix("FOR UPDATE")
+	}
+
+	var encodedQuotas sql.NullString
+	err := query.
+		RunWith(tx).
+		QueryRow().
+		Scan(&encodedQuotas)
+	if err != nil {
+		return atc.TeamQuotas{}, err
+	}
+
+	var quotas atc.TeamQuotas
+	if encodedQuotas.Valid {
+		err = json.Unmarshal([]byte(encodedQuotas.String), &quotas)
+		if err != nil {
+			return atc.TeamQuotas{}, err
+		}
+	}
+
+	return quotas, nil
+}
+
+// checkWorkerResources returns ErrWorkerResourcesExhausted if the worker's
+// allocatable resources which are not yet allocated to its containers can't
+// fit the container's limits. An unreported allocatable amount fits any limit.
+func checkWorkerResources(tx Tx, workerName string, meta ContainerMetadata) error {
+	var allocatable WorkerResources
+	err := psql.Select("allocatable_cpu", "allocatable_memory").
+		From("workers").
+		Where(sq.Eq{"name": workerName}).
+		Suffix("FOR UPDATE").
+		RunWith(tx).
+		QueryRow().
+		Scan(&allocatable.CPU, &allocatable.Memory)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrWorkerNotPresent
+		}
+
+		return err
+	}
+
+	allocated, err := allocatedResources(tx, sq.Eq{"worker_name": workerName})
+	if err != nil {
+		return err
+	}
+
+	if !fitsResource(allocatable.CPU, allocated[workerName].CPU, meta.CPULimit) ||
+		!fitsResource(allocatable.Memory, allocated[workerName].Memory, meta.MemoryLimit) {
+		return ErrWorkerResourcesExhausted
+	}
+
+	return nil
+}
+
+func fitsResource(allocatable, allocated, limit uint64) bool {
+	if allocatable == 0 {
+		return true
+	}
+
+	return allocated <= allocatable && limit <= allocatable-allocated
+}
+
+// allocatedResources sums the limits of the containers which are being
+// created or have been created, per worker.
+func allocatedResources(runner sq.Runner, where sq.Sqlizer) (map[string]WorkerResources, error) {
+	rows, err := psql.Select("worker_name", "COALESCE(SUM(meta_cpu_limit), 0)", "COALESCE(SUM(meta_memory_limit), 0)").
+		From("containers").
+		Where(sq.Eq{"state": []string{atc.ContainerStateCreating, atc.ContainerStateCreated}}).
+		Where(where).
+		GroupBy("worker_name").
+		RunWith(runner).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	allocatedByWorker := map[string]WorkerResources{}
+	for rows.Next() {
+		var workerName string
+		var allocated WorkerResources
+
+		err = rows.Scan(&workerName, &allocated.CPU, &allocated.Memory)
+		if err != nil {
+			return nil, err
+		}
+
+		allocatedByWorker[workerName] = allocated
+	}
+
+	return allocatedByWorker, nil
+}
+
 func (worker *worker) findContainer(whereClause sq.Sqlizer) (CreatingContainer, CreatedContainer, error) {
 	creating, created, destroying, _, err := scanContainer(
 		selectContainers().
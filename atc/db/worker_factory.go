@@ -23,6 +23,7 @@ type WorkerFactory interface {
 
 	FindWorkersForContainerByOwner(ContainerOwner) ([]Worker, error)
 	BuildContainersCountPerWorker() (map[string]int, error)
+	AllocatedResourcesPerWorker() (map[string]WorkerResources, error)
 }
 
 type workerFactory struct {
@@ -54,7 +55,11 @@ var workersQuery = psql.Select(`
 		w.team_id,
 		w.start_time,
 		w.expires,
-		w.ephemeral
+		w.ephemeral,
+		w.allocatable_cpu,
+		w.allocatable_memory,
+		w.unprivileged,
+		w.security_profiles
 	`).
 	From("workers w").
 	LeftJoin("teams t ON w.team_id = t.id")
@@ -140,6 +145,8 @@ func scanWorker(worker *worker, row scannable) error {
 		startTime     pq.NullTime
 		expiresAt     pq.NullTime
 		ephemeral     sql.NullBool
+
+		securityProfiles sql.NullString
 	)
 
 	err := row.Scan(
@@ -162,6 +169,10 @@ func scanWorker(worker *worker, row scannable) error {
 		&startTime,
 		&expiresAt,
 		&ephemeral,
+		&worker.allocatable.CPU,
+		&worker.allocatable.Memory,
+		&worker.unprivileged,
+		&securityProfiles,
 	)
 	if err != nil {
 		return err
@@ -215,6 +226,13 @@ func scanWorker(worker *worker, row scannable) error {
 		worker.ephemeral = ephemeral.Bool
 	}
 
+	if securityProfiles.Valid {
+		err = json.Unmarshal([]byte(securityProfiles.String), &worker.securityProfiles)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = json.Unmarshal(resourceTypes, &worker.resourceTypes)
 	if err != nil {
 		return err
@@ -256,6 +274,8 @@ func (f *workerFactory) HeartbeatWorker(atcWorker atc.Worker, ttl time.Duration)
 		Set("expires", sq.Expr(expires)).
 		Set("active_containers", atcWorker.ActiveContainers).
 		Set("active_volumes", atcWorker.ActiveVolumes).
+		Set("allocatable_cpu", atcWorker.AllocatableCPU).
+		Set("allocatable_memory", atcWorker.AllocatableMemory).
 		Set("state", sq.Expr("("+cSQL+")")).
 		Where(sq.Eq{"name": atcWorker.Name}).
 		RunWith(tx).
@@ -364,6 +384,12 @@ func (f *workerFactory) BuildContainersCountPerWorker() (map[string]int, error)
 	return countByWorker, nil
 }
 
+// AllocatedResourcesPerWorker returns the sum of the limits of the containers
+// which are being created or have been created on each worker.
+func (f *workerFactory) AllocatedResourcesPerWorker() (map[string]WorkerResources, error) {
+	return allocatedResources(f.conn, sq.Eq{})
+}
+
 func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, conn Conn) (Worker, error) {
 	resourceTypes, err := json.Marshal(atcWorker.ResourceTypes)
 	if err != nil {
@@ -375,6 +401,14 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		return nil, err
 	}
 
+	var securityProfiles interface{}
+	if len(atcWorker.SecurityProfiles) > 0 {
+		securityProfiles, err = json.Marshal(atcWorker.SecurityProfiles)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	expires := "NULL"
 	if ttl != 0 {
 		expires = fmt.Sprintf(`NOW() + '%d second'::INTERVAL`, int(ttl.Seconds()))
@@ -411,6 +445,10 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		string(workerState),
 		teamID,
 		atcWorker.Ephemeral,
+		atcWorker.AllocatableCPU,
+		atcWorker.AllocatableMemory,
+		atcWorker.Unprivileged,
+		securityProfiles,
 	}
 
 	conflictValues := values
@@ -442,6 +480,10 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 			"state",
 			"team_id",
 			"ephemeral",
+			"allocatable_cpu",
+			"allocatable_memory",
+			"unprivileged",
+			"security_profiles",
 		).
 		Values(append([]interface{}{
 			sq.Expr(expires),
@@ -466,7 +508,11 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 				version = ?,
 				state = ?,
 				team_id = ?,
-				ephemeral = ?
+				ephemeral = ?,
+				allocatable_cpu = ?,
+				allocatable_memory = ?,
+				unprivileged = ?,
+				security_profiles = ?
 			WHERE `+matchTeamUpsert,
 			conflictValues...,
 		).
@@ -509,7 +555,14 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		teamID:           workerTeamID,
 		startTime:        time.Unix(atcWorker.StartTime, 0),
 		ephemeral:        atcWorker.Ephemeral,
+		unprivileged:     atcWorker.Unprivileged,
+		securityProfiles: atcWorker.SecurityProfiles,
 		conn:             conn,
+
+		allocatable: WorkerResources{
+			CPU:    atcWorker.AllocatableCPU,
+			Memory: atcWorker.AllocatableMemory,
+		},
 	}
 
 	workerBaseResourceTypeIDs := []int{}
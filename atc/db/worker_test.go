@@ -388,6 +388,59 @@ var _ = Describe("Worker", func() {
 				})
 			})
 		})
+
+		Context("when the team has reached its container quota", func() {
+			var (
+				oneOffBuild   Build
+				taskContainer CreatingContainer
+			)
+
+			BeforeEach(func() {
+				err := defaultTeam.UpdateQuotas(atc.TeamQuotas{MaxActiveContainers: 1})
+				Expect(err).ToNot(HaveOccurred())
+
+				oneOffBuild, err = defaultTeam.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				taskContainer, err = worker.CreateContainer(NewBuildStepContainerOwner(oneOffBuild.ID(), atc.PlanID("1"), defaultTeam.ID()), ContainerMetadata{Type: "task"})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("fails to create another task container", func() {
+				_, err := worker.CreateContainer(NewBuildStepContainerOwner(oneOffBuild.ID(), atc.PlanID("2"), defaultTeam.ID()), ContainerMetadata{Type: "task"})
+				Expect(err).To(Equal(ContainerQuotaExceededError{MaxActiveContainers: 1}))
+			})
+
+			It("fails to create get, put and check containers of the team's builds", func() {
+				for _, containerType := range []ContainerType{ContainerTypeGet, ContainerTypePut, ContainerTypeCheck} {
+					_, err := worker.CreateContainer(NewBuildStepContainerOwner(oneOffBuild.ID(), atc.PlanID("2"), defaultTeam.ID()), ContainerMetadata{Type: containerType})
+					Expect(err).To(Equal(ContainerQuotaExceededError{MaxActiveContainers: 1}))
+				}
+			})
+
+			It("still creates the image containers of the build's steps", func() {
+				_, err := worker.CreateContainer(NewImageGetContainerOwner(taskContainer, defaultTeam.ID()), ContainerMetadata{Type: "get"})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			Context("once the build holding the quota has finished", func() {
+				BeforeEach(func() {
+					_, err := taskContainer.Created()
+					Expect(err).ToNot(HaveOccurred())
+
+					err = oneOffBuild.Finish(BuildStatusSucceeded)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("creates the containers of the team's other builds", func() {
+					otherBuild, err := defaultTeam.CreateOneOffBuild()
+					Expect(err).ToNot(HaveOccurred())
+
+					_, err = worker.CreateContainer(NewBuildStepContainerOwner(otherBuild.ID(), atc.PlanID("1"), defaultTeam.ID()), ContainerMetadata{Type: "task"})
+					Expect(err).ToNot(HaveOccurred())
+				})
+			})
+		})
 	})
 
 	Describe("Active tasks", func() {
@@ -436,4 +489,140 @@ var _ = Describe("Worker", func() {
 			})
 		})
 	})
+
+	Describe("Resources", func() {
+		BeforeEach(func() {
+			atcWorker.AllocatableCPU = 4096
+			atcWorker.AllocatableMemory = 8192
+
+			var err error
+			worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the allocatable resources the worker registered with", func() {
+			Expect(worker.AllocatableResources()).To(Equal(WorkerResources{CPU: 4096, Memory: 8192}))
+		})
+
+		Context("when the worker is reloaded", func() {
+			It("returns the registered allocatable resources", func() {
+				found, err := worker.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(worker.AllocatableResources()).To(Equal(WorkerResources{CPU: 4096, Memory: 8192}))
+			})
+		})
+
+		Context("when the worker has no containers", func() {
+			It("has no allocated resources", func() {
+				allocated, err := workerFactory.AllocatedResourcesPerWorker()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(allocated[worker.Name()]).To(Equal(WorkerResources{}))
+			})
+		})
+
+		Context("when the worker has containers with limits", func() {
+			var build Build
+
+			BeforeEach(func() {
+				var err error
+				build, err = defaultTeam.CreateOneOffBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = worker.CreateContainer(
+					NewBuildStepContainerOwner(build.ID(), "some-plan", defaultTeam.ID()),
+					ContainerMetadata{Type: ContainerTypeTask, CPULimit: 1024, MemoryLimit: 2048},
+				)
+				Expect(err).ToNot(HaveOccurred())
+
+				creatingContainer, err := worker.CreateContainer(
+					NewBuildStepContainerOwner(build.ID(), "other-plan", defaultTeam.ID()),
+					ContainerMetadata{Type: ContainerTypeTask, CPULimit: 512, MemoryLimit: 1024},
+				)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = creatingContainer.Created()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("sums the limits of the containers", func() {
+				allocated, err := workerFactory.AllocatedResourcesPerWorker()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(allocated[worker.Name()]).To(Equal(WorkerResources{CPU: 1536, Memory: 3072}))
+			})
+
+			Describe("CreateContainerWithinResources", func() {
+				It("creates a container which fits the remaining resources", func() {
+					_, err := worker.CreateContainerWithinResources(
+						NewBuildStepContainerOwner(build.ID(), "fitting-plan", defaultTeam.ID()),
+						ContainerMetadata{Type: ContainerTypeTask, CPULimit: 2560, MemoryLimit: 5120},
+					)
+					Expect(err).ToNot(HaveOccurred())
+
+					allocated, err := workerFactory.AllocatedResourcesPerWorker()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(allocated[worker.Name()]).To(Equal(WorkerResources{CPU: 4096, Memory: 8192}))
+				})
+
+				It("does not create a container which does not fit the remaining resources", func() {
+					_, err := worker.CreateContainerWithinResources(
+						NewBuildStepContainerOwner(build.ID(), "oversized-plan", defaultTeam.ID()),
+						ContainerMetadata{Type: ContainerTypeTask, CPULimit: 3072, MemoryLimit: 1024},
+					)
+					Expect(err).To(Equal(ErrWorkerResourcesExhausted))
+
+					creating, created, err := worker.FindContainer(NewBuildStepContainerOwner(build.ID(), "oversized-plan", defaultTeam.ID()))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(creating).To(BeNil())
+					Expect(created).To(BeNil())
+				})
+			})
+		})
+	})
+
+	Describe("SecurityProfiles", func() {
+		BeforeEach(func() {
+			atcWorker.SecurityProfiles = []string{"default", "network-tools"}
+
+			var err error
+			worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the security profiles the worker defines", func() {
+			Expect(worker.SecurityProfiles()).To(Equal([]string{"default", "network-tools"}))
+		})
+
+		Context("when the worker is reloaded", func() {
+			It("returns the security profiles the worker defines", func() {
+				found, err := worker.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(worker.SecurityProfiles()).To(Equal([]string{"default", "network-tools"}))
+			})
+		})
+	})
+
+	Describe("Unprivileged", func() {
+		BeforeEach(func() {
+			atcWorker.Unprivileged = true
+
+			var err error
+			worker, err = workerFactory.SaveWorker(atcWorker, 5*time.Minute)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns whether the worker registered as unprivileged", func() {
+			Expect(worker.Unprivileged()).To(BeTrue())
+		})
+
+		Context("when the worker is reloaded", func() {
+			It("returns whether the worker registered as unprivileged", func() {
+				found, err := worker.Reload()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(worker.Unprivileged()).To(BeTrue())
+			})
+		})
+	})
 })
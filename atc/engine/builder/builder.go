@@ -5,6 +5,7 @@ import (
 
 	"errors"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 
@@ -39,6 +40,7 @@ type DelegateFactory interface {
 	CheckDelegate(db.Check, atc.PlanID, *vars.BuildVariables) exec.CheckDelegate
 	BuildStepDelegate(db.Build, atc.PlanID, *vars.BuildVariables) exec.BuildStepDelegate
 	SetPipelineStepDelegate(db.Build, atc.PlanID, *vars.BuildVariables) exec.SetPipelineStepDelegate
+	AcrossStepDelegate(db.Build, atc.PlanID, *vars.BuildVariables) exec.AcrossStepDelegate
 }
 
 func NewStepBuilder(
@@ -47,6 +49,7 @@ func NewStepBuilder(
 	externalURL string,
 	secrets creds.Secrets,
 	varSourcePool creds.VarSourcePool,
+	leases creds.LeaseReleaser,
 ) *stepBuilder {
 	return &stepBuilder{
 		stepFactory:     stepFactory,
@@ -54,6 +57,7 @@ func NewStepBuilder(
 		externalURL:     externalURL,
 		globalSecrets:   secrets,
 		varSourcePool:   varSourcePool,
+		leases:          leases,
 	}
 }
 
@@ -63,6 +67,7 @@ type stepBuilder struct {
 	externalURL     string
 	globalSecrets   creds.Secrets
 	varSourcePool   creds.VarSourcePool
+	leases          creds.LeaseReleaser
 }
 
 func (builder *stepBuilder) BuildStep(logger lager.Logger, build db.Build) (exec.Step, error) {
@@ -76,9 +81,12 @@ func (builder *stepBuilder) BuildStep(logger lager.Logger, build db.Build) (exec
 
 	var buildVars *vars.BuildVariables
 
+	// leases on dynamic secrets are held by the build until it finishes
+	buildSecrets := creds.ForBuild(builder.globalSecrets, build.ID())
+
 	// "fly execute" generated build will have no pipeline.
 	if build.PipelineID() == 0 {
-		globalVars := creds.NewVariables(builder.globalSecrets, build.TeamName(), build.PipelineName(), false)
+		globalVars := creds.NewVariables(buildSecrets, build.TeamName(), build.PipelineName(), false)
 		buildVars = vars.NewBuildVariables(globalVars, atc.EnableRedactSecrets)
 	} else {
 		pipeline, found, err := build.Pipeline()
@@ -89,7 +97,7 @@ func (builder *stepBuilder) BuildStep(logger lager.Logger, build db.Build) (exec
 			return exec.IdentityStep{}, errors.New("pipeline not found")
 		}
 
-		varss, err := pipeline.Variables(logger, builder.globalSecrets, builder.varSourcePool)
+		varss, err := pipeline.Variables(logger, buildSecrets, creds.VarSourcePoolForBuild(builder.varSourcePool, build.ID()))
 		if err != nil {
 			return exec.IdentityStep{}, err
 		}
@@ -103,6 +111,13 @@ func (builder *stepBuilder) BuildStepErrored(logger lager.Logger, build db.Build
 	builder.delegateFactory.BuildStepDelegate(build, build.PrivatePlan().ID, nil).Errored(logger, err.Error())
 }
 
+func (builder *stepBuilder) BuildStepFinished(logger lager.Logger, build db.Build) {
+	err := builder.leases.Release(build.ID())
+	if err != nil {
+		logger.Error("failed-to-release-build-leases", err)
+	}
+}
+
 func (builder *stepBuilder) CheckStep(logger lager.Logger, check db.Check) (exec.Step, error) {
 
 	if check == nil {
@@ -236,55 +251,91 @@ func (builder *stepBuilder) buildParallelStep(build db.Build, plan atc.Plan, bui
 }
 
 func (builder *stepBuilder) buildAcrossStep(build db.Build, plan atc.Plan, buildVars *vars.BuildVariables) exec.Step {
-	step := builder.buildAcrossInParallelStep(build, 0, *plan.Across, buildVars)
-
 	stepMetadata := builder.stepMetadata(
 		build,
 		builder.externalURL,
 	)
 
-	varNames := make([]string, len(plan.Across.Vars))
-	for i, v := range plan.Across.Vars {
-		varNames[i] = v.Var
-	}
-
 	return exec.Across(
-		step,
-		varNames,
-		builder.delegateFactory.BuildStepDelegate(build, plan.ID, buildVars),
+		*plan.Across,
+		func(acrossPlan atc.AcrossPlan) exec.Step {
+			if acrossPlan.MaxInFlight != nil {
+				return builder.buildAcrossSubsteps(build, acrossPlan, buildVars)
+			}
+
+			return builder.buildAcrossInParallelStep(build, 0, acrossPlan, buildVars)
+		},
+		builder.delegateFactory.AcrossStepDelegate(build, plan.ID, buildVars),
 		stepMetadata,
 	)
 }
 
+// buildAcrossSubsteps runs every combination in parallel, limited by the
+// max_in_flight of the across step as a whole.
+func (builder *stepBuilder) buildAcrossSubsteps(build db.Build, plan atc.AcrossPlan, buildVars *vars.BuildVariables) exec.InParallelStep {
+	var steps []exec.Step
+	for _, step := range plan.Steps {
+		steps = append(steps, builder.buildAcrossScopedStep(build, plan.Vars, step, buildVars))
+	}
+
+	limit := plan.MaxInFlight.Limit
+	if plan.MaxInFlight.All {
+		limit = len(steps)
+	}
+
+	return exec.InParallel(steps, limit, plan.FailFast)
+}
+
+// buildAcrossInParallelStep nests the combinations by the values of each
+// var, in order, so that the max_in_flight of each var applies to the
+// values of that var.
 func (builder *stepBuilder) buildAcrossInParallelStep(build db.Build, varIndex int, plan atc.AcrossPlan, buildVars *vars.BuildVariables) exec.InParallelStep {
 	if varIndex == len(plan.Vars)-1 {
 		var steps []exec.Step
 		for _, step := range plan.Steps {
-			scopedBuildVars := buildVars.NewLocalScope()
-			for i, v := range plan.Vars {
-				// Don't redact because the `list` operation of a var_source should return identifiers
-				// which should be publicly accessible. For static across steps, the static list is
-				// embedded directly in the pipeline
-				scopedBuildVars.AddLocalVar(v.Var, step.Values[i], false)
-			}
-			steps = append(steps, builder.buildStep(build, step.Step, scopedBuildVars))
+			steps = append(steps, builder.buildAcrossScopedStep(build, plan.Vars, step, buildVars))
 		}
-		return exec.InParallel(steps, plan.Vars[varIndex].MaxInFlight, plan.FailFast)
+		return exec.InParallel(steps, acrossVarLimit(plan.Vars[varIndex], len(steps)), plan.FailFast)
 	}
-	stepsPerValue := 1
-	for _, v := range plan.Vars[varIndex+1:] {
-		stepsPerValue *= len(v.Values)
+
+	var groups [][]atc.VarScopedPlan
+	for i, step := range plan.Steps {
+		if i == 0 || !reflect.DeepEqual(step.Values[varIndex], plan.Steps[i-1].Values[varIndex]) {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], step)
 	}
-	numValues := len(plan.Vars[varIndex].Values)
-	substeps := make([]exec.Step, numValues)
-	for i := range substeps {
-		startIndex := i * stepsPerValue
-		endIndex := (i + 1) * stepsPerValue
+
+	substeps := make([]exec.Step, len(groups))
+	for i, group := range groups {
 		planCopy := plan
-		planCopy.Steps = plan.Steps[startIndex:endIndex]
+		planCopy.Steps = group
 		substeps[i] = builder.buildAcrossInParallelStep(build, varIndex+1, planCopy, buildVars)
 	}
-	return exec.InParallel(substeps, plan.Vars[varIndex].MaxInFlight, plan.FailFast)
+	return exec.InParallel(substeps, acrossVarLimit(plan.Vars[varIndex], len(substeps)), plan.FailFast)
+}
+
+func (builder *stepBuilder) buildAcrossScopedStep(build db.Build, acrossVars []atc.AcrossVar, step atc.VarScopedPlan, buildVars *vars.BuildVariables) exec.Step {
+	scopedBuildVars := buildVars.NewLocalScope()
+	for i, v := range acrossVars {
+		// Don't redact because the `list` operation of a var_source should return identifiers
+		// which should be publicly accessible. For static across steps, the static list is
+		// embedded directly in the pipeline
+		scopedBuildVars.AddLocalVar(v.Var, step.Values[i], false)
+	}
+	return builder.buildStep(build, step.Step, scopedBuildVars)
+}
+
+func acrossVarLimit(v atc.AcrossVar, numValues int) int {
+	if v.MaxInFlight == nil {
+		return 1
+	}
+
+	if v.MaxInFlight.All {
+		return numValues
+	}
+
+	return v.MaxInFlight.Limit
 }
 
 func (builder *stepBuilder) buildDoStep(build db.Build, plan atc.Plan, buildVars *vars.BuildVariables) exec.Step {
@@ -423,6 +474,7 @@ func (builder *stepBuilder) buildCheckStep(check db.Check, plan atc.Plan, buildV
 		TeamName:              check.TeamName(),
 		PipelineID:            check.PipelineID(),
 		PipelineName:          check.PipelineName(),
+		PipelineInstanceVars:  check.PipelineInstanceVars(),
 		ResourceConfigScopeID: check.ResourceConfigScopeID(),
 		ResourceConfigID:      check.ResourceConfigID(),
 		BaseResourceTypeID:    check.BaseResourceTypeID(),
@@ -537,14 +589,16 @@ func (builder *stepBuilder) stepMetadata(
 	externalURL string,
 ) exec.StepMetadata {
 	return exec.StepMetadata{
-		BuildID:      build.ID(),
-		BuildName:    build.Name(),
-		TeamID:       build.TeamID(),
-		TeamName:     build.TeamName(),
-		JobID:        build.JobID(),
-		JobName:      build.JobName(),
-		PipelineID:   build.PipelineID(),
-		PipelineName: build.PipelineName(),
-		ExternalURL:  externalURL,
+		BuildID:              build.ID(),
+		BuildName:            build.Name(),
+		TeamID:               build.TeamID(),
+		TeamName:             build.TeamName(),
+		JobID:                build.JobID(),
+		JobName:              build.JobName(),
+		PipelineID:           build.PipelineID(),
+		PipelineName:         build.PipelineName(),
+		PipelineInstanceVars: build.PipelineInstanceVars(),
+		ExternalURL:          externalURL,
+		BuildPriority:        build.Priority(),
 	}
 }
@@ -32,6 +32,7 @@ var _ = Describe("Builder", func() {
 			fakeStepFactory   *builderfakes.FakeStepFactory
 			fakeSecretManager *credsfakes.FakeSecrets
 			fakeVarSourcePool *credsfakes.FakeVarSourcePool
+			fakeLeases        *credsfakes.FakeLeaseReleaser
 			delegateFactory   builder.DelegateFactory
 
 			planFactory atc.PlanFactory
@@ -44,6 +45,7 @@ var _ = Describe("Builder", func() {
 			fakeStepFactory = new(builderfakes.FakeStepFactory)
 			fakeSecretManager = new(credsfakes.FakeSecrets)
 			fakeVarSourcePool = new(credsfakes.FakeVarSourcePool)
+			fakeLeases = new(credsfakes.FakeLeaseReleaser)
 			delegateFactory = builder.NewDelegateFactory()
 
 			stepBuilder = builder.NewStepBuilder(
@@ -52,6 +54,7 @@ var _ = Describe("Builder", func() {
 				"http://example.com",
 				fakeSecretManager,
 				fakeVarSourcePool,
+				fakeLeases,
 			)
 
 			planFactory = atc.NewPlanFactory(123)
@@ -132,6 +135,14 @@ var _ = Describe("Builder", func() {
 					Expect(err).NotTo(HaveOccurred())
 				})
 
+				It("finds the var sources of the pipeline through the pool", func() {
+					_, _, pool := fakePipeline.VariablesArgsForCall(0)
+
+					_, err := pool.FindOrCreate(logger, "some-team", nil, nil)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeVarSourcePool.FindOrCreateCallCount()).To(Equal(1))
+				})
+
 				Context("with a putget in an aggregate", func() {
 					var (
 						putPlan               atc.Plan
@@ -875,6 +886,56 @@ var _ = Describe("Builder", func() {
 						ensureLocalVar(1, "var1", "a1")
 					})
 				})
+
+				Context("running across steps with combination rules", func() {
+					BeforeEach(func() {
+						planner := builds.NewPlanner(planFactory)
+
+						step := &atc.AcrossStep{
+							Step: &atc.TaskStep{Name: "some-task"},
+							Vars: []atc.AcrossVarConfig{
+								{
+									Var:    "var1",
+									Values: []interface{}{"a1", "a2"},
+								},
+								{
+									Var:    "var2",
+									Values: []interface{}{"b1", "b2"},
+								},
+							},
+							Exclude: []atc.AcrossCombination{
+								{"var2": "b2"},
+							},
+							Include: []atc.AcrossCombination{
+								{"var1": "a3", "var2": "b3"},
+							},
+						}
+
+						expectedPlan, err = planner.Create(step, nil, nil, nil)
+						Expect(err).ToNot(HaveOccurred())
+					})
+
+					It("runs a step for each remaining combination with the correct local vars", func() {
+						Expect(fakeStepFactory.TaskStepCallCount()).To(Equal(3))
+
+						ensureLocalVar := func(i int, name string, value interface{}) {
+							_, _, _, delegate := fakeStepFactory.TaskStepArgsForCall(i)
+							val, found, err := delegate.Variables().Get(vars.VariableDefinition{Ref: vars.VariableReference{Source: ".", Path: name}})
+							Expect(err).ToNot(HaveOccurred())
+							Expect(found).To(BeTrue())
+							Expect(val).To(Equal(value))
+						}
+
+						ensureLocalVar(0, "var1", "a1")
+						ensureLocalVar(0, "var2", "b1")
+
+						ensureLocalVar(1, "var1", "a2")
+						ensureLocalVar(1, "var2", "b1")
+
+						ensureLocalVar(2, "var1", "a3")
+						ensureLocalVar(2, "var2", "b3")
+					})
+				})
 			})
 		})
 	})
@@ -887,6 +948,7 @@ var _ = Describe("Builder", func() {
 			fakeStepFactory   *builderfakes.FakeStepFactory
 			fakeSecretManager *credsfakes.FakeSecrets
 			fakeVarSourcePool *credsfakes.FakeVarSourcePool
+			fakeLeases        *credsfakes.FakeLeaseReleaser
 			delegateFactory   builder.DelegateFactory
 
 			planFactory atc.PlanFactory
@@ -899,6 +961,7 @@ var _ = Describe("Builder", func() {
 			fakeStepFactory = new(builderfakes.FakeStepFactory)
 			fakeSecretManager = new(credsfakes.FakeSecrets)
 			fakeVarSourcePool = new(credsfakes.FakeVarSourcePool)
+			fakeLeases = new(credsfakes.FakeLeaseReleaser)
 			delegateFactory = builder.NewDelegateFactory()
 
 			stepBuilder = builder.NewStepBuilder(
@@ -907,6 +970,7 @@ var _ = Describe("Builder", func() {
 				"http://example.com",
 				fakeSecretManager,
 				fakeVarSourcePool,
+				fakeLeases,
 			)
 
 			planFactory = atc.NewPlanFactory(123)
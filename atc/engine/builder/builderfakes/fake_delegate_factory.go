@@ -12,6 +12,19 @@ import (
 )
 
 type FakeDelegateFactory struct {
+	AcrossStepDelegateStub        func(db.Build, atc.PlanID, *vars.BuildVariables) exec.AcrossStepDelegate
+	acrossStepDelegateMutex       sync.RWMutex
+	acrossStepDelegateArgsForCall []struct {
+		arg1 db.Build
+		arg2 atc.PlanID
+		arg3 *vars.BuildVariables
+	}
+	acrossStepDelegateReturns struct {
+		result1 exec.AcrossStepDelegate
+	}
+	acrossStepDelegateReturnsOnCall map[int]struct {
+		result1 exec.AcrossStepDelegate
+	}
 	BuildStepDelegateStub        func(db.Build, atc.PlanID, *vars.BuildVariables) exec.BuildStepDelegate
 	buildStepDelegateMutex       sync.RWMutex
 	buildStepDelegateArgsForCall []struct {
@@ -94,6 +107,68 @@ type FakeDelegateFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeDelegateFactory) AcrossStepDelegate(arg1 db.Build, arg2 atc.PlanID, arg3 *vars.BuildVariables) exec.AcrossStepDelegate {
+	fake.acrossStepDelegateMutex.Lock()
+	ret, specificReturn := fake.acrossStepDelegateReturnsOnCall[len(fake.acrossStepDelegateArgsForCall)]
+	fake.acrossStepDelegateArgsForCall = append(fake.acrossStepDelegateArgsForCall, struct {
+		arg1 db.Build
+		arg2 atc.PlanID
+		arg3 *vars.BuildVariables
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("AcrossStepDelegate", []interface{}{arg1, arg2, arg3})
+	fake.acrossStepDelegateMutex.Unlock()
+	if fake.AcrossStepDelegateStub != nil {
+		return fake.AcrossStepDelegateStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.acrossStepDelegateReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeDelegateFactory) AcrossStepDelegateCallCount() int {
+	fake.acrossStepDelegateMutex.RLock()
+	defer fake.acrossStepDelegateMutex.RUnlock()
+	return len(fake.acrossStepDelegateArgsForCall)
+}
+
+func (fake *FakeDelegateFactory) AcrossStepDelegateCalls(stub func(db.Build, atc.PlanID, *vars.BuildVariables) exec.AcrossStepDelegate) {
+	fake.acrossStepDelegateMutex.Lock()
+	defer fake.acrossStepDelegateMutex.Unlock()
+	fake.AcrossStepDelegateStub = stub
+}
+
+func (fake *FakeDelegateFactory) AcrossStepDelegateArgsForCall(i int) (db.Build, atc.PlanID, *vars.BuildVariables) {
+	fake.acrossStepDelegateMutex.RLock()
+	defer fake.acrossStepDelegateMutex.RUnlock()
+	argsForCall := fake.acrossStepDelegateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDelegateFactory) AcrossStepDelegateReturns(result1 exec.AcrossStepDelegate) {
+	fake.acrossStepDelegateMutex.Lock()
+	defer fake.acrossStepDelegateMutex.Unlock()
+	fake.AcrossStepDelegateStub = nil
+	fake.acrossStepDelegateReturns = struct {
+		result1 exec.AcrossStepDelegate
+	}{result1}
+}
+
+func (fake *FakeDelegateFactory) AcrossStepDelegateReturnsOnCall(i int, result1 exec.AcrossStepDelegate) {
+	fake.acrossStepDelegateMutex.Lock()
+	defer fake.acrossStepDelegateMutex.Unlock()
+	fake.AcrossStepDelegateStub = nil
+	if fake.acrossStepDelegateReturnsOnCall == nil {
+		fake.acrossStepDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.AcrossStepDelegate
+		})
+	}
+	fake.acrossStepDelegateReturnsOnCall[i] = struct {
+		result1 exec.AcrossStepDelegate
+	}{result1}
+}
+
 func (fake *FakeDelegateFactory) BuildStepDelegate(arg1 db.Build, arg2 atc.PlanID, arg3 *vars.BuildVariables) exec.BuildStepDelegate {
 	fake.buildStepDelegateMutex.Lock()
 	ret, specificReturn := fake.buildStepDelegateReturnsOnCall[len(fake.buildStepDelegateArgsForCall)]
@@ -469,6 +544,8 @@ func (fake *FakeDelegateFactory) TaskDelegateReturnsOnCall(i int, result1 exec.T
 func (fake *FakeDelegateFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.acrossStepDelegateMutex.RLock()
+	defer fake.acrossStepDelegateMutex.RUnlock()
 	fake.buildStepDelegateMutex.RLock()
 	defer fake.buildStepDelegateMutex.RUnlock()
 	fake.checkDelegateMutex.RLock()
@@ -2,6 +2,7 @@ package builder
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"strings"
 	"time"
@@ -48,6 +49,10 @@ func (delegate *delegateFactory) SetPipelineStepDelegate(build db.Build, planID
 	return NewSetPipelineStepDelegate(build, planID, buildVars, clock.NewClock())
 }
 
+func (delegate *delegateFactory) AcrossStepDelegate(build db.Build, planID atc.PlanID, buildVars *vars.BuildVariables) exec.AcrossStepDelegate {
+	return NewAcrossStepDelegate(build, planID, buildVars, clock.NewClock())
+}
+
 func NewGetDelegate(build db.Build, planID atc.PlanID, buildVars *vars.BuildVariables, clock clock.Clock) exec.GetDelegate {
 	return &getDelegate{
 		BuildStepDelegate: NewBuildStepDelegate(build, planID, buildVars, clock),
@@ -403,6 +408,94 @@ func (delegate *setPipelineStepDelegate) SetPipelineChanged(logger lager.Logger,
 	logger.Debug("set pipeline changed")
 }
 
+func NewAcrossStepDelegate(
+	build db.Build,
+	planID atc.PlanID,
+	buildVars *vars.BuildVariables,
+	clock clock.Clock,
+) *acrossStepDelegate {
+	return &acrossStepDelegate{
+		buildStepDelegate{
+			build:     build,
+			planID:    planID,
+			clock:     clock,
+			buildVars: buildVars,
+			stdout:    nil,
+			stderr:    nil,
+		},
+	}
+}
+
+type acrossStepDelegate struct {
+	buildStepDelegate
+}
+
+// ConstructAcrossSubsteps copies the template for each combination, giving
+// every plan in the copy an ID derived from the across step's ID and the
+// index of the combination so that they stay unique within the build.
+func (delegate *acrossStepDelegate) ConstructAcrossSubsteps(template atc.Plan, combinations [][]interface{}) ([]atc.VarScopedPlan, error) {
+	payload, err := json.Marshal(template)
+	if err != nil {
+		return nil, err
+	}
+
+	substeps := make([]atc.VarScopedPlan, len(combinations))
+	for i, values := range combinations {
+		var substep atc.Plan
+		err = json.Unmarshal(payload, &substep)
+		if err != nil {
+			return nil, err
+		}
+
+		substepID := func(id atc.PlanID) atc.PlanID {
+			return atc.PlanID(fmt.Sprintf("%s/%d/%s", delegate.planID, i, id))
+		}
+
+		substep.Each(func(p *atc.Plan) {
+			p.ID = substepID(p.ID)
+
+			if p.Get != nil && p.Get.VersionFrom != nil {
+				versionFrom := substepID(*p.Get.VersionFrom)
+				p.Get.VersionFrom = &versionFrom
+			}
+		})
+
+		substeps[i] = atc.VarScopedPlan{
+			Step:   substep,
+			Values: values,
+		}
+	}
+
+	return substeps, nil
+}
+
+func (delegate *acrossStepDelegate) SubstepsPlanned(logger lager.Logger, plan atc.AcrossPlan) {
+	varNames := make([]string, len(plan.Vars))
+	for i, v := range plan.Vars {
+		varNames[i] = v.Var
+	}
+
+	substeps := make([]*json.RawMessage, len(plan.Steps))
+	for i, step := range plan.Steps {
+		substeps[i] = step.Public()
+	}
+
+	err := delegate.build.SaveEvent(event.AcrossSubsteps{
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Time:     delegate.clock.Now().Unix(),
+		Vars:     varNames,
+		Substeps: substeps,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-across-substeps-event", err)
+		return
+	}
+
+	logger.Debug("across substeps planned")
+}
+
 func (delegate *buildStepDelegate) Variables() *vars.BuildVariables {
 	return delegate.buildVars
 }
@@ -547,13 +640,14 @@ func (delegate *buildStepDelegate) Finished(logger lager.Logger, succeeded bool)
 	logger.Info("finished")
 }
 
-func (delegate *buildStepDelegate) SelectedWorker(logger lager.Logger, workerName string) {
+func (delegate *buildStepDelegate) SelectedWorker(logger lager.Logger, workerName string, placement []atc.PlacementStep) {
 	err := delegate.build.SaveEvent(event.SelectedWorker{
 		Time: time.Now().Unix(),
 		Origin: event.Origin{
 			ID: event.OriginID(delegate.planID),
 		},
 		WorkerName: workerName,
+		Placement:  placement,
 	})
 	if err != nil {
 		logger.Error("failed-to-save-selected-worker-event", err)
@@ -561,6 +655,26 @@ func (delegate *buildStepDelegate) SelectedWorker(logger lager.Logger, workerNam
 	}
 }
 
+func (delegate *buildStepDelegate) ResourceUsage(logger lager.Logger, usage atc.ResourceUsage) {
+	err := delegate.build.SaveEvent(event.ResourceUsage{
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Time:  delegate.clock.Now().Unix(),
+		Usage: usage,
+	})
+	if err != nil {
+		logger.Error("failed-to-save-resource-usage-event", err)
+		return
+	}
+
+	err = delegate.build.AddResourceUsage(usage)
+	if err != nil {
+		logger.Error("failed-to-add-resource-usage", err)
+		return
+	}
+}
+
 func (delegate *buildStepDelegate) Errored(logger lager.Logger, message string) {
 	err := delegate.build.SaveEvent(event.Error{
 		Message: message,
@@ -3,6 +3,7 @@ package builder_test
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"time"
 
@@ -156,6 +157,103 @@ var _ = Describe("DelegateFactory", func() {
 		})
 	})
 
+	Describe("AcrossStepDelegate", func() {
+		var delegate exec.AcrossStepDelegate
+
+		BeforeEach(func() {
+			delegate = builder.NewAcrossStepDelegate(fakeBuild, "some-plan-id", buildVars, fakeClock)
+		})
+
+		Describe("ConstructAcrossSubsteps", func() {
+			var (
+				template     atc.Plan
+				combinations [][]interface{}
+
+				substeps []atc.VarScopedPlan
+				err      error
+			)
+
+			BeforeEach(func() {
+				versionFrom := atc.PlanID("put")
+				template = atc.Plan{
+					ID: "on-success",
+					OnSuccess: &atc.OnSuccessPlan{
+						Step: atc.Plan{
+							ID:  "put",
+							Put: &atc.PutPlan{Name: "some-output"},
+						},
+						Next: atc.Plan{
+							ID:  "get",
+							Get: &atc.GetPlan{Name: "some-output", VersionFrom: &versionFrom},
+						},
+					},
+				}
+
+				combinations = [][]interface{}{{"a1", "b1"}, {"a2", "b1"}}
+			})
+
+			JustBeforeEach(func() {
+				substeps, err = delegate.ConstructAcrossSubsteps(template, combinations)
+			})
+
+			It("constructs a sub-step for each combination with unique plan IDs", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(substeps).To(HaveLen(2))
+
+				for i, substep := range substeps {
+					Expect(substep.Values).To(Equal(combinations[i]))
+
+					prefix := fmt.Sprintf("some-plan-id/%d/", i)
+					Expect(substep.Step.ID).To(Equal(atc.PlanID(prefix + "on-success")))
+					Expect(substep.Step.OnSuccess.Step.ID).To(Equal(atc.PlanID(prefix + "put")))
+					Expect(substep.Step.OnSuccess.Next.ID).To(Equal(atc.PlanID(prefix + "get")))
+					Expect(*substep.Step.OnSuccess.Next.Get.VersionFrom).To(Equal(atc.PlanID(prefix + "put")))
+				}
+			})
+
+			It("does not modify the template", func() {
+				Expect(template.ID).To(Equal(atc.PlanID("on-success")))
+				Expect(*template.OnSuccess.Next.Get.VersionFrom).To(Equal(atc.PlanID("put")))
+			})
+		})
+
+		Describe("SubstepsPlanned", func() {
+			var plan atc.AcrossPlan
+
+			BeforeEach(func() {
+				plan = atc.AcrossPlan{
+					Vars: []atc.AcrossVar{
+						{Var: "var1", Values: []interface{}{"a1", "a2"}},
+					},
+					Steps: []atc.VarScopedPlan{
+						{
+							Step:   atc.Plan{ID: "1", LoadVar: &atc.LoadVarPlan{Name: "some-var"}},
+							Values: []interface{}{"a1"},
+						},
+						{
+							Step:   atc.Plan{ID: "2", LoadVar: &atc.LoadVarPlan{Name: "some-var"}},
+							Values: []interface{}{"a2"},
+						},
+					},
+				}
+			})
+
+			JustBeforeEach(func() {
+				delegate.SubstepsPlanned(logger, plan)
+			})
+
+			It("saves an event with the public plans of the sub-steps", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.AcrossSubsteps{
+					Origin:   event.Origin{ID: "some-plan-id"},
+					Time:     123456789,
+					Vars:     []string{"var1"},
+					Substeps: []*json.RawMessage{plan.Steps[0].Public(), plan.Steps[1].Public()},
+				}))
+			})
+		})
+	})
+
 	Describe("PutDelegate", func() {
 		var (
 			delegate   exec.PutDelegate
@@ -484,6 +582,68 @@ var _ = Describe("DelegateFactory", func() {
 			})
 		})
 
+		Describe("ResourceUsage", func() {
+			var usage atc.ResourceUsage
+
+			BeforeEach(func() {
+				usage = atc.ResourceUsage{
+					CPUTime:         1000,
+					MemoryPeak:      2048,
+					RootfsDiskUsage: 4096,
+				}
+			})
+
+			JustBeforeEach(func() {
+				delegate.ResourceUsage(logger, usage)
+			})
+
+			It("saves an event with the current time", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.ResourceUsage{
+					Time:  123456789,
+					Usage: usage,
+					Origin: event.Origin{
+						ID: "some-plan-id",
+					},
+				}))
+			})
+
+			It("adds the usage to the build", func() {
+				Expect(fakeBuild.AddResourceUsageCallCount()).To(Equal(1))
+				Expect(fakeBuild.AddResourceUsageArgsForCall(0)).To(Equal(usage))
+			})
+
+			Context("when saving the event fails", func() {
+				BeforeEach(func() {
+					fakeBuild.SaveEventReturns(errors.New("nope"))
+				})
+
+				It("logs an error", func() {
+					logs := logger.Logs()
+					Expect(len(logs)).To(Equal(1))
+					Expect(logs[0].Message).To(Equal("test.failed-to-save-resource-usage-event"))
+					Expect(logs[0].Data).To(Equal(lager.Data{"error": "nope"}))
+				})
+
+				It("does not add the usage to the build", func() {
+					Expect(fakeBuild.AddResourceUsageCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when adding the usage to the build fails", func() {
+				BeforeEach(func() {
+					fakeBuild.AddResourceUsageReturns(errors.New("nope"))
+				})
+
+				It("logs an error", func() {
+					logs := logger.Logs()
+					Expect(len(logs)).To(Equal(1))
+					Expect(logs[0].Message).To(Equal("test.failed-to-add-resource-usage"))
+					Expect(logs[0].Data).To(Equal(lager.Data{"error": "nope"}))
+				})
+			})
+		})
+
 		Describe("Errored", func() {
 			JustBeforeEach(func() {
 				delegate.Errored(logger, "fake error message")
@@ -9,6 +9,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/worker"
 )
@@ -24,6 +25,7 @@ type stepFactory struct {
 	defaultLimits         atc.ContainerLimits
 	strategy              worker.ContainerPlacementStrategy
 	lockFactory           lock.LockFactory
+	policyChecker         *policy.Checker
 }
 
 func NewStepFactory(
@@ -37,6 +39,7 @@ func NewStepFactory(
 	defaultLimits atc.ContainerLimits,
 	strategy worker.ContainerPlacementStrategy,
 	lockFactory lock.LockFactory,
+	policyChecker *policy.Checker,
 ) *stepFactory {
 	return &stepFactory{
 		pool:                  pool,
@@ -49,6 +52,7 @@ func NewStepFactory(
 		defaultLimits:         defaultLimits,
 		strategy:              strategy,
 		lockFactory:           lockFactory,
+		policyChecker:         policyChecker,
 	}
 }
 
@@ -70,6 +74,7 @@ func (factory *stepFactory) GetStep(
 		factory.strategy,
 		delegate,
 		factory.client,
+		factory.policyChecker,
 	)
 
 	getStep = exec.LogError(getStep, delegate)
@@ -97,6 +102,7 @@ func (factory *stepFactory) PutStep(
 		factory.strategy,
 		factory.client,
 		delegate,
+		factory.policyChecker,
 	)
 
 	putStep = exec.LogError(putStep, delegate)
@@ -124,6 +130,7 @@ func (factory *stepFactory) CheckStep(
 		factory.pool,
 		delegate,
 		factory.client,
+		factory.policyChecker,
 	)
 
 	return checkStep
@@ -148,6 +155,7 @@ func (factory *stepFactory) TaskStep(
 		factory.client,
 		delegate,
 		factory.lockFactory,
+		factory.policyChecker,
 	)
 
 	taskStep = exec.LogError(taskStep, delegate)
@@ -170,6 +178,7 @@ func (factory *stepFactory) SetPipelineStep(
 		factory.teamFactory,
 		factory.buildFactory,
 		factory.client,
+		factory.policyChecker,
 	)
 
 	spStep = exec.LogError(spStep, delegate)
@@ -190,6 +199,7 @@ func (factory *stepFactory) LoadVarStep(
 		stepMetadata,
 		delegate,
 		factory.client,
+		factory.policyChecker,
 	)
 
 	loadVarStep = exec.LogError(loadVarStep, delegate)
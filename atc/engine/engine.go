@@ -43,6 +43,7 @@ type StepBuilder interface {
 	CheckStep(lager.Logger, db.Check) (exec.Step, error)
 
 	BuildStepErrored(lager.Logger, db.Build, error)
+	BuildStepFinished(lager.Logger, db.Build)
 }
 
 func NewEngine(builder StepBuilder) Engine {
@@ -191,7 +192,7 @@ func (b *engineBuild) Run(ctx context.Context) {
 		return
 	}
 	b.trackStarted(logger)
-	defer b.trackFinished(logger)
+	defer b.trackFinished(ctx, logger)
 
 	logger.Info("running")
 
@@ -249,6 +250,8 @@ func (b *engineBuild) Run(ctx context.Context) {
 }
 
 func (b *engineBuild) finish(logger lager.Logger, err error, succeeded bool) {
+	defer b.builder.BuildStepFinished(logger, b.build)
+
 	if errors.Is(err, context.Canceled) {
 		b.saveStatus(logger, atc.StatusAborted)
 		logger.Info("aborted")
@@ -283,7 +286,7 @@ func (b *engineBuild) trackStarted(logger lager.Logger) {
 	}.Emit(logger)
 }
 
-func (b *engineBuild) trackFinished(logger lager.Logger) {
+func (b *engineBuild) trackFinished(ctx context.Context, logger lager.Logger) {
 	found, err := b.build.Reload()
 	if err != nil {
 		logger.Error("failed-to-load-build-from-db", err)
@@ -304,6 +307,7 @@ func (b *engineBuild) trackFinished(logger lager.Logger) {
 			BuildStatus:   b.build.Status(),
 			BuildDuration: b.build.EndTime().Sub(b.build.StartTime()),
 			TeamName:      b.build.TeamName(),
+			TraceID:       tracing.TraceID(ctx),
 		}.Emit(logger)
 	}
 }
@@ -182,6 +182,11 @@ var _ = Describe("Engine", func() {
 									waitGroup.Wait()
 									Expect(fakeBuild.FinishCallCount()).To(Equal(0))
 								})
+
+								It("does not release the build's secrets", func() {
+									waitGroup.Wait()
+									Expect(fakeStepBuilder.BuildStepFinishedCallCount()).To(Equal(0))
+								})
 							})
 
 							Context("when the build is aborted", func() {
@@ -222,6 +227,13 @@ var _ = Describe("Engine", func() {
 										Expect(fakeBuild.FinishCallCount()).To(Equal(1))
 										Expect(fakeBuild.FinishArgsForCall(0)).To(Equal(db.BuildStatusSucceeded))
 									})
+
+									It("releases the build's secrets", func() {
+										waitGroup.Wait()
+										Expect(fakeStepBuilder.BuildStepFinishedCallCount()).To(Equal(1))
+										_, build := fakeStepBuilder.BuildStepFinishedArgsForCall(0)
+										Expect(build).To(Equal(fakeBuild))
+									})
 								})
 
 								Context("when the build finishes woefully", func() {
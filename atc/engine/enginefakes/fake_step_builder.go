@@ -32,6 +32,12 @@ type FakeStepBuilder struct {
 		arg2 db.Build
 		arg3 error
 	}
+	BuildStepFinishedStub        func(lager.Logger, db.Build)
+	buildStepFinishedMutex       sync.RWMutex
+	buildStepFinishedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 db.Build
+	}
 	CheckStepStub        func(lager.Logger, db.Check) (exec.Step, error)
 	checkStepMutex       sync.RWMutex
 	checkStepArgsForCall []struct {
@@ -147,6 +153,38 @@ func (fake *FakeStepBuilder) BuildStepErroredArgsForCall(i int) (lager.Logger, d
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
+func (fake *FakeStepBuilder) BuildStepFinished(arg1 lager.Logger, arg2 db.Build) {
+	fake.buildStepFinishedMutex.Lock()
+	fake.buildStepFinishedArgsForCall = append(fake.buildStepFinishedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 db.Build
+	}{arg1, arg2})
+	fake.recordInvocation("BuildStepFinished", []interface{}{arg1, arg2})
+	fake.buildStepFinishedMutex.Unlock()
+	if fake.BuildStepFinishedStub != nil {
+		fake.BuildStepFinishedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeStepBuilder) BuildStepFinishedCallCount() int {
+	fake.buildStepFinishedMutex.RLock()
+	defer fake.buildStepFinishedMutex.RUnlock()
+	return len(fake.buildStepFinishedArgsForCall)
+}
+
+func (fake *FakeStepBuilder) BuildStepFinishedCalls(stub func(lager.Logger, db.Build)) {
+	fake.buildStepFinishedMutex.Lock()
+	defer fake.buildStepFinishedMutex.Unlock()
+	fake.BuildStepFinishedStub = stub
+}
+
+func (fake *FakeStepBuilder) BuildStepFinishedArgsForCall(i int) (lager.Logger, db.Build) {
+	fake.buildStepFinishedMutex.RLock()
+	defer fake.buildStepFinishedMutex.RUnlock()
+	argsForCall := fake.buildStepFinishedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeStepBuilder) CheckStep(arg1 lager.Logger, arg2 db.Check) (exec.Step, error) {
 	fake.checkStepMutex.Lock()
 	ret, specificReturn := fake.checkStepReturnsOnCall[len(fake.checkStepArgsForCall)]
@@ -218,6 +256,8 @@ func (fake *FakeStepBuilder) Invocations() map[string][][]interface{} {
 	defer fake.buildStepMutex.RUnlock()
 	fake.buildStepErroredMutex.RLock()
 	defer fake.buildStepErroredMutex.RUnlock()
+	fake.buildStepFinishedMutex.RLock()
+	defer fake.buildStepFinishedMutex.RUnlock()
 	fake.checkStepMutex.RLock()
 	defer fake.checkStepMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -1,6 +1,8 @@
 package event
 
 import (
+	"encoding/json"
+
 	"github.com/concourse/concourse/atc"
 )
 
@@ -91,9 +93,10 @@ func (Status) EventType() atc.EventType  { return EventTypeStatus }
 func (Status) Version() atc.EventVersion { return "1.0" }
 
 type SelectedWorker struct {
-	Time       int64  `json:"time"`
-	Origin     Origin `json:"origin"`
-	WorkerName string `json:"selected_worker"`
+	Time       int64               `json:"time"`
+	Origin     Origin              `json:"origin"`
+	WorkerName string              `json:"selected_worker"`
+	Placement  []atc.PlacementStep `json:"placement,omitempty"`
 }
 
 func (SelectedWorker) EventType() atc.EventType  { return EventTypeSelectedWorker }
@@ -184,6 +187,30 @@ type SetPipelineChanged struct {
 func (SetPipelineChanged) EventType() atc.EventType  { return EventTypeSetPipelineChanged }
 func (SetPipelineChanged) Version() atc.EventVersion { return "1.0" }
 
+// AcrossSubsteps is emitted once an across step has planned a sub-step for
+// each combination of the values of its vars. Each sub-step is the public
+// plan of the step along with the values it runs with.
+type AcrossSubsteps struct {
+	Origin   Origin             `json:"origin"`
+	Time     int64              `json:"time,omitempty"`
+	Vars     []string           `json:"vars"`
+	Substeps []*json.RawMessage `json:"substeps"`
+}
+
+func (AcrossSubsteps) EventType() atc.EventType  { return EventTypeAcrossSubsteps }
+func (AcrossSubsteps) Version() atc.EventVersion { return "1.0" }
+
+// ResourceUsage is emitted once the container of a get, put or task step is
+// done running, with the resources it consumed.
+type ResourceUsage struct {
+	Origin Origin            `json:"origin"`
+	Time   int64             `json:"time,omitempty"`
+	Usage  atc.ResourceUsage `json:"usage"`
+}
+
+func (ResourceUsage) EventType() atc.EventType  { return EventTypeResourceUsage }
+func (ResourceUsage) Version() atc.EventVersion { return "1.0" }
+
 type Initialize struct {
 	Origin Origin `json:"origin"`
 	Time   int64  `json:"time,omitempty"`
@@ -41,6 +41,12 @@ const (
 
 	EventTypeSetPipelineChanged atc.EventType = "set-pipeline-changed"
 
+	// sub-steps of an across step planned at runtime
+	EventTypeAcrossSubsteps atc.EventType = "across-substeps"
+
+	// resources consumed by the container of a step
+	EventTypeResourceUsage atc.EventType = "resource-usage"
+
 	// initialize step
 	EventTypeInitialize atc.EventType = "initialize"
 
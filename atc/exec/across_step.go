@@ -6,38 +6,74 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/vars"
 )
 
-// AcrossStep is a step of steps to run in parallel. It behaves the same as InParallelStep
-// with the exception that an experimental warning is logged to stderr and that step
-// lifecycle build events are emitted (Initializing, Starting, and Finished)
+//go:generate counterfeiter . AcrossStepDelegate
+
+type AcrossStepDelegate interface {
+	BuildStepDelegate
+
+	// ConstructAcrossSubsteps plans a sub-step from the template for each of
+	// the combinations of values.
+	ConstructAcrossSubsteps(atc.Plan, [][]interface{}) ([]atc.VarScopedPlan, error)
+
+	// SubstepsPlanned records the combinations the sub-steps of the across
+	// step are run with.
+	SubstepsPlanned(lager.Logger, atc.AcrossPlan)
+}
+
+// AcrossSubstepsBuilder builds the step which runs the sub-steps of an
+// across plan.
+type AcrossSubstepsBuilder func(atc.AcrossPlan) Step
+
+// AcrossStep runs a sub-step for each combination of the values of its vars.
+//
+// When the values of every var are known up front the sub-steps are built
+// straight away. Otherwise the values are resolved when the step runs, e.g.
+// from a var set by a load_var step, and the sub-steps are planned from the
+// plan's template at that point.
+//
+// Step lifecycle build events (Initializing, Starting, and Finished) are
+// emitted for the across step itself, along with the combinations each of
+// its sub-steps runs with.
 type AcrossStep struct {
-	InParallelStep
-	varNames []string
+	plan          atc.AcrossPlan
+	buildSubsteps AcrossSubstepsBuilder
+
+	substeps Step
 
-	delegate BuildStepDelegate
+	delegate AcrossStepDelegate
 	metadata StepMetadata
 }
 
 // Across constructs an AcrossStep.
 func Across(
-	step InParallelStep,
-	varNames []string,
-	delegate BuildStepDelegate,
+	plan atc.AcrossPlan,
+	buildSubsteps AcrossSubstepsBuilder,
+	delegate AcrossStepDelegate,
 	metadata StepMetadata,
-) AcrossStep {
-	return AcrossStep{
-		InParallelStep: step,
-		varNames:       varNames,
-		delegate:       delegate,
-		metadata:       metadata,
+) *AcrossStep {
+	step := &AcrossStep{
+		plan:          plan,
+		buildSubsteps: buildSubsteps,
+		delegate:      delegate,
+		metadata:      metadata,
+	}
+
+	if plan.SubStepTemplate == nil {
+		step.substeps = buildSubsteps(plan)
 	}
+
+	return step
 }
 
-// Run calls out to InParallelStep.Run after logging a warning to stderr. It also emits
-// step lifecycle build events (Initializing, Starting, and Finished).
-func (step AcrossStep) Run(ctx context.Context, state RunState) error {
+// Run resolves the sub-steps if they could not be planned up front, and
+// then runs them. It also emits step lifecycle build events (Initializing,
+// Starting, and Finished).
+func (step *AcrossStep) Run(ctx context.Context, state RunState) error {
 	logger := lagerctx.FromContext(ctx)
 	logger = logger.Session("across-step", lager.Data{
 		"job-id": step.metadata.JobID,
@@ -47,23 +83,31 @@ func (step AcrossStep) Run(ctx context.Context, state RunState) error {
 
 	stderr := step.delegate.Stderr()
 
-	fmt.Fprintln(stderr, "\x1b[1;33mWARNING: the across step is experimental and subject to change!\x1b[0m")
-	fmt.Fprintln(stderr, "")
-	fmt.Fprintln(stderr, "\x1b[33mfollow RFC #29 for updates: https://github.com/concourse/rfcs/pull/29\x1b[0m")
-	fmt.Fprintln(stderr, "")
-
-	for _, varName := range step.varNames {
+	for _, v := range step.plan.Vars {
 		_, found, _ := step.delegate.Variables().Get(vars.VariableDefinition{
-			Ref: vars.VariableReference{Source: ".", Path: varName},
+			Ref: vars.VariableReference{Source: ".", Path: v.Var},
 		})
 		if found {
-			fmt.Fprintf(stderr, "\x1b[1;33mWARNING: across step shadows local var '%s'\x1b[0m\n", varName)
+			fmt.Fprintf(stderr, "\x1b[1;33mWARNING: across step shadows local var '%s'\x1b[0m\n", v.Var)
+		}
+	}
+
+	plan := step.plan
+	if step.substeps == nil {
+		var err error
+		plan, err = step.resolve()
+		if err != nil {
+			return err
 		}
+
+		step.substeps = step.buildSubsteps(plan)
 	}
 
+	step.delegate.SubstepsPlanned(logger, plan)
+
 	step.delegate.Starting(logger)
 
-	err := step.InParallelStep.Run(ctx, state)
+	err := step.substeps.Run(ctx, state)
 	if err != nil {
 		return err
 	}
@@ -72,3 +116,46 @@ func (step AcrossStep) Run(ctx context.Context, state RunState) error {
 
 	return nil
 }
+
+// resolve evaluates the values of each var and plans a sub-step for every
+// combination of them.
+func (step *AcrossStep) resolve() (atc.AcrossPlan, error) {
+	plan := step.plan
+
+	varNames := make([]string, len(plan.Vars))
+	values := make([][]interface{}, len(plan.Vars))
+	resolvedVars := make([]atc.AcrossVar, len(plan.Vars))
+	for i, v := range plan.Vars {
+		list, err := creds.NewList(step.delegate.Variables(), v.Values).Evaluate()
+		if err != nil {
+			return atc.AcrossPlan{}, fmt.Errorf("evaluate values of var '%s': %w", v.Var, err)
+		}
+
+		varNames[i] = v.Var
+		values[i] = list
+
+		v.Values = list
+		resolvedVars[i] = v
+	}
+
+	combinations := atc.AcrossCombinations(varNames, values, plan.Include, plan.Exclude)
+
+	substeps, err := step.delegate.ConstructAcrossSubsteps(*plan.SubStepTemplate, combinations)
+	if err != nil {
+		return atc.AcrossPlan{}, err
+	}
+
+	plan.Vars = resolvedVars
+	plan.Steps = substeps
+
+	return plan, nil
+}
+
+// Succeeded is true if the sub-steps were run and all of them succeeded.
+func (step *AcrossStep) Succeeded() bool {
+	if step.substeps == nil {
+		return false
+	}
+
+	return step.substeps.Succeeded()
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/vars"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -19,13 +20,15 @@ var _ = Describe("AcrossStep", func() {
 		ctx    context.Context
 		cancel func()
 
-		fakeDelegate *execfakes.FakeBuildStepDelegate
+		fakeDelegate *execfakes.FakeAcrossStepDelegate
 
 		buildVars *vars.BuildVariables
 
-		step     exec.InParallelStep
-		varNames []string
-		state    *execfakes.FakeRunState
+		plan          atc.AcrossPlan
+		fakeSubsteps  *execfakes.FakeStep
+		builtPlans    []atc.AcrossPlan
+		buildSubsteps exec.AcrossSubstepsBuilder
+		state         *execfakes.FakeRunState
 
 		stepMetadata = exec.StepMetadata{
 			TeamID:       123,
@@ -37,6 +40,9 @@ var _ = Describe("AcrossStep", func() {
 		}
 
 		stderr *gbytes.Buffer
+
+		step    *exec.AcrossStep
+		stepErr error
 	)
 
 	BeforeEach(func() {
@@ -49,10 +55,40 @@ var _ = Describe("AcrossStep", func() {
 
 		buildVars = vars.NewBuildVariables(vars.StaticVariables{}, false)
 
-		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		fakeDelegate = new(execfakes.FakeAcrossStepDelegate)
 		fakeDelegate.StderrReturns(stderr)
 
-		varNames = []string{"var1", "var2"}
+		plan = atc.AcrossPlan{
+			Vars: []atc.AcrossVar{
+				{
+					Var:    "var1",
+					Values: []interface{}{"a1", "a2"},
+				},
+				{
+					Var:    "var2",
+					Values: []interface{}{"b1"},
+				},
+			},
+			Steps: []atc.VarScopedPlan{
+				{
+					Step:   atc.Plan{ID: "1"},
+					Values: []interface{}{"a1", "b1"},
+				},
+				{
+					Step:   atc.Plan{ID: "2"},
+					Values: []interface{}{"a2", "b1"},
+				},
+			},
+		}
+
+		fakeSubsteps = new(execfakes.FakeStep)
+		fakeSubsteps.SucceededReturns(true)
+
+		builtPlans = nil
+		buildSubsteps = func(plan atc.AcrossPlan) exec.Step {
+			builtPlans = append(builtPlans, plan)
+			return fakeSubsteps
+		}
 	})
 
 	AfterEach(func() {
@@ -62,20 +98,34 @@ var _ = Describe("AcrossStep", func() {
 	JustBeforeEach(func() {
 		fakeDelegate.VariablesReturns(buildVars.NewLocalScope())
 
-		step := exec.Across(
-			step,
-			varNames,
+		step = exec.Across(
+			plan,
+			buildSubsteps,
 			fakeDelegate,
 			stepMetadata,
 		)
 
-		stepErr := step.Run(ctx, state)
+		stepErr = step.Run(ctx, state)
+	})
+
+	It("builds the sub-steps from the plan", func() {
+		Expect(builtPlans).To(Equal([]atc.AcrossPlan{plan}))
+	})
+
+	It("runs the sub-steps", func() {
 		Expect(stepErr).ToNot(HaveOccurred())
+		Expect(fakeSubsteps.RunCallCount()).To(Equal(1))
+		Expect(step.Succeeded()).To(BeTrue())
+	})
+
+	It("does not construct sub-steps at runtime", func() {
+		Expect(fakeDelegate.ConstructAcrossSubstepsCallCount()).To(Equal(0))
 	})
 
-	It("logs a warning to stderr", func() {
-		Expect(stderr).To(gbytes.Say("WARNING: the across step is experimental"))
-		Expect(stderr).To(gbytes.Say("follow RFC #29 for updates"))
+	It("records the sub-steps of the plan", func() {
+		Expect(fakeDelegate.SubstepsPlannedCallCount()).To(Equal(1))
+		_, plannedPlan := fakeDelegate.SubstepsPlannedArgsForCall(0)
+		Expect(plannedPlan).To(Equal(plan))
 	})
 
 	It("initializes the step", func() {
@@ -88,6 +138,8 @@ var _ = Describe("AcrossStep", func() {
 
 	It("finishes the step", func() {
 		Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+		_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+		Expect(succeeded).To(BeTrue())
 	})
 
 	Context("when a var shadows an existing local var", func() {
@@ -99,4 +151,84 @@ var _ = Describe("AcrossStep", func() {
 			Expect(stderr).To(gbytes.Say("WARNING: across step shadows local var 'var2'"))
 		})
 	})
+
+	Context("when the values of a var come from a local var", func() {
+		var substeps []atc.VarScopedPlan
+
+		BeforeEach(func() {
+			plan = atc.AcrossPlan{
+				Vars: []atc.AcrossVar{
+					{
+						Var:    "var1",
+						Values: "((.:some-values))",
+					},
+					{
+						Var:    "var2",
+						Values: []interface{}{"b1", "b2"},
+					},
+				},
+				Steps:           []atc.VarScopedPlan{},
+				SubStepTemplate: &atc.Plan{ID: "some-template"},
+				Exclude: []atc.AcrossCombination{
+					{"var2": "b1"},
+				},
+				Include: []atc.AcrossCombination{
+					{"var1": "a3", "var2": "b3"},
+				},
+			}
+
+			substeps = []atc.VarScopedPlan{
+				{Step: atc.Plan{ID: "1"}, Values: []interface{}{"a1", "b2"}},
+				{Step: atc.Plan{ID: "2"}, Values: []interface{}{"a2", "b2"}},
+				{Step: atc.Plan{ID: "3"}, Values: []interface{}{"a3", "b3"}},
+			}
+			fakeDelegate.ConstructAcrossSubstepsReturns(substeps, nil)
+		})
+
+		Context("when the local var is set", func() {
+			BeforeEach(func() {
+				buildVars.AddLocalVar("some-values", []interface{}{"a1", "a2"}, false)
+			})
+
+			It("constructs a sub-step for each combination of the resolved values", func() {
+				Expect(fakeDelegate.ConstructAcrossSubstepsCallCount()).To(Equal(1))
+				template, combinations := fakeDelegate.ConstructAcrossSubstepsArgsForCall(0)
+				Expect(template).To(Equal(atc.Plan{ID: "some-template"}))
+				Expect(combinations).To(Equal([][]interface{}{
+					{"a1", "b2"},
+					{"a2", "b2"},
+					{"a3", "b3"},
+				}))
+			})
+
+			It("builds the sub-steps with the resolved values", func() {
+				Expect(builtPlans).To(HaveLen(1))
+				Expect(builtPlans[0].Vars[0].Values).To(Equal([]interface{}{"a1", "a2"}))
+				Expect(builtPlans[0].Vars[1].Values).To(Equal([]interface{}{"b1", "b2"}))
+				Expect(builtPlans[0].Steps).To(Equal(substeps))
+			})
+
+			It("records the constructed sub-steps", func() {
+				Expect(fakeDelegate.SubstepsPlannedCallCount()).To(Equal(1))
+				_, plannedPlan := fakeDelegate.SubstepsPlannedArgsForCall(0)
+				Expect(plannedPlan).To(Equal(builtPlans[0]))
+			})
+
+			It("runs the sub-steps", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(fakeSubsteps.RunCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the local var is not set", func() {
+			It("errors", func() {
+				Expect(stepErr).To(MatchError(ContainSubstring("evaluate values of var 'var1'")))
+			})
+
+			It("does not run anything", func() {
+				Expect(fakeSubsteps.RunCallCount()).To(Equal(0))
+				Expect(step.Succeeded()).To(BeFalse())
+			})
+		})
+	})
 })
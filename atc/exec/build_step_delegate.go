@@ -24,7 +24,8 @@ type BuildStepDelegate interface {
 	Initializing(lager.Logger)
 	Starting(lager.Logger)
 	Finished(lager.Logger, bool)
-	SelectedWorker(lager.Logger, string)
+	SelectedWorker(lager.Logger, string, []atc.PlacementStep)
+	ResourceUsage(lager.Logger, atc.ResourceUsage)
 	Errored(lager.Logger, string)
 }
 
@@ -10,6 +10,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/tracing"
@@ -24,6 +25,7 @@ type CheckStep struct {
 	strategy          worker.ContainerPlacementStrategy
 	pool              worker.Pool
 	delegate          CheckDelegate
+	policyChecker     *policy.Checker
 	succeeded         bool
 	workerClient      worker.Client
 }
@@ -46,6 +48,7 @@ func NewCheckStep(
 	pool worker.Pool,
 	delegate CheckDelegate,
 	client worker.Client,
+	policyChecker *policy.Checker,
 ) *CheckStep {
 	return &CheckStep{
 		planID:            planID,
@@ -57,6 +60,7 @@ func NewCheckStep(
 		strategy:          strategy,
 		delegate:          delegate,
 		workerClient:      client,
+		policyChecker:     policyChecker,
 	}
 }
 
@@ -69,9 +73,13 @@ func (step *CheckStep) Run(ctx context.Context, state RunState) error {
 		"name":     step.plan.Name,
 	})
 
+	startTime := time.Now()
+
 	err := step.run(ctx, state)
 	tracing.End(span, err)
 
+	trackCheckFinished(ctx, step.metadata, step.plan.Name, err, startTime)
+
 	return err
 }
 
@@ -93,6 +101,19 @@ func (step *CheckStep) run(ctx context.Context, state RunState) error {
 		return fmt.Errorf("resource types creds evaluation: %w", err)
 	}
 
+	policyData, err := resourcePolicyData(step.delegate, step.plan.Name, step.plan.Name, step.plan.Type, source, step.plan.Tags, resourceTypes)
+	if err != nil {
+		return fmt.Errorf("redact source: %w", err)
+	}
+
+	err = checkStepPolicy(step.policyChecker, step.metadata, policy.ActionRunCheck, policyData, step.delegate.Stderr())
+	if err != nil {
+		// check steps aren't wrapped by LogError, so the reasons have to be
+		// surfaced here for them to be visible in the build
+		step.delegate.Errored(logger, err.Error())
+		return err
+	}
+
 	timeout, err := time.ParseDuration(step.plan.Timeout)
 	if err != nil {
 		return fmt.Errorf("timeout parse: %w", err)
@@ -116,6 +137,7 @@ func (step *CheckStep) run(ctx context.Context, state RunState) error {
 		Tags:          step.plan.Tags,
 		ResourceTypes: resourceTypes,
 		TeamID:        step.metadata.TeamID,
+		Privileged:    resourceTypes.Privileged(step.plan.Type),
 	}
 
 	expires := db.ContainerOwnerExpiries{
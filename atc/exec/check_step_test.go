@@ -9,6 +9,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/resource/resourcefakes"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
@@ -36,6 +37,7 @@ var _ = Describe("CheckStep", func() {
 		fakeStrategy        *workerfakes.FakeContainerPlacementStrategy
 		fakeDelegate        *execfakes.FakeCheckDelegate
 		fakeClient          *workerfakes.FakeClient
+		policyChecker       *policy.Checker
 
 		stepMetadata      exec.StepMetadata
 		checkStep         *exec.CheckStep
@@ -55,6 +57,7 @@ var _ = Describe("CheckStep", func() {
 		fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
 		fakeDelegate = new(execfakes.FakeCheckDelegate)
 		fakeClient = new(workerfakes.FakeClient)
+		policyChecker = nil
 
 		stepMetadata = exec.StepMetadata{}
 		containerMetadata = db.ContainerMetadata{}
@@ -79,6 +82,7 @@ var _ = Describe("CheckStep", func() {
 			fakePool,
 			fakeDelegate,
 			fakeClient,
+			policyChecker,
 		)
 
 		err = checkStep.Run(ctx, fakeRunState)
@@ -110,6 +114,42 @@ var _ = Describe("CheckStep", func() {
 		})
 	})
 
+	Context("when the check step is policy checked", func() {
+		BeforeEach(func() {
+			checkPlan = atc.CheckPlan{
+				Name:    "some-resource",
+				Type:    "some-type",
+				Source:  atc.Source{"some": "source"},
+				Timeout: "10s",
+			}
+
+			policyChecker = newPolicyChecker(policy.ActionRunCheck)
+			fakeDelegate.RedactImageSourceReturns(atc.Source{"some": "source"}, nil)
+		})
+
+		Context("when the policy check fails", func() {
+			BeforeEach(func() {
+				fakePolicyAgent.CheckReturns(policy.PolicyCheckOutput{
+					Allowed: false,
+					Reasons: []string{"untrusted source"},
+				}, nil)
+			})
+
+			It("fails the step with the reasons", func() {
+				Expect(err).To(Equal(policy.PolicyCheckNotPass{
+					Reasons: []string{"untrusted source"},
+				}))
+				Expect(fakeClient.RunCheckStepCallCount()).To(Equal(0))
+			})
+
+			It("emits the reasons as an error", func() {
+				Expect(fakeDelegate.ErroredCallCount()).To(Equal(1))
+				_, message := fakeDelegate.ErroredArgsForCall(0)
+				Expect(message).To(Equal("policy check failed: untrusted source"))
+			})
+		})
+	})
+
 	Context("having credentials in a resource type", func() {
 		BeforeEach(func() {
 			resTypes := atc.VersionedResourceTypes{
@@ -286,6 +326,28 @@ var _ = Describe("CheckStep", func() {
 			It("with teamid", func() {
 				Expect(workerSpec.TeamID).To(Equal(345))
 			})
+
+			It("without privileged", func() {
+				Expect(workerSpec.Privileged).To(BeFalse())
+			})
+
+			Context("when the resource type is privileged", func() {
+				BeforeEach(func() {
+					checkPlan.VersionedResourceTypes = atc.VersionedResourceTypes{
+						{
+							ResourceType: atc.ResourceType{
+								Name:       "resource-type",
+								Type:       "registry-image",
+								Privileged: true,
+							},
+						},
+					}
+				})
+
+				It("with privileged", func() {
+					Expect(workerSpec.Privileged).To(BeTrue())
+				})
+			})
 		})
 
 		It("uses container placement strategy", func() {
@@ -42,3 +42,27 @@ var _ = BeforeSuite(func() {
 
 	policy.RegisterAgent(fakePolicyAgentFactory)
 })
+
+// newPolicyChecker returns a policy checker checking the given action using
+// a fresh fakePolicyAgent.
+func newPolicyChecker(action string) *policy.Checker {
+	return initializePolicyChecker(action, false)
+}
+
+// newAuditOnlyPolicyChecker is like newPolicyChecker, but the checker lets
+// actions which do not pass the check through.
+func newAuditOnlyPolicyChecker(action string) *policy.Checker {
+	return initializePolicyChecker(action, true)
+}
+
+func initializePolicyChecker(action string, auditOnly bool) *policy.Checker {
+	fakePolicyAgent = new(policyfakes.FakeAgent)
+	fakePolicyAgentFactory.NewAgentReturns(fakePolicyAgent, nil)
+
+	checker, err := policy.Initialize(testLogger, "some-cluster", "some-version", policy.Filter{
+		Actions: []string{action},
+	}, auditOnly, nil)
+	Expect(err).ToNot(HaveOccurred())
+
+	return checker
+}
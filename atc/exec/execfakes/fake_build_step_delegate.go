@@ -54,11 +54,18 @@ type FakeBuildStepDelegate struct {
 		result1 atc.Source
 		result2 error
 	}
-	SelectedWorkerStub        func(lager.Logger, string)
+	ResourceUsageStub        func(lager.Logger, atc.ResourceUsage)
+	resourceUsageMutex       sync.RWMutex
+	resourceUsageArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.ResourceUsage
+	}
+	SelectedWorkerStub        func(lager.Logger, string, []atc.PlacementStep)
 	selectedWorkerMutex       sync.RWMutex
 	selectedWorkerArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 string
+		arg3 []atc.PlacementStep
 	}
 	StartingStub        func(lager.Logger)
 	startingMutex       sync.RWMutex
@@ -317,16 +324,54 @@ func (fake *FakeBuildStepDelegate) RedactImageSourceReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
-func (fake *FakeBuildStepDelegate) SelectedWorker(arg1 lager.Logger, arg2 string) {
+func (fake *FakeBuildStepDelegate) ResourceUsage(arg1 lager.Logger, arg2 atc.ResourceUsage) {
+	fake.resourceUsageMutex.Lock()
+	fake.resourceUsageArgsForCall = append(fake.resourceUsageArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.ResourceUsage
+	}{arg1, arg2})
+	fake.recordInvocation("ResourceUsage", []interface{}{arg1, arg2})
+	fake.resourceUsageMutex.Unlock()
+	if fake.ResourceUsageStub != nil {
+		fake.ResourceUsageStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeBuildStepDelegate) ResourceUsageCallCount() int {
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
+	return len(fake.resourceUsageArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) ResourceUsageCalls(stub func(lager.Logger, atc.ResourceUsage)) {
+	fake.resourceUsageMutex.Lock()
+	defer fake.resourceUsageMutex.Unlock()
+	fake.ResourceUsageStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) ResourceUsageArgsForCall(i int) (lager.Logger, atc.ResourceUsage) {
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
+	argsForCall := fake.resourceUsageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuildStepDelegate) SelectedWorker(arg1 lager.Logger, arg2 string, arg3 []atc.PlacementStep) {
+	var arg3Copy []atc.PlacementStep
+	if arg3 != nil {
+		arg3Copy = make([]atc.PlacementStep, len(arg3))
+		copy(arg3Copy, arg3)
+	}
 	fake.selectedWorkerMutex.Lock()
 	fake.selectedWorkerArgsForCall = append(fake.selectedWorkerArgsForCall, struct {
 		arg1 lager.Logger
 		arg2 string
-	}{arg1, arg2})
-	fake.recordInvocation("SelectedWorker", []interface{}{arg1, arg2})
+		arg3 []atc.PlacementStep
+	}{arg1, arg2, arg3Copy})
+	fake.recordInvocation("SelectedWorker", []interface{}{arg1, arg2, arg3Copy})
 	fake.selectedWorkerMutex.Unlock()
 	if fake.SelectedWorkerStub != nil {
-		fake.SelectedWorkerStub(arg1, arg2)
+		fake.SelectedWorkerStub(arg1, arg2, arg3)
 	}
 }
 
@@ -336,17 +381,17 @@ func (fake *FakeBuildStepDelegate) SelectedWorkerCallCount() int {
 	return len(fake.selectedWorkerArgsForCall)
 }
 
-func (fake *FakeBuildStepDelegate) SelectedWorkerCalls(stub func(lager.Logger, string)) {
+func (fake *FakeBuildStepDelegate) SelectedWorkerCalls(stub func(lager.Logger, string, []atc.PlacementStep)) {
 	fake.selectedWorkerMutex.Lock()
 	defer fake.selectedWorkerMutex.Unlock()
 	fake.SelectedWorkerStub = stub
 }
 
-func (fake *FakeBuildStepDelegate) SelectedWorkerArgsForCall(i int) (lager.Logger, string) {
+func (fake *FakeBuildStepDelegate) SelectedWorkerArgsForCall(i int) (lager.Logger, string, []atc.PlacementStep) {
 	fake.selectedWorkerMutex.RLock()
 	defer fake.selectedWorkerMutex.RUnlock()
 	argsForCall := fake.selectedWorkerArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeBuildStepDelegate) Starting(arg1 lager.Logger) {
@@ -549,6 +594,8 @@ func (fake *FakeBuildStepDelegate) Invocations() map[string][][]interface{} {
 	defer fake.initializingMutex.RUnlock()
 	fake.redactImageSourceMutex.RLock()
 	defer fake.redactImageSourceMutex.RUnlock()
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
 	fake.selectedWorkerMutex.RLock()
 	defer fake.selectedWorkerMutex.RUnlock()
 	fake.startingMutex.RLock()
@@ -54,6 +54,12 @@ type FakeCheckDelegate struct {
 		result1 atc.Source
 		result2 error
 	}
+	ResourceUsageStub        func(lager.Logger, atc.ResourceUsage)
+	resourceUsageMutex       sync.RWMutex
+	resourceUsageArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.ResourceUsage
+	}
 	SaveVersionsStub        func(db.SpanContext, []atc.Version) error
 	saveVersionsMutex       sync.RWMutex
 	saveVersionsArgsForCall []struct {
@@ -66,11 +72,12 @@ type FakeCheckDelegate struct {
 	saveVersionsReturnsOnCall map[int]struct {
 		result1 error
 	}
-	SelectedWorkerStub        func(lager.Logger, string)
+	SelectedWorkerStub        func(lager.Logger, string, []atc.PlacementStep)
 	selectedWorkerMutex       sync.RWMutex
 	selectedWorkerArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 string
+		arg3 []atc.PlacementStep
 	}
 	StartingStub        func(lager.Logger)
 	startingMutex       sync.RWMutex
@@ -329,6 +336,38 @@ func (fake *FakeCheckDelegate) RedactImageSourceReturnsOnCall(i int, result1 atc
 	}{result1, result2}
 }
 
+func (fake *FakeCheckDelegate) ResourceUsage(arg1 lager.Logger, arg2 atc.ResourceUsage) {
+	fake.resourceUsageMutex.Lock()
+	fake.resourceUsageArgsForCall = append(fake.resourceUsageArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.ResourceUsage
+	}{arg1, arg2})
+	fake.recordInvocation("ResourceUsage", []interface{}{arg1, arg2})
+	fake.resourceUsageMutex.Unlock()
+	if fake.ResourceUsageStub != nil {
+		fake.ResourceUsageStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeCheckDelegate) ResourceUsageCallCount() int {
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
+	return len(fake.resourceUsageArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) ResourceUsageCalls(stub func(lager.Logger, atc.ResourceUsage)) {
+	fake.resourceUsageMutex.Lock()
+	defer fake.resourceUsageMutex.Unlock()
+	fake.ResourceUsageStub = stub
+}
+
+func (fake *FakeCheckDelegate) ResourceUsageArgsForCall(i int) (lager.Logger, atc.ResourceUsage) {
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
+	argsForCall := fake.resourceUsageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeCheckDelegate) SaveVersions(arg1 db.SpanContext, arg2 []atc.Version) error {
 	var arg2Copy []atc.Version
 	if arg2 != nil {
@@ -395,16 +434,22 @@ func (fake *FakeCheckDelegate) SaveVersionsReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeCheckDelegate) SelectedWorker(arg1 lager.Logger, arg2 string) {
+func (fake *FakeCheckDelegate) SelectedWorker(arg1 lager.Logger, arg2 string, arg3 []atc.PlacementStep) {
+	var arg3Copy []atc.PlacementStep
+	if arg3 != nil {
+		arg3Copy = make([]atc.PlacementStep, len(arg3))
+		copy(arg3Copy, arg3)
+	}
 	fake.selectedWorkerMutex.Lock()
 	fake.selectedWorkerArgsForCall = append(fake.selectedWorkerArgsForCall, struct {
 		arg1 lager.Logger
 		arg2 string
-	}{arg1, arg2})
-	fake.recordInvocation("SelectedWorker", []interface{}{arg1, arg2})
+		arg3 []atc.PlacementStep
+	}{arg1, arg2, arg3Copy})
+	fake.recordInvocation("SelectedWorker", []interface{}{arg1, arg2, arg3Copy})
 	fake.selectedWorkerMutex.Unlock()
 	if fake.SelectedWorkerStub != nil {
-		fake.SelectedWorkerStub(arg1, arg2)
+		fake.SelectedWorkerStub(arg1, arg2, arg3)
 	}
 }
 
@@ -414,17 +459,17 @@ func (fake *FakeCheckDelegate) SelectedWorkerCallCount() int {
 	return len(fake.selectedWorkerArgsForCall)
 }
 
-func (fake *FakeCheckDelegate) SelectedWorkerCalls(stub func(lager.Logger, string)) {
+func (fake *FakeCheckDelegate) SelectedWorkerCalls(stub func(lager.Logger, string, []atc.PlacementStep)) {
 	fake.selectedWorkerMutex.Lock()
 	defer fake.selectedWorkerMutex.Unlock()
 	fake.SelectedWorkerStub = stub
 }
 
-func (fake *FakeCheckDelegate) SelectedWorkerArgsForCall(i int) (lager.Logger, string) {
+func (fake *FakeCheckDelegate) SelectedWorkerArgsForCall(i int) (lager.Logger, string, []atc.PlacementStep) {
 	fake.selectedWorkerMutex.RLock()
 	defer fake.selectedWorkerMutex.RUnlock()
 	argsForCall := fake.selectedWorkerArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeCheckDelegate) Starting(arg1 lager.Logger) {
@@ -627,6 +672,8 @@ func (fake *FakeCheckDelegate) Invocations() map[string][][]interface{} {
 	defer fake.initializingMutex.RUnlock()
 	fake.redactImageSourceMutex.RLock()
 	defer fake.redactImageSourceMutex.RUnlock()
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
 	fake.saveVersionsMutex.RLock()
 	defer fake.saveVersionsMutex.RUnlock()
 	fake.selectedWorkerMutex.RLock()
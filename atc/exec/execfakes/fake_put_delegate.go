@@ -56,6 +56,12 @@ type FakePutDelegate struct {
 		result1 atc.Source
 		result2 error
 	}
+	ResourceUsageStub        func(lager.Logger, atc.ResourceUsage)
+	resourceUsageMutex       sync.RWMutex
+	resourceUsageArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.ResourceUsage
+	}
 	SaveOutputStub        func(lager.Logger, atc.PutPlan, atc.Source, atc.VersionedResourceTypes, runtime.VersionResult)
 	saveOutputMutex       sync.RWMutex
 	saveOutputArgsForCall []struct {
@@ -65,11 +71,12 @@ type FakePutDelegate struct {
 		arg4 atc.VersionedResourceTypes
 		arg5 runtime.VersionResult
 	}
-	SelectedWorkerStub        func(lager.Logger, string)
+	SelectedWorkerStub        func(lager.Logger, string, []atc.PlacementStep)
 	selectedWorkerMutex       sync.RWMutex
 	selectedWorkerArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 string
+		arg3 []atc.PlacementStep
 	}
 	StartingStub        func(lager.Logger)
 	startingMutex       sync.RWMutex
@@ -329,6 +336,38 @@ func (fake *FakePutDelegate) RedactImageSourceReturnsOnCall(i int, result1 atc.S
 	}{result1, result2}
 }
 
+func (fake *FakePutDelegate) ResourceUsage(arg1 lager.Logger, arg2 atc.ResourceUsage) {
+	fake.resourceUsageMutex.Lock()
+	fake.resourceUsageArgsForCall = append(fake.resourceUsageArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.ResourceUsage
+	}{arg1, arg2})
+	fake.recordInvocation("ResourceUsage", []interface{}{arg1, arg2})
+	fake.resourceUsageMutex.Unlock()
+	if fake.ResourceUsageStub != nil {
+		fake.ResourceUsageStub(arg1, arg2)
+	}
+}
+
+func (fake *FakePutDelegate) ResourceUsageCallCount() int {
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
+	return len(fake.resourceUsageArgsForCall)
+}
+
+func (fake *FakePutDelegate) ResourceUsageCalls(stub func(lager.Logger, atc.ResourceUsage)) {
+	fake.resourceUsageMutex.Lock()
+	defer fake.resourceUsageMutex.Unlock()
+	fake.ResourceUsageStub = stub
+}
+
+func (fake *FakePutDelegate) ResourceUsageArgsForCall(i int) (lager.Logger, atc.ResourceUsage) {
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
+	argsForCall := fake.resourceUsageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakePutDelegate) SaveOutput(arg1 lager.Logger, arg2 atc.PutPlan, arg3 atc.Source, arg4 atc.VersionedResourceTypes, arg5 runtime.VersionResult) {
 	fake.saveOutputMutex.Lock()
 	fake.saveOutputArgsForCall = append(fake.saveOutputArgsForCall, struct {
@@ -364,16 +403,22 @@ func (fake *FakePutDelegate) SaveOutputArgsForCall(i int) (lager.Logger, atc.Put
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
 }
 
-func (fake *FakePutDelegate) SelectedWorker(arg1 lager.Logger, arg2 string) {
+func (fake *FakePutDelegate) SelectedWorker(arg1 lager.Logger, arg2 string, arg3 []atc.PlacementStep) {
+	var arg3Copy []atc.PlacementStep
+	if arg3 != nil {
+		arg3Copy = make([]atc.PlacementStep, len(arg3))
+		copy(arg3Copy, arg3)
+	}
 	fake.selectedWorkerMutex.Lock()
 	fake.selectedWorkerArgsForCall = append(fake.selectedWorkerArgsForCall, struct {
 		arg1 lager.Logger
 		arg2 string
-	}{arg1, arg2})
-	fake.recordInvocation("SelectedWorker", []interface{}{arg1, arg2})
+		arg3 []atc.PlacementStep
+	}{arg1, arg2, arg3Copy})
+	fake.recordInvocation("SelectedWorker", []interface{}{arg1, arg2, arg3Copy})
 	fake.selectedWorkerMutex.Unlock()
 	if fake.SelectedWorkerStub != nil {
-		fake.SelectedWorkerStub(arg1, arg2)
+		fake.SelectedWorkerStub(arg1, arg2, arg3)
 	}
 }
 
@@ -383,17 +428,17 @@ func (fake *FakePutDelegate) SelectedWorkerCallCount() int {
 	return len(fake.selectedWorkerArgsForCall)
 }
 
-func (fake *FakePutDelegate) SelectedWorkerCalls(stub func(lager.Logger, string)) {
+func (fake *FakePutDelegate) SelectedWorkerCalls(stub func(lager.Logger, string, []atc.PlacementStep)) {
 	fake.selectedWorkerMutex.Lock()
 	defer fake.selectedWorkerMutex.Unlock()
 	fake.SelectedWorkerStub = stub
 }
 
-func (fake *FakePutDelegate) SelectedWorkerArgsForCall(i int) (lager.Logger, string) {
+func (fake *FakePutDelegate) SelectedWorkerArgsForCall(i int) (lager.Logger, string, []atc.PlacementStep) {
 	fake.selectedWorkerMutex.RLock()
 	defer fake.selectedWorkerMutex.RUnlock()
 	argsForCall := fake.selectedWorkerArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakePutDelegate) Starting(arg1 lager.Logger) {
@@ -596,6 +641,8 @@ func (fake *FakePutDelegate) Invocations() map[string][][]interface{} {
 	defer fake.initializingMutex.RUnlock()
 	fake.redactImageSourceMutex.RLock()
 	defer fake.redactImageSourceMutex.RUnlock()
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
 	fake.saveOutputMutex.RLock()
 	defer fake.saveOutputMutex.RUnlock()
 	fake.selectedWorkerMutex.RLock()
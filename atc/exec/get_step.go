@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
@@ -11,6 +12,7 @@ import (
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/worker"
@@ -48,7 +50,8 @@ type GetDelegate interface {
 	Initializing(lager.Logger)
 	Starting(lager.Logger)
 	Finished(lager.Logger, ExitStatus, runtime.VersionResult)
-	SelectedWorker(lager.Logger, string)
+	SelectedWorker(lager.Logger, string, []atc.PlacementStep)
+	ResourceUsage(lager.Logger, atc.ResourceUsage)
 	Errored(lager.Logger, string)
 
 	UpdateVersion(lager.Logger, atc.GetPlan, runtime.VersionResult)
@@ -66,6 +69,7 @@ type GetStep struct {
 	strategy             worker.ContainerPlacementStrategy
 	workerClient         worker.Client
 	delegate             GetDelegate
+	policyChecker        *policy.Checker
 	succeeded            bool
 }
 
@@ -79,6 +83,7 @@ func NewGetStep(
 	strategy worker.ContainerPlacementStrategy,
 	delegate GetDelegate,
 	client worker.Client,
+	policyChecker *policy.Checker,
 ) Step {
 	return &GetStep{
 		planID:               planID,
@@ -90,6 +95,7 @@ func NewGetStep(
 		strategy:             strategy,
 		delegate:             delegate,
 		workerClient:         client,
+		policyChecker:        policyChecker,
 	}
 }
 func (step *GetStep) Run(ctx context.Context, state RunState) error {
@@ -102,9 +108,13 @@ func (step *GetStep) Run(ctx context.Context, state RunState) error {
 		"name":     step.plan.Name,
 	})
 
+	startTime := time.Now()
+
 	err := step.run(ctx, state)
 	tracing.End(span, err)
 
+	trackStepFinished(ctx, step.metadata, "get", step.plan.Name, startTime)
+
 	return err
 }
 
@@ -134,6 +144,16 @@ func (step *GetStep) run(ctx context.Context, state RunState) error {
 		return err
 	}
 
+	policyData, err := resourcePolicyData(step.delegate, step.plan.Name, step.plan.Resource, step.plan.Type, source, step.plan.Tags, resourceTypes)
+	if err != nil {
+		return err
+	}
+
+	err = checkStepPolicy(step.policyChecker, step.metadata, policy.ActionRunGet, policyData, step.delegate.Stderr())
+	if err != nil {
+		return err
+	}
+
 	version, err := NewVersionSourceFromPlan(&step.plan).Version(state)
 	if err != nil {
 		return err
@@ -153,6 +173,7 @@ func (step *GetStep) run(ctx context.Context, state RunState) error {
 		Tags:          step.plan.Tags,
 		TeamID:        step.metadata.TeamID,
 		ResourceTypes: resourceTypes,
+		Privileged:    resourceTypes.Privileged(step.plan.Type),
 	}
 
 	imageSpec := worker.ImageFetcherSpec{
@@ -202,6 +223,9 @@ func (step *GetStep) run(ctx context.Context, state RunState) error {
 		resourceCache,
 		resourceToGet,
 	)
+
+	trackResourceUsage(ctx, step.delegate, step.metadata, "get", step.plan.Name, getResult.ResourceUsage)
+
 	if err != nil {
 		return err
 	}
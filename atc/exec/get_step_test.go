@@ -10,6 +10,7 @@ import (
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/build"
 	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/resource/resourcefakes"
 	"github.com/concourse/concourse/atc/runtime"
@@ -43,6 +44,8 @@ var _ = Describe("GetStep", func() {
 
 		fakeDelegate *execfakes.FakeGetDelegate
 
+		policyChecker *policy.Checker
+
 		getPlan *atc.GetPlan
 
 		interpolatedResourceTypes atc.VersionedResourceTypes
@@ -102,6 +105,8 @@ var _ = Describe("GetStep", func() {
 		fakeDelegate.StdoutReturns(stdoutBuf)
 		fakeDelegate.StderrReturns(stderrBuf)
 
+		policyChecker = nil
+
 		uninterpolatedResourceTypes := atc.VersionedResourceTypes{
 			{
 				ResourceType: atc.ResourceType{
@@ -158,6 +163,7 @@ var _ = Describe("GetStep", func() {
 			fakeStrategy,
 			fakeDelegate,
 			fakeClient,
+			policyChecker,
 		)
 
 		getStepErr = getStep.Run(ctx, fakeState)
@@ -202,6 +208,18 @@ var _ = Describe("GetStep", func() {
 		))
 	})
 
+	Context("when the resource type is a privileged custom type", func() {
+		BeforeEach(func() {
+			getPlan.Type = "custom-resource"
+			getPlan.VersionedResourceTypes[0].Privileged = true
+		})
+
+		It("calls RunGetStep with a privileged WorkerSpec", func() {
+			_, _, _, _, actualWorkerSpec, _, _, _, _, _, _, _ := fakeClient.RunGetStepArgsForCall(0)
+			Expect(actualWorkerSpec.Privileged).To(BeTrue())
+		})
+	})
+
 	It("calls RunGetStep with the correct ContainerPlacementStrategy", func() {
 		_, _, _, _, _, actualStrategy, _, _, _, _, _, _ := fakeClient.RunGetStepArgsForCall(0)
 		Expect(actualStrategy).To(Equal(fakeStrategy))
@@ -282,6 +300,99 @@ var _ = Describe("GetStep", func() {
 		})
 	})
 
+	Context("when the get step is policy checked", func() {
+		BeforeEach(func() {
+			policyChecker = newPolicyChecker(policy.ActionRunGet)
+			fakeDelegate.RedactImageSourceReturns(atc.Source{"some": "((redacted))"}, nil)
+		})
+
+		Context("when the policy check passes", func() {
+			BeforeEach(func() {
+				fakePolicyAgent.CheckReturns(policy.PassedPolicyCheck(), nil)
+			})
+
+			It("checks the step with its redacted source", func() {
+				Expect(fakeDelegate.RedactImageSourceArgsForCall(0)).To(Equal(atc.Source{"some": "super-secret-source"}))
+
+				Expect(fakePolicyAgent.CheckCallCount()).To(Equal(1))
+				input := fakePolicyAgent.CheckArgsForCall(0)
+				Expect(input.Action).To(Equal(policy.ActionRunGet))
+				Expect(input.Team).To(Equal("some-team"))
+				Expect(input.Pipeline).To(Equal("some-pipeline"))
+				Expect(input.Data).To(Equal(map[string]interface{}{
+					"name":     "some-name",
+					"resource": "",
+					"type":     "some-resource-type",
+					"source":   atc.Source{"some": "((redacted))"},
+					"tags":     atc.Tags{"some", "tags"},
+					"job":      "",
+					"build_id": 42,
+				}))
+			})
+
+			It("runs the step", func() {
+				Expect(getStepErr).ToNot(HaveOccurred())
+				Expect(fakeClient.RunGetStepCallCount()).To(Equal(1))
+			})
+		})
+
+		Context("when the step uses a custom resource type", func() {
+			BeforeEach(func() {
+				getPlan.Type = "custom-resource"
+				fakeDelegate.RedactImageSourceReturnsOnCall(1, atc.Source{"some-custom": "((redacted))"}, nil)
+				fakePolicyAgent.CheckReturns(policy.PassedPolicyCheck(), nil)
+			})
+
+			It("checks the step with the redacted source of the resource type", func() {
+				Expect(fakeDelegate.RedactImageSourceArgsForCall(1)).To(Equal(atc.Source{"some-custom": "super-secret-source"}))
+
+				input := fakePolicyAgent.CheckArgsForCall(0)
+				Expect(input.Data).To(HaveKeyWithValue("resource_type", map[string]interface{}{
+					"name":       "custom-resource",
+					"type":       "custom-type",
+					"source":     atc.Source{"some-custom": "((redacted))"},
+					"privileged": false,
+					"tags":       atc.Tags(nil),
+				}))
+			})
+		})
+
+		Context("when the policy check fails", func() {
+			BeforeEach(func() {
+				fakePolicyAgent.CheckReturns(policy.PolicyCheckOutput{
+					Allowed: false,
+					Reasons: []string{"untrusted source"},
+				}, nil)
+			})
+
+			It("fails the step with the reasons", func() {
+				Expect(getStepErr).To(Equal(policy.PolicyCheckNotPass{
+					Reasons: []string{"untrusted source"},
+				}))
+				Expect(fakeClient.RunGetStepCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the policy check fails in audit-only mode", func() {
+			BeforeEach(func() {
+				policyChecker = newAuditOnlyPolicyChecker(policy.ActionRunGet)
+				fakePolicyAgent.CheckReturns(policy.PolicyCheckOutput{
+					Allowed: false,
+					Reasons: []string{"untrusted source"},
+				}, nil)
+			})
+
+			It("runs the step", func() {
+				Expect(getStepErr).ToNot(HaveOccurred())
+				Expect(fakeClient.RunGetStepCallCount()).To(Equal(1))
+			})
+
+			It("warns about the reasons", func() {
+				Expect(stderrBuf).To(gbytes.Say(`\[WARNING\] policy check failed \(allowed in audit-only mode\): untrusted source`))
+			})
+		})
+	})
+
 	Context("when Client.RunGetStep returns an err", func() {
 		var disaster error
 		BeforeEach(func() {
@@ -353,6 +464,23 @@ var _ = Describe("GetStep", func() {
 		It("does not return an err", func() {
 			Expect(getStepErr).ToNot(HaveOccurred())
 		})
+
+		Context("when the container reports its resource usage", func() {
+			BeforeEach(func() {
+				fakeClient.RunGetStepReturns(
+					worker.GetResult{
+						ExitStatus:    0,
+						GetArtifact:   runtime.GetArtifact{VolumeHandle: "some-volume-handle"},
+						ResourceUsage: atc.ResourceUsage{CPUTime: 1000, RootfsDiskUsage: 4096},
+					}, nil)
+			})
+
+			It("records the resource usage via the delegate", func() {
+				Expect(fakeDelegate.ResourceUsageCallCount()).To(Equal(1))
+				_, usage := fakeDelegate.ResourceUsageArgsForCall(0)
+				Expect(usage).To(Equal(atc.ResourceUsage{CPUTime: 1000, RootfsDiskUsage: 4096}))
+			})
+		})
 	})
 
 	Context("when Client.RunGetStep returns a Failed GetResult", func() {
@@ -16,18 +16,20 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/exec/artifact"
 	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/tracing"
 )
 
 // LoadVarStep loads a value from a file and sets it as a build-local var.
 type LoadVarStep struct {
-	planID    atc.PlanID
-	plan      atc.LoadVarPlan
-	metadata  StepMetadata
-	delegate  BuildStepDelegate
-	client    worker.Client
-	succeeded bool
+	planID        atc.PlanID
+	plan          atc.LoadVarPlan
+	metadata      StepMetadata
+	delegate      BuildStepDelegate
+	client        worker.Client
+	policyChecker *policy.Checker
+	succeeded     bool
 }
 
 func NewLoadVarStep(
@@ -36,13 +38,15 @@ func NewLoadVarStep(
 	metadata StepMetadata,
 	delegate BuildStepDelegate,
 	client worker.Client,
+	policyChecker *policy.Checker,
 ) Step {
 	return &LoadVarStep{
-		planID:   planID,
-		plan:     plan,
-		metadata: metadata,
-		delegate: delegate,
-		client:   client,
+		planID:        planID,
+		plan:          plan,
+		metadata:      metadata,
+		delegate:      delegate,
+		client:        client,
+		policyChecker: policyChecker,
 	}
 }
 
@@ -97,6 +101,16 @@ func (step *LoadVarStep) run(ctx context.Context, state RunState) error {
 	fmt.Fprintln(stderr, "\x1b[33mfollow RFC #27 for updates: https://github.com/concourse/rfcs/pull/27\x1b[0m")
 	fmt.Fprintln(stderr, "")
 
+	err := checkStepPolicy(step.policyChecker, step.metadata, policy.ActionRunLoadVar, map[string]interface{}{
+		"name":   step.plan.Name,
+		"file":   step.plan.File,
+		"format": step.plan.Format,
+		"reveal": step.plan.Reveal,
+	}, step.delegate.Stderr())
+	if err != nil {
+		return err
+	}
+
 	step.delegate.Starting(logger)
 
 	value, err := step.fetchVars(ctx, logger, step.plan.File, state)
@@ -106,6 +106,7 @@ var _ = Describe("LoadVarStep", func() {
 			stepMetadata,
 			fakeDelegate,
 			fakeWorkerClient,
+			nil,
 		)
 
 		stepErr = spStep.Run(ctx, state)
@@ -3,12 +3,14 @@ package exec
 import (
 	"context"
 	"io"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/worker"
@@ -30,7 +32,8 @@ type PutDelegate interface {
 	Initializing(lager.Logger)
 	Starting(lager.Logger)
 	Finished(lager.Logger, ExitStatus, runtime.VersionResult)
-	SelectedWorker(lager.Logger, string)
+	SelectedWorker(lager.Logger, string, []atc.PlacementStep)
+	ResourceUsage(lager.Logger, atc.ResourceUsage)
 	Errored(lager.Logger, string)
 
 	SaveOutput(lager.Logger, atc.PutPlan, atc.Source, atc.VersionedResourceTypes, runtime.VersionResult)
@@ -48,6 +51,7 @@ type PutStep struct {
 	strategy              worker.ContainerPlacementStrategy
 	workerClient          worker.Client
 	delegate              PutDelegate
+	policyChecker         *policy.Checker
 	succeeded             bool
 }
 
@@ -61,6 +65,7 @@ func NewPutStep(
 	strategy worker.ContainerPlacementStrategy,
 	workerClient worker.Client,
 	delegate PutDelegate,
+	policyChecker *policy.Checker,
 ) Step {
 	return &PutStep{
 		planID:                planID,
@@ -72,6 +77,7 @@ func NewPutStep(
 		workerClient:          workerClient,
 		strategy:              strategy,
 		delegate:              delegate,
+		policyChecker:         policyChecker,
 	}
 }
 
@@ -93,9 +99,13 @@ func (step *PutStep) Run(ctx context.Context, state RunState) error {
 		"name":     step.plan.Name,
 	})
 
+	startTime := time.Now()
+
 	err := step.run(ctx, state)
 	tracing.End(span, err)
 
+	trackStepFinished(ctx, step.metadata, "put", step.plan.Name, startTime)
+
 	return err
 }
 
@@ -125,6 +135,16 @@ func (step *PutStep) run(ctx context.Context, state RunState) error {
 		return err
 	}
 
+	policyData, err := resourcePolicyData(step.delegate, step.plan.Name, step.plan.Resource, step.plan.Type, source, step.plan.Tags, resourceTypes)
+	if err != nil {
+		return err
+	}
+
+	err = checkStepPolicy(step.policyChecker, step.metadata, policy.ActionRunPut, policyData, step.delegate.Stderr())
+	if err != nil {
+		return err
+	}
+
 	var putInputs PutInputs
 	if step.plan.Inputs == nil {
 		// Put step defaults to all inputs if not specified
@@ -165,6 +185,7 @@ func (step *PutStep) run(ctx context.Context, state RunState) error {
 		Tags:          step.plan.Tags,
 		TeamID:        step.metadata.TeamID,
 		ResourceTypes: resourceTypes,
+		Privileged:    resourceTypes.Privileged(step.plan.Type),
 	}
 
 	owner := db.NewBuildStepContainerOwner(step.metadata.BuildID, step.planID, step.metadata.TeamID)
@@ -200,6 +221,9 @@ func (step *PutStep) run(ctx context.Context, state RunState) error {
 		step.delegate,
 		resourceToPut,
 	)
+
+	trackResourceUsage(ctx, step.delegate, step.metadata, "put", step.plan.Name, result.ResourceUsage)
+
 	if err != nil {
 		logger.Error("failed-to-put-resource", err)
 		return err
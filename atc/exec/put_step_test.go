@@ -77,6 +77,7 @@ var _ = Describe("PutStep", func() {
 		versionResult  runtime.VersionResult
 		clientErr      error
 		someExitStatus int
+		resourceUsage  atc.ResourceUsage
 	)
 
 	BeforeEach(func() {
@@ -156,6 +157,7 @@ var _ = Describe("PutStep", func() {
 
 		someExitStatus = 0
 		clientErr = nil
+		resourceUsage = atc.ResourceUsage{}
 	})
 
 	AfterEach(func() {
@@ -169,7 +171,7 @@ var _ = Describe("PutStep", func() {
 		}
 
 		fakeClient.RunPutStepReturns(
-			worker.PutResult{ExitStatus: someExitStatus, VersionResult: versionResult},
+			worker.PutResult{ExitStatus: someExitStatus, VersionResult: versionResult, ResourceUsage: resourceUsage},
 			clientErr,
 		)
 
@@ -183,6 +185,7 @@ var _ = Describe("PutStep", func() {
 			fakeStrategy,
 			fakeClient,
 			fakeDelegate,
+			nil,
 		)
 
 		stepErr = putStep.Run(ctx, state)
@@ -318,6 +321,18 @@ var _ = Describe("PutStep", func() {
 		Expect(actualResource).To(Equal(fakeResource))
 	})
 
+	Context("when the resource type is a privileged custom type", func() {
+		BeforeEach(func() {
+			putPlan.Type = "custom-resource"
+			putPlan.VersionedResourceTypes[0].Privileged = true
+		})
+
+		It("runs the put step with a privileged WorkerSpec", func() {
+			_, _, _, _, actualWorkerSpec, _, _, _, _, _, _ := fakeClient.RunPutStepArgsForCall(0)
+			Expect(actualWorkerSpec.Privileged).To(BeTrue())
+		})
+	})
+
 	Context("when tracing is enabled", func() {
 		var buildSpan trace.Span
 
@@ -422,6 +437,22 @@ var _ = Describe("PutStep", func() {
 		It("is successful", func() {
 			Expect(putStep.Succeeded()).To(BeTrue())
 		})
+
+		It("does not record any resource usage", func() {
+			Expect(fakeDelegate.ResourceUsageCallCount()).To(BeZero())
+		})
+
+		Context("when the container reports its resource usage", func() {
+			BeforeEach(func() {
+				resourceUsage = atc.ResourceUsage{CPUTime: 1000, MemoryPeak: 2048}
+			})
+
+			It("records the resource usage via the delegate", func() {
+				Expect(fakeDelegate.ResourceUsageCallCount()).To(Equal(1))
+				_, usage := fakeDelegate.ResourceUsageArgsForCall(0)
+				Expect(usage).To(Equal(atc.ResourceUsage{CPUTime: 1000, MemoryPeak: 2048}))
+			})
+		})
 	})
 
 	Context("when RunPutStep exits unsuccessfully", func() {
@@ -21,6 +21,7 @@ import (
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/vars"
 )
 
@@ -89,6 +90,8 @@ jobs:
 
 		fakeWorkerClient *workerfakes.FakeClient
 
+		policyChecker *policy.Checker
+
 		spPlan             *atc.SetPipelinePlan
 		artifactRepository *build.Repository
 		state              *execfakes.FakeRunState
@@ -162,6 +165,8 @@ jobs:
 
 		fakeWorkerClient = new(workerfakes.FakeClient)
 
+		policyChecker = nil
+
 		spPlan = &atc.SetPipelinePlan{
 			Name: "some-pipeline",
 			File: "some-resource/pipeline.yml",
@@ -186,6 +191,7 @@ jobs:
 			fakeTeamFactory,
 			fakeBuildFactory,
 			fakeWorkerClient,
+			policyChecker,
 		)
 
 		stepErr = spStep.Run(ctx, state)
@@ -261,8 +267,8 @@ jobs:
 
 				It("should save the pipeline", func() {
 					Expect(fakeBuild.SavePipelineCallCount()).To(Equal(1))
-					name, _, _, _, paused := fakeBuild.SavePipelineArgsForCall(0)
-					Expect(name).To(Equal("some-pipeline"))
+					pipelineRef, _, _, _, paused := fakeBuild.SavePipelineArgsForCall(0)
+					Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
 					Expect(paused).To(BeFalse())
 				})
 
@@ -344,8 +350,8 @@ jobs:
 
 				It("should save the pipeline un-paused", func() {
 					Expect(fakeBuild.SavePipelineCallCount()).To(Equal(1))
-					name, _, _, _, paused := fakeBuild.SavePipelineArgsForCall(0)
-					Expect(name).To(Equal("some-pipeline"))
+					pipelineRef, _, _, _, paused := fakeBuild.SavePipelineArgsForCall(0)
+					Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
 					Expect(paused).To(BeFalse())
 				})
 
@@ -373,8 +379,8 @@ jobs:
 
 				It("should save the pipeline itself", func() {
 					Expect(fakeBuild.SavePipelineCallCount()).To(Equal(1))
-					name, _, _, _, _ := fakeBuild.SavePipelineArgsForCall(0)
-					Expect(name).To(Equal("some-pipeline"))
+					pipelineRef, _, _, _, _ := fakeBuild.SavePipelineArgsForCall(0)
+					Expect(pipelineRef).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
 				})
 
 				It("should save to the current team", func() {
@@ -490,6 +496,49 @@ jobs:
 								_, succeeded := fakeDelegate.FinishedArgsForCall(0)
 								Expect(succeeded).To(BeTrue())
 							})
+
+							Context("when the step is policy checked", func() {
+								BeforeEach(func() {
+									policyChecker = newPolicyChecker(policy.ActionRunSetPipeline)
+								})
+
+								Context("when the policy check passes", func() {
+									BeforeEach(func() {
+										fakePolicyAgent.CheckReturns(policy.PassedPolicyCheck(), nil)
+									})
+
+									It("checks the step with the target team", func() {
+										Expect(fakePolicyAgent.CheckCallCount()).To(Equal(1))
+										input := fakePolicyAgent.CheckArgsForCall(0)
+										Expect(input.Action).To(Equal(policy.ActionRunSetPipeline))
+										Expect(input.Team).To(Equal("main"))
+										Expect(input.Data).To(HaveKeyWithValue("team", "some-team"))
+										Expect(input.Data).To(HaveKeyWithValue("name", "some-pipeline"))
+										Expect(input.Data).To(HaveKeyWithValue("file", "some-resource/pipeline.yml"))
+									})
+
+									It("saves the pipeline", func() {
+										Expect(stepErr).ToNot(HaveOccurred())
+										Expect(fakeBuild.SavePipelineCallCount()).To(Equal(1))
+									})
+								})
+
+								Context("when the policy check fails", func() {
+									BeforeEach(func() {
+										fakePolicyAgent.CheckReturns(policy.PolicyCheckOutput{
+											Allowed: false,
+											Reasons: []string{"cannot set pipelines of other teams"},
+										}, nil)
+									})
+
+									It("fails the step without saving the pipeline", func() {
+										Expect(stepErr).To(Equal(policy.PolicyCheckNotPass{
+											Reasons: []string{"cannot set pipelines of other teams"},
+										}))
+										Expect(fakeBuild.SavePipelineCallCount()).To(BeZero())
+									})
+								})
+							})
 						})
 
 						Context("when the current team is not an admin team", func() {
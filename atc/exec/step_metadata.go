@@ -1,7 +1,10 @@
 package exec
 
 import (
+	"encoding/json"
 	"fmt"
+
+	"github.com/concourse/concourse/atc"
 )
 
 type StepMetadata struct {
@@ -13,10 +16,12 @@ type StepMetadata struct {
 	JobName               string
 	PipelineID            int
 	PipelineName          string
+	PipelineInstanceVars  atc.InstanceVars
 	ResourceConfigScopeID int
 	ResourceConfigID      int
 	BaseResourceTypeID    int
 	ExternalURL           string
+	BuildPriority         int
 }
 
 func (metadata StepMetadata) Env() []string {
@@ -54,6 +59,11 @@ func (metadata StepMetadata) Env() []string {
 		env = append(env, "BUILD_PIPELINE_NAME="+metadata.PipelineName)
 	}
 
+	if len(metadata.PipelineInstanceVars) != 0 {
+		instanceVars, _ := json.Marshal(metadata.PipelineInstanceVars)
+		env = append(env, "BUILD_PIPELINE_INSTANCE_VARS="+string(instanceVars))
+	}
+
 	if metadata.ExternalURL != "" {
 		env = append(env, "ATC_EXTERNAL_URL="+metadata.ExternalURL)
 	}
@@ -0,0 +1,77 @@
+package exec
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
+	"github.com/concourse/concourse/tracing"
+)
+
+// trackStepFinished emits the duration of a step that started running at the
+// given time, along with the build and trace it belongs to.
+func trackStepFinished(ctx context.Context, metadata StepMetadata, stepType string, stepName string, startTime time.Time) {
+	metric.StepFinished{
+		PipelineName: metadata.PipelineName,
+		JobName:      metadata.JobName,
+		BuildName:    metadata.BuildName,
+		BuildID:      metadata.BuildID,
+		TeamName:     metadata.TeamName,
+		StepName:     stepName,
+		StepType:     stepType,
+		StepDuration: time.Since(startTime),
+		TraceID:      tracing.TraceID(ctx),
+	}.Emit(lagerctx.FromContext(ctx))
+}
+
+// trackResourceUsage records the resources consumed by the container of a
+// step with its build and emits them, unless none could be sampled.
+func trackResourceUsage(ctx context.Context, delegate resourceUsageDelegate, metadata StepMetadata, stepType string, stepName string, usage atc.ResourceUsage) {
+	if usage == (atc.ResourceUsage{}) {
+		return
+	}
+
+	logger := lagerctx.FromContext(ctx)
+
+	delegate.ResourceUsage(logger, usage)
+
+	metric.StepResourceUsage{
+		PipelineName:    metadata.PipelineName,
+		JobName:         metadata.JobName,
+		BuildName:       metadata.BuildName,
+		BuildID:         metadata.BuildID,
+		TeamName:        metadata.TeamName,
+		StepName:        stepName,
+		StepType:        stepType,
+		CPUTime:         time.Duration(usage.CPUTime),
+		MemoryPeak:      usage.MemoryPeak,
+		RootfsDiskUsage: usage.RootfsDiskUsage,
+		TraceID:         tracing.TraceID(ctx),
+	}.Emit(logger)
+}
+
+type resourceUsageDelegate interface {
+	ResourceUsage(lager.Logger, atc.ResourceUsage)
+}
+
+// trackCheckFinished emits the duration and outcome of a check that started
+// running at the given time, along with the trace it belongs to.
+func trackCheckFinished(ctx context.Context, metadata StepMetadata, resourceName string, err error, startTime time.Time) {
+	status := db.CheckStatusSucceeded
+	if err != nil {
+		status = db.CheckStatusErrored
+	}
+
+	metric.CheckFinished{
+		PipelineName:  metadata.PipelineName,
+		ResourceName:  resourceName,
+		TeamName:      metadata.TeamName,
+		CheckStatus:   status,
+		CheckDuration: time.Since(startTime),
+		TraceID:       tracing.TraceID(ctx),
+	}.Emit(lagerctx.FromContext(ctx))
+}
@@ -0,0 +1,86 @@
+package exec
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/policy"
+)
+
+// checkStepPolicy asks the policy checker whether the step described by data
+// is allowed to run. A rejected step results in a policy.PolicyCheckNotPass
+// error carrying the reasons, which fails the step. A step which is only let
+// through because the checker is in audit-only mode gets the reasons printed
+// to stderr as a warning instead.
+func checkStepPolicy(checker *policy.Checker, metadata StepMetadata, action string, data map[string]interface{}, stderr io.Writer) error {
+	if checker == nil || !checker.ShouldCheckAction(action) {
+		return nil
+	}
+
+	data["job"] = metadata.JobName
+	data["build_id"] = metadata.BuildID
+
+	result, err := checker.Check(policy.PolicyCheckInput{
+		Action:   action,
+		Team:     metadata.TeamName,
+		Pipeline: metadata.PipelineName,
+		Data:     data,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !result.Allowed {
+		return policy.PolicyCheckNotPass{
+			Reasons: result.Reasons,
+		}
+	}
+
+	if result.Warned {
+		fmt.Fprintln(stderr, "[WARNING] policy check failed (allowed in audit-only mode):", strings.Join(result.Reasons, ", "))
+	}
+
+	return nil
+}
+
+type sourceRedactor interface {
+	RedactImageSource(atc.Source) (atc.Source, error)
+}
+
+// resourcePolicyData describes a get, put or check step, with credentials
+// redacted from its source. When the step uses a custom resource type, the
+// resource type is described as well, so that policies can act on its source.
+func resourcePolicyData(redactor sourceRedactor, name string, resource string, resourceType string, source atc.Source, tags atc.Tags, resourceTypes atc.VersionedResourceTypes) (map[string]interface{}, error) {
+	redactedSource, err := redactor.RedactImageSource(source)
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string]interface{}{
+		"name":     name,
+		"resource": resource,
+		"type":     resourceType,
+		"source":   redactedSource,
+		"tags":     tags,
+	}
+
+	customType, found := resourceTypes.Lookup(resourceType)
+	if found {
+		redactedTypeSource, err := redactor.RedactImageSource(customType.Source)
+		if err != nil {
+			return nil, err
+		}
+
+		data["resource_type"] = map[string]interface{}{
+			"name":       customType.Name,
+			"type":       customType.Type,
+			"source":     redactedTypeSource,
+			"privileged": customType.Privileged,
+			"tags":       customType.Tags,
+		}
+	}
+
+	return data, nil
+}
@@ -7,6 +7,7 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
@@ -15,6 +16,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/tracing"
@@ -66,7 +68,8 @@ type TaskDelegate interface {
 	Initializing(lager.Logger)
 	Starting(lager.Logger)
 	Finished(lager.Logger, ExitStatus)
-	SelectedWorker(lager.Logger, string)
+	SelectedWorker(lager.Logger, string, []atc.PlacementStep)
+	ResourceUsage(lager.Logger, atc.ResourceUsage)
 	Errored(lager.Logger, string)
 }
 
@@ -82,6 +85,7 @@ type TaskStep struct {
 	workerClient      worker.Client
 	delegate          TaskDelegate
 	lockFactory       lock.LockFactory
+	policyChecker     *policy.Checker
 	succeeded         bool
 }
 
@@ -95,6 +99,7 @@ func NewTaskStep(
 	workerClient worker.Client,
 	delegate TaskDelegate,
 	lockFactory lock.LockFactory,
+	policyChecker *policy.Checker,
 ) Step {
 	return &TaskStep{
 		planID:            planID,
@@ -106,6 +111,7 @@ func NewTaskStep(
 		workerClient:      workerClient,
 		delegate:          delegate,
 		lockFactory:       lockFactory,
+		policyChecker:     policyChecker,
 	}
 }
 
@@ -133,9 +139,13 @@ func (step *TaskStep) Run(ctx context.Context, state RunState) error {
 		"name":     step.plan.Name,
 	})
 
+	startTime := time.Now()
+
 	err := step.run(ctx, state)
 	tracing.End(span, err)
 
+	trackStepFinished(ctx, step.metadata, "task", step.plan.Name, startTime)
+
 	return err
 }
 
@@ -214,6 +224,17 @@ func (step *TaskStep) run(ctx context.Context, state RunState) error {
 		config.Limits.Memory = step.defaultLimits.Memory
 	}
 
+	err = checkStepPolicy(step.policyChecker, step.metadata, policy.ActionRunTask, map[string]interface{}{
+		"name":       step.plan.Name,
+		"privileged": bool(step.plan.Privileged),
+		"platform":   config.Platform,
+		"tags":       step.plan.Tags,
+		"limits":     config.Limits,
+	}, step.delegate.Stderr())
+	if err != nil {
+		return err
+	}
+
 	step.delegate.Initializing(logger)
 
 	workerSpec, err := step.workerSpec(logger, resourceTypes, repository, config)
@@ -256,6 +277,8 @@ func (step *TaskStep) run(ctx context.Context, state RunState) error {
 		step.lockFactory,
 	)
 
+	trackResourceUsage(ctx, step.delegate, step.metadata, "task", step.plan.Name, result.ResourceUsage)
+
 	if err != nil {
 		if err == context.Canceled || err == context.DeadlineExceeded {
 			step.registerOutputs(logger, repository, config, result.VolumeMounts, step.containerMetadata)
@@ -386,6 +409,8 @@ func (step *TaskStep) containerSpec(logger lager.Logger, repository *build.Repos
 		Env:       config.Params.Env(),
 		Type:      metadata.Type,
 
+		SecurityProfile: config.SecurityProfile,
+
 		Outputs: worker.OutputPaths{},
 	}
 
@@ -408,6 +433,10 @@ func (step *TaskStep) workerSpec(logger lager.Logger, resourceTypes atc.Versione
 		Tags:          step.plan.Tags,
 		TeamID:        step.metadata.TeamID,
 		ResourceTypes: resourceTypes,
+		Priority:      step.metadata.BuildPriority,
+		Privileged:    bool(step.plan.Privileged),
+
+		SecurityProfile: config.SecurityProfile,
 	}
 
 	imageSpec, err := step.imageSpec(logger, repository, config)
@@ -11,6 +11,7 @@ import (
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/build"
 	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/runtime"
 	"github.com/concourse/concourse/atc/runtime/runtimefakes"
 	"github.com/concourse/concourse/atc/worker"
@@ -38,6 +39,8 @@ var _ = Describe("TaskStep", func() {
 
 		fakeLockFactory *lockfakes.FakeLockFactory
 
+		policyChecker *policy.Checker
+
 		fakeDelegate *execfakes.FakeTaskDelegate
 		taskPlan     *atc.TaskPlan
 
@@ -77,6 +80,8 @@ var _ = Describe("TaskStep", func() {
 
 		fakeLockFactory = new(lockfakes.FakeLockFactory)
 
+		policyChecker = nil
+
 		credVars := vars.StaticVariables{"source-param": "super-secret-source"}
 		buildVars = vars.NewBuildVariables(credVars, true)
 
@@ -138,6 +143,7 @@ var _ = Describe("TaskStep", func() {
 			fakeClient,
 			fakeDelegate,
 			fakeLockFactory,
+			policyChecker,
 		)
 
 		stepErr = taskStep.Run(ctx, state)
@@ -287,6 +293,78 @@ var _ = Describe("TaskStep", func() {
 				_, _, _, containerSpec, _, _, _, _, _, _, _ := fakeClient.RunTaskStepArgsForCall(0)
 				Expect(containerSpec.ImageSpec.Privileged).To(BeTrue())
 			})
+
+			It("requires a worker which runs privileged containers", func() {
+				Expect(fakeClient.RunTaskStepCallCount()).To(Equal(1))
+				_, _, _, _, workerSpec, _, _, _, _, _, _ := fakeClient.RunTaskStepArgsForCall(0)
+				Expect(workerSpec.Privileged).To(BeTrue())
+			})
+		})
+
+		Context("when the task is policy checked", func() {
+			BeforeEach(func() {
+				taskPlan.Privileged = true
+				policyChecker = newPolicyChecker(policy.ActionRunTask)
+			})
+
+			Context("when the policy check passes", func() {
+				BeforeEach(func() {
+					fakePolicyAgent.CheckReturns(policy.PassedPolicyCheck(), nil)
+				})
+
+				It("checks the task with its privileged flag and limits", func() {
+					Expect(fakePolicyAgent.CheckCallCount()).To(Equal(1))
+					input := fakePolicyAgent.CheckArgsForCall(0)
+					Expect(input.Action).To(Equal(policy.ActionRunTask))
+					Expect(input.Data).To(Equal(map[string]interface{}{
+						"name":       "some-task",
+						"privileged": true,
+						"platform":   "some-platform",
+						"tags":       atc.Tags{"step", "tags"},
+						"limits":     taskPlan.Config.Limits,
+						"job":        "",
+						"build_id":   1234,
+					}))
+				})
+
+				It("runs the task", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(fakeClient.RunTaskStepCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the policy check fails", func() {
+				BeforeEach(func() {
+					fakePolicyAgent.CheckReturns(policy.PolicyCheckOutput{
+						Allowed: false,
+						Reasons: []string{"privileged tasks are not allowed"},
+					}, nil)
+				})
+
+				It("fails the step with the reasons", func() {
+					Expect(stepErr).To(Equal(policy.PolicyCheckNotPass{
+						Reasons: []string{"privileged tasks are not allowed"},
+					}))
+				})
+
+				It("does not run the task", func() {
+					Expect(fakeDelegate.InitializingCallCount()).To(BeZero())
+					Expect(fakeClient.RunTaskStepCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the policy check errors", func() {
+				disaster := errors.New("agent unreachable")
+
+				BeforeEach(func() {
+					fakePolicyAgent.CheckReturns(policy.FailedPolicyCheck(), disaster)
+				})
+
+				It("fails the step", func() {
+					Expect(stepErr).To(Equal(disaster))
+					Expect(fakeClient.RunTaskStepCallCount()).To(BeZero())
+				})
+			})
 		})
 
 		Context("when the configuration specifies paths for inputs", func() {
@@ -768,6 +846,22 @@ var _ = Describe("TaskStep", func() {
 			})
 		})
 
+		Context("when a security profile is specified", func() {
+			BeforeEach(func() {
+				taskPlan.Config.SecurityProfile = "network-tools"
+			})
+
+			It("adds the security profile to the container spec", func() {
+				_, _, _, containerSpec, _, _, _, _, _, _, _ := fakeClient.RunTaskStepArgsForCall(0)
+				Expect(containerSpec.SecurityProfile).To(Equal("network-tools"))
+			})
+
+			It("requires a worker which defines the security profile", func() {
+				_, _, _, _, workerSpec, _, _, _, _, _, _ := fakeClient.RunTaskStepArgsForCall(0)
+				Expect(workerSpec.SecurityProfile).To(Equal("network-tools"))
+			})
+		})
+
 		Context("when a run user is specified", func() {
 			BeforeEach(func() {
 				taskPlan.Config.Run.User = "some-user"
@@ -916,6 +1010,42 @@ var _ = Describe("TaskStep", func() {
 					Expect(stepErr).ToNot(HaveOccurred())
 				})
 			})
+
+			Context("when the task reports its resource usage", func() {
+				BeforeEach(func() {
+					taskResult := worker.TaskResult{
+						ExitStatus:   0,
+						VolumeMounts: []worker.VolumeMount{},
+						ResourceUsage: atc.ResourceUsage{
+							CPUTime:         1000,
+							MemoryPeak:      2048,
+							RootfsDiskUsage: 4096,
+						},
+					}
+					fakeClient.RunTaskStepReturns(taskResult, nil)
+				})
+
+				It("records the resource usage via the delegate", func() {
+					Expect(fakeDelegate.ResourceUsageCallCount()).To(Equal(1))
+					_, usage := fakeDelegate.ResourceUsageArgsForCall(0)
+					Expect(usage).To(Equal(atc.ResourceUsage{
+						CPUTime:         1000,
+						MemoryPeak:      2048,
+						RootfsDiskUsage: 4096,
+					}))
+				})
+			})
+
+			Context("when the task reports no resource usage", func() {
+				BeforeEach(func() {
+					taskResult := worker.TaskResult{ExitStatus: 0, VolumeMounts: []worker.VolumeMount{}}
+					fakeClient.RunTaskStepReturns(taskResult, nil)
+				})
+
+				It("does not record any resource usage", func() {
+					Expect(fakeDelegate.ResourceUsageCallCount()).To(BeZero())
+				})
+			})
 		})
 
 		Context("when running the task fails", func() {
@@ -4,5 +4,4 @@ var (
 	EnableGlobalResources                bool
 	EnableRedactSecrets                  bool
 	EnableBuildRerunWhenWorkerDisappears bool
-	EnableAcrossStep                     bool
 )
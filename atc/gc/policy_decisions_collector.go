@@ -0,0 +1,36 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type policyDecisionsCollector struct {
+	lifecycle db.PolicyDecisionLifecycle
+	retention time.Duration
+}
+
+func NewPolicyDecisionsCollector(lifecycle db.PolicyDecisionLifecycle, retention time.Duration) *policyDecisionsCollector {
+	return &policyDecisionsCollector{
+		lifecycle: lifecycle,
+		retention: retention,
+	}
+}
+
+func (c *policyDecisionsCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("policy-decisions-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	_, err := c.lifecycle.RemoveDecisionsOlderThan(c.retention)
+	if err != nil {
+		logger.Error("failed-to-remove-old-policy-decisions", err)
+		return err
+	}
+
+	return nil
+}
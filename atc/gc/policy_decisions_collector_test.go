@@ -0,0 +1,45 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PolicyDecisionsCollector", func() {
+	var collector GcCollector
+	var fakeLifecycle *dbfakes.FakePolicyDecisionLifecycle
+
+	BeforeEach(func() {
+		fakeLifecycle = new(dbfakes.FakePolicyDecisionLifecycle)
+
+		collector = gc.NewPolicyDecisionsCollector(fakeLifecycle, 72*time.Hour)
+	})
+
+	Describe("Run", func() {
+		It("tells the policy decision lifecycle to remove decisions past the retention period", func() {
+			err := collector.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeLifecycle.RemoveDecisionsOlderThanCallCount()).To(Equal(1))
+			retention := fakeLifecycle.RemoveDecisionsOlderThanArgsForCall(0)
+			Expect(retention).To(Equal(72 * time.Hour))
+		})
+
+		Context("when removing the decisions fails", func() {
+			BeforeEach(func() {
+				fakeLifecycle.RemoveDecisionsOlderThanReturns(0, errors.New("disaster"))
+			})
+
+			It("returns the error", func() {
+				err := collector.Run(context.TODO())
+				Expect(err).To(MatchError("disaster"))
+			})
+		})
+	})
+})
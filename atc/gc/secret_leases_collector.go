@@ -0,0 +1,45 @@
+package gc
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type secretLeasesCollector struct {
+	leaseRepository db.SecretLeaseRepository
+	leases          creds.LeaseReleaser
+}
+
+func NewSecretLeasesCollector(leaseRepository db.SecretLeaseRepository, leases creds.LeaseReleaser) *secretLeasesCollector {
+	return &secretLeasesCollector{
+		leaseRepository: leaseRepository,
+		leases:          leases,
+	}
+}
+
+func (c *secretLeasesCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("secret-leases-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	buildIDs, err := c.leaseRepository.OrphanedLeaseBuilds()
+	if err != nil {
+		logger.Error("failed-to-get-orphaned-lease-builds", err)
+		return err
+	}
+
+	for _, buildID := range buildIDs {
+		err := c.leases.Release(buildID)
+		if err != nil {
+			logger.Error("failed-to-release-build-leases", err, lager.Data{"build": buildID})
+			continue
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc/creds/credsfakes"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SecretLeasesCollector", func() {
+	var collector GcCollector
+	var fakeLeaseRepository *dbfakes.FakeSecretLeaseRepository
+	var fakeLeases *credsfakes.FakeLeaseReleaser
+
+	BeforeEach(func() {
+		fakeLeaseRepository = new(dbfakes.FakeSecretLeaseRepository)
+		fakeLeases = new(credsfakes.FakeLeaseReleaser)
+
+		collector = gc.NewSecretLeasesCollector(fakeLeaseRepository, fakeLeases)
+	})
+
+	Describe("Run", func() {
+		BeforeEach(func() {
+			fakeLeaseRepository.OrphanedLeaseBuildsReturns([]int{1, 2}, nil)
+		})
+
+		It("releases the leases of builds that are no longer running", func() {
+			err := collector.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeLeases.ReleaseCallCount()).To(Equal(2))
+			Expect(fakeLeases.ReleaseArgsForCall(0)).To(Equal(1))
+			Expect(fakeLeases.ReleaseArgsForCall(1)).To(Equal(2))
+		})
+
+		Context("when releasing a build fails", func() {
+			BeforeEach(func() {
+				fakeLeases.ReleaseReturnsOnCall(0, errors.New("disaster"))
+			})
+
+			It("carries on with the other builds", func() {
+				err := collector.Run(context.TODO())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeLeases.ReleaseCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when getting the orphaned builds fails", func() {
+			BeforeEach(func() {
+				fakeLeaseRepository.OrphanedLeaseBuildsReturns(nil, errors.New("disaster"))
+			})
+
+			It("returns the error", func() {
+				err := collector.Run(context.TODO())
+				Expect(err).To(MatchError("disaster"))
+			})
+		})
+	})
+})
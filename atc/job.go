@@ -3,16 +3,17 @@ package atc
 type Job struct {
 	ID int `json:"id"`
 
-	Name                 string `json:"name"`
-	PipelineName         string `json:"pipeline_name"`
-	TeamName             string `json:"team_name"`
-	Paused               bool   `json:"paused,omitempty"`
-	FirstLoggedBuildID   int    `json:"first_logged_build_id,omitempty"`
-	DisableManualTrigger bool   `json:"disable_manual_trigger,omitempty"`
-	NextBuild            *Build `json:"next_build"`
-	FinishedBuild        *Build `json:"finished_build"`
-	TransitionBuild      *Build `json:"transition_build,omitempty"`
-	HasNewInputs         bool   `json:"has_new_inputs,omitempty"`
+	Name                 string       `json:"name"`
+	PipelineName         string       `json:"pipeline_name"`
+	PipelineInstanceVars InstanceVars `json:"pipeline_instance_vars,omitempty"`
+	TeamName             string       `json:"team_name"`
+	Paused               bool         `json:"paused,omitempty"`
+	FirstLoggedBuildID   int          `json:"first_logged_build_id,omitempty"`
+	DisableManualTrigger bool         `json:"disable_manual_trigger,omitempty"`
+	NextBuild            *Build       `json:"next_build"`
+	FinishedBuild        *Build       `json:"finished_build"`
+	TransitionBuild      *Build       `json:"transition_build,omitempty"`
+	HasNewInputs         bool         `json:"has_new_inputs,omitempty"`
 
 	Inputs  []JobInput  `json:"inputs,omitempty"`
 	Outputs []JobOutput `json:"outputs,omitempty"`
@@ -48,3 +49,18 @@ type BuildInput struct {
 	Version  Version  `json:"version"`
 	Tags     []string `json:"tags,omitempty"`
 }
+
+// JobOneOffBuildConfig configures a one-off build which runs the plan of a
+// job, e.g. through fly execute --job.
+type JobOneOffBuildConfig struct {
+	// Inputs maps the names of get steps to uploaded artifacts which are used
+	// in place of fetching the resource.
+	Inputs map[string]int `json:"inputs,omitempty"`
+
+	// SkipPuts drops the put steps (and their implicit gets) from the plan.
+	SkipPuts bool `json:"skip_puts,omitempty"`
+
+	// Outputs are the names of artifacts to save once the plan has run, so
+	// that they can be downloaded.
+	Outputs []string `json:"outputs,omitempty"`
+}
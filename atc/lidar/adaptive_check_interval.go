@@ -0,0 +1,76 @@
+package lidar
+
+import (
+	"math"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+// adaptiveIdleFactor is how many times a resource is checked over the period
+// it has gone without new versions, e.g. a resource which has not had a new
+// version in ten hours is checked about once an hour.
+const adaptiveIdleFactor = 10
+
+// maxAdaptiveBackoff caps how many consecutive failures keep doubling the
+// interval.
+const maxAdaptiveBackoff = 16
+
+// AdaptiveCheckInterval stretches the interval a resource is checked at while
+// its checks keep failing or stop finding new versions.
+type AdaptiveCheckInterval struct {
+	// Default applies adaptive intervals to every resource which does not
+	// configure check_every, not only the ones using check_every: adaptive.
+	Default bool
+
+	// MaxInterval is the longest a resource can go unchecked for. It does not
+	// shorten the regular interval of a resource.
+	MaxInterval time.Duration
+}
+
+// Interval returns the interval the checkable should be checked at, starting
+// from its regular interval.
+//
+// Each consecutive check failure doubles the interval, and a checkable which
+// has gone without new versions is checked about adaptiveIdleFactor times
+// over the period it has been idle for. The failures and idleness are reset
+// by new versions, manual checks, and webhooks.
+func (adaptive AdaptiveCheckInterval) Interval(interval time.Duration, checkable db.Checkable, now time.Time) time.Duration {
+	stretched := interval
+
+	failures := checkable.ConsecutiveCheckFailures()
+	if failures > maxAdaptiveBackoff {
+		failures = maxAdaptiveBackoff
+	}
+
+	if failures > 0 {
+		limit := adaptive.MaxInterval
+		if limit <= 0 {
+			limit = math.MaxInt64
+		}
+
+		// compare before shifting, as a long interval would overflow
+		if interval > limit>>failures {
+			stretched = limit
+		} else {
+			stretched = interval << failures
+		}
+	}
+
+	idleSince := checkable.IdleSince()
+	if !idleSince.IsZero() {
+		if idle := now.Sub(idleSince) / adaptiveIdleFactor; idle > stretched {
+			stretched = idle
+		}
+	}
+
+	if adaptive.MaxInterval > 0 && stretched > adaptive.MaxInterval {
+		stretched = adaptive.MaxInterval
+	}
+
+	if stretched < interval {
+		return interval
+	}
+
+	return stretched
+}
@@ -0,0 +1,53 @@
+package lidar_test
+
+import (
+	"math"
+	"time"
+
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/lidar"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AdaptiveCheckInterval", func() {
+	now := time.Date(2020, 10, 25, 12, 0, 0, 0, time.UTC)
+
+	DescribeTable("Interval",
+		func(maxInterval time.Duration, failures int, idle time.Duration, expected time.Duration) {
+			checkable := new(dbfakes.FakeCheckable)
+			checkable.ConsecutiveCheckFailuresReturns(failures)
+			checkable.IdleSinceReturns(now.Add(-idle))
+
+			adaptive := lidar.AdaptiveCheckInterval{MaxInterval: maxInterval}
+			Expect(adaptive.Interval(time.Minute, checkable, now)).To(Equal(expected))
+		},
+		Entry("healthy and recently active", time.Hour, 0, 5*time.Minute, time.Minute),
+		Entry("doubles for each failure", time.Hour, 3, 0*time.Second, 8*time.Minute),
+		Entry("stretches with idleness", time.Hour, 0, 5*time.Hour, 30*time.Minute),
+		Entry("takes the longer of the two", time.Hour, 1, 5*time.Hour, 30*time.Minute),
+		Entry("caps at the max interval", time.Hour, 10, 0*time.Second, time.Hour),
+		Entry("does not overflow", time.Duration(0), 1000, 0*time.Second, time.Minute<<16),
+		Entry("never goes below the regular interval", 30*time.Second, 3, 0*time.Second, time.Minute),
+	)
+
+	Context("with a long regular interval", func() {
+		var checkable *dbfakes.FakeCheckable
+
+		BeforeEach(func() {
+			checkable = new(dbfakes.FakeCheckable)
+			checkable.ConsecutiveCheckFailuresReturns(16)
+		})
+
+		It("caps at the max interval without overflowing", func() {
+			adaptive := lidar.AdaptiveCheckInterval{MaxInterval: 2000 * time.Hour}
+			Expect(adaptive.Interval(1000*time.Hour, checkable, now)).To(Equal(2000 * time.Hour))
+		})
+
+		It("does not overflow without a max interval", func() {
+			adaptive := lidar.AdaptiveCheckInterval{}
+			Expect(adaptive.Interval(1000*time.Hour, checkable, now)).To(Equal(time.Duration(math.MaxInt64)))
+		})
+	})
+})
@@ -0,0 +1,263 @@
+package lidar
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
+	"golang.org/x/time/rate"
+)
+
+const (
+	CheckRateLimitKeyResourceType = "resource_type"
+	CheckRateLimitKeyTeam         = "team"
+	CheckRateLimitKeySourcePrefix = "source."
+)
+
+// CheckRateLimit is a token bucket limiting the rate at which checks are
+// started. Checks are grouped by the value of the limit's key, and each group
+// gets a bucket of its own.
+type CheckRateLimit struct {
+	// Key is what checks are grouped by: "resource_type", "team", or
+	// "source.<field>". Source fields holding a URL, e.g. source.uri, are
+	// grouped by the URL's host so that resources talking to the same API
+	// share a bucket.
+	Key string `yaml:"key"`
+
+	// Value restricts the limit to the checks whose key has the given value.
+	// When it is empty, every value of the key is limited separately.
+	Value string `yaml:"value,omitempty"`
+
+	// Rate is the number of checks per second each bucket refills with.
+	Rate float64 `yaml:"rate"`
+
+	// Burst is the number of checks each bucket can hold. Defaults to 1.
+	Burst int `yaml:"burst,omitempty"`
+}
+
+func (limit CheckRateLimit) Validate() error {
+	switch {
+	case limit.Key == CheckRateLimitKeyResourceType, limit.Key == CheckRateLimitKeyTeam:
+	case strings.HasPrefix(limit.Key, CheckRateLimitKeySourcePrefix) && len(limit.Key) > len(CheckRateLimitKeySourcePrefix):
+	default:
+		return fmt.Errorf("unknown key '%s': must be one of '%s', '%s', or '%s<field>'", limit.Key, CheckRateLimitKeyResourceType, CheckRateLimitKeyTeam, CheckRateLimitKeySourcePrefix)
+	}
+
+	if limit.Rate <= 0 {
+		return fmt.Errorf("rate of '%s' limit must be greater than 0", limit.Key)
+	}
+
+	if limit.Burst < 0 {
+		return fmt.Errorf("burst of '%s' limit must not be negative", limit.Key)
+	}
+
+	return nil
+}
+
+// keyValue returns the value of the limit's key for the check, and whether
+// the check is limited by it.
+func (limit CheckRateLimit) keyValue(check db.Check) (string, bool) {
+	plan := check.Plan().Check
+
+	var value string
+	switch {
+	case limit.Key == CheckRateLimitKeyTeam:
+		value = check.TeamName()
+
+	case limit.Key == CheckRateLimitKeyResourceType:
+		if plan == nil {
+			return "", false
+		}
+
+		value = plan.Type
+
+	default:
+		if plan == nil {
+			return "", false
+		}
+
+		field, found := plan.Source[strings.TrimPrefix(limit.Key, CheckRateLimitKeySourcePrefix)]
+		if !found {
+			return "", false
+		}
+
+		str, ok := field.(string)
+		if !ok {
+			return "", false
+		}
+
+		value = sourceHost(str)
+	}
+
+	if value == "" {
+		return "", false
+	}
+
+	if limit.Value != "" && limit.Value != value {
+		return "", false
+	}
+
+	return value, true
+}
+
+var scpLikeURL = regexp.MustCompile(`^(?:[^@/]+@)?([^:/]+):`)
+
+// sourceHost returns the host of source values which are URLs, including
+// scp-like git URLs such as git@github.com:concourse/concourse.git, and the
+// value itself otherwise.
+func sourceHost(value string) string {
+	u, err := url.Parse(value)
+	if err == nil && u.Host != "" {
+		return u.Hostname()
+	}
+
+	if match := scpLikeURL.FindStringSubmatch(value); match != nil {
+		return match[1]
+	}
+
+	return value
+}
+
+//go:generate counterfeiter . CheckLimiter
+
+type CheckLimiter interface {
+	// Wait blocks until every limit the check falls under allows it to start.
+	// It returns how long the check was held back, and whether any limit
+	// applied to it at all.
+	Wait(context.Context, db.Check) (time.Duration, bool, error)
+}
+
+// NewCheckRateLimiter constructs a CheckLimiter enforcing the given limits.
+func NewCheckRateLimiter(logger lager.Logger, limits []CheckRateLimit) CheckLimiter {
+	return &checkRateLimiter{
+		logger:  logger,
+		limits:  limits,
+		buckets: map[checkBucketKey]*checkBucket{},
+	}
+}
+
+// checkBucketPruneInterval is how often buckets which have refilled are
+// removed, so that limits keyed on e.g. source hosts don't hold on to a
+// bucket for every value ever seen.
+const checkBucketPruneInterval = time.Minute
+
+type checkBucketKey struct {
+	limit int
+	value string
+}
+
+type checkBucket struct {
+	limiter *rate.Limiter
+
+	// fullAt is when the bucket will have refilled completely, after which
+	// it is no different from a new one and can be removed.
+	fullAt time.Time
+}
+
+type checkRateLimiter struct {
+	logger lager.Logger
+	limits []CheckRateLimit
+
+	bucketsL   sync.Mutex
+	buckets    map[checkBucketKey]*checkBucket
+	lastPruned time.Time
+}
+
+func (limiter *checkRateLimiter) Wait(ctx context.Context, check db.Check) (time.Duration, bool, error) {
+	var (
+		reservations []*rate.Reservation
+		delay        time.Duration
+		throttledBy  string
+	)
+
+	now := time.Now()
+
+	limiter.bucketsL.Lock()
+	limiter.pruneBuckets(now)
+
+	for i, limit := range limiter.limits {
+		value, ok := limit.keyValue(check)
+		if !ok {
+			continue
+		}
+
+		key := checkBucketKey{limit: i, value: value}
+
+		burst := limit.Burst
+		if burst == 0 {
+			burst = 1
+		}
+
+		bucket, found := limiter.buckets[key]
+		if !found {
+			bucket = &checkBucket{
+				limiter: rate.NewLimiter(rate.Limit(limit.Rate), burst),
+			}
+
+			limiter.buckets[key] = bucket
+		}
+
+		reservation := bucket.limiter.ReserveN(now, 1)
+		reservations = append(reservations, reservation)
+
+		d := reservation.DelayFrom(now)
+		if d > delay {
+			delay = d
+			throttledBy = limit.Key
+		}
+
+		refill := time.Duration(float64(burst) / limit.Rate * float64(time.Second))
+		bucket.fullAt = now.Add(d + refill)
+	}
+	limiter.bucketsL.Unlock()
+
+	if len(reservations) == 0 {
+		return 0, false, nil
+	}
+
+	if delay == 0 {
+		return 0, true, nil
+	}
+
+	metric.CheckThrottled{
+		Limit: throttledBy,
+		Delay: delay,
+	}.Emit(limiter.logger)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return delay, true, nil
+	case <-ctx.Done():
+		for _, reservation := range reservations {
+			reservation.Cancel()
+		}
+
+		return 0, true, ctx.Err()
+	}
+}
+
+// pruneBuckets removes the buckets which have refilled since they were last
+// used. It must be called with bucketsL held.
+func (limiter *checkRateLimiter) pruneBuckets(now time.Time) {
+	if now.Sub(limiter.lastPruned) < checkBucketPruneInterval {
+		return
+	}
+
+	for key, bucket := range limiter.buckets {
+		if !now.Before(bucket.fullAt) {
+			delete(limiter.buckets, key)
+		}
+	}
+
+	limiter.lastPruned = now
+}
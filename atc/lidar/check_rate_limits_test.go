@@ -0,0 +1,195 @@
+package lidar_test
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/lidar"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CheckRateLimit", func() {
+	DescribeTable("Validate",
+		func(limit lidar.CheckRateLimit, errMatcher OmegaMatcher) {
+			Expect(limit.Validate()).To(errMatcher)
+		},
+		Entry("resource type", lidar.CheckRateLimit{Key: "resource_type", Rate: 1}, Succeed()),
+		Entry("team", lidar.CheckRateLimit{Key: "team", Value: "main", Rate: 0.5, Burst: 10}, Succeed()),
+		Entry("source field", lidar.CheckRateLimit{Key: "source.uri", Rate: 1}, Succeed()),
+		Entry("unknown key", lidar.CheckRateLimit{Key: "pipeline", Rate: 1}, MatchError(ContainSubstring("unknown key 'pipeline'"))),
+		Entry("source without a field", lidar.CheckRateLimit{Key: "source.", Rate: 1}, MatchError(ContainSubstring("unknown key 'source.'"))),
+		Entry("no rate", lidar.CheckRateLimit{Key: "team"}, MatchError(ContainSubstring("rate of 'team' limit must be greater than 0"))),
+		Entry("negative burst", lidar.CheckRateLimit{Key: "team", Rate: 1, Burst: -1}, MatchError(ContainSubstring("burst of 'team' limit must not be negative"))),
+	)
+})
+
+var _ = Describe("CheckRateLimiter", func() {
+	var (
+		limits  []lidar.CheckRateLimit
+		limiter lidar.CheckLimiter
+	)
+
+	newCheck := func(team string, resourceType string, source atc.Source) *dbfakes.FakeCheck {
+		check := new(dbfakes.FakeCheck)
+		check.TeamNameReturns(team)
+		check.PlanReturns(atc.Plan{
+			Check: &atc.CheckPlan{
+				Type:   resourceType,
+				Source: source,
+			},
+		})
+		return check
+	}
+
+	JustBeforeEach(func() {
+		limiter = lidar.NewCheckRateLimiter(lagertest.NewTestLogger("test"), limits)
+	})
+
+	wait := func(check *dbfakes.FakeCheck) (time.Duration, bool) {
+		delay, limited, err := limiter.Wait(context.Background(), check)
+		Expect(err).ToNot(HaveOccurred())
+		return delay, limited
+	}
+
+	Context("when no limits are configured", func() {
+		BeforeEach(func() {
+			limits = nil
+		})
+
+		It("does not limit checks", func() {
+			delay, limited := wait(newCheck("main", "git", nil))
+			Expect(delay).To(BeZero())
+			Expect(limited).To(BeFalse())
+		})
+	})
+
+	Context("when checks are limited per resource type", func() {
+		BeforeEach(func() {
+			limits = []lidar.CheckRateLimit{
+				{Key: "resource_type", Rate: 10},
+			}
+		})
+
+		It("lets the first check through straight away", func() {
+			delay, limited := wait(newCheck("main", "git", nil))
+			Expect(delay).To(BeZero())
+			Expect(limited).To(BeTrue())
+		})
+
+		It("holds back checks of a type which has run out of tokens", func() {
+			wait(newCheck("main", "git", nil))
+
+			delay, limited := wait(newCheck("other-team", "git", nil))
+			Expect(delay).To(BeNumerically("~", 100*time.Millisecond, 50*time.Millisecond))
+			Expect(limited).To(BeTrue())
+		})
+
+		It("gives each type a bucket of its own", func() {
+			wait(newCheck("main", "git", nil))
+
+			delay, _ := wait(newCheck("main", "time", nil))
+			Expect(delay).To(BeZero())
+		})
+
+		Context("when the bucket holds a burst of checks", func() {
+			BeforeEach(func() {
+				limits[0].Burst = 2
+			})
+
+			It("lets the burst through straight away", func() {
+				wait(newCheck("main", "git", nil))
+
+				delay, _ := wait(newCheck("main", "git", nil))
+				Expect(delay).To(BeZero())
+			})
+		})
+
+		Context("when the limit is restricted to a value", func() {
+			BeforeEach(func() {
+				limits[0].Value = "github-release"
+			})
+
+			It("only limits checks of that type", func() {
+				_, limited := wait(newCheck("main", "git", nil))
+				Expect(limited).To(BeFalse())
+
+				_, limited = wait(newCheck("main", "github-release", nil))
+				Expect(limited).To(BeTrue())
+			})
+		})
+
+		Context("when the context is canceled while waiting", func() {
+			It("errors", func() {
+				wait(newCheck("main", "git", nil))
+
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				_, _, err := limiter.Wait(ctx, newCheck("main", "git", nil))
+				Expect(err).To(Equal(context.Canceled))
+			})
+		})
+	})
+
+	Context("when checks are limited per team", func() {
+		BeforeEach(func() {
+			limits = []lidar.CheckRateLimit{
+				{Key: "team", Rate: 10},
+			}
+		})
+
+		It("holds back checks of a team which has run out of tokens", func() {
+			wait(newCheck("main", "git", nil))
+
+			delay, _ := wait(newCheck("main", "time", nil))
+			Expect(delay).To(BeNumerically(">", 0))
+
+			delay, _ = wait(newCheck("other-team", "git", nil))
+			Expect(delay).To(BeZero())
+		})
+	})
+
+	Context("when checks are limited per source field", func() {
+		BeforeEach(func() {
+			limits = []lidar.CheckRateLimit{
+				{Key: "source.uri", Rate: 10},
+			}
+		})
+
+		It("groups URLs by their host", func() {
+			wait(newCheck("main", "git", atc.Source{"uri": "https://github.com/concourse/concourse.git"}))
+
+			delay, _ := wait(newCheck("main", "git", atc.Source{"uri": "git@github.com:concourse/git-resource.git"}))
+			Expect(delay).To(BeNumerically(">", 0))
+
+			delay, _ = wait(newCheck("main", "git", atc.Source{"uri": "https://gitlab.com/some/repo.git"}))
+			Expect(delay).To(BeZero())
+		})
+
+		It("does not limit checks without the field", func() {
+			_, limited := wait(newCheck("main", "time", atc.Source{"interval": "1m"}))
+			Expect(limited).To(BeFalse())
+		})
+	})
+
+	Context("when several limits apply", func() {
+		BeforeEach(func() {
+			limits = []lidar.CheckRateLimit{
+				{Key: "team", Rate: 100},
+				{Key: "resource_type", Rate: 10},
+			}
+		})
+
+		It("holds checks back until every limit allows them", func() {
+			wait(newCheck("main", "git", nil))
+
+			delay, _ := wait(newCheck("main", "git", nil))
+			Expect(delay).To(BeNumerically("~", 100*time.Millisecond, 50*time.Millisecond))
+		})
+	})
+})
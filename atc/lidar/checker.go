@@ -7,7 +7,9 @@ import (
 	"runtime/debug"
 	"strconv"
 	"sync"
+	"time"
 
+	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc/db"
@@ -27,6 +29,8 @@ func NewChecker(
 	checkFactory db.CheckFactory,
 	engine engine.Engine,
 	checkRateCalculator RateCalculator,
+	checkLimiter CheckLimiter,
+	clock clock.Clock,
 ) *checker {
 	return &checker{
 		logger:              logger,
@@ -34,23 +38,47 @@ func NewChecker(
 		engine:              engine,
 		running:             &sync.Map{},
 		checkRateCalculator: checkRateCalculator,
+		checkLimiter:        checkLimiter,
+		clock:               clock,
+		rateLimitDelays:     map[int]rateLimitDelay{},
 	}
 }
 
+// rateLimitDelayRetention is how long the rate limit delay of a resource
+// config scope is kept without any of its checks being limited. After that
+// the delay is cleared, as the scope is either no longer limited or no longer
+// checked at all, e.g. because its resources were removed.
+const rateLimitDelayRetention = time.Hour
+
+type rateLimitDelay struct {
+	delay time.Duration
+	check db.Check
+	seen  time.Time
+}
+
 type checker struct {
 	logger lager.Logger
 
 	checkFactory        db.CheckFactory
 	engine              engine.Engine
 	checkRateCalculator RateCalculator
+	checkLimiter        CheckLimiter
+	clock               clock.Clock
 
 	running *sync.Map
+
+	// the rate limit delays last saved for each resource config scope which is
+	// limited, so that they are only written when they change
+	rateLimitDelays     map[int]rateLimitDelay
+	rateLimitDelaysLock sync.Mutex
 }
 
 func (c *checker) Run(ctx context.Context) error {
 	c.logger.Info("start")
 	defer c.logger.Info("end")
 
+	c.pruneRateLimitDelays()
+
 	checks, err := c.checkFactory.StartedChecks()
 	if err != nil {
 		c.logger.Error("failed-to-fetch-resource-checks", err)
@@ -107,6 +135,19 @@ func (c *checker) Run(ctx context.Context) error {
 				defer span.End()
 				defer c.running.Delete(check.ID())
 
+				if !check.ManuallyTriggered() {
+					delay, limited, err := c.checkLimiter.Wait(spanCtx, check)
+					if err != nil {
+						c.logger.Error("failed-to-wait-for-check-rate-limits", err, loggerData)
+						return
+					}
+
+					err = c.saveRateLimitDelay(check, delay, limited)
+					if err != nil {
+						c.logger.Error("failed-to-save-check-rate-limit-delay", err, loggerData)
+					}
+				}
+
 				c.engine.NewCheck(check).Run(
 					lagerctx.NewContext(
 						spanCtx,
@@ -119,3 +160,71 @@ func (c *checker) Run(ctx context.Context) error {
 
 	return nil
 }
+
+// saveRateLimitDelay saves the delay of a rate limited check, rounded to the
+// second as it is shown, unless it is the same as the one last saved for the
+// resource config scope. A check which is not limited clears the delay.
+func (c *checker) saveRateLimitDelay(check db.Check, delay time.Duration, limited bool) error {
+	if !limited {
+		delay = 0
+	}
+
+	delay = delay.Round(time.Second)
+	scopeID := check.ResourceConfigScopeID()
+
+	c.rateLimitDelaysLock.Lock()
+	last, saved := c.rateLimitDelays[scopeID]
+	if last.delay == delay {
+		if saved {
+			c.rateLimitDelays[scopeID] = rateLimitDelay{delay: delay, check: check, seen: c.clock.Now()}
+		}
+
+		c.rateLimitDelaysLock.Unlock()
+		return nil
+	}
+
+	if delay == 0 {
+		delete(c.rateLimitDelays, scopeID)
+	} else {
+		c.rateLimitDelays[scopeID] = rateLimitDelay{delay: delay, check: check, seen: c.clock.Now()}
+	}
+	c.rateLimitDelaysLock.Unlock()
+
+	err := check.SetRateLimitDelay(delay)
+	if err != nil {
+		// the delay last saved is still the one in the database
+		c.rateLimitDelaysLock.Lock()
+		if saved {
+			c.rateLimitDelays[scopeID] = last
+		} else {
+			delete(c.rateLimitDelays, scopeID)
+		}
+		c.rateLimitDelaysLock.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// pruneRateLimitDelays clears and forgets the delays of the resource config
+// scopes which have not had a limited check for rateLimitDelayRetention.
+func (c *checker) pruneRateLimitDelays() {
+	c.rateLimitDelaysLock.Lock()
+	defer c.rateLimitDelaysLock.Unlock()
+
+	for scopeID, saved := range c.rateLimitDelays {
+		if c.clock.Since(saved.seen) < rateLimitDelayRetention {
+			continue
+		}
+
+		err := saved.check.SetRateLimitDelay(0)
+		if err != nil {
+			c.logger.Error("failed-to-clear-check-rate-limit-delay", err, lager.Data{
+				"resource_config_scope_id": scopeID,
+			})
+			continue
+		}
+
+		delete(c.rateLimitDelays, scopeID)
+	}
+}
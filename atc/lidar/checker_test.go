@@ -5,6 +5,7 @@ import (
 	"errors"
 	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
 	"code.cloudfoundry.org/lager/lagertest"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -32,6 +33,8 @@ var _ = Describe("Checker", func() {
 		fakeEngine         *enginefakes.FakeEngine
 		fakeRateCalculator *lidarfakes.FakeRateCalculator
 		fakeLimiter        *lidarfakes.FakeLimiter
+		fakeCheckLimiter   *lidarfakes.FakeCheckLimiter
+		fakeClock          *fakeclock.FakeClock
 
 		checker Checker
 		logger  *lagertest.TestLogger
@@ -42,6 +45,8 @@ var _ = Describe("Checker", func() {
 		fakeEngine = new(enginefakes.FakeEngine)
 		fakeRateCalculator = new(lidarfakes.FakeRateCalculator)
 		fakeLimiter = new(lidarfakes.FakeLimiter)
+		fakeCheckLimiter = new(lidarfakes.FakeCheckLimiter)
+		fakeClock = fakeclock.NewFakeClock(time.Now())
 
 		logger = lagertest.NewTestLogger("test")
 	})
@@ -52,6 +57,8 @@ var _ = Describe("Checker", func() {
 			fakeCheckFactory,
 			fakeEngine,
 			fakeRateCalculator,
+			fakeCheckLimiter,
+			fakeClock,
 		)
 
 		err = checker.Run(context.TODO())
@@ -112,10 +119,13 @@ var _ = Describe("Checker", func() {
 			BeforeEach(func() {
 				fakeCheck1 = new(dbfakes.FakeCheck)
 				fakeCheck1.IDReturns(1)
+				fakeCheck1.ResourceConfigScopeIDReturns(11)
 				fakeCheck2 = new(dbfakes.FakeCheck)
 				fakeCheck2.IDReturns(2)
+				fakeCheck2.ResourceConfigScopeIDReturns(12)
 				fakeCheck3 = new(dbfakes.FakeCheck)
 				fakeCheck3.IDReturns(3)
+				fakeCheck3.ResourceConfigScopeIDReturns(13)
 
 				fakeCheckFactory.StartedChecksReturns([]db.Check{
 					fakeCheck1,
@@ -147,6 +157,154 @@ var _ = Describe("Checker", func() {
 					Eventually(fakeLimiter.WaitCallCount()).Should(Equal(3))
 				})
 
+				It("waits for the check rate limits of each check", func() {
+					Eventually(fakeCheckLimiter.WaitCallCount).Should(Equal(3))
+				})
+
+				Context("when no check rate limit applies", func() {
+					BeforeEach(func() {
+						fakeCheckLimiter.WaitReturns(0, false, nil)
+					})
+
+					It("does not record a rate limit delay", func() {
+						Eventually(fakeEngine.NewCheckCallCount).Should(Equal(3))
+						Expect(fakeCheck1.SetRateLimitDelayCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when a check rate limit applies", func() {
+					BeforeEach(func() {
+						fakeCheckLimiter.WaitReturns(5*time.Second, true, nil)
+					})
+
+					It("records the rate limit delay of the checks", func() {
+						Eventually(fakeEngine.NewCheckCallCount).Should(Equal(3))
+						Expect(fakeCheck1.SetRateLimitDelayCallCount()).To(Equal(1))
+						Expect(fakeCheck1.SetRateLimitDelayArgsForCall(0)).To(Equal(5 * time.Second))
+					})
+
+					Context("when the scope is checked again", func() {
+						BeforeEach(func() {
+							fakeEngine.NewCheckStub = nil
+							fakeEngine.NewCheckReturns(new(enginefakes.FakeRunnable))
+						})
+
+						runAgain := func(delay time.Duration, limited bool) {
+							Eventually(fakeEngine.NewCheckCallCount).Should(Equal(3))
+							fakeCheckLimiter.WaitReturns(delay, limited, nil)
+
+							// checks are skipped until their previous run is over
+							Eventually(func() int {
+								Expect(checker.Run(context.TODO())).To(Succeed())
+								return fakeEngine.NewCheckCallCount()
+							}).Should(BeNumerically(">=", 6))
+						}
+
+						It("does not save the same delay again", func() {
+							runAgain(5*time.Second+100*time.Millisecond, true)
+
+							Expect(fakeCheck1.SetRateLimitDelayCallCount()).To(Equal(1))
+						})
+
+						It("saves a different delay", func() {
+							runAgain(7*time.Second, true)
+
+							Expect(fakeCheck1.SetRateLimitDelayCallCount()).To(Equal(2))
+							Expect(fakeCheck1.SetRateLimitDelayArgsForCall(1)).To(Equal(7 * time.Second))
+						})
+
+						It("clears the delay once the check is no longer limited", func() {
+							runAgain(0, false)
+
+							Expect(fakeCheck1.SetRateLimitDelayCallCount()).To(Equal(2))
+							Expect(fakeCheck1.SetRateLimitDelayArgsForCall(1)).To(BeZero())
+						})
+
+						Context("when clearing the delay fails", func() {
+							BeforeEach(func() {
+								fakeCheck1.SetRateLimitDelayReturnsOnCall(1, errors.New("nope"))
+							})
+
+							It("clears it again on the next check", func() {
+								runAgain(0, false)
+								Expect(fakeCheck1.SetRateLimitDelayCallCount()).To(Equal(2))
+
+								Eventually(func() int {
+									Expect(checker.Run(context.TODO())).To(Succeed())
+									return fakeCheck1.SetRateLimitDelayCallCount()
+								}).Should(Equal(3))
+								Expect(fakeCheck1.SetRateLimitDelayArgsForCall(2)).To(BeZero())
+							})
+						})
+					})
+
+					Context("when the scope has not been limited for a while", func() {
+						BeforeEach(func() {
+							fakeEngine.NewCheckStub = nil
+							fakeEngine.NewCheckReturns(new(enginefakes.FakeRunnable))
+						})
+
+						It("clears and forgets the delay", func() {
+							Eventually(fakeCheck1.SetRateLimitDelayCallCount).Should(Equal(1))
+
+							fakeCheckFactory.StartedChecksReturns(nil, nil)
+							fakeClock.Increment(time.Hour)
+
+							Expect(checker.Run(context.TODO())).To(Succeed())
+							Expect(fakeCheck1.SetRateLimitDelayCallCount()).To(Equal(2))
+							Expect(fakeCheck1.SetRateLimitDelayArgsForCall(1)).To(BeZero())
+
+							Expect(checker.Run(context.TODO())).To(Succeed())
+							Expect(fakeCheck1.SetRateLimitDelayCallCount()).To(Equal(2))
+						})
+
+						It("keeps the delay while the scope is still limited", func() {
+							Eventually(fakeCheck1.SetRateLimitDelayCallCount).Should(Equal(1))
+
+							fakeClock.Increment(time.Hour - time.Second)
+							Eventually(func() int {
+								Expect(checker.Run(context.TODO())).To(Succeed())
+								return fakeEngine.NewCheckCallCount()
+							}).Should(BeNumerically(">=", 6))
+
+							fakeCheckFactory.StartedChecksReturns(nil, nil)
+							fakeClock.Increment(time.Second)
+
+							Expect(checker.Run(context.TODO())).To(Succeed())
+							Expect(fakeCheck1.SetRateLimitDelayCallCount()).To(Equal(1))
+						})
+					})
+
+					Context("when saving the delay fails", func() {
+						BeforeEach(func() {
+							fakeEngine.NewCheckStub = nil
+							fakeEngine.NewCheckReturns(new(enginefakes.FakeRunnable))
+							fakeCheck1.SetRateLimitDelayReturnsOnCall(0, errors.New("nope"))
+						})
+
+						It("saves it again on the next check", func() {
+							Eventually(fakeEngine.NewCheckCallCount).Should(Equal(3))
+							Expect(fakeCheck1.SetRateLimitDelayCallCount()).To(Equal(1))
+
+							Eventually(func() int {
+								Expect(checker.Run(context.TODO())).To(Succeed())
+								return fakeCheck1.SetRateLimitDelayCallCount()
+							}).Should(Equal(2))
+						})
+					})
+				})
+
+				Context("when waiting for the check rate limits fails", func() {
+					BeforeEach(func() {
+						fakeCheckLimiter.WaitReturns(0, true, errors.New("canceled"))
+					})
+
+					It("does not run the checks", func() {
+						Eventually(fakeCheckLimiter.WaitCallCount).Should(Equal(3))
+						Consistently(fakeEngine.NewCheckCallCount).Should(Equal(0))
+					})
+				})
+
 				Context("when there is a manually triggered check and the rate limiter is not allowing any checks to run", func() {
 					BeforeEach(func() {
 						fakeCheck1.ManuallyTriggeredReturns(true)
@@ -158,6 +316,11 @@ var _ = Describe("Checker", func() {
 						Eventually(fakeEngine.NewCheckCallCount).Should(Equal(1))
 						Expect(fakeEngine.NewCheckArgsForCall(0).ID()).To(Equal(fakeCheck1.ID()))
 					})
+
+					It("does not hold it back by the check rate limits", func() {
+						Eventually(fakeEngine.NewCheckCallCount).Should(Equal(1))
+						Expect(fakeCheckLimiter.WaitCallCount()).To(Equal(0))
+					})
 				})
 			})
 
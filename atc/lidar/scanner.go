@@ -11,6 +11,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/metric"
@@ -25,6 +26,7 @@ func NewScanner(
 	defaultCheckTimeout time.Duration,
 	defaultCheckInterval time.Duration,
 	defaultWithWebhookCheckInterval time.Duration,
+	adaptiveCheckInterval AdaptiveCheckInterval,
 ) *scanner {
 	return &scanner{
 		logger:                          logger,
@@ -33,6 +35,7 @@ func NewScanner(
 		defaultCheckTimeout:             defaultCheckTimeout,
 		defaultCheckInterval:            defaultCheckInterval,
 		defaultWithWebhookCheckInterval: defaultWithWebhookCheckInterval,
+		adaptiveCheckInterval:           adaptiveCheckInterval,
 	}
 }
 
@@ -44,6 +47,7 @@ type scanner struct {
 	defaultCheckTimeout             time.Duration
 	defaultCheckInterval            time.Duration
 	defaultWithWebhookCheckInterval time.Duration
+	adaptiveCheckInterval           AdaptiveCheckInterval
 }
 
 func (s *scanner) Run(ctx context.Context) error {
@@ -131,7 +135,13 @@ func (s *scanner) check(ctx context.Context, checkable db.Checkable, resourceTyp
 	if checkable.HasWebhook() {
 		interval = s.defaultWithWebhookCheckInterval
 	}
-	if every := checkable.CheckEvery(); every != "" {
+
+	adaptive := s.adaptiveCheckInterval.Default
+	if every := checkable.CheckEvery(); every == atc.CheckEveryAdaptive {
+		adaptive = true
+	} else if every != "" {
+		adaptive = false
+
 		interval, err = time.ParseDuration(every)
 		if err != nil {
 			s.logger.Error("failed-to-parse-check-every", err)
@@ -139,7 +149,12 @@ func (s *scanner) check(ctx context.Context, checkable db.Checkable, resourceTyp
 		}
 	}
 
-	if time.Now().Before(checkable.LastCheckEndTime().Add(interval)) {
+	now := time.Now()
+	if adaptive {
+		interval = s.adaptiveCheckInterval.Interval(interval, checkable, now)
+	}
+
+	if now.Before(checkable.LastCheckEndTime().Add(interval)) {
 		return nil
 	}
 
@@ -27,6 +27,8 @@ var _ = Describe("Scanner", func() {
 		fakeCheckFactory *dbfakes.FakeCheckFactory
 		fakeSecrets      *credsfakes.FakeSecrets
 
+		adaptiveCheckInterval lidar.AdaptiveCheckInterval
+
 		logger  *lagertest.TestLogger
 		scanner Scanner
 	)
@@ -35,7 +37,12 @@ var _ = Describe("Scanner", func() {
 		fakeCheckFactory = new(dbfakes.FakeCheckFactory)
 		fakeSecrets = new(credsfakes.FakeSecrets)
 
+		adaptiveCheckInterval = lidar.AdaptiveCheckInterval{}
+
 		logger = lagertest.NewTestLogger("test")
+	})
+
+	JustBeforeEach(func() {
 		scanner = lidar.NewScanner(
 			logger,
 			fakeCheckFactory,
@@ -43,10 +50,9 @@ var _ = Describe("Scanner", func() {
 			time.Minute*1,
 			time.Minute*1,
 			time.Minute*10,
+			adaptiveCheckInterval,
 		)
-	})
 
-	JustBeforeEach(func() {
 		err = scanner.Run(context.TODO())
 	})
 
@@ -350,5 +356,108 @@ var _ = Describe("Scanner", func() {
 				})
 			})
 		})
+
+		Context("Adaptive check interval", func() {
+			var fakeResource *dbfakes.FakeResource
+
+			BeforeEach(func() {
+				fakeResource = new(dbfakes.FakeResource)
+				fakeResource.NameReturns("some-name")
+				fakeResource.SourceReturns(atc.Source{"some": "source"})
+				fakeResource.TypeReturns("base-type")
+				fakeResource.CheckEveryReturns("adaptive")
+				fakeResource.IdleSinceReturns(time.Now())
+				fakeCheckFactory.ResourcesReturns([]db.Resource{fakeResource}, nil)
+			})
+
+			Context("when the resource checks fine and finds new versions", func() {
+				BeforeEach(func() {
+					fakeResource.LastCheckEndTimeReturns(time.Now().Add(-time.Minute * 2))
+				})
+
+				It("checks at the default interval", func() {
+					Expect(fakeCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the last 3 checks failed", func() {
+				BeforeEach(func() {
+					fakeResource.ConsecutiveCheckFailuresReturns(3)
+				})
+
+				Context("last check is 7 minutes ago", func() {
+					BeforeEach(func() {
+						fakeResource.LastCheckEndTimeReturns(time.Now().Add(-time.Minute * 7))
+					})
+
+					It("does not create a check", func() {
+						Expect(fakeCheckFactory.TryCreateCheckCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("last check is 9 minutes ago", func() {
+					BeforeEach(func() {
+						fakeResource.LastCheckEndTimeReturns(time.Now().Add(-time.Minute * 9))
+					})
+
+					It("creates a check", func() {
+						Expect(fakeCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("when the adaptive interval is capped", func() {
+					BeforeEach(func() {
+						adaptiveCheckInterval.MaxInterval = 5 * time.Minute
+						fakeResource.LastCheckEndTimeReturns(time.Now().Add(-time.Minute * 6))
+					})
+
+					It("creates a check", func() {
+						Expect(fakeCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
+					})
+				})
+			})
+
+			Context("when the resource has not found a new version in 100 minutes", func() {
+				BeforeEach(func() {
+					fakeResource.IdleSinceReturns(time.Now().Add(-time.Minute * 100))
+					fakeResource.LastCheckEndTimeReturns(time.Now().Add(-time.Minute * 5))
+				})
+
+				It("does not create a check", func() {
+					Expect(fakeCheckFactory.TryCreateCheckCallCount()).To(Equal(0))
+				})
+
+				Context("when the resource checks at a fixed interval", func() {
+					BeforeEach(func() {
+						fakeResource.CheckEveryReturns("1m")
+						adaptiveCheckInterval.Default = true
+					})
+
+					It("creates a check", func() {
+						Expect(fakeCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
+					})
+				})
+
+				Context("when the resource does not configure its check interval", func() {
+					BeforeEach(func() {
+						fakeResource.CheckEveryReturns("")
+					})
+
+					It("creates a check", func() {
+						Expect(fakeCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
+					})
+
+					Context("when adaptive check intervals are the default", func() {
+						BeforeEach(func() {
+							adaptiveCheckInterval.Default = true
+						})
+
+						It("does not create a check", func() {
+							Expect(fakeCheckFactory.TryCreateCheckCallCount()).To(Equal(0))
+						})
+					})
+				})
+			})
+		})
 	})
 })
@@ -0,0 +1,135 @@
+package logarchive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/concourse/concourse/atc/compression"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+)
+
+// An Archive stores the event stream of each build as a single
+// zstd-compressed object of newline-delimited JSON event envelopes.
+type Archive struct {
+	store       Store
+	compression compression.Compression
+}
+
+func NewArchive(store Store) *Archive {
+	return &Archive{
+		store:       store,
+		compression: compression.NewZstdCompression(),
+	}
+}
+
+// Save reads the events until the end of the stream and archives them.
+func (a *Archive) Save(ctx context.Context, buildID int, events db.EventSource) error {
+	reader, writer := io.Pipe()
+
+	go func() {
+		_ = writer.CloseWithError(a.writeEvents(writer, events))
+	}()
+
+	err := a.store.Put(ctx, buildKey(buildID), reader)
+
+	// unblock the writer in case the store gave up before reading everything
+	_ = reader.CloseWithError(err)
+
+	return err
+}
+
+func (a *Archive) writeEvents(writer io.Writer, events db.EventSource) error {
+	compressed, err := a.compression.NewWriter(writer)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(compressed)
+
+	for {
+		ev, err := events.Next()
+		if err != nil {
+			if err == db.ErrEndOfBuildEventStream {
+				break
+			}
+
+			_ = compressed.Close()
+			return err
+		}
+
+		err = encoder.Encode(ev)
+		if err != nil {
+			_ = compressed.Close()
+			return err
+		}
+	}
+
+	return compressed.Close()
+}
+
+// Events streams the archived events of the build, starting at the given
+// offset like db.Build's Events.
+func (a *Archive) Events(buildID int, from uint) (db.EventSource, error) {
+	object, err := a.store.Get(context.Background(), buildKey(buildID))
+	if err != nil {
+		return nil, err
+	}
+
+	decompressed, err := a.compression.NewReader(object)
+	if err != nil {
+		_ = object.Close()
+		return nil, err
+	}
+
+	source := &archivedEventSource{
+		object:       object,
+		decompressed: decompressed,
+		decoder:      json.NewDecoder(decompressed),
+	}
+
+	for i := uint(0); i < from; i++ {
+		_, err := source.Next()
+		if err == db.ErrEndOfBuildEventStream {
+			break
+		}
+
+		if err != nil {
+			_ = source.Close()
+			return nil, err
+		}
+	}
+
+	return source, nil
+}
+
+type archivedEventSource struct {
+	object       io.Closer
+	decompressed io.Closer
+	decoder      *json.Decoder
+}
+
+func (source *archivedEventSource) Next() (event.Envelope, error) {
+	var ev event.Envelope
+	err := source.decoder.Decode(&ev)
+	if err != nil {
+		if err == io.EOF {
+			return event.Envelope{}, db.ErrEndOfBuildEventStream
+		}
+
+		return event.Envelope{}, err
+	}
+
+	return ev, nil
+}
+
+func (source *archivedEventSource) Close() error {
+	_ = source.decompressed.Close()
+	return source.object.Close()
+}
+
+func buildKey(buildID int) string {
+	return fmt.Sprintf("builds/%d/events.json.zst", buildID)
+}
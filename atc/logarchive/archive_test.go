@@ -0,0 +1,142 @@
+package logarchive_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/logarchive"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func fakeEvent(payload string) event.Envelope {
+	msg := json.RawMessage(payload)
+	return event.Envelope{
+		Data:    &msg,
+		Event:   "fake",
+		Version: "42.0",
+	}
+}
+
+func fakeEventSource(events ...event.Envelope) *dbfakes.FakeEventSource {
+	source := new(dbfakes.FakeEventSource)
+	for i, ev := range events {
+		source.NextReturnsOnCall(i, ev, nil)
+	}
+	source.NextReturnsOnCall(len(events), event.Envelope{}, db.ErrEndOfBuildEventStream)
+	return source
+}
+
+func readAll(source db.EventSource) []event.Envelope {
+	events := []event.Envelope{}
+	for {
+		ev, err := source.Next()
+		if err == db.ErrEndOfBuildEventStream {
+			return events
+		}
+
+		Expect(err).ToNot(HaveOccurred())
+		events = append(events, ev)
+	}
+}
+
+var _ = Describe("Archive", func() {
+	var (
+		dir     string
+		archive *logarchive.Archive
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "log-archive")
+		Expect(err).ToNot(HaveOccurred())
+
+		store, err := logarchive.NewFileStore(dir)
+		Expect(err).ToNot(HaveOccurred())
+
+		archive = logarchive.NewArchive(store)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	Context("when the events of a build are saved", func() {
+		BeforeEach(func() {
+			events := fakeEventSource(
+				fakeEvent(`{"event":1}`),
+				fakeEvent(`{"event":2}`),
+				fakeEvent(`{"event":3}`),
+			)
+
+			Expect(archive.Save(context.Background(), 42, events)).To(Succeed())
+		})
+
+		It("streams them back", func() {
+			source, err := archive.Events(42, 0)
+			Expect(err).ToNot(HaveOccurred())
+
+			defer source.Close()
+
+			Expect(readAll(source)).To(Equal([]event.Envelope{
+				fakeEvent(`{"event":1}`),
+				fakeEvent(`{"event":2}`),
+				fakeEvent(`{"event":3}`),
+			}))
+		})
+
+		It("streams them back from the given offset", func() {
+			source, err := archive.Events(42, 2)
+			Expect(err).ToNot(HaveOccurred())
+
+			defer source.Close()
+
+			Expect(readAll(source)).To(Equal([]event.Envelope{
+				fakeEvent(`{"event":3}`),
+			}))
+		})
+
+		It("ends the stream when the offset is past the end", func() {
+			source, err := archive.Events(42, 10)
+			Expect(err).ToNot(HaveOccurred())
+
+			defer source.Close()
+
+			Expect(readAll(source)).To(BeEmpty())
+		})
+
+		It("compresses them with zstd", func() {
+			content, err := ioutil.ReadFile(filepath.Join(dir, "builds", "42", "events.json.zst"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(content).To(HavePrefix(string([]byte{0x28, 0xb5, 0x2f, 0xfd})))
+		})
+	})
+
+	Context("when the events of the build fail to be read", func() {
+		It("returns the error without archiving anything", func() {
+			events := new(dbfakes.FakeEventSource)
+			events.NextReturns(event.Envelope{}, errors.New("nope"))
+
+			err := archive.Save(context.Background(), 42, events)
+			Expect(err).To(MatchError("nope"))
+
+			_, err = archive.Events(42, 0)
+			Expect(err).To(Equal(logarchive.ErrNotFound))
+		})
+	})
+
+	Context("when the build has not been archived", func() {
+		It("returns ErrNotFound", func() {
+			_, err := archive.Events(42, 0)
+			Expect(err).To(Equal(logarchive.ErrNotFound))
+		})
+	})
+})
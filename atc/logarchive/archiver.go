@@ -0,0 +1,89 @@
+package logarchive
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type archiver struct {
+	buildFactory      db.BuildFactory
+	archive           *Archive
+	batchSize         int
+	drainerConfigured bool
+
+	// the last build a batch was archived up to; builds which failed to be
+	// archived are retried once the archiver has gone through all the others
+	lastBuildID int
+}
+
+// NewArchiver moves the events of completed builds out of the database and
+// into the archive.
+func NewArchiver(buildFactory db.BuildFactory, archive *Archive, batchSize int, drainerConfigured bool) *archiver {
+	return &archiver{
+		buildFactory:      buildFactory,
+		archive:           archive,
+		batchSize:         batchSize,
+		drainerConfigured: drainerConfigured,
+	}
+}
+
+func (a *archiver) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("build-log-archiver")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	// the drainer still needs to read the events of the builds it has not
+	// drained yet from the database
+	builds, err := a.buildFactory.GetArchivableBuilds(a.lastBuildID, a.batchSize, a.drainerConfigured)
+	if err != nil {
+		logger.Error("failed-to-get-archivable-builds", err)
+		return err
+	}
+
+	if len(builds) == 0 {
+		a.lastBuildID = 0
+		return nil
+	}
+
+	for _, build := range builds {
+		a.lastBuildID = build.ID()
+
+		// the error is logged, and the build is retried on the next pass
+		_ = a.archiveBuild(ctx, logger, build)
+	}
+
+	return nil
+}
+
+func (a *archiver) archiveBuild(ctx context.Context, logger lager.Logger, build db.Build) error {
+	logger = logger.Session("archive-build", lager.Data{
+		"build": build.ID(),
+	})
+
+	events, err := build.Events(0)
+	if err != nil {
+		logger.Error("failed-to-get-events", err)
+		return err
+	}
+
+	// ignore any errors coming from events.Close()
+	defer db.Close(events)
+
+	err = a.archive.Save(ctx, build.ID(), events)
+	if err != nil {
+		logger.Error("failed-to-archive-events", err)
+		return err
+	}
+
+	err = build.MarkEventsArchived()
+	if err != nil {
+		logger.Error("failed-to-mark-events-archived", err)
+		return err
+	}
+
+	return nil
+}
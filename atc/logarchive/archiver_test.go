@@ -0,0 +1,163 @@
+package logarchive_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/logarchive"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type runnable interface {
+	Run(context.Context) error
+}
+
+var _ = Describe("Archiver", func() {
+	var (
+		dir              string
+		archive          *logarchive.Archive
+		fakeBuildFactory *dbfakes.FakeBuildFactory
+
+		drainedBuild   *dbfakes.FakeBuild
+		undrainedBuild *dbfakes.FakeBuild
+
+		drainerConfigured bool
+
+		archiver runnable
+		runErr   error
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "log-archive")
+		Expect(err).ToNot(HaveOccurred())
+
+		store, err := logarchive.NewFileStore(dir)
+		Expect(err).ToNot(HaveOccurred())
+
+		archive = logarchive.NewArchive(store)
+
+		drainedBuild = new(dbfakes.FakeBuild)
+		drainedBuild.IDReturns(1)
+		drainedBuild.IsDrainedReturns(true)
+		drainedBuild.EventsReturns(fakeEventSource(fakeEvent(`{"build":1}`)), nil)
+
+		undrainedBuild = new(dbfakes.FakeBuild)
+		undrainedBuild.IDReturns(2)
+		undrainedBuild.EventsReturns(fakeEventSource(fakeEvent(`{"build":2}`)), nil)
+
+		fakeBuildFactory = new(dbfakes.FakeBuildFactory)
+		fakeBuildFactory.GetArchivableBuildsReturns([]db.Build{drainedBuild, undrainedBuild}, nil)
+
+		drainerConfigured = false
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		archiver = logarchive.NewArchiver(fakeBuildFactory, archive, 10, drainerConfigured)
+
+		ctx := lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("test"))
+		runErr = archiver.Run(ctx)
+	})
+
+	It("archives the events of the archivable builds", func() {
+		Expect(runErr).ToNot(HaveOccurred())
+
+		afterBuildID, limit, drainedOnly := fakeBuildFactory.GetArchivableBuildsArgsForCall(0)
+		Expect(afterBuildID).To(BeZero())
+		Expect(limit).To(Equal(10))
+		Expect(drainedOnly).To(BeFalse())
+
+		for _, build := range []*dbfakes.FakeBuild{drainedBuild, undrainedBuild} {
+			Expect(build.EventsArgsForCall(0)).To(BeZero())
+			Expect(build.MarkEventsArchivedCallCount()).To(Equal(1))
+
+			source, err := archive.Events(build.ID(), 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(readAll(source)).To(HaveLen(1))
+			Expect(source.Close()).To(Succeed())
+		}
+	})
+
+	Context("when the syslog drainer is configured", func() {
+		BeforeEach(func() {
+			drainerConfigured = true
+		})
+
+		It("only gets the builds which have been drained", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+
+			_, _, drainedOnly := fakeBuildFactory.GetArchivableBuildsArgsForCall(0)
+			Expect(drainedOnly).To(BeTrue())
+		})
+	})
+
+	Describe("running again", func() {
+		var secondRunErr error
+
+		BeforeEach(func() {
+			fakeBuildFactory.GetArchivableBuildsReturnsOnCall(1, []db.Build{}, nil)
+		})
+
+		JustBeforeEach(func() {
+			ctx := lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("test"))
+			secondRunErr = archiver.Run(ctx)
+		})
+
+		It("gets the builds after the last build of the previous batch", func() {
+			Expect(secondRunErr).ToNot(HaveOccurred())
+
+			afterBuildID, _, _ := fakeBuildFactory.GetArchivableBuildsArgsForCall(1)
+			Expect(afterBuildID).To(Equal(2))
+		})
+
+		It("starts over from the oldest build once there are no builds left", func() {
+			fakeBuildFactory.GetArchivableBuildsReturnsOnCall(2, []db.Build{}, nil)
+
+			ctx := lagerctx.NewContext(context.Background(), lagertest.NewTestLogger("test"))
+			Expect(archiver.Run(ctx)).To(Succeed())
+
+			afterBuildID, _, _ := fakeBuildFactory.GetArchivableBuildsArgsForCall(2)
+			Expect(afterBuildID).To(BeZero())
+		})
+	})
+
+	Context("when archiving the events fails", func() {
+		BeforeEach(func() {
+			failingEvents := new(dbfakes.FakeEventSource)
+			failingEvents.NextReturns(event.Envelope{}, errors.New("nope"))
+			drainedBuild.EventsReturns(failingEvents, nil)
+		})
+
+		It("keeps the events in the database", func() {
+			Expect(drainedBuild.MarkEventsArchivedCallCount()).To(BeZero())
+		})
+
+		It("carries on with the other builds", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(undrainedBuild.MarkEventsArchivedCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when getting the archivable builds fails", func() {
+		BeforeEach(func() {
+			fakeBuildFactory.GetArchivableBuildsReturns(nil, errors.New("nope"))
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(MatchError("nope"))
+		})
+	})
+})
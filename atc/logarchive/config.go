@@ -0,0 +1,73 @@
+package logarchive
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+type Config struct {
+	Interval  time.Duration `long:"interval" default:"1m" description:"Interval on which to archive the events of completed builds."`
+	BatchSize int           `long:"batch-size" default:"100" description:"Maximum number of builds to archive on each interval."`
+
+	Dir string `long:"dir" description:"Directory in which to archive the events of completed builds."`
+
+	S3 struct {
+		Bucket          string `long:"s3-bucket" description:"S3 bucket in which to archive the events of completed builds."`
+		Prefix          string `long:"s3-prefix" description:"Prefix of the archived objects within the bucket."`
+		Region          string `long:"s3-region" description:"AWS region of the bucket."`
+		Endpoint        string `long:"s3-endpoint" description:"Endpoint of an S3-compatible store, e.g. MinIO."`
+		ForcePathStyle  bool   `long:"s3-force-path-style" description:"Address the bucket in the path rather than the host name, as required by most S3-compatible stores."`
+		AccessKeyID     string `long:"s3-access-key" description:"AWS access key ID."`
+		SecretAccessKey string `long:"s3-secret-key" description:"AWS secret access key."`
+		SessionToken    string `long:"s3-session-token" description:"AWS session token."`
+	}
+}
+
+func (c Config) IsConfigured() bool {
+	return c.Dir != "" || c.S3.Bucket != ""
+}
+
+// Archive returns the configured archive, or nil if archiving is not
+// configured.
+func (c Config) Archive() (*Archive, error) {
+	if !c.IsConfigured() {
+		return nil, nil
+	}
+
+	if c.Dir != "" && c.S3.Bucket != "" {
+		return nil, errors.New("build log archive cannot be configured with both a directory and an S3 bucket")
+	}
+
+	if c.Dir != "" {
+		store, err := NewFileStore(c.Dir)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewArchive(store), nil
+	}
+
+	config := &aws.Config{
+		Region:           aws.String(c.S3.Region),
+		S3ForcePathStyle: aws.Bool(c.S3.ForcePathStyle),
+	}
+
+	if c.S3.Endpoint != "" {
+		config.Endpoint = aws.String(c.S3.Endpoint)
+	}
+
+	if c.S3.AccessKeyID != "" {
+		config.Credentials = credentials.NewStaticCredentials(c.S3.AccessKeyID, c.S3.SecretAccessKey, c.S3.SessionToken)
+	}
+
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewArchive(NewS3Store(sess, c.S3.Bucket, c.S3.Prefix)), nil
+}
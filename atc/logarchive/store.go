@@ -0,0 +1,17 @@
+package logarchive
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var ErrNotFound = errors.New("archived build events not found")
+
+//go:generate counterfeiter . Store
+
+// A Store holds the archived event streams of builds as opaque objects.
+type Store interface {
+	Put(ctx context.Context, key string, content io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+}
@@ -38,6 +38,14 @@ type PrometheusEmitter struct {
 	buildsFinishedVec *prometheus.CounterVec
 	buildsSucceeded   prometheus.Counter
 
+	pipelineBuildDurations *prometheus.HistogramVec
+	pipelineStepDurations  *prometheus.HistogramVec
+	pipelineCheckDurations *prometheus.HistogramVec
+	pipelineStepCPUTimes   *prometheus.HistogramVec
+	pipelineStepMemory     *prometheus.HistogramVec
+	pipelineStepDisk       *prometheus.HistogramVec
+	histogramPipelines     map[string]bool
+
 	dbConnections  *prometheus.GaugeVec
 	dbQueriesTotal prometheus.Counter
 
@@ -52,6 +60,8 @@ type PrometheusEmitter struct {
 	checksStarted   prometheus.Counter
 	checksEnqueued  prometheus.Counter
 
+	checkThrottleDurations *prometheus.HistogramVec
+
 	workerContainers        *prometheus.GaugeVec
 	workerUnknownContainers *prometheus.GaugeVec
 	workerVolumes           *prometheus.GaugeVec
@@ -69,6 +79,9 @@ type PrometheusEmitter struct {
 type PrometheusConfig struct {
 	BindIP   string `long:"prometheus-bind-ip" description:"IP to listen on to expose Prometheus metrics."`
 	BindPort string `long:"prometheus-bind-port" description:"Port to listen on to expose Prometheus metrics."`
+
+	PipelineHistograms bool     `long:"prometheus-pipeline-histograms" description:"Expose build, step and check duration histograms, along with step resource usage histograms, labelled by team, pipeline and job, with exemplars linking samples to their build and trace. Exemplars are only served in the OpenMetrics format."`
+	HistogramPipelines []string `long:"prometheus-histogram-pipeline" description:"Pipeline to expose pipeline histograms for, either as 'team/pipeline' or 'pipeline'. Samples for other pipelines are aggregated under empty pipeline and job labels. Can be specified multiple times; defaults to all pipelines."`
 }
 
 // The most natural data type to hold the labels is a set because each worker can have multiple but
@@ -252,6 +265,88 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	)
 	prometheus.MustRegister(buildDurationsVec)
 
+	// pipeline histograms, opt-in as they are labelled per pipeline and job
+	var pipelineBuildDurations, pipelineStepDurations, pipelineCheckDurations *prometheus.HistogramVec
+	var pipelineStepCPUTimes, pipelineStepMemory, pipelineStepDisk *prometheus.HistogramVec
+	if config.PipelineHistograms {
+		pipelineBuildDurations = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: "concourse",
+				Subsystem: "pipelines",
+				Name:      "build_duration_seconds",
+				Help:      "Build time in seconds",
+				Buckets:   []float64{1, 60, 180, 300, 600, 900, 1200, 1800, 2700, 3600, 7200, 18000, 36000},
+			},
+			[]string{"team", "pipeline", "job", "status"},
+		)
+		prometheus.MustRegister(pipelineBuildDurations)
+
+		pipelineStepDurations = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: "concourse",
+				Subsystem: "pipelines",
+				Name:      "step_duration_seconds",
+				Help:      "Time taken by get, put and task steps in seconds",
+				Buckets:   []float64{1, 5, 15, 30, 60, 180, 300, 600, 1200, 1800, 3600, 7200},
+			},
+			[]string{"team", "pipeline", "job", "type"},
+		)
+		prometheus.MustRegister(pipelineStepDurations)
+
+		pipelineCheckDurations = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: "concourse",
+				Subsystem: "pipelines",
+				Name:      "check_duration_seconds",
+				Help:      "Time taken by checks in seconds",
+				Buckets:   []float64{0.5, 1, 2, 5, 10, 30, 60, 120, 300, 600},
+			},
+			[]string{"team", "pipeline", "status"},
+		)
+		prometheus.MustRegister(pipelineCheckDurations)
+
+		pipelineStepCPUTimes = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: "concourse",
+				Subsystem: "pipelines",
+				Name:      "step_cpu_seconds",
+				Help:      "CPU time consumed by the containers of get, put and task steps in seconds",
+				Buckets:   []float64{1, 5, 15, 30, 60, 180, 300, 600, 1200, 1800, 3600, 7200},
+			},
+			[]string{"team", "pipeline", "job", "type"},
+		)
+		prometheus.MustRegister(pipelineStepCPUTimes)
+
+		pipelineStepMemory = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: "concourse",
+				Subsystem: "pipelines",
+				Name:      "step_memory_peak_bytes",
+				Help:      "Peak memory usage of the containers of get, put and task steps in bytes",
+				Buckets:   prometheus.ExponentialBuckets(64*1024*1024, 2, 10),
+			},
+			[]string{"team", "pipeline", "job", "type"},
+		)
+		prometheus.MustRegister(pipelineStepMemory)
+
+		pipelineStepDisk = prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: "concourse",
+				Subsystem: "pipelines",
+				Name:      "step_rootfs_disk_usage_bytes",
+				Help:      "Disk usage of the root filesystem of the containers of get, put and task steps in bytes",
+				Buckets:   prometheus.ExponentialBuckets(16*1024*1024, 4, 8),
+			},
+			[]string{"team", "pipeline", "job", "type"},
+		)
+		prometheus.MustRegister(pipelineStepDisk)
+	}
+
+	histogramPipelines := map[string]bool{}
+	for _, pipeline := range config.HistogramPipelines {
+		histogramPipelines[pipeline] = true
+	}
+
 	// worker metrics
 	workerContainers := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -391,12 +486,36 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	)
 	prometheus.MustRegister(checksEnqueued)
 
+	checkThrottleDurations := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "lidar",
+			Name:      "check_throttle_duration_seconds",
+			Help:      "Time checks were held back by the check rate limits",
+			Buckets:   []float64{1, 5, 15, 30, 60, 120, 300, 600},
+		},
+		[]string{"limit"},
+	)
+	prometheus.MustRegister(checkThrottleDurations)
+
 	listener, err := net.Listen("tcp", config.bind())
 	if err != nil {
 		return nil, err
 	}
 
-	go http.Serve(listener, promhttp.Handler())
+	handler := promhttp.Handler()
+	if config.PipelineHistograms {
+		// exemplars can only be exposed using the OpenMetrics format, which is
+		// served to scrapers asking for it
+		handler = promhttp.InstrumentMetricHandler(
+			prometheus.DefaultRegisterer,
+			promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{
+				EnableOpenMetrics: true,
+			}),
+		)
+	}
+
+	go http.Serve(listener, handler)
 
 	emitter := &PrometheusEmitter{
 		jobsScheduled:  jobsScheduled,
@@ -419,6 +538,14 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 		buildsFinishedVec: buildsFinishedVec,
 		buildsSucceeded:   buildsSucceeded,
 
+		pipelineBuildDurations: pipelineBuildDurations,
+		pipelineStepDurations:  pipelineStepDurations,
+		pipelineCheckDurations: pipelineCheckDurations,
+		pipelineStepCPUTimes:   pipelineStepCPUTimes,
+		pipelineStepMemory:     pipelineStepMemory,
+		pipelineStepDisk:       pipelineStepDisk,
+		histogramPipelines:     histogramPipelines,
+
 		dbConnections:  dbConnections,
 		dbQueriesTotal: dbQueriesTotal,
 
@@ -433,6 +560,8 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 		checksStarted:   checksStarted,
 		checksEnqueued:  checksEnqueued,
 
+		checkThrottleDurations: checkThrottleDurations,
+
 		workerContainers:        workerContainers,
 		workersRegistered:       workersRegistered,
 		workerContainersLabels:  map[string]map[string]prometheus.Labels{},
@@ -492,6 +621,17 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 			).Observe(event.Value)
 	case "build finished":
 		emitter.buildFinishedMetrics(logger, event)
+	case "step finished":
+		emitter.stepFinishedMetrics(logger, event)
+	case "check finished":
+		emitter.checkFinishedMetrics(logger, event)
+	case "step cpu time":
+		// seconds are the standard prometheus base unit for time
+		emitter.stepResourceUsageMetrics(logger, event, emitter.pipelineStepCPUTimes, event.Value/1000)
+	case "step memory peak":
+		emitter.stepResourceUsageMetrics(logger, event, emitter.pipelineStepMemory, event.Value)
+	case "step rootfs disk usage":
+		emitter.stepResourceUsageMetrics(logger, event, emitter.pipelineStepDisk, event.Value)
 	case "worker containers":
 		emitter.workerContainersMetric(logger, event)
 	case "worker volumes":
@@ -518,6 +658,8 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 		emitter.checksEnqueued.Add(event.Value)
 	case "checks queue size":
 		emitter.checksQueueSize.Set(event.Value)
+	case "check throttled":
+		emitter.checkThrottleDurations.WithLabelValues(event.Attributes["limit"]).Observe(event.Value / 1000)
 	default:
 		// unless we have a specific metric, we do nothing
 	}
@@ -597,6 +739,159 @@ func (emitter *PrometheusEmitter) buildFinishedMetrics(logger lager.Logger, even
 	// seconds are the standard prometheus base unit for time
 	duration := event.Value / 1000
 	emitter.buildDurationsVec.WithLabelValues(team, pipeline, job).Observe(duration)
+
+	// concourse_pipelines_build_duration_seconds
+	if emitter.pipelineBuildDurations != nil {
+		pipeline, job = emitter.histogramPipelineAndJob(team, pipeline, job)
+		observeWithExemplar(
+			emitter.pipelineBuildDurations.WithLabelValues(team, pipeline, job, buildStatus),
+			duration,
+			exemplarLabels(event, "build_id", "trace_id"),
+		)
+	}
+}
+
+func (emitter *PrometheusEmitter) stepFinishedMetrics(logger lager.Logger, event metric.Event) {
+	if emitter.pipelineStepDurations == nil {
+		return
+	}
+
+	team, exists := event.Attributes["team_name"]
+	if !exists {
+		logger.Error("failed-to-find-team-name-in-event", fmt.Errorf("expected team_name to exist in event.Attributes"))
+		return
+	}
+
+	pipeline, exists := event.Attributes["pipeline"]
+	if !exists {
+		logger.Error("failed-to-find-pipeline-in-event", fmt.Errorf("expected pipeline to exist in event.Attributes"))
+		return
+	}
+
+	job, exists := event.Attributes["job"]
+	if !exists {
+		logger.Error("failed-to-find-job-in-event", fmt.Errorf("expected job to exist in event.Attributes"))
+		return
+	}
+
+	stepType, exists := event.Attributes["step_type"]
+	if !exists {
+		logger.Error("failed-to-find-step-type-in-event", fmt.Errorf("expected step_type to exist in event.Attributes"))
+		return
+	}
+
+	pipeline, job = emitter.histogramPipelineAndJob(team, pipeline, job)
+	observeWithExemplar(
+		emitter.pipelineStepDurations.WithLabelValues(team, pipeline, job, stepType),
+		event.Value/1000,
+		exemplarLabels(event, "build_id", "trace_id"),
+	)
+}
+
+func (emitter *PrometheusEmitter) stepResourceUsageMetrics(logger lager.Logger, event metric.Event, histogram *prometheus.HistogramVec, value float64) {
+	if histogram == nil {
+		return
+	}
+
+	team, exists := event.Attributes["team_name"]
+	if !exists {
+		logger.Error("failed-to-find-team-name-in-event", fmt.Errorf("expected team_name to exist in event.Attributes"))
+		return
+	}
+
+	pipeline, exists := event.Attributes["pipeline"]
+	if !exists {
+		logger.Error("failed-to-find-pipeline-in-event", fmt.Errorf("expected pipeline to exist in event.Attributes"))
+		return
+	}
+
+	job, exists := event.Attributes["job"]
+	if !exists {
+		logger.Error("failed-to-find-job-in-event", fmt.Errorf("expected job to exist in event.Attributes"))
+		return
+	}
+
+	stepType, exists := event.Attributes["step_type"]
+	if !exists {
+		logger.Error("failed-to-find-step-type-in-event", fmt.Errorf("expected step_type to exist in event.Attributes"))
+		return
+	}
+
+	pipeline, job = emitter.histogramPipelineAndJob(team, pipeline, job)
+	observeWithExemplar(
+		histogram.WithLabelValues(team, pipeline, job, stepType),
+		value,
+		exemplarLabels(event, "build_id", "trace_id"),
+	)
+}
+
+func (emitter *PrometheusEmitter) checkFinishedMetrics(logger lager.Logger, event metric.Event) {
+	if emitter.pipelineCheckDurations == nil {
+		return
+	}
+
+	team, exists := event.Attributes["team_name"]
+	if !exists {
+		logger.Error("failed-to-find-team-name-in-event", fmt.Errorf("expected team_name to exist in event.Attributes"))
+		return
+	}
+
+	pipeline, exists := event.Attributes["pipeline"]
+	if !exists {
+		logger.Error("failed-to-find-pipeline-in-event", fmt.Errorf("expected pipeline to exist in event.Attributes"))
+		return
+	}
+
+	checkStatus, exists := event.Attributes["check_status"]
+	if !exists {
+		logger.Error("failed-to-find-check-status-in-event", fmt.Errorf("expected check_status to exist in event.Attributes"))
+		return
+	}
+
+	pipeline, _ = emitter.histogramPipelineAndJob(team, pipeline, "")
+	observeWithExemplar(
+		emitter.pipelineCheckDurations.WithLabelValues(team, pipeline, checkStatus),
+		event.Value/1000,
+		exemplarLabels(event, "trace_id"),
+	)
+}
+
+// histogramPipelineAndJob bounds the cardinality of the pipeline histograms by
+// dropping the pipeline and job labels of pipelines that are not explicitly
+// allowed, aggregating their samples per team instead.
+func (emitter *PrometheusEmitter) histogramPipelineAndJob(team, pipeline, job string) (string, string) {
+	if len(emitter.histogramPipelines) == 0 {
+		return pipeline, job
+	}
+
+	if emitter.histogramPipelines[pipeline] || emitter.histogramPipelines[team+"/"+pipeline] {
+		return pipeline, job
+	}
+
+	return "", ""
+}
+
+// exemplarLabels picks the given attributes of an event that are set, so that
+// a sample can be traced back to e.g. the build it was observed for.
+func exemplarLabels(event metric.Event, attributes ...string) prometheus.Labels {
+	labels := prometheus.Labels{}
+	for _, attribute := range attributes {
+		if value := event.Attributes[attribute]; value != "" {
+			labels[attribute] = value
+		}
+	}
+
+	return labels
+}
+
+func observeWithExemplar(observer prometheus.Observer, value float64, exemplar prometheus.Labels) {
+	exemplarObserver, ok := observer.(prometheus.ExemplarObserver)
+	if !ok || len(exemplar) == 0 {
+		observer.Observe(value)
+		return
+	}
+
+	exemplarObserver.ObserveWithExemplar(value, exemplar)
 }
 
 func (emitter *PrometheusEmitter) workerContainersMetric(logger lager.Logger, event metric.Event) {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -211,3 +212,192 @@ var _ = Describe("PrometheusEmitter", func() {
 		Expect(err).To(BeNil())
 	})
 })
+
+var _ = Describe("PrometheusEmitter pipeline histograms", func() {
+	var (
+		prometheusConfig  *emitter.PrometheusConfig
+		prometheusEmitter metric.Emitter
+		logger            *lagertest.TestLogger
+
+		defaultRegisterer prometheus.Registerer
+		defaultGatherer   prometheus.Gatherer
+
+		port = 9190
+	)
+
+	BeforeEach(func() {
+		// every emitter registers its metrics globally, so give each one a
+		// registry of its own
+		defaultRegisterer, defaultGatherer = prometheus.DefaultRegisterer, prometheus.DefaultGatherer
+		registry := prometheus.NewRegistry()
+		prometheus.DefaultRegisterer, prometheus.DefaultGatherer = registry, registry
+
+		logger = lagertest.NewTestLogger("test")
+
+		port++
+		prometheusConfig = &emitter.PrometheusConfig{
+			BindIP:             "localhost",
+			BindPort:           strconv.Itoa(port),
+			PipelineHistograms: true,
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		prometheusEmitter, err = prometheusConfig.NewEmitter()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		prometheus.DefaultRegisterer, prometheus.DefaultGatherer = defaultRegisterer, defaultGatherer
+	})
+
+	scrape := func(accept string) string {
+		req, err := http.NewRequest("GET", fmt.Sprintf("http://%s:%s/metrics", prometheusConfig.BindIP, prometheusConfig.BindPort), nil)
+		Expect(err).ToNot(HaveOccurred())
+		req.Header.Set("Accept", accept)
+
+		res, err := http.DefaultClient.Do(req)
+		Expect(err).ToNot(HaveOccurred())
+		defer res.Body.Close()
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+
+		body, err := ioutil.ReadAll(res.Body)
+		Expect(err).ToNot(HaveOccurred())
+
+		return string(body)
+	}
+
+	openMetrics := "application/openmetrics-text; version=0.0.1"
+
+	buildFinished := func(pipeline string) metric.Event {
+		return metric.Event{
+			Name:  "build finished",
+			Value: 30000,
+			Attributes: map[string]string{
+				"team_name":    "main",
+				"pipeline":     pipeline,
+				"job":          "unit",
+				"build_id":     "42",
+				"build_status": "succeeded",
+				"trace_id":     "0af7651916cd43dd8448eb211c80319c",
+			},
+		}
+	}
+
+	It("emits build durations with the build and trace as exemplar", func() {
+		prometheusEmitter.Emit(logger, buildFinished("some-pipeline"))
+
+		Expect(scrape(openMetrics)).To(ContainSubstring(
+			`concourse_pipelines_build_duration_seconds_bucket{job="unit",pipeline="some-pipeline",status="succeeded",team="main",le="60.0"} 1 # {build_id="42",trace_id="0af7651916cd43dd8448eb211c80319c"} 30.0`,
+		))
+	})
+
+	It("emits step durations per step type", func() {
+		prometheusEmitter.Emit(logger, metric.Event{
+			Name:  "step finished",
+			Value: 4000,
+			Attributes: map[string]string{
+				"team_name": "main",
+				"pipeline":  "some-pipeline",
+				"job":       "unit",
+				"step_name": "run-tests",
+				"step_type": "task",
+				"build_id":  "42",
+			},
+		})
+
+		Expect(scrape(openMetrics)).To(ContainSubstring(
+			`concourse_pipelines_step_duration_seconds_bucket{job="unit",pipeline="some-pipeline",team="main",type="task",le="5.0"} 1 # {build_id="42"} 4.0`,
+		))
+	})
+
+	It("emits step resource usage per step type", func() {
+		attributes := map[string]string{
+			"team_name": "main",
+			"pipeline":  "some-pipeline",
+			"job":       "unit",
+			"step_name": "run-tests",
+			"step_type": "task",
+		}
+
+		prometheusEmitter.Emit(logger, metric.Event{Name: "step cpu time", Value: 12000, Attributes: attributes})
+		prometheusEmitter.Emit(logger, metric.Event{Name: "step memory peak", Value: 100 * 1024 * 1024, Attributes: attributes})
+		prometheusEmitter.Emit(logger, metric.Event{Name: "step rootfs disk usage", Value: 1024, Attributes: attributes})
+
+		metrics := scrape("text/plain")
+		Expect(metrics).To(ContainSubstring(
+			`concourse_pipelines_step_cpu_seconds_bucket{job="unit",pipeline="some-pipeline",team="main",type="task",le="15"} 1`,
+		))
+		Expect(metrics).To(ContainSubstring(
+			`concourse_pipelines_step_memory_peak_bytes_bucket{job="unit",pipeline="some-pipeline",team="main",type="task",le="1.34217728e+08"} 1`,
+		))
+		Expect(metrics).To(ContainSubstring(
+			`concourse_pipelines_step_rootfs_disk_usage_bytes_sum{job="unit",pipeline="some-pipeline",team="main",type="task"} 1024`,
+		))
+	})
+
+	It("emits check durations", func() {
+		prometheusEmitter.Emit(logger, metric.Event{
+			Name:  "check finished",
+			Value: 1500,
+			Attributes: map[string]string{
+				"team_name":    "main",
+				"pipeline":     "some-pipeline",
+				"resource":     "some-resource",
+				"check_status": "succeeded",
+			},
+		})
+
+		Expect(scrape("text/plain")).To(ContainSubstring(
+			`concourse_pipelines_check_duration_seconds_bucket{pipeline="some-pipeline",status="succeeded",team="main",le="2"} 1`,
+		))
+	})
+
+	It("emits check throttle durations per limit", func() {
+		prometheusEmitter.Emit(logger, metric.Event{
+			Name:  "check throttled",
+			Value: 4000,
+			Attributes: map[string]string{
+				"limit": "resource_type",
+			},
+		})
+
+		Expect(scrape("text/plain")).To(ContainSubstring(
+			`concourse_lidar_check_throttle_duration_seconds_bucket{limit="resource_type",le="5"} 1`,
+		))
+	})
+
+	Context("when histograms are restricted to some pipelines", func() {
+		BeforeEach(func() {
+			prometheusConfig.HistogramPipelines = []string{"main/allowed-pipeline", "other-allowed-pipeline"}
+		})
+
+		It("aggregates samples of other pipelines per team", func() {
+			prometheusEmitter.Emit(logger, buildFinished("allowed-pipeline"))
+			prometheusEmitter.Emit(logger, buildFinished("other-allowed-pipeline"))
+			prometheusEmitter.Emit(logger, buildFinished("some-pipeline"))
+
+			metrics := scrape("text/plain")
+			Expect(metrics).To(ContainSubstring(`concourse_pipelines_build_duration_seconds_count{job="unit",pipeline="allowed-pipeline",status="succeeded",team="main"} 1`))
+			Expect(metrics).To(ContainSubstring(`concourse_pipelines_build_duration_seconds_count{job="unit",pipeline="other-allowed-pipeline",status="succeeded",team="main"} 1`))
+			Expect(metrics).To(ContainSubstring(`concourse_pipelines_build_duration_seconds_count{job="",pipeline="",status="succeeded",team="main"} 1`))
+			Expect(metrics).ToNot(ContainSubstring(`concourse_pipelines_build_duration_seconds_count{job="unit",pipeline="some-pipeline"`))
+		})
+	})
+
+	Context("when pipeline histograms are not enabled", func() {
+		BeforeEach(func() {
+			prometheusConfig.PipelineHistograms = false
+		})
+
+		It("does not emit them", func() {
+			prometheusEmitter.Emit(logger, buildFinished("some-pipeline"))
+
+			metrics := scrape(openMetrics)
+			Expect(metrics).To(ContainSubstring("concourse_builds_duration_seconds_count"))
+			Expect(metrics).ToNot(ContainSubstring("concourse_pipelines_"))
+		})
+	})
+})
@@ -459,21 +459,173 @@ type BuildFinished struct {
 	BuildStatus   db.BuildStatus
 	BuildDuration time.Duration
 	TeamName      string
+	TraceID       string
 }
 
 func (event BuildFinished) Emit(logger lager.Logger) {
+	attributes := map[string]string{
+		"pipeline":     event.PipelineName,
+		"job":          event.JobName,
+		"build_name":   event.BuildName,
+		"build_id":     strconv.Itoa(event.BuildID),
+		"build_status": string(event.BuildStatus),
+		"team_name":    event.TeamName,
+	}
+
+	if event.TraceID != "" {
+		attributes["trace_id"] = event.TraceID
+	}
+
 	Metrics.emit(
 		logger.Session("build-finished"),
 		Event{
-			Name:  "build finished",
-			Value: ms(event.BuildDuration),
+			Name:       "build finished",
+			Value:      ms(event.BuildDuration),
+			Attributes: attributes,
+		},
+	)
+}
+
+type StepFinished struct {
+	PipelineName string
+	JobName      string
+	BuildName    string
+	BuildID      int
+	TeamName     string
+	StepName     string
+	StepType     string
+	StepDuration time.Duration
+	TraceID      string
+}
+
+func (event StepFinished) Emit(logger lager.Logger) {
+	attributes := map[string]string{
+		"pipeline":   event.PipelineName,
+		"job":        event.JobName,
+		"build_name": event.BuildName,
+		"build_id":   strconv.Itoa(event.BuildID),
+		"team_name":  event.TeamName,
+		"step_name":  event.StepName,
+		"step_type":  event.StepType,
+	}
+
+	if event.TraceID != "" {
+		attributes["trace_id"] = event.TraceID
+	}
+
+	Metrics.emit(
+		logger.Session("step-finished"),
+		Event{
+			Name:       "step finished",
+			Value:      ms(event.StepDuration),
+			Attributes: attributes,
+		},
+	)
+}
+
+type StepResourceUsage struct {
+	PipelineName    string
+	JobName         string
+	BuildName       string
+	BuildID         int
+	TeamName        string
+	StepName        string
+	StepType        string
+	CPUTime         time.Duration
+	MemoryPeak      uint64
+	RootfsDiskUsage uint64
+	TraceID         string
+}
+
+func (event StepResourceUsage) Emit(logger lager.Logger) {
+	attributes := map[string]string{
+		"pipeline":   event.PipelineName,
+		"job":        event.JobName,
+		"build_name": event.BuildName,
+		"build_id":   strconv.Itoa(event.BuildID),
+		"team_name":  event.TeamName,
+		"step_name":  event.StepName,
+		"step_type":  event.StepType,
+	}
+
+	if event.TraceID != "" {
+		attributes["trace_id"] = event.TraceID
+	}
+
+	logger = logger.Session("step-resource-usage")
+
+	Metrics.emit(
+		logger,
+		Event{
+			Name:       "step cpu time",
+			Value:      ms(event.CPUTime),
+			Attributes: attributes,
+		},
+	)
+
+	Metrics.emit(
+		logger,
+		Event{
+			Name:       "step memory peak",
+			Value:      float64(event.MemoryPeak),
+			Attributes: attributes,
+		},
+	)
+
+	Metrics.emit(
+		logger,
+		Event{
+			Name:       "step rootfs disk usage",
+			Value:      float64(event.RootfsDiskUsage),
+			Attributes: attributes,
+		},
+	)
+}
+
+type CheckFinished struct {
+	PipelineName  string
+	ResourceName  string
+	TeamName      string
+	CheckStatus   db.CheckStatus
+	CheckDuration time.Duration
+	TraceID       string
+}
+
+func (event CheckFinished) Emit(logger lager.Logger) {
+	attributes := map[string]string{
+		"pipeline":     event.PipelineName,
+		"resource":     event.ResourceName,
+		"team_name":    event.TeamName,
+		"check_status": string(event.CheckStatus),
+	}
+
+	if event.TraceID != "" {
+		attributes["trace_id"] = event.TraceID
+	}
+
+	Metrics.emit(
+		logger.Session("check-finished"),
+		Event{
+			Name:       "check finished",
+			Value:      ms(event.CheckDuration),
+			Attributes: attributes,
+		},
+	)
+}
+
+type CheckThrottled struct {
+	Limit string
+	Delay time.Duration
+}
+
+func (event CheckThrottled) Emit(logger lager.Logger) {
+	Metrics.emit(
+		logger.Session("check-throttled"),
+		Event{
+			Name:  "check throttled",
+			Value: ms(event.Delay),
 			Attributes: map[string]string{
-				"pipeline":     event.PipelineName,
-				"job":          event.JobName,
-				"build_name":   event.BuildName,
-				"build_id":     strconv.Itoa(event.BuildID),
-				"build_status": string(event.BuildStatus),
-				"team_name":    event.TeamName,
+				"limit": event.Limit,
 			},
 		},
 	)
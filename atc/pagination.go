@@ -1,5 +1,7 @@
 package atc
 
+import "net/url"
+
 const (
 	LinkRelNext     = "next"
 	LinkRelPrevious = "previous"
@@ -12,4 +14,16 @@ const (
 	PaginationQueryLimit      = "limit"
 	PaginationWebLimit        = 100
 	PaginationAPIDefaultLimit = 100
+
+	BuildsQuerySecret = "secret"
 )
+
+// BuildsSecretLinkParam returns the query string suffix that keeps a secret
+// filter on the pagination links of a list of builds.
+func BuildsSecretLinkParam(secret string) string {
+	if secret == "" {
+		return ""
+	}
+
+	return "&" + BuildsQuerySecret + "=" + url.QueryEscape(secret)
+}
@@ -1,17 +1,125 @@
 package atc
 
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
 type Pipeline struct {
-	ID          int            `json:"id"`
-	Name        string         `json:"name"`
-	Paused      bool           `json:"paused"`
-	Public      bool           `json:"public"`
-	Archived    bool           `json:"archived"`
-	Groups      GroupConfigs   `json:"groups,omitempty"`
-	TeamName    string         `json:"team_name"`
-	Display     *DisplayConfig `json:"display,omitempty"`
-	LastUpdated int64          `json:"last_updated,omitempty"`
+	ID           int            `json:"id"`
+	Name         string         `json:"name"`
+	InstanceVars InstanceVars   `json:"instance_vars,omitempty"`
+	Paused       bool           `json:"paused"`
+	Public       bool           `json:"public"`
+	Archived     bool           `json:"archived"`
+	Groups       GroupConfigs   `json:"groups,omitempty"`
+	TeamName     string         `json:"team_name"`
+	Display      *DisplayConfig `json:"display,omitempty"`
+	LastUpdated  int64          `json:"last_updated,omitempty"`
+}
+
+func (p Pipeline) Ref() PipelineRef {
+	return PipelineRef{
+		Name:         p.Name,
+		InstanceVars: p.InstanceVars,
+	}
 }
 
 type RenameRequest struct {
 	NewName string `json:"name"`
 }
+
+// InstanceVarsQueryParam is the query parameter used to address a pipeline
+// instance through the API. Its value is the JSON encoded instance vars.
+const InstanceVarsQueryParam = "vars"
+
+// InstanceVars are the vars that, together with its name, identify an
+// instance of a pipeline. Pipelines that share a name but have different
+// instance vars are grouped together.
+type InstanceVars map[string]interface{}
+
+// String renders the instance vars as comma separated key:value pairs,
+// ordered by key, e.g. "branch:feature,version:1.2". Values are rendered as
+// YAML scalars; strings which would otherwise be read back as another type
+// are quoted.
+func (iv InstanceVars) String() string {
+	keys := make([]string, 0, len(iv))
+	for k := range iv {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		var value string
+		switch v := iv[k].(type) {
+		case string:
+			value = v
+
+			var parsed interface{}
+			err := yaml.Unmarshal([]byte(v), &parsed)
+			if _, isString := parsed.(string); err != nil || !isString {
+				payload, _ := json.Marshal(v)
+				value = string(payload)
+			}
+		default:
+			payload, _ := json.Marshal(v)
+			value = string(payload)
+		}
+
+		pairs[i] = k + ":" + value
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+// PipelineRef identifies a pipeline (or an instance of a pipeline) within a
+// team.
+type PipelineRef struct {
+	Name         string       `json:"name"`
+	InstanceVars InstanceVars `json:"instance_vars,omitempty"`
+}
+
+func (ref PipelineRef) String() string {
+	if len(ref.InstanceVars) == 0 {
+		return ref.Name
+	}
+
+	return ref.Name + "/" + ref.InstanceVars.String()
+}
+
+// QueryParams returns the query parameters needed to address the pipeline
+// instance through the API, or nil if the pipeline has no instance vars.
+func (ref PipelineRef) QueryParams() url.Values {
+	if len(ref.InstanceVars) == 0 {
+		return nil
+	}
+
+	payload, _ := json.Marshal(ref.InstanceVars)
+	return url.Values{InstanceVarsQueryParam: []string{string(payload)}}
+}
+
+func InstanceVarsFromQueryParams(params url.Values) (InstanceVars, error) {
+	payload := params.Get(InstanceVarsQueryParam)
+	if payload == "" {
+		return nil, nil
+	}
+
+	var instanceVars InstanceVars
+	err := json.Unmarshal([]byte(payload), &instanceVars)
+	if err != nil {
+		return nil, fmt.Errorf("malformed instance vars: %w", err)
+	}
+
+	if len(instanceVars) == 0 {
+		return nil, nil
+	}
+
+	return instanceVars, nil
+}
@@ -0,0 +1,75 @@
+package atc_test
+
+import (
+	"net/url"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/atc"
+)
+
+var _ = Describe("PipelineRef", func() {
+	Describe("String", func() {
+		It("renders just the name when there are no instance vars", func() {
+			ref := atc.PipelineRef{Name: "some-pipeline"}
+			Expect(ref.String()).To(Equal("some-pipeline"))
+		})
+
+		It("renders the instance vars ordered by key", func() {
+			ref := atc.PipelineRef{
+				Name: "some-pipeline",
+				InstanceVars: atc.InstanceVars{
+					"version": 1.2,
+					"branch":  "feature",
+				},
+			}
+			Expect(ref.String()).To(Equal("some-pipeline/branch:feature,version:1.2"))
+		})
+
+		It("quotes strings which would be read back as another type", func() {
+			ref := atc.PipelineRef{
+				Name: "some-pipeline",
+				InstanceVars: atc.InstanceVars{
+					"version": "1.2",
+					"enabled": "true",
+				},
+			}
+			Expect(ref.String()).To(Equal(`some-pipeline/enabled:"true",version:"1.2"`))
+		})
+	})
+
+	Describe("QueryParams", func() {
+		It("returns nil when there are no instance vars", func() {
+			ref := atc.PipelineRef{Name: "some-pipeline"}
+			Expect(ref.QueryParams()).To(BeNil())
+		})
+
+		It("round trips the instance vars", func() {
+			ref := atc.PipelineRef{
+				Name:         "some-pipeline",
+				InstanceVars: atc.InstanceVars{"branch": "feature"},
+			}
+
+			params := ref.QueryParams()
+			Expect(params.Get("vars")).To(Equal(`{"branch":"feature"}`))
+
+			instanceVars, err := atc.InstanceVarsFromQueryParams(params)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(instanceVars).To(Equal(ref.InstanceVars))
+		})
+	})
+
+	Describe("InstanceVarsFromQueryParams", func() {
+		It("returns nil when the param is missing", func() {
+			instanceVars, err := atc.InstanceVarsFromQueryParams(url.Values{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(instanceVars).To(BeNil())
+		})
+
+		It("errors when the param is malformed", func() {
+			_, err := atc.InstanceVarsFromQueryParams(url.Values{"vars": []string{"{"}})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -63,6 +63,10 @@ func (plan *Plan) Each(f func(*Plan)) {
 			p.Step.Each(f)
 			plan.Across.Steps[i] = p
 		}
+
+		if plan.Across.SubStepTemplate != nil {
+			plan.Across.SubStepTemplate.Each(f)
+		}
 	}
 
 	if plan.OnSuccess != nil {
@@ -160,15 +164,24 @@ type InParallelPlan struct {
 }
 
 type AcrossPlan struct {
-	Vars     []AcrossVar     `json:"vars"`
-	Steps    []VarScopedPlan `json:"steps"`
-	FailFast bool            `json:"fail_fast,omitempty"`
+	Vars  []AcrossVar     `json:"vars"`
+	Steps []VarScopedPlan `json:"steps"`
+
+	// SubStepTemplate is set instead of Steps when the values of any of the
+	// vars are only known at runtime. The sub-steps are then planned from it
+	// once the values have been resolved.
+	SubStepTemplate *Plan               `json:"substep_template,omitempty"`
+	Include         []AcrossCombination `json:"include,omitempty"`
+	Exclude         []AcrossCombination `json:"exclude,omitempty"`
+
+	MaxInFlight *MaxInFlightConfig `json:"max_in_flight,omitempty"`
+	FailFast    bool               `json:"fail_fast,omitempty"`
 }
 
 type AcrossVar struct {
-	Var         string        `json:"name"`
-	Values      []interface{} `json:"values"`
-	MaxInFlight int           `json:"max_in_flight"`
+	Var         string             `json:"name"`
+	Values      interface{}        `json:"values"`
+	MaxInFlight *MaxInFlightConfig `json:"max_in_flight,omitempty"`
 }
 
 type VarScopedPlan struct {
@@ -234,11 +247,12 @@ type TaskPlan struct {
 }
 
 type SetPipelinePlan struct {
-	Name     string                 `json:"name"`
-	File     string                 `json:"file"`
-	Team     string                 `json:"team,omitempty"`
-	Vars     map[string]interface{} `json:"vars,omitempty"`
-	VarFiles []string               `json:"var_files,omitempty"`
+	Name         string                 `json:"name"`
+	File         string                 `json:"file"`
+	Team         string                 `json:"team,omitempty"`
+	Vars         map[string]interface{} `json:"vars,omitempty"`
+	VarFiles     []string               `json:"var_files,omitempty"`
+	InstanceVars map[string]interface{} `json:"instance_vars,omitempty"`
 }
 
 type LoadVarPlan struct {
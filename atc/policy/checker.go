@@ -5,11 +5,23 @@ import (
 	"strings"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/jessevdk/go-flags"
 )
 
 const ActionUseImage = "UseImage"
 
+// Actions checked when running build steps, with the step's configuration as
+// input data.
+const (
+	ActionRunTask        = "RunTask"
+	ActionRunGet         = "RunGet"
+	ActionRunPut         = "RunPut"
+	ActionRunCheck       = "RunCheck"
+	ActionRunSetPipeline = "RunSetPipeline"
+	ActionRunLoadVar     = "RunLoadVar"
+)
+
 type PolicyCheckNotPass struct {
 	Reasons []string
 }
@@ -40,6 +52,11 @@ type PolicyCheckInput struct {
 type PolicyCheckOutput struct {
 	Allowed bool
 	Reasons []string
+
+	// Warned is set when the check did not pass but the checker is running in
+	// audit-only mode, so the action was allowed anyway. Reasons then explain
+	// why the check would have failed.
+	Warned bool
 }
 
 // FailedPolicyCheck creates a generic failed check
@@ -75,6 +92,13 @@ type AgentFactory interface {
 	NewAgent(lager.Logger) (Agent, error)
 }
 
+//go:generate counterfeiter . DecisionRecorder
+
+// DecisionRecorder keeps track of policy checks that did not pass.
+type DecisionRecorder interface {
+	CreateDecision(atc.PolicyDecision) error
+}
+
 var agentFactories []AgentFactory
 
 func RegisterAgent(factory AgentFactory) {
@@ -95,7 +119,11 @@ var (
 	clusterVersion string
 )
 
-func Initialize(logger lager.Logger, cluster string, version string, filter Filter) (*Checker, error) {
+// Initialize creates a checker using the configured policy agent, if any.
+// Checks that do not pass are recorded using the recorder, which may be nil.
+// With auditOnly set they are allowed anyway, so that new policies can be
+// tried out without breaking anything.
+func Initialize(logger lager.Logger, cluster string, version string, filter Filter, auditOnly bool, recorder DecisionRecorder) (*Checker, error) {
 	logger.Debug("policy-checker-initialize")
 
 	clusterName = cluster
@@ -122,8 +150,11 @@ func Initialize(logger lager.Logger, cluster string, version string, filter Filt
 				lager.Data{"rfc": "https://github.com/concourse/rfcs/pull/41"})
 
 			return &Checker{
-				filter: filter,
-				agent:  agent,
+				logger:    logger.Session("policy-checker"),
+				filter:    filter,
+				agent:     agent,
+				auditOnly: auditOnly,
+				recorder:  recorder,
 			}, nil
 		}
 	}
@@ -133,8 +164,11 @@ func Initialize(logger lager.Logger, cluster string, version string, filter Filt
 }
 
 type Checker struct {
-	filter Filter
-	agent  Agent
+	logger    lager.Logger
+	filter    Filter
+	agent     Agent
+	auditOnly bool
+	recorder  DecisionRecorder
 }
 
 func (c *Checker) ShouldCheckHttpMethod(method string) bool {
@@ -164,5 +198,36 @@ func (c *Checker) Check(input PolicyCheckInput) (PolicyCheckOutput, error) {
 	input.Service = "concourse"
 	input.ClusterName = clusterName
 	input.ClusterVersion = clusterVersion
-	return c.agent.Check(input)
+
+	output, err := c.agent.Check(input)
+	if err != nil || output.Allowed {
+		return output, err
+	}
+
+	if c.recorder != nil {
+		err = c.recorder.CreateDecision(atc.PolicyDecision{
+			Team:      input.Team,
+			Pipeline:  input.Pipeline,
+			Action:    input.Action,
+			Reasons:   output.Reasons,
+			AuditOnly: c.auditOnly,
+		})
+		if err != nil {
+			// failing to record the decision should not change its outcome
+			c.logger.Error("failed-to-record-decision", err, lager.Data{"action": input.Action})
+		}
+	}
+
+	if c.auditOnly {
+		c.logger.Info("policy-check-failed-in-audit-only-mode", lager.Data{
+			"action":  input.Action,
+			"team":    input.Team,
+			"reasons": output.Reasons,
+		})
+
+		output.Allowed = true
+		output.Warned = true
+	}
+
+	return output, nil
 }
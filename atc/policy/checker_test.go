@@ -3,6 +3,7 @@ package policy_test
 import (
 	"errors"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/policy/policyfakes"
 
@@ -13,9 +14,11 @@ import (
 var _ = Describe("Policy checker", func() {
 
 	var (
-		checker *policy.Checker
-		filter  policy.Filter
-		err     error
+		checker      *policy.Checker
+		filter       policy.Filter
+		auditOnly    bool
+		fakeRecorder *policyfakes.FakeDecisionRecorder
+		err          error
 	)
 
 	BeforeEach(func() {
@@ -25,12 +28,15 @@ var _ = Describe("Policy checker", func() {
 			ActionsToSkip: []string{"skip_1", "skip_2"},
 		}
 
+		auditOnly = false
+		fakeRecorder = new(policyfakes.FakeDecisionRecorder)
+
 		fakeAgent = new(policyfakes.FakeAgent)
 		fakeAgentFactory.NewAgentReturns(fakeAgent, nil)
 	})
 
 	JustBeforeEach(func() {
-		checker, err = policy.Initialize(testLogger, "some-cluster", "some-version", filter)
+		checker, err = policy.Initialize(testLogger, "some-cluster", "some-version", filter, auditOnly, fakeRecorder)
 	})
 
 	// fakeAgent is configured in BeforeSuite.
@@ -106,6 +112,10 @@ var _ = Describe("Policy checker", func() {
 						Expect(checkErr).ToNot(HaveOccurred())
 						Expect(output.Allowed).To(BeTrue())
 					})
+
+					It("should not record a decision", func() {
+						Expect(fakeRecorder.CreateDecisionCallCount()).To(Equal(0))
+					})
 				})
 
 				Context("when agent says not-pass", func() {
@@ -134,6 +144,55 @@ var _ = Describe("Policy checker", func() {
 						Expect(checkErr).ToNot(HaveOccurred())
 						Expect(output.Reasons).To(ConsistOf("a policy says you can't do that"))
 					})
+
+					Context("with team and pipeline", func() {
+						BeforeEach(func() {
+							input = policy.PolicyCheckInput{
+								Action:   "do_1",
+								Team:     "some-team",
+								Pipeline: "some-pipeline",
+							}
+						})
+
+						It("should record the decision", func() {
+							Expect(fakeRecorder.CreateDecisionCallCount()).To(Equal(1))
+							Expect(fakeRecorder.CreateDecisionArgsForCall(0)).To(Equal(atc.PolicyDecision{
+								Team:     "some-team",
+								Pipeline: "some-pipeline",
+								Action:   "do_1",
+								Reasons:  []string{"a policy says you can't do that"},
+							}))
+						})
+					})
+
+					Context("when recording the decision fails", func() {
+						BeforeEach(func() {
+							fakeRecorder.CreateDecisionReturns(errors.New("some-error"))
+						})
+
+						It("should still not pass", func() {
+							Expect(checkErr).ToNot(HaveOccurred())
+							Expect(output.Allowed).To(BeFalse())
+						})
+					})
+
+					Context("in audit-only mode", func() {
+						BeforeEach(func() {
+							auditOnly = true
+						})
+
+						It("should pass with a warning", func() {
+							Expect(checkErr).ToNot(HaveOccurred())
+							Expect(output.Allowed).To(BeTrue())
+							Expect(output.Warned).To(BeTrue())
+							Expect(output.Reasons).To(ConsistOf("a policy says you can't do that"))
+						})
+
+						It("should record the decision as audit-only", func() {
+							Expect(fakeRecorder.CreateDecisionCallCount()).To(Equal(1))
+							Expect(fakeRecorder.CreateDecisionArgsForCall(0).AuditOnly).To(BeTrue())
+						})
+					})
 				})
 
 				Context("when agent says error", func() {
@@ -146,6 +205,10 @@ var _ = Describe("Policy checker", func() {
 						Expect(checkErr.Error()).To(Equal("some-error"))
 						Expect(output.Allowed).To(BeFalse())
 					})
+
+					It("should not record a decision", func() {
+						Expect(fakeRecorder.CreateDecisionCallCount()).To(Equal(0))
+					})
 				})
 			})
 		})
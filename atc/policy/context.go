@@ -21,5 +21,20 @@ func TeamAndPipelineFromContext(ctx context.Context) (string, string) {
 	return t, p
 }
 
+// RecordWarnings stores the reasons of a policy check that was let through in
+// audit-only mode, so that they can be reported back to the user.
+func RecordWarnings(ctx context.Context, reasons []string) context.Context {
+	return context.WithValue(ctx, warningsContextKey{}, reasons)
+}
+
+func WarningsFromContext(ctx context.Context) []string {
+	w, ok := ctx.Value(warningsContextKey{}).([]string)
+	if !ok {
+		return nil
+	}
+	return w
+}
+
 type teamContextKey struct{}
 type pipelineContextKey struct{}
+type warningsContextKey struct{}
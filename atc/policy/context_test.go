@@ -23,4 +23,11 @@ var _ = Context("PolicyContext", func() {
 		Expect(team).To(Equal("some-team"))
 		Expect(pipeline).To(Equal("some-pipeline"))
 	})
+
+	It("should set and get warnings", func() {
+		Expect(policy.WarningsFromContext(ctx)).To(BeEmpty())
+
+		newCtx := policy.RecordWarnings(ctx, []string{"some-reason"})
+		Expect(policy.WarningsFromContext(newCtx)).To(Equal([]string{"some-reason"}))
+	})
 })
@@ -0,0 +1,13 @@
+package atc
+
+// PolicyDecision is a policy check that did not pass. When the policy checker
+// runs in audit-only mode the action was allowed to go ahead anyway.
+type PolicyDecision struct {
+	ID        int      `json:"id,omitempty"`
+	Team      string   `json:"team"`
+	Pipeline  string   `json:"pipeline,omitempty"`
+	Action    string   `json:"action"`
+	Reasons   []string `json:"reasons,omitempty"`
+	AuditOnly bool     `json:"audit_only"`
+	CreatedAt int64    `json:"created_at,omitempty"`
+}
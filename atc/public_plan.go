@@ -147,27 +147,29 @@ func (plan InParallelPlan) Public() *json.RawMessage {
 }
 
 func (plan AcrossPlan) Public() *json.RawMessage {
-	type scopedStep struct {
-		Step   *json.RawMessage `json:"step"`
-		Values []interface{}    `json:"values"`
-	}
-
-	steps := []scopedStep{}
+	steps := []*json.RawMessage{}
 	for _, step := range plan.Steps {
-		steps = append(steps, scopedStep{
-			Step:   step.Step.Public(),
-			Values: step.Values,
-		})
+		steps = append(steps, step.Public())
 	}
 
 	return enc(struct {
-		Vars        []AcrossVar  `json:"vars"`
-		Steps       []scopedStep `json:"steps"`
-		FailFast    bool         `json:"fail_fast,omitempty"`
+		Vars     []AcrossVar        `json:"vars"`
+		Steps    []*json.RawMessage `json:"steps"`
+		FailFast bool               `json:"fail_fast,omitempty"`
 	}{
-		Vars:        plan.Vars,
-		Steps:       steps,
-		FailFast:    plan.FailFast,
+		Vars:     plan.Vars,
+		Steps:    steps,
+		FailFast: plan.FailFast,
+	})
+}
+
+func (plan VarScopedPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Step   *json.RawMessage `json:"step"`
+		Values []interface{}    `json:"values"`
+	}{
+		Step:   plan.Step.Public(),
+		Values: plan.Values,
 	})
 }
 
@@ -291,11 +293,13 @@ func (plan TaskPlan) Public() *json.RawMessage {
 
 func (plan SetPipelinePlan) Public() *json.RawMessage {
 	return enc(struct {
-		Name string `json:"name"`
-		Team string `json:"team"`
+		Name         string                 `json:"name"`
+		Team         string                 `json:"team"`
+		InstanceVars map[string]interface{} `json:"instance_vars,omitempty"`
 	}{
-		Name: plan.Name,
-		Team: plan.Team,
+		Name:         plan.Name,
+		Team:         plan.Team,
+		InstanceVars: plan.InstanceVars,
 	})
 }
 
@@ -367,12 +367,12 @@ var _ = Describe("Plan", func() {
 								{
 									Var:         "v1",
 									Values:      []interface{}{"a"},
-									MaxInFlight: 1,
+									MaxInFlight: &atc.MaxInFlightConfig{Limit: 1},
 								},
 								{
 									Var:         "v2",
 									Values:      []interface{}{"b"},
-									MaxInFlight: 2,
+									MaxInFlight: &atc.MaxInFlightConfig{Limit: 2},
 								},
 							},
 							Steps: []atc.VarScopedPlan{
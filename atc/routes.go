@@ -17,20 +17,22 @@ const (
 
 	GetCheck = "GetCheck"
 
-	GetJob         = "GetJob"
-	CreateJobBuild = "CreateJobBuild"
-	RerunJobBuild  = "RerunJobBuild"
-	ListAllJobs    = "ListAllJobs"
-	ListJobs       = "ListJobs"
-	ListJobBuilds  = "ListJobBuilds"
-	ListJobInputs  = "ListJobInputs"
-	GetJobBuild    = "GetJobBuild"
-	PauseJob       = "PauseJob"
-	UnpauseJob     = "UnpauseJob"
-	ScheduleJob    = "ScheduleJob"
-	GetVersionsDB  = "GetVersionsDB"
-	JobBadge       = "JobBadge"
-	MainJobBadge   = "MainJobBadge"
+	GetJob                      = "GetJob"
+	CreateJobBuild              = "CreateJobBuild"
+	CreateJobOneOffBuild        = "CreateJobOneOffBuild"
+	RerunJobBuild               = "RerunJobBuild"
+	ListAllJobs                 = "ListAllJobs"
+	ListJobs                    = "ListJobs"
+	ListJobBuilds               = "ListJobBuilds"
+	ListJobInputs               = "ListJobInputs"
+	GetJobSchedulingExplanation = "GetJobSchedulingExplanation"
+	GetJobBuild                 = "GetJobBuild"
+	PauseJob                    = "PauseJob"
+	UnpauseJob                  = "UnpauseJob"
+	ScheduleJob                 = "ScheduleJob"
+	GetVersionsDB               = "GetVersionsDB"
+	JobBadge                    = "JobBadge"
+	MainJobBadge                = "MainJobBadge"
 
 	ClearTaskCache = "ClearTaskCache"
 
@@ -40,6 +42,7 @@ const (
 	GetResource          = "GetResource"
 	CheckResource        = "CheckResource"
 	CheckResourceWebHook = "CheckResourceWebHook"
+	ReceiveWebhook       = "ReceiveWebhook"
 	CheckResourceType    = "CheckResourceType"
 
 	ListResourceVersions          = "ListResourceVersions"
@@ -55,20 +58,21 @@ const (
 
 	GetCC = "GetCC"
 
-	ListAllPipelines    = "ListAllPipelines"
-	ListPipelines       = "ListPipelines"
-	GetPipeline         = "GetPipeline"
-	DeletePipeline      = "DeletePipeline"
-	OrderPipelines      = "OrderPipelines"
-	PausePipeline       = "PausePipeline"
-	ArchivePipeline     = "ArchivePipeline"
-	UnpausePipeline     = "UnpausePipeline"
-	ExposePipeline      = "ExposePipeline"
-	HidePipeline        = "HidePipeline"
-	RenamePipeline      = "RenamePipeline"
-	ListPipelineBuilds  = "ListPipelineBuilds"
-	CreatePipelineBuild = "CreatePipelineBuild"
-	PipelineBadge       = "PipelineBadge"
+	ListAllPipelines        = "ListAllPipelines"
+	ListPipelines           = "ListPipelines"
+	GetPipeline             = "GetPipeline"
+	DeletePipeline          = "DeletePipeline"
+	OrderPipelines          = "OrderPipelines"
+	PausePipeline           = "PausePipeline"
+	ArchivePipeline         = "ArchivePipeline"
+	UnpausePipeline         = "UnpausePipeline"
+	ExposePipeline          = "ExposePipeline"
+	HidePipeline            = "HidePipeline"
+	RenamePipeline          = "RenamePipeline"
+	ListPipelineBuilds      = "ListPipelineBuilds"
+	SearchPipelineBuildLogs = "SearchPipelineBuildLogs"
+	CreatePipelineBuild     = "CreatePipelineBuild"
+	PipelineBadge           = "PipelineBadge"
 
 	RegisterWorker  = "RegisterWorker"
 	LandWorker      = "LandWorker"
@@ -106,12 +110,16 @@ const (
 	GetArtifact        = "GetArtifact"
 	ListBuildArtifacts = "ListBuildArtifacts"
 
+	ListBuildSecretAccesses = "ListBuildSecretAccesses"
+
 	GetUser              = "GetUser"
 	ListActiveUsersSince = "ListActiveUsersSince"
 
 	SetWall   = "SetWall"
 	GetWall   = "GetWall"
 	ClearWall = "ClearWall"
+
+	ListPolicyDecisions = "ListPolicyDecisions"
 )
 
 const (
@@ -133,6 +141,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/builds/:build_id/abort", Method: "PUT", Name: AbortBuild},
 	{Path: "/api/v1/builds/:build_id/preparation", Method: "GET", Name: GetBuildPreparation},
 	{Path: "/api/v1/builds/:build_id/artifacts", Method: "GET", Name: ListBuildArtifacts},
+	{Path: "/api/v1/builds/:build_id/secrets-accessed", Method: "GET", Name: ListBuildSecretAccesses},
 
 	{Path: "/api/v1/checks/:check_id", Method: "GET", Name: GetCheck},
 
@@ -141,8 +150,10 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name", Method: "GET", Name: GetJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "GET", Name: ListJobBuilds},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds", Method: "POST", Name: CreateJobBuild},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/one-off-builds", Method: "POST", Name: CreateJobOneOffBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", Method: "POST", Name: RerunJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/inputs", Method: "GET", Name: ListJobInputs},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/scheduling-explanation", Method: "GET", Name: GetJobSchedulingExplanation},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/builds/:build_name", Method: "GET", Name: GetJobBuild},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/pause", Method: "PUT", Name: PauseJob},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs/:job_name/unpause", Method: "PUT", Name: UnpauseJob},
@@ -166,6 +177,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/rename", Method: "PUT", Name: RenamePipeline},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/builds", Method: "GET", Name: ListPipelineBuilds},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/builds", Method: "POST", Name: CreatePipelineBuild},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/builds/search", Method: "GET", Name: SearchPipelineBuildLogs},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/badge", Method: "GET", Name: PipelineBadge},
 
 	{Path: "/api/v1/resources", Method: "GET", Name: ListAllResources},
@@ -174,6 +186,7 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name", Method: "GET", Name: GetResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check", Method: "POST", Name: CheckResource},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/check/webhook", Method: "POST", Name: CheckResourceWebHook},
+	{Path: "/api/v1/teams/:team_name/webhooks/:provider", Method: "POST", Name: ReceiveWebhook},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resource-types/:resource_type_name/check", Method: "POST", Name: CheckResourceType},
 
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/resources/:resource_name/versions", Method: "GET", Name: ListResourceVersions},
@@ -230,4 +243,6 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/wall", Method: "GET", Name: GetWall},
 	{Path: "/api/v1/wall", Method: "PUT", Name: SetWall},
 	{Path: "/api/v1/wall", Method: "DELETE", Name: ClearWall},
+
+	{Path: "/api/v1/teams/:team_name/policy_decisions", Method: "GET", Name: ListPolicyDecisions},
 })
@@ -17,7 +17,7 @@ const (
 //go:generate counterfeiter . StartingEventDelegate
 type StartingEventDelegate interface {
 	Starting(lager.Logger)
-	SelectedWorker(lager.Logger, string)
+	SelectedWorker(lager.Logger, string, []atc.PlacementStep)
 }
 
 type VersionResult struct {
@@ -11,6 +11,10 @@ import (
 type Resolver interface {
 	Resolve(context.Context) (map[string]*versionCandidate, db.ResolutionFailure, error)
 	InputConfigs() db.InputConfigs
+
+	// InputExplanations returns the explanation of how each input was
+	// resolved, in the same order as InputConfigs.
+	InputExplanations() []db.InputExplanation
 }
 
 func New(versionsDB db.VersionsDB) *Algorithm {
@@ -63,7 +67,7 @@ func (a *Algorithm) computeResolvers(
 		// converts the version candidates into an object that is recognizable by
 		// other components. also computes the first occurrence for all satisfiable
 		// inputs
-		finalMapping, err = a.candidatesToInputMapping(ctx, finalMapping, resolver.InputConfigs(), versionCandidates, resolveErr)
+		finalMapping, err = a.candidatesToInputMapping(ctx, finalMapping, resolver.InputConfigs(), resolver.InputExplanations(), versionCandidates, resolveErr)
 		if err != nil {
 			return nil, false, false, fmt.Errorf("candidates to input mapping: %w", err)
 		}
@@ -86,11 +90,12 @@ func (a *Algorithm) finalizeHasNext(versionCandidates map[string]*versionCandida
 	return hasNextCombined
 }
 
-func (a *Algorithm) candidatesToInputMapping(ctx context.Context, mapping db.InputMapping, inputConfigs db.InputConfigs, candidates map[string]*versionCandidate, resolveErr db.ResolutionFailure) (db.InputMapping, error) {
-	for _, input := range inputConfigs {
+func (a *Algorithm) candidatesToInputMapping(ctx context.Context, mapping db.InputMapping, inputConfigs db.InputConfigs, explanations []db.InputExplanation, candidates map[string]*versionCandidate, resolveErr db.ResolutionFailure) (db.InputMapping, error) {
+	for i, input := range inputConfigs {
 		if resolveErr != "" {
 			mapping[input.Name] = db.InputResult{
 				ResolveError: resolveErr,
+				Explanation:  explanations[i],
 			}
 		} else {
 			firstOcc, err := a.versionsDB.IsFirstOccurrence(ctx, input.JobID, input.Name, candidates[input.Name].Version, input.ResourceID)
@@ -107,6 +112,7 @@ func (a *Algorithm) candidatesToInputMapping(ctx context.Context, mapping db.Inp
 					FirstOccurrence: firstOcc,
 				},
 				PassedBuildIDs: candidates[input.Name].SourceBuildIds,
+				Explanation:    explanations[i],
 			}
 		}
 	}
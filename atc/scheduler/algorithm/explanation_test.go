@@ -0,0 +1,202 @@
+package algorithm_test
+
+import (
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo/extensions/table"
+)
+
+var _ = DescribeTable("Explaining input resolution",
+	(Example).Run,
+
+	Entry("explains that a version did not pass every job", Example{
+		DB: DB{
+			BuildOutputs: []DBRow{
+				{Job: "simple-a", BuildID: 1, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Job: "simple-b", BuildID: 2, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+
+				{Job: "simple-a", BuildID: 3, Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:     "resource-x",
+				Resource: "resource-x",
+				Passed:   []string{"simple-a", "simple-b"},
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv1",
+			},
+			Rejections: map[string]map[string]string{
+				"resource-x": {
+					"rxv2": string(db.CandidateUnsatisfiable),
+				},
+			},
+			Conflicts: map[string]map[string]string{
+				"resource-x": {
+					"rxv2": "simple-b",
+				},
+			},
+		},
+	}),
+
+	Entry("explains that a version is disabled", Example{
+		DB: DB{
+			BuildOutputs: []DBRow{
+				{Job: "simple-a", BuildID: 1, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Job: "simple-a", BuildID: 2, Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+			},
+
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Resource: "resource-x", Version: "rxv2", CheckOrder: 2, Disabled: true},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:     "resource-x",
+				Resource: "resource-x",
+				Passed:   []string{"simple-a"},
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv1",
+			},
+			Rejections: map[string]map[string]string{
+				"resource-x": {
+					"rxv2": string(db.CandidateDisabled),
+				},
+			},
+		},
+	}),
+
+	Entry("explains that a version does not match the pinned version", Example{
+		DB: DB{
+			BuildOutputs: []DBRow{
+				{Job: "simple-a", BuildID: 1, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Job: "simple-a", BuildID: 2, Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:     "resource-x",
+				Resource: "resource-x",
+				Passed:   []string{"simple-a"},
+				Version:  Version{Pinned: "rxv1"},
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv1",
+			},
+			Rejections: map[string]map[string]string{
+				"resource-x": {
+					"rxv2": string(db.CandidatePinMismatch),
+				},
+			},
+		},
+	}),
+
+	Entry("explains that builds of passed jobs used different versions", Example{
+		DB: DB{
+			BuildOutputs: []DBRow{
+				{Job: "simple-a", BuildID: 1, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Job: "simple-b", BuildID: 2, Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:     "resource-x",
+				Resource: "resource-x",
+				Passed:   []string{"simple-a", "simple-b"},
+			},
+		},
+
+		Result: Result{
+			OK: false,
+			Errors: map[string]string{
+				"resource-x": string(db.NoSatisfiableBuilds),
+			},
+			Rejections: map[string]map[string]string{
+				"resource-x": {
+					"rxv1": string(db.CandidateUnsatisfiable),
+				},
+			},
+			Conflicts: map[string]map[string]string{
+				"resource-x": {
+					"rxv1": "simple-b",
+				},
+			},
+		},
+	}),
+
+	Entry("explains which build of a passed job conflicted with a version", Example{
+		DB: DB{
+			BuildOutputs: []DBRow{
+				{Job: "simple-a", BuildID: 1, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Job: "simple-a", BuildID: 1, Resource: "resource-z", Version: "rzv1", CheckOrder: 1},
+
+				{Job: "simple-b", BuildID: 2, Resource: "resource-y", Version: "ryv1", CheckOrder: 1},
+				{Job: "simple-b", BuildID: 2, Resource: "resource-z", Version: "rzv1", CheckOrder: 1},
+
+				{Job: "simple-a", BuildID: 3, Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+				{Job: "simple-a", BuildID: 3, Resource: "resource-z", Version: "rzv2", CheckOrder: 2},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:     "resource-x",
+				Resource: "resource-x",
+				Passed:   []string{"simple-a"},
+			},
+			{
+				Name:     "resource-y",
+				Resource: "resource-y",
+				Passed:   []string{"simple-b"},
+			},
+			{
+				Name:     "resource-z",
+				Resource: "resource-z",
+				Passed:   []string{"simple-a", "simple-b"},
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv1",
+				"resource-y": "ryv1",
+				"resource-z": "rzv1",
+			},
+			Rejections: map[string]map[string]string{
+				"resource-x": {
+					"rxv2": string(db.CandidateUnsatisfiable),
+				},
+				"resource-z": {
+					"rzv2": string(db.CandidateUnsatisfiable),
+				},
+			},
+			Conflicts: map[string]map[string]string{
+				"resource-x": {
+					"rxv2": "simple-b/2",
+				},
+				"resource-z": {
+					"rzv2": "simple-b/2",
+				},
+			},
+		},
+	}),
+)
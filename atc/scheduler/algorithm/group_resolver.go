@@ -38,6 +38,13 @@ type groupResolver struct {
 	doomedCandidates []*versionCandidate
 
 	lastUsedPassedBuilds map[int]db.BuildCursor
+
+	explanations []db.InputExplanation
+
+	// conflict is the passed job which last failed to be satisfied, explaining
+	// why the candidates vouched for by a build were rejected
+	conflict       db.CandidateConflict
+	doomedConflict db.CandidateConflict
 }
 
 func NewGroupResolver(vdb db.VersionsDB, inputConfigs db.InputConfigs) Resolver {
@@ -48,6 +55,7 @@ func NewGroupResolver(vdb db.VersionsDB, inputConfigs db.InputConfigs) Resolver
 		orderedJobs:      make([][]int, len(inputConfigs)),
 		candidates:       make([]*versionCandidate, len(inputConfigs)),
 		doomedCandidates: make([]*versionCandidate, len(inputConfigs)),
+		explanations:     make([]db.InputExplanation, len(inputConfigs)),
 	}
 }
 
@@ -55,6 +63,10 @@ func (r *groupResolver) InputConfigs() db.InputConfigs {
 	return r.inputConfigs
 }
 
+func (r *groupResolver) InputExplanations() []db.InputExplanation {
+	return r.explanations
+}
+
 func (r *groupResolver) Resolve(ctx context.Context) (map[string]*versionCandidate, db.ResolutionFailure, error) {
 	ctx, span := tracing.StartSpan(ctx, "groupResolver.Resolve", tracing.Attrs{
 		"inputs": r.inputConfigs.String(),
@@ -188,6 +200,8 @@ func (r *groupResolver) tryJobBuilds(ctx context.Context, inputIndex int, passed
 
 	span.SetAttributes(key.New("passedJobID").Int(passedJobID))
 
+	conflict := db.CandidateConflict{PassedJobID: passedJobID}
+
 	for {
 		buildID, ok, err := builds.Next(ctx)
 		if err != nil {
@@ -197,10 +211,15 @@ func (r *groupResolver) tryJobBuilds(ctx context.Context, inputIndex int, passed
 
 		if !ok {
 			// reached the end of the builds
+			r.conflict = conflict
 			span.SetStatus(codes.ResourceExhausted)
 			return false, nil
 		}
 
+		if conflict.BuildID == 0 {
+			conflict.BuildID = buildID
+		}
+
 		worked, err := r.tryBuildOutputs(ctx, inputIndex, passedJobID, buildID, builds.HasNext())
 		if err != nil {
 			tracing.End(span, err)
@@ -227,7 +246,8 @@ func (r *groupResolver) tryBuildOutputs(ctx context.Context, resolvingIdx, jobID
 	}
 
 	restore := map[int]*versionCandidate{}
-	var mismatch bool
+	explained := map[int]int{}
+	var mismatch, attempted bool
 
 	// loop over the resource versions that came out of this build set
 outputs:
@@ -241,7 +261,7 @@ outputs:
 			}
 
 			var related bool
-			related, mismatch, err = r.outputIsRelatedAndMatches(ctx, span, output, c, jobID)
+			related, mismatch, err = r.outputIsRelatedAndMatches(ctx, span, output, c, jobID, buildID)
 			if err != nil {
 				tracing.End(span, err)
 				return false, err
@@ -264,6 +284,13 @@ outputs:
 				}
 
 				if !exists {
+					r.explanations[c].Record(db.CandidateExplanation{
+						Version:     output.Version,
+						PassedJobID: jobID,
+						BuildID:     buildID,
+						Rejection:   db.CandidateMissing,
+					})
+
 					break outputs
 				}
 			}
@@ -280,16 +307,26 @@ outputs:
 			)
 
 			r.candidates[c] = r.vouchForCandidate(candidate, output.Version, jobID, buildID, hasNext)
+
+			explained[c] = r.explanations[c].Record(db.CandidateExplanation{
+				Version:     output.Version,
+				PassedJobID: jobID,
+				BuildID:     buildID,
+			})
 		}
 	}
 
 	// we found a candidate for ourselves and the rest are OK too - recurse
 	if r.candidates[resolvingIdx] != nil && r.candidates[resolvingIdx].VouchedForBy[jobID] && !mismatch {
+		attempted = true
+
 		if r.candidatesAreDoomed() {
 			span.AddEvent(
 				ctx,
 				"candidates are doomed",
 			)
+
+			r.conflict = r.doomedConflict
 		} else {
 			worked, _, err := r.tryResolve(ctx)
 			if err != nil {
@@ -313,6 +350,14 @@ outputs:
 		r.candidates[c] = candidate
 	}
 
+	for c, index := range explained {
+		if attempted {
+			r.explanations[c].RejectConflict(index, r.conflict)
+		} else {
+			r.explanations[c].Reject(index, db.CandidateBuildRejected)
+		}
+	}
+
 	span.SetStatus(codes.InvalidArgument)
 	return false, nil
 }
@@ -321,6 +366,8 @@ func (r *groupResolver) doomCandidates() {
 	for i, c := range r.candidates {
 		r.doomedCandidates[i] = c
 	}
+
+	r.doomedConflict = r.conflict
 }
 
 func (r *groupResolver) candidatesAreDoomed() bool {
@@ -423,7 +470,7 @@ func (r *groupResolver) constrainingCandidates(passedJobID int) map[string][]str
 	return constrainingCandidates
 }
 
-func (r *groupResolver) outputIsRelatedAndMatches(ctx context.Context, span trace.Span, output db.AlgorithmVersion, candidateIdx int, passedJobID int) (bool, bool, error) {
+func (r *groupResolver) outputIsRelatedAndMatches(ctx context.Context, span trace.Span, output db.AlgorithmVersion, candidateIdx int, passedJobID int, passedBuildID int) (bool, bool, error) {
 	inputConfig := r.inputConfigs[candidateIdx]
 	candidate := r.candidates[candidateIdx]
 
@@ -437,9 +484,17 @@ func (r *groupResolver) outputIsRelatedAndMatches(ctx context.Context, span trac
 		return false, false, nil
 	}
 
+	rejected := db.CandidateExplanation{
+		Version:     output.Version,
+		PassedJobID: passedJobID,
+		BuildID:     passedBuildID,
+	}
+
 	if candidate != nil && candidate.Version != output.Version {
 		// we have already chosen a version for the candidate but it's different
 		// from the version provided by this output
+		rejected.Rejection = db.CandidateMismatch
+		r.explanations[candidateIdx].Record(rejected)
 		return false, true, nil
 	}
 
@@ -456,6 +511,9 @@ func (r *groupResolver) outputIsRelatedAndMatches(ctx context.Context, span trac
 			key.New("resourceID").Int(output.ResourceID),
 			key.New("version").String(string(output.Version)),
 		)
+
+		rejected.Rejection = db.CandidateDisabled
+		r.explanations[candidateIdx].Record(rejected)
 		return false, false, nil
 	}
 
@@ -471,6 +529,8 @@ func (r *groupResolver) outputIsRelatedAndMatches(ctx context.Context, span trac
 			key.New("pinHas").String(string(r.pins[candidateIdx])),
 		)
 
+		rejected.Rejection = db.CandidatePinMismatch
+		r.explanations[candidateIdx].Record(rejected)
 		return false, false, nil
 	}
 
@@ -12,6 +12,7 @@ import (
 type individualResolver struct {
 	vdb         db.VersionsDB
 	inputConfig db.InputConfig
+	explanation db.InputExplanation
 }
 
 func NewIndividualResolver(vdb db.VersionsDB, inputConfig db.InputConfig) Resolver {
@@ -25,6 +26,10 @@ func (r *individualResolver) InputConfigs() db.InputConfigs {
 	return db.InputConfigs{r.inputConfig}
 }
 
+func (r *individualResolver) InputExplanations() []db.InputExplanation {
+	return []db.InputExplanation{r.explanation}
+}
+
 // Handles two different configurations of a resource without passed
 // constraints: every and latest
 func (r *individualResolver) Resolve(ctx context.Context) (map[string]*versionCandidate, db.ResolutionFailure, error) {
@@ -70,6 +75,8 @@ func (r *individualResolver) Resolve(ctx context.Context) (map[string]*versionCa
 		span.AddEvent(ctx, "found via latest", key.New("version").String(string(version)))
 	}
 
+	r.explanation.Record(db.CandidateExplanation{Version: version})
+
 	candidate := newCandidateVersion(version)
 	candidate.HasNextEveryVersion = hasNext
 
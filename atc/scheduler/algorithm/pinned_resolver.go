@@ -12,6 +12,7 @@ import (
 type pinnedResolver struct {
 	vdb         db.VersionsDB
 	inputConfig db.InputConfig
+	explanation db.InputExplanation
 }
 
 func NewPinnedResolver(vdb db.VersionsDB, inputConfig db.InputConfig) Resolver {
@@ -25,6 +26,10 @@ func (r *pinnedResolver) InputConfigs() db.InputConfigs {
 	return db.InputConfigs{r.inputConfig}
 }
 
+func (r *pinnedResolver) InputExplanations() []db.InputExplanation {
+	return []db.InputExplanation{r.explanation}
+}
+
 func (r *pinnedResolver) Resolve(ctx context.Context) (map[string]*versionCandidate, db.ResolutionFailure, error) {
 	ctx, span := tracing.StartSpan(ctx, "pinnedResolver.Resolve", tracing.Attrs{
 		"input": r.inputConfig.Name,
@@ -45,6 +50,8 @@ func (r *pinnedResolver) Resolve(ctx context.Context) (map[string]*versionCandid
 
 	span.AddEvent(ctx, "found via pin", key.New("version").String(string(version)))
 
+	r.explanation.Record(db.CandidateExplanation{Version: version})
+
 	versionCandidate := map[string]*versionCandidate{
 		r.inputConfig.Name: newCandidateVersion(version),
 	}
@@ -80,6 +80,8 @@ type Result struct {
 	ExpectedMigrated map[int]map[int][]string
 	HasNext          bool
 	NoNext           bool
+	Rejections       map[string]map[string]string
+	Conflicts        map[string]map[string]string
 }
 
 type StringMapping map[string]int
@@ -124,7 +126,7 @@ func (example Example) Run() {
 	team, err := teamFactory.CreateTeam(atc.Team{Name: "algorithm"})
 	Expect(err).NotTo(HaveOccurred())
 
-	pipeline, _, err := team.SavePipeline("algorithm", atc.Config{}, db.ConfigVersion(0), false)
+	pipeline, _, err := team.SavePipeline(atc.PipelineRef{Name: "algorithm"}, atc.Config{}, db.ConfigVersion(0), false)
 	Expect(err).NotTo(HaveOccurred())
 
 	setupTx, err := dbConn.Begin()
@@ -609,6 +611,55 @@ func (example Example) assert(
 			Expect(actualResult.PassedBuildIDs[input]).To(ConsistOf(buildIDs))
 		}
 
+		if example.Result.Rejections != nil {
+			actualRejections := map[string]map[string]string{}
+			actualConflicts := map[string]map[string]string{}
+			for _, input := range inputConfigs {
+				for _, candidate := range resolved[input.Name].Explanation.Candidates {
+					if candidate.Rejection == "" {
+						continue
+					}
+
+					var versionID int
+					err := setup.psql.Select("v.id").
+						From("resource_config_versions v").
+						Join("resources r ON r.resource_config_scope_id = v.resource_config_scope_id").
+						Where(sq.Eq{
+							"v.version_md5": candidate.Version,
+							"r.id":          input.ResourceID,
+						}).
+						QueryRow().
+						Scan(&versionID)
+					Expect(err).ToNot(HaveOccurred())
+
+					if actualRejections[input.Name] == nil {
+						actualRejections[input.Name] = map[string]string{}
+					}
+
+					actualRejections[input.Name][setup.versionIDs.Name(versionID)] = string(candidate.Rejection)
+
+					if candidate.Conflict != nil {
+						conflict := setup.jobIDs.Name(candidate.Conflict.PassedJobID)
+						if candidate.Conflict.BuildID != 0 {
+							conflict += fmt.Sprintf("/%d", candidate.Conflict.BuildID)
+						}
+
+						if actualConflicts[input.Name] == nil {
+							actualConflicts[input.Name] = map[string]string{}
+						}
+
+						actualConflicts[input.Name][setup.versionIDs.Name(versionID)] = conflict
+					}
+				}
+			}
+
+			Expect(actualRejections).To(Equal(example.Result.Rejections))
+
+			if example.Result.Conflicts != nil {
+				Expect(actualConflicts).To(Equal(example.Result.Conflicts))
+			}
+		}
+
 		if example.Result.ExpectedMigrated != nil {
 			rows, err := setup.psql.Select("build_id", "job_id", "outputs", "rerun_of").
 				From("successful_build_outputs").
@@ -74,8 +74,9 @@ func (s *buildStarter) TryStartPendingBuildsForJob(
 		}
 
 		if !results.scheduled || !results.readyToDetermineInputs {
-			// If max in flight is reached or a manually triggered build has not
-			// checked all resources, stop scheduling and retry later
+			// If max in flight or the team's build quota is reached, or a manually
+			// triggered build has not checked all resources, stop scheduling and
+			// retry later
 			needsRetry = true
 			break
 		}
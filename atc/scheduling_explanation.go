@@ -0,0 +1,43 @@
+package atc
+
+// SchedulingExplanation describes how the scheduler last went about
+// determining the inputs for the next build of a job.
+type SchedulingExplanation struct {
+	InputsDetermined bool               `json:"inputs_determined"`
+	Inputs           []InputExplanation `json:"inputs"`
+}
+
+type InputExplanation struct {
+	Name          string   `json:"name"`
+	Resource      string   `json:"resource"`
+	Every         bool     `json:"every,omitempty"`
+	PinnedVersion Version  `json:"pinned_version,omitempty"`
+	Passed        []string `json:"passed,omitempty"`
+
+	Version Version `json:"version,omitempty"`
+	Error   string  `json:"error,omitempty"`
+
+	Candidates          []CandidateVersion `json:"candidates,omitempty"`
+	CandidatesTruncated bool               `json:"candidates_truncated,omitempty"`
+}
+
+// CandidateVersion is a version the scheduler considered for an input. The
+// passed job and build are only set when the version came from a build of one
+// of the input's passed jobs.
+type CandidateVersion struct {
+	Version   Version            `json:"version"`
+	PassedJob string             `json:"passed_job,omitempty"`
+	BuildID   int                `json:"build_id,omitempty"`
+	BuildName string             `json:"build_name,omitempty"`
+	Rejection string             `json:"rejection,omitempty"`
+	Conflict  *CandidateConflict `json:"conflict,omitempty"`
+}
+
+// CandidateConflict is the passed job which could not be satisfied alongside a
+// rejected candidate. The build is the latest one of the job which was tried,
+// if any.
+type CandidateConflict struct {
+	PassedJob string `json:"passed_job"`
+	BuildID   int    `json:"build_id,omitempty"`
+	BuildName string `json:"build_name,omitempty"`
+}
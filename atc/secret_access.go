@@ -0,0 +1,12 @@
+package atc
+
+// SecretAccess is a lookup of a secret made on behalf of a build. It never
+// includes the value of the secret.
+type SecretAccess struct {
+	Path       string `json:"path"`
+	Manager    string `json:"manager"`
+	VarSource  string `json:"var_source,omitempty"`
+	CacheHit   bool   `json:"cache_hit"`
+	Found      bool   `json:"found"`
+	AccessedAt int64  `json:"accessed_at,omitempty"`
+}
@@ -2,6 +2,7 @@ package atc
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 )
@@ -84,6 +85,10 @@ func (validator *StepValidator) VisitTask(plan *TaskStep) error {
 		})
 	}
 
+	if plan.Privileged && plan.Config != nil && plan.Config.SecurityProfile != "" {
+		validator.recordError("cannot specify a `security_profile:` for a privileged task")
+	}
+
 	if plan.Config != nil {
 		validator.pushContext(".config")
 
@@ -287,30 +292,98 @@ func (validator *StepValidator) VisitAcross(step *AcrossStep) error {
 	validator.pushLocalVarScope()
 	defer validator.popLocalVarScope()
 
-	if !EnableAcrossStep {
-		validator.recordError("the across step must be explicitly opted-in to using the `--enable-across-step` flag")
-	}
-
 	if len(step.Vars) == 0 {
 		validator.recordError("no vars specified")
 	}
 
+	varNames := map[string]bool{}
 	for i, v := range step.Vars {
 		validator.pushContext("[%d]", i)
 
 		validator.declareLocalVar(v.Var)
+		varNames[v.Var] = true
+
+		validator.pushContext(".values")
+		switch values := v.Values.(type) {
+		case nil, []interface{}:
+		case string:
+			if !strings.HasPrefix(values, "((") || !strings.HasSuffix(values, "))") {
+				validator.recordError("must be a list of values or a var, e.g. ((.:some-var))")
+			}
+		default:
+			validator.recordError("must be a list of values or a var, e.g. ((.:some-var))")
+		}
+		validator.popContext()
 
 		validator.pushContext(".max_in_flight")
-		if v.MaxInFlight != nil && !v.MaxInFlight.All && v.MaxInFlight.Limit <= 0 {
-			validator.recordError("must be greater than 0")
+		if v.MaxInFlight != nil {
+			if !v.MaxInFlight.All && v.MaxInFlight.Limit <= 0 {
+				validator.recordError("must be greater than 0")
+			}
+
+			if step.MaxInFlight != nil {
+				validator.recordError("cannot be used together with the max_in_flight of the across step")
+			}
 		}
 		validator.popContext()
+
+		validator.popContext()
+	}
+
+	validator.pushContext(".max_in_flight")
+	if step.MaxInFlight != nil && !step.MaxInFlight.All && step.MaxInFlight.Limit <= 0 {
+		validator.recordError("must be greater than 0")
+	}
+	validator.popContext()
+
+	for i, rule := range step.Exclude {
+		validator.pushContext(".exclude[%d]", i)
+
+		if len(rule) == 0 {
+			validator.recordError("no vars specified")
+		}
+
+		for _, name := range sortedAcrossRuleVars(rule) {
+			if !varNames[name] {
+				validator.recordError("unknown var '%s'", name)
+			}
+		}
+
+		validator.popContext()
+	}
+
+	for i, rule := range step.Include {
+		validator.pushContext(".include[%d]", i)
+
+		for _, name := range sortedAcrossRuleVars(rule) {
+			if !varNames[name] {
+				validator.recordError("unknown var '%s'", name)
+			}
+		}
+
+		for _, v := range step.Vars {
+			if _, found := rule[v.Var]; !found {
+				validator.recordError("missing value for var '%s'", v.Var)
+			}
+		}
+
 		validator.popContext()
 	}
 
 	return step.Step.Visit(validator)
 }
 
+func sortedAcrossRuleVars(rule AcrossCombination) []string {
+	var names []string
+	for name := range rule {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
 func (validator *StepValidator) VisitTimeout(step *TimeoutStep) error {
 	err := step.Step.Visit(validator)
 	if err != nil {
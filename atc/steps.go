@@ -353,11 +353,12 @@ func (step *TaskStep) Visit(v StepVisitor) error {
 }
 
 type SetPipelineStep struct {
-	Name     string   `json:"set_pipeline"`
-	File     string   `json:"file,omitempty"`
-	Team     string   `json:"team,omitempty"`
-	Vars     Params   `json:"vars,omitempty"`
-	VarFiles []string `json:"var_files,omitempty"`
+	Name         string   `json:"set_pipeline"`
+	File         string   `json:"file,omitempty"`
+	Team         string   `json:"team,omitempty"`
+	Vars         Params   `json:"vars,omitempty"`
+	VarFiles     []string `json:"var_files,omitempty"`
+	InstanceVars Params   `json:"instance_vars,omitempty"`
 }
 
 func (step *SetPipelineStep) Visit(v StepVisitor) error {
@@ -442,12 +443,22 @@ func (c *InParallelConfig) UnmarshalJSON(payload []byte) error {
 	return nil
 }
 
+// AcrossVarConfig configures a var of an AcrossStep. Values is either a
+// static list of values or a string referring to a local var, e.g. one set by
+// a load_var step, in which case the values are only known at runtime.
 type AcrossVarConfig struct {
 	Var         string             `json:"var"`
-	Values      []interface{}      `json:"values,omitempty"`
+	Values      interface{}        `json:"values,omitempty"`
 	MaxInFlight *MaxInFlightConfig `json:"max_in_flight,omitempty"`
 }
 
+// StaticValues returns the values of the var if they are given as a list in
+// the config.
+func (config AcrossVarConfig) StaticValues() ([]interface{}, bool) {
+	values, ok := config.Values.([]interface{})
+	return values, ok
+}
+
 func (config *AcrossVarConfig) UnmarshalJSON(data []byte) error {
 	// Used to avoid infinite recursion when unmarshalling.
 	type target AcrossVarConfig
@@ -462,10 +473,18 @@ func (config *AcrossVarConfig) UnmarshalJSON(data []byte) error {
 }
 
 type AcrossStep struct {
-	Step     StepConfig        `json:"-"`
-	Vars     []AcrossVarConfig `json:"across"`
-	FailFast bool              `json:"fail_fast,omitempty"`
-}
+	Step        StepConfig          `json:"-"`
+	Vars        []AcrossVarConfig   `json:"across"`
+	MaxInFlight *MaxInFlightConfig  `json:"max_in_flight,omitempty"`
+	Include     []AcrossCombination `json:"include,omitempty"`
+	Exclude     []AcrossCombination `json:"exclude,omitempty"`
+	FailFast    bool                `json:"fail_fast,omitempty"`
+}
+
+// AcrossCombination maps the names of an AcrossStep's vars to values. It is
+// used to exclude combinations from, or include extra combinations in, the
+// cartesian product of the vars' values.
+type AcrossCombination map[string]interface{}
 
 func (step *AcrossStep) ParseJSON(data []byte) error {
 	return json.Unmarshal(data, step)
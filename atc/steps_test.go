@@ -312,6 +312,49 @@ var factoryTests = []StepTest{
 			FailFast: true,
 		},
 	},
+	{
+		Title: "across step with values from a var and combination rules",
+
+		ConfigYAML: `
+			load_var: some-var
+			file: some-file
+			across:
+			- var: var1
+			  values: ((.:some-values))
+			- var: var2
+			  values: ["a", "b"]
+			max_in_flight: 2
+			exclude:
+			- var2: a
+			include:
+			- var1: c
+			  var2: d
+		`,
+
+		StepConfig: &atc.AcrossStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Vars: []atc.AcrossVarConfig{
+				{
+					Var:    "var1",
+					Values: "((.:some-values))",
+				},
+				{
+					Var:    "var2",
+					Values: []interface{}{"a", "b"},
+				},
+			},
+			MaxInFlight: &atc.MaxInFlightConfig{Limit: 2},
+			Exclude: []atc.AcrossCombination{
+				{"var2": "a"},
+			},
+			Include: []atc.AcrossCombination{
+				{"var1": "c", "var2": "d"},
+			},
+		},
+	},
 	{
 		Title: "across step with invalid field",
 
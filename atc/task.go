@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"sigs.k8s.io/yaml"
@@ -36,6 +37,10 @@ type TaskConfig struct {
 
 	// Path to cached directory that will be shared between builds for the same task.
 	Caches []TaskCacheConfig `json:"caches,omitempty"`
+
+	// Name of a security profile, defined by the operators of the workers,
+	// granting extra privileges to the task container.
+	SecurityProfile string `json:"security_profile,omitempty"`
 }
 
 type ContainerLimits struct {
@@ -74,6 +79,14 @@ func (err TaskValidationError) Error() string {
 	return fmt.Sprintf("invalid task configuration:\n%s", strings.Join(err.Errors, "\n"))
 }
 
+var securityProfileName = regexp.MustCompile(`^[a-z0-9][a-z0-9\-_]*$`)
+
+// ValidSecurityProfileName returns whether name can be the name of a
+// security profile defined on a worker.
+func ValidSecurityProfileName(name string) bool {
+	return securityProfileName.MatchString(name)
+}
+
 func (config TaskConfig) Validate() error {
 	var errors []string
 
@@ -88,6 +101,10 @@ func (config TaskConfig) Validate() error {
 	errors = append(errors, config.validateInputContainsNames()...)
 	errors = append(errors, config.validateOutputContainsNames()...)
 
+	if config.SecurityProfile != "" && !ValidSecurityProfileName(config.SecurityProfile) {
+		errors = append(errors, fmt.Sprintf("invalid security_profile '%s': must only contain lowercase letters, digits, '-' and '_'", config.SecurityProfile))
+	}
+
 	if len(errors) > 0 {
 		return TaskValidationError{
 			Errors: errors,
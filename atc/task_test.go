@@ -386,6 +386,26 @@ run: {path: a/file}
 			})
 		})
 
+		Context("when the task has a security profile", func() {
+			BeforeEach(func() {
+				validConfig.SecurityProfile = "network-tools"
+			})
+
+			It("is valid", func() {
+				Expect(validConfig.Validate()).ToNot(HaveOccurred())
+			})
+
+			Context("when the security profile is not a valid name", func() {
+				BeforeEach(func() {
+					invalidConfig.SecurityProfile = "Network Tools"
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("invalid security_profile 'Network Tools'")))
+				})
+			})
+		})
+
 		Context("when run is missing", func() {
 			BeforeEach(func() {
 				invalidConfig.Run.Path = ""
@@ -13,6 +13,27 @@ type Team struct {
 	ID   int      `json:"id,omitempty"`
 	Name string   `json:"name,omitempty"`
 	Auth TeamAuth `json:"auth,omitempty"`
+
+	// DefaultBuildPriority and Quotas are left unchanged when a team is
+	// updated without them.
+	DefaultBuildPriority *int        `json:"default_build_priority,omitempty"`
+	Quotas               *TeamQuotas `json:"quotas,omitempty"`
+}
+
+// TeamQuotas limits how much of the cluster a single team may use at once.
+// A zero value for any quota means it is unlimited.
+type TeamQuotas struct {
+	MaxConcurrentBuilds int    `json:"max_concurrent_builds,omitempty"`
+	MaxActiveContainers int    `json:"max_active_containers,omitempty"`
+	MaxTaskCPU          uint64 `json:"max_task_cpu,omitempty"`
+	MaxTaskMemory       uint64 `json:"max_task_memory,omitempty"`
+
+	// MaxBuildPriority caps the priority of the team's builds, whether it
+	// comes from a job's config or a manual trigger. Build priorities order
+	// the tasks waiting for a worker across teams, so this is what keeps a
+	// team from jumping ahead of the others. Unlike the other quotas, nil
+	// rather than zero means it is unlimited.
+	MaxBuildPriority *int `json:"max_build_priority,omitempty"`
 }
 
 func (team Team) Validate() error {
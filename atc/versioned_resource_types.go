@@ -18,6 +18,13 @@ func (types VersionedResourceTypes) Lookup(name string) (VersionedResourceType,
 	return VersionedResourceType{}, false
 }
 
+// Privileged returns whether the containers running the resource type with
+// the given name have to be privileged. Base resource types never do.
+func (types VersionedResourceTypes) Privileged(name string) bool {
+	t, found := types.Lookup(name)
+	return found && t.Privileged
+}
+
 func (types VersionedResourceTypes) Without(name string) VersionedResourceTypes {
 	newTypes := VersionedResourceTypes{}
 	for _, t := range types {
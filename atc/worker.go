@@ -20,6 +20,15 @@ type Worker struct {
 	ActiveVolumes    int `json:"active_volumes"`
 	ActiveTasks      int `json:"active_tasks"`
 
+	AllocatableCPU    uint64 `json:"allocatable_cpu,omitempty"`
+	AllocatableMemory uint64 `json:"allocatable_memory,omitempty"`
+
+	Unprivileged bool `json:"unprivileged,omitempty"`
+
+	// SecurityProfiles are the names of the security profiles the worker
+	// defines, which tasks can select through their security_profile.
+	SecurityProfiles []string `json:"security_profiles,omitempty"`
+
 	ResourceTypes []WorkerResourceType `json:"resource_types"`
 
 	Platform  string   `json:"platform"`
@@ -59,3 +68,11 @@ type WorkerResourceType struct {
 type PruneWorkerResponseBody struct {
 	Stderr string `json:"stderr"`
 }
+
+// PlacementStep records which workers were eliminated by one of the chained
+// container placement strategies while choosing a worker for a step.
+type PlacementStep struct {
+	Strategy   string   `json:"strategy"`
+	Eliminated []string `json:"eliminated,omitempty"`
+	Skipped    bool     `json:"skipped,omitempty"`
+}
@@ -3,11 +3,14 @@ package worker_test
 import (
 	"bytes"
 	"context"
+	"errors"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/metric"
 
 	"code.cloudfoundry.org/lager/lagertest"
@@ -15,6 +18,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/compression/compressionfakes"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/db/lock/lockfakes"
 	"github.com/concourse/concourse/atc/exec/execfakes"
 	"github.com/concourse/concourse/atc/runtime"
@@ -186,6 +190,357 @@ var _ = Describe("RunTaskStep", func() {
 				Expect(output).To(ContainSubstring("Found a free worker after waiting"))
 			})
 		})
+
+		Context("when the team has task limit quotas", func() {
+			var cpu uint64
+
+			BeforeEach(func() {
+				cpu = 256
+				fakeContainerSpec.Limits = worker.ContainerLimits{CPU: &cpu}
+
+				fakeProvider.TeamQuotaUsageReturns(db.TeamQuotaUsage{
+					Quotas: atc.TeamQuotas{MaxTaskCPU: 512, MaxTaskMemory: 2048},
+				}, nil)
+				fakePool.ContainerInWorkerReturns(false, nil)
+				fakePool.FindOrChooseWorkerForContainerReturns(fakeWorker, nil)
+			})
+
+			JustBeforeEach(func() {
+				taskResult, err = subject.RunTaskStep(ctx,
+					logger,
+					fakeContainerOwner,
+					fakeContainerSpec,
+					fakeWorkerSpec,
+					fakeStrategy,
+					fakeMetadata,
+					fakeImageFetcherSpec,
+					fakeTaskProcessSpec,
+					fakeEventDelegate,
+					fakeLockFactory)
+			})
+
+			It("defaults the limits the task does not set to the quotas", func() {
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, _, containerSpec, _, _ := fakePool.FindOrChooseWorkerForContainerArgsForCall(0)
+				Expect(*containerSpec.Limits.CPU).To(Equal(uint64(256)))
+				Expect(*containerSpec.Limits.Memory).To(Equal(uint64(2048)))
+
+				_, _, _, _, _, containerSpec, _ = fakeWorker.FindOrCreateContainerArgsForCall(0)
+				Expect(*containerSpec.Limits.CPU).To(Equal(uint64(256)))
+				Expect(*containerSpec.Limits.Memory).To(Equal(uint64(2048)))
+			})
+
+			Context("when looking up the quotas fails", func() {
+				BeforeEach(func() {
+					fakeProvider.TeamQuotaUsageReturns(db.TeamQuotaUsage{}, errors.New("disaster"))
+				})
+
+				It("returns the error", func() {
+					Expect(err).To(MatchError("disaster"))
+					Expect(fakePool.FindOrChooseWorkerForContainerCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when placing tasks by the resources the workers have left", func() {
+			var fakeContainer *workerfakes.FakeContainer
+
+			BeforeEach(func() {
+				fakePool.FindOrChooseWorkerForContainerReturns(fakeWorker, nil)
+
+				fakeContainer = new(workerfakes.FakeContainer)
+				fakeContainer.PropertiesReturns(garden.Properties{"concourse:exit-status": "0"}, nil)
+				fakeWorker.FindOrCreateContainerReturns(fakeContainer, nil)
+			})
+
+			JustBeforeEach(func() {
+				taskResult, err = subject.RunTaskStep(ctx,
+					logger,
+					fakeContainerOwner,
+					fakeContainerSpec,
+					fakeWorkerSpec,
+					worker.NewResourceFitPlacementStrategy(new(dbfakes.FakeWorkerFactory)),
+					fakeMetadata,
+					fakeImageFetcherSpec,
+					fakeTaskProcessSpec,
+					fakeEventDelegate,
+					fakeLockFactory)
+			})
+
+			It("creates the container within the worker's resources", func() {
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, _, _, _, containerSpec, _ := fakeWorker.FindOrCreateContainerArgsForCall(0)
+				Expect(containerSpec.WithinWorkerResources).To(BeTrue())
+			})
+
+			Context("when the worker's resources are taken after it is chosen", func() {
+				BeforeEach(func() {
+					fakeWorker.FindOrCreateContainerReturnsOnCall(0, nil, db.ErrWorkerResourcesExhausted)
+				})
+
+				It("chooses a worker again", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(taskResult.ExitStatus).To(BeZero())
+					Expect(fakePool.FindOrChooseWorkerForContainerCallCount()).To(Equal(2))
+					Expect(fakeWorker.FindOrCreateContainerCallCount()).To(Equal(2))
+				})
+			})
+		})
+
+		Context("when the team has reached its container quota", func() {
+			BeforeEach(func() {
+				quotaErr := worker.TeamContainerQuotaExceededError{MaxActiveContainers: 2}
+				fakePool.FindOrChooseWorkerForContainerReturnsOnCall(0, nil, quotaErr)
+				fakePool.FindOrChooseWorkerForContainerReturnsOnCall(1, nil, quotaErr)
+				fakePool.FindOrChooseWorkerForContainerReturnsOnCall(2, fakeWorker, nil)
+			})
+
+			JustBeforeEach(func() {
+				taskResult, err = subject.RunTaskStep(ctx,
+					logger,
+					fakeContainerOwner,
+					fakeContainerSpec,
+					fakeWorkerSpec,
+					fakeStrategy,
+					fakeMetadata,
+					fakeImageFetcherSpec,
+					fakeTaskProcessSpec,
+					fakeEventDelegate,
+					fakeLockFactory)
+			})
+
+			It("waits for a container to be released", func() {
+				Expect(err).To(BeNil())
+				Expect(taskResult.ExitStatus).To(BeZero())
+				Expect(fakePool.FindOrChooseWorkerForContainerCallCount()).To(Equal(3))
+			})
+
+			It("writes the quota to the output writer once", func() {
+				output := outputBuffer.String()
+				Expect(strings.Count(output, "Team has reached its quota of 2 active containers, waiting for one to be released.\n")).To(Equal(1))
+			})
+
+			Context("when the quota is reached after the worker is chosen", func() {
+				var fakeContainer *workerfakes.FakeContainer
+
+				BeforeEach(func() {
+					fakePool.FindOrChooseWorkerForContainerReturnsOnCall(0, fakeWorker, nil)
+					fakePool.FindOrChooseWorkerForContainerReturnsOnCall(1, nil, worker.TeamContainerQuotaExceededError{MaxActiveContainers: 2})
+					fakePool.FindOrChooseWorkerForContainerReturnsOnCall(2, fakeWorker, nil)
+
+					fakeContainer = new(workerfakes.FakeContainer)
+					fakeContainer.PropertiesReturns(garden.Properties{"concourse:exit-status": "0"}, nil)
+
+					fakeWorker.FindOrCreateContainerReturnsOnCall(0, nil, worker.TeamContainerQuotaExceededError{MaxActiveContainers: 2})
+					fakeWorker.FindOrCreateContainerReturnsOnCall(1, fakeContainer, nil)
+				})
+
+				It("waits for a worker again", func() {
+					Expect(err).To(BeNil())
+					Expect(taskResult.ExitStatus).To(BeZero())
+					Expect(fakePool.FindOrChooseWorkerForContainerCallCount()).To(Equal(3))
+					Expect(fakeWorker.FindOrCreateContainerCallCount()).To(Equal(2))
+				})
+
+				It("does not leak active tasks", func() {
+					Expect(fakeWorker.IncreaseActiveTasksCallCount()).To(Equal(2))
+					Expect(fakeWorker.DecreaseActiveTasksCallCount()).To(Equal(2))
+				})
+			})
+
+			Context("when the strategy does not track active tasks", func() {
+				BeforeEach(func() {
+					fakeStrategy.ModifiesActiveTasksReturns(false)
+				})
+
+				It("still waits for a container to be released", func() {
+					Expect(err).To(BeNil())
+					Expect(taskResult.ExitStatus).To(BeZero())
+					Expect(fakePool.FindOrChooseWorkerForContainerCallCount()).To(Equal(3))
+				})
+			})
+		})
+
+		Context("when builds with different priorities are waiting for a worker", func() {
+			var (
+				lock       sync.Mutex
+				freed      bool
+				chosenFor  []int
+				calledWith map[int]bool
+			)
+
+			const otherTeamID = 2
+
+			BeforeEach(func() {
+				freed = false
+				chosenFor = nil
+				calledWith = map[int]bool{}
+
+				fakeWorker.SatisfiesReturns(true)
+
+				fakePool.ContainerInWorkerStub = func(_ lager.Logger, _ db.ContainerOwner, workerSpec worker.WorkerSpec) (bool, error) {
+					lock.Lock()
+					defer lock.Unlock()
+
+					chosenFor = append(chosenFor, workerSpec.Priority)
+					return false, nil
+				}
+
+				fakePool.FindOrChooseWorkerForContainerStub = func(_ context.Context, _ lager.Logger, _ db.ContainerOwner, _ worker.ContainerSpec, workerSpec worker.WorkerSpec, _ worker.ContainerPlacementStrategy) (worker.Worker, error) {
+					lock.Lock()
+					defer lock.Unlock()
+
+					calledWith[workerSpec.Priority] = true
+
+					if !freed {
+						return nil, nil
+					}
+
+					return fakeWorker, nil
+				}
+			})
+
+			runTeamTask := func(ctx context.Context, teamID int, priority int) <-chan error {
+				errs := make(chan error, 1)
+
+				workerSpec := fakeWorkerSpec
+				workerSpec.TeamID = teamID
+				workerSpec.Priority = priority
+
+				go func() {
+					defer GinkgoRecover()
+
+					_, err := subject.RunTaskStep(ctx,
+						logger,
+						fakeContainerOwner,
+						fakeContainerSpec,
+						workerSpec,
+						fakeStrategy,
+						fakeMetadata,
+						fakeImageFetcherSpec,
+						processSpecDummy(new(bytes.Buffer)),
+						fakeEventDelegate,
+						fakeLockFactory)
+					errs <- err
+				}()
+
+				return errs
+			}
+
+			runTask := func(priority int) <-chan error {
+				return runTeamTask(ctx, fakeWorkerSpec.TeamID, priority)
+			}
+
+			calledFor := func(priority int) func() bool {
+				return func() bool {
+					lock.Lock()
+					defer lock.Unlock()
+					return calledWith[priority]
+				}
+			}
+
+			It("gives the next free worker to the build with the highest priority", func() {
+				lowErrs := runTask(0)
+				Eventually(calledFor(0)).Should(BeTrue())
+
+				highErrs := runTask(10)
+				Eventually(calledFor(10)).Should(BeTrue())
+
+				lock.Lock()
+				freed = true
+				lock.Unlock()
+
+				Eventually(highErrs, 2*time.Second).Should(Receive(BeNil()))
+				Eventually(lowErrs, 2*time.Second).Should(Receive(BeNil()))
+
+				lock.Lock()
+				defer lock.Unlock()
+				Expect(chosenFor).To(Equal([]int{10, 0}))
+			})
+
+			It("gives the next free worker to the build with the highest priority across teams", func() {
+				lowErrs := runTask(0)
+				Eventually(calledFor(0)).Should(BeTrue())
+
+				highErrs := runTeamTask(ctx, otherTeamID, 10)
+				Eventually(calledFor(10)).Should(BeTrue())
+
+				lock.Lock()
+				freed = true
+				lock.Unlock()
+
+				Eventually(highErrs, 2*time.Second).Should(Receive(BeNil()))
+				Eventually(lowErrs, 2*time.Second).Should(Receive(BeNil()))
+
+				lock.Lock()
+				defer lock.Unlock()
+				Expect(chosenFor).To(Equal([]int{10, 0}))
+			})
+
+			It("does not hold up builds while waiting on the team's container quota", func() {
+				fakePool.FindOrChooseWorkerForContainerStub = func(_ context.Context, _ lager.Logger, _ db.ContainerOwner, _ worker.ContainerSpec, workerSpec worker.WorkerSpec, _ worker.ContainerPlacementStrategy) (worker.Worker, error) {
+					lock.Lock()
+					defer lock.Unlock()
+
+					calledWith[workerSpec.Priority] = true
+
+					if workerSpec.Priority == 10 {
+						return nil, worker.TeamContainerQuotaExceededError{MaxActiveContainers: 1}
+					}
+
+					return fakeWorker, nil
+				}
+
+				otherCtx, cancel := context.WithCancel(ctx)
+				defer cancel()
+
+				highErrs := runTeamTask(otherCtx, fakeWorkerSpec.TeamID, 10)
+				Eventually(calledFor(10)).Should(BeTrue())
+
+				lowErrs := runTask(0)
+				Eventually(lowErrs, 2*time.Second).Should(Receive(BeNil()))
+
+				cancel()
+				Eventually(highErrs, 2*time.Second).Should(Receive(HaveOccurred()))
+			})
+
+			It("does not hold up builds waiting for workers the others can't use", func() {
+				fakeWorker.SatisfiesStub = func(_ lager.Logger, workerSpec worker.WorkerSpec) bool {
+					return workerSpec.TeamID != otherTeamID
+				}
+
+				fakePool.FindOrChooseWorkerForContainerStub = func(_ context.Context, _ lager.Logger, _ db.ContainerOwner, _ worker.ContainerSpec, workerSpec worker.WorkerSpec, _ worker.ContainerPlacementStrategy) (worker.Worker, error) {
+					lock.Lock()
+					defer lock.Unlock()
+
+					calledWith[workerSpec.Priority] = true
+
+					if !freed || workerSpec.TeamID == otherTeamID {
+						return nil, nil
+					}
+
+					return fakeWorker, nil
+				}
+
+				otherCtx, cancel := context.WithCancel(ctx)
+				defer cancel()
+
+				highErrs := runTeamTask(otherCtx, otherTeamID, 10)
+				Eventually(calledFor(10)).Should(BeTrue())
+
+				lock.Lock()
+				freed = true
+				lock.Unlock()
+
+				lowErrs := runTask(0)
+				Eventually(lowErrs, 2*time.Second).Should(Receive(BeNil()))
+
+				cancel()
+				Eventually(highErrs, 2*time.Second).Should(Receive(HaveOccurred()))
+			})
+		})
 	})
 })
 
@@ -3,6 +3,7 @@ package worker
 import (
 	"context"
 	"crypto/sha256"
+	"errors"
 	"fmt"
 	"io"
 	"path"
@@ -109,6 +110,7 @@ func NewClient(pool Pool,
 		compression:                 compression,
 		workerPollingInterval:       workerPollingInterval,
 		workerStatusPublishInterval: workerStatusPublishInterval,
+		taskWaiters:                 newTaskWaitQueue(),
 	}
 }
 
@@ -118,11 +120,13 @@ type client struct {
 	compression                 compression.Compression
 	workerPollingInterval       time.Duration
 	workerStatusPublishInterval time.Duration
+	taskWaiters                 *taskWaitQueue
 }
 
 type TaskResult struct {
-	ExitStatus   int
-	VolumeMounts []VolumeMount
+	ExitStatus    int
+	VolumeMounts  []VolumeMount
+	ResourceUsage atc.ResourceUsage
 }
 
 type CheckResult struct {
@@ -132,12 +136,14 @@ type CheckResult struct {
 type PutResult struct {
 	ExitStatus    int
 	VersionResult runtime.VersionResult
+	ResourceUsage atc.ResourceUsage
 }
 
 type GetResult struct {
 	ExitStatus    int
 	VersionResult runtime.VersionResult
 	GetArtifact   runtime.GetArtifact
+	ResourceUsage atc.ResourceUsage
 }
 
 type ImageFetcherSpec struct {
@@ -209,29 +215,41 @@ func (client *client) RunCheckStep(
 	timeout time.Duration,
 	checkable resource.Resource,
 ) (CheckResult, error) {
-	chosenWorker, err := client.pool.FindOrChooseWorkerForContainer(
-		ctx,
-		logger,
-		owner,
-		containerSpec,
-		workerSpec,
-		strategy,
-	)
-	if err != nil {
-		return CheckResult{}, fmt.Errorf("find or choose worker for container: %w", err)
-	}
+	var container Container
+	err := client.waitForContainerPlacement(ctx, logger, nil, func() error {
+		chosenWorker, err := client.pool.FindOrChooseWorkerForContainer(
+			ctx,
+			logger,
+			owner,
+			containerSpec,
+			workerSpec,
+			strategy,
+		)
+		if err != nil {
+			return fmt.Errorf("find or choose worker for container: %w", err)
+		}
 
-	container, err := chosenWorker.FindOrCreateContainer(
-		ctx,
-		logger,
-		imageFetcherSpec.Delegate,
-		owner,
-		containerMetadata,
-		containerSpec,
-		imageFetcherSpec.ResourceTypes,
-	)
+		if chosenWorker == nil {
+			return errNoWorkerFits
+		}
+
+		container, err = chosenWorker.FindOrCreateContainer(
+			ctx,
+			logger,
+			imageFetcherSpec.Delegate,
+			owner,
+			containerMetadata,
+			containerSpec,
+			imageFetcherSpec.ResourceTypes,
+		)
+		if err != nil {
+			return fmt.Errorf("find or create container: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return CheckResult{}, fmt.Errorf("find or create container: %w", err)
+		return CheckResult{}, err
 	}
 
 	deadline, cancel := context.WithTimeout(ctx, timeout)
@@ -274,39 +292,65 @@ func (client *client) RunTaskStep(
 		}
 	}
 
-	chosenWorker, err := client.chooseTaskWorker(
-		ctx,
-		logger,
-		strategy,
-		lockFactory,
-		owner,
-		containerSpec,
-		workerSpec,
-		processSpec.StdoutWriter,
-	)
+	err = client.defaultTaskLimits(logger, &containerSpec)
 	if err != nil {
 		return TaskResult{}, err
 	}
 
+	containerSpec.WithinWorkerResources = placesByResources(strategy)
+
+	var (
+		chosenWorker Worker
+		container    Container
+	)
+
+	for {
+		chosenWorker, err = client.chooseTaskWorker(
+			ctx,
+			logger,
+			strategy,
+			lockFactory,
+			owner,
+			containerSpec,
+			workerSpec,
+			processSpec.StdoutWriter,
+		)
+		if err != nil {
+			return TaskResult{}, err
+		}
+
+		container, err = chosenWorker.FindOrCreateContainer(
+			ctx,
+			logger,
+			imageFetcherSpec.Delegate,
+			owner,
+			metadata,
+			containerSpec,
+			imageFetcherSpec.ResourceTypes,
+		)
+
+		// another task of the team may have taken the last container of its
+		// quota, or another task the last of the worker's resources, since the
+		// worker was chosen, in which case wait for a worker again
+		var quotaErr TeamContainerQuotaExceededError
+		if !errors.As(err, &quotaErr) && !errors.Is(err, db.ErrWorkerResourcesExhausted) {
+			break
+		}
+
+		if strategy.ModifiesActiveTasks() {
+			decreaseActiveTasks(logger.Session("decrease-active-tasks"), chosenWorker)
+		}
+	}
+
 	if strategy.ModifiesActiveTasks() {
 		defer decreaseActiveTasks(logger.Session("decrease-active-tasks"), chosenWorker)
 	}
 
-	container, err := chosenWorker.FindOrCreateContainer(
-		ctx,
-		logger,
-		imageFetcherSpec.Delegate,
-		owner,
-		metadata,
-		containerSpec,
-		imageFetcherSpec.ResourceTypes,
-	)
-
 	if err != nil {
 		return TaskResult{}, err
 	}
 
-	eventDelegate.SelectedWorker(logger, chosenWorker.Name())
+	eventDelegate.SelectedWorker(logger, chosenWorker.Name(), PlacementSteps(chosenWorker))
 
 	// container already exited
 	exitStatusProp, _ := container.Properties()
@@ -363,6 +407,8 @@ func (client *client) RunTaskStep(
 
 	logger.Info("attached")
 
+	usageTracker := trackResourceUsage(logger, container)
+
 	exitStatusChan := make(chan processStatus)
 
 	go func() {
@@ -380,26 +426,32 @@ func (client *client) RunTaskStep(
 
 		status := <-exitStatusChan
 		return TaskResult{
-			ExitStatus:   status.processStatus,
-			VolumeMounts: container.VolumeMounts(),
+			ExitStatus:    status.processStatus,
+			VolumeMounts:  container.VolumeMounts(),
+			ResourceUsage: usageTracker.Finish(),
 		}, ctx.Err()
 
 	case status := <-exitStatusChan:
+		usage := usageTracker.Finish()
+
 		if status.processErr != nil {
 			return TaskResult{
-				ExitStatus: status.processStatus,
+				ExitStatus:    status.processStatus,
+				ResourceUsage: usage,
 			}, status.processErr
 		}
 
 		err = container.SetProperty(taskExitStatusPropertyName, fmt.Sprintf("%d", status.processStatus))
 		if err != nil {
 			return TaskResult{
-				ExitStatus: status.processStatus,
+				ExitStatus:    status.processStatus,
+				ResourceUsage: usage,
 			}, err
 		}
 		return TaskResult{
-			ExitStatus:   status.processStatus,
-			VolumeMounts: container.VolumeMounts(),
+			ExitStatus:    status.processStatus,
+			VolumeMounts:  container.VolumeMounts(),
+			ResourceUsage: usage,
 		}, err
 	}
 }
@@ -419,43 +471,51 @@ func (client *client) RunGetStep(
 	resource resource.Resource,
 ) (GetResult, error) {
 
-	chosenWorker, err := client.pool.FindOrChooseWorkerForContainer(
-		ctx,
-		logger,
-		owner,
-		containerSpec,
-		workerSpec,
-		strategy,
-	)
+	sign, err := resource.Signature()
 	if err != nil {
 		return GetResult{}, err
 	}
 
-	eventDelegate.SelectedWorker(logger, chosenWorker.Name())
+	var getResult GetResult
+	err = client.waitForContainerPlacement(ctx, logger, processSpec.StderrWriter, func() error {
+		chosenWorker, err := client.pool.FindOrChooseWorkerForContainer(
+			ctx,
+			logger,
+			owner,
+			containerSpec,
+			workerSpec,
+			strategy,
+		)
+		if err != nil {
+			return err
+		}
 
-	sign, err := resource.Signature()
-	if err != nil {
-		return GetResult{}, err
-	}
+		if chosenWorker == nil {
+			return errNoWorkerFits
+		}
 
-	lockName := lockName(sign, chosenWorker.Name())
+		eventDelegate.SelectedWorker(logger, chosenWorker.Name(), PlacementSteps(chosenWorker))
 
-	// TODO: this needs to be emitted right before executing the `in` script
-	eventDelegate.Starting(logger)
+		lockName := lockName(sign, chosenWorker.Name())
 
-	getResult, _, err := chosenWorker.Fetch(
-		ctx,
-		logger,
-		containerMetadata,
-		chosenWorker,
-		containerSpec,
-		processSpec,
-		resource,
-		owner,
-		imageFetcherSpec,
-		resourceCache,
-		lockName,
-	)
+		// TODO: this needs to be emitted right before executing the `in` script
+		eventDelegate.Starting(logger)
+
+		getResult, _, err = chosenWorker.Fetch(
+			ctx,
+			logger,
+			containerMetadata,
+			chosenWorker,
+			containerSpec,
+			processSpec,
+			resource,
+			owner,
+			imageFetcherSpec,
+			resourceCache,
+			lockName,
+		)
+		return err
+	})
 	return getResult, err
 }
 
@@ -479,29 +539,37 @@ func (client *client) RunPutStep(
 		return PutResult{}, err
 	}
 
-	chosenWorker, err := client.pool.FindOrChooseWorkerForContainer(
-		ctx,
-		logger,
-		owner,
-		containerSpec,
-		workerSpec,
-		strategy,
-	)
-	if err != nil {
-		return PutResult{}, err
-	}
+	var container Container
+	err = client.waitForContainerPlacement(ctx, logger, spec.StderrWriter, func() error {
+		chosenWorker, err := client.pool.FindOrChooseWorkerForContainer(
+			ctx,
+			logger,
+			owner,
+			containerSpec,
+			workerSpec,
+			strategy,
+		)
+		if err != nil {
+			return err
+		}
 
-	eventDelegate.SelectedWorker(logger, chosenWorker.Name())
+		if chosenWorker == nil {
+			return errNoWorkerFits
+		}
 
-	container, err := chosenWorker.FindOrCreateContainer(
-		ctx,
-		logger,
-		imageFetcherSpec.Delegate,
-		owner,
-		metadata,
-		containerSpec,
-		imageFetcherSpec.ResourceTypes,
-	)
+		eventDelegate.SelectedWorker(logger, chosenWorker.Name(), PlacementSteps(chosenWorker))
+
+		container, err = chosenWorker.FindOrCreateContainer(
+			ctx,
+			logger,
+			imageFetcherSpec.Delegate,
+			owner,
+			metadata,
+			containerSpec,
+			imageFetcherSpec.ResourceTypes,
+		)
+		return err
+	})
 	if err != nil {
 		return PutResult{}, err
 	}
@@ -524,20 +592,25 @@ func (client *client) RunPutStep(
 
 	eventDelegate.Starting(logger)
 
+	usageTracker := trackResourceUsage(logger, container)
+
 	vr, err = resource.Put(ctx, spec, container)
+	usage := usageTracker.Finish()
 	if err != nil {
 		if failErr, ok := err.(runtime.ErrResourceScriptFailed); ok {
 			return PutResult{
 				ExitStatus:    failErr.ExitStatus,
 				VersionResult: runtime.VersionResult{},
+				ResourceUsage: usage,
 			}, nil
 		} else {
-			return PutResult{}, err
+			return PutResult{ResourceUsage: usage}, err
 		}
 	}
 	return PutResult{
 		ExitStatus:    0,
 		VersionResult: vr,
+		ResourceUsage: usage,
 	}, nil
 }
 
@@ -578,6 +651,7 @@ func (client *client) chooseTaskWorker(
 		activeTasksLock lock.Lock
 		lockAcquired    bool
 		elapsed         time.Duration
+		quotaReported   bool
 		err             error
 	)
 
@@ -593,7 +667,14 @@ func (client *client) chooseTaskWorker(
 		Platform:   workerSpec.Platform,
 	}
 
+	var waiter *taskWaiter
+	if strategy.ModifiesActiveTasks() {
+		waiter = client.taskWaiters.Enqueue(workerSpec)
+		defer client.taskWaiters.Dequeue(waiter)
+	}
+
 	for {
+		overQuota := false
 		if chosenWorker, err = client.pool.FindOrChooseWorkerForContainer(
 			ctx,
 			logger,
@@ -602,11 +683,52 @@ func (client *client) chooseTaskWorker(
 			workerSpec,
 			strategy,
 		); err != nil {
-			return nil, err
+			var quotaErr TeamContainerQuotaExceededError
+			if !errors.As(err, &quotaErr) {
+				return nil, err
+			}
+
+			// wait for one of the team's containers to go away
+			if !quotaReported {
+				message := fmt.Sprintf("Team has reached its quota of %d active containers, waiting for one to be released.\n", quotaErr.MaxActiveContainers)
+				writeOutputMessage(logger, outputWriter, message)
+				quotaReported = true
+			}
+
+			chosenWorker, err = nil, nil
+			overQuota = true
+		}
+
+		if waiter != nil {
+			// a task waiting on its team's quota must not keep the tasks of
+			// other teams from being placed
+			client.taskWaiters.SetParked(waiter, overQuota)
+
+			// leave the worker to a build with a higher priority that is
+			// waiting for a worker it could be placed on
+			if chosenWorker != nil && !client.taskWaiters.IsNext(logger, waiter, chosenWorker) {
+				chosenWorker = nil
+			}
 		}
 
 		if !strategy.ModifiesActiveTasks() {
-			return chosenWorker, nil
+			if chosenWorker != nil {
+				return chosenWorker, nil
+			}
+
+			select {
+			case <-ctx.Done():
+				logger.Info("aborted-waiting-worker")
+				return nil, ctx.Err()
+			default:
+			}
+
+			elapsed = waitForWorker(logger,
+				workerPollingTicker,
+				workerStatusPublishTicker,
+				outputWriter,
+				started)
+			continue
 		}
 
 		if activeTasksLock, lockAcquired, err = lockFactory.Acquire(logger, lock.NewActiveTasksLockID()); err != nil {
@@ -670,6 +792,29 @@ func (client *client) chooseTaskWorker(
 	}
 }
 
+// defaultTaskLimits sets the limits a task leaves unset to the maximum the
+// task limit quotas of its team allow, rather than having the task rejected
+// for being unlimited.
+func (client *client) defaultTaskLimits(logger lager.Logger, spec *ContainerSpec) error {
+	usage, err := client.provider.TeamQuotaUsage(logger, spec.TeamID)
+	if err != nil {
+		return err
+	}
+
+	spec.Limits.CPU = limitOrQuota(spec.Limits.CPU, usage.Quotas.MaxTaskCPU)
+	spec.Limits.Memory = limitOrQuota(spec.Limits.Memory, usage.Quotas.MaxTaskMemory)
+
+	return nil
+}
+
+func limitOrQuota(limit *uint64, max uint64) *uint64 {
+	if max == 0 || (limit != nil && *limit != 0) {
+		return limit
+	}
+
+	return &max
+}
+
 // TODO (runtime) don't modify spec inside here, Specs don't change after you write them
 func (client *client) wireInputsAndCaches(logger lager.Logger, spec *ContainerSpec) error {
 	var inputs []InputSource
@@ -748,6 +893,54 @@ func waitForWorker(
 	return elapsed
 }
 
+// errNoWorkerFits is returned to waitForContainerPlacement when the placement
+// strategy didn't choose any of the compatible workers, e.g. because none of
+// them has enough resources left.
+var errNoWorkerFits = errors.New("no worker fits the container")
+
+// waitForContainerPlacement calls create until it no longer fails because the
+// team has reached its quota of active containers or because no worker fits
+// the container, polling for a container to be released in between.
+func (client *client) waitForContainerPlacement(
+	ctx context.Context,
+	logger lager.Logger,
+	outputWriter io.Writer,
+	create func() error,
+) error {
+	pollingTicker := time.NewTicker(client.workerPollingInterval)
+	defer pollingTicker.Stop()
+
+	quotaReported := false
+	busyReported := false
+	for {
+		err := create()
+
+		var quotaErr TeamContainerQuotaExceededError
+		switch {
+		case errors.As(err, &quotaErr):
+			if !quotaReported && outputWriter != nil {
+				message := fmt.Sprintf("Team has reached its quota of %d active containers, waiting for one to be released.\n", quotaErr.MaxActiveContainers)
+				writeOutputMessage(logger, outputWriter, message)
+				quotaReported = true
+			}
+		case errors.Is(err, errNoWorkerFits):
+			if !busyReported && outputWriter != nil {
+				writeOutputMessage(logger, outputWriter, "All workers are busy at the moment, please stand-by.\n")
+				busyReported = true
+			}
+		default:
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Info("aborted-waiting-for-container-placement")
+			return ctx.Err()
+		case <-pollingTicker.C:
+		}
+	}
+}
+
 func writeOutputMessage(logger lager.Logger, outputWriter io.Writer, message string) {
 	_, err := outputWriter.Write([]byte(message))
 	if err != nil {
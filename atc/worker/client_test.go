@@ -20,6 +20,7 @@ import (
 	"github.com/concourse/concourse/atc/runtime/runtimefakes"
 	"github.com/onsi/gomega/gbytes"
 
+	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/baggageclaim"
 	"github.com/concourse/concourse/atc/db"
@@ -276,6 +277,25 @@ var _ = Describe("Client", func() {
 			})
 		})
 
+		Context("when no worker fits the container", func() {
+			var fakeWorker *workerfakes.FakeWorker
+
+			BeforeEach(func() {
+				fakeWorker = new(workerfakes.FakeWorker)
+				fakeWorker.FindOrCreateContainerReturns(new(workerfakes.FakeContainer), nil)
+
+				fakePool.FindOrChooseWorkerForContainerReturnsOnCall(0, nil, nil)
+				fakePool.FindOrChooseWorkerForContainerReturnsOnCall(1, fakeWorker, nil)
+			})
+
+			It("waits for a worker to be chosen", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakePool.FindOrChooseWorkerForContainerCallCount()).To(Equal(2))
+				Expect(fakeWorker.FindOrCreateContainerCallCount()).To(Equal(1))
+				Expect(fakeResource.CheckCallCount()).To(Equal(1))
+			})
+		})
+
 		Context("having found a worker", func() {
 			var fakeWorker *workerfakes.FakeWorker
 
@@ -453,7 +473,7 @@ var _ = Describe("Client", func() {
 
 			It("invokes the Starting Event on the delegate", func() {
 				Expect(fakeEventDelegate.StartingCallCount()).Should((Equal(1)))
-				_, actualWorkerName := fakeEventDelegate.SelectedWorkerArgsForCall(0)
+				_, actualWorkerName, _ := fakeEventDelegate.SelectedWorkerArgsForCall(0)
 				Expect(actualWorkerName).To(Equal(fakeChosenWorker.Name()))
 			})
 
@@ -487,6 +507,26 @@ var _ = Describe("Client", func() {
 			})
 		})
 
+		Context("when no worker fits the container", func() {
+			BeforeEach(func() {
+				fakeProcessSpec.StderrWriter = gbytes.NewBuffer()
+
+				fakePool.FindOrChooseWorkerForContainerReturnsOnCall(0, nil, nil)
+				fakePool.FindOrChooseWorkerForContainerReturnsOnCall(1, fakeChosenWorker, nil)
+			})
+
+			It("waits for a worker to be chosen", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakePool.FindOrChooseWorkerForContainerCallCount()).To(Equal(2))
+				Expect(fakeEventDelegate.SelectedWorkerCallCount()).To(Equal(1))
+				Expect(fakeChosenWorker.FetchCallCount()).To(Equal(1))
+			})
+
+			It("tells the user it is waiting", func() {
+				Expect(fakeProcessSpec.StderrWriter).To(gbytes.Say("All workers are busy at the moment, please stand-by.\n"))
+			})
+		})
+
 		Context("Calling chosenWorker.Fetch", func() {
 			var (
 				someError     error
@@ -645,7 +685,7 @@ var _ = Describe("Client", func() {
 			It("chooses a worker", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(fakePool.FindOrChooseWorkerForContainerCallCount()).To(Equal(1))
-				_, actualWorkerName := fakeEventDelegate.SelectedWorkerArgsForCall(0)
+				_, actualWorkerName, _ := fakeEventDelegate.SelectedWorkerArgsForCall(0)
 				Expect(actualWorkerName).To(Equal(fakeWorker.Name()))
 			})
 
@@ -1076,11 +1116,30 @@ var _ = Describe("Client", func() {
 				})
 
 				Context("when the process exits successfully", func() {
+					BeforeEach(func() {
+						fakeContainer.MetricsReturns(garden.Metrics{
+							CPUStat:    garden.ContainerCPUStat{Usage: 1000},
+							MemoryStat: garden.ContainerMemoryStat{TotalUsageTowardLimit: 2048},
+							DiskStat:   garden.ContainerDiskStat{ExclusiveBytesUsed: 4096},
+						}, nil)
+						fakeContainer.InfoReturns(garden.ContainerInfo{
+							Properties: garden.Properties{"garden.memory-peak": "8192"},
+						}, nil)
+					})
+
 					It("returns a successful result", func() {
 						Expect(status).To(BeZero())
 						Expect(err).ToNot(HaveOccurred())
 					})
 
+					It("returns the resources consumed by the container", func() {
+						Expect(taskResult.ResourceUsage).To(Equal(atc.ResourceUsage{
+							CPUTime:         1000,
+							MemoryPeak:      8192,
+							RootfsDiskUsage: 4096,
+						}))
+					})
+
 					It("saves the exit status property", func() {
 						Expect(fakeContainer.SetPropertyCallCount()).To(Equal(1))
 
@@ -1375,7 +1434,7 @@ var _ = Describe("Client", func() {
 
 		It("invokes the SelectedWorker Event on the delegate", func() {
 			Expect(fakeEventDelegate.SelectedWorkerCallCount()).Should((Equal(1)))
-			_, actualWorkerName := fakeEventDelegate.SelectedWorkerArgsForCall(0)
+			_, actualWorkerName, _ := fakeEventDelegate.SelectedWorkerArgsForCall(0)
 			Expect(actualWorkerName).To(Equal(fakeChosenWorker.Name()))
 		})
 
@@ -1407,6 +1466,27 @@ var _ = Describe("Client", func() {
 			})
 		})
 
+		Context("when no worker fits the container", func() {
+			BeforeEach(func() {
+				fakeProcessSpec.StderrWriter = gbytes.NewBuffer()
+
+				fakePool.FindOrChooseWorkerForContainerReturnsOnCall(0, nil, nil)
+				fakePool.FindOrChooseWorkerForContainerReturnsOnCall(1, fakeChosenWorker, nil)
+				fakeContainer.PropertyReturns("0", fmt.Errorf("property not found"))
+			})
+
+			It("waits for a worker to be chosen", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakePool.FindOrChooseWorkerForContainerCallCount()).To(Equal(2))
+				Expect(fakeChosenWorker.FindOrCreateContainerCallCount()).To(Equal(1))
+				Expect(fakeResource.PutCallCount()).To(Equal(1))
+			})
+
+			It("tells the user it is waiting", func() {
+				Expect(fakeProcessSpec.StderrWriter).To(gbytes.Say("All workers are busy at the moment, please stand-by.\n"))
+			})
+		})
+
 		Context("found a container that has run resource.Put and exited", func() {
 			BeforeEach(func() {
 				fakeChosenWorker.FindOrCreateContainerReturns(fakeContainer, nil)
@@ -1498,6 +1578,22 @@ var _ = Describe("Client", func() {
 					Expect(status).To(Equal(0))
 					Expect(versionResult).To(Equal(expectedVersionResult))
 				})
+
+				Context("when the container reports metrics", func() {
+					BeforeEach(func() {
+						fakeContainer.MetricsReturns(garden.Metrics{
+							CPUStat:    garden.ContainerCPUStat{Usage: 1000},
+							MemoryStat: garden.ContainerMemoryStat{TotalUsageTowardLimit: 2048},
+						}, nil)
+					})
+
+					It("returns the resources consumed by the container", func() {
+						Expect(result.ResourceUsage).To(Equal(atc.ResourceUsage{
+							CPUTime:    1000,
+							MemoryPeak: 2048,
+						}))
+					})
+				})
 			})
 		})
 
@@ -1512,5 +1608,41 @@ var _ = Describe("Client", func() {
 				Expect(versionResult).To(Equal(runtime.VersionResult{}))
 			})
 		})
+
+		Context("when the team has reached its container quota", func() {
+			BeforeEach(func() {
+				fakeProcessSpec.StderrWriter = gbytes.NewBuffer()
+
+				fakeChosenWorker.FindOrCreateContainerReturnsOnCall(0, nil, worker.TeamContainerQuotaExceededError{MaxActiveContainers: 3})
+				fakeChosenWorker.FindOrCreateContainerReturnsOnCall(1, fakeContainer, nil)
+				fakeContainer.PropertyReturns("0", fmt.Errorf("property not found"))
+			})
+
+			It("waits for one of the team's containers to be released", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fakeChosenWorker.FindOrCreateContainerCallCount()).To(Equal(2))
+				Expect(fakeResource.PutCallCount()).To(Equal(1))
+			})
+
+			It("tells the user it is waiting", func() {
+				Expect(fakeProcessSpec.StderrWriter).To(gbytes.Say("Team has reached its quota of 3 active containers, waiting for one to be released.\n"))
+			})
+
+			Context("when the build is aborted while waiting", func() {
+				BeforeEach(func() {
+					var cancel context.CancelFunc
+					ctx, cancel = context.WithCancel(ctx)
+					fakeChosenWorker.FindOrCreateContainerStub = func(context.Context, lager.Logger, worker.ImageFetchingDelegate, db.ContainerOwner, db.ContainerMetadata, worker.ContainerSpec, atc.VersionedResourceTypes) (worker.Container, error) {
+						cancel()
+						return nil, worker.TeamContainerQuotaExceededError{MaxActiveContainers: 3}
+					}
+				})
+
+				It("returns the context's error", func() {
+					Expect(err).To(Equal(context.Canceled))
+					Expect(fakeResource.PutCallCount()).To(BeZero())
+				})
+			})
+		})
 	})
 })
@@ -16,6 +16,17 @@ type WorkerSpec struct {
 	Tags          []string
 	TeamID        int
 	ResourceTypes atc.VersionedResourceTypes
+
+	// Priority of the build the container is for. When workers are saturated
+	// tasks of builds with a higher priority are placed first.
+	Priority int
+
+	// Privileged containers can't be placed on unprivileged workers.
+	Privileged bool
+
+	// SecurityProfile the containers select, which only the workers defining
+	// it can apply.
+	SecurityProfile string
 }
 
 type ContainerSpec struct {
@@ -49,15 +60,27 @@ type ContainerSpec struct {
 
 	// Optional user to run processes as. Overwrites the one specified in the docker image.
 	User string
+
+	// Optional name of the security profile defined on the worker to apply to
+	// the container.
+	SecurityProfile string
+
+	// Create the container only if its limits fit within the allocatable
+	// resources its worker has left, as a placement strategy fitting
+	// containers to the workers' resources assumed when choosing the worker.
+	WithinWorkerResources bool
 }
 
 // The below methods cause ContainerSpec to fulfill the
-// go.opentelemetry.io/otel/api/propagators.Supplier interface
+// go.opentelemetry.io/otel/api/propagators.Supplier interface, so that the
+// trace context (e.g. TRACEPARENT) is passed to the container's processes
+// through its environment.
 
 func (cs *ContainerSpec) Get(key string) string {
+	varName := envVarName(key)
 	for _, env := range cs.Env {
-		assignment := strings.SplitN("=", env, 2)
-		if assignment[0] == strings.ToUpper(key) {
+		assignment := strings.SplitN(env, "=", 2)
+		if len(assignment) == 2 && assignment[0] == varName {
 			return assignment[1]
 		}
 	}
@@ -65,10 +88,10 @@ func (cs *ContainerSpec) Get(key string) string {
 }
 
 func (cs *ContainerSpec) Set(key string, value string) {
-	varName := strings.ToUpper(key)
+	varName := envVarName(key)
 	envVar := varName + "=" + value
 	for i, env := range cs.Env {
-		if strings.SplitN("=", env, 2)[0] == varName {
+		if strings.SplitN(env, "=", 2)[0] == varName {
 			cs.Env[i] = envVar
 			return
 		}
@@ -76,6 +99,12 @@ func (cs *ContainerSpec) Set(key string, value string) {
 	cs.Env = append(cs.Env, envVar)
 }
 
+// envVarName converts a propagation header name (e.g. "Correlation-Context")
+// to a valid environment variable name (e.g. "CORRELATION_CONTEXT").
+func envVarName(key string) string {
+	return strings.ReplaceAll(strings.ToUpper(key), "-", "_")
+}
+
 //go:generate counterfeiter . InputSource
 
 type InputSource interface {
@@ -158,5 +187,20 @@ func (spec WorkerSpec) Description() string {
 		attrs = append(attrs, fmt.Sprintf("tag '%s'", tag))
 	}
 
+	if spec.privileged() {
+		attrs = append(attrs, "privileged containers")
+	}
+
+	if spec.SecurityProfile != "" {
+		attrs = append(attrs, fmt.Sprintf("security profile '%s'", spec.SecurityProfile))
+	}
+
 	return strings.Join(attrs, ", ")
 }
+
+// privileged returns whether the containers for the spec run privileged,
+// either because it was requested or because the custom resource type they
+// run is privileged.
+func (spec WorkerSpec) privileged() bool {
+	return spec.Privileged || spec.ResourceTypes.Privileged(spec.ResourceType)
+}
@@ -0,0 +1,113 @@
+package worker_test
+
+import (
+	"context"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/tracing"
+	"go.opentelemetry.io/otel/api/trace/testtrace"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ContainerSpec", func() {
+	var spec worker.ContainerSpec
+
+	BeforeEach(func() {
+		spec = worker.ContainerSpec{
+			Env: []string{"FOO=bar", "TRACEPARENT=stale"},
+		}
+	})
+
+	Describe("Get", func() {
+		It("returns the value of the matching env var", func() {
+			Expect(spec.Get("Traceparent")).To(Equal("stale"))
+		})
+
+		It("returns an empty string when the env var is not set", func() {
+			Expect(spec.Get("Correlation-Context")).To(BeEmpty())
+		})
+	})
+
+	Describe("Set", func() {
+		It("replaces an existing env var", func() {
+			spec.Set("Traceparent", "fresh")
+			Expect(spec.Env).To(Equal([]string{"FOO=bar", "TRACEPARENT=fresh"}))
+		})
+
+		It("appends new env vars using a valid env var name", func() {
+			spec.Set("Correlation-Context", "k=v")
+			Expect(spec.Env).To(ContainElement("CORRELATION_CONTEXT=k=v"))
+			Expect(spec.Get("Correlation-Context")).To(Equal("k=v"))
+		})
+	})
+
+	Context("when the trace context is injected", func() {
+		BeforeEach(func() {
+			tracing.ConfigureTraceProvider(&tracing.TestTraceProvider{})
+		})
+
+		AfterEach(func() {
+			tracing.Configured = false
+		})
+
+		It("propagates the span as TRACEPARENT so processes can continue the trace", func() {
+			ctx, span := tracing.StartSpan(context.Background(), "task", nil)
+			tracing.Inject(ctx, &spec)
+
+			traceID := span.(*testtrace.Span).SpanContext().TraceIDString()
+			Expect(spec.Env).To(ContainElement(MatchRegexp(`^TRACEPARENT=00-` + traceID + `-[0-9a-f]{16}-[0-9a-f]{2}$`)))
+			Expect(spec.Env).ToNot(ContainElement("TRACEPARENT=stale"))
+		})
+	})
+})
+
+var _ = Describe("WorkerSpec", func() {
+	Describe("Description", func() {
+		It("describes the requirements of the spec", func() {
+			spec := worker.WorkerSpec{
+				Platform: "some-platform",
+				Tags:     []string{"some-tag"},
+			}
+
+			Expect(spec.Description()).To(Equal("platform 'some-platform', tag 'some-tag'"))
+		})
+
+		It("mentions privileged containers", func() {
+			spec := worker.WorkerSpec{
+				Platform:   "some-platform",
+				Privileged: true,
+			}
+
+			Expect(spec.Description()).To(Equal("platform 'some-platform', privileged containers"))
+		})
+
+		It("mentions the security profile", func() {
+			spec := worker.WorkerSpec{
+				Platform:        "some-platform",
+				SecurityProfile: "network-tools",
+			}
+
+			Expect(spec.Description()).To(Equal("platform 'some-platform', security profile 'network-tools'"))
+		})
+
+		It("mentions privileged custom resource types", func() {
+			spec := worker.WorkerSpec{
+				ResourceType: "some-custom-type",
+				ResourceTypes: atc.VersionedResourceTypes{
+					{
+						ResourceType: atc.ResourceType{
+							Name:       "some-custom-type",
+							Type:       "some-base-type",
+							Privileged: true,
+						},
+					},
+				},
+			}
+
+			Expect(spec.Description()).To(Equal("resource type 'some-custom-type', privileged containers"))
+		})
+	})
+})
@@ -175,6 +175,13 @@ func (provider *dbWorkerProvider) FindWorkerForVolume(
 	return worker, true, err
 }
 
+func (provider *dbWorkerProvider) TeamQuotaUsage(
+	logger lager.Logger,
+	teamID int,
+) (db.TeamQuotaUsage, error) {
+	return provider.dbTeamFactory.GetByID(teamID).QuotaUsage()
+}
+
 func (provider *dbWorkerProvider) NewGardenWorker(logger lager.Logger, savedWorker db.Worker, buildContainersCount int) Worker {
 	gcf := gclient.NewGardenClientFactory(
 		provider.dbWorkerFactory,
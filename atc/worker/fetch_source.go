@@ -167,14 +167,18 @@ func (s *fetchSource) Create(ctx context.Context) (GetResult, Volume, error) {
 		return GetResult{}, nil, err
 	}
 
+	usageTracker := trackResourceUsage(s.logger, container)
+
 	vr, err := s.resource.Get(ctx, s.processSpec, container)
+	usage := usageTracker.Finish()
 	if err != nil {
 		sLog.Error("failed-to-fetch-resource", err)
 		// TODO: Is this compatible with previous behaviour of returning a nil when error type is NOT ErrResourceScriptFailed
 
 		if failErr, ok := err.(runtime.ErrResourceScriptFailed); ok {
 			return GetResult{
-				ExitStatus: failErr.ExitStatus,
+				ExitStatus:    failErr.ExitStatus,
+				ResourceUsage: usage,
 			}, nil, nil
 		}
 		return GetResult{}, nil, err
@@ -206,6 +210,7 @@ func (s *fetchSource) Create(ctx context.Context) (GetResult, Volume, error) {
 		GetArtifact: runtime.GetArtifact{
 			VolumeHandle: volume.Handle(),
 		},
+		ResourceUsage: usage,
 	}, volume, nil
 }
 
@@ -285,6 +285,22 @@ var _ = Describe("FetchSource", func() {
 				Expect(getResult.GetArtifact.VolumeHandle).To(Equal(fakeVolume.Handle()))
 				Expect(volume).ToNot(BeNil())
 			})
+
+			Context("when the container reports metrics", func() {
+				BeforeEach(func() {
+					fakeContainer.MetricsReturns(garden.Metrics{
+						CPUStat:  garden.ContainerCPUStat{Usage: 1000},
+						DiskStat: garden.ContainerDiskStat{ExclusiveBytesUsed: 4096},
+					}, nil)
+				})
+
+				It("returns the resources consumed by the container", func() {
+					Expect(getResult.ResourceUsage).To(Equal(atc.ResourceUsage{
+						CPUTime:         1000,
+						RootfsDiskUsage: 4096,
+					}))
+				})
+			})
 		})
 	})
 })
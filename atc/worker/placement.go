@@ -1,10 +1,12 @@
 package worker
 
 import (
+	"errors"
 	"math/rand"
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
 
@@ -15,17 +17,41 @@ type ContainerPlacementStrategy interface {
 	ModifiesActiveTasks() bool
 }
 
+// A ContainerPlacementFilter is a ContainerPlacementStrategy which can be
+// chained with others. Rather than choosing a single worker, Filter narrows
+// down the workers which the next strategy in the chain gets to consider.
+type ContainerPlacementFilter interface {
+	ContainerPlacementStrategy
+
+	Name() string
+	Filter(lager.Logger, []Worker, ContainerSpec) ([]Worker, error)
+}
+
 type VolumeLocalityPlacementStrategy struct {
 	rand *rand.Rand
 }
 
-func NewVolumeLocalityPlacementStrategy() ContainerPlacementStrategy {
+func NewVolumeLocalityPlacementStrategy() ContainerPlacementFilter {
 	return &VolumeLocalityPlacementStrategy{
 		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
 
+func (strategy *VolumeLocalityPlacementStrategy) Name() string {
+	return "volume-locality"
+}
+
 func (strategy *VolumeLocalityPlacementStrategy) Choose(logger lager.Logger, workers []Worker, spec ContainerSpec) (Worker, error) {
+	highestLocalityWorkers, err := strategy.Filter(logger, workers, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return highestLocalityWorkers[strategy.rand.Intn(len(highestLocalityWorkers))], nil
+}
+
+// Filter keeps the workers which have the most of the step's inputs.
+func (strategy *VolumeLocalityPlacementStrategy) Filter(logger lager.Logger, workers []Worker, spec ContainerSpec) ([]Worker, error) {
 	workersByCount := map[int][]Worker{}
 	var highestCount int
 	for _, w := range workers {
@@ -49,9 +75,7 @@ func (strategy *VolumeLocalityPlacementStrategy) Choose(logger lager.Logger, wor
 		}
 	}
 
-	highestLocalityWorkers := workersByCount[highestCount]
-
-	return highestLocalityWorkers[strategy.rand.Intn(len(highestLocalityWorkers))], nil
+	return workersByCount[highestCount], nil
 }
 
 func (strategy *VolumeLocalityPlacementStrategy) ModifiesActiveTasks() bool {
@@ -62,13 +86,27 @@ type FewestBuildContainersPlacementStrategy struct {
 	rand *rand.Rand
 }
 
-func NewFewestBuildContainersPlacementStrategy() ContainerPlacementStrategy {
+func NewFewestBuildContainersPlacementStrategy() ContainerPlacementFilter {
 	return &FewestBuildContainersPlacementStrategy{
 		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
 
+func (strategy *FewestBuildContainersPlacementStrategy) Name() string {
+	return "fewest-build-containers"
+}
+
 func (strategy *FewestBuildContainersPlacementStrategy) Choose(logger lager.Logger, workers []Worker, spec ContainerSpec) (Worker, error) {
+	leastBusyWorkers, err := strategy.Filter(logger, workers, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return leastBusyWorkers[strategy.rand.Intn(len(leastBusyWorkers))], nil
+}
+
+// Filter keeps the workers which have the fewest build containers.
+func (strategy *FewestBuildContainersPlacementStrategy) Filter(logger lager.Logger, workers []Worker, spec ContainerSpec) ([]Worker, error) {
 	workersByWork := map[int][]Worker{}
 	var minWork int
 
@@ -80,8 +118,7 @@ func (strategy *FewestBuildContainersPlacementStrategy) Choose(logger lager.Logg
 		}
 	}
 
-	leastBusyWorkers := workersByWork[minWork]
-	return leastBusyWorkers[strategy.rand.Intn(len(leastBusyWorkers))], nil
+	return workersByWork[minWork], nil
 }
 
 func (strategy *FewestBuildContainersPlacementStrategy) ModifiesActiveTasks() bool {
@@ -93,7 +130,7 @@ type LimitActiveTasksPlacementStrategy struct {
 	maxTasks int
 }
 
-func NewLimitActiveTasksPlacementStrategy(maxTasks int) ContainerPlacementStrategy {
+func NewLimitActiveTasksPlacementStrategy(maxTasks int) ContainerPlacementFilter {
 	return &LimitActiveTasksPlacementStrategy{
 		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
 		maxTasks: maxTasks,
@@ -130,6 +167,37 @@ func (strategy *LimitActiveTasksPlacementStrategy) Choose(logger lager.Logger, w
 	return leastBusyWorkers[strategy.rand.Intn(len(leastBusyWorkers))], nil
 }
 
+func (strategy *LimitActiveTasksPlacementStrategy) Name() string {
+	return "limit-active-tasks"
+}
+
+// Filter keeps the workers which have fewer than the maximum number of active
+// tasks. Unlike Choose, it does not prefer the least busy workers, so that a
+// strategy further down the chain can pick between them.
+func (strategy *LimitActiveTasksPlacementStrategy) Filter(logger lager.Logger, workers []Worker, spec ContainerSpec) ([]Worker, error) {
+	if strategy.maxTasks == 0 || spec.Type != db.ContainerTypeTask {
+		return workers, nil
+	}
+
+	var available []Worker
+	for _, w := range workers {
+		activeTasks, err := w.ActiveTasks()
+		if err != nil {
+			logger.Error("Cannot retrive active tasks on worker. Skipping.", err)
+			continue
+		}
+
+		if activeTasks >= strategy.maxTasks {
+			logger.Info("worker-busy")
+			continue
+		}
+
+		available = append(available, w)
+	}
+
+	return available, nil
+}
+
 func (strategy *LimitActiveTasksPlacementStrategy) ModifiesActiveTasks() bool {
 	return true
 }
@@ -138,12 +206,22 @@ type RandomPlacementStrategy struct {
 	rand *rand.Rand
 }
 
-func NewRandomPlacementStrategy() ContainerPlacementStrategy {
+func NewRandomPlacementStrategy() ContainerPlacementFilter {
 	return &RandomPlacementStrategy{
 		rand: rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
 
+func (strategy *RandomPlacementStrategy) Name() string {
+	return "random"
+}
+
+// Filter keeps all of the workers; a chain of strategies always picks one of
+// the remaining workers at random.
+func (strategy *RandomPlacementStrategy) Filter(logger lager.Logger, workers []Worker, spec ContainerSpec) ([]Worker, error) {
+	return workers, nil
+}
+
 func (strategy *RandomPlacementStrategy) Choose(logger lager.Logger, workers []Worker, spec ContainerSpec) (Worker, error) {
 	return workers[strategy.rand.Intn(len(workers))], nil
 }
@@ -151,3 +229,269 @@ func (strategy *RandomPlacementStrategy) Choose(logger lager.Logger, workers []W
 func (strategy *RandomPlacementStrategy) ModifiesActiveTasks() bool {
 	return false
 }
+
+// ErrNoWorkerResources is returned by the ResourceFitPlacementStrategy when
+// none of the workers report their allocatable resources.
+var ErrNoWorkerResources = errors.New("no workers report allocatable resources")
+
+type ResourceFitPlacementStrategy struct {
+	rand          *rand.Rand
+	workerFactory db.WorkerFactory
+}
+
+func NewResourceFitPlacementStrategy(workerFactory db.WorkerFactory) ContainerPlacementFilter {
+	return &ResourceFitPlacementStrategy{
+		rand:          rand.New(rand.NewSource(time.Now().UnixNano())),
+		workerFactory: workerFactory,
+	}
+}
+
+func (strategy *ResourceFitPlacementStrategy) Name() string {
+	return "resource-fit"
+}
+
+// Choose packs task containers onto the worker whose remaining resources fit
+// the container's limits most tightly, so that larger workers stay free for
+// larger tasks. Workers which do not report their allocatable resources are
+// skipped. If no worker has room for the task, no worker is chosen and the
+// task waits for resources to be released.
+func (strategy *ResourceFitPlacementStrategy) Choose(logger lager.Logger, workers []Worker, spec ContainerSpec) (Worker, error) {
+	bestFitWorkers, err := strategy.Filter(logger, workers, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bestFitWorkers) == 0 {
+		return nil, nil
+	}
+
+	return bestFitWorkers[strategy.rand.Intn(len(bestFitWorkers))], nil
+}
+
+// Filter keeps the workers which the container's limits fit most tightly, so
+// that the chain still packs containers when it ends with resource-fit.
+func (strategy *ResourceFitPlacementStrategy) Filter(logger lager.Logger, workers []Worker, spec ContainerSpec) ([]Worker, error) {
+	fits, err := strategy.fits(logger, workers, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var bestFitWorkers []Worker
+	var bestFitLeftover float64
+	for _, fit := range fits {
+		if len(bestFitWorkers) == 0 || fit.leftover < bestFitLeftover {
+			bestFitWorkers = []Worker{fit.worker}
+			bestFitLeftover = fit.leftover
+		} else if fit.leftover == bestFitLeftover {
+			bestFitWorkers = append(bestFitWorkers, fit.worker)
+		}
+	}
+
+	return bestFitWorkers, nil
+}
+
+type workerFit struct {
+	worker   Worker
+	leftover float64
+}
+
+func (strategy *ResourceFitPlacementStrategy) fits(logger lager.Logger, workers []Worker, spec ContainerSpec) ([]workerFit, error) {
+	var request db.WorkerResources
+	if spec.Type == db.ContainerTypeTask {
+		if spec.Limits.CPU != nil {
+			request.CPU = *spec.Limits.CPU
+		}
+		if spec.Limits.Memory != nil {
+			request.Memory = *spec.Limits.Memory
+		}
+	}
+
+	var reporting []Worker
+	for _, w := range workers {
+		if w.AllocatableResources() == (db.WorkerResources{}) {
+			logger.Debug("worker-does-not-report-resources", lager.Data{"worker": w.Name()})
+			continue
+		}
+
+		reporting = append(reporting, w)
+	}
+
+	if len(reporting) == 0 {
+		return nil, ErrNoWorkerResources
+	}
+
+	allocatedPerWorker, err := strategy.workerFactory.AllocatedResourcesPerWorker()
+	if err != nil {
+		logger.Error("failed-to-get-allocated-resources", err)
+		return nil, err
+	}
+
+	var fits []workerFit
+	for _, w := range reporting {
+		allocatable := w.AllocatableResources()
+		allocated := allocatedPerWorker[w.Name()]
+
+		cpuLeftover, cpuFits := leftover(allocatable.CPU, allocated.CPU, request.CPU)
+		memoryLeftover, memoryFits := leftover(allocatable.Memory, allocated.Memory, request.Memory)
+		if spec.Type == db.ContainerTypeTask && (!cpuFits || !memoryFits) {
+			logger.Info("worker-full", lager.Data{"worker": w.Name()})
+			continue
+		}
+
+		fits = append(fits, workerFit{
+			worker:   w,
+			leftover: cpuLeftover + memoryLeftover,
+		})
+	}
+
+	return fits, nil
+}
+
+func (strategy *ResourceFitPlacementStrategy) ModifiesActiveTasks() bool {
+	return false
+}
+
+// leftover returns the fraction of an allocatable amount which would remain
+// after the request is placed, and whether the request fits at all. An
+// unreported amount fits any request and leaves nothing to compare.
+func leftover(allocatable, allocated, request uint64) (float64, bool) {
+	if allocatable == 0 {
+		return 0, true
+	}
+
+	if allocated > allocatable || request > allocatable-allocated {
+		return 0, false
+	}
+
+	return float64(allocatable-allocated-request) / float64(allocatable), true
+}
+
+// placesByResources returns true if the strategy fits containers to the
+// resources the workers have left, in which case the containers must be
+// created within them so that concurrent placements can't overcommit a worker.
+func placesByResources(strategy ContainerPlacementStrategy) bool {
+	switch strategy := strategy.(type) {
+	case *ResourceFitPlacementStrategy:
+		return true
+	case *ChainedPlacementStrategy:
+		for _, filter := range strategy.filters {
+			if placesByResources(filter) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+type ChainedPlacementStrategy struct {
+	rand    *rand.Rand
+	filters []ContainerPlacementFilter
+}
+
+// NewChainedPlacementStrategy returns a strategy which passes the workers
+// through each of the given filters in turn, and then picks one of the
+// remaining workers at random. A filter which fails is skipped, so that e.g.
+// resource-fit can be used while only some workers report their resources.
+// If a filter eliminates every worker, no worker is chosen, and the step waits
+// until one is left after filtering rather than failing.
+func NewChainedPlacementStrategy(filters ...ContainerPlacementFilter) ContainerPlacementStrategy {
+	return &ChainedPlacementStrategy{
+		rand:    rand.New(rand.NewSource(time.Now().UnixNano())),
+		filters: filters,
+	}
+}
+
+func (strategy *ChainedPlacementStrategy) Choose(logger lager.Logger, workers []Worker, spec ContainerSpec) (Worker, error) {
+	var steps []atc.PlacementStep
+
+	candidates := workers
+	for _, filter := range strategy.filters {
+		remaining, err := filter.Filter(logger, candidates, spec)
+		if err != nil {
+			logger.Info("skipping-placement-strategy", lager.Data{
+				"strategy": filter.Name(),
+				"error":    err.Error(),
+			})
+
+			steps = append(steps, atc.PlacementStep{
+				Strategy: filter.Name(),
+				Skipped:  true,
+			})
+
+			continue
+		}
+
+		eliminated := eliminatedWorkers(candidates, remaining)
+
+		logger.Debug("filtered-workers", lager.Data{
+			"strategy":   filter.Name(),
+			"eliminated": eliminated,
+			"remaining":  len(remaining),
+		})
+
+		steps = append(steps, atc.PlacementStep{
+			Strategy:   filter.Name(),
+			Eliminated: eliminated,
+		})
+
+		candidates = remaining
+		if len(candidates) == 0 {
+			return nil, nil
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	return placedWorker{
+		Worker: candidates[strategy.rand.Intn(len(candidates))],
+		steps:  steps,
+	}, nil
+}
+
+func (strategy *ChainedPlacementStrategy) ModifiesActiveTasks() bool {
+	for _, filter := range strategy.filters {
+		if filter.ModifiesActiveTasks() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func eliminatedWorkers(candidates []Worker, remaining []Worker) []string {
+	kept := map[string]bool{}
+	for _, w := range remaining {
+		kept[w.Name()] = true
+	}
+
+	var eliminated []string
+	for _, w := range candidates {
+		if !kept[w.Name()] {
+			eliminated = append(eliminated, w.Name())
+		}
+	}
+
+	return eliminated
+}
+
+// placedWorker is a worker chosen by a ChainedPlacementStrategy, which
+// remembers how it was chosen so that it can be shown to the user.
+type placedWorker struct {
+	Worker
+
+	steps []atc.PlacementStep
+}
+
+// PlacementSteps returns the steps which a ChainedPlacementStrategy went
+// through to choose the worker, or nil if the worker was chosen otherwise.
+func PlacementSteps(worker Worker) []atc.PlacementStep {
+	placed, ok := worker.(placedWorker)
+	if !ok {
+		return nil
+	}
+
+	return placed.steps
+}
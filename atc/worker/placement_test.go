@@ -1,17 +1,23 @@
 package worker_test
 
 import (
+	"errors"
+
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 )
 
 //go:generate counterfeiter . ContainerPlacementStrategy
+//go:generate counterfeiter . ContainerPlacementFilter
 
 var (
 	strategy ContainerPlacementStrategy
@@ -450,3 +456,374 @@ var _ = Describe("LimitActiveTasksPlacementStrategy", func() {
 		})
 	})
 })
+
+var _ = Describe("ResourceFitPlacementStrategy", func() {
+	Describe("Choose", func() {
+		var compatibleWorker1 *workerfakes.FakeWorker
+		var compatibleWorker2 *workerfakes.FakeWorker
+		var compatibleWorker3 *workerfakes.FakeWorker
+		var fakeWorkerFactory *dbfakes.FakeWorkerFactory
+		var allocated map[string]db.WorkerResources
+
+		BeforeEach(func() {
+			logger = lagertest.NewTestLogger("resource-fit-placement-test")
+
+			allocated = map[string]db.WorkerResources{}
+			fakeWorkerFactory = new(dbfakes.FakeWorkerFactory)
+			fakeWorkerFactory.AllocatedResourcesPerWorkerStub = func() (map[string]db.WorkerResources, error) {
+				return allocated, nil
+			}
+
+			strategy = NewResourceFitPlacementStrategy(fakeWorkerFactory)
+
+			compatibleWorker1 = new(workerfakes.FakeWorker)
+			compatibleWorker1.NameReturns("worker1")
+			compatibleWorker2 = new(workerfakes.FakeWorker)
+			compatibleWorker2.NameReturns("worker2")
+			compatibleWorker3 = new(workerfakes.FakeWorker)
+			compatibleWorker3.NameReturns("worker3")
+
+			cpu := uint64(1024)
+			memory := uint64(1024)
+
+			spec = ContainerSpec{
+				ImageSpec: ImageSpec{ResourceType: "some-type"},
+
+				Type: "task",
+
+				TeamID: 4567,
+
+				Inputs: []InputSource{},
+
+				Limits: ContainerLimits{
+					CPU:    &cpu,
+					Memory: &memory,
+				},
+			}
+
+			workers = []Worker{compatibleWorker1, compatibleWorker2, compatibleWorker3}
+		})
+
+		JustBeforeEach(func() {
+			chosenWorker, chooseErr = strategy.Choose(
+				logger,
+				workers,
+				spec,
+			)
+		})
+
+		Context("when the workers report their resources", func() {
+			BeforeEach(func() {
+				compatibleWorker1.AllocatableResourcesReturns(db.WorkerResources{CPU: 4096, Memory: 4096})
+				allocated["worker1"] = db.WorkerResources{CPU: 1024, Memory: 1024}
+
+				compatibleWorker2.AllocatableResourcesReturns(db.WorkerResources{CPU: 2048, Memory: 2048})
+				allocated["worker2"] = db.WorkerResources{CPU: 512, Memory: 512}
+
+				compatibleWorker3.AllocatableResourcesReturns(db.WorkerResources{CPU: 8192, Memory: 8192})
+				allocated["worker3"] = db.WorkerResources{CPU: 0, Memory: 0}
+			})
+
+			It("picks the worker the task fits most tightly", func() {
+				Expect(chooseErr).ToNot(HaveOccurred())
+				Expect(chosenWorker).To(Equal(compatibleWorker2))
+			})
+
+			It("filters out all but the workers the task fits most tightly", func() {
+				compatibleWorker3.AllocatableResourcesReturns(db.WorkerResources{CPU: 2048, Memory: 2048})
+				allocated["worker3"] = db.WorkerResources{CPU: 512, Memory: 512}
+
+				fitting, err := strategy.(ContainerPlacementFilter).Filter(logger, workers, spec)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(fitting).To(Equal([]Worker{compatibleWorker2, compatibleWorker3}))
+			})
+
+			Context("when the task does not fit on some of the workers", func() {
+				BeforeEach(func() {
+					allocated["worker2"] = db.WorkerResources{CPU: 512, Memory: 1536}
+				})
+
+				It("picks the tightest worker the task fits on", func() {
+					Expect(chooseErr).ToNot(HaveOccurred())
+					Expect(chosenWorker).To(Equal(compatibleWorker1))
+				})
+			})
+
+			Context("when the task does not fit on any worker", func() {
+				BeforeEach(func() {
+					cpu := uint64(16384)
+					spec.Limits.CPU = &cpu
+				})
+
+				It("picks no worker", func() {
+					Expect(chooseErr).ToNot(HaveOccurred())
+					Expect(chosenWorker).To(BeNil())
+				})
+
+				Context("when the container is not of type 'task'", func() {
+					BeforeEach(func() {
+						spec.Type = ""
+						allocated["worker2"] = db.WorkerResources{CPU: 1536, Memory: 1536}
+					})
+
+					It("picks the fullest worker", func() {
+						Expect(chooseErr).ToNot(HaveOccurred())
+						Expect(chosenWorker).To(Equal(compatibleWorker2))
+					})
+				})
+			})
+
+			Context("when a worker only reports some of its resources", func() {
+				BeforeEach(func() {
+					compatibleWorker3.AllocatableResourcesReturns(db.WorkerResources{CPU: 1024})
+				})
+
+				It("only fits the task against the reported resources", func() {
+					Expect(chooseErr).ToNot(HaveOccurred())
+					Expect(chosenWorker).To(Equal(compatibleWorker3))
+				})
+			})
+
+			Context("when a worker does not report its resources", func() {
+				BeforeEach(func() {
+					compatibleWorker2.AllocatableResourcesReturns(db.WorkerResources{})
+				})
+
+				It("skips the worker", func() {
+					Expect(chooseErr).ToNot(HaveOccurred())
+					Expect(chosenWorker).To(Equal(compatibleWorker1))
+				})
+			})
+
+			It("fetches the allocated resources of all of the workers at once", func() {
+				Expect(fakeWorkerFactory.AllocatedResourcesPerWorkerCallCount()).To(Equal(1))
+			})
+
+			Context("when the allocated resources cannot be determined", func() {
+				disaster := errors.New("disaster")
+
+				BeforeEach(func() {
+					fakeWorkerFactory.AllocatedResourcesPerWorkerReturns(nil, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(chooseErr).To(Equal(disaster))
+					Expect(chosenWorker).To(BeNil())
+				})
+			})
+		})
+
+		Context("when no worker reports its resources", func() {
+			It("returns an error", func() {
+				Expect(chooseErr).To(Equal(ErrNoWorkerResources))
+				Expect(chosenWorker).To(BeNil())
+			})
+		})
+	})
+})
+
+var _ = Describe("ChainedPlacementStrategy", func() {
+	Describe("Choose", func() {
+		var fakeFilter1 *workerfakes.FakeContainerPlacementFilter
+		var fakeFilter2 *workerfakes.FakeContainerPlacementFilter
+		var compatibleWorker1 *workerfakes.FakeWorker
+		var compatibleWorker2 *workerfakes.FakeWorker
+		var compatibleWorker3 *workerfakes.FakeWorker
+
+		BeforeEach(func() {
+			logger = lagertest.NewTestLogger("chained-placement-test")
+
+			fakeFilter1 = new(workerfakes.FakeContainerPlacementFilter)
+			fakeFilter1.NameReturns("some-strategy")
+			fakeFilter2 = new(workerfakes.FakeContainerPlacementFilter)
+			fakeFilter2.NameReturns("other-strategy")
+			strategy = NewChainedPlacementStrategy(fakeFilter1, fakeFilter2)
+
+			compatibleWorker1 = new(workerfakes.FakeWorker)
+			compatibleWorker1.NameReturns("worker1")
+			compatibleWorker2 = new(workerfakes.FakeWorker)
+			compatibleWorker2.NameReturns("worker2")
+			compatibleWorker3 = new(workerfakes.FakeWorker)
+			compatibleWorker3.NameReturns("worker3")
+			workers = []Worker{compatibleWorker1, compatibleWorker2, compatibleWorker3}
+
+			spec = ContainerSpec{
+				ImageSpec: ImageSpec{ResourceType: "some-type"},
+
+				TeamID: 4567,
+			}
+
+			fakeFilter1.FilterReturns([]Worker{compatibleWorker1, compatibleWorker2}, nil)
+			fakeFilter2.FilterReturns([]Worker{compatibleWorker2}, nil)
+		})
+
+		JustBeforeEach(func() {
+			chosenWorker, chooseErr = strategy.Choose(
+				logger,
+				workers,
+				spec,
+			)
+		})
+
+		It("passes the workers through each filter in turn", func() {
+			_, filtered, filteredFor := fakeFilter1.FilterArgsForCall(0)
+			Expect(filtered).To(Equal(workers))
+			Expect(filteredFor).To(Equal(spec))
+
+			_, filtered, filteredFor = fakeFilter2.FilterArgsForCall(0)
+			Expect(filtered).To(Equal([]Worker{compatibleWorker1, compatibleWorker2}))
+			Expect(filteredFor).To(Equal(spec))
+		})
+
+		It("picks one of the remaining workers", func() {
+			Expect(chooseErr).ToNot(HaveOccurred())
+			Expect(chosenWorker.Name()).To(Equal("worker2"))
+		})
+
+		It("records which workers each strategy eliminated", func() {
+			Expect(PlacementSteps(chosenWorker)).To(Equal([]atc.PlacementStep{
+				{Strategy: "some-strategy", Eliminated: []string{"worker3"}},
+				{Strategy: "other-strategy", Eliminated: []string{"worker1"}},
+			}))
+		})
+
+		It("logs which workers each strategy eliminated", func() {
+			Expect(logger).To(gbytes.Say(`"eliminated":\["worker3"\],"remaining":2,"strategy":"some-strategy"`))
+			Expect(logger).To(gbytes.Say(`"eliminated":\["worker1"\],"remaining":1,"strategy":"other-strategy"`))
+		})
+
+		Context("when a filter eliminates every worker", func() {
+			BeforeEach(func() {
+				fakeFilter1.FilterReturns(nil, nil)
+			})
+
+			It("picks no worker", func() {
+				Expect(chooseErr).ToNot(HaveOccurred())
+				Expect(chosenWorker).To(BeNil())
+				Expect(fakeFilter2.FilterCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when a filter fails", func() {
+			BeforeEach(func() {
+				fakeFilter1.FilterReturns(nil, ErrNoWorkerResources)
+			})
+
+			It("skips the filter", func() {
+				_, filtered, _ := fakeFilter2.FilterArgsForCall(0)
+				Expect(filtered).To(Equal(workers))
+
+				Expect(chooseErr).ToNot(HaveOccurred())
+				Expect(chosenWorker.Name()).To(Equal("worker2"))
+			})
+
+			It("records that the strategy was skipped", func() {
+				Expect(PlacementSteps(chosenWorker)).To(Equal([]atc.PlacementStep{
+					{Strategy: "some-strategy", Skipped: true},
+					{Strategy: "other-strategy", Eliminated: []string{"worker1", "worker3"}},
+				}))
+			})
+		})
+
+		Describe("ModifiesActiveTasks", func() {
+			It("is false when no strategy modifies active tasks", func() {
+				Expect(strategy.ModifiesActiveTasks()).To(BeFalse())
+			})
+
+			It("is true when any strategy modifies active tasks", func() {
+				fakeFilter2.ModifiesActiveTasksReturns(true)
+				Expect(strategy.ModifiesActiveTasks()).To(BeTrue())
+			})
+		})
+	})
+
+	Context("when chaining the built-in strategies", func() {
+		var compatibleWorker1 *workerfakes.FakeWorker
+		var compatibleWorker2 *workerfakes.FakeWorker
+		var compatibleWorker3 *workerfakes.FakeWorker
+
+		BeforeEach(func() {
+			logger = lagertest.NewTestLogger("chained-placement-test")
+			strategy = NewChainedPlacementStrategy(
+				NewLimitActiveTasksPlacementStrategy(1),
+				NewVolumeLocalityPlacementStrategy(),
+				NewFewestBuildContainersPlacementStrategy(),
+			)
+
+			compatibleWorker1 = new(workerfakes.FakeWorker)
+			compatibleWorker1.NameReturns("worker1")
+			compatibleWorker1.ActiveTasksReturns(1, nil)
+			compatibleWorker1.BuildContainersReturns(1)
+
+			compatibleWorker2 = new(workerfakes.FakeWorker)
+			compatibleWorker2.NameReturns("worker2")
+			compatibleWorker2.BuildContainersReturns(5)
+
+			compatibleWorker3 = new(workerfakes.FakeWorker)
+			compatibleWorker3.NameReturns("worker3")
+			compatibleWorker3.BuildContainersReturns(2)
+
+			workers = []Worker{compatibleWorker1, compatibleWorker2, compatibleWorker3}
+
+			spec = ContainerSpec{
+				ImageSpec: ImageSpec{ResourceType: "some-type"},
+
+				Type: "task",
+
+				TeamID: 4567,
+			}
+		})
+
+		It("excludes busy workers and breaks the tie by build containers", func() {
+			chosenWorker, chooseErr = strategy.Choose(logger, workers, spec)
+			Expect(chooseErr).ToNot(HaveOccurred())
+			Expect(chosenWorker.Name()).To(Equal("worker3"))
+
+			Expect(PlacementSteps(chosenWorker)).To(Equal([]atc.PlacementStep{
+				{Strategy: "limit-active-tasks", Eliminated: []string{"worker1"}},
+				{Strategy: "volume-locality"},
+				{Strategy: "fewest-build-containers", Eliminated: []string{"worker2"}},
+			}))
+		})
+
+		It("modifies active tasks", func() {
+			Expect(strategy.ModifiesActiveTasks()).To(BeTrue())
+		})
+
+		Context("when the chain ends with resource-fit", func() {
+			BeforeEach(func() {
+				strategy = NewChainedPlacementStrategy(
+					NewVolumeLocalityPlacementStrategy(),
+					NewResourceFitPlacementStrategy(new(dbfakes.FakeWorkerFactory)),
+				)
+
+				cpu := uint64(1024)
+				memory := uint64(1024)
+				spec.Limits = ContainerLimits{CPU: &cpu, Memory: &memory}
+
+				compatibleWorker1.AllocatableResourcesReturns(db.WorkerResources{CPU: 4096, Memory: 4096})
+				compatibleWorker2.AllocatableResourcesReturns(db.WorkerResources{CPU: 2048, Memory: 2048})
+				compatibleWorker3.AllocatableResourcesReturns(db.WorkerResources{CPU: 8192, Memory: 8192})
+			})
+
+			It("picks the worker the task fits most tightly", func() {
+				for i := 0; i < 10; i++ {
+					chosenWorker, chooseErr = strategy.Choose(logger, workers, spec)
+					Expect(chooseErr).ToNot(HaveOccurred())
+					Expect(chosenWorker.Name()).To(Equal("worker2"))
+				}
+
+				Expect(PlacementSteps(chosenWorker)).To(Equal([]atc.PlacementStep{
+					{Strategy: "volume-locality"},
+					{Strategy: "resource-fit", Eliminated: []string{"worker1", "worker3"}},
+				}))
+			})
+		})
+	})
+})
+
+var _ = Describe("PlacementSteps", func() {
+	It("returns nothing for workers which were not chosen by a chain", func() {
+		Expect(PlacementSteps(new(workerfakes.FakeWorker))).To(BeNil())
+	})
+})
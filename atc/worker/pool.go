@@ -39,6 +39,11 @@ type WorkerProvider interface {
 		savedWorker db.Worker,
 		numBuildWorkers int,
 	) Worker
+
+	TeamQuotaUsage(
+		logger lager.Logger,
+		teamID int,
+	) (db.TeamQuotaUsage, error)
 }
 
 var (
@@ -54,6 +59,23 @@ func (err NoCompatibleWorkersError) Error() string {
 	return fmt.Sprintf("no workers satisfying: %s", err.Spec.Description())
 }
 
+type TeamContainerQuotaExceededError struct {
+	MaxActiveContainers int
+}
+
+func (err TeamContainerQuotaExceededError) Error() string {
+	return fmt.Sprintf("team has reached its quota of %d active containers", err.MaxActiveContainers)
+}
+
+type TeamTaskLimitExceededError struct {
+	Limit string
+	Max   uint64
+}
+
+func (err TeamTaskLimitExceededError) Error() string {
+	return fmt.Sprintf("task %s limit must be set to at most %d by team quota", err.Limit, err.Max)
+}
+
 //go:generate counterfeiter . Pool
 
 type Pool interface {
@@ -186,6 +208,13 @@ dance:
 	}
 
 	if worker == nil {
+		if db.IsBuildStepContainerOwner(owner) {
+			err = pool.checkTeamQuotas(logger, containerSpec)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		worker, err = strategy.Choose(logger, compatibleWorkers, containerSpec)
 		if err != nil {
 			return nil, err
@@ -195,6 +224,46 @@ dance:
 	return worker, nil
 }
 
+// checkTeamQuotas returns an error if creating the build step's container
+// would exceed one of the quotas of the team it is for. The task limits only
+// apply to task containers.
+func (pool *pool) checkTeamQuotas(logger lager.Logger, containerSpec ContainerSpec) error {
+	usage, err := pool.provider.TeamQuotaUsage(logger, containerSpec.TeamID)
+	if err != nil {
+		return err
+	}
+
+	quotas := usage.Quotas
+
+	if containerSpec.Type == db.ContainerTypeTask {
+		if quotas.MaxTaskCPU != 0 && !withinQuota(containerSpec.Limits.CPU, quotas.MaxTaskCPU) {
+			return TeamTaskLimitExceededError{Limit: "cpu", Max: quotas.MaxTaskCPU}
+		}
+
+		if quotas.MaxTaskMemory != 0 && !withinQuota(containerSpec.Limits.Memory, quotas.MaxTaskMemory) {
+			return TeamTaskLimitExceededError{Limit: "memory", Max: quotas.MaxTaskMemory}
+		}
+	}
+
+	if quotas.MaxActiveContainers != 0 && usage.ActiveContainers >= quotas.MaxActiveContainers {
+		logger.Debug("team-container-quota-exceeded", lager.Data{
+			"team-id":           containerSpec.TeamID,
+			"active-containers": usage.ActiveContainers,
+		})
+
+		return TeamContainerQuotaExceededError{MaxActiveContainers: quotas.MaxActiveContainers}
+	}
+
+	return nil
+}
+
+// an unset or zero limit means the container is unlimited, so it can never
+// be within a quota. Tasks get their unset limits defaulted to the quota
+// before being placed, so this only happens if the quota was set meanwhile.
+func withinQuota(limit *uint64, max uint64) bool {
+	return limit != nil && *limit != 0 && *limit <= max
+}
+
 func (pool *pool) FindOrChooseWorker(
 	logger lager.Logger,
 	workerSpec WorkerSpec,
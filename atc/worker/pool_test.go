@@ -8,6 +8,7 @@ import (
 	"errors"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
@@ -34,7 +35,7 @@ var _ = Describe("Pool", func() {
 			spec          ContainerSpec
 			workerSpec    WorkerSpec
 			resourceTypes atc.VersionedResourceTypes
-			fakeOwner     *dbfakes.FakeContainerOwner
+			owner         db.ContainerOwner
 
 			chosenWorker Worker
 			chooseErr    error
@@ -47,7 +48,7 @@ var _ = Describe("Pool", func() {
 		BeforeEach(func() {
 			fakeStrategy = new(workerfakes.FakeContainerPlacementStrategy)
 
-			fakeOwner = new(dbfakes.FakeContainerOwner)
+			owner = new(dbfakes.FakeContainerOwner)
 
 			fakeInput1 := new(workerfakes.FakeInputSource)
 			fakeInput1AS := new(workerfakes.FakeArtifactSource)
@@ -113,7 +114,7 @@ var _ = Describe("Pool", func() {
 			chosenWorker, chooseErr = pool.FindOrChooseWorkerForContainer(
 				context.TODO(),
 				logger,
-				fakeOwner,
+				owner,
 				spec,
 				workerSpec,
 				fakeStrategy,
@@ -413,6 +414,139 @@ var _ = Describe("Pool", func() {
 					})
 				})
 
+				Context("when choosing a worker for a step that isn't part of a build", func() {
+					BeforeEach(func() {
+						spec.Type = db.ContainerTypeCheck
+
+						fakeStrategy.ChooseReturns(compatibleWorker, nil)
+					})
+
+					It("does not look up the quota usage of the team", func() {
+						Expect(chooseErr).ToNot(HaveOccurred())
+						Expect(fakeProvider.TeamQuotaUsageCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when choosing a worker for a get step", func() {
+					BeforeEach(func() {
+						owner = db.NewBuildStepContainerOwner(1, "some-plan", 4567)
+						spec.Type = db.ContainerTypeGet
+
+						fakeStrategy.ChooseReturns(compatibleWorker, nil)
+					})
+
+					Context("when the team has reached its container quota", func() {
+						BeforeEach(func() {
+							fakeProvider.TeamQuotaUsageReturns(db.TeamQuotaUsage{
+								Quotas:           atc.TeamQuotas{MaxActiveContainers: 3},
+								ActiveContainers: 3,
+							}, nil)
+						})
+
+						It("returns a TeamContainerQuotaExceededError", func() {
+							Expect(chooseErr).To(Equal(TeamContainerQuotaExceededError{MaxActiveContainers: 3}))
+							Expect(fakeStrategy.ChooseCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the team has a task cpu quota", func() {
+						BeforeEach(func() {
+							fakeProvider.TeamQuotaUsageReturns(db.TeamQuotaUsage{
+								Quotas: atc.TeamQuotas{MaxTaskCPU: 256},
+							}, nil)
+						})
+
+						It("chooses a worker", func() {
+							Expect(chooseErr).ToNot(HaveOccurred())
+							Expect(chosenWorker.Name()).To(Equal(compatibleWorker.Name()))
+						})
+					})
+				})
+
+				Context("when choosing a worker for a task", func() {
+					var cpu, memory uint64
+
+					BeforeEach(func() {
+						cpu, memory = 512, 1024
+
+						owner = db.NewBuildStepContainerOwner(1, "some-plan", 4567)
+						spec.Type = db.ContainerTypeTask
+						spec.Limits = ContainerLimits{CPU: &cpu, Memory: &memory}
+
+						fakeStrategy.ChooseReturns(compatibleWorker, nil)
+					})
+
+					It("looks up the quota usage of the team", func() {
+						Expect(fakeProvider.TeamQuotaUsageCallCount()).To(Equal(1))
+						_, teamID := fakeProvider.TeamQuotaUsageArgsForCall(0)
+						Expect(teamID).To(Equal(4567))
+					})
+
+					Context("when the team has room for another container", func() {
+						BeforeEach(func() {
+							fakeProvider.TeamQuotaUsageReturns(db.TeamQuotaUsage{
+								Quotas:           atc.TeamQuotas{MaxActiveContainers: 3, MaxTaskCPU: 512, MaxTaskMemory: 1024},
+								ActiveContainers: 2,
+							}, nil)
+						})
+
+						It("chooses a worker", func() {
+							Expect(chooseErr).ToNot(HaveOccurred())
+							Expect(chosenWorker.Name()).To(Equal(compatibleWorker.Name()))
+						})
+					})
+
+					Context("when the team has reached its container quota", func() {
+						BeforeEach(func() {
+							fakeProvider.TeamQuotaUsageReturns(db.TeamQuotaUsage{
+								Quotas:           atc.TeamQuotas{MaxActiveContainers: 3},
+								ActiveContainers: 3,
+							}, nil)
+						})
+
+						It("returns a TeamContainerQuotaExceededError", func() {
+							Expect(chooseErr).To(Equal(TeamContainerQuotaExceededError{MaxActiveContainers: 3}))
+							Expect(fakeStrategy.ChooseCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the task asks for more cpu than the team allows", func() {
+						BeforeEach(func() {
+							fakeProvider.TeamQuotaUsageReturns(db.TeamQuotaUsage{
+								Quotas: atc.TeamQuotas{MaxTaskCPU: 256},
+							}, nil)
+						})
+
+						It("returns a TeamTaskLimitExceededError", func() {
+							Expect(chooseErr).To(Equal(TeamTaskLimitExceededError{Limit: "cpu", Max: 256}))
+						})
+					})
+
+					Context("when the task has no memory limit but the team has a memory quota", func() {
+						BeforeEach(func() {
+							spec.Limits.Memory = nil
+
+							fakeProvider.TeamQuotaUsageReturns(db.TeamQuotaUsage{
+								Quotas: atc.TeamQuotas{MaxTaskMemory: 2048},
+							}, nil)
+						})
+
+						It("returns a TeamTaskLimitExceededError", func() {
+							Expect(chooseErr).To(Equal(TeamTaskLimitExceededError{Limit: "memory", Max: 2048}))
+						})
+					})
+
+					Context("when looking up the quota usage fails", func() {
+						BeforeEach(func() {
+							fakeProvider.TeamQuotaUsageReturns(db.TeamQuotaUsage{}, errors.New("disaster"))
+						})
+
+						It("returns the error", func() {
+							Expect(chooseErr).To(MatchError("disaster"))
+						})
+					})
+				})
+
 				Context("when strategy errors", func() {
 					var (
 						strategyError error
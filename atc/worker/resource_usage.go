@@ -0,0 +1,103 @@
+package worker
+
+import (
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+)
+
+const resourceUsageSamplingInterval = 10 * time.Second
+
+// memoryPeakPropertyName is the property through which the containerd runtime
+// reports the peak memory usage of a container, as garden metrics have no
+// room for it.
+const memoryPeakPropertyName = "garden.memory-peak"
+
+// resourceUsageTracker periodically samples the metrics of a container while
+// a process runs in it, keeping track of the resources it consumed.
+type resourceUsageTracker struct {
+	logger    lager.Logger
+	container Container
+
+	usage atc.ResourceUsage
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func trackResourceUsage(logger lager.Logger, container Container) *resourceUsageTracker {
+	tracker := &resourceUsageTracker{
+		logger:    logger.Session("track-resource-usage"),
+		container: container,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+
+	go tracker.run()
+
+	return tracker
+}
+
+func (tracker *resourceUsageTracker) run() {
+	defer close(tracker.done)
+
+	ticker := time.NewTicker(resourceUsageSamplingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			tracker.sample()
+		case <-tracker.stop:
+			return
+		}
+	}
+}
+
+// Finish stops the periodic sampling and returns the resources consumed by
+// the container, including a last sample taken once the process has exited.
+func (tracker *resourceUsageTracker) Finish() atc.ResourceUsage {
+	close(tracker.stop)
+	<-tracker.done
+
+	tracker.sample()
+
+	info, err := tracker.container.Info()
+	if err != nil {
+		tracker.logger.Debug("failed-to-get-container-info", lager.Data{"error": err.Error()})
+		return tracker.usage
+	}
+
+	if peak, ok := info.Properties[memoryPeakPropertyName]; ok {
+		memoryPeak, err := strconv.ParseUint(peak, 10, 64)
+		if err == nil && memoryPeak > tracker.usage.MemoryPeak {
+			tracker.usage.MemoryPeak = memoryPeak
+		}
+	}
+
+	return tracker.usage
+}
+
+func (tracker *resourceUsageTracker) sample() {
+	metrics, err := tracker.container.Metrics()
+	if err != nil {
+		tracker.logger.Debug("failed-to-sample-metrics", lager.Data{"error": err.Error()})
+		return
+	}
+
+	// cpu usage and disk usage are cumulative, but samples taken after the
+	// process exited may come back empty
+	if metrics.CPUStat.Usage > tracker.usage.CPUTime {
+		tracker.usage.CPUTime = metrics.CPUStat.Usage
+	}
+
+	if metrics.MemoryStat.TotalUsageTowardLimit > tracker.usage.MemoryPeak {
+		tracker.usage.MemoryPeak = metrics.MemoryStat.TotalUsageTowardLimit
+	}
+
+	if metrics.DiskStat.ExclusiveBytesUsed > tracker.usage.RootfsDiskUsage {
+		tracker.usage.RootfsDiskUsage = metrics.DiskStat.ExclusiveBytesUsed
+	}
+}
@@ -0,0 +1,103 @@
+package worker
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// taskWaitQueue orders the tasks which are waiting for a worker to become
+// available so that, once one does, it is handed to the task of the build
+// with the highest priority rather than to whichever poll happens to come
+// first. Tasks compete for a worker regardless of their team, which is why
+// build priorities are capped by the maximum build priority admins set on
+// each team. A task only leaves a worker to the tasks which could be placed
+// on it, so a task waiting on a tag no worker has, on a team's own workers,
+// or on a privileged worker does not hold up the tasks placed on other
+// workers.
+//
+// The queue is kept in memory, so it only orders the tasks waiting on the
+// same ATC: tasks waiting on different web nodes still race for the workers
+// that become available.
+type taskWaitQueue struct {
+	lock    sync.Mutex
+	serial  uint64
+	waiting map[*taskWaiter]struct{}
+}
+
+type taskWaiter struct {
+	spec     WorkerSpec
+	priority int
+	serial   uint64
+
+	// parked waiters can't be placed for now (e.g. their team is over its
+	// container quota), so they don't hold up the other waiters.
+	parked bool
+}
+
+func newTaskWaitQueue() *taskWaitQueue {
+	return &taskWaitQueue{
+		waiting: map[*taskWaiter]struct{}{},
+	}
+}
+
+func (queue *taskWaitQueue) Enqueue(workerSpec WorkerSpec) *taskWaiter {
+	queue.lock.Lock()
+	defer queue.lock.Unlock()
+
+	queue.serial++
+
+	waiter := &taskWaiter{
+		spec:     workerSpec,
+		priority: workerSpec.Priority,
+		serial:   queue.serial,
+	}
+
+	queue.waiting[waiter] = struct{}{}
+
+	return waiter
+}
+
+func (queue *taskWaitQueue) Dequeue(waiter *taskWaiter) {
+	queue.lock.Lock()
+	defer queue.lock.Unlock()
+
+	delete(queue.waiting, waiter)
+}
+
+// SetParked sets whether the waiter is parked. Parked waiters are skipped
+// when looking for the next task to place.
+func (queue *taskWaitQueue) SetParked(waiter *taskWaiter, parked bool) {
+	queue.lock.Lock()
+	defer queue.lock.Unlock()
+
+	waiter.parked = parked
+}
+
+// IsNext returns whether the waiter may take the worker: no other unparked
+// task which the worker could run has a higher priority, or the same
+// priority and has been waiting for longer.
+func (queue *taskWaitQueue) IsNext(logger lager.Logger, waiter *taskWaiter, worker Worker) bool {
+	queue.lock.Lock()
+	defer queue.lock.Unlock()
+
+	for other := range queue.waiting {
+		if other == waiter || other.parked {
+			continue
+		}
+
+		if other.priority < waiter.priority {
+			continue
+		}
+
+		if other.priority == waiter.priority && other.serial > waiter.serial {
+			continue
+		}
+
+		if worker.Satisfies(logger, other.spec) {
+			return false
+		}
+	}
+
+	return true
+}
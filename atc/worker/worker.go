@@ -30,6 +30,7 @@ import (
 )
 
 const userPropertyName = "user"
+const securityProfilePropertyName = "security-profile"
 
 var ResourceConfigCheckSessionExpiredError = errors.New("no db container was found for owner")
 
@@ -84,6 +85,8 @@ type Worker interface {
 	ActiveTasks() (int, error)
 	IncreaseActiveTasks() error
 	DecreaseActiveTasks() error
+
+	AllocatableResources() db.WorkerResources
 }
 
 type gardenWorker struct {
@@ -313,8 +316,20 @@ func (worker *gardenWorker) FindOrCreateContainer(
 	} else if createdContainer != nil {
 		containerHandle = createdContainer.Handle()
 	} else {
+		if containerSpec.Limits.CPU != nil {
+			metadata.CPULimit = *containerSpec.Limits.CPU
+		}
+		if containerSpec.Limits.Memory != nil {
+			metadata.MemoryLimit = *containerSpec.Limits.Memory
+		}
+
+		createContainer := worker.dbWorker.CreateContainer
+		if containerSpec.WithinWorkerResources {
+			createContainer = worker.dbWorker.CreateContainerWithinResources
+		}
+
 		logger.Debug("creating-container-in-db")
-		creatingContainer, err = worker.dbWorker.CreateContainer(
+		creatingContainer, err = createContainer(
 			owner,
 			metadata,
 		)
@@ -324,6 +339,10 @@ func (worker *gardenWorker) FindOrCreateContainer(
 				return nil, ResourceConfigCheckSessionExpiredError
 			}
 
+			if quotaErr, ok := err.(db.ContainerQuotaExceededError); ok {
+				return nil, TeamContainerQuotaExceededError{MaxActiveContainers: quotaErr.MaxActiveContainers}
+			}
+
 			return nil, err
 		}
 		logger.Debug("created-creating-container-in-db")
@@ -810,9 +829,27 @@ func (worker *gardenWorker) Satisfies(logger lager.Logger, spec WorkerSpec) bool
 		return false
 	}
 
+	if spec.privileged() && worker.dbWorker.Unprivileged() {
+		return false
+	}
+
+	if spec.SecurityProfile != "" && !worker.definesSecurityProfile(spec.SecurityProfile) {
+		return false
+	}
+
 	return true
 }
 
+func (worker *gardenWorker) definesSecurityProfile(name string) bool {
+	for _, profile := range worker.dbWorker.SecurityProfiles() {
+		if profile == name {
+			return true
+		}
+	}
+
+	return false
+}
+
 func determineUnderlyingTypeName(typeName string, resourceTypes atc.VersionedResourceTypes) string {
 	resourceTypesMap := make(map[string]atc.VersionedResourceType)
 	for _, resourceType := range resourceTypes {
@@ -837,6 +874,14 @@ func (worker *gardenWorker) Description() string {
 		messages = append(messages, fmt.Sprintf("tag '%s'", tag))
 	}
 
+	if worker.dbWorker.Unprivileged() {
+		messages = append(messages, "unprivileged")
+	}
+
+	for _, profile := range worker.dbWorker.SecurityProfiles() {
+		messages = append(messages, fmt.Sprintf("security profile '%s'", profile))
+	}
+
 	return strings.Join(messages, ", ")
 }
 
@@ -877,3 +922,6 @@ func (worker *gardenWorker) IncreaseActiveTasks() error {
 func (worker *gardenWorker) DecreaseActiveTasks() error {
 	return worker.dbWorker.DecreaseActiveTasks()
 }
+func (worker *gardenWorker) AllocatableResources() db.WorkerResources {
+	return worker.dbWorker.AllocatableResources()
+}
@@ -33,6 +33,10 @@ func (w workerHelper) createGardenContainer(
 		gardenProperties[userPropertyName] = fetchedImage.Metadata.User
 	}
 
+	if containerSpec.SecurityProfile != "" {
+		gardenProperties[securityProfilePropertyName] = containerSpec.SecurityProfile
+	}
+
 	env := append(fetchedImage.Metadata.Env, containerSpec.Env...)
 
 	if w.dbWorker.HTTPProxyURL() != "" {
@@ -712,6 +712,67 @@ var _ = Describe("Worker", func() {
 			})
 		})
 
+		Context("when the worker is unprivileged", func() {
+			BeforeEach(func() {
+				fakeDBWorker.UnprivilegedReturns(true)
+			})
+
+			It("returns true for unprivileged containers", func() {
+				Expect(satisfies).To(BeTrue())
+			})
+
+			Context("when the containers are privileged", func() {
+				BeforeEach(func() {
+					spec.Privileged = true
+				})
+
+				It("returns false", func() {
+					Expect(satisfies).To(BeFalse())
+				})
+			})
+
+			Context("when the custom resource type is privileged", func() {
+				BeforeEach(func() {
+					customTypes[0].Privileged = true
+					spec.ResourceType = "custom-type-b"
+				})
+
+				It("returns false", func() {
+					Expect(satisfies).To(BeFalse())
+				})
+			})
+		})
+
+		Context("when the containers select a security profile", func() {
+			BeforeEach(func() {
+				spec.SecurityProfile = "network-tools"
+			})
+
+			It("returns false when the worker does not define it", func() {
+				Expect(satisfies).To(BeFalse())
+			})
+
+			Context("when the worker defines it", func() {
+				BeforeEach(func() {
+					fakeDBWorker.SecurityProfilesReturns([]string{"default", "network-tools"})
+				})
+
+				It("returns true", func() {
+					Expect(satisfies).To(BeTrue())
+				})
+			})
+		})
+
+		Context("when the worker is privileged and the containers are privileged", func() {
+			BeforeEach(func() {
+				spec.Privileged = true
+			})
+
+			It("returns true", func() {
+				Expect(satisfies).To(BeTrue())
+			})
+		})
+
 		Context("when the resource type is supported by the worker", func() {
 			BeforeEach(func() {
 				spec.ResourceType = "some-resource"
@@ -1002,6 +1063,22 @@ var _ = Describe("Worker", func() {
 					}))
 				})
 
+				Context("when the container spec has a security profile", func() {
+					BeforeEach(func() {
+						containerSpec.SecurityProfile = "network-tools"
+					})
+
+					It("creates the container in garden with the security profile as a property", func() {
+						Expect(fakeGardenClient.CreateCallCount()).To(Equal(1))
+
+						actualSpec := fakeGardenClient.CreateArgsForCall(0)
+						Expect(actualSpec.Properties).To(Equal(garden.Properties{
+							"user":             "some-user",
+							"security-profile": "network-tools",
+						}))
+					})
+				})
+
 				Context("when the input and output destination paths overlap", func() {
 					var (
 						fakeRemoteInputUnderInput    *workerfakes.FakeInputSource
@@ -1537,6 +1614,18 @@ var _ = Describe("Worker", func() {
 				Expect(fakeDBWorker.CreateContainerCallCount()).To(Equal(1))
 			})
 
+			Context("when the container must be created within the worker's resources", func() {
+				BeforeEach(func() {
+					containerSpec.WithinWorkerResources = true
+					fakeDBWorker.CreateContainerWithinResourcesReturns(fakeCreatingContainer, nil)
+				})
+
+				It("creates the container in the db within the worker's resources", func() {
+					Expect(fakeDBWorker.CreateContainerCallCount()).To(BeZero())
+					Expect(fakeDBWorker.CreateContainerWithinResourcesCallCount()).To(Equal(1))
+				})
+			})
+
 			Context("having db container creation erroring", func() {
 				Context("with ContainerOwnerDisappearedError", func() {
 					BeforeEach(func() {
@@ -1549,6 +1638,16 @@ var _ = Describe("Worker", func() {
 					})
 				})
 
+				Context("with ContainerQuotaExceededError", func() {
+					BeforeEach(func() {
+						fakeDBWorker.CreateContainerReturns(nil, db.ContainerQuotaExceededError{MaxActiveContainers: 2})
+					})
+
+					It("fails w/ TeamContainerQuotaExceededError", func() {
+						Expect(findOrCreateErr).To(Equal(TeamContainerQuotaExceededError{MaxActiveContainers: 2}))
+					})
+				})
+
 				Context("with a non-specific error", func() {
 					BeforeEach(func() {
 						fakeDBWorker.CreateContainerReturns(nil, errors.New("err"))
@@ -1562,10 +1661,14 @@ var _ = Describe("Worker", func() {
 			})
 
 			Context("having db container creation succeeding", func() {
-				It("creates a creating container in database", func() {
+				It("creates a creating container in database with the container limits", func() {
+					expectedMetadata := containerMetadata
+					expectedMetadata.CPULimit = 1024
+					expectedMetadata.MemoryLimit = 1024
+
 					owner, metadata := fakeDBWorker.CreateContainerArgsForCall(0)
 					Expect(owner).To(Equal(fakeContainerOwner))
-					Expect(metadata).To(Equal(containerMetadata))
+					Expect(metadata).To(Equal(expectedMetadata))
 				})
 			})
 
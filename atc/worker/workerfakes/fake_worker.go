@@ -29,6 +29,16 @@ type FakeWorker struct {
 		result1 int
 		result2 error
 	}
+	AllocatableResourcesStub        func() db.WorkerResources
+	allocatableResourcesMutex       sync.RWMutex
+	allocatableResourcesArgsForCall []struct {
+	}
+	allocatableResourcesReturns struct {
+		result1 db.WorkerResources
+	}
+	allocatableResourcesReturnsOnCall map[int]struct {
+		result1 db.WorkerResources
+	}
 	BuildContainersStub        func() int
 	buildContainersMutex       sync.RWMutex
 	buildContainersArgsForCall []struct {
@@ -380,6 +390,58 @@ func (fake *FakeWorker) ActiveTasksReturnsOnCall(i int, result1 int, result2 err
 	}{result1, result2}
 }
 
+func (fake *FakeWorker) AllocatableResources() db.WorkerResources {
+	fake.allocatableResourcesMutex.Lock()
+	ret, specificReturn := fake.allocatableResourcesReturnsOnCall[len(fake.allocatableResourcesArgsForCall)]
+	fake.allocatableResourcesArgsForCall = append(fake.allocatableResourcesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("AllocatableResources", []interface{}{})
+	fake.allocatableResourcesMutex.Unlock()
+	if fake.AllocatableResourcesStub != nil {
+		return fake.AllocatableResourcesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.allocatableResourcesReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) AllocatableResourcesCallCount() int {
+	fake.allocatableResourcesMutex.RLock()
+	defer fake.allocatableResourcesMutex.RUnlock()
+	return len(fake.allocatableResourcesArgsForCall)
+}
+
+func (fake *FakeWorker) AllocatableResourcesCalls(stub func() db.WorkerResources) {
+	fake.allocatableResourcesMutex.Lock()
+	defer fake.allocatableResourcesMutex.Unlock()
+	fake.AllocatableResourcesStub = stub
+}
+
+func (fake *FakeWorker) AllocatableResourcesReturns(result1 db.WorkerResources) {
+	fake.allocatableResourcesMutex.Lock()
+	defer fake.allocatableResourcesMutex.Unlock()
+	fake.AllocatableResourcesStub = nil
+	fake.allocatableResourcesReturns = struct {
+		result1 db.WorkerResources
+	}{result1}
+}
+
+func (fake *FakeWorker) AllocatableResourcesReturnsOnCall(i int, result1 db.WorkerResources) {
+	fake.allocatableResourcesMutex.Lock()
+	defer fake.allocatableResourcesMutex.Unlock()
+	fake.AllocatableResourcesStub = nil
+	if fake.allocatableResourcesReturnsOnCall == nil {
+		fake.allocatableResourcesReturnsOnCall = make(map[int]struct {
+			result1 db.WorkerResources
+		})
+	}
+	fake.allocatableResourcesReturnsOnCall[i] = struct {
+		result1 db.WorkerResources
+	}{result1}
+}
+
 func (fake *FakeWorker) BuildContainers() int {
 	fake.buildContainersMutex.Lock()
 	ret, specificReturn := fake.buildContainersReturnsOnCall[len(fake.buildContainersArgsForCall)]
@@ -1694,6 +1756,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.activeTasksMutex.RLock()
 	defer fake.activeTasksMutex.RUnlock()
+	fake.allocatableResourcesMutex.RLock()
+	defer fake.allocatableResourcesMutex.RUnlock()
 	fake.buildContainersMutex.RLock()
 	defer fake.buildContainersMutex.RUnlock()
 	fake.certsVolumeMutex.RLock()
@@ -84,6 +84,20 @@ type FakeWorkerProvider struct {
 		result1 []worker.Worker
 		result2 error
 	}
+	TeamQuotaUsageStub        func(lager.Logger, int) (db.TeamQuotaUsage, error)
+	teamQuotaUsageMutex       sync.RWMutex
+	teamQuotaUsageArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 int
+	}
+	teamQuotaUsageReturns struct {
+		result1 db.TeamQuotaUsage
+		result2 error
+	}
+	teamQuotaUsageReturnsOnCall map[int]struct {
+		result1 db.TeamQuotaUsage
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -413,6 +427,70 @@ func (fake *FakeWorkerProvider) RunningWorkersReturnsOnCall(i int, result1 []wor
 	}{result1, result2}
 }
 
+func (fake *FakeWorkerProvider) TeamQuotaUsage(arg1 lager.Logger, arg2 int) (db.TeamQuotaUsage, error) {
+	fake.teamQuotaUsageMutex.Lock()
+	ret, specificReturn := fake.teamQuotaUsageReturnsOnCall[len(fake.teamQuotaUsageArgsForCall)]
+	fake.teamQuotaUsageArgsForCall = append(fake.teamQuotaUsageArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 int
+	}{arg1, arg2})
+	fake.recordInvocation("TeamQuotaUsage", []interface{}{arg1, arg2})
+	fake.teamQuotaUsageMutex.Unlock()
+	if fake.TeamQuotaUsageStub != nil {
+		return fake.TeamQuotaUsageStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.teamQuotaUsageReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorkerProvider) TeamQuotaUsageCallCount() int {
+	fake.teamQuotaUsageMutex.RLock()
+	defer fake.teamQuotaUsageMutex.RUnlock()
+	return len(fake.teamQuotaUsageArgsForCall)
+}
+
+func (fake *FakeWorkerProvider) TeamQuotaUsageCalls(stub func(lager.Logger, int) (db.TeamQuotaUsage, error)) {
+	fake.teamQuotaUsageMutex.Lock()
+	defer fake.teamQuotaUsageMutex.Unlock()
+	fake.TeamQuotaUsageStub = stub
+}
+
+func (fake *FakeWorkerProvider) TeamQuotaUsageArgsForCall(i int) (lager.Logger, int) {
+	fake.teamQuotaUsageMutex.RLock()
+	defer fake.teamQuotaUsageMutex.RUnlock()
+	argsForCall := fake.teamQuotaUsageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorkerProvider) TeamQuotaUsageReturns(result1 db.TeamQuotaUsage, result2 error) {
+	fake.teamQuotaUsageMutex.Lock()
+	defer fake.teamQuotaUsageMutex.Unlock()
+	fake.TeamQuotaUsageStub = nil
+	fake.teamQuotaUsageReturns = struct {
+		result1 db.TeamQuotaUsage
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorkerProvider) TeamQuotaUsageReturnsOnCall(i int, result1 db.TeamQuotaUsage, result2 error) {
+	fake.teamQuotaUsageMutex.Lock()
+	defer fake.teamQuotaUsageMutex.Unlock()
+	fake.TeamQuotaUsageStub = nil
+	if fake.teamQuotaUsageReturnsOnCall == nil {
+		fake.teamQuotaUsageReturnsOnCall = make(map[int]struct {
+			result1 db.TeamQuotaUsage
+			result2 error
+		})
+	}
+	fake.teamQuotaUsageReturnsOnCall[i] = struct {
+		result1 db.TeamQuotaUsage
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeWorkerProvider) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -426,6 +504,8 @@ func (fake *FakeWorkerProvider) Invocations() map[string][][]interface{} {
 	defer fake.newGardenWorkerMutex.RUnlock()
 	fake.runningWorkersMutex.RLock()
 	defer fake.runningWorkersMutex.RUnlock()
+	fake.teamQuotaUsageMutex.RLock()
+	defer fake.teamQuotaUsageMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
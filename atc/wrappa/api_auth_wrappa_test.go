@@ -127,7 +127,8 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.GetBuildPlan:        checksIfPrivateJob(inputHandlers[atc.GetBuildPlan]),
 
 				// resource belongs to authorized team
-				atc.AbortBuild: checkWritePermissionForBuild(inputHandlers[atc.AbortBuild]),
+				atc.AbortBuild:              checkWritePermissionForBuild(inputHandlers[atc.AbortBuild]),
+				atc.ListBuildSecretAccesses: checkWritePermissionForBuild(inputHandlers[atc.ListBuildSecretAccesses]),
 
 				// resource belongs to authorized team
 				atc.PruneWorker:              checkTeamAccessForWorker(inputHandlers[atc.PruneWorker]),
@@ -157,27 +158,29 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.GetResourceVersion:            openForPublicPipelineOrAuthorized(inputHandlers[atc.GetResourceVersion]),
 
 				// authenticated
-				atc.CreateBuild:     authenticated(inputHandlers[atc.CreateBuild]),
-				atc.GetContainer:    authenticated(inputHandlers[atc.GetContainer]),
-				atc.HijackContainer: authenticated(inputHandlers[atc.HijackContainer]),
-				atc.ListContainers:  authenticated(inputHandlers[atc.ListContainers]),
-				atc.ListVolumes:     authenticated(inputHandlers[atc.ListVolumes]),
-				atc.ListTeamBuilds:  authenticated(inputHandlers[atc.ListTeamBuilds]),
-				atc.ListWorkers:     authenticated(inputHandlers[atc.ListWorkers]),
-				atc.RegisterWorker:  authenticated(inputHandlers[atc.RegisterWorker]),
-				atc.HeartbeatWorker: authenticated(inputHandlers[atc.HeartbeatWorker]),
-				atc.DeleteWorker:    authenticated(inputHandlers[atc.DeleteWorker]),
-				atc.GetTeam:         authenticated(inputHandlers[atc.GetTeam]),
-				atc.SetTeam:         authenticated(inputHandlers[atc.SetTeam]),
-				atc.RenameTeam:      authenticated(inputHandlers[atc.RenameTeam]),
-				atc.DestroyTeam:     authenticated(inputHandlers[atc.DestroyTeam]),
-				atc.GetUser:         authenticated(inputHandlers[atc.GetUser]),
+				atc.CreateBuild:         authenticated(inputHandlers[atc.CreateBuild]),
+				atc.GetContainer:        authenticated(inputHandlers[atc.GetContainer]),
+				atc.HijackContainer:     authenticated(inputHandlers[atc.HijackContainer]),
+				atc.ListContainers:      authenticated(inputHandlers[atc.ListContainers]),
+				atc.ListVolumes:         authenticated(inputHandlers[atc.ListVolumes]),
+				atc.ListTeamBuilds:      authenticated(inputHandlers[atc.ListTeamBuilds]),
+				atc.ListPolicyDecisions: authenticated(inputHandlers[atc.ListPolicyDecisions]),
+				atc.ListWorkers:         authenticated(inputHandlers[atc.ListWorkers]),
+				atc.RegisterWorker:      authenticated(inputHandlers[atc.RegisterWorker]),
+				atc.HeartbeatWorker:     authenticated(inputHandlers[atc.HeartbeatWorker]),
+				atc.DeleteWorker:        authenticated(inputHandlers[atc.DeleteWorker]),
+				atc.GetTeam:             authenticated(inputHandlers[atc.GetTeam]),
+				atc.SetTeam:             authenticated(inputHandlers[atc.SetTeam]),
+				atc.RenameTeam:          authenticated(inputHandlers[atc.RenameTeam]),
+				atc.DestroyTeam:         authenticated(inputHandlers[atc.DestroyTeam]),
+				atc.GetUser:             authenticated(inputHandlers[atc.GetUser]),
 
 				//authenticateIfTokenProvided / delegating to handler
 				atc.GetInfo:              authenticateIfTokenProvided(inputHandlers[atc.GetInfo]),
 				atc.GetCheck:             authenticateIfTokenProvided(inputHandlers[atc.GetCheck]),
 				atc.DownloadCLI:          authenticateIfTokenProvided(inputHandlers[atc.DownloadCLI]),
 				atc.CheckResourceWebHook: authenticateIfTokenProvided(inputHandlers[atc.CheckResourceWebHook]),
+				atc.ReceiveWebhook:       authenticateIfTokenProvided(inputHandlers[atc.ReceiveWebhook]),
 				atc.ListAllPipelines:     authenticateIfTokenProvided(inputHandlers[atc.ListAllPipelines]),
 				atc.ListBuilds:           authenticateIfTokenProvided(inputHandlers[atc.ListBuilds]),
 				atc.ListPipelines:        authenticateIfTokenProvided(inputHandlers[atc.ListPipelines]),
@@ -196,35 +199,38 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.ClearWall:            authenticatedAndAdmin(inputHandlers[atc.ClearWall]),
 
 				// authorized (requested team matches resource team)
-				atc.CheckResource:           authorized(inputHandlers[atc.CheckResource]),
-				atc.CheckResourceType:       authorized(inputHandlers[atc.CheckResourceType]),
-				atc.CreateJobBuild:          authorized(inputHandlers[atc.CreateJobBuild]),
-				atc.RerunJobBuild:           authorized(inputHandlers[atc.RerunJobBuild]),
-				atc.DeletePipeline:          authorized(inputHandlers[atc.DeletePipeline]),
-				atc.DisableResourceVersion:  authorized(inputHandlers[atc.DisableResourceVersion]),
-				atc.EnableResourceVersion:   authorized(inputHandlers[atc.EnableResourceVersion]),
-				atc.PinResourceVersion:      authorized(inputHandlers[atc.PinResourceVersion]),
-				atc.UnpinResource:           authorized(inputHandlers[atc.UnpinResource]),
-				atc.SetPinCommentOnResource: authorized(inputHandlers[atc.SetPinCommentOnResource]),
-				atc.GetConfig:               authorized(inputHandlers[atc.GetConfig]),
-				atc.GetCC:                   authorized(inputHandlers[atc.GetCC]),
-				atc.GetVersionsDB:           authorized(inputHandlers[atc.GetVersionsDB]),
-				atc.ListJobInputs:           authorized(inputHandlers[atc.ListJobInputs]),
-				atc.OrderPipelines:          authorized(inputHandlers[atc.OrderPipelines]),
-				atc.PauseJob:                authorized(inputHandlers[atc.PauseJob]),
-				atc.PausePipeline:           authorized(inputHandlers[atc.PausePipeline]),
-				atc.ArchivePipeline:         authorized(inputHandlers[atc.ArchivePipeline]),
-				atc.RenamePipeline:          authorized(inputHandlers[atc.RenamePipeline]),
-				atc.SaveConfig:              authorized(inputHandlers[atc.SaveConfig]),
-				atc.UnpauseJob:              authorized(inputHandlers[atc.UnpauseJob]),
-				atc.ScheduleJob:             authorized(inputHandlers[atc.ScheduleJob]),
-				atc.UnpausePipeline:         authorized(inputHandlers[atc.UnpausePipeline]),
-				atc.ExposePipeline:          authorized(inputHandlers[atc.ExposePipeline]),
-				atc.HidePipeline:            authorized(inputHandlers[atc.HidePipeline]),
-				atc.CreatePipelineBuild:     authorized(inputHandlers[atc.CreatePipelineBuild]),
-				atc.ClearTaskCache:          authorized(inputHandlers[atc.ClearTaskCache]),
-				atc.CreateArtifact:          authorized(inputHandlers[atc.CreateArtifact]),
-				atc.GetArtifact:             authorized(inputHandlers[atc.GetArtifact]),
+				atc.CheckResource:               authorized(inputHandlers[atc.CheckResource]),
+				atc.CheckResourceType:           authorized(inputHandlers[atc.CheckResourceType]),
+				atc.CreateJobBuild:              authorized(inputHandlers[atc.CreateJobBuild]),
+				atc.CreateJobOneOffBuild:        authorized(inputHandlers[atc.CreateJobOneOffBuild]),
+				atc.RerunJobBuild:               authorized(inputHandlers[atc.RerunJobBuild]),
+				atc.DeletePipeline:              authorized(inputHandlers[atc.DeletePipeline]),
+				atc.DisableResourceVersion:      authorized(inputHandlers[atc.DisableResourceVersion]),
+				atc.EnableResourceVersion:       authorized(inputHandlers[atc.EnableResourceVersion]),
+				atc.PinResourceVersion:          authorized(inputHandlers[atc.PinResourceVersion]),
+				atc.UnpinResource:               authorized(inputHandlers[atc.UnpinResource]),
+				atc.SetPinCommentOnResource:     authorized(inputHandlers[atc.SetPinCommentOnResource]),
+				atc.GetConfig:                   authorized(inputHandlers[atc.GetConfig]),
+				atc.GetCC:                       authorized(inputHandlers[atc.GetCC]),
+				atc.GetVersionsDB:               authorized(inputHandlers[atc.GetVersionsDB]),
+				atc.SearchPipelineBuildLogs:     authorized(inputHandlers[atc.SearchPipelineBuildLogs]),
+				atc.ListJobInputs:               authorized(inputHandlers[atc.ListJobInputs]),
+				atc.GetJobSchedulingExplanation: authorized(inputHandlers[atc.GetJobSchedulingExplanation]),
+				atc.OrderPipelines:              authorized(inputHandlers[atc.OrderPipelines]),
+				atc.PauseJob:                    authorized(inputHandlers[atc.PauseJob]),
+				atc.PausePipeline:               authorized(inputHandlers[atc.PausePipeline]),
+				atc.ArchivePipeline:             authorized(inputHandlers[atc.ArchivePipeline]),
+				atc.RenamePipeline:              authorized(inputHandlers[atc.RenamePipeline]),
+				atc.SaveConfig:                  authorized(inputHandlers[atc.SaveConfig]),
+				atc.UnpauseJob:                  authorized(inputHandlers[atc.UnpauseJob]),
+				atc.ScheduleJob:                 authorized(inputHandlers[atc.ScheduleJob]),
+				atc.UnpausePipeline:             authorized(inputHandlers[atc.UnpausePipeline]),
+				atc.ExposePipeline:              authorized(inputHandlers[atc.ExposePipeline]),
+				atc.HidePipeline:                authorized(inputHandlers[atc.HidePipeline]),
+				atc.CreatePipelineBuild:         authorized(inputHandlers[atc.CreatePipelineBuild]),
+				atc.ClearTaskCache:              authorized(inputHandlers[atc.ClearTaskCache]),
+				atc.CreateArtifact:              authorized(inputHandlers[atc.CreateArtifact]),
+				atc.GetArtifact:                 authorized(inputHandlers[atc.GetArtifact]),
 			}
 		})
 
@@ -27,6 +27,7 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.PausePipeline,
 			atc.UnpausePipeline,
 			atc.CreateJobBuild,
+			atc.CreateJobOneOffBuild,
 			atc.ScheduleJob,
 			atc.CheckResource,
 			atc.CheckResourceType,
@@ -46,6 +47,7 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.BuildResources,
 			atc.BuildEvents,
 			atc.ListBuildArtifacts,
+			atc.ListBuildSecretAccesses,
 			atc.GetBuildPreparation,
 			atc.GetBuildPlan,
 			atc.AbortBuild,
@@ -64,6 +66,7 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.GetJob,
 			atc.ListJobBuilds,
 			atc.ListPipelineBuilds,
+			atc.SearchPipelineBuildLogs,
 			atc.GetResource,
 			atc.ListBuildsWithVersionAsInput,
 			atc.ListBuildsWithVersionAsOutput,
@@ -91,6 +94,7 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.GetCheck,
 			atc.DownloadCLI,
 			atc.CheckResourceWebHook,
+			atc.ReceiveWebhook,
 			atc.ListAllPipelines,
 			atc.ListBuilds,
 			atc.ListPipelines,
@@ -109,6 +113,7 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.GetCC,
 			atc.GetVersionsDB,
 			atc.ListJobInputs,
+			atc.GetJobSchedulingExplanation,
 			atc.OrderPipelines,
 			atc.PauseJob,
 			atc.ArchivePipeline,
@@ -120,7 +125,8 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.CreatePipelineBuild,
 			atc.ClearTaskCache,
 			atc.CreateArtifact,
-			atc.GetArtifact:
+			atc.GetArtifact,
+			atc.ListPolicyDecisions:
 
 		default:
 			panic("how do archived pipelines affect your endpoint?")
@@ -20,15 +20,17 @@ const timeDateLayout = "2006-01-02@15:04:05-0700"
 const inputTimeLayout = "2006-01-02 15:04:05"
 
 type BuildsCommand struct {
-	AllTeams    bool                     `short:"a" long:"all-teams" description:"Show builds for the all teams that user has access to"`
-	Count       int                      `short:"c" long:"count" default:"50" description:"Number of builds you want to limit the return to"`
-	CurrentTeam bool                     `long:"current-team" description:"Show builds for the currently targeted team"`
-	Job         flaghelpers.JobFlag      `short:"j" long:"job" value-name:"PIPELINE/JOB" description:"Name of a job to get builds for"`
-	Json        bool                     `long:"json" description:"Print command result as JSON"`
-	Pipeline    flaghelpers.PipelineFlag `short:"p" long:"pipeline" description:"Name of a pipeline to get builds for"`
-	Teams       []string                 `short:"n"  long:"team" description:"Show builds for these teams"`
-	Since       string                   `long:"since" description:"Start of the range to filter builds"`
-	Until       string                   `long:"until" description:"End of the range to filter builds"`
+	AllTeams      bool                     `short:"a" long:"all-teams" description:"Show builds for the all teams that user has access to"`
+	Count         int                      `short:"c" long:"count" default:"50" description:"Number of builds you want to limit the return to"`
+	CurrentTeam   bool                     `long:"current-team" description:"Show builds for the currently targeted team"`
+	Job           flaghelpers.JobFlag      `short:"j" long:"job" value-name:"PIPELINE/JOB" description:"Name of a job to get builds for"`
+	Json          bool                     `long:"json" description:"Print command result as JSON"`
+	Pipeline      flaghelpers.PipelineFlag `short:"p" long:"pipeline" description:"Name of a pipeline to get builds for"`
+	Teams         []string                 `short:"n"  long:"team" description:"Show builds for these teams"`
+	Since         string                   `long:"since" description:"Start of the range to filter builds"`
+	Until         string                   `long:"until" description:"End of the range to filter builds"`
+	Secret        string                   `long:"secret" value-name:"PATH" description:"Only show builds which looked up the secret at this path"`
+	ResourceUsage bool                     `long:"resource-usage" description:"Show the CPU time, peak memory and root filesystem disk usage of the containers of each build"`
 }
 
 func (command *BuildsCommand) Execute([]string) error {
@@ -58,6 +60,7 @@ func (command *BuildsCommand) Execute([]string) error {
 
 	page.Limit = command.Count
 	page.Timestamps = command.Since != "" || command.Until != ""
+	page.Secret = command.Secret
 
 	currentTeam := target.Team()
 	client := target.Client()
@@ -136,7 +139,7 @@ func (command *BuildsCommand) validateJobBuilds(builds []atc.Build, currentTeam
 	)
 
 	builds, _, found, err = currentTeam.JobBuilds(
-		command.Job.PipelineName,
+		command.Job.PipelineRef,
 		command.Job.JobName,
 		page,
 	)
@@ -157,7 +160,7 @@ func (command *BuildsCommand) validatePipelineBuilds(builds []atc.Build, current
 
 	var found bool
 	builds, _, found, err = currentTeam.PipelineBuilds(
-		string(command.Pipeline),
+		command.Pipeline.Ref(),
 		page,
 	)
 
@@ -192,9 +195,18 @@ func (command *BuildsCommand) displayBuilds(builds []atc.Build) error {
 			{Contents: "end", Color: color.New(color.Bold)},
 			{Contents: "duration", Color: color.New(color.Bold)},
 			{Contents: "team", Color: color.New(color.Bold)},
+			{Contents: "priority", Color: color.New(color.Bold)},
 		},
 	}
 
+	if command.ResourceUsage {
+		table.Headers = append(table.Headers,
+			ui.TableCell{Contents: "cpu", Color: color.New(color.Bold)},
+			ui.TableCell{Contents: "memory peak", Color: color.New(color.Bold)},
+			ui.TableCell{Contents: "rootfs disk usage", Color: color.New(color.Bold)},
+		)
+	}
+
 	buildCap := command.buildCap(builds)
 	for _, b := range builds[:buildCap] {
 		startTimeCell, endTimeCell, durationCell := populateTimeCells(time.Unix(b.StartTime, 0), time.Unix(b.EndTime, 0))
@@ -210,6 +222,9 @@ func (command *BuildsCommand) displayBuilds(builds []atc.Build) error {
 
 		var statusCell ui.TableCell
 		statusCell.Contents = b.Status
+		if b.Status == "pending" && b.QuotaExceeded {
+			statusCell.Contents = "pending (quota exceeded)"
+		}
 
 		switch b.Status {
 		case "pending":
@@ -228,7 +243,7 @@ func (command *BuildsCommand) displayBuilds(builds []atc.Build) error {
 			statusCell.Color = ui.PausedColor
 		}
 
-		table.Data = append(table.Data, []ui.TableCell{
+		row := ui.TableRow{
 			{Contents: strconv.Itoa(b.ID)},
 			pipelineJobCell,
 			buildCell,
@@ -237,7 +252,14 @@ func (command *BuildsCommand) displayBuilds(builds []atc.Build) error {
 			endTimeCell,
 			durationCell,
 			{Contents: b.TeamName},
-		})
+			{Contents: strconv.Itoa(b.Priority)},
+		}
+
+		if command.ResourceUsage {
+			row = append(row, resourceUsageCells(b.ResourceUsage)...)
+		}
+
+		table.Data = append(table.Data, row)
 	}
 
 	return table.Render(os.Stdout, Fly.PrintTableHeaders)
@@ -304,16 +326,32 @@ func populateTimeCells(startTime time.Time, endTime time.Time) (ui.TableCell, ui
 	return startTimeCell, endTimeCell, durationCell
 }
 
+func resourceUsageCells(usage *atc.ResourceUsage) []ui.TableCell {
+	if usage == nil {
+		return []ui.TableCell{
+			{Contents: "n/a", Color: ui.OffColor},
+			{Contents: "n/a", Color: ui.OffColor},
+			{Contents: "n/a", Color: ui.OffColor},
+		}
+	}
+
+	return []ui.TableCell{
+		{Contents: ui.PresentCPUTime(usage.CPUTime)},
+		{Contents: ui.PresentBytes(usage.MemoryPeak)},
+		{Contents: ui.PresentBytes(usage.RootfsDiskUsage)},
+	}
+}
+
 func roundSecondsOffDuration(d time.Duration) time.Duration {
 	return d - (d % time.Second)
 }
 
 func (command *BuildsCommand) jobFlag() bool {
-	return command.Job.PipelineName != "" && command.Job.JobName != ""
+	return command.Job.PipelineRef.Name != "" && command.Job.JobName != ""
 }
 
 func (command *BuildsCommand) pipelineFlag() bool {
-	return command.Pipeline != ""
+	return command.Pipeline.Name != ""
 }
 
 func (command *BuildsCommand) buildCap(builds []atc.Build) int {
@@ -44,13 +44,13 @@ func (command *CheckResourceTypeCommand) Execute(args []string) error {
 		}
 	}
 
-	check, found, err := target.Team().CheckResourceType(command.ResourceType.PipelineName, command.ResourceType.ResourceName, version)
+	check, found, err := target.Team().CheckResourceType(command.ResourceType.PipelineRef, command.ResourceType.ResourceName, version)
 	if err != nil {
 		return err
 	}
 
 	if !found {
-		return fmt.Errorf("pipeline '%s' or resource-type '%s' not found\n", command.ResourceType.PipelineName, command.ResourceType.ResourceName)
+		return fmt.Errorf("pipeline '%s' or resource-type '%s' not found\n", command.ResourceType.PipelineRef.String(), command.ResourceType.ResourceName)
 	}
 
 	var checkID = strconv.Itoa(check.ID)
@@ -98,13 +98,13 @@ func (command *CheckResourceTypeCommand) Execute(args []string) error {
 }
 
 func (command *CheckResourceTypeCommand) checkParent(target rc.Target) error {
-	resourceTypes, found, err := target.Team().VersionedResourceTypes(command.ResourceType.PipelineName)
+	resourceTypes, found, err := target.Team().VersionedResourceTypes(command.ResourceType.PipelineRef)
 	if err != nil {
 		return err
 	}
 
 	if !found {
-		return fmt.Errorf("pipeline '%s' not found\n", command.ResourceType.PipelineName)
+		return fmt.Errorf("pipeline '%s' not found\n", command.ResourceType.PipelineRef.String())
 	}
 
 	resourceType, found := resourceTypes.Lookup(command.ResourceType.ResourceName)
@@ -120,7 +120,7 @@ func (command *CheckResourceTypeCommand) checkParent(target rc.Target) error {
 	cmd := &CheckResourceTypeCommand{
 		ResourceType: flaghelpers.ResourceFlag{
 			ResourceName: parentType.Name,
-			PipelineName: command.ResourceType.PipelineName,
+			PipelineRef:  command.ResourceType.PipelineRef,
 		},
 	}
 
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 	"os"
@@ -24,7 +25,9 @@ import (
 )
 
 type ExecuteCommand struct {
-	TaskConfig     atc.PathFlag                       `short:"c" long:"config" required:"true"                description:"The task config to execute"`
+	TaskConfig     atc.PathFlag                       `short:"c" long:"config"                                description:"The task config to execute"`
+	Job            flaghelpers.JobFlag                `          long:"job"         value-name:"PIPELINE/JOB" description:"A job whose plan to execute instead of a task config"`
+	SkipPuts       bool                               `          long:"skip-puts"                             description:"Skip the put steps of the job being executed"`
 	Privileged     bool                               `short:"p" long:"privileged"                            description:"Run the task with full privileges"`
 	IncludeIgnored bool                               `          long:"include-ignored"                       description:"Including .gitignored paths. Disregards .gitignore entries and uploads everything"`
 	Inputs         []flaghelpers.InputPairFlag        `short:"i" long:"input"       value-name:"NAME=PATH"    description:"An input to provide to the task (can be specified multiple times)"`
@@ -49,12 +52,98 @@ func (command *ExecuteCommand) Execute(args []string) error {
 		return err
 	}
 
-	taskConfig, err := command.CreateTaskConfig(args)
+	planFactory := atc.NewPlanFactory(time.Now().Unix())
+
+	var (
+		build   atc.Build
+		outputs []executehelpers.Output
+	)
+
+	if command.Job.JobName != "" {
+		build, outputs, err = command.createJobBuild(planFactory, target)
+	} else {
+		build, outputs, err = command.createTaskBuild(planFactory, target, args)
+	}
 	if err != nil {
 		return err
 	}
 
-	planFactory := atc.NewPlanFactory(time.Now().Unix())
+	client := target.Client()
+	clientURL, err := url.Parse(client.URL())
+	if err != nil {
+		return err
+	}
+
+	buildURL, err := url.Parse(fmt.Sprintf("/builds/%d", build.ID))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("executing build %d at %s \n", build.ID, clientURL.ResolveReference(buildURL))
+
+	terminate := make(chan os.Signal, 1)
+
+	go abortOnSignal(client, terminate, build)
+
+	signal.Notify(terminate, syscall.SIGINT, syscall.SIGTERM)
+
+	eventSource, err := client.BuildEvents(strconv.Itoa(build.ID))
+	if err != nil {
+		return err
+	}
+
+	renderOptions := eventstream.RenderOptions{}
+
+	exitCode := eventstream.Render(os.Stdout, eventSource, renderOptions)
+	eventSource.Close()
+
+	artifactList, err := client.ListBuildArtifacts(strconv.Itoa(build.ID))
+	if err != nil {
+		return err
+	}
+
+	artifacts := map[string]atc.WorkerArtifact{}
+
+	for _, artifact := range artifactList {
+		artifacts[artifact.Name] = artifact
+	}
+
+	prog := progress.New()
+
+	for _, output := range outputs {
+		name := output.Name
+		path := output.Path
+
+		artifact, ok := artifacts[name]
+		if !ok {
+			continue
+		}
+
+		prog.Go("downloading "+output.Name, func(bar *mpb.Bar) error {
+			return executehelpers.Download(bar, target.Team(), artifact.ID, path)
+		})
+	}
+
+	err = prog.Wait()
+	if err != nil {
+		displayhelpers.FailWithErrorf("downloading failed: %s", err)
+		return err
+	}
+
+	os.Exit(exitCode)
+
+	return nil
+}
+
+func (command *ExecuteCommand) createTaskBuild(planFactory atc.PlanFactory, target rc.Target, args []string) (atc.Build, []executehelpers.Output, error) {
+	if command.TaskConfig == "" {
+		return atc.Build{}, nil, errors.New("either --config or --job must be specified")
+	}
+
+	taskConfig, err := command.CreateTaskConfig(args)
+	if err != nil {
+		return atc.Build{}, nil, err
+	}
 
 	inputs, inputMappings, imageResource, resourceTypes, err := executehelpers.DetermineInputs(
 		planFactory,
@@ -68,7 +157,7 @@ func (command *ExecuteCommand) Execute(args []string) error {
 		taskConfig.Platform,
 	)
 	if err != nil {
-		return err
+		return atc.Build{}, nil, err
 	}
 
 	if imageResource != nil {
@@ -81,7 +170,7 @@ func (command *ExecuteCommand) Execute(args []string) error {
 		command.Outputs,
 	)
 	if err != nil {
-		return err
+		return atc.Build{}, nil, err
 	}
 
 	plan, err := executehelpers.CreateBuildPlan(
@@ -95,91 +184,111 @@ func (command *ExecuteCommand) Execute(args []string) error {
 		taskConfig,
 		command.Tags,
 	)
-
 	if err != nil {
-		return err
-	}
-
-	client := target.Client()
-	clientURL, err := url.Parse(client.URL())
-	if err != nil {
-		return err
+		return atc.Build{}, nil, err
 	}
 
 	var build atc.Build
-	var buildURL *url.URL
-
-	if command.InputsFrom.PipelineName != "" {
-		build, err = target.Team().CreatePipelineBuild(command.InputsFrom.PipelineName, plan)
-		if err != nil {
-			return err
-		}
+	if command.InputsFrom.PipelineRef.Name != "" {
+		build, err = target.Team().CreatePipelineBuild(command.InputsFrom.PipelineRef, plan)
 	} else {
 		build, err = target.Team().CreateBuild(plan)
-		if err != nil {
-			return err
-		}
 	}
-
-	buildURL, err = url.Parse(fmt.Sprintf("/builds/%d", build.ID))
 	if err != nil {
-		return err
+		return atc.Build{}, nil, err
 	}
 
-	fmt.Printf("executing build %d at %s \n", build.ID, clientURL.ResolveReference(buildURL))
-
-	terminate := make(chan os.Signal, 1)
+	return build, outputs, nil
+}
 
-	go abortOnSignal(client, terminate, build)
+// createJobBuild runs the plan of a job as a one-off build, which is planned
+// by the ATC the same way as the job's own builds. Everything which can be
+// checked up front is, so that local inputs are not uploaded in vain.
+func (command *ExecuteCommand) createJobBuild(planFactory atc.PlanFactory, target rc.Target) (atc.Build, []executehelpers.Output, error) {
+	if command.TaskConfig != "" || command.InputsFrom.JobName != "" || len(command.InputMappings) != 0 || command.Image != "" {
+		return atc.Build{}, nil, errors.New("--job cannot be used with --config, --inputs-from, --input-mapping or --image")
+	}
 
-	signal.Notify(terminate, syscall.SIGINT, syscall.SIGTERM)
+	team := target.Team()
 
-	eventSource, err := client.BuildEvents(strconv.Itoa(build.ID))
+	config, _, found, err := team.PipelineConfig(command.Job.PipelineRef)
 	if err != nil {
-		return err
+		return atc.Build{}, nil, err
 	}
 
-	renderOptions := eventstream.RenderOptions{}
+	if !found {
+		return atc.Build{}, nil, fmt.Errorf("pipeline '%s' not found", command.Job.PipelineRef.String())
+	}
 
-	exitCode := eventstream.Render(os.Stdout, eventSource, renderOptions)
-	eventSource.Close()
+	job, found := config.Jobs.Lookup(command.Job.JobName)
+	if !found {
+		return atc.Build{}, nil, fmt.Errorf("job '%s' not found in pipeline '%s'", command.Job.JobName, command.Job.PipelineRef.String())
+	}
 
-	artifactList, err := client.ListBuildArtifacts(strconv.Itoa(build.ID))
+	err = executehelpers.CheckForUnknownJobInputs(command.Inputs, job)
 	if err != nil {
-		return err
+		return atc.Build{}, nil, err
 	}
 
-	artifacts := map[string]atc.WorkerArtifact{}
+	err = executehelpers.CheckForInputType(command.Inputs)
+	if err != nil {
+		return atc.Build{}, nil, err
+	}
 
-	for _, artifact := range artifactList {
-		artifacts[artifact.Name] = artifact
+	err = command.checkForMissingJobInputs(team, job)
+	if err != nil {
+		return atc.Build{}, nil, err
 	}
 
-	prog := progress.New()
+	localInputs, err := executehelpers.GenerateLocalInputs(planFactory, team, command.Inputs, command.IncludeIgnored, "")
+	if err != nil {
+		return atc.Build{}, nil, err
+	}
 
-	for _, output := range outputs {
-		name := output.Name
-		path := output.Path
+	buildConfig := atc.JobOneOffBuildConfig{
+		Inputs:   map[string]int{},
+		SkipPuts: command.SkipPuts,
+	}
 
-		artifact, ok := artifacts[name]
-		if !ok {
-			continue
-		}
+	for name, input := range localInputs {
+		buildConfig.Inputs[name] = input.Plan.ArtifactInput.ArtifactID
+	}
 
-		prog.Go("downloading "+output.Name, func(bar *mpb.Bar) error {
-			return executehelpers.Download(bar, target.Team(), artifact.ID, path)
+	outputs := []executehelpers.Output{}
+	for _, output := range command.Outputs {
+		buildConfig.Outputs = append(buildConfig.Outputs, output.Name)
+		outputs = append(outputs, executehelpers.Output{
+			Name: output.Name,
+			Path: output.Path,
 		})
 	}
 
-	err = prog.Wait()
+	build, err := team.CreateJobOneOffBuild(command.Job.PipelineRef, command.Job.JobName, buildConfig)
+	if err != nil {
+		return atc.Build{}, nil, err
+	}
+
+	return build, outputs, nil
+}
+
+// checkForMissingJobInputs makes sure that every input of the job which is
+// not provided locally has a version to run with.
+func (command *ExecuteCommand) checkForMissingJobInputs(team concourse.Team, job atc.JobConfig) error {
+	remoteInputs := executehelpers.RemoteJobInputs(command.Inputs, job)
+	if len(remoteInputs) == 0 {
+		return nil
+	}
+
+	buildInputs, found, err := team.BuildInputsForJob(command.Job.PipelineRef, command.Job.JobName)
 	if err != nil {
-		displayhelpers.FailWithErrorf("downloading failed: %s", err)
 		return err
 	}
 
-	os.Exit(exitCode)
+	if !found {
+		return fmt.Errorf("build inputs for %s/%s not found", command.Job.PipelineRef.String(), command.Job.JobName)
+	}
 
-	return nil
+	return executehelpers.CheckForMissingJobInputs(remoteInputs, buildInputs)
 }
 
 func (command *ExecuteCommand) CreateTaskConfig(args []string) (atc.TaskConfig, error) {
@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type ExplainJobCommand struct {
+	Job  flaghelpers.JobFlag `short:"j" long:"job" required:"true" value-name:"PIPELINE/JOB" description:"Name of the job to explain the scheduling of"`
+	Json bool                `long:"json" description:"Print command result as JSON"`
+}
+
+func (command *ExplainJobCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	explanation, found, err := target.Team().JobSchedulingExplanation(command.Job.PipelineRef, command.Job.JobName)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("pipeline '%s' or job '%s' not found", command.Job.PipelineRef.String(), command.Job.JobName)
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(explanation)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "input", Color: color.New(color.Bold)},
+			{Contents: "version", Color: color.New(color.Bold)},
+			{Contents: "passed build", Color: color.New(color.Bold)},
+			{Contents: "outcome", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, input := range explanation.Inputs {
+		for _, candidate := range input.Candidates {
+			table.Data = append(table.Data, ui.TableRow{
+				{Contents: input.Name},
+				{Contents: ui.PresentVersion(candidate.Version)},
+				passedBuildCell(candidate),
+				candidateOutcomeCell(input, candidate),
+			})
+		}
+
+		if len(input.Candidates) == 0 || input.Error != "" {
+			versionCell := ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+			if input.Version != nil {
+				versionCell = ui.TableCell{Contents: ui.PresentVersion(input.Version)}
+			}
+
+			outcomeCell := ui.TableCell{Contents: "chosen", Color: ui.SucceededColor}
+			if input.Error != "" {
+				outcomeCell = ui.TableCell{Contents: input.Error, Color: ui.FailedColor}
+			}
+
+			table.Data = append(table.Data, ui.TableRow{
+				{Contents: input.Name},
+				versionCell,
+				{Contents: "n/a", Color: ui.OffColor},
+				outcomeCell,
+			})
+		}
+
+		if input.CandidatesTruncated {
+			table.Data = append(table.Data, ui.TableRow{
+				{Contents: input.Name},
+				{Contents: "..."},
+				{Contents: "n/a", Color: ui.OffColor},
+				{Contents: "too many candidates to show", Color: ui.OffColor},
+			})
+		}
+	}
+
+	err = table.Render(os.Stdout, Fly.PrintTableHeaders)
+	if err != nil {
+		return err
+	}
+
+	if !explanation.InputsDetermined {
+		fmt.Fprintln(ui.Stderr, "")
+		fmt.Fprintln(ui.Stderr, "the inputs for the next build of the job could not be determined")
+	}
+
+	return nil
+}
+
+func passedBuildCell(candidate atc.CandidateVersion) ui.TableCell {
+	if candidate.PassedJob == "" {
+		return ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+	}
+
+	return ui.TableCell{Contents: candidate.PassedJob + "/" + candidate.BuildName}
+}
+
+func candidateOutcomeCell(input atc.InputExplanation, candidate atc.CandidateVersion) ui.TableCell {
+	if candidate.Conflict != nil {
+		conflict := candidate.Conflict.PassedJob
+		if candidate.Conflict.BuildName != "" {
+			conflict += "/" + candidate.Conflict.BuildName
+		}
+
+		return ui.TableCell{Contents: fmt.Sprintf("%s (conflicts with %s)", candidate.Rejection, conflict), Color: ui.FailedColor}
+	}
+
+	if candidate.Rejection != "" {
+		return ui.TableCell{Contents: candidate.Rejection, Color: ui.FailedColor}
+	}
+
+	if input.Error != "" {
+		return ui.TableCell{Contents: "accepted"}
+	}
+
+	return ui.TableCell{Contents: "chosen", Color: ui.SucceededColor}
+}
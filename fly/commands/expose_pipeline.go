@@ -23,7 +23,7 @@ func (command *ExposePipelineCommand) Execute(args []string) error {
 		return err
 	}
 
-	pipelineName := string(command.Pipeline)
+	pipelineRef := command.Pipeline.Ref()
 
 	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
 	if err != nil {
@@ -35,15 +35,15 @@ func (command *ExposePipelineCommand) Execute(args []string) error {
 		return err
 	}
 
-	found, err := target.Team().ExposePipeline(pipelineName)
+	found, err := target.Team().ExposePipeline(pipelineRef)
 	if err != nil {
 		return err
 	}
 
 	if found {
-		fmt.Printf("exposed '%s'\n", pipelineName)
+		fmt.Printf("exposed '%s'\n", pipelineRef.String())
 	} else {
-		displayhelpers.Failf("pipeline '%s' not found\n", pipelineName)
+		displayhelpers.Failf("pipeline '%s' not found\n", pipelineRef.String())
 	}
 
 	return nil
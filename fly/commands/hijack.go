@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/url"
@@ -259,11 +260,17 @@ func (command *HijackCommand) getContainerFingerprintFromUrl(target rc.Target, u
 		return nil, err
 	}
 
+	instanceVars, err := atc.InstanceVarsFromQueryParams(u.Query())
+	if err != nil {
+		return nil, err
+	}
+
 	fingerprint := &containerFingerprint{
-		pipelineName:  urlMap["pipelines"],
-		jobName:       urlMap["jobs"],
-		buildNameOrID: urlMap["builds"],
-		checkName:     urlMap["resources"],
+		pipelineName:         urlMap["pipelines"],
+		pipelineInstanceVars: instanceVars,
+		jobName:              urlMap["jobs"],
+		buildNameOrID:        urlMap["builds"],
+		checkName:            urlMap["resources"],
 	}
 
 	return fingerprint, nil
@@ -280,16 +287,20 @@ func (command *HijackCommand) getContainerFingerprint(target rc.Target, team con
 		}
 	}
 
-	pipelineName := command.Check.PipelineName
-	if command.Job.PipelineName != "" {
-		pipelineName = command.Job.PipelineName
+	pipelineRef := command.Check.PipelineRef
+	if command.Job.PipelineRef.Name != "" {
+		pipelineRef = command.Job.PipelineRef
+	}
+
+	if pipelineRef.InstanceVars != nil {
+		fingerprint.pipelineInstanceVars = pipelineRef.InstanceVars
 	}
 
 	for _, field := range []struct {
 		fp  *string
 		cmd string
 	}{
-		{fp: &fingerprint.pipelineName, cmd: pipelineName},
+		{fp: &fingerprint.pipelineName, cmd: pipelineRef.Name},
 		{fp: &fingerprint.buildNameOrID, cmd: command.Build},
 		{fp: &fingerprint.stepName, cmd: command.StepName},
 		{fp: &fingerprint.stepType, cmd: command.StepType},
@@ -362,6 +373,9 @@ func (locator stepContainerLocator) locate(fingerprint *containerFingerprint) (m
 
 	if fingerprint.jobName != "" {
 		reqValues["pipeline_name"] = fingerprint.pipelineName
+		if err := fingerprint.addInstanceVars(reqValues); err != nil {
+			return reqValues, err
+		}
 		reqValues["job_name"] = fingerprint.jobName
 		if fingerprint.buildNameOrID != "" {
 			reqValues["build_name"] = fingerprint.buildNameOrID
@@ -369,7 +383,7 @@ func (locator stepContainerLocator) locate(fingerprint *containerFingerprint) (m
 	} else if fingerprint.buildNameOrID != "" {
 		reqValues["build_id"] = fingerprint.buildNameOrID
 	} else {
-		build, err := GetBuild(locator.client, nil, "", "", "")
+		build, err := GetBuild(locator.client, nil, "", "", atc.PipelineRef{})
 		if err != nil {
 			return reqValues, err
 		}
@@ -390,15 +404,19 @@ func (locator checkContainerLocator) locate(fingerprint *containerFingerprint) (
 	}
 	if fingerprint.pipelineName != "" {
 		reqValues["pipeline_name"] = fingerprint.pipelineName
+		if err := fingerprint.addInstanceVars(reqValues); err != nil {
+			return reqValues, err
+		}
 	}
 
 	return reqValues, nil
 }
 
 type containerFingerprint struct {
-	pipelineName  string
-	jobName       string
-	buildNameOrID string
+	pipelineName         string
+	pipelineInstanceVars atc.InstanceVars
+	jobName              string
+	buildNameOrID        string
 
 	stepName string
 	stepType string
@@ -407,6 +425,20 @@ type containerFingerprint struct {
 	attempt   string
 }
 
+func (fingerprint *containerFingerprint) addInstanceVars(reqValues map[string]string) error {
+	if fingerprint.pipelineInstanceVars == nil {
+		return nil
+	}
+
+	payload, err := json.Marshal(fingerprint.pipelineInstanceVars)
+	if err != nil {
+		return err
+	}
+
+	reqValues[atc.InstanceVarsQueryParam] = string(payload)
+	return nil
+}
+
 func locateContainer(client concourse.Client, fingerprint *containerFingerprint) (map[string]string, error) {
 	var locator containerLocator
 
@@ -47,6 +47,11 @@ func ShowWarnings(warnings []concourse.ConfigWarning) {
 		fmt.Fprintln(ui.Stderr, "identifier schema documentation: https://concourse-ci.org/config-basics.html#schema.identifier")
 		fmt.Fprintln(ui.Stderr, "")
 	}
+
+	if warningTypes["policy"] {
+		fmt.Fprintln(ui.Stderr, "the policy checks above did not pass, but are not enforced as the policy checker is running in audit-only mode; run `fly policy-decisions` for details")
+		fmt.Fprintln(ui.Stderr, "")
+	}
 }
 
 func Failf(message string, args ...interface{}) {
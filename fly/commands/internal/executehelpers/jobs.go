@@ -0,0 +1,64 @@
+package executehelpers
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+)
+
+func CheckForUnknownJobInputs(inputMappings []flaghelpers.InputPairFlag, job atc.JobConfig) error {
+	for _, inputMapping := range inputMappings {
+		if !JobInputsContainsName(job.Inputs(), inputMapping.Name) {
+			return fmt.Errorf("unknown input `%s`", inputMapping.Name)
+		}
+	}
+	return nil
+}
+
+func JobInputsContainsName(inputs []atc.JobInputParams, name string) bool {
+	for _, input := range inputs {
+		if input.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoteJobInputs returns the names of the job's inputs which are not
+// provided locally, and so are fetched at the versions the job would run its
+// next build with.
+func RemoteJobInputs(inputMappings []flaghelpers.InputPairFlag, job atc.JobConfig) []string {
+	var names []string
+	for _, input := range job.Inputs() {
+		local := false
+		for _, inputMapping := range inputMappings {
+			if inputMapping.Name == input.Name {
+				local = true
+				break
+			}
+		}
+
+		if !local {
+			names = append(names, input.Name)
+		}
+	}
+	return names
+}
+
+func CheckForMissingJobInputs(names []string, buildInputs []atc.BuildInput) error {
+	for _, name := range names {
+		found := false
+		for _, buildInput := range buildInputs {
+			if buildInput.Name == name {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("no version available for input `%s`", name)
+		}
+	}
+	return nil
+}
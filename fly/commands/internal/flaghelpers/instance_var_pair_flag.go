@@ -0,0 +1,31 @@
+package flaghelpers
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// InstanceVarPairFlag is a name=value pair whose value is parsed as YAML into
+// JSON-compatible types, so that it can be stored as a pipeline instance var.
+type InstanceVarPairFlag struct {
+	Name  string
+	Value interface{}
+}
+
+func (pair *InstanceVarPairFlag) UnmarshalFlag(value string) error {
+	vs := strings.SplitN(value, "=", 2)
+	if len(vs) != 2 || vs[0] == "" {
+		return fmt.Errorf("invalid instance var '%s' (must be name=value)", value)
+	}
+
+	pair.Name = vs[0]
+
+	err := yaml.Unmarshal([]byte(vs[1]), &pair.Value)
+	if err != nil {
+		return fmt.Errorf("invalid value for instance var '%s': %w", vs[0], err)
+	}
+
+	return nil
+}
@@ -7,23 +7,32 @@ import (
 
 	"github.com/jessevdk/go-flags"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/rc"
 )
 
 type JobFlag struct {
-	PipelineName string
-	JobName      string
+	PipelineRef atc.PipelineRef
+	JobName     string
 }
 
 func (job *JobFlag) UnmarshalFlag(value string) error {
-	vs := strings.SplitN(value, "/", -1)
+	jobSep := strings.LastIndex(value, "/")
+	if jobSep == -1 {
+		return errors.New("argument format should be <pipeline>/<job>")
+	}
+
+	pipelineRef, err := unmarshalPipelineRef(value[:jobSep])
+	if err != nil {
+		return err
+	}
 
-	if len(vs) != 2 {
+	if strings.Contains(pipelineRef.Name, "/") {
 		return errors.New("argument format should be <pipeline>/<job>")
 	}
 
-	job.PipelineName = vs[0]
-	job.JobName = vs[1]
+	job.PipelineRef = pipelineRef
+	job.JobName = value[jobSep+1:]
 
 	return nil
 }
@@ -43,28 +52,34 @@ func (flag *JobFlag) Complete(match string) []flags.Completion {
 
 	team := target.Team()
 	comps := []flags.Completion{}
-	vs := strings.SplitN(match, "/", 2)
 
-	if len(vs) == 1 {
+	jobSep := strings.LastIndex(match, "/")
+	if jobSep == -1 {
 		pipelines, err := team.ListPipelines()
 		if err != nil {
 			return comps
 		}
 
 		for _, pipeline := range pipelines {
-			if strings.HasPrefix(pipeline.Name, vs[0]) {
-				comps = append(comps, flags.Completion{Item: pipeline.Name + "/"})
+			name := pipeline.Ref().String()
+			if strings.HasPrefix(name, match) {
+				comps = append(comps, flags.Completion{Item: name + "/"})
 			}
 		}
-	} else if len(vs) == 2 {
-		jobs, err := team.ListJobs(vs[0])
+	} else {
+		pipelineRef, err := unmarshalPipelineRef(match[:jobSep])
+		if err != nil {
+			return comps
+		}
+
+		jobs, err := team.ListJobs(pipelineRef)
 		if err != nil {
 			return comps
 		}
 
 		for _, job := range jobs {
-			if strings.HasPrefix(job.Name, vs[1]) {
-				comps = append(comps, flags.Completion{Item: fmt.Sprintf("%s/%s", vs[0], job.Name)})
+			if strings.HasPrefix(job.Name, match[jobSep+1:]) {
+				comps = append(comps, flags.Completion{Item: fmt.Sprintf("%s/%s", match[:jobSep], job.Name)})
 			}
 		}
 	}
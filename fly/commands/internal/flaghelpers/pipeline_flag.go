@@ -2,24 +2,56 @@ package flaghelpers
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/jessevdk/go-flags"
+	"sigs.k8s.io/yaml"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/rc"
 	"github.com/concourse/concourse/go-concourse/concourse"
 )
 
-type PipelineFlag string
+// PipelineFlag identifies a pipeline, optionally followed by the instance vars
+// of one of its instances, e.g. "release" or "release/branch:1.2,os:linux".
+type PipelineFlag struct {
+	Name         string
+	InstanceVars atc.InstanceVars
+}
+
+func (flag *PipelineFlag) UnmarshalFlag(value string) error {
+	vs := strings.SplitN(value, "/", 2)
+
+	flag.Name = vs[0]
+	flag.InstanceVars = nil
+
+	// without any key:value pair the "/" is part of the name, which Validate
+	// then rejects
+	if len(vs) == 2 && !strings.Contains(vs[1], ":") {
+		flag.Name = value
+		return nil
+	}
+
+	if len(vs) == 2 {
+		instanceVars, err := unmarshalInstanceVars(vs[1])
+		if err != nil {
+			return err
+		}
+
+		flag.InstanceVars = instanceVars
+	}
+
+	return nil
+}
 
 func (flag *PipelineFlag) Validate() ([]concourse.ConfigWarning, error) {
-	if strings.Contains(string(*flag), "/") {
+	if strings.Contains(flag.Name, "/") {
 		return nil, errors.New("pipeline name cannot contain '/'")
 	}
 
 	var warnings []concourse.ConfigWarning
-	if warning := atc.ValidateIdentifier(string(*flag), "pipeline"); warning != nil {
+	if warning := atc.ValidateIdentifier(flag.Name, "pipeline"); warning != nil {
 		warnings = append(warnings, concourse.ConfigWarning{
 			Type:    warning.Type,
 			Message: warning.Message,
@@ -28,6 +60,17 @@ func (flag *PipelineFlag) Validate() ([]concourse.ConfigWarning, error) {
 	return warnings, nil
 }
 
+func (flag PipelineFlag) Ref() atc.PipelineRef {
+	return atc.PipelineRef{
+		Name:         flag.Name,
+		InstanceVars: flag.InstanceVars,
+	}
+}
+
+func (flag PipelineFlag) String() string {
+	return flag.Ref().String()
+}
+
 func (flag *PipelineFlag) Complete(match string) []flags.Completion {
 	fly := parseFlags()
 
@@ -48,10 +91,46 @@ func (flag *PipelineFlag) Complete(match string) []flags.Completion {
 
 	comps := []flags.Completion{}
 	for _, pipeline := range pipelines {
-		if strings.HasPrefix(pipeline.Name, match) {
-			comps = append(comps, flags.Completion{Item: pipeline.Name})
+		name := pipeline.Ref().String()
+		if strings.HasPrefix(name, match) {
+			comps = append(comps, flags.Completion{Item: name})
 		}
 	}
 
 	return comps
 }
+
+// unmarshalPipelineRef parses "<pipeline>[/<instance vars>]".
+func unmarshalPipelineRef(value string) (atc.PipelineRef, error) {
+	var flag PipelineFlag
+	err := flag.UnmarshalFlag(value)
+	if err != nil {
+		return atc.PipelineRef{}, err
+	}
+
+	return flag.Ref(), nil
+}
+
+// unmarshalInstanceVars parses comma separated key:value pairs. Values are
+// parsed as YAML, so "version:1.2" yields a number whereas `version:"1.2"`
+// yields a string.
+func unmarshalInstanceVars(value string) (atc.InstanceVars, error) {
+	instanceVars := atc.InstanceVars{}
+
+	for _, pair := range strings.Split(value, ",") {
+		kv := strings.SplitN(pair, ":", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid instance var '%s' (must be key:value)", pair)
+		}
+
+		var v interface{}
+		err := yaml.Unmarshal([]byte(kv[1]), &v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for instance var '%s': %w", kv[0], err)
+		}
+
+		instanceVars[kv[0]] = v
+	}
+
+	return instanceVars, nil
+}
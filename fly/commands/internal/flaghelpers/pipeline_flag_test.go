@@ -0,0 +1,62 @@
+package flaghelpers_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	. "github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PipelineFlag", func() {
+	var pipelineFlag *PipelineFlag
+
+	BeforeEach(func() {
+		pipelineFlag = &PipelineFlag{}
+	})
+
+	Context("when only a pipeline name is specified", func() {
+		It("has no instance vars", func() {
+			err := pipelineFlag.UnmarshalFlag("some-pipeline")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pipelineFlag.Ref()).To(Equal(atc.PipelineRef{Name: "some-pipeline"}))
+		})
+	})
+
+	Context("when instance vars are specified", func() {
+		It("parses the values as YAML", func() {
+			err := pipelineFlag.UnmarshalFlag(`some-pipeline/branch:feature/foo,version:"1.2",count:3`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pipelineFlag.Ref()).To(Equal(atc.PipelineRef{
+				Name: "some-pipeline",
+				InstanceVars: atc.InstanceVars{
+					"branch":  "feature/foo",
+					"version": "1.2",
+					"count":   float64(3),
+				},
+			}))
+		})
+
+		It("renders back to the same string", func() {
+			err := pipelineFlag.UnmarshalFlag(`some-pipeline/branch:foo,version:"1.2"`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pipelineFlag.String()).To(Equal(`some-pipeline/branch:foo,version:"1.2"`))
+		})
+
+		It("errors when a pair is missing its value", func() {
+			err := pipelineFlag.UnmarshalFlag("some-pipeline/branch:foo,os")
+			Expect(err).To(MatchError("invalid instance var 'os' (must be key:value)"))
+		})
+	})
+
+	Context("when the value after the '/' is not instance vars", func() {
+		It("keeps it as part of the name, which fails validation", func() {
+			err := pipelineFlag.UnmarshalFlag("some-pipeline/branch")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pipelineFlag.Ref()).To(Equal(atc.PipelineRef{Name: "some-pipeline/branch"}))
+
+			_, err = pipelineFlag.Validate()
+			Expect(err).To(MatchError("pipeline name cannot contain '/'"))
+		})
+	})
+})
@@ -3,22 +3,32 @@ package flaghelpers
 import (
 	"errors"
 	"strings"
+
+	"github.com/concourse/concourse/atc"
 )
 
 type ResourceFlag struct {
-	PipelineName string
+	PipelineRef  atc.PipelineRef
 	ResourceName string
 }
 
 func (resource *ResourceFlag) UnmarshalFlag(value string) error {
-	vs := strings.SplitN(value, "/", 2)
+	resourceSep := strings.LastIndex(value, "/")
+	if resourceSep == -1 {
+		return errors.New("argument format should be <pipeline>/<resource>")
+	}
+
+	pipelineRef, err := unmarshalPipelineRef(value[:resourceSep])
+	if err != nil {
+		return err
+	}
 
-	if len(vs) != 2 {
+	if strings.Contains(pipelineRef.Name, "/") {
 		return errors.New("argument format should be <pipeline>/<resource>")
 	}
 
-	resource.PipelineName = vs[0]
-	resource.ResourceName = vs[1]
+	resource.PipelineRef = pipelineRef
+	resource.ResourceName = value[resourceSep+1:]
 
 	return nil
 }
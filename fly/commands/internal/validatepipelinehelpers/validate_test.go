@@ -133,31 +133,27 @@ jobs:
 		})
 
 		It("validates a good pipeline", func() {
-			err := validatepipelinehelpers.Validate(goodPipeline, false, false, false)
+			err := validatepipelinehelpers.Validate(goodPipeline, false, false)
 			Expect(err).To(BeNil())
 		})
 		It("validates a good pipeline with strict", func() {
-			err := validatepipelinehelpers.Validate(goodPipeline, true, false, false)
+			err := validatepipelinehelpers.Validate(goodPipeline, true, false)
 			Expect(err).To(BeNil())
 		})
 		It("validates a good pipeline with output", func() {
-			err := validatepipelinehelpers.Validate(goodPipeline, true, true, false)
+			err := validatepipelinehelpers.Validate(goodPipeline, true, true)
 			Expect(err).To(BeNil())
 		})
 		It("do not fail validating a pipeline with repeated resource types (probably should but for compat doesn't)", func() {
-			err := validatepipelinehelpers.Validate(dupkeyPipeline, false, false, false)
+			err := validatepipelinehelpers.Validate(dupkeyPipeline, false, false)
 			Expect(err).To(BeNil())
 		})
 		It("fail validating a pipeline with repeated resource types with strict", func() {
-			err := validatepipelinehelpers.Validate(dupkeyPipeline, true, false, false)
+			err := validatepipelinehelpers.Validate(dupkeyPipeline, true, false)
 			Expect(err).ToNot(BeNil())
 		})
-		It("fail validating a pipeline using experimental `across` without the command flag enabling it", func() {
-			err := validatepipelinehelpers.Validate(goodAcrossPipeline, false, false, false)
-			Expect(err).ToNot(BeNil())
-		})
-		It("validates a pipeline using experimental `across` when the command flag enabling it is present", func() {
-			err := validatepipelinehelpers.Validate(goodAcrossPipeline, false, false, true)
+		It("validates a pipeline using `across`", func() {
+			err := validatepipelinehelpers.Validate(goodAcrossPipeline, false, false)
 			Expect(err).To(BeNil())
 		})
 	})
@@ -25,7 +25,10 @@ func (command *OrderPipelinesCommand) Validate() ([]string, error) {
 		if err != nil {
 			return nil, err
 		}
-		pipelines = append(pipelines, string(p))
+		if p.InstanceVars != nil {
+			return nil, errors.New("instanced pipelines are ordered as a group, specify the pipeline name only")
+		}
+		pipelines = append(pipelines, p.Name)
 	}
 	return pipelines, nil
 
@@ -54,7 +57,12 @@ func (command *OrderPipelinesCommand) Execute(args []string) error {
 			return err
 		}
 
+		seen := map[string]bool{}
 		for _, p := range ps {
+			if seen[p.Name] {
+				continue
+			}
+			seen[p.Name] = true
 			pipelines = append(pipelines, p.Name)
 		}
 		sort.Strings(pipelines)
@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type PolicyDecisionsCommand struct {
+	Pipeline string `short:"p" long:"pipeline" description:"Only show decisions made for this pipeline"`
+	Count    int    `short:"c" long:"count" default:"50" description:"Number of decisions you want to limit the return to"`
+	Json     bool   `long:"json" description:"Print command result as JSON"`
+	Team     string `long:"team" description:"Name of the team to show decisions for, if different from the target default"`
+}
+
+func (command *PolicyDecisionsCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var team concourse.Team
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	decisions, err := team.ListPolicyDecisions(command.Pipeline, command.Count)
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(decisions)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "time", Color: color.New(color.Bold)},
+			{Contents: "pipeline", Color: color.New(color.Bold)},
+			{Contents: "action", Color: color.New(color.Bold)},
+			{Contents: "outcome", Color: color.New(color.Bold)},
+			{Contents: "reasons", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, decision := range decisions {
+		pipelineCell := ui.TableCell{Contents: decision.Pipeline}
+		if decision.Pipeline == "" {
+			pipelineCell = ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+		}
+
+		outcomeCell := ui.TableCell{Contents: "rejected", Color: ui.FailedColor}
+		if decision.AuditOnly {
+			outcomeCell = ui.TableCell{Contents: "warned", Color: ui.StartedColor}
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: time.Unix(decision.CreatedAt, 0).Format(timeDateLayout)},
+			pipelineCell,
+			{Contents: decision.Action},
+			outcomeCell,
+			{Contents: strings.Join(decision.Reasons, ", ")},
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
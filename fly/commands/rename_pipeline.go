@@ -1,16 +1,20 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
 	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
 	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/go-concourse/concourse"
 )
 
 type RenamePipelineCommand struct {
 	Pipeline flaghelpers.PipelineFlag `short:"o"  long:"old-name" required:"true"  description:"Pipeline to rename"`
-	Name     flaghelpers.PipelineFlag `short:"n"  long:"new-name" required:"true"  description:"Name to set as pipeline name"`
+	Name     string                   `short:"n"  long:"new-name" required:"true"  description:"Name to set as pipeline name"`
 }
 
 func (command *RenamePipelineCommand) Validate() error {
@@ -19,8 +23,18 @@ func (command *RenamePipelineCommand) Validate() error {
 		return err
 	}
 
-	_, err = command.Name.Validate()
-	return err
+	if strings.Contains(command.Name, "/") {
+		return errors.New("pipeline name cannot contain '/'")
+	}
+
+	if warning := atc.ValidateIdentifier(command.Name, "pipeline"); warning != nil {
+		displayhelpers.ShowWarnings([]concourse.ConfigWarning{{
+			Type:    warning.Type,
+			Message: warning.Message,
+		}})
+	}
+
+	return nil
 }
 
 func (command *RenamePipelineCommand) Execute([]string) error {
@@ -39,10 +53,9 @@ func (command *RenamePipelineCommand) Execute([]string) error {
 		return err
 	}
 
-	oldName := string(command.Pipeline)
-	newName := string(command.Name)
+	newName := command.Name
 
-	found, warnings, err := target.Team().RenamePipeline(oldName, newName)
+	found, warnings, err := target.Team().RenamePipeline(command.Pipeline.Ref(), newName)
 	if err != nil {
 		return err
 	}
@@ -52,7 +65,7 @@ func (command *RenamePipelineCommand) Execute([]string) error {
 	}
 
 	if !found {
-		displayhelpers.Failf("pipeline '%s' not found\n", oldName)
+		displayhelpers.Failf("pipeline '%s' not found\n", command.Pipeline.String())
 		return nil
 	}
 
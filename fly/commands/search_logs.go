@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type SearchLogsCommand struct {
+	Job      flaghelpers.JobFlag      `short:"j" long:"job" value-name:"PIPELINE/JOB" description:"Name of a job to search the builds of"`
+	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" description:"Name of a pipeline to search the builds of"`
+	Count    int                      `short:"c" long:"count" default:"20" description:"Number of builds with matching lines you want to limit the search to"`
+	Json     bool                     `long:"json" description:"Print command result as JSON"`
+	Team     string                   `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+
+	Args struct {
+		Pattern string `positional-arg-name:"PATTERN" required:"true" description:"Words which must all appear in a log line for it to match"`
+	} `positional-args:"yes"`
+}
+
+func (command *SearchLogsCommand) Execute([]string) error {
+	var (
+		pipelineRef atc.PipelineRef
+		jobName     string
+		team        concourse.Team
+	)
+
+	if command.Job.JobName != "" && command.Pipeline.Name != "" {
+		return errors.New("Cannot specify both --pipeline and --job")
+	}
+
+	if command.Job.JobName != "" {
+		pipelineRef = command.Job.PipelineRef
+		jobName = command.Job.JobName
+	} else if command.Pipeline.Name != "" {
+		pipelineRef = command.Pipeline.Ref()
+	} else {
+		return errors.New("Either --pipeline or --job must be specified")
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	if command.Team != "" {
+		team, err = target.FindTeam(command.Team)
+		if err != nil {
+			return err
+		}
+	} else {
+		team = target.Team()
+	}
+
+	search, _, found, err := team.SearchBuildLogs(pipelineRef, jobName, command.Args.Pattern, concourse.Page{Limit: command.Count})
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		if jobName != "" {
+			return fmt.Errorf("pipeline '%s' or job '%s' not found", pipelineRef.String(), jobName)
+		}
+
+		return fmt.Errorf("pipeline '%s' not found", pipelineRef.String())
+	}
+
+	if command.Json {
+		err = displayhelpers.JsonPrint(search)
+		if err != nil {
+			return err
+		}
+		return nil
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "build", Color: color.New(color.Bold)},
+			{Contents: "step", Color: color.New(color.Bold)},
+			{Contents: "line", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, match := range search.Matches {
+		build := match.BuildName
+		if match.JobName != "" {
+			build = match.JobName + "/" + match.BuildName
+		}
+
+		step := match.StepName
+		if step == "" {
+			step = match.StepID
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: build},
+			{Contents: step},
+			{Contents: match.Line},
+		})
+	}
+
+	err = table.Render(os.Stdout, Fly.PrintTableHeaders)
+	if err != nil {
+		return err
+	}
+
+	if search.ArchivedBuilds > 0 {
+		fmt.Fprintf(ui.Stderr, "%d builds were not searched because their logs have been archived; use `fly watch` to read them\n", search.ArchivedBuilds)
+	}
+
+	return nil
+}
@@ -24,6 +24,8 @@ type SetPipelineCommand struct {
 
 	VarsFrom []atc.PathFlag `short:"l"  long:"load-vars-from"  description:"Variable flag that can be used for filling in template values in configuration from a YAML file"`
 
+	InstanceVars []flaghelpers.InstanceVarPairFlag `short:"i"  long:"instance-var"  value-name:"[NAME=YAML]"  description:"Set an instance var identifying this instance of the pipeline. Instance vars are also available for templating the pipeline config"`
+
 	Team string `long:"team"              description:"Name of the team to which the pipeline belongs, if different from the target default"`
 }
 
@@ -47,7 +49,13 @@ func (command *SetPipelineCommand) Execute(args []string) error {
 	}
 	configPath := command.Config
 	templateVariablesFiles := command.VarsFrom
-	pipelineName := string(command.Pipeline)
+	pipelineRef := command.Pipeline.Ref()
+	for _, instanceVar := range command.InstanceVars {
+		if pipelineRef.InstanceVars == nil {
+			pipelineRef.InstanceVars = atc.InstanceVars{}
+		}
+		pipelineRef.InstanceVars[instanceVar.Name] = instanceVar.Value
+	}
 
 	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
 	if err != nil {
@@ -74,7 +82,7 @@ func (command *SetPipelineCommand) Execute(args []string) error {
 
 	atcConfig := setpipelinehelpers.ATCConfig{
 		Team:             team,
-		PipelineName:     pipelineName,
+		PipelineRef:      pipelineRef,
 		TargetName:       Fly.Target,
 		Target:           target.Client().URL(),
 		SkipInteraction:  command.SkipInteractive || command.Config.FromStdin(),
@@ -82,6 +90,11 @@ func (command *SetPipelineCommand) Execute(args []string) error {
 		CommandWarnings:  warnings,
 	}
 
-	yamlTemplateWithParams := templatehelpers.NewYamlTemplateWithParams(configPath, templateVariablesFiles, command.Var, command.YAMLVar)
+	yamlVars := command.YAMLVar
+	for name, value := range pipelineRef.InstanceVars {
+		yamlVars = append(yamlVars, flaghelpers.YAMLVariablePairFlag{Name: name, Value: value})
+	}
+
+	yamlTemplateWithParams := templatehelpers.NewYamlTemplateWithParams(configPath, templateVariablesFiles, command.Var, yamlVars)
 	return atcConfig.Set(yamlTemplateWithParams)
 }
@@ -28,7 +28,16 @@ func WireTeamConnectors(command *flags.Command) {
 type SetTeamCommand struct {
 	Team            flaghelpers.TeamFlag `short:"n" long:"team-name" required:"true" description:"The team to create or modify"`
 	SkipInteractive bool                 `long:"non-interactive" description:"Force apply configuration"`
-	AuthFlags       skycmd.AuthTeamFlags `group:"Authentication"`
+
+	DefaultBuildPriority *int `long:"default-build-priority" value-name:"PRIORITY" description:"Priority of builds for jobs which do not configure one. Builds with a higher priority are started first. Only admins may change it."`
+
+	MaxConcurrentBuilds *int    `long:"max-concurrent-builds" value-name:"COUNT" description:"Maximum number of builds the team may run at once. Further builds stay pending until one finishes."`
+	MaxActiveContainers *int    `long:"max-active-containers" value-name:"COUNT" description:"Maximum number of containers the steps of the team's builds may have on workers at once. Further steps wait until a container is released."`
+	MaxTaskCPU          *uint64 `long:"max-task-cpu" value-name:"SHARES" description:"Maximum CPU limit a task of the team may run with."`
+	MaxTaskMemory       *uint64 `long:"max-task-memory" value-name:"BYTES" description:"Maximum memory limit a task of the team may run with."`
+	MaxBuildPriority    *int    `long:"max-build-priority" value-name:"PRIORITY" description:"Maximum priority of the team's builds. Higher priorities configured on jobs or given when triggering builds are lowered to it."`
+
+	AuthFlags skycmd.AuthTeamFlags `group:"Authentication"`
 }
 
 func (command *SetTeamCommand) Validate() ([]concourse.ConfigWarning, error) {
@@ -99,6 +108,35 @@ func (command *SetTeamCommand) Execute([]string) error {
 		}
 	}
 
+	if command.DefaultBuildPriority != nil {
+		fmt.Println()
+		fmt.Printf("default build priority: %d\n", *command.DefaultBuildPriority)
+	}
+
+	quotas := command.quotas()
+	if quotas != nil {
+		fmt.Println()
+		fmt.Printf("quotas:\n")
+		if *quotas == (atc.TeamQuotas{}) {
+			fmt.Printf("  %s\n", ui.OffColor.Sprint("none"))
+		}
+		if quotas.MaxConcurrentBuilds != 0 {
+			fmt.Printf("  max concurrent builds: %d\n", quotas.MaxConcurrentBuilds)
+		}
+		if quotas.MaxActiveContainers != 0 {
+			fmt.Printf("  max active containers: %d\n", quotas.MaxActiveContainers)
+		}
+		if quotas.MaxTaskCPU != 0 {
+			fmt.Printf("  max task cpu: %d\n", quotas.MaxTaskCPU)
+		}
+		if quotas.MaxTaskMemory != 0 {
+			fmt.Printf("  max task memory: %d\n", quotas.MaxTaskMemory)
+		}
+		if quotas.MaxBuildPriority != nil {
+			fmt.Printf("  max build priority: %d\n", *quotas.MaxBuildPriority)
+		}
+	}
+
 	if len(warnings) > 0 {
 		displayhelpers.ShowWarnings(warnings)
 	}
@@ -116,7 +154,11 @@ func (command *SetTeamCommand) Execute([]string) error {
 		displayhelpers.Failf("bailing out")
 	}
 
-	team := atc.Team{Auth: authRoles}
+	team := atc.Team{
+		Auth:                 authRoles,
+		DefaultBuildPriority: command.DefaultBuildPriority,
+		Quotas:               quotas,
+	}
 
 	_, created, updated, warnings, err := target.Client().Team(teamName).CreateOrUpdate(team)
 	if err != nil {
@@ -135,3 +177,33 @@ func (command *SetTeamCommand) Execute([]string) error {
 
 	return nil
 }
+
+// quotas returns the team quotas to send, or nil when no quota flag was
+// given so that the team's current quotas are left unchanged. Giving any
+// quota flag replaces all of them; quotas that are not given are unlimited.
+func (command *SetTeamCommand) quotas() *atc.TeamQuotas {
+	if command.MaxConcurrentBuilds == nil &&
+		command.MaxActiveContainers == nil &&
+		command.MaxTaskCPU == nil &&
+		command.MaxTaskMemory == nil &&
+		command.MaxBuildPriority == nil {
+		return nil
+	}
+
+	var quotas atc.TeamQuotas
+	if command.MaxConcurrentBuilds != nil {
+		quotas.MaxConcurrentBuilds = *command.MaxConcurrentBuilds
+	}
+	if command.MaxActiveContainers != nil {
+		quotas.MaxActiveContainers = *command.MaxActiveContainers
+	}
+	if command.MaxTaskCPU != nil {
+		quotas.MaxTaskCPU = *command.MaxTaskCPU
+	}
+	if command.MaxTaskMemory != nil {
+		quotas.MaxTaskMemory = *command.MaxTaskMemory
+	}
+	quotas.MaxBuildPriority = command.MaxBuildPriority
+
+	return &quotas
+}
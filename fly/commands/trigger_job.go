@@ -15,13 +15,14 @@ import (
 )
 
 type TriggerJobCommand struct {
-	Job   flaghelpers.JobFlag `short:"j" long:"job" required:"true" value-name:"PIPELINE/JOB" description:"Name of a job to trigger"`
-	Watch bool                `short:"w" long:"watch" description:"Start watching the build output"`
-	Team  string              `long:"team" description:"Name of the team to which the job belongs, if different from the target default"`
+	Job      flaghelpers.JobFlag `short:"j" long:"job" required:"true" value-name:"PIPELINE/JOB" description:"Name of a job to trigger"`
+	Watch    bool                `short:"w" long:"watch" description:"Start watching the build output"`
+	Team     string              `long:"team" description:"Name of the team to which the job belongs, if different from the target default"`
+	Priority *int                `long:"priority" value-name:"PRIORITY" description:"Priority of the build, overriding the one configured for the job or team. Builds with a higher priority are started first."`
 }
 
 func (command *TriggerJobCommand) Execute(args []string) error {
-	pipelineName, jobName := command.Job.PipelineName, command.Job.JobName
+	pipelineName, jobName := command.Job.PipelineRef, command.Job.JobName
 
 	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
 	if err != nil {
@@ -46,7 +47,11 @@ func (command *TriggerJobCommand) Execute(args []string) error {
 		team = target.Team()
 	}
 
-	build, err = team.CreateJobBuild(pipelineName, jobName)
+	if command.Priority != nil {
+		build, err = team.CreateJobBuildWithPriority(pipelineName, jobName, *command.Priority)
+	} else {
+		build, err = team.CreateJobBuild(pipelineName, jobName)
+	}
 	if err != nil {
 		return err
 	} else {
@@ -11,6 +11,7 @@ import (
 	_ "github.com/concourse/concourse/atc/creds/credhub"
 	_ "github.com/concourse/concourse/atc/creds/dummy"
 	_ "github.com/concourse/concourse/atc/creds/kubernetes"
+	_ "github.com/concourse/concourse/atc/creds/local"
 	_ "github.com/concourse/concourse/atc/creds/secretsmanager"
 	_ "github.com/concourse/concourse/atc/creds/ssm"
 	_ "github.com/concourse/concourse/atc/creds/vault"
@@ -20,7 +21,7 @@ type ValidatePipelineCommand struct {
 	Config           atc.PathFlag `short:"c" long:"config" required:"true"  description:"Pipeline configuration file"`
 	Strict           bool         `short:"s" long:"strict"                  description:"Fail on warnings"`
 	Output           bool         `short:"o" long:"output"                  description:"Output templated pipeline to stdout"`
-	EnableAcrossStep bool         `long:"enable-across-step"                description:"Enable the experimental across step to be used in jobs. The API is subject to change."`
+	EnableAcrossStep bool         `long:"enable-across-step" hidden:"true"  description:"Deprecated: the across step is always enabled."`
 
 	Var     []flaghelpers.VariablePairFlag     `short:"v"  long:"var"       value-name:"[NAME=STRING]"  description:"Specify a string value to set for a variable in the pipeline"`
 	YAMLVar []flaghelpers.YAMLVariablePairFlag `short:"y"  long:"yaml-var"  value-name:"[NAME=YAML]"    description:"Specify a YAML value to set for a variable in the pipeline"`
@@ -30,5 +31,5 @@ type ValidatePipelineCommand struct {
 
 func (command *ValidatePipelineCommand) Execute(args []string) error {
 	yamlTemplate := templatehelpers.NewYamlTemplateWithParams(command.Config, command.VarsFrom, command.Var, command.YAMLVar)
-	return validatepipelinehelpers.Validate(yamlTemplate, command.Strict, command.Output, command.EnableAcrossStep)
+	return validatepipelinehelpers.Validate(yamlTemplate, command.Strict, command.Output)
 }
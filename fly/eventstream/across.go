@@ -0,0 +1,95 @@
+package eventstream
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/concourse/concourse/atc/event"
+)
+
+// acrossCombinations keeps track of which combination of an across step's
+// values each step of the build runs with, so that their output can be
+// grouped by combination.
+type acrossCombinations struct {
+	labels map[event.OriginID]string
+}
+
+func newAcrossCombinations() *acrossCombinations {
+	return &acrossCombinations{
+		labels: map[event.OriginID]string{},
+	}
+}
+
+// Add labels every step in the sub-steps of an across step with the values of
+// the combination it runs with, prefixed by the label of the across step
+// itself when it is nested in another across step.
+func (c *acrossCombinations) Add(e event.AcrossSubsteps) {
+	parent := c.labels[e.Origin.ID]
+
+	for _, raw := range e.Substeps {
+		if raw == nil {
+			continue
+		}
+
+		var substep struct {
+			Step   interface{}   `json:"step"`
+			Values []interface{} `json:"values"`
+		}
+
+		err := json.Unmarshal(*raw, &substep)
+		if err != nil {
+			continue
+		}
+
+		pairs := []string{}
+		for i, value := range substep.Values {
+			if i < len(e.Vars) {
+				pairs = append(pairs, fmt.Sprintf("%s: %s", e.Vars[i], presentAcrossValue(value)))
+			}
+		}
+
+		label := strings.Join(pairs, ", ")
+		if parent != "" {
+			label = parent + ", " + label
+		}
+
+		c.labelPlan(substep.Step, label)
+	}
+}
+
+// Label returns the combination the step runs with, if any.
+func (c *acrossCombinations) Label(id event.OriginID) string {
+	return c.labels[id]
+}
+
+func (c *acrossCombinations) labelPlan(plan interface{}, label string) {
+	switch node := plan.(type) {
+	case map[string]interface{}:
+		if id, ok := node["id"].(string); ok {
+			c.labels[event.OriginID(id)] = label
+		}
+
+		for _, child := range node {
+			c.labelPlan(child, label)
+		}
+
+	case []interface{}:
+		for _, child := range node {
+			c.labelPlan(child, label)
+		}
+	}
+}
+
+func presentAcrossValue(value interface{}) string {
+	if str, ok := value.(string); ok {
+		return str
+	}
+
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return string(payload)
+}
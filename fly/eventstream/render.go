@@ -21,6 +21,31 @@ func Render(dst io.Writer, src eventstream.EventStream, options RenderOptions) i
 
 	exitStatus := 0
 
+	// output of steps run by an across step is preceded by the combination
+	// of values the step runs with whenever it changes
+	combinations := newAcrossCombinations()
+	currentCombination := ""
+	atLineStart := true
+
+	group := func(origin event.Origin) {
+		combination := combinations.Label(origin.ID)
+		if combination == currentCombination {
+			return
+		}
+
+		currentCombination = combination
+		if combination == "" {
+			return
+		}
+
+		if !atLineStart {
+			fmt.Fprintln(dstImpl)
+			atLineStart = true
+		}
+
+		fmt.Fprintf(dstImpl, "\x1b[1m[%s]\x1b[0m\n", combination)
+	}
+
 	for {
 		ev, err := src.NextEvent()
 		if err != nil {
@@ -36,32 +61,70 @@ func Render(dst io.Writer, src eventstream.EventStream, options RenderOptions) i
 		}
 
 		switch e := ev.(type) {
+		case event.AcrossSubsteps:
+			combinations.Add(e)
+
 		case event.Log:
 			dstImpl.SetTimestamp(e.Time)
+			group(e.Origin)
 			fmt.Fprintf(dstImpl, "%s", e.Payload)
 
+			if e.Payload != "" {
+				atLineStart = strings.HasSuffix(e.Payload, "\n")
+			}
+
 		case event.SelectedWorker:
 			dstImpl.SetTimestamp(e.Time)
+			group(e.Origin)
 			fmt.Fprintf(dstImpl, "\x1b[1mselected worker:\x1b[0m %s\n", e.WorkerName)
+			for _, step := range e.Placement {
+				switch {
+				case step.Skipped:
+					fmt.Fprintf(dstImpl, "  %s: skipped\n", step.Strategy)
+				case len(step.Eliminated) == 0:
+					fmt.Fprintf(dstImpl, "  %s: eliminated no workers\n", step.Strategy)
+				default:
+					fmt.Fprintf(dstImpl, "  %s: eliminated %s\n", step.Strategy, strings.Join(step.Eliminated, ", "))
+				}
+			}
+			atLineStart = true
 
 		case event.InitializeTask:
 			dstImpl.SetTimestamp(e.Time)
+			group(e.Origin)
 			fmt.Fprintf(dstImpl, "\x1b[1minitializing\x1b[0m\n")
+			atLineStart = true
 
 		case event.StartTask:
 			buildConfig := e.TaskConfig
 
 			argv := strings.Join(append([]string{buildConfig.Run.Path}, buildConfig.Run.Args...), " ")
 			dstImpl.SetTimestamp(e.Time)
+			group(e.Origin)
 			fmt.Fprintf(dstImpl, "\x1b[1mrunning %s\x1b[0m\n", argv)
+			atLineStart = true
 
 		case event.FinishTask:
 			exitStatus = e.ExitStatus
 
+		case event.ResourceUsage:
+			dstImpl.SetTimestamp(e.Time)
+			group(e.Origin)
+			fmt.Fprintf(
+				dstImpl,
+				"\x1b[1mresource usage:\x1b[0m cpu %s, memory peak %s, rootfs disk usage %s\n",
+				ui.PresentCPUTime(e.Usage.CPUTime),
+				ui.PresentBytes(e.Usage.MemoryPeak),
+				ui.PresentBytes(e.Usage.RootfsDiskUsage),
+			)
+			atLineStart = true
+
 		case event.Error:
 			errCol := ui.ErroredColor.SprintFunc()
 			dstImpl.SetTimestamp(0)
+			group(e.Origin)
 			fmt.Fprintf(dstImpl, "%s\n", errCol(e.Message))
+			atLineStart = true
 
 		case event.Status:
 			dstImpl.SetTimestamp(e.Time)
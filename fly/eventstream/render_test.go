@@ -1,6 +1,7 @@
 package eventstream_test
 
 import (
+	"encoding/json"
 	"io"
 	"time"
 
@@ -75,6 +76,48 @@ var _ = Describe("V1.0 Renderer", func() {
 		})
 	})
 
+	Context("when the sub-steps of an across step are received", func() {
+		BeforeEach(func() {
+			substep := func(id string, values ...interface{}) *json.RawMessage {
+				payload, err := json.Marshal(map[string]interface{}{
+					"step": map[string]interface{}{
+						"id": id,
+						"try": map[string]interface{}{
+							"step": map[string]interface{}{"id": id + "/task"},
+						},
+					},
+					"values": values,
+				})
+				Expect(err).ToNot(HaveOccurred())
+				msg := json.RawMessage(payload)
+				return &msg
+			}
+
+			receivedEvents <- event.AcrossSubsteps{
+				Origin:   event.Origin{ID: "across"},
+				Vars:     []string{"os", "version"},
+				Substeps: []*json.RawMessage{substep("1", "linux", 1), substep("2", "darwin", 2)},
+			}
+			receivedEvents <- event.Log{Origin: event.Origin{ID: "1/task"}, Payload: "from linux "}
+			receivedEvents <- event.Log{Origin: event.Origin{ID: "1/task"}, Payload: "again\n"}
+			receivedEvents <- event.Log{Origin: event.Origin{ID: "2/task"}, Payload: "from darwin"}
+			receivedEvents <- event.Log{Origin: event.Origin{ID: "1/task"}, Payload: "back to linux\n"}
+			receivedEvents <- event.Log{Origin: event.Origin{ID: "other"}, Payload: "not across\n"}
+		})
+
+		It("groups the output of the steps by combination", func() {
+			Expect(string(out.Contents())).To(Equal(
+				"\x1b[1m[os: linux, version: 1]\x1b[0m\n" +
+					"from linux again\n" +
+					"\x1b[1m[os: darwin, version: 2]\x1b[0m\n" +
+					"from darwin\n" +
+					"\x1b[1m[os: linux, version: 1]\x1b[0m\n" +
+					"back to linux\n" +
+					"not across\n",
+			))
+		})
+	})
+
 	Context("when an Error event is received", func() {
 		BeforeEach(func() {
 			receivedEvents <- event.Error{
@@ -308,6 +351,56 @@ var _ = Describe("V1.0 Renderer", func() {
 			Expect(out.Contents()).To(ContainSubstring("\x1b[1mselected worker:\u001B[0m some-worker\n"))
 		})
 
+		Context("when the worker was chosen by chained placement strategies", func() {
+			BeforeEach(func() {
+				receivedEvents <- event.SelectedWorker{
+					Time:       time.Now().Unix(),
+					WorkerName: "other-worker",
+					Placement: []atc.PlacementStep{
+						{Strategy: "limit-active-tasks", Eliminated: []string{"some-worker", "another-worker"}},
+						{Strategy: "resource-fit", Skipped: true},
+						{Strategy: "fewest-build-containers"},
+					},
+				}
+			})
+
+			It("prints which workers each strategy eliminated", func() {
+				Expect(out.Contents()).To(ContainSubstring(
+					"\x1b[1mselected worker:\u001B[0m other-worker\n" +
+						"  limit-active-tasks: eliminated some-worker, another-worker\n" +
+						"  resource-fit: skipped\n" +
+						"  fewest-build-containers: eliminated no workers\n",
+				))
+			})
+		})
+
+		Context("and time configuration enabled", func() {
+			BeforeEach(func() {
+				options.ShowTimestamp = true
+			})
+
+			It("timestamp is prefixed", func() {
+				Expect(out).To(gbytes.Say(`\d{2}\:\d{2}\:\d{2}\s{2}\w*`))
+			})
+		})
+	})
+
+	Context("when a ResourceUsage event is received", func() {
+		BeforeEach(func() {
+			receivedEvents <- event.ResourceUsage{
+				Time: time.Now().Unix(),
+				Usage: atc.ResourceUsage{
+					CPUTime:         1234567890,
+					MemoryPeak:      512 * 1024 * 1024,
+					RootfsDiskUsage: 10 * 1024 * 1024,
+				},
+			}
+		})
+
+		It("prints the resources consumed by the step", func() {
+			Expect(out.Contents()).To(ContainSubstring("\x1b[1mresource usage:\x1b[0m cpu 1.235s, memory peak 512.0MiB, rootfs disk usage 10.0MiB\n"))
+		})
+
 		Context("and time configuration enabled", func() {
 			BeforeEach(func() {
 				options.ShowTimestamp = true
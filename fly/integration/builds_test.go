@@ -68,6 +68,7 @@ var _ = Describe("Fly CLI", func() {
 				{Contents: "end", Color: color.New(color.Bold)},
 				{Contents: "duration", Color: color.New(color.Bold)},
 				{Contents: "team", Color: color.New(color.Bold)},
+				{Contents: "priority", Color: color.New(color.Bold)},
 			}
 		})
 
@@ -103,14 +104,16 @@ var _ = Describe("Fly CLI", func() {
 						TeamName:     "team1",
 					},
 					{
-						ID:           3,
-						PipelineName: "some-other-pipeline",
-						JobName:      "some-other-job",
-						Name:         "63",
-						Status:       "pending",
-						StartTime:    pendingBuildStartTime.Unix(),
-						EndTime:      pendingBuildEndTime.Unix(),
-						TeamName:     "team1",
+						ID:            3,
+						PipelineName:  "some-other-pipeline",
+						JobName:       "some-other-job",
+						Name:          "63",
+						Status:        "pending",
+						StartTime:     pendingBuildStartTime.Unix(),
+						EndTime:       pendingBuildEndTime.Unix(),
+						TeamName:      "team1",
+						Priority:      10,
+						QuotaExceeded: true,
 					},
 					{
 						ID:           1000001,
@@ -172,7 +175,9 @@ var _ = Describe("Fly CLI", func() {
                 "api_url": "",
                 "pipeline_name": "some-other-pipeline",
                 "start_time": 1448932815,
-                "end_time": 1448937315
+                "end_time": 1448937315,
+                "priority": 10,
+                "quota_exceeded": true
               },
               {
                 "id": 1000001,
@@ -223,16 +228,18 @@ var _ = Describe("Fly CLI", func() {
 								}.String(),
 							},
 							{Contents: "team1"},
+							{Contents: "0"},
 						},
 						{
 							{Contents: "3"},
 							{Contents: "some-other-pipeline/some-other-job"},
 							{Contents: "63"},
-							{Contents: "pending"},
+							{Contents: "pending (quota exceeded)"},
 							{Contents: pendingBuildStartTime.Local().Format(timeDateLayout)},
 							{Contents: pendingBuildEndTime.Local().Format(timeDateLayout)},
 							{Contents: "1h15m0s"},
 							{Contents: "team1"},
+							{Contents: "10"},
 						},
 						{
 							{Contents: "1000001"},
@@ -243,6 +250,7 @@ var _ = Describe("Fly CLI", func() {
 							{Contents: erroredBuildEndTime.Local().Format(timeDateLayout)},
 							{Contents: "2h45m0s"},
 							{Contents: "team1"},
+							{Contents: "0"},
 						},
 						{
 							{Contents: "1002"},
@@ -253,6 +261,7 @@ var _ = Describe("Fly CLI", func() {
 							{Contents: abortedBuildEndTime.Local().Format(timeDateLayout)},
 							{Contents: "n/a"},
 							{Contents: "team1"},
+							{Contents: "0"},
 						},
 						{
 							{Contents: "39"},
@@ -263,6 +272,7 @@ var _ = Describe("Fly CLI", func() {
 							{Contents: "n/a"},
 							{Contents: "n/a"},
 							{Contents: "team1"},
+							{Contents: "0"},
 						},
 					},
 				}))
@@ -282,6 +292,128 @@ var _ = Describe("Fly CLI", func() {
 			})
 		})
 
+		Context("when passing the secret argument", func() {
+			BeforeEach(func() {
+				cmdArgs = append(cmdArgs, "--secret", "prod-db-password")
+
+				expectedURL = "/api/v1/builds"
+				queryParams = "limit=50&secret=prod-db-password"
+
+				returnedStatusCode = http.StatusOK
+				returnedBuilds = []atc.Build{
+					{
+						ID:           3,
+						PipelineName: "some-pipeline",
+						JobName:      "some-job",
+						Name:         "63",
+						Status:       "succeeded",
+						StartTime:    succeededBuildStartTime.Unix(),
+						EndTime:      succeededBuildEndTime.Unix(),
+						TeamName:     "main",
+					},
+				}
+			})
+
+			It("asks the server for the builds which looked up the secret", func() {
+				Eventually(session.Out).Should(PrintTable(ui.Table{
+					Headers: expectedHeaders,
+					Data: []ui.TableRow{
+						{
+							{Contents: "3"},
+							{Contents: "some-pipeline/some-job"},
+							{Contents: "63"},
+							{Contents: "succeeded", Color: color.New(color.FgGreen)},
+							{Contents: succeededBuildStartTime.Local().Format(timeDateLayout)},
+							{Contents: succeededBuildEndTime.Local().Format(timeDateLayout)},
+							{Contents: "1h15m0s"},
+							{Contents: "main"},
+							{Contents: "0"},
+						},
+					},
+				}))
+
+				Eventually(session).Should(gexec.Exit(0))
+			})
+		})
+
+		Context("when passing the resource-usage argument", func() {
+			BeforeEach(func() {
+				cmdArgs = append(cmdArgs, "--resource-usage")
+
+				expectedURL = "/api/v1/builds"
+				queryParams = "limit=50"
+
+				returnedStatusCode = http.StatusOK
+				returnedBuilds = []atc.Build{
+					{
+						ID:           3,
+						PipelineName: "some-pipeline",
+						JobName:      "some-job",
+						Name:         "63",
+						Status:       "succeeded",
+						StartTime:    succeededBuildStartTime.Unix(),
+						EndTime:      succeededBuildEndTime.Unix(),
+						TeamName:     "main",
+						ResourceUsage: &atc.ResourceUsage{
+							CPUTime:         uint64(90 * time.Second),
+							MemoryPeak:      1536 * 1024 * 1024,
+							RootfsDiskUsage: 300 * 1024,
+						},
+					},
+					{
+						ID:           4,
+						PipelineName: "some-pipeline",
+						JobName:      "some-job",
+						Name:         "64",
+						Status:       "pending",
+						TeamName:     "main",
+					},
+				}
+			})
+
+			It("shows the resources consumed by each build", func() {
+				Eventually(session.Out).Should(PrintTable(ui.Table{
+					Headers: append(expectedHeaders,
+						ui.TableCell{Contents: "cpu", Color: color.New(color.Bold)},
+						ui.TableCell{Contents: "memory peak", Color: color.New(color.Bold)},
+						ui.TableCell{Contents: "rootfs disk usage", Color: color.New(color.Bold)},
+					),
+					Data: []ui.TableRow{
+						{
+							{Contents: "3"},
+							{Contents: "some-pipeline/some-job"},
+							{Contents: "63"},
+							{Contents: "succeeded", Color: color.New(color.FgGreen)},
+							{Contents: succeededBuildStartTime.Local().Format(timeDateLayout)},
+							{Contents: succeededBuildEndTime.Local().Format(timeDateLayout)},
+							{Contents: "1h15m0s"},
+							{Contents: "main"},
+							{Contents: "0"},
+							{Contents: "1m30s"},
+							{Contents: "1.5GiB"},
+							{Contents: "300.0KiB"},
+						},
+						{
+							{Contents: "4"},
+							{Contents: "some-pipeline/some-job"},
+							{Contents: "64"},
+							{Contents: "pending", Color: color.New(color.FgWhite)},
+							{Contents: "n/a"},
+							{Contents: "n/a"},
+							{Contents: "n/a"},
+							{Contents: "main"},
+							{Contents: "0"},
+							{Contents: "n/a", Color: color.New(color.Faint)},
+							{Contents: "n/a", Color: color.New(color.Faint)},
+							{Contents: "n/a", Color: color.New(color.Faint)},
+						},
+					},
+				}))
+
+				Eventually(session).Should(gexec.Exit(0))
+			})
+		})
+
 		Context("when validating parameters", func() {
 			Context("when specifying --all-teams and --team", func() {
 				BeforeEach(func() {
@@ -356,6 +488,8 @@ var _ = Describe("Fly CLI", func() {
 							{Contents: "n/a"},
 							{Contents: "n/a"},
 							{Contents: "n/a"},
+							{Contents: ""},
+							{Contents: "0"},
 						},
 					},
 				}))
@@ -370,6 +504,8 @@ var _ = Describe("Fly CLI", func() {
 							{Contents: "n/a"},
 							{Contents: "n/a"},
 							{Contents: "n/a"},
+							{Contents: ""},
+							{Contents: "0"},
 						},
 					},
 				}))
@@ -411,6 +547,8 @@ var _ = Describe("Fly CLI", func() {
 							{Contents: succeededBuildStartTime.Local().Format(timeDateLayout)},
 							{Contents: succeededBuildEndTime.Local().Format(timeDateLayout)},
 							{Contents: "1h15m0s"},
+							{Contents: ""},
+							{Contents: "0"},
 						},
 					},
 				}))
@@ -491,6 +629,8 @@ var _ = Describe("Fly CLI", func() {
 								{Contents: succeededBuildStartTime.Local().Format(timeDateLayout)},
 								{Contents: succeededBuildEndTime.Local().Format(timeDateLayout)},
 								{Contents: "1h15m0s"},
+								{Contents: ""},
+								{Contents: "0"},
 							},
 						},
 					}))
@@ -531,6 +671,8 @@ var _ = Describe("Fly CLI", func() {
 							{Contents: succeededBuildStartTime.Local().Format(timeDateLayout)},
 							{Contents: succeededBuildEndTime.Local().Format(timeDateLayout)},
 							{Contents: "1h15m0s"},
+							{Contents: ""},
+							{Contents: "0"},
 						},
 					},
 				}))
@@ -580,6 +722,8 @@ var _ = Describe("Fly CLI", func() {
 								{Contents: succeededBuildStartTime.Local().Format(timeDateLayout)},
 								{Contents: succeededBuildEndTime.Local().Format(timeDateLayout)},
 								{Contents: "1h15m0s"},
+								{Contents: ""},
+								{Contents: "0"},
 							},
 						},
 					}))
@@ -624,6 +768,7 @@ var _ = Describe("Fly CLI", func() {
 								{Contents: succeededBuildEndTime.Local().Format(timeDateLayout)},
 								{Contents: "1h15m0s"},
 								{Contents: "team1"},
+								{Contents: "0"},
 							},
 						},
 					}))
@@ -692,6 +837,7 @@ var _ = Describe("Fly CLI", func() {
 								{Contents: succeededBuildEndTime.Local().Format(timeDateLayout)},
 								{Contents: "1h15m0s"},
 								{Contents: "team1"},
+								{Contents: "0"},
 							},
 
 							{
@@ -703,6 +849,7 @@ var _ = Describe("Fly CLI", func() {
 								{Contents: succeededBuildEndTime.Local().Format(timeDateLayout)},
 								{Contents: "1h15m0s"},
 								{Contents: "team2"},
+								{Contents: "0"},
 							},
 						},
 					}))
@@ -791,6 +938,7 @@ var _ = Describe("Fly CLI", func() {
 							{Contents: succeededBuildEndTime.Local().Format(timeDateLayout)},
 							{Contents: "1h15m0s"},
 							{Contents: "team1"},
+							{Contents: "0"},
 						},
 						{
 							{Contents: "4"},
@@ -801,6 +949,7 @@ var _ = Describe("Fly CLI", func() {
 							{Contents: succeededBuildEndTime.Local().Format(timeDateLayout)},
 							{Contents: "1h15m0s"},
 							{Contents: "team2"},
+							{Contents: "0"},
 						},
 					},
 				}))
@@ -852,6 +1001,7 @@ var _ = Describe("Fly CLI", func() {
 							{Contents: succeededBuildEndTime.Local().Format(timeDateLayout)},
 							{Contents: "1h15m0s"},
 							{Contents: "team1"},
+							{Contents: "0"},
 						},
 					},
 				}))
@@ -892,6 +1042,8 @@ var _ = Describe("Fly CLI", func() {
 							{Contents: succeededBuildStartTime.Local().Format(timeDateLayout)},
 							{Contents: succeededBuildEndTime.Local().Format(timeDateLayout)},
 							{Contents: "1h15m0s"},
+							{Contents: ""},
+							{Contents: "0"},
 						},
 					},
 				}))
@@ -952,6 +1104,8 @@ var _ = Describe("Fly CLI", func() {
 								{Contents: succeededBuildStartTime.Local().Format(timeDateLayout)},
 								{Contents: succeededBuildEndTime.Local().Format(timeDateLayout)},
 								{Contents: "1h15m0s"},
+								{Contents: ""},
+								{Contents: "0"},
 							},
 						},
 					}))
@@ -0,0 +1,311 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/event"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/vito/go-sse/sse"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("execute --job", func() {
+		var (
+			inputDir string
+
+			config              atc.Config
+			buildInputs         []atc.BuildInput
+			expectedBuildConfig atc.JobOneOffBuildConfig
+
+			streaming chan struct{}
+			events    chan atc.Event
+			uploading chan struct{}
+		)
+
+		BeforeEach(func() {
+			var err error
+			inputDir, err = ioutil.TempDir("", "fly-input-dir")
+			Expect(err).NotTo(HaveOccurred())
+
+			err = ioutil.WriteFile(filepath.Join(inputDir, "some-file"), []byte("blob"), 0644)
+			Expect(err).NotTo(HaveOccurred())
+
+			config = atc.Config{
+				Resources: atc.ResourceConfigs{
+					{
+						Name:   "some-resource",
+						Type:   "git",
+						Source: atc.Source{"uri": "https://internet.com"},
+					},
+					{
+						Name:   "some-other-resource",
+						Type:   "git",
+						Source: atc.Source{"uri": "https://example.com"},
+					},
+				},
+				Jobs: atc.JobConfigs{
+					{
+						Name: "some-job",
+						PlanSequence: []atc.Step{
+							{
+								Config: &atc.InParallelStep{
+									Config: atc.InParallelConfig{
+										Steps: []atc.Step{
+											{
+												Config: &atc.GetStep{
+													Name:     "some-input",
+													Resource: "some-resource",
+												},
+											},
+											{
+												Config: &atc.GetStep{
+													Name:     "some-other-input",
+													Resource: "some-other-resource",
+													Params:   atc.Params{"some": "other-params"},
+												},
+											},
+										},
+									},
+								},
+							},
+							{
+								Config: &atc.TaskStep{
+									Name:       "some-task",
+									ConfigPath: "some-input/task.yml",
+								},
+							},
+							{
+								Config: &atc.PutStep{
+									Name:     "some-output",
+									Resource: "some-resource",
+									Params:   atc.Params{"repository": "some-other-input"},
+								},
+							},
+						},
+					},
+				},
+			}
+
+			buildInputs = []atc.BuildInput{
+				{
+					Name:     "some-input",
+					Type:     "git",
+					Resource: "some-resource",
+					Source:   atc.Source{"uri": "https://internet.com"},
+					Version:  atc.Version{"some": "version"},
+				},
+				{
+					Name:     "some-other-input",
+					Type:     "git",
+					Resource: "some-other-resource",
+					Source:   atc.Source{"uri": "https://example.com"},
+					Params:   atc.Params{"some": "other-params"},
+					Version:  atc.Version{"some": "other-version"},
+				},
+			}
+
+			streaming = make(chan struct{})
+			events = make(chan atc.Event)
+			uploading = make(chan struct{})
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(inputDir)
+		})
+
+		JustBeforeEach(func() {
+			atcServer.RouteToHandler("GET", "/api/v1/teams/main/pipelines/some-pipeline/config",
+				ghttp.RespondWithJSONEncoded(http.StatusOK, atc.ConfigResponse{Config: config}, http.Header{atc.ConfigVersionHeader: {"42"}}),
+			)
+			atcServer.RouteToHandler("GET", "/api/v1/teams/main/pipelines/some-pipeline/jobs/some-job/inputs",
+				ghttp.RespondWithJSONEncoded(http.StatusOK, buildInputs),
+			)
+			atcServer.RouteToHandler("POST", "/api/v1/teams/main/artifacts",
+				ghttp.CombineHandlers(
+					func(w http.ResponseWriter, req *http.Request) {
+						close(uploading)
+					},
+					ghttp.RespondWithJSONEncoded(http.StatusCreated, atc.WorkerArtifact{ID: 125}),
+				),
+			)
+			atcServer.RouteToHandler("POST", "/api/v1/teams/main/pipelines/some-pipeline/jobs/some-job/one-off-builds",
+				ghttp.CombineHandlers(
+					ghttp.VerifyJSONRepresenting(expectedBuildConfig),
+					ghttp.RespondWith(http.StatusCreated, `{"id":128}`),
+				),
+			)
+			atcServer.RouteToHandler("GET", "/api/v1/builds/128/events",
+				func(w http.ResponseWriter, r *http.Request) {
+					flusher := w.(http.Flusher)
+
+					w.Header().Add("Content-Type", "text/event-stream; charset=utf-8")
+					w.Header().Add("Cache-Control", "no-cache, no-store, must-revalidate")
+					w.Header().Add("Connection", "keep-alive")
+
+					w.WriteHeader(http.StatusOK)
+
+					flusher.Flush()
+
+					close(streaming)
+
+					id := 0
+
+					for e := range events {
+						payload, err := json.Marshal(event.Message{Event: e})
+						Expect(err).NotTo(HaveOccurred())
+
+						event := sse.Event{
+							ID:   fmt.Sprintf("%d", id),
+							Name: "event",
+							Data: payload,
+						}
+
+						err = event.Write(w)
+						Expect(err).NotTo(HaveOccurred())
+
+						flusher.Flush()
+
+						id++
+					}
+
+					err := sse.Event{
+						Name: "end",
+					}.Write(w)
+					Expect(err).NotTo(HaveOccurred())
+				},
+			)
+			atcServer.RouteToHandler("GET", "/api/v1/builds/128/artifacts",
+				ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.WorkerArtifact{}),
+			)
+		})
+
+		runsTheBuild := func(args ...string) {
+			flyCmd := exec.Command(flyPath, append([]string{"-t", targetName, "execute", "--job", "some-pipeline/some-job"}, args...)...)
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(streaming).Should(BeClosed())
+
+			events <- event.Log{Payload: "sup"}
+			close(events)
+
+			Eventually(sess.Out).Should(gbytes.Say("sup"))
+
+			<-sess.Exited
+			Expect(sess).To(gexec.Exit(0))
+		}
+
+		Context("when inputs are overridden and puts are skipped", func() {
+			var outputDir string
+
+			BeforeEach(func() {
+				var err error
+				outputDir, err = ioutil.TempDir("", "fly-output-dir")
+				Expect(err).NotTo(HaveOccurred())
+
+				expectedBuildConfig = atc.JobOneOffBuildConfig{
+					Inputs:   map[string]int{"some-input": 125},
+					SkipPuts: true,
+					Outputs:  []string{"some-artifact"},
+				}
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(outputDir)
+			})
+
+			It("has the ATC run the plan of the job with the local inputs", func() {
+				runsTheBuild(
+					"--input", fmt.Sprintf("some-input=%s", inputDir),
+					"--output", fmt.Sprintf("some-artifact=%s", outputDir),
+					"--skip-puts",
+				)
+				Expect(uploading).To(BeClosed())
+			})
+		})
+
+		Context("when nothing is overridden", func() {
+			BeforeEach(func() {
+				expectedBuildConfig = atc.JobOneOffBuildConfig{}
+			})
+
+			It("has the ATC run the whole plan of the job", func() {
+				runsTheBuild()
+				Expect(uploading).ToNot(BeClosed())
+			})
+		})
+
+		Context("when an input which is not overridden has no version", func() {
+			BeforeEach(func() {
+				buildInputs = buildInputs[:1]
+			})
+
+			It("errors before uploading the local inputs", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "execute", "--job", "some-pipeline/some-job", "--input", fmt.Sprintf("some-input=%s", inputDir))
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess.Err).Should(gbytes.Say("no version available for input `some-other-input`"))
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(1))
+				Expect(uploading).ToNot(BeClosed())
+			})
+		})
+
+		Context("when overriding an input the job does not have", func() {
+			It("errors", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "execute", "--job", "some-pipeline/some-job", "--input", fmt.Sprintf("bogus=%s", inputDir))
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess.Err).Should(gbytes.Say("unknown input `bogus`"))
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(1))
+			})
+		})
+
+		Context("when a task config is also given", func() {
+			It("errors", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "execute", "--job", "some-pipeline/some-job", "--config", filepath.Join(inputDir, "some-file"))
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess.Err).Should(gbytes.Say("--job cannot be used with --config"))
+
+				<-sess.Exited
+				Expect(sess.ExitCode()).To(Equal(1))
+			})
+		})
+	})
+
+	Context("when neither a task config nor a job is given", func() {
+		It("errors", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "execute")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess.Err).Should(gbytes.Say("either --config or --job must be specified"))
+
+			<-sess.Exited
+			Expect(sess.ExitCode()).To(Equal(1))
+		})
+	})
+})
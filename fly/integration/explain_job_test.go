@@ -0,0 +1,199 @@
+package integration_test
+
+import (
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("explain-job", func() {
+		var (
+			flyCmd      *exec.Cmd
+			explanation atc.SchedulingExplanation
+		)
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "explain-job", "-j", "some-pipeline/some-job")
+
+			explanation = atc.SchedulingExplanation{
+				InputsDetermined: false,
+				Inputs: []atc.InputExplanation{
+					{
+						Name:     "some-input",
+						Resource: "some-resource",
+						Passed:   []string{"other-upstream-job", "upstream-job"},
+						Error:    "no satisfiable builds from passed jobs found for set of inputs",
+						Candidates: []atc.CandidateVersion{
+							{
+								Version:   atc.Version{"ref": "v2"},
+								PassedJob: "upstream-job",
+								BuildID:   42,
+								BuildName: "7",
+								Rejection: "version is disabled",
+							},
+							{
+								Version:   atc.Version{"ref": "v1"},
+								PassedJob: "upstream-job",
+								BuildID:   41,
+								BuildName: "6",
+								Rejection: "no build of another passed job could be used alongside this version",
+								Conflict: &atc.CandidateConflict{
+									PassedJob: "other-upstream-job",
+									BuildID:   43,
+									BuildName: "3",
+								},
+							},
+						},
+					},
+					{
+						Name:     "some-other-input",
+						Resource: "some-other-resource",
+						Version:  atc.Version{"ref": "v1"},
+						Candidates: []atc.CandidateVersion{
+							{
+								Version: atc.Version{"ref": "v1"},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		Context("when the job exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/jobs/some-job/scheduling-explanation"),
+						ghttp.RespondWithJSONEncoded(200, explanation),
+					),
+				)
+			})
+
+			It("explains how each input was resolved", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "input", Color: color.New(color.Bold)},
+						{Contents: "version", Color: color.New(color.Bold)},
+						{Contents: "passed build", Color: color.New(color.Bold)},
+						{Contents: "outcome", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{
+							{Contents: "some-input"},
+							{Contents: "ref:v2"},
+							{Contents: "upstream-job/7"},
+							{Contents: "version is disabled", Color: ui.FailedColor},
+						},
+						{
+							{Contents: "some-input"},
+							{Contents: "ref:v1"},
+							{Contents: "upstream-job/6"},
+							{Contents: "no build of another passed job could be used alongside this version (conflicts with other-upstream-job/3)", Color: ui.FailedColor},
+						},
+						{
+							{Contents: "some-input"},
+							{Contents: "n/a", Color: ui.OffColor},
+							{Contents: "n/a", Color: ui.OffColor},
+							{Contents: "no satisfiable builds from passed jobs found for set of inputs", Color: ui.FailedColor},
+						},
+						{
+							{Contents: "some-other-input"},
+							{Contents: "ref:v1"},
+							{Contents: "n/a", Color: ui.OffColor},
+							{Contents: "chosen", Color: ui.SucceededColor},
+						},
+					},
+				}))
+
+				Expect(sess.Err).To(gbytes.Say("the inputs for the next build of the job could not be determined"))
+			})
+
+			Context("when --json is given", func() {
+				BeforeEach(func() {
+					flyCmd.Args = append(flyCmd.Args, "--json")
+				})
+
+				It("prints response in json as stdout", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Out.Contents()).To(MatchJSON(`{
+						"inputs_determined": false,
+						"inputs": [
+							{
+								"name": "some-input",
+								"resource": "some-resource",
+								"passed": ["other-upstream-job", "upstream-job"],
+								"error": "no satisfiable builds from passed jobs found for set of inputs",
+								"candidates": [
+									{
+										"version": {"ref": "v2"},
+										"passed_job": "upstream-job",
+										"build_id": 42,
+										"build_name": "7",
+										"rejection": "version is disabled"
+									},
+									{
+										"version": {"ref": "v1"},
+										"passed_job": "upstream-job",
+										"build_id": 41,
+										"build_name": "6",
+										"rejection": "no build of another passed job could be used alongside this version",
+										"conflict": {
+											"passed_job": "other-upstream-job",
+											"build_id": 43,
+											"build_name": "3"
+										}
+									}
+								]
+							},
+							{
+								"name": "some-other-input",
+								"resource": "some-other-resource",
+								"version": {"ref": "v1"},
+								"candidates": [
+									{
+										"version": {"ref": "v1"}
+									}
+								]
+							}
+						]
+					}`))
+				})
+			})
+		})
+
+		Context("when the job does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/jobs/some-job/scheduling-explanation"),
+						ghttp.RespondWith(404, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("pipeline 'some-pipeline' or job 'some-job' not found"))
+			})
+		})
+	})
+})
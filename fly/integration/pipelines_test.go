@@ -115,6 +115,39 @@ var _ = Describe("Fly CLI", func() {
 				})
 			})
 
+			Context("when pipelines are instances of the same pipeline", func() {
+				BeforeEach(func() {
+					atcServer.AppendHandlers(
+						ghttp.CombineHandlers(
+							ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines"),
+							ghttp.RespondWithJSONEncoded(200, []atc.Pipeline{
+								{Name: "some-pipeline", InstanceVars: atc.InstanceVars{"branch": "master"}, LastUpdated: 1},
+								{Name: "some-pipeline", InstanceVars: atc.InstanceVars{"branch": "feature"}, LastUpdated: 1},
+							}),
+						),
+					)
+				})
+
+				It("shows the instance vars of each pipeline", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+					Eventually(sess).Should(gexec.Exit(0))
+
+					Expect(sess.Out).To(PrintTableWithHeaders(ui.Table{
+						Headers: ui.TableRow{
+							{Contents: "name", Color: color.New(color.Bold)},
+							{Contents: "paused", Color: color.New(color.Bold)},
+							{Contents: "public", Color: color.New(color.Bold)},
+							{Contents: "last updated", Color: color.New(color.Bold)},
+						},
+						Data: []ui.TableRow{
+							{{Contents: "some-pipeline/branch:master"}, {Contents: "no"}, {Contents: "no"}, {Contents: time.Unix(1, 0).String()}},
+							{{Contents: "some-pipeline/branch:feature"}, {Contents: "no"}, {Contents: "no"}, {Contents: time.Unix(1, 0).String()}},
+						},
+					}))
+				})
+			})
+
 			Context("when --all is specified", func() {
 				BeforeEach(func() {
 					flyCmd.Args = append(flyCmd.Args, "--all")
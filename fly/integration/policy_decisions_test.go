@@ -0,0 +1,161 @@
+package integration_test
+
+import (
+	"os/exec"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("policy-decisions", func() {
+		var (
+			flyCmd    *exec.Cmd
+			createdAt time.Time
+		)
+
+		BeforeEach(func() {
+			flyCmd = exec.Command(flyPath, "-t", targetName, "policy-decisions")
+			createdAt = time.Date(2020, 10, 15, 12, 0, 0, 0, time.UTC)
+		})
+
+		Context("when decisions are returned from the API", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/policy_decisions", "limit=50"),
+						ghttp.RespondWithJSONEncoded(200, []atc.PolicyDecision{
+							{
+								ID:        2,
+								Team:      "main",
+								Pipeline:  "some-pipeline",
+								Action:    "SaveConfig",
+								Reasons:   []string{"reason-1", "reason-2"},
+								AuditOnly: true,
+								CreatedAt: createdAt.Unix(),
+							},
+							{
+								ID:        1,
+								Team:      "main",
+								Action:    "UseImage",
+								Reasons:   []string{"reason-3"},
+								CreatedAt: createdAt.Unix(),
+							},
+						}),
+					),
+				)
+			})
+
+			It("lists them to the user", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "time", Color: color.New(color.Bold)},
+						{Contents: "pipeline", Color: color.New(color.Bold)},
+						{Contents: "action", Color: color.New(color.Bold)},
+						{Contents: "outcome", Color: color.New(color.Bold)},
+						{Contents: "reasons", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{
+							{Contents: createdAt.Local().Format(timeDateLayout)},
+							{Contents: "some-pipeline"},
+							{Contents: "SaveConfig"},
+							{Contents: "warned", Color: ui.StartedColor},
+							{Contents: "reason-1, reason-2"},
+						},
+						{
+							{Contents: createdAt.Local().Format(timeDateLayout)},
+							{Contents: "n/a", Color: ui.OffColor},
+							{Contents: "UseImage"},
+							{Contents: "rejected", Color: ui.FailedColor},
+							{Contents: "reason-3"},
+						},
+					},
+				}))
+			})
+
+			Context("when --json is given", func() {
+				BeforeEach(func() {
+					flyCmd.Args = append(flyCmd.Args, "--json")
+				})
+
+				It("prints response in json as stdout", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Out.Contents()).To(MatchJSON(`[
+						{
+							"id": 2,
+							"team": "main",
+							"pipeline": "some-pipeline",
+							"action": "SaveConfig",
+							"reasons": ["reason-1", "reason-2"],
+							"audit_only": true,
+							"created_at": 1602763200
+						},
+						{
+							"id": 1,
+							"team": "main",
+							"action": "UseImage",
+							"reasons": ["reason-3"],
+							"audit_only": false,
+							"created_at": 1602763200
+						}
+					]`))
+				})
+			})
+		})
+
+		Context("when filtering by pipeline", func() {
+			BeforeEach(func() {
+				flyCmd.Args = append(flyCmd.Args, "-p", "some-pipeline", "-c", "10")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/policy_decisions", "limit=10&pipeline=some-pipeline"),
+						ghttp.RespondWithJSONEncoded(200, []atc.PolicyDecision{}),
+					),
+				)
+			})
+
+			It("passes the filters on to the API", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+			})
+		})
+
+		Context("when the API returns an error", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/policy_decisions"),
+						ghttp.RespondWith(500, ""),
+					),
+				)
+			})
+
+			It("writes an error message to stderr", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Eventually(sess.Err).Should(gbytes.Say("Unexpected Response"))
+			})
+		})
+	})
+})
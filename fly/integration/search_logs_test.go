@@ -0,0 +1,195 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("search-logs", func() {
+		var (
+			flyCmd       *exec.Cmd
+			search       atc.BuildLogSearch
+			searchStatus int
+		)
+
+		BeforeEach(func() {
+			searchStatus = http.StatusOK
+			search = atc.BuildLogSearch{
+				Matches: []atc.BuildLogMatch{
+					{
+						BuildID:   42,
+						BuildName: "3",
+						JobName:   "some-job",
+						StepID:    "some-plan-id",
+						StepName:  "unit",
+						Source:    "stderr",
+						Time:      1602763200,
+						Line:      "dial tcp: connection refused",
+					},
+					{
+						BuildID:   12,
+						BuildName: "1",
+						JobName:   "some-job",
+						StepID:    "some-other-plan-id",
+						Line:      "connection refused, retrying",
+					},
+				},
+			}
+		})
+
+		Context("when a job is given", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "search-logs", "-j", "some-pipeline/some-job", "connection refused")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/builds/search", "q=connection+refused&job=some-job&limit=20"),
+						ghttp.RespondWithJSONEncodedPtr(&searchStatus, &search),
+					),
+				)
+			})
+
+			It("prints the matching lines with their build and step", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "build", Color: color.New(color.Bold)},
+						{Contents: "step", Color: color.New(color.Bold)},
+						{Contents: "line", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{
+							{Contents: "some-job/3"},
+							{Contents: "unit"},
+							{Contents: "dial tcp: connection refused"},
+						},
+						{
+							{Contents: "some-job/1"},
+							{Contents: "some-other-plan-id"},
+							{Contents: "connection refused, retrying"},
+						},
+					},
+				}))
+			})
+
+			Context("when --json is given", func() {
+				BeforeEach(func() {
+					flyCmd.Args = append(flyCmd.Args, "--json")
+				})
+
+				It("prints response in json as stdout", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Out.Contents()).To(MatchJSON(`{
+						"matches": [
+							{
+								"build_id": 42,
+								"build_name": "3",
+								"job_name": "some-job",
+								"step_id": "some-plan-id",
+								"step_name": "unit",
+								"source": "stderr",
+								"time": 1602763200,
+								"line": "dial tcp: connection refused"
+							},
+							{
+								"build_id": 12,
+								"build_name": "1",
+								"job_name": "some-job",
+								"step_id": "some-other-plan-id",
+								"time": 0,
+								"line": "connection refused, retrying"
+							}
+						]
+					}`))
+				})
+			})
+
+			Context("when builds were not searched because they were archived", func() {
+				BeforeEach(func() {
+					search.ArchivedBuilds = 7
+				})
+
+				It("says so", func() {
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gexec.Exit(0))
+					Expect(sess.Err).To(gbytes.Say("7 builds were not searched because their logs have been archived"))
+				})
+			})
+		})
+
+		Context("when a pipeline is given", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "search-logs", "-p", "some-pipeline", "-c", "5", "oops")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/builds/search", "q=oops&limit=5"),
+						ghttp.RespondWithJSONEncoded(200, atc.BuildLogSearch{Matches: []atc.BuildLogMatch{}}),
+					),
+				)
+			})
+
+			It("searches the builds of the whole pipeline", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+				Expect(atcServer.ReceivedRequests()).To(HaveLen(5))
+			})
+		})
+
+		Context("when the pipeline or job does not exist", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "search-logs", "-j", "some-pipeline/some-job", "oops")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/builds/search"),
+						ghttp.RespondWith(404, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("pipeline 'some-pipeline' or job 'some-job' not found"))
+			})
+		})
+
+		Context("when neither a pipeline nor a job is given", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "search-logs", "oops")
+			})
+
+			It("errors", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(1))
+				Expect(sess.Err).To(gbytes.Say("Either --pipeline or --job must be specified"))
+			})
+		})
+	})
+})
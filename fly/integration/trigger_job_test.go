@@ -66,6 +66,29 @@ var _ = Describe("Fly CLI", func() {
 						})
 					})
 
+					Context("when --priority is provided", func() {
+						BeforeEach(func() {
+							atcServer.AppendHandlers(
+								ghttp.CombineHandlers(
+									ghttp.VerifyRequest("POST", mainPath, "priority=-5"),
+									ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Build{ID: 57, Name: "42", Priority: -5}),
+								),
+							)
+						})
+
+						It("starts the build with the priority", func() {
+							flyCmd := exec.Command(flyPath, "-t", targetName, "trigger-job", "-j", "awesome-pipeline/awesome-job", "--priority", "-5")
+
+							sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+							Expect(err).NotTo(HaveOccurred())
+
+							Eventually(sess).Should(gbytes.Say(`started awesome-pipeline/awesome-job #42`))
+
+							<-sess.Exited
+							Expect(sess.ExitCode()).To(Equal(0))
+						})
+					})
+
 					Context("user is NOT targeting the same team that the pipeline belongs to", func() {
 
 						BeforeEach(func() {
@@ -1,8 +1,10 @@
 package ui
 
 import (
+	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 )
@@ -18,3 +20,23 @@ func PresentVersion(version atc.Version) string {
 
 	return strings.Join(pairs, ",")
 }
+
+func PresentBytes(bytes uint64) string {
+	const unit = 1024
+
+	if bytes < unit {
+		return fmt.Sprintf("%dB", bytes)
+	}
+
+	div, exp := uint64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f%ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+func PresentCPUTime(nanoseconds uint64) string {
+	return time.Duration(nanoseconds).Round(time.Millisecond).String()
+}
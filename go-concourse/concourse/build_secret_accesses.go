@@ -0,0 +1,32 @@
+package concourse
+
+import (
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (client *client) BuildSecretAccesses(buildID int) ([]atc.SecretAccess, bool, error) {
+	params := rata.Params{
+		"build_id": strconv.Itoa(buildID),
+	}
+
+	var secretAccesses []atc.SecretAccess
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.ListBuildSecretAccesses,
+		Params:      params,
+	}, &internal.Response{
+		Result: &secretAccesses,
+	})
+
+	switch err.(type) {
+	case nil:
+		return secretAccesses, true, nil
+	case internal.ResourceNotFoundError:
+		return secretAccesses, false, nil
+	default:
+		return secretAccesses, false, err
+	}
+}
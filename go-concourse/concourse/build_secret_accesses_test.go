@@ -0,0 +1,69 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Build Secret Accesses", func() {
+	Describe("BuildSecretAccesses", func() {
+		expectedURL := "/api/v1/builds/6/secrets-accessed"
+
+		Context("when build exists", func() {
+			var expectedSecretAccesses []atc.SecretAccess
+
+			BeforeEach(func() {
+				expectedSecretAccesses = []atc.SecretAccess{
+					{
+						Path:       "/concourse/main/prod-db-password",
+						Manager:    "vault",
+						Found:      true,
+						AccessedAt: 1,
+					},
+					{
+						Path:      "/some-pipeline/token",
+						Manager:   "ssm",
+						VarSource: "some-ssm",
+						CacheHit:  true,
+					},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedSecretAccesses),
+					),
+				)
+			})
+
+			It("returns the secret accesses of the build", func() {
+				secretAccesses, found, err := client.BuildSecretAccesses(6)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(secretAccesses).To(Equal(expectedSecretAccesses))
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when build does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false in the found value and no error", func() {
+				_, found, err := client.BuildSecretAccesses(6)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
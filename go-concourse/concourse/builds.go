@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/go-concourse/concourse/internal"
@@ -35,10 +37,10 @@ func (team *team) CreateBuild(plan atc.Plan) (atc.Build, error) {
 	return build, err
 }
 
-func (team *team) CreateJobBuild(pipelineName string, jobName string) (atc.Build, error) {
+func (team *team) CreateJobBuild(pipelineRef atc.PipelineRef, jobName string) (atc.Build, error) {
 	params := rata.Params{
 		"job_name":      jobName,
-		"pipeline_name": pipelineName,
+		"pipeline_name": pipelineRef.Name,
 		"team_name":     team.name,
 	}
 
@@ -46,6 +48,7 @@ func (team *team) CreateJobBuild(pipelineName string, jobName string) (atc.Build
 	err := team.connection.Send(internal.Request{
 		RequestName: atc.CreateJobBuild,
 		Params:      params,
+		Query:       pipelineRef.QueryParams(),
 	}, &internal.Response{
 		Result: &build,
 	})
@@ -53,11 +56,64 @@ func (team *team) CreateJobBuild(pipelineName string, jobName string) (atc.Build
 	return build, err
 }
 
-func (team *team) RerunJobBuild(pipelineName string, jobName string, buildName string) (atc.Build, error) {
+func (team *team) CreateJobBuildWithPriority(pipelineRef atc.PipelineRef, jobName string, priority int) (atc.Build, error) {
+	params := rata.Params{
+		"job_name":      jobName,
+		"pipeline_name": pipelineRef.Name,
+		"team_name":     team.name,
+	}
+
+	queryParams := url.Values{}
+	for k, v := range pipelineRef.QueryParams() {
+		queryParams[k] = v
+	}
+	queryParams.Set("priority", strconv.Itoa(priority))
+
+	var build atc.Build
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.CreateJobBuild,
+		Params:      params,
+		Query:       queryParams,
+	}, &internal.Response{
+		Result: &build,
+	})
+
+	return build, err
+}
+
+func (team *team) CreateJobOneOffBuild(pipelineRef atc.PipelineRef, jobName string, config atc.JobOneOffBuildConfig) (atc.Build, error) {
+	var build atc.Build
+
+	buffer := &bytes.Buffer{}
+	err := json.NewEncoder(buffer).Encode(config)
+	if err != nil {
+		return build, fmt.Errorf("Unable to marshal one-off build config: %s", err)
+	}
+
+	err = team.connection.Send(internal.Request{
+		RequestName: atc.CreateJobOneOffBuild,
+		Params: rata.Params{
+			"job_name":      jobName,
+			"pipeline_name": pipelineRef.Name,
+			"team_name":     team.name,
+		},
+		Query: pipelineRef.QueryParams(),
+		Body:  buffer,
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+		},
+	}, &internal.Response{
+		Result: &build,
+	})
+
+	return build, err
+}
+
+func (team *team) RerunJobBuild(pipelineRef atc.PipelineRef, jobName string, buildName string) (atc.Build, error) {
 	params := rata.Params{
 		"build_name":    buildName,
 		"job_name":      jobName,
-		"pipeline_name": pipelineName,
+		"pipeline_name": pipelineRef.Name,
 		"team_name":     team.name,
 	}
 
@@ -65,6 +121,7 @@ func (team *team) RerunJobBuild(pipelineName string, jobName string, buildName s
 	err := team.connection.Send(internal.Request{
 		RequestName: atc.RerunJobBuild,
 		Params:      params,
+		Query:       pipelineRef.QueryParams(),
 	}, &internal.Response{
 		Result: &build,
 	})
@@ -72,11 +129,11 @@ func (team *team) RerunJobBuild(pipelineName string, jobName string, buildName s
 	return build, err
 }
 
-func (team *team) JobBuild(pipelineName, jobName, buildName string) (atc.Build, bool, error) {
+func (team *team) JobBuild(pipelineRef atc.PipelineRef, jobName, buildName string) (atc.Build, bool, error) {
 	params := rata.Params{
 		"job_name":      jobName,
 		"build_name":    buildName,
-		"pipeline_name": pipelineName,
+		"pipeline_name": pipelineRef.Name,
 		"team_name":     team.name,
 	}
 
@@ -84,6 +141,7 @@ func (team *team) JobBuild(pipelineName, jobName, buildName string) (atc.Build,
 	err := team.connection.Send(internal.Request{
 		RequestName: atc.GetJobBuild,
 		Params:      params,
+		Query:       pipelineRef.QueryParams(),
 	}, &internal.Response{
 		Result: &build,
 	})
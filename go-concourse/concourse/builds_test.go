@@ -90,7 +90,78 @@ var _ = Describe("ATC Handler Builds", func() {
 		})
 
 		It("takes a pipeline and a job and creates the build", func() {
-			build, err := team.CreateJobBuild(pipelineName, jobName)
+			build, err := team.CreateJobBuild(atc.PipelineRef{Name: pipelineName}, jobName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build).To(Equal(expectedBuild))
+		})
+	})
+
+	Describe("CreateJobBuildWithPriority", func() {
+		var expectedBuild atc.Build
+
+		BeforeEach(func() {
+			expectedBuild = atc.Build{
+				ID:       123,
+				Name:     "mybuild",
+				Status:   "pending",
+				JobName:  "myjob",
+				APIURL:   "api/v1/builds/123",
+				Priority: 10,
+			}
+			expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/jobs/myjob/builds"
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", expectedURL, `vars=%7B%22branch%22%3A%22master%22%7D&priority=10`),
+					ghttp.RespondWithJSONEncoded(http.StatusCreated, expectedBuild),
+				),
+			)
+		})
+
+		It("passes the priority along with the pipeline ref", func() {
+			build, err := team.CreateJobBuildWithPriority(atc.PipelineRef{
+				Name:         "mypipeline",
+				InstanceVars: atc.InstanceVars{"branch": "master"},
+			}, "myjob", 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build).To(Equal(expectedBuild))
+		})
+	})
+
+	Describe("CreateJobOneOffBuild", func() {
+		var expectedBuild atc.Build
+
+		BeforeEach(func() {
+			expectedBuild = atc.Build{
+				ID:     123,
+				Name:   "123",
+				Status: "started",
+				APIURL: "api/v1/builds/123",
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/api/v1/teams/some-team/pipelines/mypipeline/jobs/myjob/one-off-builds", "vars=%7B%22branch%22%3A%22master%22%7D"),
+					ghttp.VerifyJSONRepresenting(atc.JobOneOffBuildConfig{
+						Inputs:   map[string]int{"some-input": 12},
+						SkipPuts: true,
+						Outputs:  []string{"some-output"},
+					}),
+					ghttp.RespondWithJSONEncoded(http.StatusCreated, expectedBuild),
+				),
+			)
+		})
+
+		It("sends the config and returns the build", func() {
+			build, err := team.CreateJobOneOffBuild(
+				atc.PipelineRef{Name: "mypipeline", InstanceVars: atc.InstanceVars{"branch": "master"}},
+				"myjob",
+				atc.JobOneOffBuildConfig{
+					Inputs:   map[string]int{"some-input": 12},
+					SkipPuts: true,
+					Outputs:  []string{"some-output"},
+				},
+			)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(build).To(Equal(expectedBuild))
 		})
@@ -127,7 +198,7 @@ var _ = Describe("ATC Handler Builds", func() {
 		})
 
 		It("takes a pipeline and a job and creates the build", func() {
-			build, err := team.RerunJobBuild(pipelineName, jobName, buildName)
+			build, err := team.RerunJobBuild(atc.PipelineRef{Name: pipelineName}, jobName, buildName)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(build).To(Equal(expectedBuild))
 		})
@@ -160,7 +231,7 @@ var _ = Describe("ATC Handler Builds", func() {
 			})
 
 			It("returns the given build", func() {
-				build, found, err := team.JobBuild("mypipeline", "myjob", "mybuild")
+				build, found, err := team.JobBuild(atc.PipelineRef{Name: "mypipeline"}, "myjob", "mybuild")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeTrue())
 				Expect(build).To(Equal(expectedBuild))
@@ -180,7 +251,7 @@ var _ = Describe("ATC Handler Builds", func() {
 			})
 
 			It("return false and no error", func() {
-				_, found, err := team.JobBuild("mypipeline", "myjob", "mybuild")
+				_, found, err := team.JobBuild(atc.PipelineRef{Name: "mypipeline"}, "myjob", "mybuild")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(found).To(BeFalse())
 			})
@@ -10,10 +10,10 @@ import (
 	"github.com/tedsuo/rata"
 )
 
-func (team *team) CheckResource(pipelineName string, resourceName string, version atc.Version) (atc.Check, bool, error) {
+func (team *team) CheckResource(pipelineRef atc.PipelineRef, resourceName string, version atc.Version) (atc.Check, bool, error) {
 
 	params := rata.Params{
-		"pipeline_name": pipelineName,
+		"pipeline_name": pipelineRef.Name,
 		"resource_name": resourceName,
 		"team_name":     team.name,
 	}
@@ -28,6 +28,7 @@ func (team *team) CheckResource(pipelineName string, resourceName string, versio
 	err = team.connection.Send(internal.Request{
 		RequestName: atc.CheckResource,
 		Params:      params,
+		Query:       pipelineRef.QueryParams(),
 		Body:        bytes.NewBuffer(jsonBytes),
 		Header:      http.Header{"Content-Type": []string{"application/json"}},
 	}, &internal.Response{
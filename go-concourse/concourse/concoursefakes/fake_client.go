@@ -81,6 +81,21 @@ type FakeClient struct {
 		result2 bool
 		result3 error
 	}
+	BuildSecretAccessesStub        func(int) ([]atc.SecretAccess, bool, error)
+	buildSecretAccessesMutex       sync.RWMutex
+	buildSecretAccessesArgsForCall []struct {
+		arg1 int
+	}
+	buildSecretAccessesReturns struct {
+		result1 []atc.SecretAccess
+		result2 bool
+		result3 error
+	}
+	buildSecretAccessesReturnsOnCall map[int]struct {
+		result1 []atc.SecretAccess
+		result2 bool
+		result3 error
+	}
 	BuildsStub        func(concourse.Page) ([]atc.Build, concourse.Pagination, error)
 	buildsMutex       sync.RWMutex
 	buildsArgsForCall []struct {
@@ -630,6 +645,72 @@ func (fake *FakeClient) BuildResourcesReturnsOnCall(i int, result1 atc.BuildInpu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeClient) BuildSecretAccesses(arg1 int) ([]atc.SecretAccess, bool, error) {
+	fake.buildSecretAccessesMutex.Lock()
+	ret, specificReturn := fake.buildSecretAccessesReturnsOnCall[len(fake.buildSecretAccessesArgsForCall)]
+	fake.buildSecretAccessesArgsForCall = append(fake.buildSecretAccessesArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("BuildSecretAccesses", []interface{}{arg1})
+	fake.buildSecretAccessesMutex.Unlock()
+	if fake.BuildSecretAccessesStub != nil {
+		return fake.BuildSecretAccessesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.buildSecretAccessesReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) BuildSecretAccessesCallCount() int {
+	fake.buildSecretAccessesMutex.RLock()
+	defer fake.buildSecretAccessesMutex.RUnlock()
+	return len(fake.buildSecretAccessesArgsForCall)
+}
+
+func (fake *FakeClient) BuildSecretAccessesCalls(stub func(int) ([]atc.SecretAccess, bool, error)) {
+	fake.buildSecretAccessesMutex.Lock()
+	defer fake.buildSecretAccessesMutex.Unlock()
+	fake.BuildSecretAccessesStub = stub
+}
+
+func (fake *FakeClient) BuildSecretAccessesArgsForCall(i int) int {
+	fake.buildSecretAccessesMutex.RLock()
+	defer fake.buildSecretAccessesMutex.RUnlock()
+	argsForCall := fake.buildSecretAccessesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) BuildSecretAccessesReturns(result1 []atc.SecretAccess, result2 bool, result3 error) {
+	fake.buildSecretAccessesMutex.Lock()
+	defer fake.buildSecretAccessesMutex.Unlock()
+	fake.BuildSecretAccessesStub = nil
+	fake.buildSecretAccessesReturns = struct {
+		result1 []atc.SecretAccess
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) BuildSecretAccessesReturnsOnCall(i int, result1 []atc.SecretAccess, result2 bool, result3 error) {
+	fake.buildSecretAccessesMutex.Lock()
+	defer fake.buildSecretAccessesMutex.Unlock()
+	fake.BuildSecretAccessesStub = nil
+	if fake.buildSecretAccessesReturnsOnCall == nil {
+		fake.buildSecretAccessesReturnsOnCall = make(map[int]struct {
+			result1 []atc.SecretAccess
+			result2 bool
+			result3 error
+		})
+	}
+	fake.buildSecretAccessesReturnsOnCall[i] = struct {
+		result1 []atc.SecretAccess
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeClient) Builds(arg1 concourse.Page) ([]atc.Build, concourse.Pagination, error) {
 	fake.buildsMutex.Lock()
 	ret, specificReturn := fake.buildsReturnsOnCall[len(fake.buildsArgsForCall)]
@@ -1709,6 +1790,8 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.buildPlanMutex.RUnlock()
 	fake.buildResourcesMutex.RLock()
 	defer fake.buildResourcesMutex.RUnlock()
+	fake.buildSecretAccessesMutex.RLock()
+	defer fake.buildSecretAccessesMutex.RUnlock()
 	fake.buildsMutex.RLock()
 	defer fake.buildsMutex.RUnlock()
 	fake.checkMutex.RLock()
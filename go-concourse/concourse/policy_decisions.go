@@ -0,0 +1,36 @@
+package concourse
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) ListPolicyDecisions(pipelineName string, limit int) ([]atc.PolicyDecision, error) {
+	var decisions []atc.PolicyDecision
+
+	params := rata.Params{
+		"team_name": team.name,
+	}
+
+	urlValues := url.Values{}
+	if pipelineName != "" {
+		urlValues.Add("pipeline", pipelineName)
+	}
+	if limit > 0 {
+		urlValues.Add("limit", strconv.Itoa(limit))
+	}
+
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListPolicyDecisions,
+		Params:      params,
+		Query:       urlValues,
+	}, &internal.Response{
+		Result: &decisions,
+	})
+
+	return decisions, err
+}
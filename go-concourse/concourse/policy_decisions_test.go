@@ -0,0 +1,67 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Policy Decisions", func() {
+	Describe("ListPolicyDecisions", func() {
+		var (
+			expectedDecisions []atc.PolicyDecision
+		)
+
+		BeforeEach(func() {
+			expectedDecisions = []atc.PolicyDecision{
+				{
+					ID:        1,
+					Team:      "some-team",
+					Pipeline:  "some-pipeline",
+					Action:    "SaveConfig",
+					Reasons:   []string{"some-reason"},
+					AuditOnly: true,
+					CreatedAt: 42,
+				},
+			}
+		})
+
+		Context("without filters", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/policy_decisions", ""),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedDecisions),
+					),
+				)
+			})
+
+			It("returns the decisions", func() {
+				decisions, err := team.ListPolicyDecisions("", 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decisions).To(Equal(expectedDecisions))
+			})
+		})
+
+		Context("with a pipeline and a limit", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/policy_decisions", "limit=5&pipeline=some-pipeline"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedDecisions),
+					),
+				)
+			})
+
+			It("passes the filters as query params", func() {
+				decisions, err := team.ListPolicyDecisions("some-pipeline", 5)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decisions).To(Equal(expectedDecisions))
+			})
+		})
+	})
+})
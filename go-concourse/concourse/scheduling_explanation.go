@@ -0,0 +1,33 @@
+package concourse
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) JobSchedulingExplanation(pipelineRef atc.PipelineRef, jobName string) (atc.SchedulingExplanation, bool, error) {
+	params := rata.Params{
+		"pipeline_name": pipelineRef.Name,
+		"job_name":      jobName,
+		"team_name":     team.name,
+	}
+
+	var explanation atc.SchedulingExplanation
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.GetJobSchedulingExplanation,
+		Params:      params,
+		Query:       pipelineRef.QueryParams(),
+	}, &internal.Response{
+		Result: &explanation,
+	})
+
+	switch err.(type) {
+	case nil:
+		return explanation, true, nil
+	case internal.ResourceNotFoundError:
+		return explanation, false, nil
+	default:
+		return explanation, false, err
+	}
+}
@@ -0,0 +1,98 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Scheduling Explanation", func() {
+	Describe("JobSchedulingExplanation", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/jobs/myjob/scheduling-explanation"
+
+		Context("when pipeline/job exists", func() {
+			var expectedExplanation atc.SchedulingExplanation
+
+			BeforeEach(func() {
+				expectedExplanation = atc.SchedulingExplanation{
+					InputsDetermined: true,
+					Inputs: []atc.InputExplanation{
+						{
+							Name:     "some-input",
+							Resource: "some-resource",
+							Passed:   []string{"upstream-job"},
+							Version:  atc.Version{"ref": "v1"},
+							Candidates: []atc.CandidateVersion{
+								{
+									Version:   atc.Version{"ref": "v2"},
+									PassedJob: "upstream-job",
+									BuildID:   42,
+									BuildName: "7",
+									Rejection: "version is disabled",
+								},
+								{
+									Version:   atc.Version{"ref": "v1"},
+									PassedJob: "upstream-job",
+									BuildID:   41,
+									BuildName: "6",
+								},
+							},
+						},
+					},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedExplanation),
+					),
+				)
+			})
+
+			It("returns the scheduling explanation for the given job", func() {
+				explanation, found, err := team.JobSchedulingExplanation(atc.PipelineRef{Name: "mypipeline"}, "myjob")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(explanation).To(Equal(expectedExplanation))
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when pipeline/job does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false in the found value and no error", func() {
+				_, found, err := team.JobSchedulingExplanation(atc.PipelineRef{Name: "mypipeline"}, "myjob")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when the server returns an error", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusInternalServerError, ""),
+					),
+				)
+			})
+
+			It("returns the error", func() {
+				_, found, err := team.JobSchedulingExplanation(atc.PipelineRef{Name: "mypipeline"}, "myjob")
+				Expect(err).To(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
@@ -0,0 +1,363 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/flag"
+	"github.com/golang/protobuf/proto"
+	coltracepb "github.com/open-telemetry/opentelemetry-proto/gen/go/collector/trace/v1"
+	commonpb "github.com/open-telemetry/opentelemetry-proto/gen/go/common/v1"
+	resourcepb "github.com/open-telemetry/opentelemetry-proto/gen/go/resource/v1"
+	tracepb "github.com/open-telemetry/opentelemetry-proto/gen/go/trace/v1"
+	"go.opentelemetry.io/otel/api/core"
+	export "go.opentelemetry.io/otel/sdk/export/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	OTLPProtocolGRPC = "grpc"
+	OTLPProtocolHTTP = "http"
+
+	otlpHTTPTracesPath = "/v1/traces"
+	otlpExportTimeout  = 10 * time.Second
+)
+
+type OTLP struct {
+	Address            string            `long:"otlp-address"              description:"otlp collector address to send traces to (host:port for grpc, URL for http)"`
+	Protocol           string            `long:"otlp-protocol"             description:"protocol used to send traces to the otlp collector" choice:"grpc" choice:"http" default:"grpc"`
+	Headers            map[string]string `long:"otlp-header"               description:"headers to attach to every export request"`
+	UseTLS             bool              `long:"otlp-use-tls"              description:"whether to connect to the otlp collector over tls"`
+	CACert             flag.File         `long:"otlp-ca-cert"              description:"file containing a CA certificate used to verify the otlp collector"`
+	InsecureSkipVerify bool              `long:"otlp-insecure-skip-verify" description:"skip verification of the otlp collector's certificate"`
+	Service            string            `long:"otlp-service"              description:"service name reported to the otlp collector" default:"web"`
+	ResourceAttributes map[string]string `long:"otlp-resource-attribute"   description:"attributes describing the resource producing the traces"`
+}
+
+func (o OTLP) IsConfigured() bool {
+	return o.Address != ""
+}
+
+// Exporter returns an exporter sending spans to the collector. Errors
+// uploading spans are logged, rather than returned, as spans are exported in
+// the background.
+//
+func (o OTLP) Exporter(logger lager.Logger) (*OTLPExporter, error) {
+	tlsConfig, err := o.tlsConfig()
+	if err != nil {
+		err = fmt.Errorf("failed to create otlp exporter: %w", err)
+		return nil, err
+	}
+
+	var client otlpClient
+	switch o.Protocol {
+	case OTLPProtocolHTTP:
+		client = o.httpClient(tlsConfig)
+	case OTLPProtocolGRPC, "":
+		client, err = o.grpcClient(tlsConfig)
+	default:
+		err = fmt.Errorf("unknown protocol '%s'", o.Protocol)
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to create otlp exporter: %w", err)
+		return nil, err
+	}
+
+	return &OTLPExporter{
+		client:   client,
+		resource: o.resource(),
+		logger:   logger,
+	}, nil
+}
+
+func (o OTLP) tlsConfig() (*tls.Config, error) {
+	if !o.UseTLS {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: o.InsecureSkipVerify,
+	}
+
+	if o.CACert != "" {
+		caCert, err := ioutil.ReadFile(o.CACert.Path())
+		if err != nil {
+			return nil, fmt.Errorf("read ca cert: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, errors.New("ca cert does not contain any valid certificates")
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+func (o OTLP) resource() *resourcepb.Resource {
+	attrs := Attrs{"service.name": o.Service}
+	for k, v := range o.ResourceAttributes {
+		attrs[k] = v
+	}
+
+	return &resourcepb.Resource{
+		Attributes: otlpAttributes(keyValueSlice(attrs)),
+	}
+}
+
+func (o OTLP) grpcClient(tlsConfig *tls.Config) (otlpClient, error) {
+	dialOpt := grpc.WithInsecure()
+	if tlsConfig != nil {
+		dialOpt = grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
+	}
+
+	// dialing is non-blocking, so an unavailable collector only results in
+	// failed exports rather than preventing startup.
+	conn, err := grpc.Dial(o.Address, dialOpt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &otlpGRPCClient{
+		conn:    conn,
+		client:  coltracepb.NewTraceServiceClient(conn),
+		headers: metadata.New(o.Headers),
+	}, nil
+}
+
+func (o OTLP) httpClient(tlsConfig *tls.Config) otlpClient {
+	endpoint := o.Address
+	if !strings.Contains(endpoint, "://") {
+		scheme := "http"
+		if tlsConfig != nil {
+			scheme = "https"
+		}
+
+		endpoint = scheme + "://" + endpoint
+	}
+
+	return &otlpHTTPClient{
+		client: &http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: tlsConfig,
+			},
+		},
+		url:     strings.TrimSuffix(endpoint, "/") + otlpHTTPTracesPath,
+		headers: o.Headers,
+	}
+}
+
+type otlpClient interface {
+	Export(context.Context, *coltracepb.ExportTraceServiceRequest) error
+	Close() error
+}
+
+type otlpGRPCClient struct {
+	conn    *grpc.ClientConn
+	client  coltracepb.TraceServiceClient
+	headers metadata.MD
+}
+
+func (c *otlpGRPCClient) Export(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) error {
+	ctx = metadata.NewOutgoingContext(ctx, c.headers)
+
+	_, err := c.client.Export(ctx, req)
+	return err
+}
+
+func (c *otlpGRPCClient) Close() error {
+	return c.conn.Close()
+}
+
+type otlpHTTPClient struct {
+	client  *http.Client
+	url     string
+	headers map[string]string
+}
+
+func (c *otlpHTTPClient) Export(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) error {
+	payload, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, c.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	for k, v := range c.headers {
+		httpReq.Header.Set(k, v)
+	}
+	httpReq.Header.Set("Content-Type", "application/x-protobuf")
+
+	resp, err := c.client.Do(httpReq.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response from collector: %s", resp.Status)
+	}
+
+	return nil
+}
+
+func (c *otlpHTTPClient) Close() error {
+	c.client.CloseIdleConnections()
+	return nil
+}
+
+// OTLPExporter converts spans to the OTLP protobuf representation and sends
+// them to a collector. It implements export.SpanBatcher so that spans are
+// buffered rather than sent in a request of their own.
+//
+type OTLPExporter struct {
+	client   otlpClient
+	resource *resourcepb.Resource
+	logger   lager.Logger
+}
+
+func (e *OTLPExporter) ExportSpan(ctx context.Context, span *export.SpanData) {
+	e.ExportSpans(ctx, []*export.SpanData{span})
+}
+
+// Close closes the connection to the collector. Spans exported afterwards
+// fail to be sent.
+//
+func (e *OTLPExporter) Close() error {
+	return e.client.Close()
+}
+
+func (e *OTLPExporter) ExportSpans(ctx context.Context, spans []*export.SpanData) {
+	if len(spans) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, otlpExportTimeout)
+	defer cancel()
+
+	err := e.client.Export(ctx, &coltracepb.ExportTraceServiceRequest{
+		ResourceSpans: []*tracepb.ResourceSpans{
+			{
+				Resource: e.resource,
+				InstrumentationLibrarySpans: []*tracepb.InstrumentationLibrarySpans{
+					{
+						InstrumentationLibrary: &commonpb.InstrumentationLibrary{
+							Name: "concourse",
+						},
+						Spans: otlpSpans(spans),
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		e.logger.Error("failed-to-export-spans", err, lager.Data{"spans": len(spans)})
+	}
+}
+
+func otlpSpans(spans []*export.SpanData) []*tracepb.Span {
+	res := make([]*tracepb.Span, len(spans))
+	for i, span := range spans {
+		res[i] = otlpSpan(span)
+	}
+
+	return res
+}
+
+func otlpSpan(span *export.SpanData) *tracepb.Span {
+	s := &tracepb.Span{
+		TraceId:                span.SpanContext.TraceID[:],
+		SpanId:                 span.SpanContext.SpanID[:],
+		Name:                   span.Name,
+		Kind:                   tracepb.Span_SpanKind(span.SpanKind),
+		StartTimeUnixNano:      uint64(span.StartTime.UnixNano()),
+		EndTimeUnixNano:        uint64(span.EndTime.UnixNano()),
+		Attributes:             otlpAttributes(span.Attributes),
+		DroppedAttributesCount: uint32(span.DroppedAttributeCount),
+		DroppedEventsCount:     uint32(span.DroppedMessageEventCount),
+		DroppedLinksCount:      uint32(span.DroppedLinkCount),
+		Status: &tracepb.Status{
+			Code: tracepb.Status_StatusCode(span.Status),
+		},
+	}
+
+	if span.ParentSpanID.IsValid() {
+		s.ParentSpanId = span.ParentSpanID[:]
+	}
+
+	for _, event := range span.MessageEvents {
+		s.Events = append(s.Events, &tracepb.Span_Event{
+			TimeUnixNano: uint64(event.Time.UnixNano()),
+			Name:         event.Name,
+			Attributes:   otlpAttributes(event.Attributes),
+		})
+	}
+
+	for _, link := range span.Links {
+		traceID, spanID := link.TraceID, link.SpanID
+		s.Links = append(s.Links, &tracepb.Span_Link{
+			TraceId:    traceID[:],
+			SpanId:     spanID[:],
+			Attributes: otlpAttributes(link.Attributes),
+		})
+	}
+
+	return s
+}
+
+func otlpAttributes(kvs []core.KeyValue) []*commonpb.KeyValue {
+	if len(kvs) == 0 {
+		return nil
+	}
+
+	res := make([]*commonpb.KeyValue, len(kvs))
+	for i, kv := range kvs {
+		res[i] = &commonpb.KeyValue{
+			Key:   string(kv.Key),
+			Value: otlpValue(kv.Value),
+		}
+	}
+
+	return res
+}
+
+func otlpValue(v core.Value) *commonpb.AnyValue {
+	switch v.Type() {
+	case core.BOOL:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v.AsBool()}}
+	case core.INT32:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: int64(v.AsInt32())}}
+	case core.INT64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: v.AsInt64()}}
+	case core.UINT32:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: int64(v.AsUint32())}}
+	case core.UINT64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: int64(v.AsUint64())}}
+	case core.FLOAT32:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: float64(v.AsFloat32())}}
+	case core.FLOAT64:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: v.AsFloat64()}}
+	default:
+		return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v.Emit()}}
+	}
+}
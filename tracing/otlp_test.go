@@ -0,0 +1,269 @@
+package tracing_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/tracing"
+	"github.com/golang/protobuf/proto"
+	coltracepb "github.com/open-telemetry/opentelemetry-proto/gen/go/collector/trace/v1"
+	commonpb "github.com/open-telemetry/opentelemetry-proto/gen/go/common/v1"
+	"go.opentelemetry.io/otel/api/core"
+	"go.opentelemetry.io/otel/api/key"
+	apitrace "go.opentelemetry.io/otel/api/trace"
+	export "go.opentelemetry.io/otel/sdk/export/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// collectorStub is an in-process OTLP collector recording the requests it
+// receives, along with the headers they were sent with.
+type collectorStub struct {
+	mu       sync.Mutex
+	requests []*coltracepb.ExportTraceServiceRequest
+	headers  []map[string]string
+}
+
+func (c *collectorStub) record(req *coltracepb.ExportTraceServiceRequest, headers map[string]string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.requests = append(c.requests, req)
+	c.headers = append(c.headers, headers)
+}
+
+func (c *collectorStub) Requests() []*coltracepb.ExportTraceServiceRequest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.requests
+}
+
+func (c *collectorStub) Headers() []map[string]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.headers
+}
+
+func (c *collectorStub) Export(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) (*coltracepb.ExportTraceServiceResponse, error) {
+	headers := map[string]string{}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for k, v := range md {
+		headers[k] = v[0]
+	}
+
+	c.record(req, headers)
+
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+func (c *collectorStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	defer GinkgoRecover()
+
+	Expect(r.URL.Path).To(Equal("/v1/traces"))
+	Expect(r.Header.Get("Content-Type")).To(Equal("application/x-protobuf"))
+
+	payload, err := ioutil.ReadAll(r.Body)
+	Expect(err).ToNot(HaveOccurred())
+
+	req := &coltracepb.ExportTraceServiceRequest{}
+	Expect(proto.Unmarshal(payload, req)).To(Succeed())
+
+	headers := map[string]string{}
+	for k := range r.Header {
+		headers[k] = r.Header.Get(k)
+	}
+
+	c.record(req, headers)
+}
+
+var _ = Describe("OTLP", func() {
+	var (
+		collector *collectorStub
+		config    tracing.OTLP
+		span      *export.SpanData
+	)
+
+	BeforeEach(func() {
+		collector = &collectorStub{}
+
+		config = tracing.OTLP{
+			Service: "web",
+			Headers: map[string]string{"x-api-key": "some-key"},
+			ResourceAttributes: map[string]string{
+				"deployment.environment": "test",
+			},
+		}
+
+		span = &export.SpanData{
+			SpanContext: core.SpanContext{
+				TraceID: core.TraceID{1, 2, 3},
+				SpanID:  core.SpanID{4, 5, 6},
+			},
+			ParentSpanID: core.SpanID{7, 8, 9},
+			SpanKind:     apitrace.SpanKindInternal,
+			Name:         "build",
+			StartTime:    time.Unix(1, 0),
+			EndTime:      time.Unix(2, 0),
+			Attributes: []core.KeyValue{
+				key.New("team").String("main"),
+				key.New("build-id").Int64(42),
+			},
+		}
+	})
+
+	It("is configured when an address is given", func() {
+		Expect(config.IsConfigured()).To(BeFalse())
+
+		config.Address = "localhost:4317"
+		Expect(config.IsConfigured()).To(BeTrue())
+	})
+
+	exportSpan := func() {
+		exporter, err := config.Exporter(lagertest.NewTestLogger("test"))
+		Expect(err).ToNot(HaveOccurred())
+
+		defer exporter.Close()
+
+		exporter.ExportSpans(context.Background(), []*export.SpanData{span})
+	}
+
+	itExportsTheSpan := func() {
+		It("sends the span along with the resource", func() {
+			exportSpan()
+
+			Eventually(collector.Requests).Should(HaveLen(1))
+			resourceSpans := collector.Requests()[0].ResourceSpans
+			Expect(resourceSpans).To(HaveLen(1))
+
+			Expect(resourceSpans[0].Resource.Attributes).To(ConsistOf(
+				stringAttribute("service.name", "web"),
+				stringAttribute("deployment.environment", "test"),
+			))
+
+			spans := resourceSpans[0].InstrumentationLibrarySpans[0].Spans
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Name).To(Equal("build"))
+			Expect(spans[0].TraceId).To(Equal(span.SpanContext.TraceID[:]))
+			Expect(spans[0].SpanId).To(Equal(span.SpanContext.SpanID[:]))
+			Expect(spans[0].ParentSpanId).To(Equal(span.ParentSpanID[:]))
+			Expect(spans[0].StartTimeUnixNano).To(Equal(uint64(time.Second)))
+			Expect(spans[0].EndTimeUnixNano).To(Equal(uint64(2 * time.Second)))
+			Expect(spans[0].Attributes).To(ConsistOf(
+				stringAttribute("team", "main"),
+				&commonpb.KeyValue{
+					Key:   "build-id",
+					Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: 42}},
+				},
+			))
+		})
+	}
+
+	Context("using grpc", func() {
+		var server *grpc.Server
+
+		BeforeEach(func() {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).ToNot(HaveOccurred())
+
+			server = grpc.NewServer()
+			coltracepb.RegisterTraceServiceServer(server, collector)
+			go server.Serve(listener)
+
+			config.Protocol = tracing.OTLPProtocolGRPC
+			config.Address = listener.Addr().String()
+		})
+
+		AfterEach(func() {
+			server.Stop()
+		})
+
+		itExportsTheSpan()
+
+		It("sends the configured headers as metadata", func() {
+			exportSpan()
+
+			Eventually(collector.Headers).Should(HaveLen(1))
+			Expect(collector.Headers()[0]).To(HaveKeyWithValue("x-api-key", "some-key"))
+		})
+
+		Context("when configured as the exporter of the tracer", func() {
+			BeforeEach(func() {
+				err := tracing.Config{OTLP: config}.Prepare(lagertest.NewTestLogger("test"))
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				tracing.Configured = false
+			})
+
+			It("sends the buffered spans on shutdown", func() {
+				_, span := tracing.StartSpan(context.Background(), "build", nil)
+				span.End()
+
+				Expect(collector.Requests()).To(BeEmpty())
+
+				tracing.Shutdown()
+
+				Expect(collector.Requests()).To(HaveLen(1))
+				spans := collector.Requests()[0].ResourceSpans[0].InstrumentationLibrarySpans[0].Spans
+				Expect(spans).To(HaveLen(1))
+				Expect(spans[0].Name).To(Equal("concourse/build"))
+			})
+		})
+	})
+
+	Context("using http", func() {
+		var server *httptest.Server
+
+		BeforeEach(func() {
+			server = httptest.NewServer(collector)
+
+			config.Protocol = tracing.OTLPProtocolHTTP
+			config.Address = server.URL
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		itExportsTheSpan()
+
+		It("sends the configured headers", func() {
+			exportSpan()
+
+			Eventually(collector.Headers).Should(HaveLen(1))
+			Expect(collector.Headers()[0]).To(HaveKeyWithValue("X-Api-Key", "some-key"))
+		})
+	})
+
+	Context("using tls with an invalid ca cert", func() {
+		BeforeEach(func() {
+			config.Address = "localhost:4317"
+			config.UseTLS = true
+			config.CACert = "/does/not/exist"
+		})
+
+		It("errors", func() {
+			_, err := config.Exporter(lagertest.NewTestLogger("test"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
+
+func stringAttribute(k, v string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   k,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v}},
+	}
+}
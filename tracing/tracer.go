@@ -3,6 +3,7 @@ package tracing
 import (
 	"context"
 
+	"code.cloudfoundry.org/lager"
 	"go.opentelemetry.io/otel/api/core"
 	"go.opentelemetry.io/otel/api/global"
 	"go.opentelemetry.io/otel/api/key"
@@ -37,12 +38,18 @@ func (tp *TestTraceProvider) Tracer(name string) trace.Tracer {
 //
 var Configured bool
 
+// shutdown flushes the spans buffered by the configured exporter and releases
+// its resources.
+//
+var shutdown = func() {}
+
 type Config struct {
 	Jaeger      Jaeger
 	Stackdriver Stackdriver
+	OTLP        OTLP
 }
 
-func (c Config) Prepare() error {
+func (c Config) Prepare(logger lager.Logger) error {
 	var exp export.SpanSyncer
 	var err error
 	switch {
@@ -50,6 +57,8 @@ func (c Config) Prepare() error {
 		exp, err = c.Jaeger.Exporter()
 	case c.Stackdriver.IsConfigured():
 		exp, err = c.Stackdriver.Exporter()
+	case c.OTLP.IsConfigured():
+		return c.prepareOTLP(logger.Session("otlp"))
 	}
 	if err != nil {
 		return err
@@ -60,6 +69,36 @@ func (c Config) Prepare() error {
 	return nil
 }
 
+func (c Config) prepareOTLP(logger lager.Logger) error {
+	exporter, err := c.OTLP.Exporter(logger)
+	if err != nil {
+		return err
+	}
+
+	provider, flush := BatchTraceProvider(exporter)
+	ConfigureTraceProvider(provider)
+
+	shutdown = func() {
+		flush()
+
+		err := exporter.Close()
+		if err != nil {
+			logger.Error("failed-to-close-exporter", err)
+		}
+	}
+
+	return nil
+}
+
+// Shutdown sends the spans which are still buffered to the configured
+// exporter and closes its connection. It should be called once the process
+// is done producing spans.
+//
+func Shutdown() {
+	shutdown()
+	shutdown = func() {}
+}
+
 // StartSpan creates a span, giving back a context that has itself added as the
 // parent span.
 //
@@ -105,6 +144,18 @@ func FromContext(ctx context.Context) trace.Span {
 	return trace.SpanFromContext(ctx)
 }
 
+// TraceID returns the hex-encoded ID of the trace the span in the context
+// belongs to, or an empty string if there is no such trace.
+//
+func TraceID(ctx context.Context) string {
+	spanContext := trace.SpanFromContext(ctx).SpanContext()
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+
+	return spanContext.TraceIDString()
+}
+
 func Inject(ctx context.Context, supplier propagators.Supplier) {
 	propagators.TraceContext{}.Inject(ctx, supplier)
 }
@@ -218,3 +269,24 @@ func TraceProvider(exporter export.SpanSyncer) trace.Provider {
 	)
 	return provider
 }
+
+// BatchTraceProvider returns a provider which buffers spans and sends them to
+// the exporter in batches, rather than paying for a round trip per span,
+// along with a function which sends the spans still buffered.
+//
+func BatchTraceProvider(exporter export.SpanBatcher) (trace.Provider, func()) {
+	// the only way NewBatchSpanProcessor can error is if exporter is nil,
+	// but this method is never called in such circumstances.
+	processor, _ := sdktrace.NewBatchSpanProcessor(exporter)
+
+	provider, _ := sdktrace.NewProvider(sdktrace.WithConfig(
+		sdktrace.Config{
+			DefaultSampler: sdktrace.AlwaysSample(),
+		}),
+	)
+	provider.RegisterSpanProcessor(processor)
+
+	return provider, func() {
+		provider.UnregisterSpanProcessor(processor)
+	}
+}
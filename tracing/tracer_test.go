@@ -3,6 +3,7 @@ package tracing_test
 import (
 	"context"
 
+	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/concourse/concourse/tracing"
 	"github.com/concourse/concourse/tracing/tracingfakes"
 	"go.opentelemetry.io/otel/api/core"
@@ -76,6 +77,22 @@ var _ = Describe("Tracer", func() {
 
 	})
 
+	Describe("TraceID", func() {
+		It("returns the id of the trace the span belongs to", func() {
+			fakeSpan.SpanContextReturns(core.SpanContext{
+				TraceID: core.TraceID{0xab, 0xcd},
+				SpanID:  core.SpanID{1},
+			})
+
+			ctx := trace.ContextWithSpan(context.Background(), fakeSpan)
+			Expect(tracing.TraceID(ctx)).To(Equal("abcd0000000000000000000000000000"))
+		})
+
+		It("returns an empty string without a span", func() {
+			Expect(tracing.TraceID(context.Background())).To(BeEmpty())
+		})
+	})
+
 	Describe("Prepare", func() {
 		BeforeEach(func() {
 			tracing.Configured = false
@@ -87,13 +104,25 @@ var _ = Describe("Tracer", func() {
 					Endpoint: "http://jaeger:14268/api/traces",
 				},
 			}
-			c.Prepare()
+			c.Prepare(lagertest.NewTestLogger("test"))
 			Expect(tracing.Configured).To(BeTrue())
 		})
 
+		It("configures tracing if otlp flags are provided", func() {
+			c := tracing.Config{
+				OTLP: tracing.OTLP{
+					Address: "localhost:4317",
+				},
+			}
+			c.Prepare(lagertest.NewTestLogger("test"))
+			Expect(tracing.Configured).To(BeTrue())
+
+			tracing.Shutdown()
+		})
+
 		It("does not configure tracing if no flags are provided", func() {
 			c := tracing.Config{}
-			c.Prepare()
+			c.Prepare(lagertest.NewTestLogger("test"))
 			Expect(tracing.Configured).To(BeFalse())
 		})
 	})
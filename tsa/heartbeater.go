@@ -282,6 +282,15 @@ func (heartbeater *Heartbeater) pingWorker(logger lager.Logger) (atc.Worker, boo
 	registration.ActiveContainers = len(containers)
 	registration.ActiveVolumes = len(volumes)
 
+	if registration.AllocatableMemory == 0 {
+		capacity, err := heartbeater.gardenClient.Capacity()
+		if err != nil {
+			logger.Error("failed-to-fetch-capacity", err)
+		} else {
+			registration.AllocatableMemory = capacity.MemoryInBytes
+		}
+	}
+
 	return registration, true
 }
 
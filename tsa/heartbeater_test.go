@@ -271,6 +271,41 @@ var _ = Describe("Heartbeater", func() {
 					Eventually(clientWriter).Should(gbytes.Say(`{"event":"heartbeated"}`))
 				})
 			})
+
+			Context("when the worker does not configure its allocatable memory", func() {
+				BeforeEach(func() {
+					fakeGardenClient.CapacityReturns(garden.Capacity{MemoryInBytes: 1024}, nil)
+
+					fakeATC1.AppendHandlers(verifyRegister)
+					fakeATC2.AppendHandlers(verifyHeartbeat)
+				})
+
+				It("registers with the memory capacity reported by Garden", func() {
+					expectedWorker.ActiveContainers = 2
+					expectedWorker.ActiveVolumes = 3
+					expectedWorker.AllocatableMemory = 1024
+					Eventually(registrations).Should(Receive(Equal(registration{expectedWorker, 2 * interval})))
+				})
+			})
+
+			Context("when the worker configures its allocatable memory", func() {
+				BeforeEach(func() {
+					worker.AllocatableMemory = 512
+					expectedWorker = worker
+
+					fakeGardenClient.CapacityReturns(garden.Capacity{MemoryInBytes: 1024}, nil)
+
+					fakeATC1.AppendHandlers(verifyRegister)
+					fakeATC2.AppendHandlers(verifyHeartbeat)
+				})
+
+				It("registers with the configured memory", func() {
+					expectedWorker.ActiveContainers = 2
+					expectedWorker.ActiveVolumes = 3
+					Eventually(registrations).Should(Receive(Equal(registration{expectedWorker, 2 * interval})))
+					Expect(fakeGardenClient.CapacityCallCount()).To(BeZero())
+				})
+			})
 		})
 
 		Context("when heartbeat returns worker is landed", func() {
@@ -143,6 +143,7 @@ buildView session model =
                     |> Maybe.map
                         (\j ->
                             { pipelineName = j.pipelineName
+                            , pipelineInstanceVars = j.pipelineInstanceVars
                             , teamName = j.teamName
                             }
                         )
@@ -847,6 +848,7 @@ sampleJob : String -> List String -> Concourse.Job
 sampleJob name passed =
     { name = name
     , pipelineName = "pipeline"
+    , pipelineInstanceVars = Dict.empty
     , teamName = "team"
     , nextBuild = Nothing
     , finishedBuild = Nothing
@@ -970,6 +972,7 @@ jobByName jobs job =
         Nothing ->
             { name = ""
             , pipelineName = ""
+            , pipelineInstanceVars = Dict.empty
             , teamName = ""
             , nextBuild = Nothing
             , finishedBuild = Nothing
@@ -10,6 +10,7 @@ module Api.Endpoints exposing
     )
 
 import Concourse
+import Dict
 import Url.Builder
 
 
@@ -102,7 +103,37 @@ resourcePath { pipelineName, teamName, resourceName } =
 
 toString : List Url.Builder.QueryParameter -> Endpoint -> String
 toString query endpoint =
-    Url.Builder.absolute (toPath endpoint) query
+    Url.Builder.absolute (toPath endpoint) (instanceVarsQuery endpoint ++ query)
+
+
+instanceVarsQuery : Endpoint -> List Url.Builder.QueryParameter
+instanceVarsQuery endpoint =
+    let
+        instanceVars =
+            case endpoint of
+                Pipeline id _ ->
+                    id.pipelineInstanceVars
+
+                Job id _ ->
+                    id.pipelineInstanceVars
+
+                JobBuild id ->
+                    id.pipelineInstanceVars
+
+                Resource id _ ->
+                    id.pipelineInstanceVars
+
+                ResourceVersion id _ ->
+                    id.pipelineInstanceVars
+
+                _ ->
+                    Dict.empty
+    in
+    if Dict.isEmpty instanceVars then
+        []
+
+    else
+        [ Url.Builder.string "vars" <| Concourse.instanceVarsKey instanceVars ]
 
 
 toPath : Endpoint -> List String
@@ -661,6 +661,7 @@ view session model =
                     |> Maybe.map
                         (\j ->
                             { pipelineName = j.pipelineName
+                            , pipelineInstanceVars = j.pipelineInstanceVars
                             , teamName = j.teamName
                             }
                         )
@@ -934,6 +935,7 @@ viewBuildPrep buildPrep =
                             ++ viewBuildPrepInputs prep.inputs
                             ++ [ viewBuildPrepLi "waiting for a suitable set of input versions" prep.inputsSatisfied prep.missingInputReasons
                                , viewBuildPrepLi "checking max-in-flight is not reached" prep.maxRunningBuilds Dict.empty
+                               , viewBuildPrepLi "checking team quota is not exceeded" prep.teamQuota Dict.empty
                                ]
                         )
                     ]
@@ -132,10 +132,10 @@ isPipelineArchived :
     -> Bool
 isPipelineArchived pipelines jobId =
     case jobId of
-        Just { pipelineName, teamName } ->
+        Just { pipelineName, pipelineInstanceVars, teamName } ->
             pipelines
                 |> RemoteData.withDefault []
-                |> List.Extra.find (\p -> p.name == pipelineName && p.teamName == teamName)
+                |> List.Extra.find (\p -> p.name == pipelineName && p.instanceVars == pipelineInstanceVars && p.teamName == teamName)
                 |> Maybe.map .archived
                 |> Maybe.withDefault False
 
@@ -454,6 +454,7 @@ update msg ( model, effects ) =
                                 RerunJobBuild
                                     { teamName = j.teamName
                                     , pipelineName = j.pipelineName
+                                    , pipelineInstanceVars = j.pipelineInstanceVars
                                     , jobName = j.jobName
                                     , buildName = model.name
                                     }
@@ -145,8 +145,13 @@ handleEvent event ( model, effects ) =
             , effects
             )
 
-        SelectedWorker origin output time ->
-            ( updateStep origin.id (setRunning << appendStepLog ("\u{001B}[1mselected worker: \u{001B}[0m" ++ output ++ "\n") time) model
+        SelectedWorker origin output placement time ->
+            ( updateStep origin.id (setRunning << appendStepLog ("\u{001B}[1mselected worker: \u{001B}[0m" ++ output ++ "\n" ++ String.concat (List.map placementStepLog placement)) time) model
+            , effects
+            )
+
+        ResourceUsage origin usage time ->
+            ( updateStep origin.id (appendStepLog (resourceUsageLog usage) time) model
             , effects
             )
 
@@ -220,6 +225,16 @@ handleEvent event ( model, effects ) =
             , effects
             )
 
+        AcrossSubsteps origin substeps ->
+            ( { model
+                | steps =
+                    Maybe.map
+                        (Build.StepTree.StepTree.setAcrossSubsteps origin.id substeps)
+                        model.steps
+              }
+            , effects
+            )
+
         BuildStatus status _ ->
             let
                 newSt =
@@ -282,6 +297,65 @@ appendStepLog output mtime tree =
             { step | log = newLog, timestamps = newTimestamps }
 
 
+placementStepLog : StepTree.PlacementStep -> String
+placementStepLog step =
+    if step.skipped then
+        "  " ++ step.strategy ++ ": skipped\n"
+
+    else if List.isEmpty step.eliminated then
+        "  " ++ step.strategy ++ ": eliminated no workers\n"
+
+    else
+        "  " ++ step.strategy ++ ": eliminated " ++ String.join ", " step.eliminated ++ "\n"
+
+
+resourceUsageLog : StepTree.StepResourceUsage -> String
+resourceUsageLog usage =
+    "\u{001B}[1mresource usage: \u{001B}[0m"
+        ++ ("cpu " ++ formatCpuTime usage.cpuTime)
+        ++ (", memory peak " ++ formatBytes usage.memoryPeak)
+        ++ (", rootfs disk usage " ++ formatBytes usage.rootfsDiskUsage)
+        ++ "\n"
+
+
+formatCpuTime : Int -> String
+formatCpuTime nanoseconds =
+    let
+        milliseconds =
+            nanoseconds // 1000000
+    in
+    if milliseconds < 1000 then
+        String.fromInt milliseconds ++ "ms"
+
+    else
+        String.fromFloat (toFloat milliseconds / 1000) ++ "s"
+
+
+formatBytes : Int -> String
+formatBytes bytes =
+    let
+        scale value units =
+            case units of
+                [] ->
+                    String.fromInt bytes ++ "B"
+
+                [ unit ] ->
+                    String.fromFloat (toFloat (round (value * 10)) / 10) ++ unit
+
+                unit :: rest ->
+                    if value < 1024 then
+                        String.fromFloat (toFloat (round (value * 10)) / 10) ++ unit
+
+                    else
+                        scale (value / 1024) rest
+    in
+    if bytes < 1024 then
+        String.fromInt bytes ++ "B"
+
+    else
+        scale (toFloat bytes / 1024) [ "KiB", "MiB", "GiB", "TiB" ]
+
+
 setStepError : String -> Time.Posix -> StepTree -> StepTree
 setStepError message time tree =
     StepTree.map
@@ -4,9 +4,11 @@ module Build.StepTree.Models exposing
     , HookedStep
     , MetadataField
     , Origin
+    , PlacementStep
     , Step
     , StepFocus
     , StepName
+    , StepResourceUsage
     , StepState(..)
     , StepTree(..)
     , StepTreeModel
@@ -271,8 +273,10 @@ type BuildEvent
     | StartPut Origin Time.Posix
     | FinishPut Origin Int Concourse.Version Concourse.Metadata (Maybe Time.Posix)
     | SetPipelineChanged Origin Bool
+    | AcrossSubsteps Origin (List ( List Concourse.JsonValue, Concourse.BuildPlan ))
     | Log Origin String (Maybe Time.Posix)
-    | SelectedWorker Origin String (Maybe Time.Posix)
+    | SelectedWorker Origin String (List PlacementStep) (Maybe Time.Posix)
+    | ResourceUsage Origin StepResourceUsage (Maybe Time.Posix)
     | Error Origin String Time.Posix
     | End
     | Opened
@@ -285,6 +289,20 @@ type alias Origin =
     }
 
 
+type alias PlacementStep =
+    { strategy : String
+    , eliminated : List String
+    , skipped : Bool
+    }
+
+
+type alias StepResourceUsage =
+    { cpuTime : Int
+    , memoryPeak : Int
+    , rootfsDiskUsage : Int
+    }
+
+
 
 -- model manipulation functions
 
@@ -2,6 +2,7 @@ module Build.StepTree.StepTree exposing
     ( extendHighlight
     , finished
     , init
+    , setAcrossSubsteps
     , setHighlight
     , switchTab
     , toggleStep
@@ -197,6 +198,56 @@ startingTab hl planID plans =
             { id = planID, tab = tab, focus = User }
 
 
+setAcrossSubsteps :
+    StepID
+    -> List ( List JsonValue, Concourse.BuildPlan )
+    -> StepTreeModel
+    -> StepTreeModel
+setAcrossSubsteps id substeps root =
+    case Dict.get id root.foci of
+        Nothing ->
+            root
+
+        Just focus ->
+            let
+                ( values, plans ) =
+                    List.unzip substeps
+
+                inited =
+                    plans
+                        |> List.map (init root.highlight Concourse.emptyBuildResources)
+                        |> Array.fromList
+
+                trees =
+                    inited
+                        |> Array.map (.tree >> map (\s -> { s | expanded = True }))
+
+                setSubsteps tree =
+                    case tree of
+                        Across vars _ _ step _ ->
+                            Across
+                                vars
+                                values
+                                (plans |> List.map (planIsHighlighted root.highlight))
+                                step
+                                trees
+
+                        _ ->
+                            tree
+
+                substepFoci =
+                    inited
+                        |> Array.map .foci
+                        |> Array.indexedMap wrapMultiStep
+                        |> Array.foldr Dict.union Dict.empty
+                        |> Dict.map (\_ subFocus -> subFocus >> focus)
+            in
+            { root
+                | tree = focus setSubsteps root.tree
+                , foci = Dict.union root.foci substepFoci
+            }
+
+
 initMultiStep :
     Highlight
     -> Concourse.BuildResources
@@ -20,6 +20,7 @@ module Concourse exposing
     , ClusterInfo
     , DatabaseID
     , HookedPlan
+    , InstanceVars
     , Job
     , JobBuildIdentifier
     , JobIdentifier
@@ -43,6 +44,7 @@ module Concourse exposing
     , VersionedResourceIdentifier
     , csrfTokenHeaderName
     , customDecoder
+    , decodeAcrossSubsteps
     , decodeAuthToken
     , decodeBuild
     , decodeBuildPlan
@@ -51,6 +53,7 @@ module Concourse exposing
     , decodeCause
     , decodeCheck
     , decodeInfo
+    , decodeInstanceVars
     , decodeJob
     , decodeMetadata
     , decodePipeline
@@ -61,10 +64,14 @@ module Concourse exposing
     , decodeVersionedResource
     , emptyBuildResources
     , encodeBuild
+    , encodeInstanceVars
     , encodeJob
     , encodePipeline
     , encodeTeam
+    , instanceVarsKey
+    , instanceVarsToString
     , mapBuildPlan
+    , pipelineDisplayName
     , retrieveCSRFToken
     )
 
@@ -159,6 +166,7 @@ type alias BuildName =
 type alias JobBuildIdentifier =
     { teamName : TeamName
     , pipelineName : PipelineName
+    , pipelineInstanceVars : InstanceVars
     , jobName : JobName
     , buildName : BuildName
     }
@@ -187,6 +195,7 @@ encodeBuild build =
          , ( "name", build.name |> Json.Encode.string ) |> Just
          , optionalField "team_name" Json.Encode.string (build.job |> Maybe.map .teamName)
          , optionalField "pipeline_name" Json.Encode.string (build.job |> Maybe.map .pipelineName)
+         , optionalField "pipeline_instance_vars" encodeInstanceVars (build.job |> Maybe.map .pipelineInstanceVars)
          , optionalField "job_name" Json.Encode.string (build.job |> Maybe.map .jobName)
          , ( "status", build.status |> Concourse.BuildStatus.encodeBuildStatus ) |> Just
          , optionalField "start_time" (secondsFromDate >> Json.Encode.int) build.duration.startedAt
@@ -217,6 +226,7 @@ decodeBuild =
                 (Json.Decode.succeed JobIdentifier
                     |> andMap (Json.Decode.field "team_name" Json.Decode.string)
                     |> andMap (Json.Decode.field "pipeline_name" Json.Decode.string)
+                    |> andMap (defaultTo Dict.empty <| Json.Decode.field "pipeline_instance_vars" decodeInstanceVars)
                     |> andMap (Json.Decode.field "job_name" Json.Decode.string)
                 )
             )
@@ -237,6 +247,7 @@ type alias BuildPrep =
     { pausedPipeline : BuildPrepStatus
     , pausedJob : BuildPrepStatus
     , maxRunningBuilds : BuildPrepStatus
+    , teamQuota : BuildPrepStatus
     , inputs : Dict String BuildPrepStatus
     , inputsSatisfied : BuildPrepStatus
     , missingInputReasons : Dict String String
@@ -255,6 +266,7 @@ decodeBuildPrep =
         |> andMap (Json.Decode.field "paused_pipeline" decodeBuildPrepStatus)
         |> andMap (Json.Decode.field "paused_job" decodeBuildPrepStatus)
         |> andMap (Json.Decode.field "max_running_builds" decodeBuildPrepStatus)
+        |> andMap (Json.Decode.field "team_quota" decodeBuildPrepStatus)
         |> andMap (Json.Decode.field "inputs" <| Json.Decode.dict decodeBuildPrepStatus)
         |> andMap (Json.Decode.field "inputs_satisfied" decodeBuildPrepStatus)
         |> andMap (defaultTo Dict.empty <| Json.Decode.field "missing_input_reasons" <| Json.Decode.dict Json.Decode.string)
@@ -658,16 +670,18 @@ decodeBuildStepAcross =
                     Json.Decode.list <|
                         Json.Decode.field "name" Json.Decode.string
                 )
-            |> andMap
-                (Json.Decode.field "steps" <|
-                    Json.Decode.list <|
-                        Json.Decode.map2 Tuple.pair
-                            (Json.Decode.field "values" <| Json.Decode.list decodeJsonValue)
-                            (Json.Decode.field "step" decodeBuildPlan_)
-                )
+            |> andMap (Json.Decode.field "steps" decodeAcrossSubsteps)
         )
 
 
+decodeAcrossSubsteps : Json.Decode.Decoder (List ( List JsonValue, BuildPlan ))
+decodeAcrossSubsteps =
+    Json.Decode.list <|
+        Json.Decode.map2 Tuple.pair
+            (Json.Decode.field "values" <| Json.Decode.list decodeJsonValue)
+            (Json.Decode.field "step" <| lazy (\_ -> decodeBuildPlan_))
+
+
 
 -- Info
 
@@ -696,6 +710,7 @@ type alias JobName =
 type alias JobIdentifier =
     { teamName : TeamName
     , pipelineName : PipelineName
+    , pipelineInstanceVars : InstanceVars
     , jobName : JobName
     }
 
@@ -703,6 +718,7 @@ type alias JobIdentifier =
 type alias Job =
     { name : JobName
     , pipelineName : PipelineName
+    , pipelineInstanceVars : InstanceVars
     , teamName : TeamName
     , nextBuild : Maybe Build
     , finishedBuild : Maybe Build
@@ -734,6 +750,7 @@ encodeJob job =
     Json.Encode.object
         [ ( "name", job.name |> Json.Encode.string )
         , ( "pipeline_name", job.pipelineName |> Json.Encode.string )
+        , ( "pipeline_instance_vars", job.pipelineInstanceVars |> encodeInstanceVars )
         , ( "team_name", job.teamName |> Json.Encode.string )
         , ( "next_build", job.nextBuild |> encodeMaybeBuild )
         , ( "finished_build", job.finishedBuild |> encodeMaybeBuild )
@@ -752,6 +769,7 @@ decodeJob =
     Json.Decode.succeed Job
         |> andMap (Json.Decode.field "name" Json.Decode.string)
         |> andMap (Json.Decode.field "pipeline_name" Json.Decode.string)
+        |> andMap (defaultTo Dict.empty <| Json.Decode.field "pipeline_instance_vars" decodeInstanceVars)
         |> andMap (Json.Decode.field "team_name" Json.Decode.string)
         |> andMap (Json.Decode.maybe (Json.Decode.field "next_build" decodeBuild))
         |> andMap (Json.Decode.maybe (Json.Decode.field "finished_build" decodeBuild))
@@ -808,12 +826,14 @@ type alias PipelineName =
 type alias PipelineIdentifier =
     { teamName : TeamName
     , pipelineName : PipelineName
+    , pipelineInstanceVars : InstanceVars
     }
 
 
 type alias Pipeline =
     { id : Int
     , name : PipelineName
+    , instanceVars : InstanceVars
     , paused : Bool
     , archived : Bool
     , public : Bool
@@ -835,6 +855,7 @@ encodePipeline pipeline =
     Json.Encode.object
         [ ( "id", pipeline.id |> Json.Encode.int )
         , ( "name", pipeline.name |> Json.Encode.string )
+        , ( "instance_vars", pipeline.instanceVars |> encodeInstanceVars )
         , ( "paused", pipeline.paused |> Json.Encode.bool )
         , ( "archived", pipeline.archived |> Json.Encode.bool )
         , ( "public", pipeline.public |> Json.Encode.bool )
@@ -849,6 +870,7 @@ decodePipeline =
     Json.Decode.succeed Pipeline
         |> andMap (Json.Decode.field "id" Json.Decode.int)
         |> andMap (Json.Decode.field "name" Json.Decode.string)
+        |> andMap (defaultTo Dict.empty <| Json.Decode.field "instance_vars" decodeInstanceVars)
         |> andMap (Json.Decode.field "paused" Json.Decode.bool)
         |> andMap (Json.Decode.field "archived" Json.Decode.bool)
         |> andMap (Json.Decode.field "public" Json.Decode.bool)
@@ -875,12 +897,64 @@ decodePipelineGroup =
 
 
 
+-- InstanceVars
+
+
+-- the values are kept JSON encoded, so that instance vars can be compared and
+-- be part of keys
+type alias InstanceVars =
+    Dict String String
+
+
+decodeInstanceVars : Json.Decode.Decoder InstanceVars
+decodeInstanceVars =
+    Json.Decode.dict (Json.Decode.value |> Json.Decode.map (Json.Encode.encode 0))
+
+
+encodeInstanceVars : InstanceVars -> Json.Encode.Value
+encodeInstanceVars =
+    Json.Encode.dict identity
+        (Json.Decode.decodeString Json.Decode.value
+            >> Result.withDefault Json.Encode.null
+        )
+
+
+instanceVarsKey : InstanceVars -> String
+instanceVarsKey =
+    encodeInstanceVars >> Json.Encode.encode 0
+
+
+instanceVarsToString : InstanceVars -> String
+instanceVarsToString =
+    Dict.toList
+        >> List.map
+            (\( key, value ) ->
+                key
+                    ++ ":"
+                    ++ (Json.Decode.decodeString Json.Decode.string value
+                            |> Result.withDefault value
+                       )
+            )
+        >> String.join ","
+
+
+pipelineDisplayName : PipelineName -> InstanceVars -> String
+pipelineDisplayName name instanceVars =
+    if Dict.isEmpty instanceVars then
+        name
+
+    else
+        name ++ "/" ++ instanceVarsToString instanceVars
+
+
+
 -- Resource
 
 
 type alias Resource =
     { teamName : String
     , pipelineName : String
+    , pipelineInstanceVars : InstanceVars
     , name : String
     , icon : Maybe String
     , failingToCheck : Bool
@@ -890,12 +964,14 @@ type alias Resource =
     , pinnedVersion : Maybe Version
     , pinnedInConfig : Bool
     , pinComment : Maybe String
+    , checkRateLimitDelay : Maybe Int
     }
 
 
 type alias ResourceIdentifier =
     { teamName : String
     , pipelineName : String
+    , pipelineInstanceVars : InstanceVars
     , resourceName : String
     }
 
@@ -903,6 +979,7 @@ type alias ResourceIdentifier =
 type alias CheckIdentifier =
     { teamName : String
     , pipelineName : String
+    , pipelineInstanceVars : InstanceVars
     , resourceName : String
     , checkID : Int
     }
@@ -919,6 +996,7 @@ type alias VersionedResource =
 type alias VersionedResourceIdentifier =
     { teamName : String
     , pipelineName : String
+    , pipelineInstanceVars : InstanceVars
     , resourceName : String
     , versionID : Int
     }
@@ -945,6 +1023,7 @@ decodeResource =
     Json.Decode.succeed Resource
         |> andMap (Json.Decode.field "team_name" Json.Decode.string)
         |> andMap (Json.Decode.field "pipeline_name" Json.Decode.string)
+        |> andMap (defaultTo Dict.empty <| Json.Decode.field "pipeline_instance_vars" decodeInstanceVars)
         |> andMap (Json.Decode.field "name" Json.Decode.string)
         |> andMap (Json.Decode.maybe (Json.Decode.field "icon" Json.Decode.string))
         |> andMap (defaultTo False <| Json.Decode.field "failing_to_check" Json.Decode.bool)
@@ -954,6 +1033,7 @@ decodeResource =
         |> andMap (Json.Decode.maybe (Json.Decode.field "pinned_version" decodeVersion))
         |> andMap (defaultTo False <| Json.Decode.field "pinned_in_config" Json.Decode.bool)
         |> andMap (Json.Decode.maybe (Json.Decode.field "pin_comment" Json.Decode.string))
+        |> andMap (Json.Decode.maybe (Json.Decode.field "check_rate_limit_delay" Json.Decode.int))
 
 
 decodeVersionedResource : Json.Decode.Decoder VersionedResource
@@ -7,7 +7,7 @@ module Concourse.BuildEvents exposing
     , decodeOrigin
     )
 
-import Build.StepTree.Models exposing (BuildEvent(..), BuildEventEnvelope, Origin)
+import Build.StepTree.Models exposing (BuildEvent(..), BuildEventEnvelope, Origin, PlacementStep, StepResourceUsage)
 import Concourse
 import Concourse.BuildStatus
 import Dict
@@ -89,9 +89,19 @@ decodeBuildEvent =
                     "selected-worker" ->
                         Json.Decode.field
                             "data"
-                            (Json.Decode.map3 SelectedWorker
+                            (Json.Decode.map4 SelectedWorker
                                 (Json.Decode.field "origin" <| Json.Decode.lazy (\_ -> decodeOrigin))
                                 (Json.Decode.field "selected_worker" Json.Decode.string)
+                                (Json.Decode.map (Maybe.withDefault []) << Json.Decode.maybe <| Json.Decode.field "placement" <| Json.Decode.list decodePlacementStep)
+                                (Json.Decode.maybe <| Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+                            )
+
+                    "resource-usage" ->
+                        Json.Decode.field
+                            "data"
+                            (Json.Decode.map3 ResourceUsage
+                                (Json.Decode.field "origin" <| Json.Decode.lazy (\_ -> decodeOrigin))
+                                (Json.Decode.field "usage" decodeStepResourceUsage)
                                 (Json.Decode.maybe <| Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
                             )
 
@@ -194,6 +204,14 @@ decodeBuildEvent =
                                 (Json.Decode.field "changed" Json.Decode.bool)
                             )
 
+                    "across-substeps" ->
+                        Json.Decode.field
+                            "data"
+                            (Json.Decode.map2 AcrossSubsteps
+                                (Json.Decode.field "origin" decodeOrigin)
+                                (Json.Decode.field "substeps" Concourse.decodeAcrossSubsteps)
+                            )
+
                     unknown ->
                         Json.Decode.fail ("unknown event type: " ++ unknown)
             )
@@ -246,3 +264,19 @@ decodeOrigin =
     Json.Decode.map2 Origin
         (Json.Decode.map (Maybe.withDefault "") << Json.Decode.maybe <| Json.Decode.field "source" Json.Decode.string)
         (Json.Decode.field "id" Json.Decode.string)
+
+
+decodePlacementStep : Json.Decode.Decoder PlacementStep
+decodePlacementStep =
+    Json.Decode.map3 PlacementStep
+        (Json.Decode.field "strategy" Json.Decode.string)
+        (Json.Decode.map (Maybe.withDefault []) << Json.Decode.maybe <| Json.Decode.field "eliminated" <| Json.Decode.list Json.Decode.string)
+        (Json.Decode.map (Maybe.withDefault False) << Json.Decode.maybe <| Json.Decode.field "skipped" Json.Decode.bool)
+
+
+decodeStepResourceUsage : Json.Decode.Decoder StepResourceUsage
+decodeStepResourceUsage =
+    Json.Decode.map3 StepResourceUsage
+        (Json.Decode.map (Maybe.withDefault 0) << Json.Decode.maybe <| Json.Decode.field "cpu_time" Json.Decode.int)
+        (Json.Decode.map (Maybe.withDefault 0) << Json.Decode.maybe <| Json.Decode.field "memory_peak" Json.Decode.int)
+        (Json.Decode.map (Maybe.withDefault 0) << Json.Decode.maybe <| Json.Decode.field "rootfs_disk_usage" Json.Decode.int)
@@ -17,7 +17,7 @@ import Dashboard.Drag as Drag
 import Dashboard.Filter as Filter
 import Dashboard.Footer as Footer
 import Dashboard.Group as Group
-import Dashboard.Group.Models exposing (Pipeline)
+import Dashboard.Group.Models exposing (JobKey, Pipeline, PipelineKey, jobKey, jobPipelineKey)
 import Dashboard.Models as Models
     exposing
         ( DragState(..)
@@ -230,10 +230,7 @@ handleCallback callback ( model, effects ) =
                     allJobsInEntireCluster
                         |> List.map
                             (\job ->
-                                ( ( job.teamName
-                                  , job.pipelineName
-                                  , job.name
-                                  )
+                                ( jobKey job job.name
                                 , job
                                 )
                             )
@@ -315,7 +312,7 @@ handleCallback callback ( model, effects ) =
                 | pipelinesWithResourceErrors =
                     resources
                         |> List.filter .failingToCheck
-                        |> List.map (\r -> ( r.teamName, r.pipelineName ))
+                        |> List.map jobPipelineKey
                         |> Set.fromList
                 , resourcesError = Nothing
               }
@@ -450,7 +447,10 @@ updatePipeline updater pipelineId model =
                     (Dict.update pipelineId.teamName
                         (Maybe.map
                             (List.Extra.updateIf
-                                (\p -> p.name == pipelineId.pipelineName)
+                                (\p ->
+                                    (p.name == pipelineId.pipelineName)
+                                        && (p.instanceVars == pipelineId.pipelineInstanceVars)
+                                )
                                 updater
                             )
                         )
@@ -462,7 +462,13 @@ findPipeline : Concourse.PipelineIdentifier -> Maybe (Dict String (List Pipeline
 findPipeline pipelineId pipelines =
     pipelines
         |> Maybe.andThen (Dict.get pipelineId.teamName)
-        |> Maybe.andThen (List.Extra.find (.name >> (==) pipelineId.pipelineName))
+        |> Maybe.andThen
+            (List.Extra.find
+                (\p ->
+                    (p.name == pipelineId.pipelineName)
+                        && (p.instanceVars == pipelineId.pipelineInstanceVars)
+                )
+            )
 
 
 handleDelivery : Delivery -> ET Model
@@ -510,10 +516,7 @@ handleDeliveryBody delivery ( model, effects ) =
                     jobs
                         |> List.map
                             (\job ->
-                                ( ( job.teamName
-                                  , job.pipelineName
-                                  , job.name
-                                  )
+                                ( jobKey job job.name
                                 , job
                                 )
                             )
@@ -551,6 +554,7 @@ toDashboardPipeline : Bool -> Bool -> Concourse.Pipeline -> Pipeline
 toDashboardPipeline isStale jobsDisabled p =
     { id = p.id
     , name = p.name
+    , instanceVars = p.instanceVars
     , teamName = p.teamName
     , public = p.public
     , isToggleLoading = False
@@ -566,6 +570,7 @@ toConcoursePipeline : Pipeline -> Concourse.Pipeline
 toConcoursePipeline p =
     { id = p.id
     , name = p.name
+    , instanceVars = p.instanceVars
     , teamName = p.teamName
     , public = p.public
     , paused = p.paused
@@ -610,11 +615,12 @@ precomputeJobMetadata model =
                 |> Dict.values
 
         pipelineJobs =
-            allJobs |> groupBy (\j -> ( j.teamName, j.pipelineName ))
+            allJobs |> groupBy jobPipelineKey
 
         jobToId job =
             { teamName = job.teamName
             , pipelineName = job.pipelineName
+            , pipelineInstanceVars = job.pipelineInstanceVars
             , jobName = job.name
             }
     in
@@ -676,6 +682,7 @@ updateBody msg ( model, effects ) =
                     , effects
                         ++ [ teamPipelines
                                 |> List.map .name
+                                |> List.Extra.unique
                                 |> SendOrderPipelinesRequest teamName
                            , pipelines
                                 |> Dict.values
@@ -1116,17 +1123,17 @@ pipelinesView :
             , query : String
             , highDensity : Bool
             , dashboardView : Routes.DashboardView
-            , pipelinesWithResourceErrors : Set ( String, String )
-            , pipelineLayers : Dict ( String, String ) (List (List Concourse.JobIdentifier))
+            , pipelinesWithResourceErrors : Set PipelineKey
+            , pipelineLayers : Dict PipelineKey (List (List Concourse.JobIdentifier))
             , pipelines : Maybe (Dict String (List Pipeline))
-            , jobs : FetchResult (Dict ( String, String, String ) Concourse.Job)
+            , jobs : FetchResult (Dict JobKey Concourse.Job)
             , dragState : DragState
             , dropState : DropState
             , now : Maybe Time.Posix
             , viewportWidth : Float
             , viewportHeight : Float
             , scrollTop : Float
-            , pipelineJobs : Dict ( String, String ) (List Concourse.JobIdentifier)
+            , pipelineJobs : Dict PipelineKey (List Concourse.JobIdentifier)
         }
     -> List (Html Message)
 pipelinesView session params =
@@ -48,6 +48,7 @@ viewJob section hovered job =
         jobId =
             { jobName = job.name
             , pipelineName = job.pipelineName
+            , pipelineInstanceVars = job.pipelineInstanceVars
             , teamName = job.teamName
             }
     in
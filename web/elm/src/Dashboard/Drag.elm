@@ -18,8 +18,14 @@ insertAt idx x xs =
 dragPipeline : String -> DropTarget -> List Pipeline -> List Pipeline
 dragPipeline pipeline target pipelines =
     let
+        -- the instances of a pipeline share its name and are dragged together
+        names =
+            pipelines
+                |> List.map .name
+                |> List.Extra.unique
+
         pipelineIndex name =
-            pipelines |> List.Extra.findIndex (.name >> (==) name)
+            names |> List.Extra.elemIndex name
 
         fromIndex =
             pipelineIndex pipeline
@@ -34,7 +40,9 @@ dragPipeline pipeline target pipelines =
     in
     case ( fromIndex, toIndex ) of
         ( Just from, Just to ) ->
-            drag from (to + 1) pipelines
+            drag from (to + 1) names
+                |> List.concatMap
+                    (\name -> List.filter (.name >> (==) name) pipelines)
 
         _ ->
             pipelines
@@ -8,7 +8,7 @@ module Dashboard.Group exposing
     )
 
 import Concourse
-import Dashboard.Group.Models exposing (Group, Pipeline)
+import Dashboard.Group.Models exposing (Group, JobKey, Pipeline, PipelineKey, jobKey, pipelineKey)
 import Dashboard.Group.Tag as Tag
 import Dashboard.Models exposing (DragState(..), DropState(..))
 import Dashboard.Pipeline as Pipeline
@@ -51,13 +51,13 @@ view :
         , now : Maybe Time.Posix
         , hovered : HoverState.HoverState
         , pipelineRunningKeyframes : String
-        , pipelinesWithResourceErrors : Set ( String, String )
-        , pipelineLayers : Dict ( String, String ) (List (List Concourse.JobIdentifier))
+        , pipelinesWithResourceErrors : Set PipelineKey
+        , pipelineLayers : Dict PipelineKey (List (List Concourse.JobIdentifier))
         , pipelineCards : List PipelineGrid.PipelineCard
         , dropAreas : List PipelineGrid.DropArea
         , groupCardsHeight : Float
-        , pipelineJobs : Dict ( String, String ) (List Concourse.JobIdentifier)
-        , jobs : Dict ( String, String, String ) Concourse.Job
+        , pipelineJobs : Dict PipelineKey (List Concourse.JobIdentifier)
+        , jobs : Dict JobKey Concourse.Job
         }
     -> Group
     -> Html Message
@@ -131,13 +131,13 @@ viewFavoritePipelines :
         , now : Maybe Time.Posix
         , hovered : HoverState.HoverState
         , pipelineRunningKeyframes : String
-        , pipelinesWithResourceErrors : Set ( String, String )
-        , pipelineLayers : Dict ( String, String ) (List (List Concourse.JobIdentifier))
+        , pipelinesWithResourceErrors : Set PipelineKey
+        , pipelineLayers : Dict PipelineKey (List (List Concourse.JobIdentifier))
         , pipelineCards : List PipelineGrid.PipelineCard
         , headers : List PipelineGrid.Header
         , groupCardsHeight : Float
-        , pipelineJobs : Dict ( String, String ) (List Concourse.JobIdentifier)
-        , jobs : Dict ( String, String, String ) Concourse.Job
+        , pipelineJobs : Dict PipelineKey (List Concourse.JobIdentifier)
+        , jobs : Dict JobKey Concourse.Job
         }
     -> Html Message
 viewFavoritePipelines session params =
@@ -190,9 +190,9 @@ tag { userState } g =
 
 hdView :
     { pipelineRunningKeyframes : String
-    , pipelinesWithResourceErrors : Set ( String, String )
-    , pipelineJobs : Dict ( String, String ) (List Concourse.JobIdentifier)
-    , jobs : Dict ( String, String, String ) Concourse.Job
+    , pipelinesWithResourceErrors : Set PipelineKey
+    , pipelineJobs : Dict PipelineKey (List Concourse.JobIdentifier)
+    , jobs : Dict JobKey Concourse.Job
     }
     -> { a | userState : UserState }
     -> Group
@@ -221,10 +221,10 @@ hdView { pipelineRunningKeyframes, pipelinesWithResourceErrors, pipelineJobs, jo
                                 , pipelineRunningKeyframes = pipelineRunningKeyframes
                                 , resourceError =
                                     pipelinesWithResourceErrors
-                                        |> Set.member ( p.teamName, p.name )
+                                        |> Set.member (pipelineKey p)
                                 , existingJobs =
                                     pipelineJobs
-                                        |> Dict.get ( p.teamName, p.name )
+                                        |> Dict.get (pipelineKey p)
                                         |> Maybe.withDefault []
                                         |> List.filterMap (lookupJob jobs)
                                 }
@@ -256,10 +256,10 @@ pipelineNotSetView =
         ]
 
 
-lookupJob : Dict ( String, String, String ) Concourse.Job -> Concourse.JobIdentifier -> Maybe Concourse.Job
+lookupJob : Dict JobKey Concourse.Job -> Concourse.JobIdentifier -> Maybe Concourse.Job
 lookupJob jobs jobId =
     jobs
-        |> Dict.get ( jobId.teamName, jobId.pipelineName, jobId.jobName )
+        |> Dict.get (jobKey jobId jobId.jobName)
 
 
 pipelineCardView :
@@ -271,10 +271,10 @@ pipelineCardView :
             , now : Maybe Time.Posix
             , hovered : HoverState.HoverState
             , pipelineRunningKeyframes : String
-            , pipelinesWithResourceErrors : Set ( String, String )
-            , pipelineLayers : Dict ( String, String ) (List (List Concourse.JobIdentifier))
-            , pipelineJobs : Dict ( String, String ) (List Concourse.JobIdentifier)
-            , jobs : Dict ( String, String, String ) Concourse.Job
+            , pipelinesWithResourceErrors : Set PipelineKey
+            , pipelineLayers : Dict PipelineKey (List (List Concourse.JobIdentifier))
+            , pipelineJobs : Dict PipelineKey (List Concourse.JobIdentifier)
+            , jobs : Dict JobKey Concourse.Job
         }
     -> PipelinesSection
     ->
@@ -308,6 +308,7 @@ pipelineCardView session params section { bounds, pipeline } teamName =
                 Just <|
                     PipelineWrapper
                         { pipelineName = pipeline.name
+                        , pipelineInstanceVars = pipeline.instanceVars
                         , teamName = pipeline.teamName
                         }
          , onMouseOut <| Hover Nothing
@@ -322,6 +323,7 @@ pipelineCardView session params section { bounds, pipeline } teamName =
                     hoverStyle id =
                         if
                             (id.pipelineName == pipeline.name)
+                                && (id.pipelineInstanceVars == pipeline.instanceVars)
                                 && (id.teamName == pipeline.teamName)
                         then
                             [ style "z-index" "1" ]
@@ -380,15 +382,15 @@ pipelineCardView session params section { bounds, pipeline } teamName =
                 , pipeline = pipeline
                 , resourceError =
                     params.pipelinesWithResourceErrors
-                        |> Set.member ( pipeline.teamName, pipeline.name )
+                        |> Set.member (pipelineKey pipeline)
                 , existingJobs =
                     params.pipelineJobs
-                        |> Dict.get ( pipeline.teamName, pipeline.name )
+                        |> Dict.get (pipelineKey pipeline)
                         |> Maybe.withDefault []
                         |> List.filterMap (lookupJob params.jobs)
                 , layers =
                     params.pipelineLayers
-                        |> Dict.get ( pipeline.teamName, pipeline.name )
+                        |> Dict.get (pipelineKey pipeline)
                         |> Maybe.withDefault []
                         |> List.map (List.filterMap <| lookupJob params.jobs)
                 , hovered = params.hovered
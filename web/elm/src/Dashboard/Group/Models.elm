@@ -1,4 +1,14 @@
-module Dashboard.Group.Models exposing (Group, Pipeline)
+module Dashboard.Group.Models exposing
+    ( Group
+    , JobKey
+    , Pipeline
+    , PipelineKey
+    , jobKey
+    , jobPipelineKey
+    , pipelineKey
+    )
+
+import Concourse
 
 
 type alias Group =
@@ -10,6 +20,7 @@ type alias Group =
 type alias Pipeline =
     { id : Int
     , name : String
+    , instanceVars : Concourse.InstanceVars
     , teamName : String
     , public : Bool
     , isToggleLoading : Bool
@@ -19,3 +30,44 @@ type alias Pipeline =
     , stale : Bool
     , jobsDisabled : Bool
     }
+
+
+
+-- the instances of a pipeline share its name, so pipelines are keyed by their
+-- instance vars as well
+
+
+type alias PipelineKey =
+    ( Concourse.TeamName, Concourse.PipelineName, String )
+
+
+type alias JobKey =
+    ( PipelineKey, Concourse.JobName )
+
+
+pipelineKey : Pipeline -> PipelineKey
+pipelineKey p =
+    ( p.teamName, p.name, Concourse.instanceVarsKey p.instanceVars )
+
+
+jobPipelineKey :
+    { a
+        | teamName : Concourse.TeamName
+        , pipelineName : Concourse.PipelineName
+        , pipelineInstanceVars : Concourse.InstanceVars
+    }
+    -> PipelineKey
+jobPipelineKey j =
+    ( j.teamName, j.pipelineName, Concourse.instanceVarsKey j.pipelineInstanceVars )
+
+
+jobKey :
+    { a
+        | teamName : Concourse.TeamName
+        , pipelineName : Concourse.PipelineName
+        , pipelineInstanceVars : Concourse.InstanceVars
+    }
+    -> Concourse.JobName
+    -> JobKey
+jobKey j jobName =
+    ( jobPipelineKey j, jobName )
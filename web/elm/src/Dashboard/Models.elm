@@ -8,7 +8,7 @@ module Dashboard.Models exposing
     )
 
 import Concourse
-import Dashboard.Group.Models
+import Dashboard.Group.Models exposing (JobKey, PipelineKey)
 import Dict exposing (Dict)
 import FetchResult exposing (FetchResult)
 import Login.Login as Login
@@ -25,9 +25,9 @@ type alias Model =
             { now : Maybe Time.Posix
             , highDensity : Bool
             , query : String
-            , pipelinesWithResourceErrors : Set ( String, String )
-            , jobs : FetchResult (Dict ( String, String, String ) Concourse.Job)
-            , pipelineLayers : Dict ( String, String ) (List (List Concourse.JobIdentifier))
+            , pipelinesWithResourceErrors : Set PipelineKey
+            , jobs : FetchResult (Dict JobKey Concourse.Job)
+            , pipelineLayers : Dict PipelineKey (List (List Concourse.JobIdentifier))
             , teams : FetchResult (List Concourse.Team)
             , dragState : DragState
             , dropState : DropState
@@ -42,7 +42,7 @@ type alias Model =
             , viewportWidth : Float
             , viewportHeight : Float
             , scrollTop : Float
-            , pipelineJobs : Dict ( String, String ) (List Concourse.JobIdentifier)
+            , pipelineJobs : Dict PipelineKey (List Concourse.JobIdentifier)
             , effectsToRetry : List Effect
             }
         )
@@ -12,6 +12,7 @@ import Concourse.PipelineStatus as PipelineStatus
 import Dashboard.DashboardPreview as DashboardPreview
 import Dashboard.Group.Models exposing (Pipeline)
 import Dashboard.Styles as Styles
+import Dict
 import Duration
 import HoverState
 import Html exposing (Html)
@@ -94,7 +95,7 @@ hdPipelineView { pipeline, pipelineRunningKeyframes, resourceError, existingJobs
             []
         , Html.div
             (class "dashboardhd-pipeline-name" :: Styles.pipelineCardBodyHd)
-            [ Html.text pipeline.name ]
+            [ Html.text <| Concourse.pipelineDisplayName pipeline.name pipeline.instanceVars ]
         ]
             ++ (if resourceError then
                     [ Html.div Styles.resourceErrorTriangle [] ]
@@ -268,6 +269,13 @@ headerView pipeline resourceError =
             [ Html.div
                 (class "dashboard-pipeline-name" :: Styles.pipelineName)
                 [ Html.text pipeline.name ]
+            , if Dict.isEmpty pipeline.instanceVars then
+                Html.text ""
+
+              else
+                Html.div
+                    (class "dashboard-pipeline-instance-vars" :: Styles.pipelineInstanceVars)
+                    [ Html.text <| Concourse.instanceVarsToString pipeline.instanceVars ]
             , Html.div
                 [ classList
                     [ ( "dashboard-resource-error", resourceError )
@@ -301,6 +309,7 @@ footerView userState favoritedPipelines pipeline section now hovered existingJob
 
         pipelineId =
             { pipelineName = pipeline.name
+            , pipelineInstanceVars = pipeline.instanceVars
             , teamName = pipeline.teamName
             }
 
@@ -366,6 +375,7 @@ pipelineStatusView section pipeline status now =
     let
         pipelineId =
             { pipelineName = pipeline.name
+            , pipelineInstanceVars = pipeline.instanceVars
             , teamName = pipeline.teamName
             }
     in
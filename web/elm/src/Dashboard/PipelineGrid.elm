@@ -9,7 +9,7 @@ module Dashboard.PipelineGrid exposing
 
 import Concourse
 import Dashboard.Drag exposing (dragPipeline)
-import Dashboard.Group.Models exposing (Group, Pipeline)
+import Dashboard.Group.Models exposing (Group, Pipeline, PipelineKey, pipelineKey)
 import Dashboard.Models exposing (DragState(..), DropState(..))
 import Dashboard.PipelineGrid.Constants
     exposing
@@ -54,7 +54,7 @@ type alias Header =
 computeLayout :
     { dragState : DragState
     , dropState : DropState
-    , pipelineLayers : Dict ( String, String ) (List (List Concourse.JobIdentifier))
+    , pipelineLayers : Dict PipelineKey (List (List Concourse.JobIdentifier))
     , viewportWidth : Float
     , viewportHeight : Float
     , scrollTop : Float
@@ -208,7 +208,7 @@ computeLayout params g =
 
 
 computeFavoritePipelinesLayout :
-    { pipelineLayers : Dict ( String, String ) (List (List Concourse.JobIdentifier))
+    { pipelineLayers : Dict PipelineKey (List (List Concourse.JobIdentifier))
     , viewportWidth : Float
     , viewportHeight : Float
     , scrollTop : Float
@@ -322,13 +322,13 @@ computeFavoritePipelinesLayout params pipelines =
 
 
 previewSizes :
-    Dict ( String, String ) (List (List Concourse.JobIdentifier))
+    Dict PipelineKey (List (List Concourse.JobIdentifier))
     -> List Pipeline
     -> List ( Int, Int )
 previewSizes pipelineLayers =
     List.map
         (\pipeline ->
-            Dict.get ( pipeline.teamName, pipeline.name ) pipelineLayers
+            Dict.get (pipelineKey pipeline) pipelineLayers
                 |> Maybe.withDefault []
         )
         >> List.map
@@ -37,6 +37,7 @@ module Dashboard.Styles exposing
     , pipelineCardHeader
     , pipelineCardTransitionAge
     , pipelineCardTransitionAgeStale
+    , pipelineInstanceVars
     , pipelineName
     , pipelinePreviewGrid
     , pipelineSectionHeader
@@ -207,6 +208,19 @@ pipelineName =
     ]
 
 
+pipelineInstanceVars : List (Html.Attribute msg)
+pipelineInstanceVars =
+    [ style "width" "245px"
+    , style "white-space" "nowrap"
+    , style "overflow" "hidden"
+    , style "text-overflow" "ellipsis"
+    , style "font-size" "0.6em"
+    , style "letter-spacing" "0.05em"
+    , style "padding-top" "4px"
+    , style "opacity" "0.7"
+    ]
+
+
 cardBody : List (Html.Attribute msg)
 cardBody =
     [ style "padding" "20px 36px"
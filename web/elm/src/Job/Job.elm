@@ -175,6 +175,7 @@ handleCallback callback ( model, effects ) =
                                         { id =
                                             { teamName = job.teamName
                                             , pipelineName = job.pipelineName
+                                            , pipelineInstanceVars = job.pipelineInstanceVars
                                             , jobName = job.jobName
                                             , buildName = build.name
                                             }
@@ -421,6 +422,7 @@ view session model =
             [ SideBar.view session
                 (Just
                     { pipelineName = model.jobIdentifier.pipelineName
+                    , pipelineInstanceVars = model.jobIdentifier.pipelineInstanceVars
                     , teamName = model.jobIdentifier.teamName
                     }
                 )
@@ -583,10 +585,10 @@ isPipelineArchived :
     WebData (List Concourse.Pipeline)
     -> Concourse.JobIdentifier
     -> Bool
-isPipelineArchived pipelines { pipelineName, teamName } =
+isPipelineArchived pipelines { pipelineName, pipelineInstanceVars, teamName } =
     pipelines
         |> RemoteData.withDefault []
-        |> List.Extra.find (\p -> p.name == pipelineName && p.teamName == teamName)
+        |> List.Extra.find (\p -> p.name == pipelineName && p.instanceVars == pipelineInstanceVars && p.teamName == teamName)
         |> Maybe.map .archived
         |> Maybe.withDefault False
 
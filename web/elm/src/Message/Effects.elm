@@ -677,22 +677,18 @@ toHtmlID domId =
             pipelinesSectionName section ++ "_" ++ Base64.encode t
 
         SideBarPipeline section p ->
-            pipelinesSectionName section ++ "_" ++ Base64.encode p.teamName ++ "_" ++ Base64.encode p.pipelineName
+            pipelinesSectionName section ++ "_" ++ pipelineHtmlID p
 
         PipelineStatusIcon section p ->
             pipelinesSectionName section
                 ++ "_"
-                ++ Base64.encode p.teamName
-                ++ "_"
-                ++ Base64.encode p.pipelineName
+                ++ pipelineHtmlID p
                 ++ "_status"
 
         VisibilityButton section p ->
             pipelinesSectionName section
                 ++ "_"
-                ++ Base64.encode p.teamName
-                ++ "_"
-                ++ Base64.encode p.pipelineName
+                ++ pipelineHtmlID p
                 ++ "_visibility"
 
         ChangedStepLabel stepID _ ->
@@ -717,6 +713,13 @@ toHtmlID domId =
             ""
 
 
+pipelineHtmlID : Concourse.PipelineIdentifier -> String
+pipelineHtmlID p =
+    Base64.encode p.teamName
+        ++ "_"
+        ++ Base64.encode (Concourse.pipelineDisplayName p.pipelineName p.pipelineInstanceVars)
+
+
 scroll : ScrollDirection -> String -> Cmd Callback
 scroll direction id =
     case direction of
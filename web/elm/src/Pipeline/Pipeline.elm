@@ -368,7 +368,9 @@ subscriptions =
 
 documentTitle : Model -> String
 documentTitle model =
-    model.pipelineLocator.pipelineName
+    Concourse.pipelineDisplayName
+        model.pipelineLocator.pipelineName
+        model.pipelineLocator.pipelineInstanceVars
 
 
 view : Session -> Model -> Html Message
@@ -33,6 +33,7 @@ type alias Model =
         , checkStatus : CheckStatus
         , checkError : String
         , checkSetupError : String
+        , checkRateLimitDelay : Maybe Int
         , lastChecked : Maybe Time.Posix
         , pinnedVersion : PinnedVersion
         , now : Maybe Time.Posix
@@ -111,6 +111,7 @@ init flags =
             , checkStatus = Models.CheckingSuccessfully
             , checkError = ""
             , checkSetupError = ""
+            , checkRateLimitDelay = Nothing
             , lastChecked = Nothing
             , pinnedVersion = NotPinned
             , currentPage = flags.paging
@@ -238,6 +239,7 @@ handleCallback callback session ( model, effects ) =
                 , resourceIdentifier =
                     { teamName = resource.teamName
                     , pipelineName = resource.pipelineName
+                    , pipelineInstanceVars = resource.pipelineInstanceVars
                     , resourceName = resource.name
                     }
                 , checkStatus =
@@ -248,6 +250,7 @@ handleCallback callback session ( model, effects ) =
                         Models.CheckingSuccessfully
                 , checkError = resource.checkError
                 , checkSetupError = resource.checkSetupError
+                , checkRateLimitDelay = resource.checkRateLimitDelay
                 , lastChecked = resource.lastChecked
                 , icon = resource.icon
               }
@@ -321,6 +324,7 @@ handleCallback callback session ( model, effects ) =
                                             { id =
                                                 { teamName = model.resourceIdentifier.teamName
                                                 , pipelineName = model.resourceIdentifier.pipelineName
+                                                , pipelineInstanceVars = model.resourceIdentifier.pipelineInstanceVars
                                                 , resourceName = model.resourceIdentifier.resourceName
                                                 , versionID = vr.id
                                                 }
@@ -867,6 +871,7 @@ view session model =
             [ SideBar.view session
                 (Just
                     { pipelineName = model.resourceIdentifier.pipelineName
+                    , pipelineInstanceVars = model.resourceIdentifier.pipelineInstanceVars
                     , teamName = model.resourceIdentifier.teamName
                     }
                 )
@@ -951,6 +956,7 @@ body session model =
             { checkStatus = model.checkStatus
             , checkSetupError = model.checkSetupError
             , checkError = model.checkError
+            , checkRateLimitDelay = model.checkRateLimitDelay
             , hovered = session.hovered
             , userState = session.userState
             , teamName = model.resourceIdentifier.teamName
@@ -1091,12 +1097,13 @@ checkSection :
         | checkStatus : Models.CheckStatus
         , checkSetupError : String
         , checkError : String
+        , checkRateLimitDelay : Maybe Int
         , hovered : HoverState.HoverState
         , userState : UserState
         , teamName : String
     }
     -> Html Message
-checkSection ({ checkStatus, checkSetupError, checkError } as model) =
+checkSection ({ checkStatus, checkSetupError, checkError, checkRateLimitDelay } as model) =
     let
         failingToCheck =
             checkStatus == Models.FailingToCheck
@@ -1130,7 +1137,19 @@ checkSection ({ checkStatus, checkSetupError, checkError } as model) =
                     ]
 
             else
-                []
+                case checkRateLimitDelay of
+                    Just delay ->
+                        [ Html.div
+                            (class "step-body" :: Resource.Styles.checkRateLimitDelay)
+                            [ Html.text <|
+                                "last check was held back for "
+                                    ++ Duration.format (delay * 1000)
+                                    ++ " by the check rate limits"
+                            ]
+                        ]
+
+                    Nothing ->
+                        []
 
         statusIcon =
             case checkStatus of
@@ -1474,10 +1493,10 @@ isPipelineArchived :
     WebData (List Concourse.Pipeline)
     -> Concourse.ResourceIdentifier
     -> Bool
-isPipelineArchived pipelines { pipelineName, teamName } =
+isPipelineArchived pipelines { pipelineName, pipelineInstanceVars, teamName } =
     pipelines
         |> RemoteData.withDefault []
-        |> List.Extra.find (\p -> p.name == pipelineName && p.teamName == teamName)
+        |> List.Extra.find (\p -> p.name == pipelineName && p.instanceVars == pipelineInstanceVars && p.teamName == teamName)
         |> Maybe.map .archived
         |> Maybe.withDefault False
 
@@ -1832,6 +1851,7 @@ viewBuildsByJob buildDict jobName =
                                     { id =
                                         { teamName = job.teamName
                                         , pipelineName = job.pipelineName
+                                        , pipelineInstanceVars = job.pipelineInstanceVars
                                         , jobName = job.jobName
                                         , buildName = build.name
                                         }
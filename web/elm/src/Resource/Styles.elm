@@ -3,6 +3,7 @@ module Resource.Styles exposing
     , checkBarStatus
     , checkButton
     , checkButtonIcon
+    , checkRateLimitDelay
     , checkStatusIcon
     , commentBar
     , commentBarIconContainer
@@ -424,6 +425,13 @@ checkBarStatus =
     ]
 
 
+checkRateLimitDelay : List (Html.Attribute msg)
+checkRateLimitDelay =
+    [ style "padding" "5px 10px"
+    , style "color" Colors.pending
+    ]
+
+
 checkButton : Bool -> List (Html.Attribute msg)
 checkButton isClickable =
     [ style "height" "28px"
@@ -19,6 +19,7 @@ module Routes exposing
 import Concourse
 import Concourse.Pagination as Pagination exposing (Direction(..))
 import Dict
+import Json.Decode
 import Maybe.Extra
 import Url
 import Url.Builder as Builder
@@ -98,11 +99,12 @@ type alias Transition =
 build : Parser (Route -> a) a
 build =
     let
-        buildHelper teamName pipelineName jobName buildName h =
+        buildHelper teamName pipelineName jobName buildName h instanceVars =
             Build
                 { id =
                     { teamName = teamName
                     , pipelineName = pipelineName
+                    , pipelineInstanceVars = instanceVars
                     , jobName = jobName
                     , buildName = buildName
                     }
@@ -119,6 +121,7 @@ build =
             </> s "builds"
             </> string
             </> fragment parseHighlight
+            <?> instanceVarsQuery
         )
 
 
@@ -151,11 +154,12 @@ parsePage since until limit =
 resource : Parser (Route -> a) a
 resource =
     let
-        resourceHelper teamName pipelineName resourceName since until limit =
+        resourceHelper teamName pipelineName resourceName since until limit instanceVars =
             Resource
                 { id =
                     { teamName = teamName
                     , pipelineName = pipelineName
+                    , pipelineInstanceVars = instanceVars
                     , resourceName = resourceName
                     }
                 , page = parsePage since until limit
@@ -171,17 +175,19 @@ resource =
             <?> Query.int "since"
             <?> Query.int "until"
             <?> Query.int "limit"
+            <?> instanceVarsQuery
         )
 
 
 job : Parser (Route -> a) a
 job =
     let
-        jobHelper teamName pipelineName jobName since until limit =
+        jobHelper teamName pipelineName jobName since until limit instanceVars =
             Job
                 { id =
                     { teamName = teamName
                     , pipelineName = pipelineName
+                    , pipelineInstanceVars = instanceVars
                     , jobName = jobName
                     }
                 , page = parsePage since until limit
@@ -197,17 +203,19 @@ job =
             <?> Query.int "since"
             <?> Query.int "until"
             <?> Query.int "limit"
+            <?> instanceVarsQuery
         )
 
 
 pipeline : Parser (Route -> a) a
 pipeline =
     map
-        (\t p g ->
+        (\t p g iv ->
             Pipeline
                 { id =
                     { teamName = t
                     , pipelineName = p
+                    , pipelineInstanceVars = iv
                     }
                 , groups = g
                 }
@@ -217,6 +225,7 @@ pipeline =
             </> s "pipelines"
             </> string
             <?> Query.custom "group" identity
+            <?> instanceVarsQuery
         )
 
 
@@ -247,6 +256,16 @@ dashboardViewQuery =
         |> Query.map (Maybe.withDefault ViewNonArchivedPipelines)
 
 
+instanceVarsQuery : Query.Parser Concourse.InstanceVars
+instanceVarsQuery =
+    Query.string "vars"
+        |> Query.map
+            (Maybe.andThen
+                (Json.Decode.decodeString Concourse.decodeInstanceVars >> Result.toMaybe)
+                >> Maybe.withDefault Dict.empty
+            )
+
+
 flySuccess : Parser (Route -> a) a
 flySuccess =
     map (\s -> FlySuccess (s == Just "true"))
@@ -268,6 +287,7 @@ buildRoute id name jobId =
                 { id =
                     { teamName = j.teamName
                     , pipelineName = j.pipelineName
+                    , pipelineInstanceVars = j.pipelineInstanceVars
                     , jobName = j.jobName
                     , buildName = name
                     }
@@ -284,15 +304,23 @@ jobRoute j =
         { id =
             { teamName = j.teamName
             , pipelineName = j.pipelineName
+            , pipelineInstanceVars = j.pipelineInstanceVars
             , jobName = j.name
             }
         , page = Nothing
         }
 
 
-pipelineRoute : { a | name : String, teamName : String } -> Route
+pipelineRoute : { a | name : String, teamName : String, instanceVars : Concourse.InstanceVars } -> Route
 pipelineRoute p =
-    Pipeline { id = { teamName = p.teamName, pipelineName = p.name }, groups = [] }
+    Pipeline
+        { id =
+            { teamName = p.teamName
+            , pipelineName = p.name
+            , pipelineInstanceVars = p.instanceVars
+            }
+        , groups = []
+        }
 
 
 showHighlight : Highlight -> String
@@ -371,6 +399,15 @@ sitemap =
         ]
 
 
+instanceVarsToQueryParams : Concourse.InstanceVars -> List Builder.QueryParameter
+instanceVarsToQueryParams instanceVars =
+    if Dict.isEmpty instanceVars then
+        []
+
+    else
+        [ Builder.string "vars" <| Concourse.instanceVarsKey instanceVars ]
+
+
 pageToQueryParams : Maybe Pagination.Page -> List Builder.QueryParameter
 pageToQueryParams page =
     case page of
@@ -408,7 +445,7 @@ toString route =
                 , "builds"
                 , id.buildName
                 ]
-                []
+                (instanceVarsToQueryParams id.pipelineInstanceVars)
                 ++ showHighlight highlight
 
         Job { id, page } ->
@@ -420,7 +457,7 @@ toString route =
                 , "jobs"
                 , id.jobName
                 ]
-                (pageToQueryParams page)
+                (instanceVarsToQueryParams id.pipelineInstanceVars ++ pageToQueryParams page)
 
         Resource { id, page } ->
             Builder.absolute
@@ -431,7 +468,7 @@ toString route =
                 , "resources"
                 , id.resourceName
                 ]
-                (pageToQueryParams page)
+                (instanceVarsToQueryParams id.pipelineInstanceVars ++ pageToQueryParams page)
 
         OneOffBuild { id, highlight } ->
             Builder.absolute
@@ -448,7 +485,9 @@ toString route =
                 , "pipelines"
                 , id.pipelineName
                 ]
-                (groups |> List.map (Builder.string "group"))
+                (instanceVarsToQueryParams id.pipelineInstanceVars
+                    ++ (groups |> List.map (Builder.string "group"))
+                )
 
         Dashboard { searchType, dashboardView } ->
             let
@@ -508,13 +547,25 @@ extractPid : Route -> Maybe Concourse.PipelineIdentifier
 extractPid route =
     case route of
         Build { id } ->
-            Just { teamName = id.teamName, pipelineName = id.pipelineName }
+            Just
+                { teamName = id.teamName
+                , pipelineName = id.pipelineName
+                , pipelineInstanceVars = id.pipelineInstanceVars
+                }
 
         Job { id } ->
-            Just { teamName = id.teamName, pipelineName = id.pipelineName }
+            Just
+                { teamName = id.teamName
+                , pipelineName = id.pipelineName
+                , pipelineInstanceVars = id.pipelineInstanceVars
+                }
 
         Resource { id } ->
-            Just { teamName = id.teamName, pipelineName = id.pipelineName }
+            Just
+                { teamName = id.teamName
+                , pipelineName = id.pipelineName
+                , pipelineInstanceVars = id.pipelineInstanceVars
+                }
 
         Pipeline { id } ->
             Just id
@@ -14,6 +14,7 @@ type alias PipelineScoped a =
     { a
         | teamName : String
         , pipelineName : String
+        , pipelineInstanceVars : Concourse.InstanceVars
     }
 
 
@@ -31,13 +32,16 @@ pipeline params p =
         isCurrent =
             case params.currentPipeline of
                 Just cp ->
-                    cp.pipelineName == p.name && cp.teamName == p.teamName
+                    cp.pipelineName == p.name && cp.pipelineInstanceVars == p.instanceVars && cp.teamName == p.teamName
 
                 Nothing ->
                     False
 
         pipelineId =
-            { pipelineName = p.name, teamName = p.teamName }
+            { pipelineName = p.name
+            , pipelineInstanceVars = p.instanceVars
+            , teamName = p.teamName
+            }
 
         domID =
             SideBarPipeline
@@ -76,7 +80,7 @@ pipeline params p =
 
             else
                 Styles.Dim
-        , text = p.name
+        , text = Concourse.pipelineDisplayName p.name p.instanceVars
         , weight =
             if isCurrent then
                 Styles.Bold
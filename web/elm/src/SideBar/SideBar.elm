@@ -50,6 +50,7 @@ type alias PipelineScoped a =
     { a
         | teamName : String
         , pipelineName : String
+        , pipelineInstanceVars : Concourse.InstanceVars
     }
 
 
@@ -253,7 +254,13 @@ tooltip { hovered } =
 
         HoverState.Tooltip (SideBarPipeline _ pipelineID) _ ->
             Just
-                { body = Html.div Styles.tooltipBody [ Html.text pipelineID.pipelineName ]
+                { body =
+                    Html.div Styles.tooltipBody
+                        [ Html.text <|
+                            Concourse.pipelineDisplayName
+                                pipelineID.pipelineName
+                                pipelineID.pipelineInstanceVars
+                        ]
                 , attachPosition =
                     { direction =
                         Tooltip.Right <|
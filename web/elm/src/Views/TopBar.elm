@@ -33,6 +33,7 @@ breadcrumbs route =
                 [ pipelineBreadcumb
                     { teamName = id.teamName
                     , pipelineName = id.pipelineName
+                    , pipelineInstanceVars = id.pipelineInstanceVars
                     }
                 ]
 
@@ -40,6 +41,7 @@ breadcrumbs route =
                 [ pipelineBreadcumb
                     { teamName = id.teamName
                     , pipelineName = id.pipelineName
+                    , pipelineInstanceVars = id.pipelineInstanceVars
                     }
                 , breadcrumbSeparator
                 , jobBreadcrumb id.jobName
@@ -49,6 +51,7 @@ breadcrumbs route =
                 [ pipelineBreadcumb
                     { teamName = id.teamName
                     , pipelineName = id.pipelineName
+                    , pipelineInstanceVars = id.pipelineInstanceVars
                     }
                 , breadcrumbSeparator
                 , resourceBreadcrumb id.resourceName
@@ -58,6 +61,7 @@ breadcrumbs route =
                 [ pipelineBreadcumb
                     { teamName = id.teamName
                     , pipelineName = id.pipelineName
+                    , pipelineInstanceVars = id.pipelineInstanceVars
                     }
                 , breadcrumbSeparator
                 , jobBreadcrumb id.jobName
@@ -107,7 +111,10 @@ pipelineBreadcumb pipelineId =
                 , widthPx = 28
                 , heightPx = 16
                 }
-            , name = pipelineId.pipelineName
+            , name =
+                Concourse.pipelineDisplayName
+                    pipelineId.pipelineName
+                    pipelineId.pipelineInstanceVars
             }
         )
 
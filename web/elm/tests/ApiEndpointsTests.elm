@@ -2,6 +2,7 @@ module ApiEndpointsTests exposing (testEndpoints, testToString)
 
 import Api.Endpoints as E exposing (Endpoint(..), toString)
 import Data
+import Dict
 import Expect
 import Test exposing (Test, describe, test)
 import Url.Builder
@@ -113,6 +114,18 @@ testEndpoints =
                     )
                     |> toPath
                     |> Expect.equal "/api/v1/teams/team/pipelines/pipeline/jobs/job/builds/build"
+        , test "JobBuild of a pipeline instance" <|
+            \_ ->
+                JobBuild
+                    (Data.jobBuildId
+                        |> Data.withBuildName "build"
+                        |> Data.withJobName "job"
+                        |> Data.withPipelineName "pipeline"
+                        |> Data.withTeamName "team"
+                        |> (\id -> { id | pipelineInstanceVars = Dict.fromList [ ( "branch", "\"feature\"" ) ] })
+                    )
+                    |> toPath
+                    |> Expect.equal "/api/v1/teams/team/pipelines/pipeline/jobs/job/builds/build?vars=%7B%22branch%22%3A%22feature%22%7D"
         , describe "Build" <|
             let
                 baseBuildEndpoint =
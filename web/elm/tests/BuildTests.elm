@@ -939,6 +939,7 @@ all =
                                 { pausedPipeline = BuildPrepStatusUnknown
                                 , pausedJob = BuildPrepStatusUnknown
                                 , maxRunningBuilds = BuildPrepStatusUnknown
+                                , teamQuota = BuildPrepStatusUnknown
                                 , inputs = Dict.empty
                                 , inputsSatisfied = BuildPrepStatusUnknown
                                 , missingInputReasons = Dict.empty
@@ -1128,6 +1129,7 @@ all =
                             Ok
                                 { name = ""
                                 , pipelineName = "p"
+                                , pipelineInstanceVars = Dict.empty
                                 , teamName = "t"
                                 , nextBuild = Nothing
                                 , finishedBuild = Nothing
@@ -1182,6 +1184,7 @@ all =
                             Ok
                                 { name = ""
                                 , pipelineName = "p"
+                                , pipelineInstanceVars = Dict.empty
                                 , teamName = "t"
                                 , nextBuild = Nothing
                                 , finishedBuild = Nothing
@@ -1216,6 +1219,7 @@ all =
                             Ok
                                 { name = ""
                                 , pipelineName = "p"
+                                , pipelineInstanceVars = Dict.empty
                                 , teamName = "t"
                                 , nextBuild = Nothing
                                 , finishedBuild = Nothing
@@ -2478,6 +2482,7 @@ all =
                             { pausedPipeline = BuildPrepStatusNotBlocking
                             , pausedJob = BuildPrepStatusNotBlocking
                             , maxRunningBuilds = BuildPrepStatusNotBlocking
+                            , teamQuota = BuildPrepStatusNotBlocking
                             , inputs = Dict.empty
                             , inputsSatisfied = BuildPrepStatusNotBlocking
                             , missingInputReasons = Dict.empty
@@ -2520,6 +2525,7 @@ all =
                             { pausedPipeline = BuildPrepStatusBlocking
                             , pausedJob = BuildPrepStatusNotBlocking
                             , maxRunningBuilds = BuildPrepStatusNotBlocking
+                            , teamQuota = BuildPrepStatusNotBlocking
                             , inputs = Dict.empty
                             , inputsSatisfied = BuildPrepStatusNotBlocking
                             , missingInputReasons = Dict.empty
@@ -2557,6 +2563,7 @@ all =
                             { pausedPipeline = BuildPrepStatusUnknown
                             , pausedJob = BuildPrepStatusNotBlocking
                             , maxRunningBuilds = BuildPrepStatusNotBlocking
+                            , teamQuota = BuildPrepStatusNotBlocking
                             , inputs = Dict.empty
                             , inputsSatisfied = BuildPrepStatusNotBlocking
                             , missingInputReasons = Dict.empty
@@ -8,6 +8,7 @@ import Concourse.BuildStatus exposing (BuildStatus(..))
 import Dashboard.DashboardPreview as DP
 import DashboardTests exposing (whenOnDashboard)
 import Data
+import Dict
 import Expect
 import Message.Callback as Callback
 import Message.Message exposing (DomID(..), Message(..), PipelinesSection(..))
@@ -256,6 +257,7 @@ job : Concourse.Job
 job =
     { name = "job"
     , pipelineName = "pipeline"
+    , pipelineInstanceVars = Dict.empty
     , teamName = "team"
     , nextBuild = Nothing
     , finishedBuild = Nothing
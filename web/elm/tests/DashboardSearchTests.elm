@@ -6,6 +6,7 @@ import Concourse
 import Concourse.BuildStatus exposing (BuildStatus(..))
 import DashboardTests exposing (whenOnDashboard)
 import Data
+import Dict
 import Expect exposing (Expectation)
 import Message.Callback as Callback
 import Message.Message
@@ -44,6 +45,7 @@ hasData =
                     Ok
                         [ { name = "job"
                           , pipelineName = "pipeline1"
+                          , pipelineInstanceVars = Dict.empty
                           , teamName = "team1"
                           , nextBuild =
                                 Just
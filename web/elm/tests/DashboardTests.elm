@@ -700,6 +700,7 @@ all =
                             Ok
                                 [ { name = "job"
                                   , pipelineName = "pipeline"
+                                  , pipelineInstanceVars = Dict.empty
                                   , teamName = "team"
                                   , nextBuild = Nothing
                                   , finishedBuild =
@@ -2241,6 +2242,7 @@ jobWithNameTransitionedAt : String -> Maybe Time.Posix -> BuildStatus -> Concour
 jobWithNameTransitionedAt jobName transitionedAt status =
     { name = jobName
     , pipelineName = "pipeline"
+    , pipelineInstanceVars = Dict.empty
     , teamName = "team"
     , nextBuild = Nothing
     , finishedBuild =
@@ -2282,6 +2284,7 @@ circularJobs : List Concourse.Job
 circularJobs =
     [ { name = "jobA"
       , pipelineName = "pipeline"
+      , pipelineInstanceVars = Dict.empty
       , teamName = "team"
       , nextBuild = Nothing
       , finishedBuild =
@@ -2322,6 +2325,7 @@ circularJobs =
       }
     , { name = "jobB"
       , pipelineName = "pipeline"
+      , pipelineInstanceVars = Dict.empty
       , teamName = "team"
       , nextBuild = Nothing
       , finishedBuild =
@@ -145,10 +145,12 @@ resource : String -> Concourse.Resource
 resource pinnedVersion =
     { teamName = teamName
     , pipelineName = pipelineName
+    , pipelineInstanceVars = Dict.empty
     , name = resourceName
     , failingToCheck = False
     , checkError = ""
     , checkSetupError = ""
+    , checkRateLimitDelay = Nothing
     , lastChecked = Nothing
     , pinnedVersion = Just <| version pinnedVersion
     , pinnedInConfig = False
@@ -161,6 +163,7 @@ pipeline : String -> Int -> Concourse.Pipeline
 pipeline team id =
     { id = id
     , name = "pipeline-" ++ String.fromInt id
+    , instanceVars = Dict.empty
     , paused = False
     , archived = False
     , public = True
@@ -174,6 +177,7 @@ dashboardPipeline : Int -> Bool -> Dashboard.Group.Models.Pipeline
 dashboardPipeline id public =
     { id = id
     , name = pipelineName
+    , instanceVars = Dict.empty
     , teamName = teamName
     , public = public
     , isToggleLoading = False
@@ -219,6 +223,7 @@ job : Int -> Concourse.Job
 job pipelineID =
     { name = jobName
     , pipelineName = "pipeline-" ++ String.fromInt pipelineID
+    , pipelineInstanceVars = Dict.empty
     , teamName = teamName
     , nextBuild = Nothing
     , finishedBuild = Nothing
@@ -312,6 +317,7 @@ pipelineId : Concourse.PipelineIdentifier
 pipelineId =
     { teamName = teamName
     , pipelineName = pipelineName
+    , pipelineInstanceVars = Dict.empty
     }
 
 
@@ -324,6 +330,7 @@ jobId : Concourse.JobIdentifier
 jobId =
     { teamName = teamName
     , pipelineName = pipelineName
+    , pipelineInstanceVars = Dict.empty
     , jobName = jobName
     }
 
@@ -337,6 +344,7 @@ resourceId : Concourse.ResourceIdentifier
 resourceId =
     { teamName = teamName
     , pipelineName = pipelineName
+    , pipelineInstanceVars = Dict.empty
     , resourceName = resourceName
     }
 
@@ -350,6 +358,7 @@ resourceVersionId : Int -> Concourse.VersionedResourceIdentifier
 resourceVersionId v =
     { teamName = teamName
     , pipelineName = pipelineName
+    , pipelineInstanceVars = Dict.empty
     , resourceName = resourceName
     , versionID = v
     }
@@ -369,6 +378,7 @@ longJobBuildId : Concourse.JobBuildIdentifier
 longJobBuildId =
     { teamName = teamName
     , pipelineName = pipelineName
+    , pipelineInstanceVars = Dict.empty
     , jobName = jobName
     , buildName = buildName
     }
@@ -1,7 +1,9 @@
 module DragTests exposing (all)
 
 import Dashboard.Drag as Drag
+import Data
 import Expect
+import Message.Message exposing (DropTarget(..))
 import Test exposing (Test, describe, test)
 
 
@@ -20,4 +22,13 @@ all =
             \_ ->
                 Drag.drag 1 0 [ "a", "b" ]
                     |> Expect.equal [ "b", "a" ]
+        , test "drags the instances of a pipeline together" <|
+            \_ ->
+                [ Data.dashboardPipeline 1 True |> Data.withName "a"
+                , Data.dashboardPipeline 2 True |> Data.withName "a"
+                , Data.dashboardPipeline 3 True |> Data.withName "b"
+                ]
+                    |> Drag.dragPipeline "a" (After "b")
+                    |> List.map .id
+                    |> Expect.equal [ 3, 1, 2 ]
         ]
@@ -70,6 +70,7 @@ all =
                 someJob =
                     { name = "some-job"
                     , pipelineName = "some-pipeline"
+                    , pipelineInstanceVars = Dict.empty
                     , teamName = "some-team"
                     , nextBuild = Nothing
                     , finishedBuild = Just someBuild
@@ -113,6 +114,7 @@ all =
                                 Ok
                                     { name = "job"
                                     , pipelineName = "pipeline"
+                                    , pipelineInstanceVars = Dict.empty
                                     , teamName = "team"
                                     , nextBuild = Nothing
                                     , finishedBuild = Just someBuild
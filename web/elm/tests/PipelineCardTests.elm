@@ -34,6 +34,7 @@ import DashboardTests
         , white
         )
 import Data
+import Dict
 import Expect exposing (Expectation)
 import Html.Attributes as Attr
 import Message.Callback as Callback
@@ -936,10 +937,12 @@ all =
                                     Ok
                                         [ { teamName = "team"
                                           , pipelineName = "pipeline"
+                                          , pipelineInstanceVars = Dict.empty
                                           , name = "resource"
                                           , failingToCheck = True
                                           , checkError = ""
                                           , checkSetupError = ""
+                                          , checkRateLimitDelay = Nothing
                                           , lastChecked = Nothing
                                           , pinnedVersion = Nothing
                                           , pinnedInConfig = False
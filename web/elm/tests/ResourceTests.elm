@@ -1257,10 +1257,12 @@ all =
                                     Ok
                                         { teamName = teamName
                                         , pipelineName = pipelineName
+                                        , pipelineInstanceVars = Dict.empty
                                         , name = resourceName
                                         , failingToCheck = False
                                         , checkError = ""
                                         , checkSetupError = ""
+                                        , checkRateLimitDelay = Nothing
                                         , lastChecked = Nothing
                                         , pinnedVersion = Just (Dict.fromList [ ( "version", version ) ])
                                         , pinnedInConfig = False
@@ -1317,10 +1319,12 @@ all =
                                     Ok
                                         { teamName = teamName
                                         , pipelineName = pipelineName
+                                        , pipelineInstanceVars = Dict.empty
                                         , name = resourceName
                                         , failingToCheck = False
                                         , checkError = ""
                                         , checkSetupError = ""
+                                        , checkRateLimitDelay = Nothing
                                         , lastChecked = Nothing
                                         , pinnedVersion = Just (Dict.fromList [ ( "version", version ) ])
                                         , pinnedInConfig = False
@@ -1379,10 +1383,12 @@ all =
                                     Ok
                                         { teamName = teamName
                                         , pipelineName = pipelineName
+                                        , pipelineInstanceVars = Dict.empty
                                         , name = resourceName
                                         , failingToCheck = False
                                         , checkError = ""
                                         , checkSetupError = ""
+                                        , checkRateLimitDelay = Nothing
                                         , lastChecked = Nothing
                                         , pinnedVersion = Just (Dict.fromList [ ( "version", version ) ])
                                         , pinnedInConfig = False
@@ -1444,10 +1450,12 @@ all =
                                     Ok
                                         { teamName = teamName
                                         , pipelineName = pipelineName
+                                        , pipelineInstanceVars = Dict.empty
                                         , name = resourceName
                                         , failingToCheck = False
                                         , checkError = ""
                                         , checkSetupError = ""
+                                        , checkRateLimitDelay = Nothing
                                         , lastChecked = Nothing
                                         , pinnedVersion = Just (Dict.fromList [ ( "version", version ) ])
                                         , pinnedInConfig = False
@@ -1491,10 +1499,12 @@ all =
                                     Ok
                                         { teamName = teamName
                                         , pipelineName = pipelineName
+                                        , pipelineInstanceVars = Dict.empty
                                         , name = resourceName
                                         , failingToCheck = False
                                         , checkError = ""
                                         , checkSetupError = ""
+                                        , checkRateLimitDelay = Nothing
                                         , lastChecked = Nothing
                                         , pinnedVersion = Just (Dict.fromList [ ( "version", version ) ])
                                         , pinnedInConfig = False
@@ -2704,6 +2714,13 @@ all =
                         |> checkBar UserStateLoggedOut
                         |> Query.children []
                         |> Query.count (Expect.equal 2)
+            , test "shows how long the last check was held back by the check rate limits" <|
+                \_ ->
+                    init
+                        |> givenResourceWasRateLimited
+                        |> queryView
+                        |> Query.find [ class "resource-check-status" ]
+                        |> Query.has [ text "last check was held back for 1m 30s by the check rate limits" ]
             , describe "status bar"
                 [ test "lays out horizontally and spreads its children" <|
                     \_ ->
@@ -3194,10 +3211,12 @@ all =
                                     Ok
                                         { teamName = teamName
                                         , pipelineName = pipelineName
+                                        , pipelineInstanceVars = Dict.empty
                                         , name = resourceName
                                         , failingToCheck = False
                                         , checkError = ""
                                         , checkSetupError = ""
+                                        , checkRateLimitDelay = Nothing
                                         , lastChecked = Just (Time.millisToPosix 0)
                                         , pinnedVersion = Nothing
                                         , pinnedInConfig = False
@@ -3223,10 +3242,12 @@ all =
                                     Ok
                                         { teamName = teamName
                                         , pipelineName = pipelineName
+                                        , pipelineInstanceVars = Dict.empty
                                         , name = resourceName
                                         , failingToCheck = False
                                         , checkError = ""
                                         , checkSetupError = ""
+                                        , checkRateLimitDelay = Nothing
                                         , lastChecked =
                                             Just
                                                 (Time.millisToPosix 0)
@@ -3263,10 +3284,12 @@ all =
                                 Ok
                                     { teamName = teamName
                                     , pipelineName = pipelineName
+                                    , pipelineInstanceVars = Dict.empty
                                     , name = resourceName
                                     , failingToCheck = True
                                     , checkError = "some error"
                                     , checkSetupError = ""
+                                    , checkRateLimitDelay = Nothing
                                     , lastChecked = Nothing
                                     , pinnedVersion = Nothing
                                     , pinnedInConfig = False
@@ -3351,10 +3374,12 @@ givenResourcePinnedStatically =
             Ok
                 { teamName = teamName
                 , pipelineName = pipelineName
+                , pipelineInstanceVars = Dict.empty
                 , name = resourceName
                 , failingToCheck = False
                 , checkError = ""
                 , checkSetupError = ""
+                , checkRateLimitDelay = Nothing
                 , lastChecked = Nothing
                 , pinnedVersion = Just (Dict.fromList [ ( "version", version ) ])
                 , pinnedInConfig = True
@@ -3372,10 +3397,12 @@ givenResourcePinnedDynamically =
             Ok
                 { teamName = teamName
                 , pipelineName = pipelineName
+                , pipelineInstanceVars = Dict.empty
                 , name = resourceName
                 , failingToCheck = False
                 , checkError = ""
                 , checkSetupError = ""
+                , checkRateLimitDelay = Nothing
                 , lastChecked = Nothing
                 , pinnedVersion = Just (Dict.fromList [ ( "version", version ) ])
                 , pinnedInConfig = False
@@ -3393,10 +3420,12 @@ whenResourceLoadsWithPinnedComment =
             Ok
                 { teamName = teamName
                 , pipelineName = pipelineName
+                , pipelineInstanceVars = Dict.empty
                 , name = resourceName
                 , failingToCheck = False
                 , checkError = ""
                 , checkSetupError = ""
+                , checkRateLimitDelay = Nothing
                 , lastChecked = Nothing
                 , pinnedVersion =
                     Just (Dict.fromList [ ( "version", version ) ])
@@ -3419,10 +3448,35 @@ givenResourceIsNotPinned =
             Ok
                 { teamName = teamName
                 , pipelineName = pipelineName
+                , pipelineInstanceVars = Dict.empty
+                , name = resourceName
+                , failingToCheck = False
+                , checkError = ""
+                , checkSetupError = ""
+                , checkRateLimitDelay = Nothing
+                , lastChecked = Just (Time.millisToPosix 0)
+                , pinnedVersion = Nothing
+                , pinnedInConfig = False
+                , pinComment = Nothing
+                , icon = Nothing
+                }
+        )
+        >> Tuple.first
+
+
+givenResourceWasRateLimited : Application.Model -> Application.Model
+givenResourceWasRateLimited =
+    Application.handleCallback
+        (Callback.ResourceFetched <|
+            Ok
+                { teamName = teamName
+                , pipelineName = pipelineName
+                , pipelineInstanceVars = Dict.empty
                 , name = resourceName
                 , failingToCheck = False
                 , checkError = ""
                 , checkSetupError = ""
+                , checkRateLimitDelay = Just 90
                 , lastChecked = Just (Time.millisToPosix 0)
                 , pinnedVersion = Nothing
                 , pinnedInConfig = False
@@ -3440,10 +3494,12 @@ givenResourceHasIcon =
             Ok
                 { teamName = teamName
                 , pipelineName = pipelineName
+                , pipelineInstanceVars = Dict.empty
                 , name = resourceName
                 , failingToCheck = False
                 , checkError = ""
                 , checkSetupError = ""
+                , checkRateLimitDelay = Nothing
                 , lastChecked = Just (Time.millisToPosix 0)
                 , pinnedVersion = Nothing
                 , pinnedInConfig = False
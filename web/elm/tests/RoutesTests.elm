@@ -1,5 +1,6 @@
 module RoutesTests exposing (all)
 
+import Dict
 import Expect
 import Routes
 import Test exposing (Test, describe, test)
@@ -121,4 +122,23 @@ all =
                     |> Url.fromString
                     |> Maybe.andThen Routes.parsePath
                     |> Expect.equal (Just <| Routes.FlySuccess True Nothing)
+        , test "toString keeps the instance vars of a pipeline" <|
+            \_ ->
+                let
+                    route =
+                        Routes.Job
+                            { id =
+                                { teamName = "team"
+                                , pipelineName = "pipeline"
+                                , pipelineInstanceVars =
+                                    Dict.fromList [ ( "branch", "\"feature\"" ) ]
+                                , jobName = "job"
+                                }
+                            , page = Nothing
+                            }
+                in
+                ("http://example.com" ++ Routes.toString route)
+                    |> Url.fromString
+                    |> Maybe.andThen Routes.parsePath
+                    |> Expect.equal (Just route)
         ]
@@ -8,6 +8,7 @@ package runtime
 import (
 	"context"
 	"fmt"
+	"syscall"
 	"time"
 
 	"code.cloudfoundry.org/garden"
@@ -16,6 +17,7 @@ import (
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/cio"
 	"github.com/containerd/containerd/errdefs"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
 )
 
 var _ garden.Backend = (*GardenBackend)(nil)
@@ -31,7 +33,13 @@ type GardenBackend struct {
 
 	maxContainers  int
 	requestTimeout time.Duration
+	diskPath       string
 	createLock     TimeoutWithByPassLock
+
+	unprivilegedOnly bool
+	seccompProfile   *specs.LinuxSeccomp
+	apparmorProfile  string
+	securityProfiles map[string]SecurityProfile
 }
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . UserNamespace
@@ -91,6 +99,51 @@ func WithRequestTimeout(requestTimeout time.Duration) GardenBackendOpt {
 	}
 }
 
+// WithDiskPath configures the path whose filesystem size is reported as the
+// disk capacity of the backend.
+//
+func WithDiskPath(path string) GardenBackendOpt {
+	return func(b *GardenBackend) {
+		b.diskPath = path
+	}
+}
+
+// WithUnprivilegedOnly configures the backend to reject the creation of
+// privileged containers, so that every container runs in a user namespace.
+//
+func WithUnprivilegedOnly(enabled bool) GardenBackendOpt {
+	return func(b *GardenBackend) {
+		b.unprivilegedOnly = enabled
+	}
+}
+
+// WithSeccompProfile configures the seccomp profile applied to unprivileged
+// containers instead of the default one.
+//
+func WithSeccompProfile(profile *specs.LinuxSeccomp) GardenBackendOpt {
+	return func(b *GardenBackend) {
+		b.seccompProfile = profile
+	}
+}
+
+// WithAppArmorProfile configures the name of the AppArmor profile (already
+// loaded on the host) that unprivileged containers are confined by.
+//
+func WithAppArmorProfile(profile string) GardenBackendOpt {
+	return func(b *GardenBackend) {
+		b.apparmorProfile = profile
+	}
+}
+
+// WithSecurityProfiles configures the named security profiles that
+// unprivileged containers can select.
+//
+func WithSecurityProfiles(profiles map[string]SecurityProfile) GardenBackendOpt {
+	return func(b *GardenBackend) {
+		b.securityProfiles = profiles
+	}
+}
+
 // NewGardenBackend instantiates a GardenBackend with tweakable configurations passed as Config.
 //
 func NewGardenBackend(client libcontainerd.Client, opts ...GardenBackendOpt) (b GardenBackend, err error) {
@@ -147,6 +200,11 @@ func (b *GardenBackend) Start() (err error) {
 		return fmt.Errorf("setup restricted networks failed: %w", err)
 	}
 
+	err = b.network.SetupPortForwarding()
+	if err != nil {
+		return fmt.Errorf("setup port forwarding failed: %w", err)
+	}
+
 	return
 }
 
@@ -187,11 +245,21 @@ func (b *GardenBackend) Create(gdnSpec garden.ContainerSpec) (garden.Container,
 		cont,
 		b.killer,
 		b.rootfsManager,
+		b.network,
 	), nil
 }
 
 func (b *GardenBackend) createContainer(ctx context.Context, gdnSpec garden.ContainerSpec) (containerd.Container, error) {
-	err := b.createLock.Acquire(ctx)
+	if gdnSpec.Privileged && b.unprivilegedOnly {
+		return nil, ErrPrivilegedNotAllowed
+	}
+
+	profile, err := b.securityProfile(gdnSpec.Properties[SecurityProfileKey])
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.createLock.Acquire(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("acquiring create container lock: %w", err)
 
@@ -213,6 +281,10 @@ func (b *GardenBackend) createContainer(ctx context.Context, gdnSpec garden.Cont
 		return nil, fmt.Errorf("garden spec to oci spec: %w", err)
 	}
 
+	if !gdnSpec.Privileged {
+		b.confine(oci, profile)
+	}
+
 	netMounts, err := b.network.SetupMounts(gdnSpec.Handle)
 	if err != nil {
 		return nil, fmt.Errorf("network setup mounts: %w", err)
@@ -223,17 +295,54 @@ func (b *GardenBackend) createContainer(ctx context.Context, gdnSpec garden.Cont
 	return b.client.NewContainer(ctx, gdnSpec.Handle, gdnSpec.Properties, oci)
 }
 
+// securityProfile looks up the security profile selected by a container,
+// falling back to the default one (if defined) when none is selected.
+//
+func (b *GardenBackend) securityProfile(name string) (SecurityProfile, error) {
+	if name == "" {
+		return b.securityProfiles[DefaultSecurityProfile], nil
+	}
+
+	profile, found := b.securityProfiles[name]
+	if !found {
+		return SecurityProfile{}, ErrInvalidInput(fmt.Sprintf("unknown security profile '%s'", name))
+	}
+
+	return profile, nil
+}
+
+// confine applies the seccomp and AppArmor profiles configured for the
+// backend, and then the selected security profile, to the spec of an
+// unprivileged container.
+//
+func (b *GardenBackend) confine(oci *specs.Spec, profile SecurityProfile) {
+	if b.seccompProfile != nil {
+		oci.Linux.Seccomp = b.seccompProfile
+	}
+
+	if b.apparmorProfile != "" {
+		oci.Process.ApparmorProfile = b.apparmorProfile
+	}
+
+	profile.apply(oci)
+}
+
 func (b *GardenBackend) startTask(ctx context.Context, cont containerd.Container) error {
 	task, err := cont.NewTask(ctx, cio.NullIO, containerd.WithNoNewKeyring)
 	if err != nil {
 		return fmt.Errorf("new task: %w", err)
 	}
 
-	err = b.network.Add(ctx, task)
+	ip, err := b.network.Add(ctx, task)
 	if err != nil {
 		return fmt.Errorf("network add: %w", err)
 	}
 
+	_, err = cont.SetLabels(ctx, map[string]string{ContainerIPKey: ip})
+	if err != nil {
+		return fmt.Errorf("set container ip label: %w", err)
+	}
+
 	return task.Start(ctx)
 }
 
@@ -309,6 +418,7 @@ func (b *GardenBackend) Containers(properties garden.Properties) (containers []g
 			containerdContainer,
 			b.killer,
 			b.rootfsManager,
+			b.network,
 		)
 	}
 
@@ -331,6 +441,7 @@ func (b *GardenBackend) Lookup(handle string) (garden.Container, error) {
 		containerdContainer,
 		b.killer,
 		b.rootfsManager,
+		b.network,
 	), nil
 }
 
@@ -350,25 +461,91 @@ func (b *GardenBackend) GraceTime(container garden.Container) (duration time.Dur
 	return duration
 }
 
-// Capacity - Not Implemented
+// Capacity returns the amount of memory of the host, the size of the
+// filesystem configured through WithDiskPath and the max number of
+// containers that can be created.
 //
 func (b *GardenBackend) Capacity() (capacity garden.Capacity, err error) {
-	err = ErrNotImplemented
+	var sysinfo syscall.Sysinfo_t
+
+	err = syscall.Sysinfo(&sysinfo)
+	if err != nil {
+		err = fmt.Errorf("sysinfo: %w", err)
+		return
+	}
+
+	capacity.MemoryInBytes = uint64(sysinfo.Totalram) * uint64(sysinfo.Unit)
+	capacity.MaxContainers = uint64(b.maxContainers)
+
+	if b.diskPath != "" {
+		var statfs syscall.Statfs_t
+
+		err = syscall.Statfs(b.diskPath, &statfs)
+		if err != nil {
+			err = fmt.Errorf("statfs %s: %w", b.diskPath, err)
+			return
+		}
+
+		capacity.DiskInBytes = statfs.Blocks * uint64(statfs.Bsize)
+	}
+
 	return
 }
 
-// BulkInfo - Not Implemented
+// BulkInfo returns the info of each of the containers with the specified
+// handles.
 //
-func (b *GardenBackend) BulkInfo(handles []string) (info map[string]garden.ContainerInfoEntry, err error) {
-	err = ErrNotImplemented
-	return
+// Failing to retrieve the info of a container doesn't fail the whole call -
+// the error is reported in the entry of that container instead.
+//
+func (b *GardenBackend) BulkInfo(handles []string) (map[string]garden.ContainerInfoEntry, error) {
+	info := make(map[string]garden.ContainerInfoEntry, len(handles))
+
+	for _, handle := range handles {
+		container, err := b.Lookup(handle)
+		if err != nil {
+			info[handle] = garden.ContainerInfoEntry{Err: garden.NewError(err.Error())}
+			continue
+		}
+
+		containerInfo, err := container.Info()
+		if err != nil {
+			info[handle] = garden.ContainerInfoEntry{Err: garden.NewError(err.Error())}
+			continue
+		}
+
+		info[handle] = garden.ContainerInfoEntry{Info: containerInfo}
+	}
+
+	return info, nil
 }
 
-// BulkMetrics - Not Implemented
+// BulkMetrics returns the metrics of each of the containers with the
+// specified handles.
 //
-func (b *GardenBackend) BulkMetrics(handles []string) (metrics map[string]garden.ContainerMetricsEntry, err error) {
-	err = ErrNotImplemented
-	return
+// Failing to retrieve the metrics of a container doesn't fail the whole call
+// - the error is reported in the entry of that container instead.
+//
+func (b *GardenBackend) BulkMetrics(handles []string) (map[string]garden.ContainerMetricsEntry, error) {
+	metrics := make(map[string]garden.ContainerMetricsEntry, len(handles))
+
+	for _, handle := range handles {
+		container, err := b.Lookup(handle)
+		if err != nil {
+			metrics[handle] = garden.ContainerMetricsEntry{Err: garden.NewError(err.Error())}
+			continue
+		}
+
+		containerMetrics, err := container.Metrics()
+		if err != nil {
+			metrics[handle] = garden.ContainerMetricsEntry{Err: garden.NewError(err.Error())}
+			continue
+		}
+
+		metrics[handle] = garden.ContainerMetricsEntry{Metrics: containerMetrics}
+	}
+
+	return metrics, nil
 }
 
 // checkContainerCapacity ensures that Garden.MaxContainers is respected
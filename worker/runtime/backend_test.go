@@ -3,6 +3,8 @@ package runtime_test
 import (
 	"context"
 	"errors"
+	"io/ioutil"
+	"os"
 	"sync"
 	"testing"
 	"time"
@@ -12,6 +14,7 @@ import (
 	"github.com/concourse/concourse/worker/runtime"
 	"github.com/concourse/concourse/worker/runtime/libcontainerd/libcontainerdfakes"
 	"github.com/concourse/concourse/worker/runtime/runtimefakes"
+	bespec "github.com/concourse/concourse/worker/runtime/spec"
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/errdefs"
 	"github.com/opencontainers/runtime-spec/specs-go"
@@ -132,6 +135,35 @@ func (s *BackendSuite) TestCreateContainerTaskStartFailure() {
 	s.EqualError(errors.Unwrap(err), "start-err")
 }
 
+func (s *BackendSuite) TestCreateContainerRecordsContainerIP() {
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+
+	s.client.NewContainerReturns(fakeContainer, nil)
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	s.network.AddReturns("10.80.0.2", nil)
+
+	_, err := s.backend.Create(minimumValidGdnSpec)
+	s.NoError(err)
+
+	s.Equal(1, fakeContainer.SetLabelsCallCount())
+	_, labels := fakeContainer.SetLabelsArgsForCall(0)
+	s.Equal(map[string]string{runtime.ContainerIPKey: "10.80.0.2"}, labels)
+}
+
+func (s *BackendSuite) TestCreateContainerSetLabelsFailure() {
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+
+	s.client.NewContainerReturns(fakeContainer, nil)
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	fakeContainer.SetLabelsReturns(nil, errors.New("set-labels-err"))
+
+	_, err := s.backend.Create(minimumValidGdnSpec)
+	s.EqualError(errors.Unwrap(errors.Unwrap(err)), "set-labels-err")
+	s.Equal(0, fakeTask.StartCallCount())
+}
+
 func (s *BackendSuite) TestCreateContainerSetsHandle() {
 	fakeTask := new(libcontainerdfakes.FakeTask)
 	fakeContainer := new(libcontainerdfakes.FakeContainer)
@@ -146,6 +178,145 @@ func (s *BackendSuite) TestCreateContainerSetsHandle() {
 	s.Equal("handle", cont.Handle())
 }
 
+func (s *BackendSuite) TestCreatePrivilegedWhenUnprivilegedOnly() {
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithNetwork(s.network),
+		runtime.WithUserNamespace(s.userns),
+		runtime.WithUnprivilegedOnly(true),
+	)
+	s.NoError(err)
+
+	privilegedGdnSpec := minimumValidGdnSpec
+	privilegedGdnSpec.Privileged = true
+
+	_, err = backend.Create(privilegedGdnSpec)
+	s.True(errors.Is(err, runtime.ErrPrivilegedNotAllowed))
+	s.Equal(0, s.client.NewContainerCallCount())
+}
+
+func (s *BackendSuite) TestCreateUnprivilegedWhenUnprivilegedOnly() {
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeContainer.NewTaskReturns(new(libcontainerdfakes.FakeTask), nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithNetwork(s.network),
+		runtime.WithUserNamespace(s.userns),
+		runtime.WithUnprivilegedOnly(true),
+	)
+	s.NoError(err)
+
+	_, err = backend.Create(minimumValidGdnSpec)
+	s.NoError(err)
+	s.Equal(1, s.client.NewContainerCallCount())
+}
+
+func (s *BackendSuite) TestCreateConfinesUnprivilegedContainers() {
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeContainer.NewTaskReturns(new(libcontainerdfakes.FakeTask), nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	seccompProfile := &specs.LinuxSeccomp{DefaultAction: specs.ActKill}
+
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithNetwork(s.network),
+		runtime.WithUserNamespace(s.userns),
+		runtime.WithSeccompProfile(seccompProfile),
+		runtime.WithAppArmorProfile("some-profile"),
+	)
+	s.NoError(err)
+
+	_, err = backend.Create(minimumValidGdnSpec)
+	s.NoError(err)
+
+	_, _, _, oci := s.client.NewContainerArgsForCall(0)
+	s.Equal(seccompProfile, oci.Linux.Seccomp)
+	s.Equal("some-profile", oci.Process.ApparmorProfile)
+
+	privilegedGdnSpec := minimumValidGdnSpec
+	privilegedGdnSpec.Privileged = true
+
+	_, err = backend.Create(privilegedGdnSpec)
+	s.NoError(err)
+
+	_, _, _, oci = s.client.NewContainerArgsForCall(1)
+	s.Nil(oci.Linux.Seccomp)
+	s.Empty(oci.Process.ApparmorProfile)
+}
+
+func (s *BackendSuite) TestCreateWithSecurityProfile() {
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeContainer.NewTaskReturns(new(libcontainerdfakes.FakeTask), nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	seccompProfile := &specs.LinuxSeccomp{DefaultAction: specs.ActKill}
+
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithNetwork(s.network),
+		runtime.WithUserNamespace(s.userns),
+		runtime.WithSecurityProfiles(map[string]runtime.SecurityProfile{
+			"network-tools": {
+				Capabilities: []string{"CAP_NET_ADMIN", "CAP_CHOWN"},
+				Seccomp:      seccompProfile,
+				AppArmor:     "network-tools",
+			},
+		}),
+	)
+	s.NoError(err)
+
+	gdnSpec := minimumValidGdnSpec
+	gdnSpec.Properties = garden.Properties{runtime.SecurityProfileKey: "network-tools"}
+
+	_, err = backend.Create(gdnSpec)
+	s.NoError(err)
+
+	_, _, _, oci := s.client.NewContainerArgsForCall(0)
+	s.Equal(seccompProfile, oci.Linux.Seccomp)
+	s.Equal("network-tools", oci.Process.ApparmorProfile)
+
+	for _, set := range [][]string{
+		oci.Process.Capabilities.Bounding,
+		oci.Process.Capabilities.Effective,
+		oci.Process.Capabilities.Inheritable,
+		oci.Process.Capabilities.Permitted,
+	} {
+		s.Contains(set, "CAP_NET_ADMIN")
+		s.Len(set, len(bespec.UnprivilegedContainerCapabilities.Bounding)+1)
+	}
+
+	s.NotContains(bespec.UnprivilegedContainerCapabilities.Bounding, "CAP_NET_ADMIN")
+}
+
+func (s *BackendSuite) TestCreateWithDefaultSecurityProfile() {
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeContainer.NewTaskReturns(new(libcontainerdfakes.FakeTask), nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithNetwork(s.network),
+		runtime.WithUserNamespace(s.userns),
+		runtime.WithSecurityProfiles(map[string]runtime.SecurityProfile{
+			runtime.DefaultSecurityProfile: {AppArmor: "some-default"},
+		}),
+	)
+	s.NoError(err)
+
+	_, err = backend.Create(minimumValidGdnSpec)
+	s.NoError(err)
+
+	_, _, _, oci := s.client.NewContainerArgsForCall(0)
+	s.Equal("some-default", oci.Process.ApparmorProfile)
+}
+
+func (s *BackendSuite) TestCreateWithUnknownSecurityProfile() {
+	gdnSpec := minimumValidGdnSpec
+	gdnSpec.Properties = garden.Properties{runtime.SecurityProfileKey: "network-tools"}
+
+	_, err := s.backend.Create(gdnSpec)
+	s.EqualError(errors.Unwrap(err), "unknown security profile 'network-tools'")
+	s.Equal(0, s.client.NewContainerCallCount())
+}
+
 func (s *BackendSuite) TestCreateMaxContainersReached() {
 	backend, err := runtime.NewGardenBackend(s.client,
 		runtime.WithKiller(s.killer),
@@ -489,6 +660,13 @@ func (s *BackendSuite) TestStartInitsClientAndSetsUpRestrictedNetworks() {
 	s.NoError(err)
 	s.Equal(1, s.client.InitCallCount())
 	s.Equal(1, s.network.SetupRestrictedNetworksCallCount())
+	s.Equal(1, s.network.SetupPortForwardingCallCount())
+}
+
+func (s *BackendSuite) TestStartSetupPortForwardingError() {
+	s.network.SetupPortForwardingReturns(errors.New("port-forwarding-err"))
+	err := s.backend.Start()
+	s.EqualError(errors.Unwrap(err), "port-forwarding-err")
 }
 
 func (s *BackendSuite) TestStartInitError() {
@@ -522,3 +700,97 @@ func (s *BackendSuite) TestGraceTimeReturnsDuration() {
 	result := s.backend.GraceTime(fakeContainer)
 	s.Equal(time.Duration(123), result)
 }
+
+func (s *BackendSuite) TestCapacity() {
+	diskPath, err := ioutil.TempDir("", "backend-capacity")
+	s.NoError(err)
+	defer os.RemoveAll(diskPath)
+
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithNetwork(s.network),
+		runtime.WithMaxContainers(10),
+		runtime.WithDiskPath(diskPath),
+	)
+	s.NoError(err)
+
+	capacity, err := backend.Capacity()
+	s.NoError(err)
+
+	s.Equal(uint64(10), capacity.MaxContainers)
+	s.NotZero(capacity.MemoryInBytes)
+	s.NotZero(capacity.DiskInBytes)
+}
+
+func (s *BackendSuite) TestCapacityWithoutDiskPath() {
+	capacity, err := s.backend.Capacity()
+	s.NoError(err)
+
+	s.NotZero(capacity.MemoryInBytes)
+	s.Zero(capacity.DiskInBytes)
+}
+
+func (s *BackendSuite) TestCapacityInvalidDiskPath() {
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithNetwork(s.network),
+		runtime.WithDiskPath("/non-existent"),
+	)
+	s.NoError(err)
+
+	_, err = backend.Capacity()
+	s.Error(err)
+}
+
+func (s *BackendSuite) TestBulkInfoReportsErrorsPerContainer() {
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeTask.StatusReturns(containerd.Status{Status: containerd.Stopped}, nil)
+
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeContainer.TaskReturns(fakeTask, nil)
+	fakeContainer.SpecReturns(&specs.Spec{}, nil)
+	fakeContainer.LabelsReturns(map[string]string{"foo": "bar"}, nil)
+
+	s.client.GetContainerStub = func(_ context.Context, handle string) (containerd.Container, error) {
+		if handle == "missing" {
+			return nil, errors.New("not found")
+		}
+
+		return fakeContainer, nil
+	}
+
+	info, err := s.backend.BulkInfo([]string{"handle", "missing"})
+	s.NoError(err)
+	s.Len(info, 2)
+
+	s.Nil(info["handle"].Err)
+	s.Equal("stopped", info["handle"].Info.State)
+	s.Equal(garden.Properties{"foo": "bar"}, info["handle"].Info.Properties)
+
+	s.NotNil(info["missing"].Err)
+	s.Contains(info["missing"].Err.Error(), "not found")
+}
+
+func (s *BackendSuite) TestBulkMetricsReportsErrorsPerContainer() {
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeTask.MetricsReturns(nil, errors.New("metrics-err"))
+
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeContainer.TaskReturns(fakeTask, nil)
+
+	s.client.GetContainerStub = func(_ context.Context, handle string) (containerd.Container, error) {
+		if handle == "missing" {
+			return nil, errors.New("not found")
+		}
+
+		return fakeContainer, nil
+	}
+
+	metrics, err := s.backend.BulkMetrics([]string{"handle", "missing"})
+	s.NoError(err)
+	s.Len(metrics, 2)
+
+	s.NotNil(metrics["handle"].Err)
+	s.Contains(metrics["handle"].Err.Error(), "metrics-err")
+
+	s.NotNil(metrics["missing"].Err)
+	s.Contains(metrics["missing"].Err.Error(), "not found")
+}
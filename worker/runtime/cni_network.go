@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"sync"
 
+	"code.cloudfoundry.org/garden"
 	"github.com/concourse/concourse/worker/runtime/iptables"
 	"github.com/containerd/containerd"
 	"github.com/containerd/go-cni"
@@ -45,6 +47,11 @@ const (
 	binariesDir = "/usr/local/concourse/bin"
 
 	ipTablesAdminChainName = "CONCOURSE-OPERATOR"
+
+	// ipTablesNetInChainName is the chain in the `nat` table where the rules
+	// forwarding ports of the host to containers live.
+	//
+	ipTablesNetInChainName = "CONCOURSE-NETIN"
 )
 
 var (
@@ -166,6 +173,11 @@ type cniNetwork struct {
 	binariesDir        string
 	restrictedNetworks []string
 	ipt                iptables.Iptables
+
+	// netInLock serializes port forwardings, so that the same port of the
+	// host can't be forwarded to two containers at once.
+	//
+	netInLock *sync.Mutex
 }
 
 var _ Network = (*cniNetwork)(nil)
@@ -176,6 +188,7 @@ func NewCNINetwork(opts ...CNINetworkOpt) (*cniNetwork, error) {
 	n := &cniNetwork{
 		binariesDir: binariesDir,
 		config:      defaultCNINetworkConfig,
+		netInLock:   &sync.Mutex{},
 	}
 
 	for _, opt := range opts {
@@ -276,6 +289,25 @@ func (n cniNetwork) SetupRestrictedNetworks() error {
 	return nil
 }
 
+func (n cniNetwork) SetupPortForwarding() error {
+	const tableName = "nat"
+	err := n.ipt.CreateChainOrFlushIfExists(tableName, ipTablesNetInChainName)
+	if err != nil {
+		return fmt.Errorf("create chain or flush if exists failed: %w", err)
+	}
+
+	// Traffic addressed to the host either comes from the outside or is
+	// generated locally
+	for _, chain := range []string{"PREROUTING", "OUTPUT"} {
+		err = n.ipt.AppendUniqueRule(tableName, chain, "-m", "addrtype", "--dst-type", "LOCAL", "-j", ipTablesNetInChainName)
+		if err != nil {
+			return fmt.Errorf("appending jump to %s from %s failed: %w", ipTablesNetInChainName, chain, err)
+		}
+	}
+
+	return nil
+}
+
 func (n cniNetwork) generateResolvConfContents() ([]byte, error) {
 	contents := ""
 	resolvConfEntries := n.nameServers
@@ -290,19 +322,19 @@ func (n cniNetwork) generateResolvConfContents() ([]byte, error) {
 	return []byte(contents), err
 }
 
-func (n cniNetwork) Add(ctx context.Context, task containerd.Task) error {
+func (n cniNetwork) Add(ctx context.Context, task containerd.Task) (string, error) {
 	if task == nil {
-		return ErrInvalidInput("nil task")
+		return "", ErrInvalidInput("nil task")
 	}
 
 	id, netns := netId(task), netNsPath(task)
 
-	_, err := n.client.Setup(ctx, id, netns)
+	result, err := n.client.Setup(ctx, id, netns)
 	if err != nil {
-		return fmt.Errorf("cni net setup: %w", err)
+		return "", fmt.Errorf("cni net setup: %w", err)
 	}
 
-	return nil
+	return containerIP(result), nil
 }
 
 func (n cniNetwork) Remove(ctx context.Context, task containerd.Task) error {
@@ -317,6 +349,102 @@ func (n cniNetwork) Remove(ctx context.Context, task containerd.Task) error {
 		return fmt.Errorf("cni net teardown: %w", err)
 	}
 
+	err = n.removeRules(id)
+	if err != nil {
+		return fmt.Errorf("remove rules: %w", err)
+	}
+
+	return nil
+}
+
+func (n cniNetwork) NetIn(handle, containerIP string, hostPort, containerPort uint32) error {
+	if containerIP == "" {
+		return ErrInvalidInput("empty container ip")
+	}
+
+	n.netInLock.Lock()
+	defer n.netInLock.Unlock()
+
+	rules, err := n.ipt.ListRules("nat", ipTablesNetInChainName)
+	if err != nil {
+		return fmt.Errorf("listing rules of %s failed: %w", ipTablesNetInChainName, err)
+	}
+
+	for _, rule := range rules {
+		if forwardsHostPort(rule, hostPort) {
+			return ErrHostPortInUse
+		}
+	}
+
+	err = n.ipt.AppendRule("nat", ipTablesNetInChainName,
+		netInRuleSpec(handle, containerIP, hostPort, containerPort)...,
+	)
+	if err != nil {
+		return fmt.Errorf("appending port forwarding rule failed: %w", err)
+	}
+
+	// The forwarded traffic must not be caught by the rules rejecting
+	// traffic to restricted networks
+	err = n.ipt.InsertRule("filter", ipTablesAdminChainName, 1,
+		netInAcceptRuleSpec(handle, containerIP, containerPort)...,
+	)
+	if err != nil {
+		return fmt.Errorf("inserting accept rule for forwarded port failed: %w", err)
+	}
+
+	return nil
+}
+
+func (n cniNetwork) NetOut(handle, containerIP string, rule garden.NetOutRule) error {
+	if containerIP == "" {
+		return ErrInvalidInput("empty container ip")
+	}
+
+	ruleSpecs, err := netOutRuleSpecs(handle, containerIP, rule)
+	if err != nil {
+		return err
+	}
+
+	// Inserted at the top so that they take precedence over the rules
+	// rejecting traffic to restricted networks
+	for _, ruleSpec := range ruleSpecs {
+		err = n.ipt.InsertRule("filter", ipTablesAdminChainName, 1, ruleSpec...)
+		if err != nil {
+			return fmt.Errorf("inserting net out rule failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// removeRules deletes all of the rules that were set up for the container
+// with the given handle.
+//
+func (n cniNetwork) removeRules(handle string) error {
+	chains := []struct{ table, chain string }{
+		{"nat", ipTablesNetInChainName},
+		{"filter", ipTablesAdminChainName},
+	}
+
+	for _, c := range chains {
+		rules, err := n.ipt.ListRules(c.table, c.chain)
+		if err != nil {
+			return fmt.Errorf("listing rules of %s failed: %w", c.chain, err)
+		}
+
+		for _, rule := range rules {
+			ruleSpec, ok := containerRuleSpec(rule, c.chain, handle)
+			if !ok {
+				continue
+			}
+
+			err = n.ipt.DeleteRule(c.table, c.chain, ruleSpec...)
+			if err != nil {
+				return fmt.Errorf("deleting rule from %s failed: %w", c.chain, err)
+			}
+		}
+	}
+
 	return nil
 }
 
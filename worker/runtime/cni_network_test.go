@@ -3,12 +3,15 @@ package runtime_test
 import (
 	"context"
 	"errors"
+	"net"
 	"strings"
 
+	"code.cloudfoundry.org/garden"
 	"github.com/concourse/concourse/worker/runtime"
 	"github.com/concourse/concourse/worker/runtime/iptables/iptablesfakes"
 	"github.com/concourse/concourse/worker/runtime/libcontainerd/libcontainerdfakes"
 	"github.com/concourse/concourse/worker/runtime/runtimefakes"
+	"github.com/containerd/go-cni"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -18,9 +21,10 @@ type CNINetworkSuite struct {
 	suite.Suite
 	*require.Assertions
 
-	network runtime.Network
-	cni     *runtimefakes.FakeCNI
-	store   *runtimefakes.FakeFileStore
+	network  runtime.Network
+	cni      *runtimefakes.FakeCNI
+	store    *runtimefakes.FakeFileStore
+	iptables *iptablesfakes.FakeIptables
 }
 
 func (s *CNINetworkSuite) SetupTest() {
@@ -28,11 +32,12 @@ func (s *CNINetworkSuite) SetupTest() {
 
 	s.store = new(runtimefakes.FakeFileStore)
 	s.cni = new(runtimefakes.FakeCNI)
+	s.iptables = new(iptablesfakes.FakeIptables)
 
 	s.network, err = runtime.NewCNINetwork(
 		runtime.WithCNIFileStore(s.store),
 		runtime.WithCNIClient(s.cni),
-		runtime.WithIptables(new(iptablesfakes.FakeIptables)),
+		runtime.WithIptables(s.iptables),
 	)
 	s.NoError(err)
 }
@@ -164,8 +169,37 @@ func (s *CNINetworkSuite) TestSetupRestrictedNetworksCreatesEmptyAdminChain() {
 	s.Equal(rulespec, []string{"-d", "8.8.8.8", "-j", "REJECT"})
 }
 
+func (s *CNINetworkSuite) TestSetupPortForwardingCreatesNetInChain() {
+	err := s.network.SetupPortForwarding()
+	s.NoError(err)
+
+	tablename, chainName := s.iptables.CreateChainOrFlushIfExistsArgsForCall(0)
+	s.Equal("nat", tablename)
+	s.Equal("CONCOURSE-NETIN", chainName)
+
+	s.Equal(2, s.iptables.AppendUniqueRuleCallCount())
+
+	tablename, chainName, rulespec := s.iptables.AppendUniqueRuleArgsForCall(0)
+	s.Equal("nat", tablename)
+	s.Equal("PREROUTING", chainName)
+	s.Equal([]string{"-m", "addrtype", "--dst-type", "LOCAL", "-j", "CONCOURSE-NETIN"}, rulespec)
+
+	tablename, chainName, rulespec = s.iptables.AppendUniqueRuleArgsForCall(1)
+	s.Equal("nat", tablename)
+	s.Equal("OUTPUT", chainName)
+	s.Equal([]string{"-m", "addrtype", "--dst-type", "LOCAL", "-j", "CONCOURSE-NETIN"}, rulespec)
+}
+
+func (s *CNINetworkSuite) TestSetupPortForwardingFailsToCreateChain() {
+	s.iptables.CreateChainOrFlushIfExistsReturns(errors.New("chain-err"))
+
+	err := s.network.SetupPortForwarding()
+	s.EqualError(errors.Unwrap(err), "chain-err")
+	s.Equal(0, s.iptables.AppendUniqueRuleCallCount())
+}
+
 func (s *CNINetworkSuite) TestAddNilTask() {
-	err := s.network.Add(context.Background(), nil)
+	_, err := s.network.Add(context.Background(), nil)
 	s.EqualError(err, "nil task")
 }
 
@@ -173,7 +207,7 @@ func (s *CNINetworkSuite) TestAddSetupErrors() {
 	s.cni.SetupReturns(nil, errors.New("setup-err"))
 	task := new(libcontainerdfakes.FakeTask)
 
-	err := s.network.Add(context.Background(), task)
+	_, err := s.network.Add(context.Background(), task)
 	s.EqualError(errors.Unwrap(err), "setup-err")
 }
 
@@ -182,8 +216,25 @@ func (s *CNINetworkSuite) TestAdd() {
 	task.PidReturns(123)
 	task.IDReturns("id")
 
-	err := s.network.Add(context.Background(), task)
+	s.cni.SetupReturns(&cni.CNIResult{
+		Interfaces: map[string]*cni.Config{
+			"lo": {
+				IPConfigs: []*cni.IPConfig{{IP: net.ParseIP("127.0.0.1")}},
+				Sandbox:   "/proc/123/ns/net",
+			},
+			"concourse0": {
+				IPConfigs: []*cni.IPConfig{{IP: net.ParseIP("10.80.0.1")}},
+			},
+			"eth0": {
+				IPConfigs: []*cni.IPConfig{{IP: net.ParseIP("10.80.0.2")}},
+				Sandbox:   "/proc/123/ns/net",
+			},
+		},
+	}, nil)
+
+	ip, err := s.network.Add(context.Background(), task)
 	s.NoError(err)
+	s.Equal("10.80.0.2", ip)
 
 	s.Equal(1, s.cni.SetupCallCount())
 	_, id, netns, _ := s.cni.SetupArgsForCall(0)
@@ -217,3 +268,198 @@ func (s *CNINetworkSuite) TestRemove() {
 	s.Equal("id", id)
 	s.Equal("/proc/123/ns/net", netns)
 }
+
+func (s *CNINetworkSuite) TestRemoveDeletesTheRulesOfTheContainer() {
+	task := new(libcontainerdfakes.FakeTask)
+	task.IDReturns("id")
+
+	s.iptables.ListRulesStub = func(table, chain string) ([]string, error) {
+		switch chain {
+		case "CONCOURSE-NETIN":
+			return []string{
+				"-N CONCOURSE-NETIN",
+				"-A CONCOURSE-NETIN -p tcp -m tcp --dport 8080 -m comment --comment id -j DNAT --to-destination 10.80.0.2:80",
+				"-A CONCOURSE-NETIN -p tcp -m tcp --dport 8081 -m comment --comment other-id -j DNAT --to-destination 10.80.0.3:80",
+			}, nil
+		case "CONCOURSE-OPERATOR":
+			return []string{
+				"-N CONCOURSE-OPERATOR",
+				"-A CONCOURSE-OPERATOR -s 10.80.0.2/32 -d 1.1.1.1/32 -m comment --comment id -j ACCEPT",
+				"-A CONCOURSE-OPERATOR -m conntrack --ctstate RELATED,ESTABLISHED -j ACCEPT",
+				"-A CONCOURSE-OPERATOR -d 1.1.1.1/32 -j REJECT --reject-with icmp-port-unreachable",
+			}, nil
+		}
+
+		return nil, nil
+	}
+
+	err := s.network.Remove(context.Background(), task)
+	s.NoError(err)
+
+	s.Equal(2, s.iptables.DeleteRuleCallCount())
+
+	table, chain, rulespec := s.iptables.DeleteRuleArgsForCall(0)
+	s.Equal("nat", table)
+	s.Equal("CONCOURSE-NETIN", chain)
+	s.Equal(strings.Fields("-p tcp -m tcp --dport 8080 -m comment --comment id -j DNAT --to-destination 10.80.0.2:80"), rulespec)
+
+	table, chain, rulespec = s.iptables.DeleteRuleArgsForCall(1)
+	s.Equal("filter", table)
+	s.Equal("CONCOURSE-OPERATOR", chain)
+	s.Equal(strings.Fields("-s 10.80.0.2/32 -d 1.1.1.1/32 -m comment --comment id -j ACCEPT"), rulespec)
+}
+
+func (s *CNINetworkSuite) TestRemoveFailsToListRules() {
+	task := new(libcontainerdfakes.FakeTask)
+	s.iptables.ListRulesReturns(nil, errors.New("list-err"))
+
+	err := s.network.Remove(context.Background(), task)
+	s.EqualError(errors.Unwrap(errors.Unwrap(err)), "list-err")
+}
+
+func (s *CNINetworkSuite) TestNetInEmptyContainerIP() {
+	err := s.network.NetIn("handle", "", 8080, 80)
+	s.EqualError(err, "empty container ip")
+}
+
+func (s *CNINetworkSuite) TestNetIn() {
+	err := s.network.NetIn("handle", "10.80.0.2", 8080, 80)
+	s.NoError(err)
+
+	s.Equal(1, s.iptables.AppendRuleCallCount())
+	table, chain, rulespec := s.iptables.AppendRuleArgsForCall(0)
+	s.Equal("nat", table)
+	s.Equal("CONCOURSE-NETIN", chain)
+	s.Equal([]string{
+		"-p", "tcp",
+		"--dport", "8080",
+		"-m", "comment", "--comment", "handle",
+		"-j", "DNAT",
+		"--to-destination", "10.80.0.2:80",
+	}, rulespec)
+
+	s.Equal(1, s.iptables.InsertRuleCallCount())
+	table, chain, pos, rulespec := s.iptables.InsertRuleArgsForCall(0)
+	s.Equal("filter", table)
+	s.Equal("CONCOURSE-OPERATOR", chain)
+	s.Equal(1, pos)
+	s.Equal([]string{
+		"-d", "10.80.0.2",
+		"-p", "tcp",
+		"--dport", "80",
+		"-m", "comment", "--comment", "handle",
+		"-j", "ACCEPT",
+	}, rulespec)
+}
+
+func (s *CNINetworkSuite) TestNetInHostPortInUse() {
+	s.iptables.ListRulesReturns([]string{
+		"-N CONCOURSE-NETIN",
+		"-A CONCOURSE-NETIN -p tcp -m tcp --dport 8080 -m comment --comment other-handle -j DNAT --to-destination 10.80.0.3:80",
+	}, nil)
+
+	err := s.network.NetIn("handle", "10.80.0.2", 8080, 80)
+	s.True(errors.Is(err, runtime.ErrHostPortInUse))
+	s.Equal(0, s.iptables.AppendRuleCallCount())
+}
+
+func (s *CNINetworkSuite) TestNetInFailsToListRules() {
+	s.iptables.ListRulesReturns(nil, errors.New("list-err"))
+
+	err := s.network.NetIn("handle", "10.80.0.2", 8080, 80)
+	s.EqualError(errors.Unwrap(err), "list-err")
+	s.Equal(0, s.iptables.AppendRuleCallCount())
+}
+
+func (s *CNINetworkSuite) TestNetInFailsToAppendRule() {
+	s.iptables.AppendRuleReturns(errors.New("append-err"))
+
+	err := s.network.NetIn("handle", "10.80.0.2", 8080, 80)
+	s.EqualError(errors.Unwrap(err), "append-err")
+	s.Equal(0, s.iptables.InsertRuleCallCount())
+}
+
+func (s *CNINetworkSuite) TestNetOutEmptyContainerIP() {
+	err := s.network.NetOut("handle", "", garden.NetOutRule{})
+	s.EqualError(err, "empty container ip")
+}
+
+func (s *CNINetworkSuite) TestNetOutAllProtocols() {
+	err := s.network.NetOut("handle", "10.80.0.2", garden.NetOutRule{
+		Networks: []garden.IPRange{garden.IPRangeFromIP(net.ParseIP("1.1.1.1"))},
+	})
+	s.NoError(err)
+
+	s.Equal(1, s.iptables.InsertRuleCallCount())
+	table, chain, pos, rulespec := s.iptables.InsertRuleArgsForCall(0)
+	s.Equal("filter", table)
+	s.Equal("CONCOURSE-OPERATOR", chain)
+	s.Equal(1, pos)
+	s.Equal([]string{
+		"-s", "10.80.0.2",
+		"-d", "1.1.1.1",
+		"-m", "comment", "--comment", "handle",
+		"-j", "ACCEPT",
+	}, rulespec)
+}
+
+func (s *CNINetworkSuite) TestNetOutNetworksAndPorts() {
+	err := s.network.NetOut("handle", "10.80.0.2", garden.NetOutRule{
+		Protocol: garden.ProtocolTCP,
+		Networks: []garden.IPRange{
+			garden.IPRangeFromIP(net.ParseIP("1.1.1.1")),
+			{Start: net.ParseIP("10.0.0.1"), End: net.ParseIP("10.0.0.255")},
+		},
+		Ports: []garden.PortRange{
+			garden.PortRangeFromPort(443),
+			{Start: 8000, End: 9000},
+		},
+		Log: true,
+	})
+	s.NoError(err)
+
+	s.Equal(8, s.iptables.InsertRuleCallCount())
+
+	var rules []string
+	for i := 0; i < s.iptables.InsertRuleCallCount(); i++ {
+		_, _, _, rulespec := s.iptables.InsertRuleArgsForCall(i)
+		rules = append(rules, strings.Join(rulespec, " "))
+	}
+
+	s.Equal([]string{
+		"-s 10.80.0.2 -p tcp -d 1.1.1.1 --dport 443 -m comment --comment handle -j ACCEPT",
+		"-s 10.80.0.2 -p tcp -d 1.1.1.1 --dport 443 -m comment --comment handle -j LOG --log-prefix handle ",
+		"-s 10.80.0.2 -p tcp -d 1.1.1.1 --dport 8000:9000 -m comment --comment handle -j ACCEPT",
+		"-s 10.80.0.2 -p tcp -d 1.1.1.1 --dport 8000:9000 -m comment --comment handle -j LOG --log-prefix handle ",
+		"-s 10.80.0.2 -p tcp -m iprange --dst-range 10.0.0.1-10.0.0.255 --dport 443 -m comment --comment handle -j ACCEPT",
+		"-s 10.80.0.2 -p tcp -m iprange --dst-range 10.0.0.1-10.0.0.255 --dport 443 -m comment --comment handle -j LOG --log-prefix handle ",
+		"-s 10.80.0.2 -p tcp -m iprange --dst-range 10.0.0.1-10.0.0.255 --dport 8000:9000 -m comment --comment handle -j ACCEPT",
+		"-s 10.80.0.2 -p tcp -m iprange --dst-range 10.0.0.1-10.0.0.255 --dport 8000:9000 -m comment --comment handle -j LOG --log-prefix handle ",
+	}, rules)
+}
+
+func (s *CNINetworkSuite) TestNetOutICMP() {
+	code := garden.ICMPCode(1)
+
+	err := s.network.NetOut("handle", "10.80.0.2", garden.NetOutRule{
+		Protocol: garden.ProtocolICMP,
+		ICMPs:    &garden.ICMPControl{Type: 3, Code: &code},
+	})
+	s.NoError(err)
+
+	_, _, _, rulespec := s.iptables.InsertRuleArgsForCall(0)
+	s.Equal([]string{
+		"-s", "10.80.0.2",
+		"-p", "icmp", "--icmp-type", "3/1",
+		"-m", "comment", "--comment", "handle",
+		"-j", "ACCEPT",
+	}, rulespec)
+}
+
+func (s *CNINetworkSuite) TestNetOutPortsWithAllProtocols() {
+	err := s.network.NetOut("handle", "10.80.0.2", garden.NetOutRule{
+		Ports: []garden.PortRange{garden.PortRangeFromPort(443)},
+	})
+	s.EqualError(err, "ports cannot be specified for protocol all")
+	s.Equal(0, s.iptables.InsertRuleCallCount())
+}
@@ -2,8 +2,14 @@ package runtime
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/garden"
@@ -11,9 +17,15 @@ import (
 	"github.com/containerd/containerd/cio"
 	uuid "github.com/nu7hatch/gouuid"
 	"github.com/opencontainers/runtime-spec/specs-go"
+	"golang.org/x/sys/unix"
 )
 
-const GraceTimeKey = "garden.grace-time"
+const (
+	GraceTimeKey   = "garden.grace-time"
+	MemoryPeakKey  = "garden.memory-peak"
+	ContainerIPKey = "garden.network.container-ip"
+	MappedPortsKey = "garden.network.mapped-ports"
+)
 
 type UserNotFoundError struct {
 	User string
@@ -27,17 +39,20 @@ type Container struct {
 	container     containerd.Container
 	killer        Killer
 	rootfsManager RootfsManager
+	network       Network
 }
 
 func NewContainer(
 	container containerd.Container,
 	killer Killer,
 	rootfsManager RootfsManager,
+	network Network,
 ) *Container {
 	return &Container{
 		container:     container,
 		killer:        killer,
 		rootfsManager: rootfsManager,
+		network:       network,
 	}
 }
 
@@ -218,28 +233,236 @@ func (c *Container) RemoveProperty(name string) (err error) {
 	return
 }
 
-// Info - Not Implemented
-func (c *Container) Info() (info garden.ContainerInfo, err error) {
-	err = ErrNotImplemented
-	return
+// Info returns the state of the container, the IDs of the processes running
+// in it, its properties and the path to its rootfs.
+//
+// While the task is running, its peak memory usage is reported through the
+// "garden.memory-peak" property, as garden.Metrics has no room for it.
+//
+func (c *Container) Info() (garden.ContainerInfo, error) {
+	ctx := context.Background()
+
+	task, err := c.container.Task(ctx, cio.Load)
+	if err != nil {
+		return garden.ContainerInfo{}, fmt.Errorf("task lookup: %w", err)
+	}
+
+	status, err := task.Status(ctx)
+	if err != nil {
+		return garden.ContainerInfo{}, fmt.Errorf("task status: %w", err)
+	}
+
+	processIDs, err := execIDs(ctx, task)
+	if err != nil {
+		return garden.ContainerInfo{}, err
+	}
+
+	spec, err := c.container.Spec(ctx)
+	if err != nil {
+		return garden.ContainerInfo{}, fmt.Errorf("container spec: %w", err)
+	}
+
+	properties, err := c.Properties()
+	if err != nil {
+		return garden.ContainerInfo{}, err
+	}
+
+	state := "stopped"
+	if status.Status == containerd.Running {
+		state = "active"
+
+		stats, err := taskCgroupMetrics(ctx, task)
+		if err != nil {
+			return garden.ContainerInfo{}, err
+		}
+
+		properties[MemoryPeakKey] = strconv.FormatUint(memoryPeak(stats), 10)
+	}
+
+	mappedPorts, err := parseMappedPorts(properties[MappedPortsKey])
+	if err != nil {
+		return garden.ContainerInfo{}, err
+	}
+
+	info := garden.ContainerInfo{
+		State:       state,
+		Events:      []string{},
+		ContainerIP: properties[ContainerIPKey],
+		ProcessIDs:  processIDs,
+		Properties:  properties,
+		MappedPorts: mappedPorts,
+	}
+
+	if spec != nil && spec.Root != nil {
+		info.ContainerPath = spec.Root.Path
+	}
+
+	return info, nil
 }
 
-// Metrics - Not Implemented
-func (c *Container) Metrics() (metrics garden.Metrics, err error) {
-	err = ErrNotImplemented
-	return
+// Metrics retrieves the cpu, memory and pids usage of the container from the
+// cgroup stats of its task, the network counters from the task's network
+// namespace and the disk usage of its rootfs.
+//
+// The disk usage is only recomputed once it's older than `diskStatTTL`.
+//
+func (c *Container) Metrics() (garden.Metrics, error) {
+	ctx := context.Background()
+
+	task, err := c.container.Task(ctx, cio.Load)
+	if err != nil {
+		return garden.Metrics{}, fmt.Errorf("task lookup: %w", err)
+	}
+
+	stats, err := taskCgroupMetrics(ctx, task)
+	if err != nil {
+		return garden.Metrics{}, err
+	}
+
+	network, err := networkStat(stats, task.Pid())
+	if err != nil {
+		return garden.Metrics{}, fmt.Errorf("network stat: %w", err)
+	}
+
+	spec, err := c.container.Spec(ctx)
+	if err != nil {
+		return garden.Metrics{}, fmt.Errorf("container spec: %w", err)
+	}
+
+	var disk garden.ContainerDiskStat
+	if spec != nil && spec.Root != nil && spec.Root.Path != "" {
+		disk, err = rootfsDiskStats.get(spec.Root.Path)
+		if err != nil {
+			return garden.Metrics{}, fmt.Errorf("disk stat: %w", err)
+		}
+	}
+
+	info, err := c.container.Info(ctx)
+	if err != nil {
+		return garden.Metrics{}, fmt.Errorf("container info: %w", err)
+	}
+
+	return garden.Metrics{
+		MemoryStat:  memoryStat(stats),
+		CPUStat:     cpuStat(stats),
+		DiskStat:    disk,
+		NetworkStat: network,
+		PidStat:     pidStat(stats),
+		Age:         time.Since(info.CreatedAt),
+	}, nil
 }
 
-// StreamIn - Not Implemented
-func (c *Container) StreamIn(spec garden.StreamInSpec) (err error) {
-	err = ErrNotImplemented
-	return
+// StreamIn extracts a tar stream into a directory of the container, which
+// gets created if it doesn't exist yet.
+//
+// The entries are owned by the user specified in the spec (root by default),
+// as mapped to the host by the user namespace of the container.
+//
+func (c *Container) StreamIn(spec garden.StreamInSpec) error {
+	containerSpec, err := c.container.Spec(context.Background())
+	if err != nil {
+		return fmt.Errorf("container spec: %w", err)
+	}
+
+	root, path, err := hostPath(containerSpec, spec.Path)
+	if err != nil {
+		return fmt.Errorf("resolve path: %w", err)
+	}
+
+	uid, gid, err := c.streamInOwner(containerSpec, spec.User)
+	if err != nil {
+		return err
+	}
+
+	rootDir, err := openRoot(root)
+	if err != nil {
+		return fmt.Errorf("open root: %w", err)
+	}
+
+	defer rootDir.Close()
+
+	dest, err := mkdirAllIn(rootDir, path)
+	if err != nil {
+		return fmt.Errorf("mkdirall: %w", err)
+	}
+
+	defer dest.Close()
+
+	err = extractTar(spec.TarStream, dest, uid, gid)
+	if err != nil {
+		return fmt.Errorf("extract: %w", err)
+	}
+
+	return nil
 }
 
-// StreamOut - Not Implemented
-func (c *Container) StreamOut(spec garden.StreamOutSpec) (readCloser io.ReadCloser, err error) {
-	err = ErrNotImplemented
-	return
+// StreamOut streams the file or directory at the path in the spec out of the
+// container as a tar stream.
+//
+// Following the semantics of `tar`, a path ending in `/` streams out the
+// contents of the directory rather than the directory itself.
+//
+func (c *Container) StreamOut(spec garden.StreamOutSpec) (io.ReadCloser, error) {
+	containerSpec, err := c.container.Spec(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("container spec: %w", err)
+	}
+
+	root, path, err := hostPath(containerSpec, spec.Path)
+	if err != nil {
+		return nil, fmt.Errorf("resolve path: %w", err)
+	}
+
+	rootDir, err := openRoot(root)
+	if err != nil {
+		return nil, fmt.Errorf("open root: %w", err)
+	}
+
+	defer rootDir.Close()
+
+	src, err := openIn(rootDir, path, unix.O_PATH|unix.O_NOFOLLOW, 0)
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+
+	name := filepath.Base(filepath.Clean("/" + spec.Path))
+	if strings.HasSuffix(spec.Path, "/") {
+		name = "."
+	}
+
+	ids := newIDMapper(containerSpec)
+
+	r, w := io.Pipe()
+	go func() {
+		defer src.Close()
+		w.CloseWithError(compressTar(w, src, name, ids))
+	}()
+
+	return r, nil
+}
+
+// streamInOwner determines the host user and group that the files streamed
+// into the container should belong to.
+//
+func (c *Container) streamInOwner(spec *specs.Spec, user string) (int, int, error) {
+	uid, gid := 0, 0
+
+	if user != "" && user != "root" {
+		u, found, err := c.rootfsManager.LookupUser(spec.Root.Path, user)
+		if err != nil {
+			return 0, 0, fmt.Errorf("lookup user: %w", err)
+		}
+
+		if !found {
+			return 0, 0, UserNotFoundError{User: user}
+		}
+
+		uid, gid = int(u.UID), int(u.GID)
+	}
+
+	uid, gid = newIDMapper(spec).hostIDs(uid, gid)
+
+	return uid, gid, nil
 }
 
 // SetGraceTime stores the grace time as a containerd label with key "garden.grace-time"
@@ -303,22 +526,125 @@ func (c *Container) CurrentMemoryLimits() (limits garden.MemoryLimits, err error
 	}, nil
 }
 
-// NetIn - Not Implemented
-func (c *Container) NetIn(hostPort, containerPort uint32) (a, b uint32, err error) {
-	err = ErrNotImplemented
-	return
+// NetIn forwards tcp traffic sent to a port of the host to a port of the
+// container.
+//
+// A `hostPort` of 0 picks a free port of the host, picking another one should
+// it have been forwarded to another container in the meantime, and a
+// `containerPort` of 0 uses the same port as the host. The mapping is recorded in the
+// "garden.network.mapped-ports" property.
+//
+func (c *Container) NetIn(hostPort, containerPort uint32) (uint32, uint32, error) {
+	properties, err := c.Properties()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	mappedPorts, err := parseMappedPorts(properties[MappedPortsKey])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	pickHostPort := hostPort == 0
+	forwardToHostPort := containerPort == 0
+
+	for attempt := 1; ; attempt++ {
+		if pickHostPort {
+			hostPort, err = freePort()
+			if err != nil {
+				return 0, 0, fmt.Errorf("free port: %w", err)
+			}
+		}
+
+		if forwardToHostPort {
+			containerPort = hostPort
+		}
+
+		err = c.network.NetIn(c.Handle(), properties[ContainerIPKey], hostPort, containerPort)
+		if err == nil {
+			break
+		}
+
+		// the port was free when it was picked, but may have been picked for
+		// another container since
+		if pickHostPort && errors.Is(err, ErrHostPortInUse) && attempt < maxFreePortAttempts {
+			continue
+		}
+
+		return 0, 0, fmt.Errorf("net in: %w", err)
+	}
+
+	mappedPorts = append(mappedPorts, garden.PortMapping{
+		HostPort:      hostPort,
+		ContainerPort: containerPort,
+	})
+
+	payload, err := json.Marshal(mappedPorts)
+	if err != nil {
+		return 0, 0, fmt.Errorf("marshal mapped ports: %w", err)
+	}
+
+	err = c.SetProperty(MappedPortsKey, string(payload))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return hostPort, containerPort, nil
 }
 
-// NetOut - Not Implemented
-func (c *Container) NetOut(netOutRule garden.NetOutRule) (err error) {
-	err = ErrNotImplemented
-	return
+// NetOut allows the container to reach the destinations described by the
+// rule, even if they fall into one of the restricted networks.
+//
+func (c *Container) NetOut(netOutRule garden.NetOutRule) error {
+	return c.BulkNetOut([]garden.NetOutRule{netOutRule})
 }
 
-// BulkNetOut - Not Implemented
-func (c *Container) BulkNetOut(netOutRules []garden.NetOutRule) (err error) {
-	err = ErrNotImplemented
-	return
+// BulkNetOut applies NetOut for each of the rules.
+//
+func (c *Container) BulkNetOut(netOutRules []garden.NetOutRule) error {
+	ip, err := c.Property(ContainerIPKey)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range netOutRules {
+		err = c.network.NetOut(c.Handle(), ip, rule)
+		if err != nil {
+			return fmt.Errorf("net out: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func parseMappedPorts(payload string) ([]garden.PortMapping, error) {
+	mappedPorts := []garden.PortMapping{}
+	if payload == "" {
+		return mappedPorts, nil
+	}
+
+	err := json.Unmarshal([]byte(payload), &mappedPorts)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal mapped ports: %w", err)
+	}
+
+	return mappedPorts, nil
+}
+
+// maxFreePortAttempts is the number of free ports of the host that NetIn
+// tries forwarding before giving up.
+//
+const maxFreePortAttempts = 10
+
+func freePort() (uint32, error) {
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		return 0, err
+	}
+
+	defer listener.Close()
+
+	return uint32(listener.Addr().(*net.TCPAddr).Port), nil
 }
 
 func procID(gdnProcSpec garden.ProcessSpec) string {
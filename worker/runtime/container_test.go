@@ -1,16 +1,31 @@
 package runtime_test
 
 import (
+	"archive/tar"
+	"bytes"
 	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
 
 	"code.cloudfoundry.org/garden"
 	"github.com/concourse/concourse/worker/runtime"
 	"github.com/concourse/concourse/worker/runtime/libcontainerd/libcontainerdfakes"
 	"github.com/concourse/concourse/worker/runtime/runtimefakes"
+	v1 "github.com/containerd/cgroups/stats/v1"
 	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/api/types"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/runtime/linux/runctypes"
+	"github.com/containerd/typeurl"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/sys/unix"
 )
 
 type ContainerSuite struct {
@@ -23,6 +38,7 @@ type ContainerSuite struct {
 	containerdTask      *libcontainerdfakes.FakeTask
 	rootfsManager       *runtimefakes.FakeRootfsManager
 	killer              *runtimefakes.FakeKiller
+	network             *runtimefakes.FakeNetwork
 }
 
 func (s *ContainerSuite) SetupTest() {
@@ -31,11 +47,13 @@ func (s *ContainerSuite) SetupTest() {
 	s.containerdTask = new(libcontainerdfakes.FakeTask)
 	s.rootfsManager = new(runtimefakes.FakeRootfsManager)
 	s.killer = new(runtimefakes.FakeKiller)
+	s.network = new(runtimefakes.FakeNetwork)
 
 	s.container = runtime.NewContainer(
 		s.containerdContainer,
 		s.killer,
 		s.rootfsManager,
+		s.network,
 	)
 }
 
@@ -365,3 +383,614 @@ func (s *ContainerSuite) TestCurrentMemoryLimitsReturnsLimit() {
 	s.NoError(err)
 	s.Equal(garden.MemoryLimits{LimitInBytes: uint64(limitBytes)}, limits)
 }
+
+func (s *ContainerSuite) cgroupMetrics(stats *v1.Metrics) *types.Metric {
+	data, err := typeurl.MarshalAny(stats)
+	s.NoError(err)
+
+	return &types.Metric{Data: data}
+}
+
+func (s *ContainerSuite) TestMetricsTaskLookupFails() {
+	s.containerdContainer.TaskReturns(nil, errors.New("task-err"))
+
+	_, err := s.container.Metrics()
+	s.EqualError(errors.Unwrap(err), "task-err")
+}
+
+func (s *ContainerSuite) TestMetricsTaskMetricsFails() {
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.MetricsReturns(nil, errors.New("metrics-err"))
+
+	_, err := s.container.Metrics()
+	s.EqualError(errors.Unwrap(err), "metrics-err")
+}
+
+func (s *ContainerSuite) TestMetricsConvertsCgroupStats() {
+	rootfs, err := ioutil.TempDir("", "container-metrics")
+	s.NoError(err)
+	defer os.RemoveAll(rootfs)
+
+	s.NoError(ioutil.WriteFile(filepath.Join(rootfs, "file"), make([]byte, 8192), 0644))
+
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Root: &specs.Root{Path: rootfs},
+	}, nil)
+	s.containerdContainer.InfoReturns(containers.Container{
+		CreatedAt: time.Now().Add(-time.Minute),
+	}, nil)
+	s.containerdTask.MetricsReturns(s.cgroupMetrics(&v1.Metrics{
+		Pids: &v1.PidsStat{Current: 3, Limit: 100},
+		CPU: &v1.CPUStat{
+			Usage: &v1.CPUUsage{Total: 300, User: 200, Kernel: 100},
+		},
+		Memory: &v1.MemoryStat{
+			RSS:               1024,
+			Cache:             512,
+			TotalInactiveFile: 256,
+			Usage:             &v1.MemoryEntry{Usage: 2048, Max: 4096},
+			Swap:              &v1.MemoryEntry{Usage: 128},
+		},
+		Network: []*v1.NetworkStat{
+			{Name: "lo", RxBytes: 1000, TxBytes: 1000},
+			{Name: "eth0", RxBytes: 10, TxBytes: 20},
+			{Name: "eth1", RxBytes: 1, TxBytes: 2},
+		},
+	}), nil)
+
+	metrics, err := s.container.Metrics()
+	s.NoError(err)
+
+	s.Equal(garden.ContainerCPUStat{Usage: 300, User: 200, System: 100}, metrics.CPUStat)
+	s.Equal(garden.ContainerPidStat{Current: 3, Max: 100}, metrics.PidStat)
+	s.Equal(garden.ContainerNetworkStat{RxBytes: 11, TxBytes: 22}, metrics.NetworkStat)
+
+	s.Equal(uint64(1024), metrics.MemoryStat.Rss)
+	s.Equal(uint64(512), metrics.MemoryStat.Cache)
+	s.Equal(uint64(128), metrics.MemoryStat.Swap)
+	s.Equal(uint64(2048-256), metrics.MemoryStat.TotalUsageTowardLimit)
+
+	s.Equal(uint64(2), metrics.DiskStat.TotalInodesUsed)
+	s.True(metrics.DiskStat.TotalBytesUsed >= 8192)
+	s.Equal(metrics.DiskStat.TotalBytesUsed, metrics.DiskStat.ExclusiveBytesUsed)
+
+	s.True(metrics.Age >= time.Minute)
+}
+
+func (s *ContainerSuite) TestMetricsReusesRecentDiskStat() {
+	rootfs, err := ioutil.TempDir("", "container-metrics")
+	s.NoError(err)
+	defer os.RemoveAll(rootfs)
+
+	s.NoError(ioutil.WriteFile(filepath.Join(rootfs, "file"), make([]byte, 8192), 0644))
+
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Root: &specs.Root{Path: rootfs},
+	}, nil)
+	s.containerdTask.MetricsReturns(s.cgroupMetrics(&v1.Metrics{}), nil)
+
+	metrics, err := s.container.Metrics()
+	s.NoError(err)
+	s.Equal(uint64(2), metrics.DiskStat.TotalInodesUsed)
+
+	s.NoError(ioutil.WriteFile(filepath.Join(rootfs, "other-file"), make([]byte, 8192), 0644))
+
+	metrics, err = s.container.Metrics()
+	s.NoError(err)
+	s.Equal(uint64(2), metrics.DiskStat.TotalInodesUsed)
+}
+
+func (s *ContainerSuite) TestInfoTaskLookupFails() {
+	s.containerdContainer.TaskReturns(nil, errors.New("task-err"))
+
+	_, err := s.container.Info()
+	s.EqualError(errors.Unwrap(err), "task-err")
+}
+
+func (s *ContainerSuite) TestInfoStoppedContainer() {
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Root: &specs.Root{Path: "/rootfs"},
+	}, nil)
+	s.containerdContainer.LabelsReturns(map[string]string{"foo": "bar"}, nil)
+	s.containerdTask.StatusReturns(containerd.Status{Status: containerd.Stopped}, nil)
+
+	info, err := s.container.Info()
+	s.NoError(err)
+
+	s.Equal("stopped", info.State)
+	s.Equal("/rootfs", info.ContainerPath)
+	s.Equal(garden.Properties{"foo": "bar"}, info.Properties)
+	s.Empty(info.ProcessIDs)
+	s.Equal(0, s.containerdTask.MetricsCallCount())
+}
+
+func (s *ContainerSuite) TestInfoRunningContainer() {
+	details, err := typeurl.MarshalAny(&runctypes.ProcessDetails{ExecID: "some-proc"})
+	s.NoError(err)
+
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdContainer.SpecReturns(&specs.Spec{}, nil)
+	s.containerdContainer.LabelsReturns(map[string]string{}, nil)
+	s.containerdTask.StatusReturns(containerd.Status{Status: containerd.Running}, nil)
+	s.containerdTask.PidsReturns([]containerd.ProcessInfo{
+		{Pid: 1},
+		{Pid: 42, Info: details},
+	}, nil)
+	s.containerdTask.MetricsReturns(s.cgroupMetrics(&v1.Metrics{
+		Memory: &v1.MemoryStat{
+			Usage: &v1.MemoryEntry{Usage: 2048, Max: 4096},
+		},
+	}), nil)
+
+	info, err := s.container.Info()
+	s.NoError(err)
+
+	s.Equal("active", info.State)
+	s.Equal([]string{"some-proc"}, info.ProcessIDs)
+	s.Equal("4096", info.Properties[runtime.MemoryPeakKey])
+}
+
+func (s *ContainerSuite) TestInfoNetwork() {
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdContainer.SpecReturns(&specs.Spec{}, nil)
+	s.containerdContainer.LabelsReturns(map[string]string{
+		runtime.ContainerIPKey: "10.80.0.2",
+		runtime.MappedPortsKey: `[{"HostPort":8080,"ContainerPort":80}]`,
+	}, nil)
+	s.containerdTask.StatusReturns(containerd.Status{Status: containerd.Stopped}, nil)
+
+	info, err := s.container.Info()
+	s.NoError(err)
+
+	s.Equal("10.80.0.2", info.ContainerIP)
+	s.Equal([]garden.PortMapping{{HostPort: 8080, ContainerPort: 80}}, info.MappedPorts)
+}
+
+func (s *ContainerSuite) TestNetInGetLabelsFails() {
+	s.containerdContainer.LabelsReturns(nil, errors.New("labels-err"))
+
+	_, _, err := s.container.NetIn(8080, 80)
+	s.EqualError(errors.Unwrap(err), "labels-err")
+	s.Equal(0, s.network.NetInCallCount())
+}
+
+func (s *ContainerSuite) TestNetInNetworkFails() {
+	s.containerdContainer.LabelsReturns(map[string]string{}, nil)
+	s.network.NetInReturns(errors.New("net-in-err"))
+
+	_, _, err := s.container.NetIn(8080, 80)
+	s.EqualError(errors.Unwrap(err), "net-in-err")
+	s.Equal(0, s.containerdContainer.SetLabelsCallCount())
+}
+
+func (s *ContainerSuite) TestNetInRecordsMappedPorts() {
+	s.containerdContainer.IDReturns("handle")
+	s.containerdContainer.LabelsReturns(map[string]string{
+		runtime.ContainerIPKey: "10.80.0.2",
+		runtime.MappedPortsKey: `[{"HostPort":8080,"ContainerPort":80}]`,
+	}, nil)
+
+	hostPort, containerPort, err := s.container.NetIn(8081, 0)
+	s.NoError(err)
+	s.Equal(uint32(8081), hostPort)
+	s.Equal(uint32(8081), containerPort)
+
+	s.Equal(1, s.network.NetInCallCount())
+	handle, ip, actualHostPort, actualContainerPort := s.network.NetInArgsForCall(0)
+	s.Equal("handle", handle)
+	s.Equal("10.80.0.2", ip)
+	s.Equal(uint32(8081), actualHostPort)
+	s.Equal(uint32(8081), actualContainerPort)
+
+	s.Equal(1, s.containerdContainer.SetLabelsCallCount())
+	_, labels := s.containerdContainer.SetLabelsArgsForCall(0)
+	s.JSONEq(
+		`[{"HostPort":8080,"ContainerPort":80},{"HostPort":8081,"ContainerPort":8081}]`,
+		labels[runtime.MappedPortsKey],
+	)
+}
+
+func (s *ContainerSuite) TestNetInPicksFreeHostPort() {
+	s.containerdContainer.LabelsReturns(map[string]string{
+		runtime.ContainerIPKey: "10.80.0.2",
+	}, nil)
+
+	hostPort, containerPort, err := s.container.NetIn(0, 80)
+	s.NoError(err)
+	s.NotZero(hostPort)
+	s.Equal(uint32(80), containerPort)
+}
+
+func (s *ContainerSuite) TestNetInPicksAnotherHostPortWhenInUse() {
+	s.containerdContainer.LabelsReturns(map[string]string{
+		runtime.ContainerIPKey: "10.80.0.2",
+	}, nil)
+	s.network.NetInReturnsOnCall(0, runtime.ErrHostPortInUse)
+
+	hostPort, _, err := s.container.NetIn(0, 80)
+	s.NoError(err)
+	s.Equal(2, s.network.NetInCallCount())
+
+	_, _, actualHostPort, _ := s.network.NetInArgsForCall(1)
+	s.Equal(actualHostPort, hostPort)
+}
+
+func (s *ContainerSuite) TestNetInRequestedHostPortInUse() {
+	s.containerdContainer.LabelsReturns(map[string]string{
+		runtime.ContainerIPKey: "10.80.0.2",
+	}, nil)
+	s.network.NetInReturns(runtime.ErrHostPortInUse)
+
+	_, _, err := s.container.NetIn(8080, 80)
+	s.True(errors.Is(err, runtime.ErrHostPortInUse))
+	s.Equal(1, s.network.NetInCallCount())
+}
+
+func (s *ContainerSuite) TestBulkNetOutWithoutContainerIP() {
+	s.containerdContainer.LabelsReturns(map[string]string{}, nil)
+
+	err := s.container.BulkNetOut([]garden.NetOutRule{{}})
+	s.True(errors.Is(err, runtime.ErrNotFound(runtime.ContainerIPKey)))
+	s.Equal(0, s.network.NetOutCallCount())
+}
+
+func (s *ContainerSuite) TestBulkNetOutAppliesEachRule() {
+	s.containerdContainer.IDReturns("handle")
+	s.containerdContainer.LabelsReturns(map[string]string{
+		runtime.ContainerIPKey: "10.80.0.2",
+	}, nil)
+
+	rules := []garden.NetOutRule{
+		{Protocol: garden.ProtocolTCP},
+		{Protocol: garden.ProtocolUDP},
+	}
+
+	err := s.container.BulkNetOut(rules)
+	s.NoError(err)
+
+	s.Equal(2, s.network.NetOutCallCount())
+	for i, rule := range rules {
+		handle, ip, actualRule := s.network.NetOutArgsForCall(i)
+		s.Equal("handle", handle)
+		s.Equal("10.80.0.2", ip)
+		s.Equal(rule, actualRule)
+	}
+}
+
+func (s *ContainerSuite) TestNetOutNetworkFails() {
+	s.containerdContainer.LabelsReturns(map[string]string{
+		runtime.ContainerIPKey: "10.80.0.2",
+	}, nil)
+	s.network.NetOutReturns(errors.New("net-out-err"))
+
+	err := s.container.NetOut(garden.NetOutRule{})
+	s.EqualError(errors.Unwrap(err), "net-out-err")
+}
+
+func (s *ContainerSuite) TestStreamInGetSpecFails() {
+	s.containerdContainer.SpecReturns(nil, errors.New("spec-err"))
+
+	err := s.container.StreamIn(garden.StreamInSpec{Path: "/dst"})
+	s.EqualError(errors.Unwrap(err), "spec-err")
+}
+
+func (s *ContainerSuite) TestStreamInOntoNonBindMount() {
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Root:   &specs.Root{Path: "/rootfs"},
+		Mounts: []specs.Mount{{Destination: "/dev/shm", Type: "tmpfs"}},
+	}, nil)
+
+	err := s.container.StreamIn(garden.StreamInSpec{Path: "/dev/shm/dst"})
+	s.EqualError(errors.Unwrap(err), "/dev/shm/dst is not on a bind mount")
+}
+
+func (s *ContainerSuite) TestStreamInUserNotFound() {
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Root: &specs.Root{Path: "/rootfs"},
+	}, nil)
+	s.rootfsManager.LookupUserReturns(specs.User{}, false, nil)
+
+	err := s.container.StreamIn(garden.StreamInSpec{Path: "/dst", User: "some-user"})
+	s.Equal(runtime.UserNotFoundError{User: "some-user"}, err)
+}
+
+func (s *ContainerSuite) TestStreamInExtractsIntoRootfs() {
+	rootfs := s.tempDir()
+
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Root: &specs.Root{Path: rootfs},
+		Linux: &specs.Linux{
+			UIDMappings: []specs.LinuxIDMapping{{ContainerID: 0, HostID: 100000, Size: 65536}},
+			GIDMappings: []specs.LinuxIDMapping{{ContainerID: 0, HostID: 100000, Size: 65536}},
+		},
+	}, nil)
+	s.rootfsManager.LookupUserReturns(specs.User{UID: 1000, GID: 1001}, true, nil)
+
+	err := s.container.StreamIn(garden.StreamInSpec{
+		Path:      "/some/dst",
+		User:      "some-user",
+		TarStream: s.tarStream(map[string]string{"dir/file": "contents"}),
+	})
+	s.NoError(err)
+
+	rootfsPath, user := s.rootfsManager.LookupUserArgsForCall(0)
+	s.Equal(rootfs, rootfsPath)
+	s.Equal("some-user", user)
+
+	path := filepath.Join(rootfs, "some", "dst", "dir", "file")
+
+	contents, err := ioutil.ReadFile(path)
+	s.NoError(err)
+	s.Equal("contents", string(contents))
+
+	info, err := os.Stat(path)
+	s.NoError(err)
+	s.Equal(uint32(101000), info.Sys().(*syscall.Stat_t).Uid)
+	s.Equal(uint32(101001), info.Sys().(*syscall.Stat_t).Gid)
+}
+
+func (s *ContainerSuite) TestStreamInExtractsIntoBindMount() {
+	rootfs, volume := s.tempDir(), s.tempDir()
+
+	s.specWithBindMount(rootfs, volume)
+
+	err := s.container.StreamIn(garden.StreamInSpec{
+		Path:      "/tmp/build/input/sub",
+		TarStream: s.tarStream(map[string]string{"file": "contents"}),
+	})
+	s.NoError(err)
+
+	contents, err := ioutil.ReadFile(filepath.Join(volume, "sub", "file"))
+	s.NoError(err)
+	s.Equal("contents", string(contents))
+}
+
+func (s *ContainerSuite) TestStreamInDoesNotFollowSymlinksOutOfTheRootfs() {
+	rootfs, outside := s.tempDir(), s.tempDir()
+
+	s.NoError(os.Symlink(outside, filepath.Join(rootfs, "escape")))
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Root: &specs.Root{Path: rootfs},
+	}, nil)
+
+	err := s.container.StreamIn(garden.StreamInSpec{
+		Path:      "/escape",
+		TarStream: s.tarStream(map[string]string{"file": "contents"}),
+	})
+	s.Error(err)
+
+	_, err = os.Stat(filepath.Join(outside, "file"))
+	s.True(os.IsNotExist(err))
+}
+
+func (s *ContainerSuite) TestStreamInFollowsSymlinksWithinTheRootfs() {
+	rootfs := s.tempDir()
+
+	s.NoError(os.Mkdir(filepath.Join(rootfs, "real"), 0755))
+	s.NoError(os.Symlink("/real", filepath.Join(rootfs, "alias")))
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Root: &specs.Root{Path: rootfs},
+	}, nil)
+
+	err := s.container.StreamIn(garden.StreamInSpec{
+		Path:      "/alias/sub",
+		TarStream: s.tarStream(map[string]string{"file": "contents"}),
+	})
+	s.NoError(err)
+
+	contents, err := ioutil.ReadFile(filepath.Join(rootfs, "real", "sub", "file"))
+	s.NoError(err)
+	s.Equal("contents", string(contents))
+}
+
+func (s *ContainerSuite) TestStreamInRacingSymlinkSwap() {
+	rootfs, outside := s.tempDir(), s.tempDir()
+
+	s.NoError(os.Mkdir(filepath.Join(rootfs, "dir"), 0755))
+	s.NoError(os.Symlink(outside, filepath.Join(rootfs, "link")))
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Root: &specs.Root{Path: rootfs},
+	}, nil)
+
+	stop := s.swapContinuously(filepath.Join(rootfs, "dir"), filepath.Join(rootfs, "link"))
+	defer stop()
+
+	for i := 0; i < 500; i++ {
+		_ = s.container.StreamIn(garden.StreamInSpec{
+			Path: "/dir/sub",
+			TarStream: s.tarStream(map[string]string{
+				"file": "contents",
+			}),
+		})
+	}
+
+	stop()
+
+	entries, err := ioutil.ReadDir(outside)
+	s.NoError(err)
+	s.Empty(entries)
+}
+
+func (s *ContainerSuite) TestStreamOutMissingPath() {
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Root: &specs.Root{Path: s.tempDir()},
+	}, nil)
+
+	_, err := s.container.StreamOut(garden.StreamOutSpec{Path: "/missing"})
+	s.True(os.IsNotExist(errors.Unwrap(err)))
+}
+
+func (s *ContainerSuite) TestStreamOutDirectory() {
+	rootfs := s.tempDir()
+
+	s.NoError(os.MkdirAll(filepath.Join(rootfs, "src", "dir"), 0755))
+	s.NoError(ioutil.WriteFile(filepath.Join(rootfs, "src", "dir", "file"), []byte("contents"), 0644))
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Root: &specs.Root{Path: rootfs},
+	}, nil)
+
+	stream, err := s.container.StreamOut(garden.StreamOutSpec{Path: "/src"})
+	s.NoError(err)
+
+	s.Equal(map[string]string{
+		"src/":         "",
+		"src/dir/":     "",
+		"src/dir/file": "contents",
+	}, s.tarEntries(stream))
+}
+
+func (s *ContainerSuite) TestStreamOutDirectoryContents() {
+	rootfs, volume := s.tempDir(), s.tempDir()
+
+	s.NoError(ioutil.WriteFile(filepath.Join(volume, "file"), []byte("contents"), 0644))
+	s.specWithBindMount(rootfs, volume)
+
+	stream, err := s.container.StreamOut(garden.StreamOutSpec{Path: "/tmp/build/input/"})
+	s.NoError(err)
+
+	s.Equal(map[string]string{
+		"./":     "",
+		"./file": "contents",
+	}, s.tarEntries(stream))
+}
+
+func (s *ContainerSuite) TestStreamOutFollowsSymlinksWithinTheRootfs() {
+	rootfs := s.tempDir()
+
+	s.NoError(os.Mkdir(filepath.Join(rootfs, "real"), 0755))
+	s.NoError(ioutil.WriteFile(filepath.Join(rootfs, "real", "file"), []byte("contents"), 0644))
+	s.NoError(os.Symlink("/real", filepath.Join(rootfs, "alias")))
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Root: &specs.Root{Path: rootfs},
+	}, nil)
+
+	stream, err := s.container.StreamOut(garden.StreamOutSpec{Path: "/alias/file"})
+	s.NoError(err)
+
+	s.Equal(map[string]string{
+		"file": "contents",
+	}, s.tarEntries(stream))
+}
+
+func (s *ContainerSuite) TestStreamOutRacingSymlinkSwap() {
+	rootfs, outside := s.tempDir(), s.tempDir()
+
+	s.NoError(os.Mkdir(filepath.Join(rootfs, "dir"), 0755))
+	s.NoError(ioutil.WriteFile(filepath.Join(rootfs, "dir", "file"), []byte("contents"), 0644))
+	s.NoError(ioutil.WriteFile(filepath.Join(outside, "file"), []byte("secret"), 0644))
+	s.NoError(os.Symlink(outside, filepath.Join(rootfs, "link")))
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Root: &specs.Root{Path: rootfs},
+	}, nil)
+
+	stop := s.swapContinuously(filepath.Join(rootfs, "dir"), filepath.Join(rootfs, "link"))
+	defer stop()
+
+	for i := 0; i < 500; i++ {
+		stream, err := s.container.StreamOut(garden.StreamOutSpec{Path: "/dir/file"})
+		if err != nil {
+			continue
+		}
+
+		contents, _ := ioutil.ReadAll(tar.NewReader(stream))
+		stream.Close()
+
+		s.NotContains(string(contents), "secret")
+	}
+}
+
+func (s *ContainerSuite) specWithBindMount(rootfs, volume string) {
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Root: &specs.Root{Path: rootfs},
+		Mounts: []specs.Mount{
+			{Destination: "/tmp", Type: "tmpfs", Source: "tmpfs"},
+			{Destination: "/tmp/build/input", Type: "bind", Source: volume},
+		},
+	}, nil)
+}
+
+// swapContinuously atomically exchanges two paths over and over until the
+// returned function is called.
+//
+func (s *ContainerSuite) swapContinuously(a, b string) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			_ = unix.Renameat2(unix.AT_FDCWD, a, unix.AT_FDCWD, b, unix.RENAME_EXCHANGE)
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			<-stopped
+		})
+	}
+}
+
+func (s *ContainerSuite) tempDir() string {
+	dir, err := ioutil.TempDir("", "container-test")
+	s.NoError(err)
+
+	s.T().Cleanup(func() { os.RemoveAll(dir) })
+
+	return dir
+}
+
+func (s *ContainerSuite) tarStream(files map[string]string) io.Reader {
+	buf := new(bytes.Buffer)
+	w := tar.NewWriter(buf)
+
+	for name, contents := range files {
+		s.NoError(w.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(contents)),
+			Typeflag: tar.TypeReg,
+			ModTime:  time.Now(),
+		}))
+
+		_, err := w.Write([]byte(contents))
+		s.NoError(err)
+	}
+
+	s.NoError(w.Close())
+
+	return buf
+}
+
+func (s *ContainerSuite) tarEntries(stream io.ReadCloser) map[string]string {
+	defer stream.Close()
+
+	entries := map[string]string{}
+
+	r := tar.NewReader(stream)
+	for {
+		header, err := r.Next()
+		if err == io.EOF {
+			break
+		}
+		s.NoError(err)
+
+		contents, err := ioutil.ReadAll(r)
+		s.NoError(err)
+
+		entries[header.Name] = string(contents)
+	}
+
+	return entries
+}
@@ -27,4 +27,20 @@ var (
 	// ErrNotImplemented indicates that a method is not implemented.
 	//
 	ErrNotImplemented = errors.New("not implemented")
+
+	// ErrPrivilegedNotAllowed indicates that a privileged container was
+	// requested from a backend that only runs unprivileged ones.
+	//
+	ErrPrivilegedNotAllowed = errors.New("privileged containers are not allowed on this worker")
+
+	// ErrOpenat2NotSupported indicates that the kernel lacks openat2(2),
+	// which streaming in and out of containers relies on to resolve paths
+	// safely.
+	//
+	ErrOpenat2NotSupported = errors.New("streaming in and out of containers requires openat2(2), available since Linux 5.6")
+
+	// ErrHostPortInUse indicates that traffic sent to a port of the host is
+	// already forwarded to a container.
+	//
+	ErrHostPortInUse = errors.New("host port is already forwarded to a container")
 )
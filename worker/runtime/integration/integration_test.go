@@ -583,3 +583,128 @@ func (s *IntegrationSuite) TestMaxContainers() {
 	s.Error(err)
 	s.Contains(err.Error(), "max containers reached")
 }
+
+// TestContainerMetrics verifies that we're able to gather the resource usage
+// of a container that has a process running in it, both directly and in
+// bulk.
+//
+func (s *IntegrationSuite) TestContainerMetrics() {
+	handle := uuid()
+
+	container, err := s.gardenBackend.Create(garden.ContainerSpec{
+		Handle:     handle,
+		RootFSPath: "raw://" + s.rootfs,
+		Privileged: true,
+	})
+	s.NoError(err)
+
+	defer func() {
+		s.NoError(s.gardenBackend.Destroy(handle))
+	}()
+
+	buf := new(buffer)
+	proc, err := container.Run(
+		garden.ProcessSpec{
+			Path: "/executable",
+			Args: []string{"-http-get=http://example.com"},
+		},
+		garden.ProcessIO{
+			Stdout: buf,
+			Stderr: buf,
+		},
+	)
+	s.NoError(err)
+
+	exitCode, err := proc.Wait()
+	s.NoError(err)
+	s.Equal(0, exitCode)
+
+	metrics, err := container.Metrics()
+	s.NoError(err)
+
+	s.NotZero(metrics.CPUStat.Usage)
+	s.NotZero(metrics.MemoryStat.TotalUsageTowardLimit)
+	s.NotZero(metrics.PidStat.Current)
+	s.NotZero(metrics.NetworkStat.RxBytes)
+	s.NotZero(metrics.NetworkStat.TxBytes)
+	s.NotZero(metrics.DiskStat.TotalBytesUsed)
+	s.NotZero(metrics.DiskStat.TotalInodesUsed)
+	s.NotZero(metrics.Age)
+
+	bulkMetrics, err := s.gardenBackend.BulkMetrics([]string{handle, "missing"})
+	s.NoError(err)
+
+	s.Nil(bulkMetrics[handle].Err)
+	s.NotZero(bulkMetrics[handle].Metrics.CPUStat.Usage)
+	s.NotNil(bulkMetrics["missing"].Err)
+}
+
+// TestContainerInfo verifies that we're able to inspect a running container.
+//
+func (s *IntegrationSuite) TestContainerInfo() {
+	handle := uuid()
+	properties := garden.Properties{"test": uuid()}
+
+	container, err := s.gardenBackend.Create(garden.ContainerSpec{
+		Handle:     handle,
+		RootFSPath: "raw://" + s.rootfs,
+		Privileged: true,
+		Properties: properties,
+	})
+	s.NoError(err)
+
+	defer func() {
+		s.NoError(s.gardenBackend.Destroy(handle))
+	}()
+
+	_, err = container.Run(
+		garden.ProcessSpec{
+			ID:   "waiting",
+			Path: "/executable",
+			Args: []string{"-wait-for-signal=sighup"},
+		},
+		garden.ProcessIO{
+			Stdout: os.Stdout,
+			Stderr: os.Stderr,
+		},
+	)
+	s.NoError(err)
+
+	info, err := container.Info()
+	s.NoError(err)
+
+	s.Equal("active", info.State)
+	s.Equal(s.rootfs, info.ContainerPath)
+	s.Equal([]string{"waiting"}, info.ProcessIDs)
+	s.Equal(properties["test"], info.Properties["test"])
+	s.NotEmpty(info.Properties[runtime.MemoryPeakKey])
+
+	bulkInfo, err := s.gardenBackend.BulkInfo([]string{handle})
+	s.NoError(err)
+
+	s.Nil(bulkInfo[handle].Err)
+	s.Equal("active", bulkInfo[handle].Info.State)
+}
+
+// TestCapacity verifies that the capacity reported reflects the host and the
+// configured limits.
+//
+func (s *IntegrationSuite) TestCapacity() {
+	customBackend, err := runtime.NewGardenBackend(
+		libcontainerd.New(
+			s.containerdSocket(),
+			"test-capacity",
+			time.Second,
+		),
+		runtime.WithMaxContainers(42),
+		runtime.WithDiskPath(s.tmpDir),
+	)
+	s.NoError(err)
+
+	capacity, err := customBackend.Capacity()
+	s.NoError(err)
+
+	s.Equal(uint64(42), capacity.MaxContainers)
+	s.NotZero(capacity.MemoryInBytes)
+	s.NotZero(capacity.DiskInBytes)
+}
@@ -9,6 +9,10 @@ import (
 type Iptables interface {
 	CreateChainOrFlushIfExists(table string, chain string) error
 	AppendRule(table string, chain string, rulespec ...string) error
+	AppendUniqueRule(table string, chain string, rulespec ...string) error
+	InsertRule(table string, chain string, pos int, rulespec ...string) error
+	DeleteRule(table string, chain string, rulespec ...string) error
+	ListRules(table string, chain string) ([]string, error)
 }
 
 type iptables struct {
@@ -38,4 +42,23 @@ func (ipt *iptables) CreateChainOrFlushIfExists(table string, chain string) erro
 func (ipt *iptables) AppendRule(table string, chain string, rulespec ...string) error {
 	err := ipt.goipt.Append(table, chain, rulespec...)
 	return err
-}
\ No newline at end of file
+}
+func (ipt *iptables) AppendUniqueRule(table string, chain string, rulespec ...string) error {
+	err := ipt.goipt.AppendUnique(table, chain, rulespec...)
+	return err
+}
+
+func (ipt *iptables) InsertRule(table string, chain string, pos int, rulespec ...string) error {
+	err := ipt.goipt.Insert(table, chain, pos, rulespec...)
+	return err
+}
+
+func (ipt *iptables) DeleteRule(table string, chain string, rulespec ...string) error {
+	err := ipt.goipt.Delete(table, chain, rulespec...)
+	return err
+}
+
+func (ipt *iptables) ListRules(table string, chain string) ([]string, error) {
+	rules, err := ipt.goipt.List(table, chain)
+	return rules, err
+}
@@ -21,6 +21,19 @@ type FakeIptables struct {
 	appendRuleReturnsOnCall map[int]struct {
 		result1 error
 	}
+	AppendUniqueRuleStub        func(string, string, ...string) error
+	appendUniqueRuleMutex       sync.RWMutex
+	appendUniqueRuleArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 []string
+	}
+	appendUniqueRuleReturns struct {
+		result1 error
+	}
+	appendUniqueRuleReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CreateChainOrFlushIfExistsStub        func(string, string) error
 	createChainOrFlushIfExistsMutex       sync.RWMutex
 	createChainOrFlushIfExistsArgsForCall []struct {
@@ -33,6 +46,47 @@ type FakeIptables struct {
 	createChainOrFlushIfExistsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeleteRuleStub        func(string, string, ...string) error
+	deleteRuleMutex       sync.RWMutex
+	deleteRuleArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 []string
+	}
+	deleteRuleReturns struct {
+		result1 error
+	}
+	deleteRuleReturnsOnCall map[int]struct {
+		result1 error
+	}
+	InsertRuleStub        func(string, string, int, ...string) error
+	insertRuleMutex       sync.RWMutex
+	insertRuleArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 int
+		arg4 []string
+	}
+	insertRuleReturns struct {
+		result1 error
+	}
+	insertRuleReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ListRulesStub        func(string, string) ([]string, error)
+	listRulesMutex       sync.RWMutex
+	listRulesArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	listRulesReturns struct {
+		result1 []string
+		result2 error
+	}
+	listRulesReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -99,6 +153,68 @@ func (fake *FakeIptables) AppendRuleReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeIptables) AppendUniqueRule(arg1 string, arg2 string, arg3 ...string) error {
+	fake.appendUniqueRuleMutex.Lock()
+	ret, specificReturn := fake.appendUniqueRuleReturnsOnCall[len(fake.appendUniqueRuleArgsForCall)]
+	fake.appendUniqueRuleArgsForCall = append(fake.appendUniqueRuleArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 []string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("AppendUniqueRule", []interface{}{arg1, arg2, arg3})
+	fake.appendUniqueRuleMutex.Unlock()
+	if fake.AppendUniqueRuleStub != nil {
+		return fake.AppendUniqueRuleStub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.appendUniqueRuleReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeIptables) AppendUniqueRuleCallCount() int {
+	fake.appendUniqueRuleMutex.RLock()
+	defer fake.appendUniqueRuleMutex.RUnlock()
+	return len(fake.appendUniqueRuleArgsForCall)
+}
+
+func (fake *FakeIptables) AppendUniqueRuleCalls(stub func(string, string, ...string) error) {
+	fake.appendUniqueRuleMutex.Lock()
+	defer fake.appendUniqueRuleMutex.Unlock()
+	fake.AppendUniqueRuleStub = stub
+}
+
+func (fake *FakeIptables) AppendUniqueRuleArgsForCall(i int) (string, string, []string) {
+	fake.appendUniqueRuleMutex.RLock()
+	defer fake.appendUniqueRuleMutex.RUnlock()
+	argsForCall := fake.appendUniqueRuleArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeIptables) AppendUniqueRuleReturns(result1 error) {
+	fake.appendUniqueRuleMutex.Lock()
+	defer fake.appendUniqueRuleMutex.Unlock()
+	fake.AppendUniqueRuleStub = nil
+	fake.appendUniqueRuleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIptables) AppendUniqueRuleReturnsOnCall(i int, result1 error) {
+	fake.appendUniqueRuleMutex.Lock()
+	defer fake.appendUniqueRuleMutex.Unlock()
+	fake.AppendUniqueRuleStub = nil
+	if fake.appendUniqueRuleReturnsOnCall == nil {
+		fake.appendUniqueRuleReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.appendUniqueRuleReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeIptables) CreateChainOrFlushIfExists(arg1 string, arg2 string) error {
 	fake.createChainOrFlushIfExistsMutex.Lock()
 	ret, specificReturn := fake.createChainOrFlushIfExistsReturnsOnCall[len(fake.createChainOrFlushIfExistsArgsForCall)]
@@ -160,13 +276,210 @@ func (fake *FakeIptables) CreateChainOrFlushIfExistsReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeIptables) DeleteRule(arg1 string, arg2 string, arg3 ...string) error {
+	fake.deleteRuleMutex.Lock()
+	ret, specificReturn := fake.deleteRuleReturnsOnCall[len(fake.deleteRuleArgsForCall)]
+	fake.deleteRuleArgsForCall = append(fake.deleteRuleArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 []string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("DeleteRule", []interface{}{arg1, arg2, arg3})
+	fake.deleteRuleMutex.Unlock()
+	if fake.DeleteRuleStub != nil {
+		return fake.DeleteRuleStub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteRuleReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeIptables) DeleteRuleCallCount() int {
+	fake.deleteRuleMutex.RLock()
+	defer fake.deleteRuleMutex.RUnlock()
+	return len(fake.deleteRuleArgsForCall)
+}
+
+func (fake *FakeIptables) DeleteRuleCalls(stub func(string, string, ...string) error) {
+	fake.deleteRuleMutex.Lock()
+	defer fake.deleteRuleMutex.Unlock()
+	fake.DeleteRuleStub = stub
+}
+
+func (fake *FakeIptables) DeleteRuleArgsForCall(i int) (string, string, []string) {
+	fake.deleteRuleMutex.RLock()
+	defer fake.deleteRuleMutex.RUnlock()
+	argsForCall := fake.deleteRuleArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeIptables) DeleteRuleReturns(result1 error) {
+	fake.deleteRuleMutex.Lock()
+	defer fake.deleteRuleMutex.Unlock()
+	fake.DeleteRuleStub = nil
+	fake.deleteRuleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIptables) DeleteRuleReturnsOnCall(i int, result1 error) {
+	fake.deleteRuleMutex.Lock()
+	defer fake.deleteRuleMutex.Unlock()
+	fake.DeleteRuleStub = nil
+	if fake.deleteRuleReturnsOnCall == nil {
+		fake.deleteRuleReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteRuleReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIptables) InsertRule(arg1 string, arg2 string, arg3 int, arg4 ...string) error {
+	fake.insertRuleMutex.Lock()
+	ret, specificReturn := fake.insertRuleReturnsOnCall[len(fake.insertRuleArgsForCall)]
+	fake.insertRuleArgsForCall = append(fake.insertRuleArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 int
+		arg4 []string
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("InsertRule", []interface{}{arg1, arg2, arg3, arg4})
+	fake.insertRuleMutex.Unlock()
+	if fake.InsertRuleStub != nil {
+		return fake.InsertRuleStub(arg1, arg2, arg3, arg4...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.insertRuleReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeIptables) InsertRuleCallCount() int {
+	fake.insertRuleMutex.RLock()
+	defer fake.insertRuleMutex.RUnlock()
+	return len(fake.insertRuleArgsForCall)
+}
+
+func (fake *FakeIptables) InsertRuleCalls(stub func(string, string, int, ...string) error) {
+	fake.insertRuleMutex.Lock()
+	defer fake.insertRuleMutex.Unlock()
+	fake.InsertRuleStub = stub
+}
+
+func (fake *FakeIptables) InsertRuleArgsForCall(i int) (string, string, int, []string) {
+	fake.insertRuleMutex.RLock()
+	defer fake.insertRuleMutex.RUnlock()
+	argsForCall := fake.insertRuleArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeIptables) InsertRuleReturns(result1 error) {
+	fake.insertRuleMutex.Lock()
+	defer fake.insertRuleMutex.Unlock()
+	fake.InsertRuleStub = nil
+	fake.insertRuleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIptables) InsertRuleReturnsOnCall(i int, result1 error) {
+	fake.insertRuleMutex.Lock()
+	defer fake.insertRuleMutex.Unlock()
+	fake.InsertRuleStub = nil
+	if fake.insertRuleReturnsOnCall == nil {
+		fake.insertRuleReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.insertRuleReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIptables) ListRules(arg1 string, arg2 string) ([]string, error) {
+	fake.listRulesMutex.Lock()
+	ret, specificReturn := fake.listRulesReturnsOnCall[len(fake.listRulesArgsForCall)]
+	fake.listRulesArgsForCall = append(fake.listRulesArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("ListRules", []interface{}{arg1, arg2})
+	fake.listRulesMutex.Unlock()
+	if fake.ListRulesStub != nil {
+		return fake.ListRulesStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listRulesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeIptables) ListRulesCallCount() int {
+	fake.listRulesMutex.RLock()
+	defer fake.listRulesMutex.RUnlock()
+	return len(fake.listRulesArgsForCall)
+}
+
+func (fake *FakeIptables) ListRulesCalls(stub func(string, string) ([]string, error)) {
+	fake.listRulesMutex.Lock()
+	defer fake.listRulesMutex.Unlock()
+	fake.ListRulesStub = stub
+}
+
+func (fake *FakeIptables) ListRulesArgsForCall(i int) (string, string) {
+	fake.listRulesMutex.RLock()
+	defer fake.listRulesMutex.RUnlock()
+	argsForCall := fake.listRulesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeIptables) ListRulesReturns(result1 []string, result2 error) {
+	fake.listRulesMutex.Lock()
+	defer fake.listRulesMutex.Unlock()
+	fake.ListRulesStub = nil
+	fake.listRulesReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeIptables) ListRulesReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.listRulesMutex.Lock()
+	defer fake.listRulesMutex.Unlock()
+	fake.ListRulesStub = nil
+	if fake.listRulesReturnsOnCall == nil {
+		fake.listRulesReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.listRulesReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeIptables) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.appendRuleMutex.RLock()
 	defer fake.appendRuleMutex.RUnlock()
+	fake.appendUniqueRuleMutex.RLock()
+	defer fake.appendUniqueRuleMutex.RUnlock()
 	fake.createChainOrFlushIfExistsMutex.RLock()
 	defer fake.createChainOrFlushIfExistsMutex.RUnlock()
+	fake.deleteRuleMutex.RLock()
+	defer fake.deleteRuleMutex.RUnlock()
+	fake.insertRuleMutex.RLock()
+	defer fake.insertRuleMutex.RUnlock()
+	fake.listRulesMutex.RLock()
+	defer fake.listRulesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
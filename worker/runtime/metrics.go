@@ -0,0 +1,376 @@
+package runtime
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	v1 "github.com/containerd/cgroups/stats/v1"
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/api/types"
+	"github.com/containerd/containerd/runtime/linux/runctypes"
+	"github.com/containerd/containerd/runtime/v2/runc/options"
+	"github.com/containerd/typeurl"
+)
+
+// taskCgroupMetrics retrieves the cgroup stats of a task.
+//
+func taskCgroupMetrics(ctx context.Context, task containerd.Task) (*v1.Metrics, error) {
+	metric, err := task.Metrics(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("task metrics: %w", err)
+	}
+
+	return cgroupMetrics(metric)
+}
+
+// execIDs lists the IDs of the processes that were exec'ed in a task (i.e.,
+// those that can be attached to), leaving the init process out.
+//
+func execIDs(ctx context.Context, task containerd.Task) ([]string, error) {
+	procs, err := task.Pids(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("task pids: %w", err)
+	}
+
+	ids := []string{}
+	for _, proc := range procs {
+		if proc.Info == nil {
+			continue
+		}
+
+		details, err := typeurl.UnmarshalAny(proc.Info)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal process details: %w", err)
+		}
+
+		switch d := details.(type) {
+		case *runctypes.ProcessDetails:
+			ids = append(ids, d.ExecID)
+		case *options.ProcessDetails:
+			ids = append(ids, d.ExecID)
+		}
+	}
+
+	return ids, nil
+}
+
+// cgroupMetrics decodes the cgroup stats carried by a task metric.
+//
+func cgroupMetrics(metric *types.Metric) (*v1.Metrics, error) {
+	if metric == nil || metric.Data == nil {
+		return nil, fmt.Errorf("empty task metric")
+	}
+
+	data, err := typeurl.UnmarshalAny(metric.Data)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal metric: %w", err)
+	}
+
+	stats, ok := data.(*v1.Metrics)
+	if !ok {
+		return nil, fmt.Errorf("unexpected metric type %T", data)
+	}
+
+	return stats, nil
+}
+
+// cpuStat converts the cgroup cpuacct stats into garden's representation
+// (all values in nanoseconds).
+//
+func cpuStat(stats *v1.Metrics) garden.ContainerCPUStat {
+	if stats.CPU == nil || stats.CPU.Usage == nil {
+		return garden.ContainerCPUStat{}
+	}
+
+	return garden.ContainerCPUStat{
+		Usage:  stats.CPU.Usage.Total,
+		User:   stats.CPU.Usage.User,
+		System: stats.CPU.Usage.Kernel,
+	}
+}
+
+// memoryStat converts the cgroup memory stats into garden's representation.
+//
+// `TotalUsageTowardLimit` follows Guardian in discounting the inactive page
+// cache, as that is reclaimed before the limit is enforced.
+//
+func memoryStat(stats *v1.Metrics) garden.ContainerMemoryStat {
+	m := stats.Memory
+	if m == nil {
+		return garden.ContainerMemoryStat{}
+	}
+
+	stat := garden.ContainerMemoryStat{
+		ActiveAnon:              m.ActiveAnon,
+		ActiveFile:              m.ActiveFile,
+		Cache:                   m.Cache,
+		HierarchicalMemoryLimit: m.HierarchicalMemoryLimit,
+		InactiveAnon:            m.InactiveAnon,
+		InactiveFile:            m.InactiveFile,
+		MappedFile:              m.MappedFile,
+		Pgfault:                 m.PgFault,
+		Pgmajfault:              m.PgMajFault,
+		Pgpgin:                  m.PgPgIn,
+		Pgpgout:                 m.PgPgOut,
+		Rss:                     m.RSS,
+		TotalActiveAnon:         m.TotalActiveAnon,
+		TotalActiveFile:         m.TotalActiveFile,
+		TotalCache:              m.TotalCache,
+		TotalInactiveAnon:       m.TotalInactiveAnon,
+		TotalInactiveFile:       m.TotalInactiveFile,
+		TotalMappedFile:         m.TotalMappedFile,
+		TotalPgfault:            m.TotalPgFault,
+		TotalPgmajfault:         m.TotalPgMajFault,
+		TotalPgpgin:             m.TotalPgPgIn,
+		TotalPgpgout:            m.TotalPgPgOut,
+		TotalRss:                m.TotalRSS,
+		TotalUnevictable:        m.TotalUnevictable,
+		Unevictable:             m.Unevictable,
+		HierarchicalMemswLimit:  m.HierarchicalSwapLimit,
+	}
+
+	if m.Swap != nil {
+		stat.Swap = m.Swap.Usage
+		stat.TotalSwap = m.Swap.Usage
+	}
+
+	if m.Usage != nil && m.Usage.Usage > m.TotalInactiveFile {
+		stat.TotalUsageTowardLimit = m.Usage.Usage - m.TotalInactiveFile
+	}
+
+	return stat
+}
+
+// memoryPeak returns the maximum memory usage recorded for the cgroup.
+//
+func memoryPeak(stats *v1.Metrics) uint64 {
+	if stats.Memory == nil || stats.Memory.Usage == nil {
+		return 0
+	}
+
+	return stats.Memory.Usage.Max
+}
+
+// pidStat converts the cgroup pids stats into garden's representation.
+//
+func pidStat(stats *v1.Metrics) garden.ContainerPidStat {
+	if stats.Pids == nil {
+		return garden.ContainerPidStat{}
+	}
+
+	return garden.ContainerPidStat{
+		Current: stats.Pids.Current,
+		Max:     stats.Pids.Limit,
+	}
+}
+
+// networkStat sums up the counters of every non-loopback interface in the
+// network namespace of the process with the given pid.
+//
+// Network counters are not part of the cgroup stats collected by the runc
+// shim, so unless those are present, they're read from procfs instead.
+//
+func networkStat(stats *v1.Metrics, pid uint32) (garden.ContainerNetworkStat, error) {
+	var stat garden.ContainerNetworkStat
+
+	if len(stats.Network) > 0 {
+		for _, iface := range stats.Network {
+			if iface.Name == "lo" {
+				continue
+			}
+
+			stat.RxBytes += iface.RxBytes
+			stat.TxBytes += iface.TxBytes
+		}
+
+		return stat, nil
+	}
+
+	if pid == 0 {
+		return stat, nil
+	}
+
+	f, err := os.Open(filepath.Join("/proc", strconv.Itoa(int(pid)), "net", "dev"))
+	if err != nil {
+		return stat, fmt.Errorf("open net dev: %w", err)
+	}
+	defer f.Close()
+
+	return parseNetDev(f)
+}
+
+// parseNetDev parses the contents of `/proc/<pid>/net/dev`, summing the
+// received and transmitted bytes of all interfaces but the loopback one.
+//
+func parseNetDev(r io.Reader) (stat garden.ContainerNetworkStat, err error) {
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), ":", 2)
+		if len(parts) != 2 {
+			// header lines
+			continue
+		}
+
+		if strings.TrimSpace(parts[0]) == "lo" {
+			continue
+		}
+
+		fields := strings.Fields(parts[1])
+		if len(fields) < 9 {
+			err = fmt.Errorf("malformed net dev line: %q", scanner.Text())
+			return
+		}
+
+		var rx, tx uint64
+
+		rx, err = strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			err = fmt.Errorf("parse rx bytes: %w", err)
+			return
+		}
+
+		tx, err = strconv.ParseUint(fields[8], 10, 64)
+		if err != nil {
+			err = fmt.Errorf("parse tx bytes: %w", err)
+			return
+		}
+
+		stat.RxBytes += rx
+		stat.TxBytes += tx
+	}
+
+	err = scanner.Err()
+	return
+}
+
+// diskStatTTL is for how long the disk usage of a rootfs is reused before
+// walking it again. Metrics are collected for every container periodically,
+// and walking a large rootfs on each collection is expensive.
+//
+const diskStatTTL = 30 * time.Second
+
+// rootfsDiskStats caches the disk usage of the rootfses across the
+// `Container`s, which get instantiated on every lookup.
+//
+var rootfsDiskStats = newDiskStatCache(diskStatTTL)
+
+type diskStatEntry struct {
+	stat garden.ContainerDiskStat
+	at   time.Time
+}
+
+// diskStatCache keeps the disk usage of rootfses computed within the last
+// `ttl`.
+//
+type diskStatCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]diskStatEntry
+}
+
+func newDiskStatCache(ttl time.Duration) *diskStatCache {
+	return &diskStatCache{
+		ttl:     ttl,
+		entries: map[string]diskStatEntry{},
+	}
+}
+
+// get returns the disk usage of the tree rooted at `root`, only walking it if
+// it wasn't computed within the ttl.
+//
+// Expired entries get dropped along the way, so that those of containers
+// that are gone don't pile up.
+//
+func (c *diskStatCache) get(root string) (garden.ContainerDiskStat, error) {
+	now := time.Now()
+
+	c.mu.Lock()
+	for path, entry := range c.entries {
+		if now.Sub(entry.at) >= c.ttl {
+			delete(c.entries, path)
+		}
+	}
+
+	entry, found := c.entries[root]
+	c.mu.Unlock()
+
+	if found {
+		return entry.stat, nil
+	}
+
+	stat, err := diskStat(root)
+	if err != nil {
+		return garden.ContainerDiskStat{}, err
+	}
+
+	c.mu.Lock()
+	c.entries[root] = diskStatEntry{stat: stat, at: now}
+	c.mu.Unlock()
+
+	return stat, nil
+}
+
+// diskStat computes the space and inodes taken by the tree rooted at `root`.
+//
+// As rootfses are not shared between containers, all of the usage is
+// accounted as exclusive to the container.
+//
+func diskStat(root string) (garden.ContainerDiskStat, error) {
+	var (
+		bytes, inodes uint64
+		seen          = map[uint64]bool{}
+	)
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			// files may come and go while the container is running
+			if os.IsNotExist(err) {
+				return nil
+			}
+
+			return err
+		}
+
+		st, ok := info.Sys().(*syscall.Stat_t)
+		if !ok {
+			inodes++
+			bytes += uint64(info.Size())
+			return nil
+		}
+
+		if st.Nlink > 1 {
+			if seen[st.Ino] {
+				return nil
+			}
+
+			seen[st.Ino] = true
+		}
+
+		inodes++
+		bytes += uint64(st.Blocks) * 512
+
+		return nil
+	})
+	if err != nil {
+		return garden.ContainerDiskStat{}, fmt.Errorf("walk %s: %w", root, err)
+	}
+
+	return garden.ContainerDiskStat{
+		TotalBytesUsed:      bytes,
+		TotalInodesUsed:     inodes,
+		ExclusiveBytesUsed:  bytes,
+		ExclusiveInodesUsed: inodes,
+	}, nil
+}
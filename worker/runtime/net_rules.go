@@ -0,0 +1,214 @@
+package runtime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/garden"
+	"github.com/containerd/go-cni"
+)
+
+// maxLogPrefixLength is the maximum length of the prefix iptables accepts
+// for the messages of its LOG target.
+//
+const maxLogPrefixLength = 29
+
+// containerIP retrieves the IPv4 address assigned to the interface set up in
+// the network namespace of a container.
+//
+func containerIP(result *cni.CNIResult) string {
+	if result == nil {
+		return ""
+	}
+
+	for _, iface := range result.Interfaces {
+		if iface == nil || iface.Sandbox == "" {
+			continue
+		}
+
+		for _, ipConfig := range iface.IPConfigs {
+			if ipConfig.IP == nil || ipConfig.IP.IsLoopback() || ipConfig.IP.To4() == nil {
+				continue
+			}
+
+			return ipConfig.IP.String()
+		}
+	}
+
+	return ""
+}
+
+// netInRuleSpec builds the `nat` rule that forwards tcp traffic sent to
+// `hostPort` to `containerPort` of the container.
+//
+func netInRuleSpec(handle, containerIP string, hostPort, containerPort uint32) []string {
+	return []string{
+		"-p", "tcp",
+		"--dport", strconv.FormatUint(uint64(hostPort), 10),
+		"-m", "comment", "--comment", handle,
+		"-j", "DNAT",
+		"--to-destination", containerIP + ":" + strconv.FormatUint(uint64(containerPort), 10),
+	}
+}
+
+// forwardsHostPort returns true if `rule`, as listed by `iptables -S`, is a
+// port forwarding rule for `hostPort`.
+//
+func forwardsHostPort(rule string, hostPort uint32) bool {
+	fields := strings.Fields(rule)
+	port := strconv.FormatUint(uint64(hostPort), 10)
+
+	for i := 0; i+1 < len(fields); i++ {
+		if fields[i] == "--dport" && fields[i+1] == port {
+			return true
+		}
+	}
+
+	return false
+}
+
+// netInAcceptRuleSpec builds the `filter` rule that lets traffic forwarded
+// to `containerPort` reach the container.
+//
+func netInAcceptRuleSpec(handle, containerIP string, containerPort uint32) []string {
+	return []string{
+		"-d", containerIP,
+		"-p", "tcp",
+		"--dport", strconv.FormatUint(uint64(containerPort), 10),
+		"-m", "comment", "--comment", handle,
+		"-j", "ACCEPT",
+	}
+}
+
+// netOutRuleSpecs translates a garden NetOutRule into the `filter` rules that
+// accept the matching traffic coming from the container, one for each
+// combination of network and port range.
+//
+// When logging is requested, each of them is followed by a LOG rule with the
+// same matches, so that inserting the rules one after the other at the top
+// of a chain has traffic logged before being accepted.
+//
+func netOutRuleSpecs(handle, containerIP string, rule garden.NetOutRule) ([][]string, error) {
+	var protocol []string
+
+	switch rule.Protocol {
+	case garden.ProtocolAll:
+		if len(rule.Ports) > 0 {
+			return nil, ErrInvalidInput("ports cannot be specified for protocol all")
+		}
+	case garden.ProtocolTCP:
+		protocol = []string{"-p", "tcp"}
+	case garden.ProtocolUDP:
+		protocol = []string{"-p", "udp"}
+	case garden.ProtocolICMP:
+		protocol = []string{"-p", "icmp"}
+
+		if rule.ICMPs != nil {
+			icmpType := strconv.Itoa(int(rule.ICMPs.Type))
+			if rule.ICMPs.Code != nil {
+				icmpType += "/" + strconv.Itoa(int(*rule.ICMPs.Code))
+			}
+
+			protocol = append(protocol, "--icmp-type", icmpType)
+		}
+	default:
+		return nil, ErrInvalidInput(fmt.Sprintf("unknown protocol %d", rule.Protocol))
+	}
+
+	destinations := [][]string{nil}
+	if len(rule.Networks) > 0 {
+		destinations = nil
+
+		for _, network := range rule.Networks {
+			destination, err := networkMatch(network)
+			if err != nil {
+				return nil, err
+			}
+
+			destinations = append(destinations, destination)
+		}
+	}
+
+	ports := [][]string{nil}
+	if len(rule.Ports) > 0 && rule.Protocol != garden.ProtocolICMP {
+		ports = nil
+
+		for _, portRange := range rule.Ports {
+			ports = append(ports, portMatch(portRange))
+		}
+	}
+
+	logged := rule.Log && (rule.Protocol == garden.ProtocolAll || rule.Protocol == garden.ProtocolTCP)
+
+	logPrefix := handle
+	if len(logPrefix) > maxLogPrefixLength-1 {
+		logPrefix = logPrefix[:maxLogPrefixLength-1]
+	}
+
+	var ruleSpecs [][]string
+	for _, destination := range destinations {
+		for _, port := range ports {
+			matches := []string{"-s", containerIP}
+			matches = append(matches, protocol...)
+			matches = append(matches, destination...)
+			matches = append(matches, port...)
+			matches = append(matches, "-m", "comment", "--comment", handle)
+
+			ruleSpecs = append(ruleSpecs, withTarget(matches, "-j", "ACCEPT"))
+
+			if logged {
+				ruleSpecs = append(ruleSpecs, withTarget(matches, "-j", "LOG", "--log-prefix", logPrefix+" "))
+			}
+		}
+	}
+
+	return ruleSpecs, nil
+}
+
+func networkMatch(network garden.IPRange) ([]string, error) {
+	switch {
+	case network.Start == nil && network.End == nil:
+		return nil, ErrInvalidInput("empty network range")
+	case network.End == nil || network.Start.Equal(network.End):
+		return []string{"-d", network.Start.String()}, nil
+	case network.Start == nil:
+		return []string{"-d", network.End.String()}, nil
+	default:
+		return []string{"-m", "iprange", "--dst-range", network.Start.String() + "-" + network.End.String()}, nil
+	}
+}
+
+func portMatch(portRange garden.PortRange) []string {
+	if portRange.End == 0 || portRange.Start == portRange.End {
+		return []string{"--dport", strconv.Itoa(int(portRange.Start))}
+	}
+
+	return []string{"--dport", strconv.Itoa(int(portRange.Start)) + ":" + strconv.Itoa(int(portRange.End))}
+}
+
+func withTarget(matches []string, target ...string) []string {
+	ruleSpec := make([]string, 0, len(matches)+len(target))
+	ruleSpec = append(ruleSpec, matches...)
+	return append(ruleSpec, target...)
+}
+
+// containerRuleSpec parses a rule as listed by iptables (`-A CHAIN <spec>`),
+// returning its spec if it was set up for the container with the given
+// handle.
+//
+func containerRuleSpec(rule, chain, handle string) ([]string, bool) {
+	fields := strings.Fields(rule)
+	if len(fields) < 2 || fields[0] != "-A" || fields[1] != chain {
+		return nil, false
+	}
+
+	ruleSpec := fields[2:]
+	for i := 0; i < len(ruleSpec)-1; i++ {
+		if ruleSpec[i] == "--comment" && strings.Trim(ruleSpec[i+1], `"`) == handle {
+			return ruleSpec, true
+		}
+	}
+
+	return nil, false
+}
@@ -3,6 +3,7 @@ package runtime
 import (
 	"context"
 
+	"code.cloudfoundry.org/garden"
 	"github.com/containerd/containerd"
 	"github.com/opencontainers/runtime-spec/specs-go"
 )
@@ -20,11 +21,29 @@ type Network interface {
 	//
 	SetupRestrictedNetworks() (err error)
 
-	// Add adds a task to the network.
+	// SetupPortForwarding sets up the rules through which traffic sent to
+	// ports of the host gets forwarded to containers.
 	//
-	Add(ctx context.Context, task containerd.Task) (err error)
+	SetupPortForwarding() (err error)
 
-	// Removes a task from the network.
+	// Add adds a task to the network, returning the IP address assigned to
+	// it.
+	//
+	Add(ctx context.Context, task containerd.Task) (ip string, err error)
+
+	// Removes a task from the network, along with any port forwarding or
+	// egress rules set up for it.
 	//
 	Remove(ctx context.Context, task containerd.Task) (err error)
+
+	// NetIn forwards the traffic sent to `hostPort` on the host to
+	// `containerPort` of the container with the given handle and IP.
+	//
+	NetIn(handle, containerIP string, hostPort, containerPort uint32) (err error)
+
+	// NetOut allows the container with the given handle and IP to reach the
+	// destinations described by `rule`, even if they fall into a restricted
+	// network.
+	//
+	NetOut(handle, containerIP string, rule garden.NetOutRule) (err error)
 }
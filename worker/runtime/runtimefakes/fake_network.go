@@ -5,22 +5,52 @@ import (
 	"context"
 	"sync"
 
+	"code.cloudfoundry.org/garden"
 	"github.com/concourse/concourse/worker/runtime"
 	"github.com/containerd/containerd"
 	specs "github.com/opencontainers/runtime-spec/specs-go"
 )
 
 type FakeNetwork struct {
-	AddStub        func(context.Context, containerd.Task) error
+	AddStub        func(context.Context, containerd.Task) (string, error)
 	addMutex       sync.RWMutex
 	addArgsForCall []struct {
 		arg1 context.Context
 		arg2 containerd.Task
 	}
 	addReturns struct {
-		result1 error
+		result1 string
+		result2 error
 	}
 	addReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	NetInStub        func(string, string, uint32, uint32) error
+	netInMutex       sync.RWMutex
+	netInArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 uint32
+		arg4 uint32
+	}
+	netInReturns struct {
+		result1 error
+	}
+	netInReturnsOnCall map[int]struct {
+		result1 error
+	}
+	NetOutStub        func(string, string, garden.NetOutRule) error
+	netOutMutex       sync.RWMutex
+	netOutArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 garden.NetOutRule
+	}
+	netOutReturns struct {
+		result1 error
+	}
+	netOutReturnsOnCall map[int]struct {
 		result1 error
 	}
 	RemoveStub        func(context.Context, containerd.Task) error
@@ -48,6 +78,16 @@ type FakeNetwork struct {
 		result1 []specs.Mount
 		result2 error
 	}
+	SetupPortForwardingStub        func() error
+	setupPortForwardingMutex       sync.RWMutex
+	setupPortForwardingArgsForCall []struct {
+	}
+	setupPortForwardingReturns struct {
+		result1 error
+	}
+	setupPortForwardingReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SetupRestrictedNetworksStub        func() error
 	setupRestrictedNetworksMutex       sync.RWMutex
 	setupRestrictedNetworksArgsForCall []struct {
@@ -62,7 +102,7 @@ type FakeNetwork struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeNetwork) Add(arg1 context.Context, arg2 containerd.Task) error {
+func (fake *FakeNetwork) Add(arg1 context.Context, arg2 containerd.Task) (string, error) {
 	fake.addMutex.Lock()
 	ret, specificReturn := fake.addReturnsOnCall[len(fake.addArgsForCall)]
 	fake.addArgsForCall = append(fake.addArgsForCall, struct {
@@ -75,10 +115,10 @@ func (fake *FakeNetwork) Add(arg1 context.Context, arg2 containerd.Task) error {
 		return fake.AddStub(arg1, arg2)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
 	fakeReturns := fake.addReturns
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *FakeNetwork) AddCallCount() int {
@@ -87,7 +127,7 @@ func (fake *FakeNetwork) AddCallCount() int {
 	return len(fake.addArgsForCall)
 }
 
-func (fake *FakeNetwork) AddCalls(stub func(context.Context, containerd.Task) error) {
+func (fake *FakeNetwork) AddCalls(stub func(context.Context, containerd.Task) (string, error)) {
 	fake.addMutex.Lock()
 	defer fake.addMutex.Unlock()
 	fake.AddStub = stub
@@ -100,25 +140,153 @@ func (fake *FakeNetwork) AddArgsForCall(i int) (context.Context, containerd.Task
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeNetwork) AddReturns(result1 error) {
+func (fake *FakeNetwork) AddReturns(result1 string, result2 error) {
 	fake.addMutex.Lock()
 	defer fake.addMutex.Unlock()
 	fake.AddStub = nil
 	fake.addReturns = struct {
-		result1 error
-	}{result1}
+		result1 string
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeNetwork) AddReturnsOnCall(i int, result1 error) {
+func (fake *FakeNetwork) AddReturnsOnCall(i int, result1 string, result2 error) {
 	fake.addMutex.Lock()
 	defer fake.addMutex.Unlock()
 	fake.AddStub = nil
 	if fake.addReturnsOnCall == nil {
 		fake.addReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 string
+			result2 error
 		})
 	}
 	fake.addReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNetwork) NetIn(arg1 string, arg2 string, arg3 uint32, arg4 uint32) error {
+	fake.netInMutex.Lock()
+	ret, specificReturn := fake.netInReturnsOnCall[len(fake.netInArgsForCall)]
+	fake.netInArgsForCall = append(fake.netInArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 uint32
+		arg4 uint32
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("NetIn", []interface{}{arg1, arg2, arg3, arg4})
+	fake.netInMutex.Unlock()
+	if fake.NetInStub != nil {
+		return fake.NetInStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.netInReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeNetwork) NetInCallCount() int {
+	fake.netInMutex.RLock()
+	defer fake.netInMutex.RUnlock()
+	return len(fake.netInArgsForCall)
+}
+
+func (fake *FakeNetwork) NetInCalls(stub func(string, string, uint32, uint32) error) {
+	fake.netInMutex.Lock()
+	defer fake.netInMutex.Unlock()
+	fake.NetInStub = stub
+}
+
+func (fake *FakeNetwork) NetInArgsForCall(i int) (string, string, uint32, uint32) {
+	fake.netInMutex.RLock()
+	defer fake.netInMutex.RUnlock()
+	argsForCall := fake.netInArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeNetwork) NetInReturns(result1 error) {
+	fake.netInMutex.Lock()
+	defer fake.netInMutex.Unlock()
+	fake.NetInStub = nil
+	fake.netInReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNetwork) NetInReturnsOnCall(i int, result1 error) {
+	fake.netInMutex.Lock()
+	defer fake.netInMutex.Unlock()
+	fake.NetInStub = nil
+	if fake.netInReturnsOnCall == nil {
+		fake.netInReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.netInReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNetwork) NetOut(arg1 string, arg2 string, arg3 garden.NetOutRule) error {
+	fake.netOutMutex.Lock()
+	ret, specificReturn := fake.netOutReturnsOnCall[len(fake.netOutArgsForCall)]
+	fake.netOutArgsForCall = append(fake.netOutArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 garden.NetOutRule
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("NetOut", []interface{}{arg1, arg2, arg3})
+	fake.netOutMutex.Unlock()
+	if fake.NetOutStub != nil {
+		return fake.NetOutStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.netOutReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeNetwork) NetOutCallCount() int {
+	fake.netOutMutex.RLock()
+	defer fake.netOutMutex.RUnlock()
+	return len(fake.netOutArgsForCall)
+}
+
+func (fake *FakeNetwork) NetOutCalls(stub func(string, string, garden.NetOutRule) error) {
+	fake.netOutMutex.Lock()
+	defer fake.netOutMutex.Unlock()
+	fake.NetOutStub = stub
+}
+
+func (fake *FakeNetwork) NetOutArgsForCall(i int) (string, string, garden.NetOutRule) {
+	fake.netOutMutex.RLock()
+	defer fake.netOutMutex.RUnlock()
+	argsForCall := fake.netOutArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeNetwork) NetOutReturns(result1 error) {
+	fake.netOutMutex.Lock()
+	defer fake.netOutMutex.Unlock()
+	fake.NetOutStub = nil
+	fake.netOutReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNetwork) NetOutReturnsOnCall(i int, result1 error) {
+	fake.netOutMutex.Lock()
+	defer fake.netOutMutex.Unlock()
+	fake.NetOutStub = nil
+	if fake.netOutReturnsOnCall == nil {
+		fake.netOutReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.netOutReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
@@ -247,6 +415,58 @@ func (fake *FakeNetwork) SetupMountsReturnsOnCall(i int, result1 []specs.Mount,
 	}{result1, result2}
 }
 
+func (fake *FakeNetwork) SetupPortForwarding() error {
+	fake.setupPortForwardingMutex.Lock()
+	ret, specificReturn := fake.setupPortForwardingReturnsOnCall[len(fake.setupPortForwardingArgsForCall)]
+	fake.setupPortForwardingArgsForCall = append(fake.setupPortForwardingArgsForCall, struct {
+	}{})
+	fake.recordInvocation("SetupPortForwarding", []interface{}{})
+	fake.setupPortForwardingMutex.Unlock()
+	if fake.SetupPortForwardingStub != nil {
+		return fake.SetupPortForwardingStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.setupPortForwardingReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeNetwork) SetupPortForwardingCallCount() int {
+	fake.setupPortForwardingMutex.RLock()
+	defer fake.setupPortForwardingMutex.RUnlock()
+	return len(fake.setupPortForwardingArgsForCall)
+}
+
+func (fake *FakeNetwork) SetupPortForwardingCalls(stub func() error) {
+	fake.setupPortForwardingMutex.Lock()
+	defer fake.setupPortForwardingMutex.Unlock()
+	fake.SetupPortForwardingStub = stub
+}
+
+func (fake *FakeNetwork) SetupPortForwardingReturns(result1 error) {
+	fake.setupPortForwardingMutex.Lock()
+	defer fake.setupPortForwardingMutex.Unlock()
+	fake.SetupPortForwardingStub = nil
+	fake.setupPortForwardingReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNetwork) SetupPortForwardingReturnsOnCall(i int, result1 error) {
+	fake.setupPortForwardingMutex.Lock()
+	defer fake.setupPortForwardingMutex.Unlock()
+	fake.SetupPortForwardingStub = nil
+	if fake.setupPortForwardingReturnsOnCall == nil {
+		fake.setupPortForwardingReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setupPortForwardingReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeNetwork) SetupRestrictedNetworks() error {
 	fake.setupRestrictedNetworksMutex.Lock()
 	ret, specificReturn := fake.setupRestrictedNetworksReturnsOnCall[len(fake.setupRestrictedNetworksArgsForCall)]
@@ -304,10 +524,16 @@ func (fake *FakeNetwork) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.addMutex.RLock()
 	defer fake.addMutex.RUnlock()
+	fake.netInMutex.RLock()
+	defer fake.netInMutex.RUnlock()
+	fake.netOutMutex.RLock()
+	defer fake.netOutMutex.RUnlock()
 	fake.removeMutex.RLock()
 	defer fake.removeMutex.RUnlock()
 	fake.setupMountsMutex.RLock()
 	defer fake.setupMountsMutex.RUnlock()
+	fake.setupPortForwardingMutex.RLock()
+	defer fake.setupPortForwardingMutex.RUnlock()
 	fake.setupRestrictedNetworksMutex.RLock()
 	defer fake.setupRestrictedNetworksMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -0,0 +1,159 @@
+package runtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/worker/runtime/spec"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+const (
+	// SecurityProfileKey is the garden property through which a container
+	// selects the security profile to be applied to it.
+	//
+	SecurityProfileKey = "security-profile"
+
+	// DefaultSecurityProfile is the name of the security profile applied to
+	// the containers that don't select any.
+	//
+	DefaultSecurityProfile = "default"
+)
+
+// SecurityProfile is a named set of privileges, defined by the operator of a
+// worker, that unprivileged containers can select so that they don't have to
+// be privileged just to perform a few specific operations.
+//
+type SecurityProfile struct {
+	// Capabilities granted in addition to the ones of any unprivileged
+	// container (e.g. `CAP_NET_ADMIN`).
+	//
+	Capabilities []string `json:"capabilities,omitempty"`
+
+	// Seccomp profile replacing the default one.
+	//
+	Seccomp *specs.LinuxSeccomp `json:"seccomp,omitempty"`
+
+	// AppArmor profile, already loaded on the host, confining the container
+	// instead of the default one.
+	//
+	AppArmor string `json:"apparmor,omitempty"`
+}
+
+// LoadSecurityProfiles reads the security profiles defined in a directory,
+// where each `<name>.json` file holds the definition of the profile `name`.
+//
+func LoadSecurityProfiles(dir string) (map[string]SecurityProfile, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("glob: %w", err)
+	}
+
+	profiles := make(map[string]SecurityProfile, len(paths))
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		if !atc.ValidSecurityProfileName(name) {
+			return nil, ErrInvalidInput(fmt.Sprintf("invalid security profile name '%s'", name))
+		}
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read file %s: %w", path, err)
+		}
+
+		var profile SecurityProfile
+		err = json.Unmarshal(content, &profile)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal %s: %w", path, err)
+		}
+
+		err = profile.validate()
+		if err != nil {
+			return nil, fmt.Errorf("security profile '%s': %w", name, err)
+		}
+
+		profiles[name] = profile
+	}
+
+	return profiles, nil
+}
+
+func (p SecurityProfile) validate() error {
+	for _, capability := range p.Capabilities {
+		if !knownCapability(capability) {
+			return ErrInvalidInput(fmt.Sprintf("unknown capability '%s'", capability))
+		}
+	}
+
+	if p.Seccomp != nil && p.Seccomp.DefaultAction == "" {
+		return ErrInvalidInput("seccomp profile is missing a defaultAction")
+	}
+
+	return nil
+}
+
+// knownCapability returns whether the capability is one that privileged
+// containers are granted, which are the only ones a profile can add.
+//
+func knownCapability(capability string) bool {
+	for _, known := range spec.PrivilegedContainerCapabilities.Bounding {
+		if capability == known {
+			return true
+		}
+	}
+
+	return false
+}
+
+// apply applies the security profile to the spec of an unprivileged
+// container.
+//
+func (p SecurityProfile) apply(oci *specs.Spec) {
+	if p.Seccomp != nil {
+		oci.Linux.Seccomp = p.Seccomp
+	}
+
+	if p.AppArmor != "" {
+		oci.Process.ApparmorProfile = p.AppArmor
+	}
+
+	if len(p.Capabilities) == 0 || oci.Process.Capabilities == nil {
+		return
+	}
+
+	// the capability sets of a spec share their backing arrays with the
+	// default ones, so they must be copied rather than appended to.
+	//
+	capabilities := *oci.Process.Capabilities
+	capabilities.Bounding = withCapabilities(capabilities.Bounding, p.Capabilities)
+	capabilities.Effective = withCapabilities(capabilities.Effective, p.Capabilities)
+	capabilities.Inheritable = withCapabilities(capabilities.Inheritable, p.Capabilities)
+	capabilities.Permitted = withCapabilities(capabilities.Permitted, p.Capabilities)
+
+	oci.Process.Capabilities = &capabilities
+}
+
+func withCapabilities(set, extra []string) []string {
+	result := make([]string, len(set), len(set)+len(extra))
+	copy(result, set)
+
+	seen := make(map[string]bool, len(set))
+	for _, capability := range set {
+		seen[capability] = true
+	}
+
+	for _, capability := range extra {
+		if seen[capability] {
+			continue
+		}
+
+		seen[capability] = true
+		result = append(result, capability)
+	}
+
+	return result
+}
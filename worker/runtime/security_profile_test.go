@@ -0,0 +1,89 @@
+package runtime_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/concourse/concourse/worker/runtime"
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type SecurityProfileSuite struct {
+	suite.Suite
+	*require.Assertions
+
+	dir string
+}
+
+func (s *SecurityProfileSuite) SetupTest() {
+	var err error
+
+	s.dir, err = ioutil.TempDir("", "bcknd-security-profiles")
+	s.NoError(err)
+}
+
+func (s *SecurityProfileSuite) TearDownTest() {
+	os.RemoveAll(s.dir)
+}
+
+func (s *SecurityProfileSuite) writeProfile(name, content string) {
+	err := ioutil.WriteFile(filepath.Join(s.dir, name), []byte(content), 0644)
+	s.NoError(err)
+}
+
+func (s *SecurityProfileSuite) TestLoadSecurityProfiles() {
+	s.writeProfile("network-tools.json", `{"capabilities": ["CAP_NET_ADMIN", "CAP_NET_RAW"]}`)
+	s.writeProfile("strict.json", `{"seccomp": {"defaultAction": "SCMP_ACT_KILL"}, "apparmor": "strict"}`)
+	s.writeProfile("README.md", `not a profile`)
+
+	profiles, err := runtime.LoadSecurityProfiles(s.dir)
+	s.NoError(err)
+
+	s.Equal(map[string]runtime.SecurityProfile{
+		"network-tools": {
+			Capabilities: []string{"CAP_NET_ADMIN", "CAP_NET_RAW"},
+		},
+		"strict": {
+			Seccomp:  &specs.LinuxSeccomp{DefaultAction: specs.ActKill},
+			AppArmor: "strict",
+		},
+	}, profiles)
+}
+
+func (s *SecurityProfileSuite) TestLoadSecurityProfilesInvalidName() {
+	s.writeProfile("Network Tools.json", `{}`)
+
+	_, err := runtime.LoadSecurityProfiles(s.dir)
+	s.EqualError(err, "invalid security profile name 'Network Tools'")
+}
+
+func (s *SecurityProfileSuite) TestLoadSecurityProfilesInvalidJSON() {
+	s.writeProfile("broken.json", `{`)
+
+	_, err := runtime.LoadSecurityProfiles(s.dir)
+	s.Error(err)
+}
+
+func (s *SecurityProfileSuite) TestLoadSecurityProfilesInvalidCapability() {
+	s.writeProfile("network-tools.json", `{"capabilities": ["net_admin"]}`)
+
+	_, err := runtime.LoadSecurityProfiles(s.dir)
+	s.EqualError(err, "security profile 'network-tools': unknown capability 'net_admin'")
+}
+
+func (s *SecurityProfileSuite) TestLoadSecurityProfilesMisspelledCapability() {
+	s.writeProfile("network-tools.json", `{"capabilities": ["CAP_NET_ADMN"]}`)
+
+	_, err := runtime.LoadSecurityProfiles(s.dir)
+	s.EqualError(err, "security profile 'network-tools': unknown capability 'CAP_NET_ADMN'")
+}
+
+func (s *SecurityProfileSuite) TestLoadSecurityProfilesSeccompWithoutDefaultAction() {
+	s.writeProfile("strict.json", `{"seccomp": {"syscalls": []}}`)
+
+	_, err := runtime.LoadSecurityProfiles(s.dir)
+	s.EqualError(err, "security profile 'strict': seccomp profile is missing a defaultAction")
+}
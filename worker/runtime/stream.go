@@ -0,0 +1,506 @@
+package runtime
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"golang.org/x/sys/unix"
+)
+
+// resolveInRoot are the flags used for resolving every path inside of a
+// container: paths are resolved as if the directory they're relative to was
+// the root of the filesystem, so that symlinks, absolute or not, are followed
+// the way the processes of the container see them and can't point outside of
+// it, and magic links such as `/proc/self/root` are not traversed at all.
+//
+// As the resolution is performed by the kernel in a single step, a process
+// in the container can't have a path escape by swapping one of its
+// components for a symlink after it was checked.
+//
+const resolveInRoot = unix.RESOLVE_IN_ROOT | unix.RESOLVE_NO_MAGICLINKS
+
+// maxOpenRetries is the number of times opening a path is retried when the
+// kernel reports that a concurrent rename could have affected its resolution.
+//
+const maxOpenRetries = 128
+
+// hostPath resolves a path inside a container to the directory on the host
+// it lives under, that is, either the source of the bind mount it falls into
+// or the container's rootfs, and the path relative to that directory.
+//
+func hostPath(spec *specs.Spec, path string) (string, string, error) {
+	if spec == nil || spec.Root == nil || spec.Root.Path == "" {
+		return "", "", ErrInvalidInput("container has no rootfs")
+	}
+
+	path = filepath.Clean("/" + path)
+
+	var mount *specs.Mount
+	for i, m := range spec.Mounts {
+		if path != m.Destination && !strings.HasPrefix(path, strings.TrimSuffix(m.Destination, "/")+"/") {
+			continue
+		}
+
+		if mount == nil || len(m.Destination) > len(mount.Destination) {
+			mount = &spec.Mounts[i]
+		}
+	}
+
+	if mount == nil {
+		return spec.Root.Path, path, nil
+	}
+
+	if mount.Type != "bind" {
+		return "", "", ErrInvalidInput(fmt.Sprintf("%s is not on a bind mount", path))
+	}
+
+	rel, err := filepath.Rel(mount.Destination, path)
+	if err != nil {
+		return "", "", fmt.Errorf("relative path: %w", err)
+	}
+
+	return mount.Source, rel, nil
+}
+
+// openRoot opens the directory on the host that paths inside of a container
+// are resolved against.
+//
+func openRoot(root string) (*os.File, error) {
+	return os.OpenFile(root, unix.O_RDONLY|unix.O_DIRECTORY|unix.O_CLOEXEC, 0)
+}
+
+// openIn opens `path` relative to the directory `dir`, resolving it with
+// `openat2(2)` as if `dir` was the root of the filesystem.
+//
+// There is no fallback for kernels older than Linux 5.6, which lack
+// `openat2(2)`, as resolving paths one component at a time can't be made
+// safe against a process of the container swapping them concurrently.
+//
+func openIn(dir *os.File, path string, flags int, mode uint32) (*os.File, error) {
+	how := unix.OpenHow{
+		Flags:   uint64(flags | unix.O_CLOEXEC),
+		Mode:    uint64(mode),
+		Resolve: resolveInRoot,
+	}
+
+	var (
+		fd  int
+		err error
+	)
+
+	for i := 0; i < maxOpenRetries; i++ {
+		fd, err = unix.Openat2(int(dir.Fd()), path, &how)
+		if err != unix.EAGAIN && err != unix.EINTR {
+			break
+		}
+	}
+
+	if err == unix.ENOSYS {
+		return nil, ErrOpenat2NotSupported
+	}
+
+	if err != nil {
+		return nil, &os.PathError{Op: "openat2", Path: path, Err: err}
+	}
+
+	return os.NewFile(uintptr(fd), filepath.Join(dir.Name(), path)), nil
+}
+
+// mkdirAllIn creates the directory `path` under `dir` along with any missing
+// parents, one component at a time, returning it opened.
+//
+// Each directory is opened by resolving its whole path from `dir`, so that a
+// symlink among the parents is followed within `dir` rather than within the
+// directory it was found in.
+//
+func mkdirAllIn(dir *os.File, path string) (*os.File, error) {
+	current, err := openIn(dir, ".", unix.O_RDONLY|unix.O_DIRECTORY, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	created := "."
+	for _, component := range strings.Split(filepath.Clean("/"+path), "/") {
+		if component == "" {
+			continue
+		}
+
+		err = unix.Mkdirat(int(current.Fd()), component, 0755)
+		if err != nil && err != unix.EEXIST {
+			current.Close()
+			return nil, &os.PathError{Op: "mkdirat", Path: component, Err: err}
+		}
+
+		created = filepath.Join(created, component)
+
+		next, err := openIn(dir, created, unix.O_RDONLY|unix.O_DIRECTORY, 0)
+		current.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		current = next
+	}
+
+	return current, nil
+}
+
+// idMapper translates user and group IDs between a container and the host
+// according to the container's user namespace mappings.
+//
+type idMapper struct {
+	uids, gids []specs.LinuxIDMapping
+}
+
+func newIDMapper(spec *specs.Spec) idMapper {
+	if spec == nil || spec.Linux == nil {
+		return idMapper{}
+	}
+
+	return idMapper{
+		uids: spec.Linux.UIDMappings,
+		gids: spec.Linux.GIDMappings,
+	}
+}
+
+func (m idMapper) hostIDs(uid, gid int) (int, int) {
+	return toHostID(uid, m.uids), toHostID(gid, m.gids)
+}
+
+func (m idMapper) containerIDs(uid, gid int) (int, int) {
+	return toContainerID(uid, m.uids), toContainerID(gid, m.gids)
+}
+
+func toHostID(id int, mappings []specs.LinuxIDMapping) int {
+	for _, m := range mappings {
+		if uint32(id) >= m.ContainerID && uint32(id)-m.ContainerID < m.Size {
+			return int(m.HostID + uint32(id) - m.ContainerID)
+		}
+	}
+
+	return id
+}
+
+func toContainerID(id int, mappings []specs.LinuxIDMapping) int {
+	for _, m := range mappings {
+		if uint32(id) >= m.HostID && uint32(id)-m.HostID < m.Size {
+			return int(m.ContainerID + uint32(id) - m.HostID)
+		}
+	}
+
+	return id
+}
+
+// extractTar extracts a tar stream into the directory `dest`, making the
+// host user and group identified by `uid` and `gid` the owners of every
+// entry, just like if the archive was extracted by that user.
+//
+// Every entry is created and modified through file descriptors obtained
+// relative to `dest`, so that none of them can be redirected outside of it.
+//
+func extractTar(src io.Reader, dest *os.File, uid, gid int) error {
+	tarReader := tar.NewReader(src)
+
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("next tar entry: %w", err)
+		}
+
+		err = extractTarEntry(tarReader, header, dest, uid, gid)
+		if err != nil {
+			return fmt.Errorf("extract %s: %w", header.Name, err)
+		}
+	}
+}
+
+func extractTarEntry(src io.Reader, header *tar.Header, dest *os.File, uid, gid int) error {
+	name := filepath.Clean("/" + header.Name)
+	if name == "/" {
+		return nil
+	}
+
+	parent, err := mkdirAllIn(dest, filepath.Dir(name))
+	if err != nil {
+		return err
+	}
+
+	defer parent.Close()
+
+	base := filepath.Base(name)
+
+	switch header.Typeflag {
+	case tar.TypeDir:
+		err = removeSymlinkAt(parent, base)
+		if err != nil {
+			return err
+		}
+
+		err = unix.Mkdirat(int(parent.Fd()), base, 0755)
+		if err != nil && err != unix.EEXIST {
+			return &os.PathError{Op: "mkdirat", Path: base, Err: err}
+		}
+
+		dir, err := openIn(parent, base, unix.O_RDONLY|unix.O_DIRECTORY, 0)
+		if err != nil {
+			return err
+		}
+
+		defer dir.Close()
+
+		return setAttributes(dir, header, uid, gid)
+
+	case tar.TypeReg, tar.TypeRegA:
+		err = removeSymlinkAt(parent, base)
+		if err != nil {
+			return err
+		}
+
+		// non-blocking, so that a fifo planted in place of the file can't
+		// block the extraction.
+		file, err := openIn(parent, base, unix.O_CREAT|unix.O_TRUNC|unix.O_WRONLY|unix.O_NOFOLLOW|unix.O_NONBLOCK, 0600)
+		if err != nil {
+			return err
+		}
+
+		defer file.Close()
+
+		info, err := file.Stat()
+		if err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return fmt.Errorf("%s is not a regular file", base)
+		}
+
+		_, err = io.Copy(file, src)
+		if err != nil {
+			return fmt.Errorf("copy: %w", err)
+		}
+
+		return setAttributes(file, header, uid, gid)
+
+	case tar.TypeSymlink:
+		err = unix.Unlinkat(int(parent.Fd()), base, 0)
+		if err != nil && err != unix.ENOENT {
+			return &os.PathError{Op: "unlinkat", Path: base, Err: err}
+		}
+
+		err = unix.Symlinkat(header.Linkname, int(parent.Fd()), base)
+		if err != nil {
+			return &os.PathError{Op: "symlinkat", Path: base, Err: err}
+		}
+
+		err = unix.Fchownat(int(parent.Fd()), base, uid, gid, unix.AT_SYMLINK_NOFOLLOW)
+		if err != nil {
+			return &os.PathError{Op: "fchownat", Path: base, Err: err}
+		}
+
+		return nil
+
+	case tar.TypeLink:
+		target := filepath.Clean("/" + header.Linkname)
+
+		targetParent, err := openIn(dest, filepath.Dir(target), unix.O_RDONLY|unix.O_DIRECTORY, 0)
+		if err != nil {
+			return err
+		}
+
+		defer targetParent.Close()
+
+		err = unix.Linkat(int(targetParent.Fd()), filepath.Base(target), int(parent.Fd()), base, 0)
+		if err != nil {
+			return &os.PathError{Op: "linkat", Path: base, Err: err}
+		}
+
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported entry type (%c)", header.Typeflag)
+	}
+}
+
+// setAttributes sets the owner, permissions and times of an extracted entry.
+//
+func setAttributes(file *os.File, header *tar.Header, uid, gid int) error {
+	err := file.Chown(uid, gid)
+	if err != nil {
+		return err
+	}
+
+	// must be done after chown, as it clears the setuid and setgid bits
+	err = file.Chmod(header.FileInfo().Mode())
+	if err != nil {
+		return err
+	}
+
+	accessTime := header.AccessTime
+	if accessTime.Before(header.ModTime) {
+		accessTime = header.ModTime
+	}
+
+	err = unix.Futimes(int(file.Fd()), []unix.Timeval{
+		unix.NsecToTimeval(accessTime.UnixNano()),
+		unix.NsecToTimeval(header.ModTime.UnixNano()),
+	})
+	if err != nil {
+		return &os.PathError{Op: "futimes", Path: file.Name(), Err: err}
+	}
+
+	return nil
+}
+
+func removeSymlinkAt(dir *os.File, name string) error {
+	var stat unix.Stat_t
+
+	err := unix.Fstatat(int(dir.Fd()), name, &stat, unix.AT_SYMLINK_NOFOLLOW)
+	if err != nil {
+		if err == unix.ENOENT {
+			return nil
+		}
+
+		return &os.PathError{Op: "fstatat", Path: name, Err: err}
+	}
+
+	if stat.Mode&unix.S_IFMT != unix.S_IFLNK {
+		return nil
+	}
+
+	err = unix.Unlinkat(int(dir.Fd()), name, 0)
+	if err != nil {
+		return &os.PathError{Op: "unlinkat", Path: name, Err: err}
+	}
+
+	return nil
+}
+
+// compressTar writes a tar stream of the tree rooted at `src`, an `O_PATH`
+// file descriptor, to `dest`, naming its root entry `name`.
+//
+// Following the semantics of `tar`, streaming the contents of a directory
+// rather than the directory itself is done by naming it `.`, so that the
+// entries are named `./...`.
+//
+// The tree is traversed through file descriptors, each entry being opened
+// relative to its parent without following symlinks, so that it can't be
+// redirected outside of `src` while it's being streamed.
+//
+func compressTar(dest io.Writer, src *os.File, name string, ids idMapper) error {
+	tarWriter := tar.NewWriter(dest)
+
+	err := addTarEntry(tarWriter, src, name, ids)
+	if err != nil {
+		return err
+	}
+
+	return tarWriter.Close()
+}
+
+func addTarEntry(tarWriter *tar.Writer, entry *os.File, name string, ids idMapper) error {
+	info, err := entry.Stat()
+	if err != nil {
+		return err
+	}
+
+	var link string
+	if info.Mode()&os.ModeSymlink != 0 {
+		link, err = readlinkFd(entry)
+		if err != nil {
+			return err
+		}
+	}
+
+	header, err := tar.FileInfoHeader(info, link)
+	if err != nil {
+		return err
+	}
+
+	header.Name = name
+	if info.IsDir() {
+		header.Name += "/"
+	}
+
+	header.Uid, header.Gid = ids.containerIDs(header.Uid, header.Gid)
+	header.Uname, header.Gname = "", ""
+
+	err = tarWriter.WriteHeader(header)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case info.IsDir():
+		dir, err := openIn(entry, ".", unix.O_RDONLY|unix.O_DIRECTORY, 0)
+		if err != nil {
+			return err
+		}
+
+		defer dir.Close()
+
+		children, err := dir.Readdirnames(-1)
+		if err != nil {
+			return err
+		}
+
+		sort.Strings(children)
+
+		for _, child := range children {
+			childEntry, err := openIn(dir, child, unix.O_PATH|unix.O_NOFOLLOW, 0)
+			if err != nil {
+				return err
+			}
+
+			err = addTarEntry(tarWriter, childEntry, name+"/"+child, ids)
+			childEntry.Close()
+			if err != nil {
+				return err
+			}
+		}
+
+	case info.Mode().IsRegular():
+		file, err := reopen(entry, unix.O_RDONLY)
+		if err != nil {
+			return err
+		}
+
+		defer file.Close()
+
+		_, err = io.CopyN(tarWriter, file, header.Size)
+		if err != nil {
+			return fmt.Errorf("copy %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// reopen opens the file that an `O_PATH` file descriptor refers to, which,
+// as opposed to opening it by name again, can't end up opening something
+// else.
+//
+func reopen(file *os.File, flags int) (*os.File, error) {
+	return os.OpenFile("/proc/self/fd/"+strconv.Itoa(int(file.Fd())), flags|unix.O_CLOEXEC, 0)
+}
+
+func readlinkFd(file *os.File) (string, error) {
+	buf := make([]byte, unix.PathMax)
+
+	n, err := unix.Readlinkat(int(file.Fd()), "", buf)
+	if err != nil {
+		return "", &os.PathError{Op: "readlinkat", Path: file.Name(), Err: err}
+	}
+
+	return string(buf[:n]), nil
+}
@@ -16,6 +16,7 @@ func TestSuite(t *testing.T) {
 	suite.Run(t, &ProcessKillerSuite{Assertions: require.New(t)})
 	suite.Run(t, &ProcessSuite{Assertions: require.New(t)})
 	suite.Run(t, &RootfsManagerSuite{Assertions: require.New(t)})
+	suite.Run(t, &SecurityProfileSuite{Assertions: require.New(t)})
 	suite.Run(t, &UserNamespaceSuite{Assertions: require.New(t)})
 	suite.Run(t, &TimeoutLockSuite{Assertions: require.New(t)})
 	suite.Run(t, &ResolveconfParserSuite{Assertions: require.New(t)})
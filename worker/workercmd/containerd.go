@@ -3,6 +3,7 @@
 package workercmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -17,6 +18,7 @@ import (
 	concourseCmd "github.com/concourse/concourse/cmd"
 	"github.com/concourse/concourse/worker/runtime"
 	"github.com/concourse/concourse/worker/runtime/libcontainerd"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/tedsuo/ifrit"
 	"github.com/tedsuo/ifrit/grouper"
 )
@@ -25,12 +27,17 @@ import (
 func containerdGardenServerRunner(
 	logger lager.Logger,
 	bindAddr,
-	containerdAddr string,
+	containerdAddr,
+	workDir string,
 	requestTimeout time.Duration,
 	dnsServers []string,
 	networkPool string,
 	maxContainers int,
 	restrictedNetworks []string,
+	unprivilegedOnly bool,
+	seccompProfilePath string,
+	apparmorProfile string,
+	securityProfilesDir string,
 ) (ifrit.Runner, error) {
 	const (
 		graceTime = 0
@@ -66,8 +73,29 @@ func containerdGardenServerRunner(
 		runtime.WithNetwork(cniNetwork),
 		runtime.WithRequestTimeout(requestTimeout),
 		runtime.WithMaxContainers(maxContainers),
+		runtime.WithDiskPath(workDir),
+		runtime.WithUnprivilegedOnly(unprivilegedOnly),
+		runtime.WithAppArmorProfile(apparmorProfile),
 	)
 
+	if seccompProfilePath != "" {
+		seccompProfile, err := loadSeccompProfile(seccompProfilePath)
+		if err != nil {
+			return nil, fmt.Errorf("load seccomp profile: %w", err)
+		}
+
+		backendOpts = append(backendOpts, runtime.WithSeccompProfile(seccompProfile))
+	}
+
+	if securityProfilesDir != "" {
+		securityProfiles, err := runtime.LoadSecurityProfiles(securityProfilesDir)
+		if err != nil {
+			return nil, fmt.Errorf("load security profiles: %w", err)
+		}
+
+		backendOpts = append(backendOpts, runtime.WithSecurityProfiles(securityProfiles))
+	}
+
 	gardenBackend, err := runtime.NewGardenBackend(
 		libcontainerd.New(containerdAddr, namespace, requestTimeout),
 		backendOpts...,
@@ -85,6 +113,28 @@ func containerdGardenServerRunner(
 	return gardenServerRunner{logger, server}, nil
 }
 
+// loadSeccompProfile reads a seccomp profile in the format of the
+// `linux.seccomp` section of an OCI runtime spec.
+//
+func loadSeccompProfile(path string) (*specs.LinuxSeccomp, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read file %s: %w", path, err)
+	}
+
+	var profile specs.LinuxSeccomp
+	err = json.Unmarshal(content, &profile)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal %s: %w", path, err)
+	}
+
+	if profile.DefaultAction == "" {
+		return nil, fmt.Errorf("%s: missing defaultAction", path)
+	}
+
+	return &profile, nil
+}
+
 // writeDefaultContainerdConfig writes a default containerd configuration file
 // to a destination.
 //
@@ -177,11 +227,16 @@ func (cmd *WorkerCommand) containerdRunner(logger lager.Logger) (ifrit.Runner, e
 		logger,
 		cmd.bindAddr(),
 		sock,
+		cmd.WorkDir.Path(),
 		cmd.Containerd.RequestTimeout,
 		dnsServers,
 		cmd.Containerd.NetworkPool,
 		cmd.Containerd.MaxContainers,
 		cmd.Containerd.RestrictedNetworks,
+		cmd.Containerd.UnprivilegedOnly,
+		cmd.Containerd.SeccompProfile.Path(),
+		cmd.Containerd.AppArmorProfile,
+		cmd.Containerd.SecurityProfiles.Path(),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("containerd garden server runner: %w", err)
@@ -1,6 +1,7 @@
 package workercmd
 
 import (
+	"runtime"
 	"time"
 
 	"github.com/concourse/concourse/atc"
@@ -17,10 +18,18 @@ type WorkerConfig struct {
 
 	Ephemeral bool `long:"ephemeral" description:"If set, the worker will be immediately removed upon stalling."`
 
+	AllocatableCPU    uint64 `long:"allocatable-cpu"    description:"CPU shares available to task containers, used by the resource-fit placement strategy. Defaults to 1024 shares per CPU."`
+	AllocatableMemory uint64 `long:"allocatable-memory" description:"Memory in bytes available to task containers, used by the resource-fit placement strategy. Defaults to the memory capacity reported by the runtime."`
+
 	Version string `long:"version" hidden:"true" description:"Version of the worker. This is normally baked in to the binary, so this flag is hidden."`
 }
 
 func (c WorkerConfig) Worker() atc.Worker {
+	allocatableCPU := c.AllocatableCPU
+	if allocatableCPU == 0 {
+		allocatableCPU = uint64(runtime.NumCPU()) * 1024
+	}
+
 	return atc.Worker{
 		Tags:          c.Tags,
 		Team:          c.TeamName,
@@ -31,5 +40,8 @@ func (c WorkerConfig) Worker() atc.Worker {
 		HTTPSProxyURL: c.HTTPSProxy,
 		NoProxy:       c.NoProxy,
 		Ephemeral:     c.Ephemeral,
+
+		AllocatableCPU:    allocatableCPU,
+		AllocatableMemory: c.AllocatableMemory,
 	}
 }
@@ -8,12 +8,14 @@ import (
 	"os"
 	"os/user"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
 	concourseCmd "github.com/concourse/concourse/cmd"
+	"github.com/concourse/concourse/worker/runtime"
 	"github.com/concourse/flag"
 	"github.com/jessevdk/go-flags"
 	"github.com/tedsuo/ifrit"
@@ -45,6 +47,11 @@ type ContainerdRuntime struct {
 	RestrictedNetworks []string  `long:"restricted-network" description:"Network ranges to which traffic from containers will be restricted. Can be specified multiple times."`
 	MaxContainers      int       `long:"max-containers" default:"0" description:"Max container capacity. 0 means no limit."`
 	NetworkPool        string    `long:"network-pool" default:"10.80.0.0/16" description:"Network range to use for dynamically allocated container subnets."`
+
+	UnprivilegedOnly bool      `long:"unprivileged-only" description:"Opt in to running every container in an unprivileged user namespace. Privileged containers are rejected, and the worker registers as unprivileged so that privileged steps are placed on other workers. Disabled by default."`
+	SeccompProfile   flag.File `long:"seccomp-profile"   description:"Path to a seccomp profile, in the format of the 'linux.seccomp' section of an OCI runtime spec, applied to unprivileged containers instead of the default one."`
+	AppArmorProfile  string    `long:"apparmor-profile"  description:"Name of an AppArmor profile, already loaded on the host, that unprivileged containers are confined by."`
+	SecurityProfiles flag.Dir  `long:"security-profiles" description:"Directory of named security profiles ('<name>.json', holding 'capabilities', 'seccomp' and 'apparmor') that tasks can select through 'security_profile'. The 'default' profile applies to containers that don't select any."`
 }
 
 const containerdRuntime = "containerd"
@@ -83,6 +90,18 @@ func (cmd *WorkerCommand) gardenServerRunner(logger lager.Logger) (atc.Worker, i
 		return atc.Worker{}, nil, err
 	}
 
+	if cmd.Runtime == containerdRuntime && cmd.Containerd.UnprivilegedOnly {
+		worker.Unprivileged = true
+		worker.ResourceTypes = unprivilegedResourceTypes(logger, worker.ResourceTypes)
+	}
+
+	if cmd.Runtime == containerdRuntime && cmd.Containerd.SecurityProfiles.Path() != "" {
+		worker.SecurityProfiles, err = securityProfileNames(cmd.Containerd.SecurityProfiles.Path())
+		if err != nil {
+			return atc.Worker{}, nil, err
+		}
+	}
+
 	worker.Name, err = cmd.workerName()
 	if err != nil {
 		return atc.Worker{}, nil, err
@@ -202,6 +221,23 @@ func (cmd *WorkerCommand) loadResources(logger lager.Logger) ([]atc.WorkerResour
 	return types, nil
 }
 
+// unprivilegedResourceTypes drops the resource types whose containers need to
+// be privileged, as an unprivileged worker can't run them.
+//
+func unprivilegedResourceTypes(logger lager.Logger, types []atc.WorkerResourceType) []atc.WorkerResourceType {
+	var unprivileged []atc.WorkerResourceType
+	for _, t := range types {
+		if t.Privileged {
+			logger.Info("skipping-privileged-resource-type", lager.Data{"type": t.Type})
+			continue
+		}
+
+		unprivileged = append(unprivileged, t)
+	}
+
+	return unprivileged
+}
+
 
 func (cmd *WorkerCommand) hasFlags(prefix string) bool {
 	env := os.Environ()
@@ -238,4 +274,22 @@ func (cmd *WorkerCommand) verifyRuntimeFlags() error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+// securityProfileNames loads the security profiles defined in the directory,
+// failing on any invalid one, and returns their names so that the worker can
+// advertise them.
+func securityProfileNames(dir string) ([]string, error) {
+	profiles, err := runtime.LoadSecurityProfiles(dir)
+	if err != nil {
+		return nil, fmt.Errorf("load security profiles: %w", err)
+	}
+
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}